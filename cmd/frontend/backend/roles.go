@@ -0,0 +1,118 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// PermissionError is returned when the current user is neither a site admin
+// nor assigned a role that grants a required permission.
+type PermissionError struct {
+	Permission database.Permission
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("must be site admin or have the %s permission", e.Permission)
+}
+
+func (e *PermissionError) Unauthorized() bool { return true }
+
+// CheckCurrentUserHasPermission returns an error if the current user is
+// NEITHER (1) a site admin NOR (2) assigned a role that grants the permission,
+// directly or through one of their organizations.
+//
+// It replaces CheckCurrentUserIsSiteAdmin for actions that can be delegated to
+// users who aren't site admins.
+func CheckCurrentUserHasPermission(ctx context.Context, db database.DB, p database.Permission) error {
+	if actor.FromContext(ctx).IsInternal() {
+		return nil
+	}
+	user, err := CurrentUser(ctx, db)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrNotAuthenticated
+	}
+	if user.SiteAdmin {
+		return nil
+	}
+	ok, err := db.Roles().UserHasPermission(ctx, user.ID, p)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &PermissionError{Permission: p}
+	}
+	return nil
+}
+
+// CurrentUserHasPermission is like CheckCurrentUserHasPermission, but returns
+// false instead of an error if the current user doesn't have the permission or
+// isn't authenticated.
+func CurrentUserHasPermission(ctx context.Context, db database.DB, p database.Permission) (bool, error) {
+	err := CheckCurrentUserHasPermission(ctx, db, p)
+	if err == ErrNotAuthenticated || errors.HasType(err, &PermissionError{}) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// CheckPermissionOrSameUser returns an error if the current user is NEITHER
+// (1) the user specified by subjectUserID, (2) a site admin NOR (3) assigned a
+// role that grants the permission.
+//
+// Like CheckSiteAdminOrSameUser, it returns an *InsufficientAuthorizationError
+// if the current user isn't allowed to perform the action.
+func CheckPermissionOrSameUser(ctx context.Context, db database.DB, p database.Permission, subjectUserID int32) error {
+	a := actor.FromContext(ctx)
+	if a.IsInternal() || (a.IsAuthenticated() && a.UID == subjectUserID) {
+		return nil
+	}
+	ok, err := CurrentUserHasPermission(ctx, db, p)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &InsufficientAuthorizationError{fmt.Sprintf("must be authenticated as the authorized user, as an admin or as a user with the %s permission", p)}
+	}
+	return nil
+}
+
+// ErrOrgHasRoles is returned when a user who isn't a site admin tries to let
+// another user join an organization that has roles assigned to it.
+var ErrOrgHasRoles = errors.New("only site admins can add members to an organization that has roles assigned to it")
+
+// CheckUserCanAddOrgMembers returns an error if the organization has roles
+// assigned to it and the user specified by userID is not a site admin.
+//
+// Members of an organization have the roles assigned to it, so letting any
+// member add other members would let them grant those roles without being
+// able to assign roles themselves. It must be checked both when a user is
+// invited (for the sender) and when the invitation is accepted (again for the
+// sender), since roles may have been assigned in between.
+func CheckUserCanAddOrgMembers(ctx context.Context, db database.DB, userID, orgID int32) error {
+	if actor.FromContext(ctx).IsInternal() {
+		return nil
+	}
+	assignments, err := db.Roles().ListAssignments(ctx, database.ListRoleAssignmentsOpts{OrgID: orgID})
+	if err != nil {
+		return err
+	}
+	if len(assignments) == 0 {
+		return nil
+	}
+	user, err := db.Users().GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.SiteAdmin {
+		return ErrOrgHasRoles
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCheckCurrentUserHasPermission(t *testing.T) {
+	newDB := func(user *types.User, permitted bool) *database.MockDB {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(user, nil)
+		if user == nil {
+			users.GetByCurrentAuthUserFunc.SetDefaultReturn(nil, database.ErrNoCurrentUser)
+		}
+
+		roles := database.NewStrictMockRoleStore()
+		roles.UserHasPermissionFunc.SetDefaultHook(func(_ context.Context, userID int32, p database.Permission) (bool, error) {
+			return permitted && p == database.PermissionRepoAdmin, nil
+		})
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.RolesFunc.SetDefaultReturn(roles)
+		return db
+	}
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	for _, tc := range []struct {
+		name      string
+		ctx       context.Context
+		user      *types.User
+		permitted bool
+		wantErr   error
+	}{
+		{
+			name: "internal actor",
+			ctx:  actor.WithInternalActor(context.Background()),
+		},
+		{
+			name:    "not authenticated",
+			ctx:     context.Background(),
+			wantErr: ErrNotAuthenticated,
+		},
+		{
+			name: "site admin",
+			ctx:  ctx,
+			user: &types.User{ID: 1, SiteAdmin: true},
+		},
+		{
+			name:      "user with permission",
+			ctx:       ctx,
+			user:      &types.User{ID: 1},
+			permitted: true,
+		},
+		{
+			name:    "user without permission",
+			ctx:     ctx,
+			user:    &types.User{ID: 1},
+			wantErr: &PermissionError{Permission: database.PermissionRepoAdmin},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newDB(tc.user, tc.permitted)
+
+			err := CheckCurrentUserHasPermission(tc.ctx, db, database.PermissionRepoAdmin)
+			if tc.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantErr != nil && (err == nil || err.Error() != tc.wantErr.Error()) {
+				t.Fatalf("want error %q, got %v", tc.wantErr, err)
+			}
+
+			ok, err := CurrentUserHasPermission(tc.ctx, db, database.PermissionRepoAdmin)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ok != (tc.wantErr == nil) {
+				t.Fatalf("want CurrentUserHasPermission to return %v", tc.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestCheckPermissionOrSameUser(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+	roles := database.NewMockRoleStore()
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.RolesFunc.SetDefaultReturn(roles)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	if err := CheckPermissionOrSameUser(ctx, db, database.PermissionBatchChangesAdmin, 1); err != nil {
+		t.Fatalf("same user: unexpected error: %v", err)
+	}
+
+	err := CheckPermissionOrSameUser(ctx, db, database.PermissionBatchChangesAdmin, 2)
+	if !errors.HasType(err, &InsufficientAuthorizationError{}) {
+		t.Fatalf("other user: want InsufficientAuthorizationError, got %v", err)
+	}
+
+	roles.UserHasPermissionFunc.SetDefaultReturn(true, nil)
+	if err := CheckPermissionOrSameUser(ctx, db, database.PermissionBatchChangesAdmin, 2); err != nil {
+		t.Fatalf("other user with permission: unexpected error: %v", err)
+	}
+}
+
+func TestCheckUserCanAddOrgMembers(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, SiteAdmin: id == 1}, nil
+	})
+	roles := database.NewMockRoleStore()
+	roles.ListAssignmentsFunc.SetDefaultHook(func(_ context.Context, opts database.ListRoleAssignmentsOpts) ([]*database.RoleAssignment, error) {
+		if opts.OrgID == 10 {
+			return []*database.RoleAssignment{{ID: 1, RoleID: 1, OrgID: 10}}, nil
+		}
+		return nil, nil
+	})
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.RolesFunc.SetDefaultReturn(roles)
+
+	ctx := context.Background()
+
+	if err := CheckUserCanAddOrgMembers(ctx, db, 2, 20); err != nil {
+		t.Fatalf("org without roles: unexpected error: %v", err)
+	}
+	if err := CheckUserCanAddOrgMembers(ctx, db, 1, 10); err != nil {
+		t.Fatalf("site admin: unexpected error: %v", err)
+	}
+	if err := CheckUserCanAddOrgMembers(ctx, db, 2, 10); err != ErrOrgHasRoles {
+		t.Fatalf("org member: want ErrOrgHasRoles, got %v", err)
+	}
+	if err := CheckUserCanAddOrgMembers(actor.WithInternalActor(ctx), db, 2, 10); err != nil {
+		t.Fatalf("internal actor: unexpected error: %v", err)
+	}
+}
@@ -19,9 +19,11 @@ type Services struct {
 	GitHubWebhook                 webhooks.Registerer
 	GitLabWebhook                 http.Handler
 	BitbucketServerWebhook        http.Handler
+	BitbucketCloudWebhook         http.Handler
 	NewCodeIntelUploadHandler     NewCodeIntelUploadHandler
 	NewExecutorProxyHandler       NewExecutorProxyHandler
 	NewGitHubAppCloudSetupHandler NewGitHubAppCloudSetupHandler
+	NewSCIMHandler                NewSCIMHandler
 	AuthzResolver                 graphqlbackend.AuthzResolver
 	BatchChangesResolver          graphqlbackend.BatchChangesResolver
 	CodeIntelResolver             graphqlbackend.CodeIntelResolver
@@ -47,15 +49,22 @@ type NewExecutorProxyHandler func() http.Handler
 // GitHub App setup URL endpoint.
 type NewGitHubAppCloudSetupHandler func() http.Handler
 
+// NewSCIMHandler creates a new handler for the SCIM 2.0 user and group
+// provisioning API. This handler is protected via a bearer token set in the
+// site configuration.
+type NewSCIMHandler func() http.Handler
+
 // DefaultServices creates a new Services value that has default implementations for all services.
 func DefaultServices() Services {
 	return Services{
 		GitHubWebhook:                 registerFunc(func(webhook *webhooks.GitHubWebhook) {}),
 		GitLabWebhook:                 makeNotFoundHandler("gitlab webhook"),
 		BitbucketServerWebhook:        makeNotFoundHandler("bitbucket server webhook"),
+		BitbucketCloudWebhook:         makeNotFoundHandler("bitbucket cloud webhook"),
 		NewCodeIntelUploadHandler:     func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
 		NewExecutorProxyHandler:       func() http.Handler { return makeNotFoundHandler("executor proxy") },
 		NewGitHubAppCloudSetupHandler: func() http.Handler { return makeNotFoundHandler("Sourcegraph Cloud GitHub App setup") },
+		NewSCIMHandler:                func() http.Handler { return makeNotFoundHandler("SCIM") },
 	}
 }
 
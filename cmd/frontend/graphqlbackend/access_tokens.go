@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go"
@@ -19,9 +20,10 @@ import (
 )
 
 type createAccessTokenInput struct {
-	User   graphql.ID
-	Scopes []string
-	Note   string
+	User      graphql.ID
+	Scopes    []string
+	Note      string
+	ExpiresAt *DateTime
 }
 
 func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAccessTokenInput) (*createAccessTokenResult, error) {
@@ -58,14 +60,18 @@ func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAcce
 	}
 
 	// Validate scopes.
-	var hasUserAllScope bool
+	var hasUserAllScope, hasUserScope, hasSudoScope bool
 	seenScope := map[string]struct{}{}
 	sort.Strings(args.Scopes)
 	for _, scope := range args.Scopes {
 		switch scope {
 		case authz.ScopeUserAll:
 			hasUserAllScope = true
+			hasUserScope = true
+		case authz.ScopeUserRead, authz.ScopeSearch, authz.ScopeBatchChanges:
+			hasUserScope = true
 		case authz.ScopeSiteAdminSudo:
+			hasSudoScope = true
 			// 🚨 SECURITY: Only site admins may create a token with the "site-admin:sudo" scope.
 			if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
 				return nil, err
@@ -81,11 +87,31 @@ func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAcce
 		}
 		seenScope[scope] = struct{}{}
 	}
-	if !hasUserAllScope {
-		return nil, errors.Errorf("all access tokens must have scope %q", authz.ScopeUserAll)
+	if !hasUserScope {
+		return nil, errors.Errorf("all access tokens must have one of the scopes %q", authz.UserScopes)
+	}
+	if hasSudoScope && !hasUserAllScope {
+		return nil, errors.Errorf("access tokens with scope %q must also have scope %q", authz.ScopeSiteAdminSudo, authz.ScopeUserAll)
 	}
 
-	id, token, err := r.db.AccessTokens().Create(ctx, userID, args.Scopes, args.Note, actor.FromContext(ctx).UID)
+	var expiresAt *time.Time
+	if args.ExpiresAt != nil {
+		if !args.ExpiresAt.Time.After(time.Now()) {
+			return nil, errors.New("access tokens must expire in the future")
+		}
+		expiresAt = &args.ExpiresAt.Time
+	}
+
+	id, token, err := r.db.AccessTokens().Create(ctx, userID, args.Scopes, args.Note, actor.FromContext(ctx).UID, expiresAt)
+	if err == nil {
+		database.SecurityEventLogs(r.db).LogAuditEvent(ctx, database.SecurityEventNameAccessTokenCreated, nil, nil, accessTokenAuditState{
+			ID:            id,
+			SubjectUserID: userID,
+			Scopes:        args.Scopes,
+			Note:          args.Note,
+			ExpiresAt:     expiresAt,
+		})
+	}
 
 	if conf.CanSendEmail() {
 		if err := backend.UserEmails.SendUserEmailOnFieldUpdate(ctx, r.db, userID, "created an access token"); err != nil {
@@ -104,6 +130,26 @@ type createAccessTokenResult struct {
 func (r *createAccessTokenResult) ID() graphql.ID { return r.id }
 func (r *createAccessTokenResult) Token() string  { return r.token }
 
+// accessTokenAuditState is the state of an access token recorded in the
+// audit log. It never contains the token's secret.
+type accessTokenAuditState struct {
+	ID            int64      `json:"id"`
+	SubjectUserID int32      `json:"subjectUserID"`
+	Scopes        []string   `json:"scopes"`
+	Note          string     `json:"note"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+}
+
+func logAccessTokenDeleted(ctx context.Context, db database.DB, t *database.AccessToken) {
+	database.SecurityEventLogs(db).LogAuditEvent(ctx, database.SecurityEventNameAccessTokenDeleted, nil, accessTokenAuditState{
+		ID:            t.ID,
+		SubjectUserID: t.SubjectUserID,
+		Scopes:        t.Scopes,
+		Note:          t.Note,
+		ExpiresAt:     t.ExpiresAt,
+	}, nil)
+}
+
 type deleteAccessTokenInput struct {
 	ByID    *graphql.ID
 	ByToken *string
@@ -136,6 +182,7 @@ func (r *schemaResolver) DeleteAccessToken(ctx context.Context, args *deleteAcce
 		if err := r.db.AccessTokens().DeleteByID(ctx, token.ID); err != nil {
 			return nil, err
 		}
+		logAccessTokenDeleted(ctx, r.db, token)
 
 	case args.ByToken != nil:
 		token, err := r.db.AccessTokens().GetByToken(ctx, *args.ByToken)
@@ -149,6 +196,7 @@ func (r *schemaResolver) DeleteAccessToken(ctx context.Context, args *deleteAcce
 		if err := r.db.AccessTokens().DeleteByToken(ctx, *args.ByToken); err != nil {
 			return nil, err
 		}
+		logAccessTokenDeleted(ctx, r.db, token)
 
 	}
 
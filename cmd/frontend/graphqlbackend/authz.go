@@ -12,15 +12,18 @@ type AuthzResolver interface {
 	ScheduleRepositoryPermissionsSync(ctx context.Context, args *RepositoryIDArgs) (*EmptyResponse, error)
 	ScheduleUserPermissionsSync(ctx context.Context, args *UserPermissionsSyncArgs) (*EmptyResponse, error)
 	SetSubRepositoryPermissionsForUsers(ctx context.Context, args *SubRepoPermsArgs) (*EmptyResponse, error)
+	SetSubRepositoryPathRules(ctx context.Context, args *SubRepoPathRulesArgs) (*EmptyResponse, error)
 
 	// Queries
 	AuthorizedUserRepositories(ctx context.Context, args *AuthorizedRepoArgs) (RepositoryConnectionResolver, error)
 	UsersWithPendingPermissions(ctx context.Context) ([]string, error)
 	AuthorizedUsers(ctx context.Context, args *RepoAuthorizedUserArgs) (UserConnectionResolver, error)
+	ExplainRepositoryPermissions(ctx context.Context, args *ExplainRepositoryPermissionsArgs) (RepositoryPermissionsExplanationResolver, error)
 
 	// Helpers
 	RepositoryPermissionsInfo(ctx context.Context, repoID graphql.ID) (PermissionsInfoResolver, error)
 	UserPermissionsInfo(ctx context.Context, userID graphql.ID) (PermissionsInfoResolver, error)
+	SubRepositoryPathRules(ctx context.Context, repoID graphql.ID) ([]SubRepositoryPathRuleResolver, error)
 }
 
 type RepositoryIDArgs struct {
@@ -51,6 +54,21 @@ type SubRepoPermsArgs struct {
 	}
 }
 
+type SubRepoPathRulesArgs struct {
+	Repository graphql.ID
+	Rules      []struct {
+		Pattern      string
+		Action       string
+		User         *graphql.ID
+		Organization *graphql.ID
+	}
+}
+
+type ExplainRepositoryPermissionsArgs struct {
+	User       graphql.ID
+	Repository graphql.ID
+}
+
 type AuthorizedRepoArgs struct {
 	Username *string
 	Email    *string
@@ -64,3 +82,46 @@ type PermissionsInfoResolver interface {
 	SyncedAt() *DateTime
 	UpdatedAt() DateTime
 }
+
+type SubRepositoryPathRuleResolver interface {
+	Pattern() string
+	Action() string
+	User(ctx context.Context) (*UserResolver, error)
+	Organization(ctx context.Context) (*OrgResolver, error)
+}
+
+type RepositoryPermissionsExplanationResolver interface {
+	User() *UserResolver
+	Repository() *RepositoryResolver
+	Accessible() bool
+	Reasons() []string
+	BypassedAsSiteAdmin() bool
+	Public() bool
+	Unrestricted() bool
+	AuthorizationProvider() AuthorizationProviderResolver
+	ExternalAccount() ExternalAccountResolver
+	GrantedByPermissions() bool
+	UserPermissionsInfo() PermissionsInfoResolver
+	RepositoryPermissionsInfo() PermissionsInfoResolver
+	LastUserPermissionsSync() PermissionsSyncOutcomeResolver
+	LastRepositoryPermissionsSync() PermissionsSyncOutcomeResolver
+	SubRepositoryPermissions() SubRepositoryPermissionsResolver
+}
+
+type AuthorizationProviderResolver interface {
+	ServiceType() string
+	ServiceID() string
+	URN() string
+}
+
+type PermissionsSyncOutcomeResolver interface {
+	StartedAt() DateTime
+	FinishedAt() DateTime
+	Error() *string
+}
+
+type SubRepositoryPermissionsResolver interface {
+	Enabled() bool
+	PathIncludes() []string
+	PathExcludes() []string
+}
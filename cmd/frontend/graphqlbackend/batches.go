@@ -43,6 +43,16 @@ type CloseBatchChangeArgs struct {
 	CloseChangesets bool
 }
 
+type SetBatchChangeScheduleArgs struct {
+	BatchChange graphql.ID
+	Schedule    *string
+}
+
+type ListBatchChangeScheduledRunsArgs struct {
+	First int32
+	After *string
+}
+
 type MoveBatchChangeArgs struct {
 	BatchChange  graphql.ID
 	NewName      *string
@@ -161,6 +171,7 @@ type CreateBatchChangesCredentialArgs struct {
 	ExternalServiceKind string
 	ExternalServiceURL  string
 	User                *graphql.ID
+	Username            *string
 	Credential          string
 }
 
@@ -168,6 +179,23 @@ type DeleteBatchChangesCredentialArgs struct {
 	BatchChangesCredential graphql.ID
 }
 
+type CreateBatchChangesSigningKeyArgs struct {
+	User           *graphql.ID
+	Format         string
+	PrivateKey     string
+	Passphrase     *string
+	CommitterName  string
+	CommitterEmail string
+}
+
+type DeleteBatchChangesSigningKeyArgs struct {
+	SigningKey graphql.ID
+}
+
+type BatchChangesSigningKeyArgs struct {
+	User *graphql.ID
+}
+
 type ListBatchChangesCodeHostsArgs struct {
 	First  int32
 	After  *string
@@ -255,10 +283,13 @@ type BatchChangesResolver interface {
 
 	ApplyBatchChange(ctx context.Context, args *ApplyBatchChangeArgs) (BatchChangeResolver, error)
 	CloseBatchChange(ctx context.Context, args *CloseBatchChangeArgs) (BatchChangeResolver, error)
+	SetBatchChangeSchedule(ctx context.Context, args *SetBatchChangeScheduleArgs) (BatchChangeResolver, error)
 	MoveBatchChange(ctx context.Context, args *MoveBatchChangeArgs) (BatchChangeResolver, error)
 	DeleteBatchChange(ctx context.Context, args *DeleteBatchChangeArgs) (*EmptyResponse, error)
 	CreateBatchChangesCredential(ctx context.Context, args *CreateBatchChangesCredentialArgs) (BatchChangesCredentialResolver, error)
 	DeleteBatchChangesCredential(ctx context.Context, args *DeleteBatchChangesCredentialArgs) (*EmptyResponse, error)
+	CreateBatchChangesSigningKey(ctx context.Context, args *CreateBatchChangesSigningKeyArgs) (BatchChangesSigningKeyResolver, error)
+	DeleteBatchChangesSigningKey(ctx context.Context, args *DeleteBatchChangesSigningKeyArgs) (*EmptyResponse, error)
 
 	CreateChangesetSpec(ctx context.Context, args *CreateChangesetSpecArgs) (ChangesetSpecResolver, error)
 	SyncChangeset(ctx context.Context, args *SyncChangesetArgs) (*EmptyResponse, error)
@@ -277,6 +308,7 @@ type BatchChangesResolver interface {
 	BatchChangesCodeHosts(ctx context.Context, args *ListBatchChangesCodeHostsArgs) (BatchChangesCodeHostConnectionResolver, error)
 	RepoChangesetsStats(ctx context.Context, repo *graphql.ID) (RepoChangesetsStatsResolver, error)
 	RepoDiffStat(ctx context.Context, repo *graphql.ID) (*DiffStat, error)
+	BatchChangesSigningKey(ctx context.Context, args *BatchChangesSigningKeyArgs) (BatchChangesSigningKeyResolver, error)
 
 	BatchSpecs(cx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
 
@@ -327,6 +359,7 @@ type BatchSpecResolver interface {
 	ViewerCanAdminister(context.Context) (bool, error)
 
 	DiffStat(ctx context.Context) (*DiffStat, error)
+	DiffAnalytics(ctx context.Context) (BatchSpecDiffAnalyticsResolver, error)
 
 	AppliesToBatchChange(ctx context.Context) (BatchChangeResolver, error)
 
@@ -348,6 +381,41 @@ type BatchSpecResolver interface {
 	ViewerCanRetry(context.Context) (bool, error)
 }
 
+type BatchSpecDiffAnalyticsArgs struct {
+	First int32
+}
+
+type BatchSpecDiffAnalyticsResolver interface {
+	LinesChanged() DiffDistributionResolver
+	FilesChanged() DiffDistributionResolver
+	AverageRiskScore() float64
+	MaxRiskScore() float64
+	HighRiskThreshold() float64
+	HighRiskCount() int32
+	LargestDiffs(*BatchSpecDiffAnalyticsArgs) []ChangesetSpecDiffAnalyticsResolver
+	RiskiestChangesetSpecs(*BatchSpecDiffAnalyticsArgs) []ChangesetSpecDiffAnalyticsResolver
+	UnusualChangesetSpecs(*BatchSpecDiffAnalyticsArgs) []ChangesetSpecDiffAnalyticsResolver
+}
+
+type DiffDistributionResolver interface {
+	Total() int32
+	Median() int32
+	P90() int32
+	P99() int32
+	Max() int32
+}
+
+type ChangesetSpecDiffAnalyticsResolver interface {
+	ChangesetSpec() VisibleChangesetSpecResolver
+	LinesChanged() int32
+	FilesChanged() int32
+	BinaryFiles() []string
+	DeletedFiles() []string
+	Lockfiles() []string
+	RiskScore() float64
+	HighRisk() bool
+}
+
 type BatchChangeDescriptionResolver interface {
 	Name() string
 	Description() string
@@ -419,6 +487,8 @@ type ChangesetApplyPreviewConnectionStatsResolver interface {
 	Sleep() int32
 	Detach() int32
 	Archive() int32
+	Merge() int32
+	Reexecute() int32
 
 	Added() int32
 	Modified() int32
@@ -470,6 +540,9 @@ type ChangesetSpecDeltaResolver interface {
 	CommitMessageChanged() bool
 	AuthorNameChanged() bool
 	AuthorEmailChanged() bool
+	LabelsChanged() bool
+	AssigneesChanged() bool
+	MilestoneChanged() bool
 }
 
 type ChangesetDescription interface {
@@ -524,6 +597,7 @@ type BatchChangesCodeHostResolver interface {
 	ExternalServiceKind() string
 	ExternalServiceURL() string
 	RequiresSSH() bool
+	RequiresUsername() bool
 	HasWebhooks() bool
 	Credential() BatchChangesCredentialResolver
 }
@@ -537,6 +611,16 @@ type BatchChangesCredentialResolver interface {
 	IsSiteCredential() bool
 }
 
+type BatchChangesSigningKeyResolver interface {
+	ID() graphql.ID
+	Format() string
+	PublicKey() string
+	CommitterName() string
+	CommitterEmail() string
+	IsSiteKey() bool
+	CreatedAt() DateTime
+}
+
 type ChangesetCountsArgs struct {
 	From            *DateTime
 	To              *DateTime
@@ -611,12 +695,29 @@ type BatchChangeResolver interface {
 	Changesets(ctx context.Context, args *ListChangesetsArgs) (ChangesetsConnectionResolver, error)
 	ChangesetCountsOverTime(ctx context.Context, args *ChangesetCountsArgs) ([]ChangesetCountsResolver, error)
 	ClosedAt() *DateTime
+	Schedule() *string
+	NextScheduledRunAt() *DateTime
+	ScheduledRuns(ctx context.Context, args *ListBatchChangeScheduledRunsArgs) (BatchChangeScheduledRunConnectionResolver, error)
 	DiffStat(ctx context.Context) (*DiffStat, error)
 	CurrentSpec(ctx context.Context) (BatchSpecResolver, error)
 	BulkOperations(ctx context.Context, args *ListBatchChangeBulkOperationArgs) (BulkOperationConnectionResolver, error)
 	BatchSpecs(ctx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
 }
 
+type BatchChangeScheduledRunConnectionResolver interface {
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+	Nodes(ctx context.Context) ([]BatchChangeScheduledRunResolver, error)
+}
+
+type BatchChangeScheduledRunResolver interface {
+	State() string
+	BatchSpec(ctx context.Context) (BatchSpecResolver, error)
+	FailureMessage() *string
+	CreatedAt() DateTime
+	FinishedAt() *DateTime
+}
+
 type BatchChangesConnectionResolver interface {
 	Nodes(ctx context.Context) ([]BatchChangeResolver, error)
 	TotalCount(ctx context.Context) (int32, error)
@@ -711,6 +812,9 @@ type ExternalChangesetResolver interface {
 	ReviewState(context.Context) *string
 	// CheckState returns a value of type *btypes.ChangesetCheckState.
 	CheckState() *string
+	AutoMergeBlockedReason() *string
+	Rebasing() bool
+	RebaseFailureMessage() *string
 	Repository(ctx context.Context) *RepositoryResolver
 
 	Events(ctx context.Context, args *ChangesetEventsConnectionArgs) (ChangesetEventsConnectionResolver, error)
@@ -12,11 +12,32 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 )
 
+type ExternalAccountResolver interface {
+	ID() graphql.ID
+	User(ctx context.Context) (*UserResolver, error)
+	ServiceType() string
+	ServiceID() string
+	ClientID() string
+	AccountID() string
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+	RefreshURL() *string
+	AccountData(ctx context.Context) (*JSONValue, error)
+}
+
+// NewExternalAccountResolver returns a resolver for the external account. The
+// caller is responsible for checking that the current user may see it.
+func NewExternalAccountResolver(db database.DB, account extsvc.Account) *externalAccountResolver {
+	return &externalAccountResolver{db: db, account: account}
+}
+
 type externalAccountResolver struct {
 	db      database.DB
 	account extsvc.Account
 }
 
+var _ ExternalAccountResolver = &externalAccountResolver{}
+
 func externalAccountByID(ctx context.Context, db database.DB, id graphql.ID) (*externalAccountResolver, error) {
 	externalAccountID, err := unmarshalExternalAccountID(id)
 	if err != nil {
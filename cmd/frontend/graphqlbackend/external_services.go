@@ -2,6 +2,7 @@ package graphqlbackend
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -22,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -101,6 +103,7 @@ func (r *schemaResolver) AddExternalService(ctx context.Context, args *addExtern
 	if err = r.db.ExternalServices().Create(ctx, conf.Get, externalService); err != nil {
 		return nil, err
 	}
+	database.SecurityEventLogs(r.db).LogAuditEvent(ctx, database.SecurityEventNameExternalServiceCreated, nil, nil, externalServiceAuditState(externalService))
 
 	res := &externalServiceResolver{db: r.db, externalService: externalService}
 	if err = syncExternalService(ctx, externalService, syncExternalServiceTimeout, r.repoupdaterClient); err != nil {
@@ -150,6 +153,8 @@ func (r *schemaResolver) UpdateExternalService(ctx context.Context, args *update
 		return nil, err
 	}
 
+	before := externalServiceAuditState(es)
+
 	ps := conf.Get().AuthProviders
 	update := &database.ExternalServiceUpdate{
 		DisplayName: args.Input.DisplayName,
@@ -164,6 +169,7 @@ func (r *schemaResolver) UpdateExternalService(ctx context.Context, args *update
 	if err != nil {
 		return nil, err
 	}
+	database.SecurityEventLogs(r.db).LogAuditEvent(ctx, database.SecurityEventNameExternalServiceUpdated, nil, before, externalServiceAuditState(es))
 
 	res := &externalServiceResolver{db: r.db, externalService: es}
 	if err = syncExternalService(ctx, es, syncExternalServiceTimeout, r.repoupdaterClient); err != nil {
@@ -215,6 +221,30 @@ func syncExternalService(ctx context.Context, svc *types.ExternalService, timeou
 	return errors.Wrapf(err, "error in syncExternalService for service %q with ID %d", svc.Kind, svc.ID)
 }
 
+// externalServiceAuditState returns the state of an external service recorded
+// in the audit log, with the secrets in its configuration redacted.
+func externalServiceAuditState(es *types.ExternalService) interface{} {
+	state := struct {
+		ID              int64           `json:"id"`
+		Kind            string          `json:"kind"`
+		DisplayName     string          `json:"displayName"`
+		NamespaceUserID int32           `json:"namespaceUserID,omitempty"`
+		NamespaceOrgID  int32           `json:"namespaceOrgID,omitempty"`
+		Config          json.RawMessage `json:"config,omitempty"`
+	}{
+		ID:              es.ID,
+		Kind:            es.Kind,
+		DisplayName:     es.DisplayName,
+		NamespaceUserID: es.NamespaceUserID,
+		NamespaceOrgID:  es.NamespaceOrgID,
+	}
+	// Leave out configurations whose secrets can't be redacted.
+	if config, err := es.RedactConfigSecrets(); err == nil && config != "" {
+		state.Config = jsonc.Normalize(config)
+	}
+	return state
+}
+
 type deleteExternalServiceArgs struct {
 	ExternalService graphql.ID
 }
@@ -247,6 +277,7 @@ func (r *schemaResolver) DeleteExternalService(ctx context.Context, args *delete
 	if err = r.db.ExternalServices().Delete(ctx, id); err != nil {
 		return nil, err
 	}
+	database.SecurityEventLogs(r.db).LogAuditEvent(ctx, database.SecurityEventNameExternalServiceDeleted, nil, externalServiceAuditState(es), nil)
 	now := time.Now()
 	es.DeletedAt = now
 
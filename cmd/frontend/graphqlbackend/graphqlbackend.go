@@ -524,6 +524,9 @@ func newSchemaResolver(db database.DB) *schemaResolver {
 		"WebhookLog": func(ctx context.Context, id graphql.ID) (Node, error) {
 			return webhookLogByID(ctx, db, id)
 		},
+		roleIDKind: func(ctx context.Context, id graphql.ID) (Node, error) {
+			return roleByID(ctx, db, id)
+		},
 		"Executor": func(ctx context.Context, id graphql.ID) (Node, error) {
 			return executorByID(ctx, db, id, r)
 		},
@@ -263,6 +263,11 @@ func (r *NodeResolver) ToWebhookLog() (*webhookLogResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToRole() (*roleResolver, bool) {
+	n, ok := r.Node.(*roleResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToExecutor() (*executor.ExecutorResolver, bool) {
 	n, ok := r.Node.(*executor.ExecutorResolver)
 	return n, ok
@@ -80,6 +80,11 @@ func (r *schemaResolver) InviteUserToOrganization(ctx context.Context, args *str
 	if err != nil {
 		return nil, err
 	}
+	// 🚨 SECURITY: Members of the org have its roles, so only site admins may invite users to
+	// orgs with roles.
+	if err := backend.CheckUserCanAddOrgMembers(ctx, r.db, sender.ID, orgID); err != nil {
+		return nil, err
+	}
 	recipient, recipientEmail, err := getUserToInviteToOrganization(ctx, r.db, args.Username, orgID)
 	if err != nil {
 		return nil, err
@@ -127,6 +132,18 @@ func (r *schemaResolver) RespondToOrganizationInvitation(ctx context.Context, ar
 		return nil, errors.Errorf("invalid OrganizationInvitationResponseType value %q", args.ResponseType)
 	}
 
+	if accept {
+		// 🚨 SECURITY: Roles may have been assigned to the org after the invitation was sent, so
+		// check again that its sender may add members.
+		invitation, err := database.OrgInvitations(r.db).GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := backend.CheckUserCanAddOrgMembers(ctx, r.db, invitation.SenderUserID, invitation.OrgID); err != nil {
+			return nil, err
+		}
+	}
+
 	// 🚨 SECURITY: This fails if the org invitation's recipient is not the one given (or if the
 	// invitation is otherwise invalid), so we do not need to separately perform that check.
 	orgID, err := database.OrgInvitations(r.db).Respond(ctx, id, a.UID, accept)
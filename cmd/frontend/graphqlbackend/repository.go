@@ -16,6 +16,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/phabricator"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -127,13 +130,7 @@ func (r *RepositoryResolver) Description(ctx context.Context) (string, error) {
 }
 
 func (r *RepositoryResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
-		if err == backend.ErrMustBeSiteAdmin || err == backend.ErrNotAuthenticated {
-			return false, nil // not an error
-		}
-		return false, err
-	}
-	return true, nil
+	return backend.CurrentUserHasPermission(ctx, r.db, database.PermissionRepoAdmin)
 }
 
 func (r *RepositoryResolver) CloneInProgress(ctx context.Context) (bool, error) {
@@ -210,15 +207,22 @@ func (r *RepositoryResolver) DefaultBranch(ctx context.Context) (*GitRefResolver
 }
 
 func (r *RepositoryResolver) Language(ctx context.Context) (string, error) {
-	// The repository language is the most common language at the HEAD commit of the repository.
-	// Note: the repository database field is no longer updated as of
-	// https://github.com/sourcegraph/sourcegraph/issues/2586, so we do not use it anymore and
-	// instead compute the language on the fly.
 	repo, err := r.repo(ctx)
 	if err != nil {
 		return "", err
 	}
 
+	// Prefer the primary language reported by the code host, which is synced
+	// with the repository metadata and is cheap to look up.
+	if lang, _, _ := codeHostMetadata(repo); lang != "" {
+		return lang, nil
+	}
+
+	// Otherwise, the repository language is the most common language at the HEAD commit of the repository.
+	// Note: the repository database field is no longer updated as of
+	// https://github.com/sourcegraph/sourcegraph/issues/2586, so we do not use it anymore and
+	// instead compute the language on the fly.
+
 	commitID, err := backend.NewRepos(r.db.Repos()).ResolveRev(ctx, repo, "")
 	if err != nil {
 		// Comment: Should we return a nil error?
@@ -306,6 +310,44 @@ func (r *RepositoryResolver) Stars(ctx context.Context) (int32, error) {
 	return int32(repo.Stars), nil
 }
 
+func (r *RepositoryResolver) Topics(ctx context.Context) ([]string, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, topics, _ := codeHostMetadata(repo)
+	if topics == nil {
+		topics = []string{}
+	}
+	return topics, nil
+}
+
+func (r *RepositoryResolver) PushedAt(ctx context.Context) (*DateTime, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, _, pushedAt := codeHostMetadata(repo)
+	if pushedAt == nil {
+		return nil, nil
+	}
+	return &DateTime{Time: *pushedAt}, nil
+}
+
+// codeHostMetadata extracts the primary language, topics and time of the last
+// push from the code host metadata of repo, where the code host provides them.
+func codeHostMetadata(repo *types.Repo) (language string, topics []string, pushedAt *time.Time) {
+	switch m := repo.Metadata.(type) {
+	case *github.Repository:
+		return m.Language(), m.Topics(), m.PushedAt
+	case *gitlab.Project:
+		return "", m.ProjectTopics(), m.LastActivityAt
+	case *bitbucketcloud.Repo:
+		return m.Language, nil, m.UpdatedOn
+	}
+	return "", nil, nil
+}
+
 func (r *RepositoryResolver) hydrate(ctx context.Context) error {
 	r.hydration.Do(func() {
 		// Repositories with an empty creation date were created using RepoName.ToRepo(),
@@ -375,6 +417,10 @@ func (r *RepositoryResolver) PermissionsInfo(ctx context.Context) (PermissionsIn
 	return EnterpriseResolvers.authzResolver.RepositoryPermissionsInfo(ctx, r.ID())
 }
 
+func (r *RepositoryResolver) SubRepositoryPathRules(ctx context.Context) ([]SubRepositoryPathRuleResolver, error) {
+	return EnterpriseResolvers.authzResolver.SubRepositoryPathRules(ctx, r.ID())
+}
+
 func (r *schemaResolver) AddPhabricatorRepo(ctx context.Context, args *struct {
 	Callsign string
 	Name     *string
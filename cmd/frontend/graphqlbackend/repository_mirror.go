@@ -205,8 +205,8 @@ func (r *schemaResolver) CheckMirrorRepositoryConnection(ctx context.Context, ar
 	Name       *string
 }) (*checkMirrorRepositoryConnectionResult, error) {
 	// 🚨 SECURITY: This is an expensive operation and the errors may contain secrets,
-	// so only site admins may run it.
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// so only site admins and repository admins may run it.
+	if err := backend.CheckCurrentUserHasPermission(ctx, r.db, database.PermissionRepoAdmin); err != nil {
 		return nil, err
 	}
 
@@ -251,8 +251,9 @@ func (r *checkMirrorRepositoryConnectionResult) Error() *string {
 func (r *schemaResolver) UpdateMirrorRepository(ctx context.Context, args *struct {
 	Repository graphql.ID
 }) (*EmptyResponse, error) {
-	// 🚨 SECURITY: There is no reason why non-site-admins would need to run this operation.
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: There is no reason why users other than site admins and
+	// repository admins would need to run this operation.
+	if err := backend.CheckCurrentUserHasPermission(ctx, r.db, database.PermissionRepoAdmin); err != nil {
 		return nil, err
 	}
 
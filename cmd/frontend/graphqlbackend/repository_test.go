@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
@@ -15,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -65,6 +67,52 @@ func TestRepository_Commit(t *testing.T) {
 	})
 }
 
+func TestRepository_CodeHostMetadata(t *testing.T) {
+	pushedAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	repos := database.NewMockRepoStore()
+	repos.GetFunc.SetDefaultReturn(&types.Repo{
+		ID:        2,
+		Name:      "github.com/gorilla/mux",
+		CreatedAt: time.Now(),
+		Metadata: &github.Repository{
+			PrimaryLanguage: &github.Language{Name: "Go"},
+			RepositoryTopics: &github.RepositoryTopics{Nodes: []github.RepositoryTopic{
+				{Topic: github.Topic{Name: "router"}},
+				{Topic: github.Topic{Name: "http"}},
+			}},
+			PushedAt: &pushedAt,
+		},
+	}, nil)
+
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	RunTests(t, []*Test{
+		{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					repository(name: "github.com/gorilla/mux") {
+						language
+						topics
+						pushedAt
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"repository": {
+						"language": "Go",
+						"topics": ["router", "http"],
+						"pushedAt": "2022-01-02T03:04:05Z"
+					}
+				}
+			`,
+		},
+	})
+}
+
 func TestRepositoryHydration(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,244 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func (r *schemaResolver) Roles(ctx context.Context) ([]*roleResolver, error) {
+	// 🚨 SECURITY: Only site admins can list roles.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	roles, err := r.db.Roles().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*roleResolver, len(roles))
+	for i, role := range roles {
+		resolvers[i] = &roleResolver{db: r.db, role: role}
+	}
+	return resolvers, nil
+}
+
+type roleAssignmentArgs struct {
+	Role         graphql.ID
+	User         *graphql.ID
+	Organization *graphql.ID
+}
+
+// toRoleAssignment resolves the IDs of the arguments to a role assignment.
+func (args *roleAssignmentArgs) toRoleAssignment() (*database.RoleAssignment, error) {
+	var (
+		a   database.RoleAssignment
+		err error
+	)
+	if a.RoleID, err = unmarshalRoleID(args.Role); err != nil {
+		return nil, err
+	}
+	if args.User != nil {
+		if a.UserID, err = UnmarshalUserID(*args.User); err != nil {
+			return nil, err
+		}
+	}
+	if args.Organization != nil {
+		if a.OrgID, err = UnmarshalOrgID(*args.Organization); err != nil {
+			return nil, err
+		}
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// roleAssignmentAuditArgument is the argument of the security events of role
+// assignments.
+type roleAssignmentAuditArgument struct {
+	RoleID int32 `json:"roleID"`
+	UserID int32 `json:"userID,omitempty"`
+	OrgID  int32 `json:"orgID,omitempty"`
+}
+
+func (r *schemaResolver) AssignRole(ctx context.Context, args *roleAssignmentArgs) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins can assign roles.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	a, err := args.toRoleAssignment()
+	if err != nil {
+		return nil, err
+	}
+	// Check that the role exists to return a helpful error.
+	if _, err := r.db.Roles().GetByID(ctx, a.RoleID); err != nil {
+		return nil, err
+	}
+	if err := r.db.Roles().Assign(ctx, a); err != nil {
+		return nil, err
+	}
+
+	database.SecurityEventLogs(r.db).LogAuditEvent(ctx, database.SecurityEventNameRoleAssigned,
+		roleAssignmentAuditArgument{RoleID: a.RoleID, UserID: a.UserID, OrgID: a.OrgID}, nil, nil)
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) UnassignRole(ctx context.Context, args *roleAssignmentArgs) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins can unassign roles.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	a, err := args.toRoleAssignment()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Roles().Unassign(ctx, a); err != nil {
+		return nil, err
+	}
+
+	database.SecurityEventLogs(r.db).LogAuditEvent(ctx, database.SecurityEventNameRoleUnassigned,
+		roleAssignmentAuditArgument{RoleID: a.RoleID, UserID: a.UserID, OrgID: a.OrgID}, nil, nil)
+	return &EmptyResponse{}, nil
+}
+
+const roleIDKind = "Role"
+
+func marshalRoleID(id int32) graphql.ID {
+	return relay.MarshalID(roleIDKind, id)
+}
+
+func unmarshalRoleID(id graphql.ID) (roleID int32, err error) {
+	if kind := relay.UnmarshalKind(id); kind != roleIDKind {
+		return 0, errors.Errorf("expected graphql ID to have kind %q; got %q", roleIDKind, kind)
+	}
+	err = relay.UnmarshalSpec(id, &roleID)
+	return roleID, err
+}
+
+func roleByID(ctx context.Context, db database.DB, id graphql.ID) (*roleResolver, error) {
+	// 🚨 SECURITY: Only site admins can view roles.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, db); err != nil {
+		return nil, err
+	}
+
+	roleID, err := unmarshalRoleID(id)
+	if err != nil {
+		return nil, err
+	}
+	role, err := db.Roles().GetByID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return &roleResolver{db: db, role: role}, nil
+}
+
+type roleResolver struct {
+	db   database.DB
+	role *database.Role
+}
+
+func (r *roleResolver) ID() graphql.ID { return marshalRoleID(r.role.ID) }
+
+func (r *roleResolver) Name() string { return r.role.Name }
+
+func (r *roleResolver) Description() string { return r.role.Description }
+
+func (r *roleResolver) Permissions() []string { return permissionStrings(r.role.Permissions) }
+
+func (r *roleResolver) Assignments(ctx context.Context) ([]*roleAssignmentResolver, error) {
+	// 🚨 SECURITY: Only site admins can view role assignments.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	assignments, err := r.db.Roles().ListAssignments(ctx, database.ListRoleAssignmentsOpts{RoleID: r.role.ID})
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*roleAssignmentResolver, len(assignments))
+	for i, a := range assignments {
+		resolvers[i] = &roleAssignmentResolver{db: r.db, role: r, assignment: a}
+	}
+	return resolvers, nil
+}
+
+type roleAssignmentResolver struct {
+	db         database.DB
+	role       *roleResolver
+	assignment *database.RoleAssignment
+}
+
+func (r *roleAssignmentResolver) Role() *roleResolver { return r.role }
+
+func (r *roleAssignmentResolver) User(ctx context.Context) (*UserResolver, error) {
+	if r.assignment.UserID == 0 {
+		return nil, nil
+	}
+	return UserByIDInt32(ctx, r.db, r.assignment.UserID)
+}
+
+func (r *roleAssignmentResolver) Organization(ctx context.Context) (*OrgResolver, error) {
+	if r.assignment.OrgID == 0 {
+		return nil, nil
+	}
+	return OrgByIDInt32(ctx, r.db, r.assignment.OrgID)
+}
+
+func (r *roleAssignmentResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.assignment.CreatedAt}
+}
+
+func (r *UserResolver) Roles(ctx context.Context) ([]*roleResolver, error) {
+	// 🚨 SECURITY: Only the user and site admins can view the roles of the
+	// user.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.db, r.user.ID); err != nil {
+		return nil, err
+	}
+
+	assignments, err := r.db.Roles().ListAssignments(ctx, database.ListRoleAssignmentsOpts{UserID: r.user.ID})
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*roleResolver, 0, len(assignments))
+	for _, a := range assignments {
+		role, err := r.db.Roles().GetByID(ctx, a.RoleID)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, &roleResolver{db: r.db, role: role})
+	}
+	return resolvers, nil
+}
+
+func (r *UserResolver) Permissions(ctx context.Context) ([]string, error) {
+	// 🚨 SECURITY: Only the user and site admins can view the permissions of
+	// the user.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.db, r.user.ID); err != nil {
+		return nil, err
+	}
+
+	if r.user.SiteAdmin {
+		return permissionStrings(database.AllPermissions), nil
+	}
+	permissions, err := r.db.Roles().ListPermissionsForUser(ctx, r.user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return permissionStrings(permissions), nil
+}
+
+func permissionStrings(permissions []database.Permission) []string {
+	s := make([]string, len(permissions))
+	for i, p := range permissions {
+		s[i] = string(p)
+	}
+	return s
+}
@@ -0,0 +1,144 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/graph-gophers/graphql-go/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestAssignRole(t *testing.T) {
+	newDB := func(currentUser *types.User) (*database.MockDB, *database.MockRoleStore) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(currentUser, nil)
+
+		roles := database.NewMockRoleStore()
+		roles.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*database.Role, error) {
+			return &database.Role{ID: id, Name: "repo_admin"}, nil
+		})
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.RolesFunc.SetDefaultReturn(roles)
+		return db, roles
+	}
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	query := `
+		mutation($role: ID!, $user: ID, $organization: ID) {
+			assignRole(role: $role, user: $user, organization: $organization) {
+				alwaysNil
+			}
+		}
+	`
+
+	t.Run("non-admin", func(t *testing.T) {
+		db, roles := newDB(&types.User{ID: 1})
+		RunTests(t, []*Test{{
+			Context:        ctx,
+			Schema:         mustParseGraphQLSchema(t, db),
+			Query:          query,
+			ExpectedResult: "null",
+			ExpectedErrors: []*errors.QueryError{{
+				Message: "must be site admin",
+				Path:    []interface{}{"assignRole"},
+			}},
+			Variables: map[string]interface{}{"role": string(marshalRoleID(1)), "user": string(MarshalUserID(2))},
+		}})
+		if len(roles.AssignFunc.History()) != 0 {
+			t.Fatal("role was assigned")
+		}
+	})
+
+	t.Run("user and organization", func(t *testing.T) {
+		db, roles := newDB(&types.User{ID: 1, SiteAdmin: true})
+		RunTests(t, []*Test{{
+			Context:        ctx,
+			Schema:         mustParseGraphQLSchema(t, db),
+			Query:          query,
+			ExpectedResult: "null",
+			ExpectedErrors: []*errors.QueryError{{
+				Message: "a role must be assigned to either a user or an organization",
+				Path:    []interface{}{"assignRole"},
+			}},
+			Variables: map[string]interface{}{
+				"role":         string(marshalRoleID(1)),
+				"user":         string(MarshalUserID(2)),
+				"organization": string(MarshalOrgID(3)),
+			},
+		}})
+		if len(roles.AssignFunc.History()) != 0 {
+			t.Fatal("role was assigned")
+		}
+	})
+
+	t.Run("organization", func(t *testing.T) {
+		db, roles := newDB(&types.User{ID: 1, SiteAdmin: true})
+		RunTests(t, []*Test{{
+			Context:        ctx,
+			Schema:         mustParseGraphQLSchema(t, db),
+			Query:          query,
+			ExpectedResult: `{"assignRole": {"alwaysNil": null}}`,
+			Variables:      map[string]interface{}{"role": string(marshalRoleID(1)), "organization": string(MarshalOrgID(3))},
+		}})
+
+		h := roles.AssignFunc.History()
+		if len(h) != 1 {
+			t.Fatalf("want 1 call to Assign, got %d", len(h))
+		}
+		if diff := cmp.Diff(&database.RoleAssignment{RoleID: 1, OrgID: 3}, h[0].Arg1); diff != "" {
+			t.Fatalf("unexpected assignment (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestUserPermissions(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, SiteAdmin: id == 2}, nil
+	})
+
+	roles := database.NewMockRoleStore()
+	roles.ListPermissionsForUserFunc.SetDefaultReturn([]database.Permission{database.PermissionInsightsEdit}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.RolesFunc.SetDefaultReturn(roles)
+
+	RunTests(t, []*Test{
+		{
+			Context: actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					node(id: "VXNlcjox") {
+						... on User {
+							permissions
+						}
+					}
+				}
+			`,
+			ExpectedResult: `{"node": {"permissions": ["INSIGHTS_EDIT"]}}`,
+		},
+		{
+			Context: actor.WithActor(context.Background(), &actor.Actor{UID: 2}),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					node(id: "VXNlcjoy") {
+						... on User {
+							permissions
+						}
+					}
+				}
+			`,
+			ExpectedResult: `{"node": {"permissions": ["BATCH_CHANGES_ADMIN", "CODE_MONITORS_MANAGE", "INSIGHTS_EDIT", "REPO_ADMIN"]}}`,
+		},
+	})
+}
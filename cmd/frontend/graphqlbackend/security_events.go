@@ -0,0 +1,351 @@
+package graphqlbackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+type securityEventsArgs struct {
+	graphqlutil.ConnectionArgs
+	After *string
+	Names *[]string
+	User  *graphql.ID
+	Since *DateTime
+	Until *DateTime
+}
+
+// toListOpts transforms the GraphQL securityEventsArgs into options that can
+// be provided to the SecurityEventLogStore's Count and List methods.
+func (args *securityEventsArgs) toListOpts() (database.SecurityEventsListOptions, error) {
+	var opts database.SecurityEventsListOptions
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	} else {
+		opts.Limit = 50
+	}
+	if args.After != nil {
+		var err error
+		opts.Cursor, err = strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return opts, errors.Wrap(err, "parsing the after cursor")
+		}
+	}
+	if args.Names != nil {
+		opts.Names = *args.Names
+	}
+	if args.User != nil {
+		var err error
+		opts.UserID, err = UnmarshalUserID(*args.User)
+		if err != nil {
+			return opts, err
+		}
+	}
+	if args.Since != nil {
+		opts.Since = &args.Since.Time
+	}
+	if args.Until != nil {
+		opts.Until = &args.Until.Time
+	}
+	return opts, nil
+}
+
+// SecurityEvents is the top level query used to return the events of the
+// security audit log.
+func (r *schemaResolver) SecurityEvents(ctx context.Context, args *securityEventsArgs) (*securityEventConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins can view the audit log.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	opts, err := args.toListOpts()
+	if err != nil {
+		return nil, err
+	}
+	return &securityEventConnectionResolver{db: r.db, opts: opts}, nil
+}
+
+type securityEventConnectionResolver struct {
+	db   database.DB
+	opts database.SecurityEventsListOptions
+
+	once   sync.Once
+	events []*database.SecurityEvent
+	next   int64
+	err    error
+}
+
+func (r *securityEventConnectionResolver) Nodes(ctx context.Context) ([]*securityEventResolver, error) {
+	events, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*securityEventResolver, len(events))
+	for i, e := range events {
+		nodes[i] = &securityEventResolver{db: r.db, event: e}
+	}
+	return nodes, nil
+}
+
+func (r *securityEventConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := database.SecurityEventLogs(r.db).Count(ctx, r.opts)
+	return int32(count), err
+}
+
+func (r *securityEventConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next == 0 {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	return graphqlutil.NextPageCursor(fmt.Sprint(next)), nil
+}
+
+func (r *securityEventConnectionResolver) compute(ctx context.Context) ([]*database.SecurityEvent, int64, error) {
+	r.once.Do(func() {
+		// Fetch one extra event to determine whether there is a next page.
+		opts := r.opts
+		if opts.Limit > 0 {
+			opts.Limit++
+		}
+		r.events, r.err = database.SecurityEventLogs(r.db).List(ctx, opts)
+		if r.err == nil && r.opts.Limit > 0 && len(r.events) > r.opts.Limit {
+			r.events = r.events[:r.opts.Limit]
+			r.next = r.events[len(r.events)-1].ID
+		}
+	})
+	return r.events, r.next, r.err
+}
+
+type securityEventResolver struct {
+	db    database.DB
+	event *database.SecurityEvent
+}
+
+func (r *securityEventResolver) ID() graphql.ID {
+	return relay.MarshalID("SecurityEvent", r.event.ID)
+}
+
+func (r *securityEventResolver) Name() string {
+	return string(r.event.Name)
+}
+
+func (r *securityEventResolver) User(ctx context.Context) (*UserResolver, error) {
+	if r.event.UserID == 0 {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, r.db, int32(r.event.UserID))
+	if err != nil && errcode.IsNotFound(err) {
+		// Don't throw an error if a user has been deleted.
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *securityEventResolver) AnonymousUserID() string {
+	return r.event.AnonymousUserID
+}
+
+func (r *securityEventResolver) URL() string {
+	// 🚨 SECURITY: It is important to sanitize event URL before responding to the
+	// client to prevent malicious data being rendered in browser.
+	return database.SanitizeEventURL(r.event.URL)
+}
+
+func (r *securityEventResolver) Source() string {
+	return r.event.Source
+}
+
+func (r *securityEventResolver) Argument() (JSONValue, error) {
+	v, err := unmarshalJSONValue(r.event.Argument)
+	if err != nil || v == nil {
+		return JSONValue{Value: map[string]interface{}{}}, err
+	}
+	return *v, nil
+}
+
+func (r *securityEventResolver) Before() (*JSONValue, error) {
+	return unmarshalJSONValue(r.event.Before)
+}
+
+func (r *securityEventResolver) After() (*JSONValue, error) {
+	return unmarshalJSONValue(r.event.After)
+}
+
+func (r *securityEventResolver) Changes() ([]*securityEventChangeResolver, error) {
+	before, err := unmarshalJSONValue(r.event.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := unmarshalJSONValue(r.event.After)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil && after == nil {
+		return []*securityEventChangeResolver{}, nil
+	}
+
+	var b, a interface{}
+	if before != nil {
+		b = before.Value
+	}
+	if after != nil {
+		a = after.Value
+	}
+	return diffJSONValues(b, a), nil
+}
+
+func (r *securityEventResolver) Version() string {
+	return r.event.Version
+}
+
+func (r *securityEventResolver) Timestamp() DateTime {
+	return DateTime{Time: r.event.Timestamp}
+}
+
+func (r *securityEventResolver) Hash() *string {
+	if r.event.Hash == "" {
+		return nil
+	}
+	return &r.event.Hash
+}
+
+func unmarshalJSONValue(raw json.RawMessage) (*JSONValue, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return &JSONValue{Value: v}, nil
+}
+
+type securityEventChangeResolver struct {
+	path          string
+	before, after interface{}
+}
+
+func (r *securityEventChangeResolver) Path() string { return r.path }
+
+func (r *securityEventChangeResolver) Before() *JSONValue {
+	if r.before == nil {
+		return nil
+	}
+	return &JSONValue{Value: r.before}
+}
+
+func (r *securityEventChangeResolver) After() *JSONValue {
+	if r.after == nil {
+		return nil
+	}
+	return &JSONValue{Value: r.after}
+}
+
+// diffJSONValues returns the leaf values that differ between the decoded JSON
+// values before and after, sorted by path.
+func diffJSONValues(before, after interface{}) []*securityEventChangeResolver {
+	b, a := map[string]interface{}{}, map[string]interface{}{}
+	flattenJSONValue("", before, b)
+	flattenJSONValue("", after, a)
+
+	changes := []*securityEventChangeResolver{}
+	for path, v := range b {
+		if w, ok := a[path]; !ok || !reflect.DeepEqual(v, w) {
+			changes = append(changes, &securityEventChangeResolver{path: path, before: v, after: a[path]})
+		}
+	}
+	for path, w := range a {
+		if _, ok := b[path]; !ok {
+			changes = append(changes, &securityEventChangeResolver{path: path, after: w})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].path < changes[j].path })
+	return changes
+}
+
+// flattenJSONValue adds the leaf values of v to leaves, keyed by their
+// dot-separated path. Empty objects and arrays are leaves.
+func flattenJSONValue(path string, v interface{}, leaves map[string]interface{}) {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && path != "" {
+			leaves[path] = v
+		}
+		for key, child := range v {
+			flattenJSONValue(join(key), child, leaves)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			leaves[path] = v
+		}
+		for i, child := range v {
+			flattenJSONValue(join(strconv.Itoa(i)), child, leaves)
+		}
+	case nil:
+		if path != "" {
+			leaves[path] = nil
+		}
+	default:
+		leaves[path] = v
+	}
+}
+
+// SecurityEventLogVerification verifies the hash chain of the security audit
+// log.
+func (r *schemaResolver) SecurityEventLogVerification(ctx context.Context) (*securityEventLogVerificationResolver, error) {
+	// 🚨 SECURITY: Only site admins can view the audit log.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	res, err := database.SecurityEventLogs(r.db).VerifyChain(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "verifying security event log")
+	}
+	return &securityEventLogVerificationResolver{res: res}, nil
+}
+
+type securityEventLogVerificationResolver struct {
+	res *database.SecurityEventLogVerification
+}
+
+func (r *securityEventLogVerificationResolver) Valid() bool {
+	return r.res.FirstInvalidID == 0
+}
+
+func (r *securityEventLogVerificationResolver) VerifiedCount() int32 {
+	return int32(r.res.Verified)
+}
+
+func (r *securityEventLogVerificationResolver) FirstInvalidEvent() *graphql.ID {
+	if r.res.FirstInvalidID == 0 {
+		return nil
+	}
+	id := relay.MarshalID("SecurityEvent", r.res.FirstInvalidID)
+	return &id
+}
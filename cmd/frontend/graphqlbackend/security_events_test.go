@@ -0,0 +1,67 @@
+package graphqlbackend
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffJSONValues(t *testing.T) {
+	decode := func(s string) interface{} {
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	type change struct {
+		Path          string
+		Before, After interface{}
+	}
+	diff := func(before, after interface{}) []change {
+		var changes []change
+		for _, c := range diffJSONValues(before, after) {
+			changes = append(changes, change{c.path, c.before, c.after})
+		}
+		return changes
+	}
+
+	for _, tc := range []struct {
+		name          string
+		before, after interface{}
+		want          []change
+	}{
+		{
+			name:   "changed, added and removed values",
+			before: decode(`{"a": 1, "b": {"c": "x", "d": [1, 2]}, "e": true}`),
+			after:  decode(`{"a": 2, "b": {"c": "x", "d": [1]}, "f": "new"}`),
+			want: []change{
+				{Path: "a", Before: 1.0, After: 2.0},
+				{Path: "b.d.1", Before: 2.0},
+				{Path: "e", Before: true},
+				{Path: "f", After: "new"},
+			},
+		},
+		{
+			name:  "created resource",
+			after: decode(`{"id": 1, "scopes": []}`),
+			want: []change{
+				{Path: "id", After: 1.0},
+				{Path: "scopes", After: []interface{}{}},
+			},
+		},
+		{
+			name:   "unchanged",
+			before: decode(`{"a": {"b": 1}}`),
+			after:  decode(`{"a": {"b": 1}}`),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, diff(tc.before, tc.after)); d != "" {
+				t.Fatalf("unexpected changes (-want +have):\n%s", d)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package graphqlbackend
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"strconv"
 	"strings"
@@ -14,8 +15,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/siteid"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/internal/version"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
@@ -179,14 +182,27 @@ func (r *schemaResolver) UpdateSiteConfiguration(ctx context.Context, args *stru
 	if err != nil {
 		return false, errors.Errorf("error unredacting secrets: %s", err)
 	}
+	before := prev.Site
 	prev.Site = unredacted
 	// TODO(slimsag): future: actually pass lastID through to prevent race conditions
 	if err := globals.ConfigurationServerFrontendOnly.Write(ctx, prev); err != nil {
 		return false, err
 	}
+	database.SecurityEventLogs(r.db).LogAuditEvent(ctx, database.SecurityEventNameSiteConfigUpdated, nil, redactedSiteConfig(before), redactedSiteConfig(unredacted))
 	return globals.ConfigurationServerFrontendOnly.NeedServerRestart(), nil
 }
 
+// redactedSiteConfig returns the site configuration with its secrets redacted,
+// for recording in the audit log.
+func redactedSiteConfig(site string) json.RawMessage {
+	redacted, err := conf.RedactSecrets(conftypes.RawUnified{Site: site})
+	if err != nil {
+		// Don't risk logging secrets of a configuration we can't parse.
+		return nil
+	}
+	return jsonc.Normalize(redacted.Site)
+}
+
 var siteConfigAllowEdits, _ = strconv.ParseBool(env.Get("SITE_CONFIG_ALLOW_EDITS", "false", "When SITE_CONFIG_FILE is in use, allow edits in the application to be made which will be overwritten on next process restart"))
 
 func canUpdateSiteConfiguration() bool {
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/session"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/suspiciousnames"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -351,6 +352,12 @@ func (r *schemaResolver) UpdatePassword(ctx context.Context, args *struct {
 		return nil, err
 	}
 
+	// 🚨 SECURITY: Revoke the other sessions of the user, which might belong to
+	// someone who knew the previous password.
+	if err := r.db.UserSessions().DeleteByUser(ctx, user.ID, session.KeyFromContext(ctx)); err != nil {
+		return nil, errors.Wrap(err, "revoking other sessions")
+	}
+
 	if conf.CanSendEmail() {
 		if err := backend.UserEmails.SendUserEmailOnFieldUpdate(ctx, r.db, user.ID, "updated the password"); err != nil {
 			log15.Warn("Failed to send email to inform user of password update", "error", err)
@@ -375,6 +382,12 @@ func (r *schemaResolver) CreatePassword(ctx context.Context, args *struct {
 		return nil, err
 	}
 
+	// 🚨 SECURITY: Revoke the other sessions of the user, which might belong to
+	// someone who knew the previous password.
+	if err := r.db.UserSessions().DeleteByUser(ctx, user.ID, session.KeyFromContext(ctx)); err != nil {
+		return nil, errors.Wrap(err, "revoking other sessions")
+	}
+
 	if conf.CanSendEmail() {
 		if err := backend.UserEmails.SendUserEmailOnFieldUpdate(ctx, r.db, user.ID, "created a password"); err != nil {
 			log15.Warn("Failed to send email to inform user of password creation", "error", err)
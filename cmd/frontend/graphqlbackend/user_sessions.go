@@ -0,0 +1,162 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/session"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func (r *UserResolver) Sessions(ctx context.Context) ([]*userSessionResolver, error) {
+	// 🚨 SECURITY: Only site admins and the user can list a user's sessions.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.db, r.user.ID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := r.db.UserSessions().ListByUser(ctx, r.user.ID)
+	if err != nil {
+		return nil, err
+	}
+	currentKey := session.KeyFromContext(ctx)
+	resolvers := make([]*userSessionResolver, len(sessions))
+	for i, s := range sessions {
+		resolvers[i] = &userSessionResolver{session: s, current: currentKey != "" && s.Key == currentKey}
+	}
+	return resolvers, nil
+}
+
+type userSessionResolver struct {
+	session *database.UserSession
+	current bool
+}
+
+func marshalUserSessionID(id int64) graphql.ID {
+	return relay.MarshalID("UserSession", id)
+}
+
+func unmarshalUserSessionID(id graphql.ID) (sessionID int64, err error) {
+	err = relay.UnmarshalSpec(id, &sessionID)
+	return
+}
+
+func (r *userSessionResolver) ID() graphql.ID { return marshalUserSessionID(r.session.ID) }
+
+func (r *userSessionResolver) Device() string { return describeUserAgent(r.session.UserAgent) }
+
+func (r *userSessionResolver) IPAddress() *string {
+	if r.session.IPAddress == "" {
+		return nil
+	}
+	return &r.session.IPAddress
+}
+
+func (r *userSessionResolver) UserAgent() *string {
+	if r.session.UserAgent == "" {
+		return nil
+	}
+	return &r.session.UserAgent
+}
+
+func (r *userSessionResolver) CreatedAt() DateTime { return DateTime{Time: r.session.CreatedAt} }
+
+func (r *userSessionResolver) LastSeenAt() DateTime { return DateTime{Time: r.session.LastSeenAt} }
+
+func (r *userSessionResolver) ExpiresAt() DateTime { return DateTime{Time: r.session.ExpiresAt} }
+
+func (r *userSessionResolver) Current() bool { return r.current }
+
+func (r *schemaResolver) RevokeSession(ctx context.Context, args *struct {
+	Session graphql.ID
+}) (*EmptyResponse, error) {
+	id, err := unmarshalUserSessionID(args.Session)
+	if err != nil {
+		return nil, err
+	}
+	s, err := r.db.UserSessions().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins and the user can revoke a user's session.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.db, s.UserID); err != nil {
+		return nil, err
+	}
+	if err := r.db.UserSessions().Delete(ctx, s.ID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) RevokeAllSessions(ctx context.Context, args *struct {
+	User        graphql.ID
+	KeepCurrent *bool
+}) (*EmptyResponse, error) {
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins and the user can revoke a user's sessions.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.db, userID); err != nil {
+		return nil, err
+	}
+
+	var exceptKey string
+	if args.KeepCurrent != nil && *args.KeepCurrent {
+		exceptKey = session.KeyFromContext(ctx)
+	}
+	if err := r.db.UserSessions().DeleteByUser(ctx, userID, exceptKey); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+// describeUserAgent returns a short description of the browser and operating
+// system of a user agent, such as "Firefox on Windows".
+func describeUserAgent(ua string) string {
+	browser := ""
+	switch {
+	case strings.Contains(ua, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(ua, "Firefox/"), strings.Contains(ua, "FxiOS/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "Chrome/"), strings.Contains(ua, "CriOS/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "Safari/"):
+		browser = "Safari"
+	}
+
+	os := ""
+	switch {
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"):
+		os = "iOS"
+	case strings.Contains(ua, "Android"):
+		os = "Android"
+	case strings.Contains(ua, "CrOS"):
+		os = "ChromeOS"
+	case strings.Contains(ua, "Windows"):
+		os = "Windows"
+	case strings.Contains(ua, "Macintosh"), strings.Contains(ua, "Mac OS X"):
+		os = "macOS"
+	case strings.Contains(ua, "Linux"):
+		os = "Linux"
+	}
+
+	switch {
+	case browser != "" && os != "":
+		return browser + " on " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return "Unknown browser on " + os
+	default:
+		return "Unknown device"
+	}
+}
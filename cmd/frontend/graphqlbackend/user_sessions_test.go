@@ -0,0 +1,82 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// 🚨 SECURITY: This tests that users can't revoke the sessions of other users.
+func TestMutation_RevokeSession(t *testing.T) {
+	newDB := func(currentUser *types.User) (database.DB, *database.MockUserSessionStore) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(currentUser, nil)
+
+		sessions := database.NewMockUserSessionStore()
+		sessions.GetByIDFunc.SetDefaultReturn(&database.UserSession{ID: 7, UserID: 2}, nil)
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.UserSessionsFunc.SetDefaultReturn(sessions)
+		return db, sessions
+	}
+
+	t.Run("other user", func(t *testing.T) {
+		db, sessions := newDB(&types.User{ID: 1})
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+		_, err := (&schemaResolver{db: db}).RevokeSession(ctx, &struct{ Session graphql.ID }{Session: marshalUserSessionID(7)})
+		if _, ok := err.(*backend.InsufficientAuthorizationError); !ok {
+			t.Fatalf("got error %v, want InsufficientAuthorizationError", err)
+		}
+		if len(sessions.DeleteFunc.History()) != 0 {
+			t.Fatal("session was revoked")
+		}
+	})
+
+	t.Run("same user", func(t *testing.T) {
+		db, sessions := newDB(&types.User{ID: 2})
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 2})
+
+		if _, err := (&schemaResolver{db: db}).RevokeSession(ctx, &struct{ Session graphql.ID }{Session: marshalUserSessionID(7)}); err != nil {
+			t.Fatal(err)
+		}
+		if h := sessions.DeleteFunc.History(); len(h) != 1 || h[0].Arg1 != 7 {
+			t.Fatalf("unexpected calls to Delete: %+v", h)
+		}
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		db, sessions := newDB(&types.User{ID: 1, SiteAdmin: true})
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+		if _, err := (&schemaResolver{db: db}).RevokeSession(ctx, &struct{ Session graphql.ID }{Session: marshalUserSessionID(7)}); err != nil {
+			t.Fatal(err)
+		}
+		if len(sessions.DeleteFunc.History()) != 1 {
+			t.Fatal("session was not revoked")
+		}
+	})
+}
+
+func TestDescribeUserAgent(t *testing.T) {
+	for ua, want := range map[string]string{
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/98.0.4758.102 Safari/537.36":               "Chrome on macOS",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:97.0) Gecko/20100101 Firefox/97.0":                                                          "Firefox on Windows",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 15_3 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.3 Mobile/15E148 Safari/604.1": "Safari on iOS",
+		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/98.0.4758.102 Safari/537.36 Edg/98.0.1108.56":              "Edge on Linux",
+		"Mozilla/5.0 (Linux; Android 12) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/98.0.4758.101 Mobile Safari/537.36":                        "Chrome on Android",
+		"curl/7.79.1": "Unknown device",
+		"":            "Unknown device",
+	} {
+		if got := describeUserAgent(ua); got != want {
+			t.Errorf("describeUserAgent(%q) = %q, want %q", ua, got, want)
+		}
+	}
+}
@@ -203,6 +203,13 @@ func HandleResetPasswordCode(db database.DB) func(w http.ResponseWriter, r *http
 			return
 		}
 
+		// 🚨 SECURITY: Revoke all sessions of the user, which might belong to
+		// someone who knew the previous password.
+		if err := db.Users().InvalidateSessionsByID(ctx, params.UserID); err != nil {
+			httpLogAndError(w, "Unexpected error", http.StatusInternalServerError, "err", err)
+			return
+		}
+
 		database.LogPasswordEvent(ctx, db, r, database.SecurityEventNamePasswordChanged, params.UserID)
 
 		if conf.CanSendEmail() {
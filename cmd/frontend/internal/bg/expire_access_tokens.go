@@ -0,0 +1,107 @@
+package bg
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+)
+
+// accessTokenExpiryNotice is how long before an access token expires its
+// subject user is notified.
+const accessTokenExpiryNotice = 7 * 24 * time.Hour
+
+// ExpireAccessTokens periodically revokes access tokens whose expiry has
+// passed and notifies users of access tokens that expire soon.
+func ExpireAccessTokens(ctx context.Context, db database.DB) {
+	for {
+		revoked, err := db.AccessTokens().DeleteExpired(ctx)
+		if err != nil {
+			log15.Error("revoking expired access tokens", "error", err)
+		} else if len(revoked) > 0 {
+			log15.Info("revoked expired access tokens", "count", len(revoked))
+		}
+
+		if conf.CanSendEmail() {
+			if err := notifyExpiringAccessTokens(ctx, db); err != nil {
+				log15.Error("notifying users of expiring access tokens", "error", err)
+			}
+		}
+
+		time.Sleep(time.Hour)
+	}
+}
+
+func notifyExpiringAccessTokens(ctx context.Context, db database.DB) error {
+	tokens, err := db.AccessTokens().ListExpiring(ctx, time.Now().Add(accessTokenExpiryNotice))
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		// Mark the token first, so that concurrent frontend processes don't
+		// notify the user again.
+		if err := db.AccessTokens().MarkExpiryNotified(ctx, token.ID); err != nil {
+			if err != database.ErrAccessTokenNotFound {
+				return err
+			}
+			continue
+		}
+
+		if err := sendAccessTokenExpiryEmail(ctx, db, token); err != nil {
+			log15.Warn("Failed to send email to inform user of access token expiry", "tokenID", token.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+func sendAccessTokenExpiryEmail(ctx context.Context, db database.DB, token *database.AccessToken) error {
+	email, _, err := db.UserEmails().GetPrimaryEmail(ctx, token.SubjectUserID)
+	if err != nil {
+		return err
+	}
+	user, err := db.Users().GetByID(ctx, token.SubjectUserID)
+	if err != nil {
+		return err
+	}
+
+	return txemail.Send(ctx, txemail.Message{
+		To:       []string{email},
+		Template: accessTokenExpiryEmailTemplate,
+		Data: struct {
+			Username  string
+			Note      string
+			ExpiresAt string
+			Host      string
+		}{
+			Username:  user.Username,
+			Note:      token.Note,
+			ExpiresAt: token.ExpiresAt.UTC().Format(time.RFC1123),
+			Host:      globals.ExternalURL().Host,
+		},
+	})
+}
+
+var accessTokenExpiryEmailTemplate = txemail.MustValidate(txtypes.Templates{
+	Subject: `Your Sourcegraph access token expires soon ({{.Host}})`,
+	Text: `
+Hi {{.Username}},
+
+Your access token "{{.Note}}" on Sourcegraph ({{.Host}}) expires on {{.ExpiresAt}}.
+
+If you still need it, create a new access token in your user settings and replace the expiring one.
+`,
+	HTML: `
+<p>Hi <strong>{{.Username}}</strong>,</p>
+
+<p>Your access token <strong>{{.Note}}</strong> on Sourcegraph ({{.Host}}) expires on {{.ExpiresAt}}.</p>
+
+<p>If you still need it, create a new access token in your user settings and replace the expiring one.</p>
+`,
+})
@@ -38,10 +38,11 @@ func newExternalHTTPHandler(
 	db database.DB,
 	schema *graphql.Schema,
 	gitHubWebhook webhooks.Registerer,
-	gitLabWebhook, bitbucketServerWebhook http.Handler,
+	gitLabWebhook, bitbucketServerWebhook, bitbucketCloudWebhook http.Handler,
 	newCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler,
 	newExecutorProxyHandler enterprise.NewExecutorProxyHandler,
 	newGitHubAppCloudSetupHandler enterprise.NewGitHubAppCloudSetupHandler,
+	newSCIMHandler enterprise.NewSCIMHandler,
 	rateLimitWatcher graphqlbackend.LimitWatcher,
 ) (http.Handler, error) {
 	// Each auth middleware determines on a per-request basis whether it should be enabled (if not, it
@@ -50,7 +51,7 @@ func newExternalHTTPHandler(
 
 	// HTTP API handler, the call order of middleware is LIFO.
 	r := router.New(mux.NewRouter().PathPrefix("/.api/").Subrouter())
-	apiHandler := internalhttpapi.NewHandler(db, r, schema, gitHubWebhook, gitLabWebhook, bitbucketServerWebhook, newCodeIntelUploadHandler, rateLimitWatcher)
+	apiHandler := internalhttpapi.NewHandler(db, r, schema, gitHubWebhook, gitLabWebhook, bitbucketServerWebhook, bitbucketCloudWebhook, newCodeIntelUploadHandler, rateLimitWatcher)
 	if hooks.PostAuthMiddleware != nil {
 		// 🚨 SECURITY: These all run after the auth handler so the client is authenticated.
 		apiHandler = hooks.PostAuthMiddleware(apiHandler)
@@ -69,6 +70,9 @@ func newExternalHTTPHandler(
 	// 🚨 SECURITY: This handler implements its own token auth inside enterprise
 	executorProxyHandler := newExecutorProxyHandler()
 
+	// 🚨 SECURITY: This handler implements its own bearer token auth inside enterprise
+	scimHandler := newSCIMHandler()
+
 	githubAppCloudSetupHandler := newGitHubAppCloudSetupHandler()
 
 	// App handler (HTML pages), the call order of middleware is LIFO.
@@ -87,6 +91,7 @@ func newExternalHTTPHandler(
 	// Mount handlers and assets.
 	sm := http.NewServeMux()
 	sm.Handle("/.api/", secureHeadersMiddleware(apiHandler, crossOriginPolicyAPI))
+	sm.Handle("/.api/scim/", secureHeadersMiddleware(scimHandler, crossOriginPolicyNever))
 	sm.Handle("/.executors/", secureHeadersMiddleware(executorProxyHandler, crossOriginPolicyNever))
 	sm.Handle("/", secureHeadersMiddleware(appHandler, crossOriginPolicyNever))
 	assetsutil.Mount(sm)
@@ -250,6 +250,7 @@ func Main(enterpriseSetupHook func(db database.DB, c conftypes.UnifiedWatchable)
 	goroutine.Go(func() { bg.DeleteOldCacheDataInRedis() })
 	goroutine.Go(func() { bg.DeleteOldEventLogsInPostgres(context.Background(), db) })
 	goroutine.Go(func() { bg.DeleteOldSecurityEventLogsInPostgres(context.Background(), db) })
+	goroutine.Go(func() { bg.ExpireAccessTokens(context.Background(), db) })
 	goroutine.Go(func() { updatecheck.Start(db) })
 
 	schema, err := graphqlbackend.NewSchema(db,
@@ -313,9 +314,11 @@ func makeExternalAPI(db database.DB, schema *graphql.Schema, enterprise enterpri
 		enterprise.GitHubWebhook,
 		enterprise.GitLabWebhook,
 		enterprise.BitbucketServerWebhook,
+		enterprise.BitbucketCloudWebhook,
 		enterprise.NewCodeIntelUploadHandler,
 		enterprise.NewExecutorProxyHandler,
 		enterprise.NewGitHubAppCloudSetupHandler,
+		enterprise.NewSCIMHandler,
 		rateLimiter,
 	)
 	if err != nil {
@@ -1,9 +1,16 @@
 package httpapi
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/cockroachdb/errors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
@@ -12,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/realip"
 )
 
 // AccessTokenAuthMiddleware authenticates the user based on the
@@ -63,13 +71,16 @@ func AccessTokenAuthMiddleware(db database.DB, next http.Handler) http.Handler {
 			//
 			// 🚨 SECURITY: It's important we check for the correct scopes to know what this token
 			// is allowed to do.
-			var requiredScope string
+			var scopes []string
 			if sudoUser == "" {
-				requiredScope = authz.ScopeUserAll
+				scopes = authz.UserScopes
 			} else {
-				requiredScope = authz.ScopeSiteAdminSudo
+				scopes = []string{authz.ScopeSiteAdminSudo}
 			}
-			subjectUserID, err := db.AccessTokens().Lookup(r.Context(), token, requiredScope)
+			accessToken, err := db.AccessTokens().Lookup(r.Context(), token, database.AccessTokenLookupOptions{
+				Scopes:   scopes,
+				RemoteIP: realip.FromRequest(r),
+			})
 			if err != nil {
 				if err == database.ErrAccessTokenNotFound || errors.HasType(err, database.InvalidTokenError{}) {
 					log15.Error("AccessTokenAuthMiddleware.invalidAccessToken", "token", token, "error", err)
@@ -81,6 +92,21 @@ func AccessTokenAuthMiddleware(db database.DB, next http.Handler) http.Handler {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 				return
 			}
+			subjectUserID := accessToken.SubjectUserID
+
+			// 🚨 SECURITY: Tokens without the "user:all" scope may only be used for the requests
+			// their scopes permit.
+			if sudoUser == "" {
+				allowed, err := accessTokenScopesAllow(r, accessToken.Scopes)
+				if err != nil {
+					http.Error(w, "Unable to determine the operations of the request.", http.StatusBadRequest)
+					return
+				}
+				if !allowed {
+					http.Error(w, "The access token's scopes do not permit this request.", http.StatusForbidden)
+					return
+				}
+			}
 
 			// Determine the actor's user ID.
 			var actorUserID int32
@@ -119,3 +145,191 @@ func AccessTokenAuthMiddleware(db database.DB, next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// accessTokenScopesAllow reports whether an access token with the given scopes
+// may be used to authenticate the request.
+//
+// Tokens with the "user:all" scope may be used for any request. Otherwise, a
+// token may be used for:
+//
+// - "user:read": GraphQL queries and read-only requests of isUserReadPath.
+// - "search": GraphQL queries of the search field and streaming search.
+// - "batch-changes": GraphQL queries and mutations of batchChangesFields.
+//
+// To inspect GraphQL requests, the request body is read and replaced.
+func accessTokenScopesAllow(r *http.Request, scopes []string) (bool, error) {
+	granted := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		granted[scope] = true
+	}
+	if granted[authz.ScopeUserAll] {
+		return true, nil
+	}
+
+	readOnly := r.Method == "GET" || r.Method == "HEAD"
+	if r.URL.Path != graphQLPath || r.Method != "POST" {
+		switch {
+		case granted[authz.ScopeUserRead] && readOnly && isUserReadPath(r.URL.Path):
+			return true, nil
+		case granted[authz.ScopeSearch] && readOnly && r.URL.Path == streamSearchPath:
+			return true, nil
+		}
+		return false, nil
+	}
+
+	ops, err := readGraphQLOperations(r)
+	if err != nil {
+		return false, err
+	}
+	if len(ops) == 0 {
+		return false, nil
+	}
+	for _, op := range ops {
+		switch {
+		case granted[authz.ScopeUserRead] && op.Operation == ast.OperationTypeQuery:
+		case granted[authz.ScopeSearch] && op.Operation == ast.OperationTypeQuery && topLevelFieldsMatch(op, isSearchField):
+		case granted[authz.ScopeBatchChanges] && op.Operation != ast.OperationTypeSubscription && topLevelFieldsMatch(op, isBatchChangesField):
+		default:
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+const (
+	graphQLPath      = "/.api/graphql"
+	streamSearchPath = "/.api/search/stream"
+)
+
+// isUserReadPath reports whether the path is one of the read-only endpoints
+// that tokens with the "user:read" scope may request, besides GraphQL queries.
+// Some GET endpoints have side effects (such as signing out), so this is an
+// allowlist.
+func isUserReadPath(path string) bool {
+	switch {
+	case path == streamSearchPath:
+		return true
+	case strings.HasPrefix(path, "/.api/repos/") && strings.HasSuffix(path, "/-/shield"):
+		return true
+	case !strings.HasPrefix(path, "/.api/") && strings.Contains(path, "/-/raw"):
+		// Raw file contents, served by the app at /<repo>[@<rev>]/-/raw/<path>.
+		return true
+	}
+	return false
+}
+
+// readGraphQLOperations parses the operations of the GraphQL request r and
+// restores its body so that it can be read again.
+func readGraphQLOperations(r *http.Request) ([]*ast.OperationDefinition, error) {
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(raw))
+
+	var body io.Reader = bytes.NewReader(raw)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		body = gzipReader
+	}
+
+	var params graphQLQueryParams
+	if err := json.NewDecoder(body).Decode(&params); err != nil {
+		return nil, err
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: params.Query})
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing query")
+	}
+
+	var ops []*ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		// Only the named operation is executed if one is given.
+		if params.OperationName != "" && (op.Name == nil || op.Name.Value != params.OperationName) {
+			continue
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// topLevelFieldsMatch reports whether all top-level selections of op are
+// fields matching the given predicate. Fragments are never matched, because
+// their fields are not known without resolving them.
+func topLevelFieldsMatch(op *ast.OperationDefinition, match func(name string) bool) bool {
+	if op.SelectionSet == nil {
+		return false
+	}
+	for _, sel := range op.SelectionSet.Selections {
+		field, ok := sel.(*ast.Field)
+		if !ok || field.Name == nil {
+			return false
+		}
+		if name := field.Name.Value; name != "__typename" && !match(name) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSearchField(name string) bool {
+	return name == "search"
+}
+
+func isBatchChangesField(name string) bool {
+	return batchChangesFields[name]
+}
+
+// batchChangesFields are the top-level query and mutation fields of the batch
+// changes GraphQL API.
+var batchChangesFields = map[string]bool{
+	// Queries
+	"batchChanges":           true,
+	"batchChange":            true,
+	"batchChangesCodeHosts":  true,
+	"batchChangesSigningKey": true,
+	"batchSpecs":             true,
+
+	// Mutations
+	"createChangesetSpec":                true,
+	"syncChangeset":                      true,
+	"reenqueueChangeset":                 true,
+	"createBatchChange":                  true,
+	"createBatchSpec":                    true,
+	"createEmptyBatchChange":             true,
+	"createBatchSpecFromRaw":             true,
+	"replaceBatchSpecInput":              true,
+	"deleteBatchSpec":                    true,
+	"executeBatchSpec":                   true,
+	"applyBatchChange":                   true,
+	"closeBatchChange":                   true,
+	"setBatchChangeSchedule":             true,
+	"moveBatchChange":                    true,
+	"deleteBatchChange":                  true,
+	"createBatchChangesCredential":       true,
+	"deleteBatchChangesCredential":       true,
+	"createBatchChangesSigningKey":       true,
+	"deleteBatchChangesSigningKey":       true,
+	"detachChangesets":                   true,
+	"createChangesetComments":            true,
+	"reenqueueChangesets":                true,
+	"mergeChangesets":                    true,
+	"closeChangesets":                    true,
+	"publishChangesets":                  true,
+	"cancelBatchSpecExecution":           true,
+	"cancelBatchSpecWorkspaceExecution":  true,
+	"retryBatchSpecWorkspaceExecution":   true,
+	"retryBatchSpecExecution":            true,
+	"enqueueBatchSpecWorkspaceExecution": true,
+	"toggleBatchSpecAutoApply":           true,
+}
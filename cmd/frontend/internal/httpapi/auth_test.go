@@ -3,9 +3,12 @@ package httpapi
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
+	"strings"
 	"testing"
 
 	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
@@ -74,7 +77,7 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req.Header.Set("Authorization", "token badbad")
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultReturn(0, database.InvalidTokenError{})
+		accessTokens.LookupFunc.SetDefaultReturn(nil, database.InvalidTokenError{})
 		db := database.NewMockDB()
 		db.AccessTokensFunc.SetDefaultReturn(accessTokens)
 
@@ -88,14 +91,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 			req.Header.Set("Authorization", headerValue)
 
 			accessTokens := database.NewMockAccessTokenStore()
-			accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+			accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, opts database.AccessTokenLookupOptions) (*database.AccessToken, error) {
 				if want := "abcdef"; tokenHexEncoded != want {
 					t.Errorf("got %q, want %q", tokenHexEncoded, want)
 				}
-				if want := authz.ScopeUserAll; requiredScope != want {
-					t.Errorf("got %q, want %q", requiredScope, want)
+				if want := authz.UserScopes; !reflect.DeepEqual(opts.Scopes, want) {
+					t.Errorf("got %q, want %q", opts.Scopes, want)
 				}
-				return 123, nil
+				return &database.AccessToken{SubjectUserID: 123, Scopes: []string{authz.ScopeUserAll}}, nil
 			})
 			db := database.NewMockDB()
 			db.AccessTokensFunc.SetDefaultReturn(accessTokens)
@@ -112,14 +115,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req = req.WithContext(actor.WithActor(context.Background(), &actor.Actor{UID: 456}))
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, opts database.AccessTokenLookupOptions) (*database.AccessToken, error) {
 			if want := "abcdef"; tokenHexEncoded != want {
 				t.Errorf("got %q, want %q", tokenHexEncoded, want)
 			}
-			if want := authz.ScopeUserAll; requiredScope != want {
-				t.Errorf("got %q, want %q", requiredScope, want)
+			if want := authz.UserScopes; !reflect.DeepEqual(opts.Scopes, want) {
+				t.Errorf("got %q, want %q", opts.Scopes, want)
 			}
-			return 123, nil
+			return &database.AccessToken{SubjectUserID: 123, Scopes: []string{authz.ScopeUserAll}}, nil
 		})
 		db := database.NewMockDB()
 		db.AccessTokensFunc.SetDefaultReturn(accessTokens)
@@ -146,14 +149,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 			req = req.WithContext(actor.WithActor(context.Background(), &actor.Actor{UID: 456}))
 
 			accessTokens := database.NewMockAccessTokenStore()
-			accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+			accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, opts database.AccessTokenLookupOptions) (*database.AccessToken, error) {
 				if want := "abcdef"; tokenHexEncoded != want {
 					t.Errorf("got %q, want %q", tokenHexEncoded, want)
 				}
-				if want := authz.ScopeUserAll; requiredScope != want {
-					t.Errorf("got %q, want %q", requiredScope, want)
+				if want := authz.UserScopes; !reflect.DeepEqual(opts.Scopes, want) {
+					t.Errorf("got %q, want %q", opts.Scopes, want)
 				}
-				return 123, nil
+				return &database.AccessToken{SubjectUserID: 123, Scopes: []string{authz.ScopeUserAll}}, nil
 			})
 			db := database.NewMockDB()
 			db.AccessTokensFunc.SetDefaultReturn(accessTokens)
@@ -168,14 +171,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req.Header.Set("Authorization", `token-sudo token="abcdef",user="alice"`)
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, opts database.AccessTokenLookupOptions) (*database.AccessToken, error) {
 			if want := "abcdef"; tokenHexEncoded != want {
 				t.Errorf("got %q, want %q", tokenHexEncoded, want)
 			}
-			if want := authz.ScopeSiteAdminSudo; requiredScope != want {
-				t.Errorf("got %q, want %q", requiredScope, want)
+			if want := []string{authz.ScopeSiteAdminSudo}; !reflect.DeepEqual(opts.Scopes, want) {
+				t.Errorf("got %q, want %q", opts.Scopes, want)
 			}
-			return 123, nil
+			return &database.AccessToken{SubjectUserID: 123, Scopes: []string{authz.ScopeUserAll}}, nil
 		})
 
 		users := database.NewMockUserStore()
@@ -209,14 +212,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req.Header.Set("Authorization", `token-sudo token="abcdef",user="alice"`)
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, opts database.AccessTokenLookupOptions) (*database.AccessToken, error) {
 			if want := "abcdef"; tokenHexEncoded != want {
 				t.Errorf("got %q, want %q", tokenHexEncoded, want)
 			}
-			if want := authz.ScopeSiteAdminSudo; requiredScope != want {
-				t.Errorf("got %q, want %q", requiredScope, want)
+			if want := []string{authz.ScopeSiteAdminSudo}; !reflect.DeepEqual(opts.Scopes, want) {
+				t.Errorf("got %q, want %q", opts.Scopes, want)
 			}
-			return 123, nil
+			return &database.AccessToken{SubjectUserID: 123, Scopes: []string{authz.ScopeUserAll}}, nil
 		})
 
 		users := database.NewMockUserStore()
@@ -241,14 +244,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req.Header.Set("Authorization", `token-sudo token="abcdef",user="doesntexist"`)
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, opts database.AccessTokenLookupOptions) (*database.AccessToken, error) {
 			if want := "abcdef"; tokenHexEncoded != want {
 				t.Errorf("got %q, want %q", tokenHexEncoded, want)
 			}
-			if want := authz.ScopeSiteAdminSudo; requiredScope != want {
-				t.Errorf("got %q, want %q", requiredScope, want)
+			if want := []string{authz.ScopeSiteAdminSudo}; !reflect.DeepEqual(opts.Scopes, want) {
+				t.Errorf("got %q, want %q", opts.Scopes, want)
 			}
-			return 123, nil
+			return &database.AccessToken{SubjectUserID: 123, Scopes: []string{authz.ScopeUserAll}}, nil
 		})
 
 		users := database.NewMockUserStore()
@@ -275,3 +278,175 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		mockrequire.Called(t, users.GetByUsernameFunc)
 	})
 }
+
+func TestAccessTokenAuthMiddleware_Scopes(t *testing.T) {
+	newDB := func(scopes ...string) database.DB {
+		accessTokens := database.NewMockAccessTokenStore()
+		accessTokens.LookupFunc.SetDefaultReturn(&database.AccessToken{SubjectUserID: 123, Scopes: scopes}, nil)
+		db := database.NewMockDB()
+		db.AccessTokensFunc.SetDefaultReturn(accessTokens)
+		return db
+	}
+
+	graphQLRequest := func(query string) *http.Request {
+		body := fmt.Sprintf(`{"query": %q}`, query)
+		req, _ := http.NewRequest("POST", "/.api/graphql", strings.NewReader(body))
+		return req
+	}
+
+	const (
+		searchQuery      = `query Search { search(query: "foo") { results { matchCount } } }`
+		batchChangeQuery = `query { batchChanges { totalCount } }`
+		batchMutation    = `mutation { applyBatchChange(batchSpec: "x") { id } }`
+		userMutation     = `mutation { deleteUser(user: "x") { alwaysNil } }`
+		mixedQuery       = `query { search(query: "foo") { results { matchCount } } currentUser { username } }`
+	)
+
+	for _, tc := range []struct {
+		name   string
+		scopes []string
+		req    func() *http.Request
+		want   int
+	}{
+		{
+			name:   "user:all, mutation",
+			scopes: []string{authz.ScopeUserAll},
+			req:    func() *http.Request { return graphQLRequest(userMutation) },
+			want:   http.StatusOK,
+		},
+		{
+			name:   "user:read, query",
+			scopes: []string{authz.ScopeUserRead},
+			req:    func() *http.Request { return graphQLRequest(mixedQuery) },
+			want:   http.StatusOK,
+		},
+		{
+			name:   "user:read, mutation",
+			scopes: []string{authz.ScopeUserRead},
+			req:    func() *http.Request { return graphQLRequest(userMutation) },
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "user:read, GET request",
+			scopes: []string{authz.ScopeUserRead},
+			req: func() *http.Request {
+				req, _ := http.NewRequest("GET", "/.api/repos/github.com/foo/bar/-/shield", nil)
+				return req
+			},
+			want: http.StatusOK,
+		},
+		{
+			name:   "user:read, raw file",
+			scopes: []string{authz.ScopeUserRead},
+			req: func() *http.Request {
+				req, _ := http.NewRequest("GET", "/github.com/foo/bar@main/-/raw/README.md", nil)
+				return req
+			},
+			want: http.StatusOK,
+		},
+		{
+			name:   "user:read, GET request with side effects",
+			scopes: []string{authz.ScopeUserRead},
+			req: func() *http.Request {
+				req, _ := http.NewRequest("GET", "/-/sign-out", nil)
+				return req
+			},
+			want: http.StatusForbidden,
+		},
+		{
+			name:   "user:read, POST request",
+			scopes: []string{authz.ScopeUserRead},
+			req: func() *http.Request {
+				req, _ := http.NewRequest("POST", "/.api/repos/github.com/foo/bar/-/refresh", nil)
+				return req
+			},
+			want: http.StatusForbidden,
+		},
+		{
+			name:   "search, search query",
+			scopes: []string{authz.ScopeSearch},
+			req:    func() *http.Request { return graphQLRequest(searchQuery) },
+			want:   http.StatusOK,
+		},
+		{
+			name:   "search, streaming search",
+			scopes: []string{authz.ScopeSearch},
+			req: func() *http.Request {
+				req, _ := http.NewRequest("GET", "/.api/search/stream?q=foo", nil)
+				return req
+			},
+			want: http.StatusOK,
+		},
+		{
+			name:   "search, other query",
+			scopes: []string{authz.ScopeSearch},
+			req:    func() *http.Request { return graphQLRequest(mixedQuery) },
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "search, batch changes query",
+			scopes: []string{authz.ScopeSearch},
+			req:    func() *http.Request { return graphQLRequest(batchChangeQuery) },
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "batch-changes, batch changes query",
+			scopes: []string{authz.ScopeBatchChanges},
+			req:    func() *http.Request { return graphQLRequest(batchChangeQuery) },
+			want:   http.StatusOK,
+		},
+		{
+			name:   "batch-changes, batch changes mutation",
+			scopes: []string{authz.ScopeBatchChanges},
+			req:    func() *http.Request { return graphQLRequest(batchMutation) },
+			want:   http.StatusOK,
+		},
+		{
+			name:   "batch-changes, other mutation",
+			scopes: []string{authz.ScopeBatchChanges},
+			req:    func() *http.Request { return graphQLRequest(userMutation) },
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "batch-changes, mutation resembling a batch changes field",
+			scopes: []string{authz.ScopeBatchChanges},
+			req:    func() *http.Request { return graphQLRequest(`mutation { deleteChangesetsOfAllUsers { alwaysNil } }`) },
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "search and batch-changes, search query",
+			scopes: []string{authz.ScopeSearch, authz.ScopeBatchChanges},
+			req:    func() *http.Request { return graphQLRequest(searchQuery) },
+			want:   http.StatusOK,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var body string
+			handler := AccessTokenAuthMiddleware(newDB(tc.scopes...), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// The body must still be readable by the handler.
+				if r.Body != nil {
+					b, _ := io.ReadAll(r.Body)
+					body = string(b)
+				}
+			}))
+
+			req := tc.req()
+			req.Header.Set("Authorization", "token abcdef")
+			var wantBody string
+			if req.Body != nil {
+				b, _ := io.ReadAll(req.Body)
+				wantBody = string(b)
+				req.Body = io.NopCloser(strings.NewReader(wantBody))
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tc.want {
+				t.Fatalf("got response status %d, want %d: %s", rr.Code, tc.want, rr.Body.String())
+			}
+			if tc.want == http.StatusOK && body != wantBody {
+				t.Errorf("got request body %q, want %q", body, wantBody)
+			}
+		})
+	}
+}
@@ -43,7 +43,7 @@ import (
 //
 // 🚨 SECURITY: The caller MUST wrap the returned handler in middleware that checks authentication
 // and sets the actor in the request context.
-func NewHandler(db database.DB, m *mux.Router, schema *graphql.Schema, githubWebhook webhooks.Registerer, gitlabWebhook, bitbucketServerWebhook http.Handler, newCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler, rateLimiter graphqlbackend.LimitWatcher) http.Handler {
+func NewHandler(db database.DB, m *mux.Router, schema *graphql.Schema, githubWebhook webhooks.Registerer, gitlabWebhook, bitbucketServerWebhook, bitbucketCloudWebhook http.Handler, newCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler, rateLimiter graphqlbackend.LimitWatcher) http.Handler {
 	if m == nil {
 		m = apirouter.New(nil)
 	}
@@ -64,8 +64,16 @@ func NewHandler(db database.DB, m *mux.Router, schema *graphql.Schema, githubWeb
 	gh := webhooks.GitHubWebhook{
 		ExternalServices: database.ExternalServices(db),
 	}
+	gl := webhooks.GitLabWebhook{
+		ExternalServices: database.ExternalServices(db),
+		Next:             gitlabWebhook,
+	}
+	bbs := webhooks.BitbucketServerWebhook{
+		ExternalServices: database.ExternalServices(db),
+		Next:             bitbucketServerWebhook,
+	}
 
-	webhookhandlers.Init(db, &gh)
+	webhookhandlers.Init(db, &gh, &gl, &bbs)
 	webhookMiddleware := webhooks.NewLogMiddleware(
 		database.WebhookLogs(db, keyring.Default().WebhookLogKey),
 	)
@@ -73,8 +81,9 @@ func NewHandler(db database.DB, m *mux.Router, schema *graphql.Schema, githubWeb
 	githubWebhook.Register(&gh)
 
 	m.Get(apirouter.GitHubWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&gh)))
-	m.Get(apirouter.GitLabWebhooks).Handler(trace.Route(webhookMiddleware.Logger(gitlabWebhook)))
-	m.Get(apirouter.BitbucketServerWebhooks).Handler(trace.Route(webhookMiddleware.Logger(bitbucketServerWebhook)))
+	m.Get(apirouter.GitLabWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&gl)))
+	m.Get(apirouter.BitbucketServerWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&bbs)))
+	m.Get(apirouter.BitbucketCloudWebhooks).Handler(trace.Route(webhookMiddleware.Logger(bitbucketCloudWebhook)))
 	m.Get(apirouter.LSIFUpload).Handler(trace.Route(newCodeIntelUploadHandler(false)))
 
 	if envvar.SourcegraphDotComMode() {
@@ -0,0 +1,73 @@
+package webhookhandlers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// handleBitbucketServerRepoAuthzEvent handles a Bitbucket Server repository modified event, and
+// enqueues the modified repo for permissions synchronisation.
+func handleBitbucketServerRepoAuthzEvent(db database.DB) func(ctx context.Context, extSvc *types.ExternalService, payload interface{}) error {
+	return func(ctx context.Context, extSvc *types.ExternalService, payload interface{}) error {
+		if !conf.ExperimentalFeatures().EnablePermissionsWebhooks {
+			return nil
+		}
+		if globals.PermissionsUserMapping().Enabled {
+			return nil
+		}
+
+		e, ok := payload.(*bitbucketserver.RepoModifiedEvent)
+		if !ok {
+			return errors.Errorf("incorrect event type sent to bitbucket server repo event handler: %T", payload)
+		}
+		if e.New == nil {
+			return nil
+		}
+
+		serviceID, err := codeHostURL(extSvc)
+		if err != nil {
+			return err
+		}
+
+		// 🚨 SECURITY: we want to be able to find any private repo here, so set internal actor
+		repos, err := database.Repos(db).List(actor.WithInternalActor(ctx), database.ReposListOptions{
+			ExternalRepos: []api.ExternalRepoSpec{{
+				ID:          strconv.Itoa(e.New.ID),
+				ServiceType: extsvc.TypeBitbucketServer,
+				ServiceID:   serviceID,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+		if len(repos) == 0 {
+			return nil
+		}
+
+		req := protocol.PermsSyncRequest{
+			Options: authz.FetchPermsOptions{TriggeredAt: time.Now()},
+		}
+		for _, r := range repos {
+			req.RepoIDs = append(req.RepoIDs, r.ID)
+		}
+
+		log15.Debug("handleBitbucketServerRepoAuthzEvent: Dispatching permissions update", "repos", req.RepoIDs)
+		return repoupdater.DefaultClient.SchedulePermsSync(ctx, req)
+	}
+}
@@ -0,0 +1,82 @@
+package webhookhandlers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// handleGitLabMemberEvent handles a GitLab member event, which is sent when a user is added to,
+// removed from or has their access level changed in a group or project. It enqueues the user,
+// and the project for project member events, for permissions synchronisation.
+func handleGitLabMemberEvent(db database.DB) func(ctx context.Context, extSvc *types.ExternalService, payload interface{}) error {
+	return func(ctx context.Context, extSvc *types.ExternalService, payload interface{}) error {
+		if !conf.ExperimentalFeatures().EnablePermissionsWebhooks {
+			return nil
+		}
+		if globals.PermissionsUserMapping().Enabled {
+			return nil
+		}
+
+		e, ok := payload.(*gitlabwebhooks.MemberEvent)
+		if !ok {
+			return errors.Errorf("incorrect event type sent to gitlab member event handler: %T", payload)
+		}
+		log15.Debug("handleGitLabMemberEvent: Got gitlab event", "name", e.EventName)
+
+		serviceID, err := codeHostURL(extSvc)
+		if err != nil {
+			return err
+		}
+
+		req := protocol.PermsSyncRequest{
+			Options: authz.FetchPermsOptions{TriggeredAt: time.Now()},
+		}
+		req.UserIDs, err = usersByExternalAccount(ctx, db, extsvc.TypeGitLab, serviceID, int64(e.UserID))
+		if err != nil {
+			return err
+		}
+
+		// Syncing the user is enough to revoke their access, but the project also
+		// has pending permissions of users that haven't signed in yet.
+		if e.ProjectID != 0 {
+			// 🚨 SECURITY: we want to be able to find any private repo here, so set internal actor
+			repos, err := database.Repos(db).List(actor.WithInternalActor(ctx), database.ReposListOptions{
+				ExternalRepos: []api.ExternalRepoSpec{{
+					ID:          strconv.Itoa(int(e.ProjectID)),
+					ServiceType: extsvc.TypeGitLab,
+					ServiceID:   serviceID,
+				}},
+			})
+			if err != nil {
+				return err
+			}
+			for _, r := range repos {
+				req.RepoIDs = append(req.RepoIDs, r.ID)
+			}
+		}
+
+		if len(req.UserIDs) == 0 && len(req.RepoIDs) == 0 {
+			// Neither the user nor the project is known to Sourcegraph.
+			return nil
+		}
+
+		log15.Debug("handleGitLabMemberEvent: Dispatching permissions update", "users", req.UserIDs, "repos", req.RepoIDs)
+		return repoupdater.DefaultClient.SchedulePermsSync(ctx, req)
+	}
+}
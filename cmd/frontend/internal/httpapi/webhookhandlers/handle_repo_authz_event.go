@@ -3,6 +3,7 @@ package webhookhandlers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	gh "github.com/google/go-github/v28/github"
@@ -61,6 +62,7 @@ func scheduleRepoUpdate(ctx context.Context, db database.DB, repo *gh.Repository
 
 	log15.Debug("scheduleRepoUpdate: Dispatching permissions update", "repos", repo.GetFullName())
 
+	opts.TriggeredAt = time.Now()
 	c := repoupdater.DefaultClient
 	return c.SchedulePermsSync(ctx, protocol.PermsSyncRequest{
 		RepoIDs: []api.RepoID{r.ID},
@@ -3,6 +3,7 @@ package webhookhandlers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/cockroachdb/errors"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -62,29 +64,44 @@ func scheduleUserUpdate(ctx context.Context, db database.DB, extSvc *types.Exter
 	if githubUser == nil {
 		return nil
 	}
-	accs, err := database.ExternalAccounts(db).List(ctx, database.ExternalAccountsListOptions{
-		ServiceID:   fmt.Sprint(extSvc.ID),
-		ServiceType: "github",
-		AccountID:   githubUser.GetID(),
-	})
+	serviceID, err := codeHostURL(extSvc)
+	if err != nil {
+		return err
+	}
+	ids, err := usersByExternalAccount(ctx, db, extsvc.TypeGitHub, serviceID, githubUser.GetID())
 	if err != nil {
 		return err
 	}
-	if len(accs) == 0 {
+	if len(ids) == 0 {
 		// this user is not a sourcegraph user (yet...)
 		return nil
 	}
 
-	ids := []int32{}
-	for _, acc := range accs {
-		ids = append(ids, acc.UserID)
-	}
-
 	log15.Debug("scheduleUserUpdate: Dispatching permissions update", "users", ids)
 
+	opts.TriggeredAt = time.Now()
 	c := repoupdater.DefaultClient
 	return c.SchedulePermsSync(ctx, protocol.PermsSyncRequest{
 		UserIDs: ids,
 		Options: opts,
 	})
 }
+
+// usersByExternalAccount returns the IDs of the users with the given external
+// account on the code host.
+func usersByExternalAccount(ctx context.Context, db database.DB, serviceType, serviceID string, accountID int64) ([]int32, error) {
+	accs, err := database.ExternalAccounts(db).List(ctx, database.ExternalAccountsListOptions{
+		ServiceType: serviceType,
+		ServiceID:   serviceID,
+		AccountID:   accountID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int32, 0, len(accs))
+	for _, acc := range accs {
+		ids = append(ids, acc.UserID)
+	}
+	return ids, nil
+}
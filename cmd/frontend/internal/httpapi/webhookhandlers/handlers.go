@@ -1,12 +1,21 @@
 package webhookhandlers
 
 import (
+	"net/url"
+
+	"github.com/cockroachdb/errors"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
-func Init(db database.DB, w *webhooks.GitHubWebhook) {
+func Init(db database.DB, w *webhooks.GitHubWebhook, gl *webhooks.GitLabWebhook, bbs *webhooks.BitbucketServerWebhook) {
 	// Refer to https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads
 	// for event types
 
@@ -20,7 +29,48 @@ func Init(db database.DB, w *webhooks.GitHubWebhook) {
 
 	// Events that touch cached permissions in authz/github.Provider implementation
 	w.Register(handleGitHubRepoAuthzEvent(db, authz.FetchPermsOptions{InvalidateCaches: true}), "team_add")
-	w.Register(handleGitHubUserAuthzEvent(db, authz.FetchPermsOptions{InvalidateCaches: true}), "organisation")
+	w.Register(handleGitHubUserAuthzEvent(db, authz.FetchPermsOptions{InvalidateCaches: true}), "organization")
 	w.Register(handleGitHubUserAuthzEvent(db, authz.FetchPermsOptions{InvalidateCaches: true}), "membership")
 
+	// Refer to https://docs.gitlab.com/ee/user/project/integrations/webhooks.html#group-member-events
+	// and https://docs.gitlab.com/ee/system_hooks/system_hooks.html for event names
+	gl.Register(handleGitLabMemberEvent(db),
+		gitlabwebhooks.EventNameUserAddToProject,
+		gitlabwebhooks.EventNameUserUpdateForProject,
+		gitlabwebhooks.EventNameUserRemoveFromProject,
+		gitlabwebhooks.EventNameUserAddToGroup,
+		gitlabwebhooks.EventNameUserUpdateForGroup,
+		gitlabwebhooks.EventNameUserRemoveFromGroup,
+	)
+
+	// Bitbucket Server doesn't send events for permission changes, but making
+	// a repository public or private sends a modified event.
+	bbs.Register(handleBitbucketServerRepoAuthzEvent(db), bitbucketserver.RepoModifiedEventKey)
+}
+
+// codeHostURL returns the normalized URL of the code host of the external
+// service, which is the service ID of its repositories and external accounts.
+func codeHostURL(extSvc *types.ExternalService) (string, error) {
+	c, err := extSvc.Configuration()
+	if err != nil {
+		return "", errors.Wrap(err, "getting external service configuration")
+	}
+
+	var rawURL string
+	switch c := c.(type) {
+	case *schema.GitHubConnection:
+		rawURL = c.Url
+	case *schema.GitLabConnection:
+		rawURL = c.Url
+	case *schema.BitbucketServerConnection:
+		rawURL = c.Url
+	default:
+		return "", errors.Errorf("unexpected external service kind %q", extSvc.Kind)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing code host URL")
+	}
+	return extsvc.NormalizeBaseURL(u).String(), nil
 }
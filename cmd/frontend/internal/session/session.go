@@ -3,6 +3,8 @@ package session
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -18,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/realip"
 	"github.com/sourcegraph/sourcegraph/internal/redispool"
 
 	"github.com/inconshreveable/log15"
@@ -61,6 +64,12 @@ type sessionInfo struct {
 	LastActive    time.Time     `json:"lastActive"`
 	ExpiryPeriod  time.Duration `json:"expiryPeriod"`
 	UserCreatedAt time.Time     `json:"userCreatedAt"`
+
+	// Key is the key of the session in the session index (the user_sessions
+	// table). It is empty until the session is added to the index on its first
+	// authenticated request. Once set, the session is valid only as long as it
+	// is in the index.
+	Key string `json:"key,omitempty"`
 }
 
 // SetSessionStore sets the backing store used for storing sessions on the server. It should be called exactly once.
@@ -373,7 +382,17 @@ func authenticateByCookie(db database.DB, r *http.Request, w http.ResponseWriter
 		}
 
 		// Check that the session is still valid
-		if info.LastActive.Before(usr.InvalidatedSessionsAt) {
+		if info.Key != "" {
+			// Indexed sessions are revoked by removing them from the index.
+			us, err := db.UserSessions().GetByKey(r.Context(), info.Key)
+			if err == database.ErrUserSessionNotFound || (err == nil && us.UserID != info.Actor.UID) {
+				_ = deleteSession(w, r) // Delete the revoked session
+				return r.Context()
+			} else if err != nil {
+				// Don't delete session, since the error might be an ephemeral DB error.
+				log15.Error("Error looking up session in session index.", "uid", info.Actor.UID, "error", err)
+			}
+		} else if info.LastActive.Before(usr.InvalidatedSessionsAt) {
 			_ = deleteSession(w, r) // Delete the now invalid session
 			return r.Context()
 		}
@@ -396,6 +415,29 @@ func authenticateByCookie(db database.DB, r *http.Request, w http.ResponseWriter
 			return r.Context()
 		}
 
+		// Add the session to the session index, so that it can be listed and revoked.
+		if info.Key == "" {
+			if key := sessionKey(r); key != "" {
+				err := db.UserSessions().Upsert(r.Context(), &database.UserSession{
+					UserID:    info.Actor.UID,
+					Key:       key,
+					IPAddress: realip.FromRequest(r),
+					UserAgent: r.UserAgent(),
+					ExpiresAt: info.LastActive.Add(info.ExpiryPeriod),
+				})
+				if err != nil {
+					// Try again on the next request.
+					log15.Error("error adding session to session index", "error", err)
+				} else {
+					info.Key = key
+					if err := SetData(w, r, "actor", info); err != nil {
+						log15.Error("error setting session key", "error", err)
+						return r.Context()
+					}
+				}
+			}
+		}
+
 		// Renew session
 		if time.Since(info.LastActive) > 5*time.Minute {
 			info.LastActive = time.Now()
@@ -403,11 +445,44 @@ func authenticateByCookie(db database.DB, r *http.Request, w http.ResponseWriter
 				log15.Error("error renewing session", "error", err)
 				return r.Context()
 			}
+			if info.Key != "" {
+				if err := db.UserSessions().Touch(r.Context(), info.Key, realip.FromRequest(r), r.UserAgent(), info.LastActive.Add(info.ExpiryPeriod)); err != nil {
+					log15.Error("error renewing session in session index", "error", err)
+				}
+			}
 		}
 
 		info.Actor.FromSessionCookie = true
-		return actor.WithActor(r.Context(), info.Actor)
+		ctx := actor.WithActor(r.Context(), info.Actor)
+		if info.Key != "" {
+			ctx = context.WithValue(ctx, sessionKeyContextKey, info.Key)
+		}
+		return ctx
 	}
 
 	return r.Context()
 }
+
+type contextKey int
+
+const sessionKeyContextKey contextKey = iota
+
+// KeyFromContext returns the key of the session in the session index that
+// authenticated the request, or "" if the request was not authenticated by an
+// indexed session.
+func KeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(sessionKeyContextKey).(string)
+	return key
+}
+
+// sessionKey returns the key of the session of the request in the session
+// index. It is the SHA-256 hash of the session ID, so that the index doesn't
+// contain the IDs of the sessions in the session store.
+func sessionKey(r *http.Request) string {
+	s, err := sessionStore.Get(r, cookieName)
+	if err != nil || s.ID == "" {
+		return ""
+	}
+	h := sha256.Sum256([]byte(s.ID))
+	return hex.EncodeToString(h[:])
+}
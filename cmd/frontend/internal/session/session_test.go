@@ -30,6 +30,7 @@ func TestSetActorDeleteSession(t *testing.T) {
 
 	db := database.NewStrictMockDB()
 	db.UsersFunc.SetDefaultReturn(users)
+	db.UserSessionsFunc.SetDefaultReturn(newMockUserSessions().store)
 
 	// Start new session
 	w := httptest.NewRecorder()
@@ -149,6 +150,7 @@ func TestSessionExpiry(t *testing.T) {
 
 	db := database.NewStrictMockDB()
 	db.UsersFunc.SetDefaultReturn(users)
+	db.UserSessionsFunc.SetDefaultReturn(newMockUserSessions().store)
 
 	// Start new session
 	w := httptest.NewRecorder()
@@ -194,6 +196,7 @@ func TestManualSessionExpiry(t *testing.T) {
 
 	db := database.NewStrictMockDB()
 	db.UsersFunc.SetDefaultReturn(users)
+	db.UserSessionsFunc.SetDefaultReturn(newMockUserSessions().store)
 
 	// Start new session
 	w := httptest.NewRecorder()
@@ -243,6 +246,7 @@ func TestCookieMiddleware(t *testing.T) {
 
 	db := database.NewStrictMockDB()
 	db.UsersFunc.SetDefaultReturn(users)
+	db.UserSessionsFunc.SetDefaultReturn(newMockUserSessions().store)
 
 	// Start new sessions for all actors
 	authedReqs := make([]*http.Request, len(actors))
@@ -361,6 +365,7 @@ func TestMismatchedUserCreationFails(t *testing.T) {
 
 	db := database.NewStrictMockDB()
 	db.UsersFunc.SetDefaultReturn(users)
+	db.UserSessionsFunc.SetDefaultReturn(newMockUserSessions().store)
 
 	// Start a new session for the user with ID 1. Their creation time
 	// will be recorded into the session store.
@@ -419,6 +424,7 @@ func TestOldUserSessionSucceeds(t *testing.T) {
 
 	db := database.NewStrictMockDB()
 	db.UsersFunc.SetDefaultReturn(users)
+	db.UserSessionsFunc.SetDefaultReturn(newMockUserSessions().store)
 
 	// Start a new session for the user with ID 1. Their creation time will not be
 	// be recorded into the session store.
@@ -457,3 +463,91 @@ func TestOldUserSessionSucceeds(t *testing.T) {
 		t.Fatal("user creation date was not set")
 	}
 }
+
+// mockUserSessions is an in-memory session index.
+type mockUserSessions struct {
+	store    *database.MockUserSessionStore
+	sessions map[string]*database.UserSession
+}
+
+func newMockUserSessions() *mockUserSessions {
+	m := &mockUserSessions{store: database.NewStrictMockUserSessionStore(), sessions: map[string]*database.UserSession{}}
+	m.store.UpsertFunc.SetDefaultHook(func(_ context.Context, us *database.UserSession) error {
+		us.ID = int64(len(m.sessions) + 1)
+		stored := *us
+		m.sessions[us.Key] = &stored
+		return nil
+	})
+	m.store.GetByKeyFunc.SetDefaultHook(func(_ context.Context, key string) (*database.UserSession, error) {
+		us, ok := m.sessions[key]
+		if !ok {
+			return nil, database.ErrUserSessionNotFound
+		}
+		return us, nil
+	})
+	m.store.TouchFunc.SetDefaultReturn(nil)
+	return m
+}
+
+func TestSessionIndex(t *testing.T) {
+	cleanup := ResetMockSessionStore(t)
+	defer cleanup()
+
+	user := &types.User{ID: 123, CreatedAt: time.Now()}
+	users := database.NewStrictMockUserStore()
+	users.GetByIDFunc.SetDefaultReturn(user, nil)
+
+	sessions := newMockUserSessions()
+	db := database.NewStrictMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserSessionsFunc.SetDefaultReturn(sessions.store)
+
+	w := httptest.NewRecorder()
+	actr := &actor.Actor{UID: 123, FromSessionCookie: true}
+	if err := SetActor(w, httptest.NewRequest("GET", "/", nil), actr, time.Hour, user.CreatedAt); err != nil {
+		t.Fatal(err)
+	}
+	authedReq := func() *http.Request {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		req.RemoteAddr = "10.0.0.2:1234"
+		req.Header.Set("X-Forwarded-For", "203.0.113.1, 10.0.0.1")
+		for _, cookie := range w.Result().Cookies() {
+			req.AddCookie(cookie)
+		}
+		return req
+	}
+
+	// The first authenticated request adds the session to the index.
+	ctx := authenticateByCookie(db, authedReq(), httptest.NewRecorder())
+	if got := actor.FromContext(ctx); !reflect.DeepEqual(got, actr) {
+		t.Fatalf("got actor %+v, want %+v", got, actr)
+	}
+	if len(sessions.sessions) != 1 {
+		t.Fatalf("got %d indexed sessions, want 1", len(sessions.sessions))
+	}
+	key := KeyFromContext(ctx)
+	us, ok := sessions.sessions[key]
+	if !ok {
+		t.Fatalf("session with key %q from context is not indexed", key)
+	}
+	if us.UserID != 123 || us.IPAddress != "203.0.113.1" || us.UserAgent != "Mozilla/5.0" {
+		t.Fatalf("unexpected indexed session %+v", us)
+	}
+
+	// Indexed sessions are not revoked by InvalidatedSessionsAt, which revokes
+	// the sessions that aren't indexed yet.
+	user.InvalidatedSessionsAt = time.Now().Add(time.Minute)
+	if got := actor.FromContext(authenticateByCookie(db, authedReq(), httptest.NewRecorder())); !reflect.DeepEqual(got, actr) {
+		t.Fatalf("got actor %+v, want %+v", got, actr)
+	}
+	if len(sessions.sessions) != 1 {
+		t.Fatalf("got %d indexed sessions, want 1", len(sessions.sessions))
+	}
+
+	// Removing the session from the index revokes it.
+	delete(sessions.sessions, key)
+	if got := actor.FromContext(authenticateByCookie(db, authedReq(), httptest.NewRecorder())); !reflect.DeepEqual(got, &actor.Actor{}) {
+		t.Fatalf("revoked session is still valid, got actor %+v", got)
+	}
+}
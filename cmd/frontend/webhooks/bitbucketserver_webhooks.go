@@ -0,0 +1,111 @@
+package webhooks
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	gh "github.com/google/go-github/v28/github"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// BitbucketServerWebhook is responsible for handling incoming http requests for
+// Bitbucket Server webhooks and routing them to any registered
+// WebhookHandlers. Events are routed by their event key, passed in the
+// X-Event-Key header. Events that no handler is registered for are passed on
+// to Next.
+type BitbucketServerWebhook struct {
+	ExternalServices database.ExternalServiceStore
+	Next             http.Handler
+
+	router
+}
+
+func (h *BitbucketServerWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	eventType := bitbucketserver.WebhookEventType(r)
+	if !h.handles(eventType) {
+		h.Next.ServeHTTP(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log15.Error("Error reading bitbucket server webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	extSvc, err := h.getExternalService(r, body)
+	if err != nil {
+		log15.Error("Could not find valid external service for webhook", "error", err)
+		http.Error(w, "External service not found", http.StatusUnauthorized)
+		return
+	}
+
+	SetExternalServiceID(r.Context(), extSvc.ID)
+
+	// 🚨 SECURITY: now that the payload signature has been validated, we can
+	// use an internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	e, err := bitbucketserver.ParseWebhookEvent(eventType, body)
+	if err != nil {
+		log15.Error("Error parsing bitbucket server webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(ctx, eventType, extSvc, e); err != nil {
+		log15.Error("Error handling bitbucket server webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getExternalService returns the Bitbucket Server external service whose
+// webhook secret the payload is signed with.
+func (h *BitbucketServerWebhook) getExternalService(r *http.Request, body []byte) (*types.ExternalService, error) {
+	args := database.ExternalServicesListOptions{Kinds: []string{extsvc.KindBitbucketServer}}
+	// The ID could be blank if the hook URL doesn't include the parameter yet.
+	if rawID := r.FormValue(extsvc.IDParam); rawID != "" {
+		id, err := strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing external service ID")
+		}
+		args.IDs = []int64{id}
+	}
+	es, err := h.ExternalServices.List(r.Context(), args)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Try to authenticate the request with the webhook secrets of
+	// the external services. If there are no secrets or none of them
+	// authenticates the request, we return an error to the client.
+	sig := r.Header.Get("X-Hub-Signature")
+	for _, e := range es {
+		c, err := e.Configuration()
+		if err != nil {
+			return nil, err
+		}
+		con, ok := c.(*schema.BitbucketServerConnection)
+		if !ok {
+			continue
+		}
+		if secret := con.WebhookSecret(); secret != "" {
+			if err := gh.ValidateSignature(sig, body, []byte(secret)); err == nil {
+				return e, nil
+			}
+		}
+	}
+	return nil, errors.New("couldn't find any external service for webhook")
+}
@@ -0,0 +1,79 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestBitbucketServerWebhook(t *testing.T) {
+	extSvc := &types.ExternalService{
+		ID:   1,
+		Kind: extsvc.KindBitbucketServer,
+		Config: marshalJSON(t, &schema.BitbucketServerConnection{
+			Url:      "https://bitbucket.example.com",
+			Webhooks: &schema.Webhooks{Secret: "secret"},
+		}),
+	}
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.ListFunc.SetDefaultReturn([]*types.ExternalService{extSvc}, nil)
+
+	var (
+		events     []interface{}
+		nextCalled bool
+	)
+	hook := &BitbucketServerWebhook{
+		ExternalServices: externalServices,
+		Next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nextCalled = true
+		}),
+	}
+	hook.Register(func(ctx context.Context, svc *types.ExternalService, payload interface{}) error {
+		events = append(events, payload)
+		return nil
+	}, bitbucketserver.RepoModifiedEventKey)
+
+	serve := func(eventKey, payload, secret string) int {
+		req, err := http.NewRequest("POST", extsvc.WebhookURL(extsvc.TypeBitbucketServer, extSvc.ID, "https://example.com/"), strings.NewReader(payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-Event-Key", eventKey)
+		req.Header.Set("X-Hub-Signature", sign(t, []byte(payload), []byte(secret)))
+		rec := httptest.NewRecorder()
+		hook.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	payload := `{"eventKey":"repo:modified","old":{"id":1,"public":true},"new":{"id":1,"public":false}}`
+
+	if code := serve(bitbucketserver.RepoModifiedEventKey, payload, "wrong"); code != http.StatusUnauthorized {
+		t.Fatalf("unexpected status code for incorrect signature: %d", code)
+	}
+	if len(events) != 0 {
+		t.Fatal("event with incorrect signature was dispatched")
+	}
+
+	if code := serve(bitbucketserver.RepoModifiedEventKey, payload, "secret"); code != http.StatusNoContent {
+		t.Fatalf("unexpected status code: %d", code)
+	}
+	if len(events) != 1 {
+		t.Fatalf("want 1 dispatched event, got %d", len(events))
+	}
+	if e, ok := events[0].(*bitbucketserver.RepoModifiedEvent); !ok || e.New.ID != 1 || e.New.Public {
+		t.Fatalf("unexpected event: %+v", events[0])
+	}
+
+	serve("pr:activity:status", `{}`, "secret")
+	if !nextCalled {
+		t.Fatal("unregistered event was not passed on to the next handler")
+	}
+}
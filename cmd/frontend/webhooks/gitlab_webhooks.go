@@ -0,0 +1,114 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GitLabWebhook is responsible for handling incoming http requests for GitLab
+// webhooks and routing them to any registered WebhookHandlers. Events are
+// routed by their object kind or, if they don't have one like member events,
+// their event name. Events that no handler is registered for are passed on to
+// Next.
+type GitLabWebhook struct {
+	ExternalServices database.ExternalServiceStore
+	Next             http.Handler
+
+	router
+}
+
+func (h *GitLabWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log15.Error("Error reading gitlab webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	// Invalid payloads are reported by Next.
+	var event struct {
+		ObjectKind string `json:"object_kind"`
+		EventName  string `json:"event_name"`
+	}
+	_ = json.Unmarshal(body, &event)
+	eventType := event.ObjectKind
+	if eventType == "" {
+		eventType = event.EventName
+	}
+	if !h.handles(eventType) {
+		h.Next.ServeHTTP(w, r)
+		return
+	}
+
+	extSvc, err := h.getExternalService(r)
+	if err != nil {
+		log15.Error("Could not find valid external service for webhook", "error", err)
+		http.Error(w, "External service not found", http.StatusUnauthorized)
+		return
+	}
+
+	SetExternalServiceID(r.Context(), extSvc.ID)
+
+	// 🚨 SECURITY: now that the shared secret has been validated, we can use an
+	// internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	e, err := gitlabwebhooks.UnmarshalEvent(body)
+	if err != nil {
+		log15.Error("Error parsing gitlab webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(ctx, eventType, extSvc, e); err != nil {
+		log15.Error("Error handling gitlab webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *GitLabWebhook) getExternalService(r *http.Request) (*types.ExternalService, error) {
+	id, err := strconv.ParseInt(r.FormValue(extsvc.IDParam), 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing external service ID")
+	}
+	e, err := h.ExternalServices.GetByID(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	c, err := e.Configuration()
+	if err != nil {
+		return nil, err
+	}
+	gc, ok := c.(*schema.GitLabConnection)
+	if !ok {
+		return nil, errors.Errorf("invalid configuration, received gitlab webhook for non-gitlab external service: %v", id)
+	}
+
+	// 🚨 SECURITY: The shared secret must match the secret of one of the
+	// webhooks configured for the external service. An empty secret never
+	// matches.
+	secret := r.Header.Get(gitlabwebhooks.TokenHeaderName)
+	for _, hook := range gc.Webhooks {
+		if secret != "" && subtle.ConstantTimeCompare([]byte(hook.Secret), []byte(secret)) == 1 {
+			return e, nil
+		}
+	}
+	return nil, errors.New("shared secret is incorrect")
+}
@@ -0,0 +1,102 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGitLabWebhook(t *testing.T) {
+	extSvc := &types.ExternalService{
+		ID:   1,
+		Kind: extsvc.KindGitLab,
+		Config: marshalJSON(t, &schema.GitLabConnection{
+			Url:      "https://gitlab.com",
+			Webhooks: []*schema.GitLabWebhook{{Secret: "secret"}},
+		}),
+	}
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultReturn(extSvc, nil)
+
+	const memberPayload = `{"event_name":"user_remove_from_group","group_id":78,"user_id":41}`
+
+	newHook := func() (*GitLabWebhook, *[]interface{}, *[]string) {
+		var (
+			events     []interface{}
+			nextBodies []string
+		)
+		hook := &GitLabWebhook{
+			ExternalServices: externalServices,
+			Next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				nextBodies = append(nextBodies, string(body))
+				w.WriteHeader(http.StatusAccepted)
+			}),
+		}
+		hook.Register(func(ctx context.Context, svc *types.ExternalService, payload interface{}) error {
+			events = append(events, payload)
+			return nil
+		}, gitlabwebhooks.EventNameUserRemoveFromGroup)
+		return hook, &events, &nextBodies
+	}
+
+	serve := func(hook *GitLabWebhook, payload, secret string) int {
+		req, err := http.NewRequest("POST", extsvc.WebhookURL(extsvc.TypeGitLab, extSvc.ID, "https://example.com/"), strings.NewReader(payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(gitlabwebhooks.TokenHeaderName, secret)
+		rec := httptest.NewRecorder()
+		hook.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("registered event", func(t *testing.T) {
+		hook, events, nextBodies := newHook()
+		if code := serve(hook, memberPayload, "secret"); code != http.StatusNoContent {
+			t.Fatalf("unexpected status code: %d", code)
+		}
+		if len(*events) != 1 {
+			t.Fatalf("want 1 dispatched event, got %d", len(*events))
+		}
+		if e, ok := (*events)[0].(*gitlabwebhooks.MemberEvent); !ok || e.UserID != 41 {
+			t.Fatalf("unexpected event: %+v", (*events)[0])
+		}
+		if len(*nextBodies) != 0 {
+			t.Fatal("registered event was passed on to the next handler")
+		}
+	})
+
+	t.Run("incorrect secret", func(t *testing.T) {
+		hook, events, _ := newHook()
+		if code := serve(hook, memberPayload, "wrong"); code != http.StatusUnauthorized {
+			t.Fatalf("unexpected status code: %d", code)
+		}
+		if len(*events) != 0 {
+			t.Fatal("event was dispatched")
+		}
+	})
+
+	t.Run("unregistered event", func(t *testing.T) {
+		hook, events, nextBodies := newHook()
+		payload := `{"object_kind":"merge_request"}`
+		if code := serve(hook, payload, "secret"); code != http.StatusAccepted {
+			t.Fatalf("unexpected status code: %d", code)
+		}
+		if len(*events) != 0 {
+			t.Fatal("event was dispatched")
+		}
+		if len(*nextBodies) != 1 || (*nextBodies)[0] != payload {
+			t.Fatalf("unexpected bodies passed on to the next handler: %q", *nextBodies)
+		}
+	})
+}
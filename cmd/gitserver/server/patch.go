@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -17,6 +18,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/internal/commitsigning"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
 )
@@ -75,6 +77,17 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 		return http.StatusInternalServerError, resp
 	}
 
+	// Parse the signing key up front, so that an invalid key fails the
+	// request before we do any work.
+	var signer commitsigning.Signer
+	if req.Signing != nil {
+		signer, err = commitsigning.NewSigner(commitsigning.Format(req.Signing.Format), req.Signing.PrivateKey, req.Signing.Passphrase)
+		if err != nil {
+			resp.SetError(repo, "", "", errors.Wrap(err, "gitserver: loading commit signing key"))
+			return http.StatusBadRequest, resp
+		}
+	}
+
 	redactor := newURLRedactor(remoteURL)
 	defer func() {
 		if resp.Error != nil {
@@ -222,6 +235,14 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 	}
 	cmtHash := strings.TrimSpace(string(out))
 
+	if signer != nil {
+		cmtHash, err = signCommit(ctx, tmpRepoDir, []string{tmpGitPathEnv, altObjectsEnv}, cmtHash, signer)
+		if err != nil {
+			resp.SetError(repo, "", "", errors.Wrap(err, "gitserver: signing commit"))
+			return http.StatusInternalServerError, resp
+		}
+	}
+
 	// Move objects from tmpObjectsDir to repoObjectsDir.
 	err = filepath.Walk(tmpObjectsDir, func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
@@ -298,6 +319,38 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 	return http.StatusOK, resp
 }
 
+// signCommit signs the commit with the given hash and writes the signed
+// commit to the object directory of the repository in dir. It returns the hash
+// of the signed commit.
+func signCommit(ctx context.Context, dir string, env []string, commit string, signer commitsigning.Signer) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "commit", commit)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	payload, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrap(err, "reading commit")
+	}
+
+	signature, err := signer.Sign(payload)
+	if err != nil {
+		return "", err
+	}
+	signed, err := commitsigning.AddSignature(payload, signature)
+	if err != nil {
+		return "", err
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "hash-object", "-t", "commit", "-w", "--stdin")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = bytes.NewReader(signed)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrap(err, "writing signed commit")
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func cleanUpTmpRepo(path string) {
 	err := os.RemoveAll(path)
 	if err != nil {
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+func TestCreateCommitFromPatch_Signing(t *testing.T) {
+	reposDir := t.TempDir()
+	repoName := api.RepoName("example.com/foo/bar")
+	repoDir := filepath.Join(reposDir, string(repoName))
+
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, repoDir, name, arg...)
+	}
+	runCmd(t, reposDir, "mkdir", "-p", string(repoName))
+	baseCommit := strings.TrimSpace(makeSingleCommitRepo(cmd))
+
+	s := &Server{
+		ReposDir:         reposDir,
+		GetRemoteURLFunc: staticGetRemoteURL(repoDir),
+	}
+
+	keypair, err := encryption.GenerateRSAKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := protocol.CreateCommitFromPatchRequest{
+		Repo:       repoName,
+		BaseCommit: api.CommitID(baseCommit),
+		Patch: `diff --git hello.txt hello.txt
+--- hello.txt
++++ hello.txt
+@@ -1 +1 @@
+-hello world
++hello signed world
+`,
+		TargetRef: "refs/heads/signed",
+		CommitInfo: protocol.PatchCommitInfo{
+			Message:     "Sign me",
+			AuthorName:  "a",
+			AuthorEmail: "a@a.com",
+			Date:        time.Now(),
+		},
+		GitApplyArgs: []string{"-p0"},
+	}
+
+	t.Run("signed", func(t *testing.T) {
+		req := req
+		req.Signing = &protocol.CommitSigningConfig{
+			Format:     "ssh",
+			PrivateKey: keypair.PrivateKey,
+			Passphrase: keypair.Passphrase,
+		}
+
+		status, resp := s.createCommitFromPatch(context.Background(), req)
+		if resp.Error != nil {
+			t.Fatalf("unexpected error: %+v", resp.Error)
+		}
+		if status != http.StatusOK {
+			t.Fatalf("wrong status %d", status)
+		}
+
+		commit := runCmd(t, repoDir, "git", "cat-file", "commit", resp.Rev)
+		if !strings.Contains(commit, "\ngpgsig -----BEGIN SSH SIGNATURE-----\n") {
+			t.Fatalf("commit is not signed:\n%s", commit)
+		}
+		if !strings.HasSuffix(commit, "\n\nSign me\n") {
+			t.Fatalf("wrong commit message:\n%s", commit)
+		}
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		req := req
+		req.Signing = &protocol.CommitSigningConfig{
+			Format:     "ssh",
+			PrivateKey: keypair.PrivateKey,
+			Passphrase: "wrong",
+		}
+
+		status, resp := s.createCommitFromPatch(context.Background(), req)
+		if status != http.StatusBadRequest {
+			t.Fatalf("wrong status %d", status)
+		}
+		if resp.Error == nil || !strings.Contains(resp.Error.InternalError, "loading commit signing key") {
+			t.Fatalf("wrong error: %+v", resp.Error)
+		}
+	})
+}
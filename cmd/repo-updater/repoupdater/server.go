@@ -49,7 +49,7 @@ type Server struct {
 		// ScheduleUsers schedules new permissions syncing requests for given users.
 		ScheduleUsers(ctx context.Context, opts authz.FetchPermsOptions, userIDs ...int32)
 		// ScheduleRepos schedules new permissions syncing requests for given repositories.
-		ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID)
+		ScheduleRepos(ctx context.Context, opts authz.FetchPermsOptions, repoIDs ...api.RepoID)
 	}
 }
 
@@ -379,7 +379,7 @@ func (s *Server) handleSchedulePermsSync(w http.ResponseWriter, r *http.Request)
 	}
 
 	s.PermsSyncer.ScheduleUsers(r.Context(), req.Options, req.UserIDs...)
-	s.PermsSyncer.ScheduleRepos(r.Context(), req.RepoIDs...)
+	s.PermsSyncer.ScheduleRepos(r.Context(), req.Options, req.RepoIDs...)
 
 	respond(w, http.StatusOK, nil)
 }
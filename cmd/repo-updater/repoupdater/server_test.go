@@ -668,7 +668,7 @@ type fakePermsSyncer struct{}
 func (*fakePermsSyncer) ScheduleUsers(ctx context.Context, opts authz.FetchPermsOptions, userIDs ...int32) {
 }
 
-func (*fakePermsSyncer) ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID) {
+func (*fakePermsSyncer) ScheduleRepos(ctx context.Context, opts authz.FetchPermsOptions, repoIDs ...api.RepoID) {
 }
 
 func TestServer_handleSchedulePermsSync(t *testing.T) {
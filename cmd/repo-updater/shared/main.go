@@ -370,7 +370,7 @@ type scheduler interface {
 
 type permsSyncer interface {
 	// ScheduleRepos schedules new permissions syncing requests for given repositories.
-	ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID)
+	ScheduleRepos(ctx context.Context, opts authz.FetchPermsOptions, repoIDs ...api.RepoID)
 }
 
 func watchSyncer(
@@ -409,7 +409,7 @@ func watchSyncer(
 					}
 				}
 
-				permsSyncer.ScheduleRepos(ctx, repoIDs...)
+				permsSyncer.ScheduleRepos(ctx, authz.FetchPermsOptions{}, repoIDs...)
 			}
 
 			if gps == nil {
@@ -0,0 +1,87 @@
+package ldap
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var mockGetProviderValue *provider
+
+// getProvider looks up the registered LDAP auth provider with the given ID.
+func getProvider(id string) *provider {
+	if mockGetProviderValue != nil {
+		return mockGetProviderValue
+	}
+	p, _ := providers.GetProviderByConfigID(providers.ConfigID{Type: providerType, ID: id}).(*provider)
+	return p
+}
+
+// getProviders returns the LDAP auth providers in the site configuration.
+func getProviders() []*provider {
+	var ps []*provider
+	for _, p := range conf.Get().AuthProviders {
+		if p.Ldap != nil {
+			ps = append(ps, &provider{config: *p.Ldap})
+		}
+	}
+	return ps
+}
+
+func init() {
+	conf.ContributeValidator(validateConfig)
+
+	go func() {
+		conf.Watch(func() {
+			ps := getProviders()
+			registered := make([]providers.Provider, 0, len(ps))
+			for _, p := range ps {
+				registered = append(registered, p)
+			}
+			providers.Update(providerType, registered)
+		})
+	}()
+}
+
+func validateConfig(c conftypes.SiteConfigQuerier) (problems conf.Problems) {
+	seen := map[string]int{}
+	for i, p := range c.SiteConfig().AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+		if p.Ldap.BindDN != "" && p.Ldap.BindPassword == "" {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d sets `bindDN` without `bindPassword`", i)))
+		}
+		key := p.Ldap.Url + "\x00" + p.Ldap.UserSearchBase
+		if j, ok := seen[key]; ok {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d is duplicate of index %d, ignoring", i, j)))
+		} else {
+			seen[key] = i
+		}
+	}
+	return problems
+}
+
+// providerConfigID produces a semi-stable identifier for an LDAP auth provider config object. It
+// is used to distinguish between multiple auth providers of the same type. Its value is never
+// persisted, and it must be deterministic.
+func providerConfigID(pc *schema.LDAPAuthProvider) string {
+	if pc.ConfigID != "" {
+		return pc.ConfigID
+	}
+	// 🚨 SECURITY: The ID is shown to anonymous clients, so leave out the password.
+	withoutPassword := *pc
+	withoutPassword.BindPassword = ""
+	data, err := json.Marshal(withoutPassword)
+	if err != nil {
+		panic(err)
+	}
+	b := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(b[:16])
+}
@@ -0,0 +1,123 @@
+// Package ldap implements auth via LDAP and Active Directory.
+package ldap
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// All LDAP endpoints are under this path prefix.
+const authPrefix = auth.AuthURLPrefix + "/ldap"
+
+// Middleware is middleware for LDAP authentication, adding the endpoint under the auth path
+// prefix ("/.auth/ldap/login") that signs users in with their LDAP username and password.
+//
+// The login endpoint accepts a POST of a JSON object with "username" and "password" properties,
+// and the "pc" query parameter selects the auth provider. On success, it creates a new session
+// and session cookie.
+//
+// 🚨 SECURITY
+func Middleware(db database.DB) *auth.Middleware {
+	return &auth.Middleware{
+		API: func(next http.Handler) http.Handler { return next },
+		App: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, authPrefix+"/") {
+					authHandler(db)(w, r)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		},
+	}
+}
+
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func authHandler(db database.DB) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimPrefix(r.URL.Path, authPrefix) != "/login" {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		if r.Method != "POST" {
+			http.Error(w, "Sign in with a POST request.", http.StatusMethodNotAllowed)
+			return
+		}
+
+		p := getProvider(r.URL.Query().Get("pc"))
+		if p == nil {
+			log15.Error("No LDAP auth provider found with ID.", "id", r.URL.Query().Get("pc"))
+			http.Error(w, "Misconfigured LDAP auth provider.", http.StatusInternalServerError)
+			return
+		}
+
+		var creds credentials
+		if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+			http.Error(w, "Could not decode request body", http.StatusBadRequest)
+			return
+		}
+
+		entry, err := p.authenticate(creds.Username, creds.Password)
+		if err != nil {
+			logSignInEvent(r, db, 0, creds.Username, database.SecurityEventNameSignInFailed)
+			if err == errInvalidCredentials {
+				http.Error(w, "Authentication failed", http.StatusUnauthorized)
+				return
+			}
+			log15.Error("Error authenticating LDAP user.", "id", p.ConfigID(), "error", err)
+			http.Error(w, "Unexpected error contacting the LDAP server. Check the logs for more details.", http.StatusInternalServerError)
+			return
+		}
+
+		a, safeErrMsg, err := getOrCreateUser(r.Context(), db, p, entry)
+		if err != nil {
+			log15.Error("Error looking up LDAP-authenticated user.", "dn", entry.DN, "error", err, "userErr", safeErrMsg)
+			logSignInEvent(r, db, 0, creds.Username, database.SecurityEventNameSignInFailed)
+			http.Error(w, safeErrMsg, http.StatusInternalServerError)
+			return
+		}
+
+		user, err := db.Users().GetByID(r.Context(), a.UID)
+		if err != nil {
+			log15.Error("Error retrieving LDAP-authenticated user.", "error", err)
+			http.Error(w, "Error retrieving LDAP-authenticated user.", http.StatusInternalServerError)
+			return
+		}
+		if err := session.SetActor(w, r, a, 0, user.CreatedAt); err != nil {
+			log15.Error("Error setting LDAP-authenticated actor in session.", "error", err)
+			http.Error(w, "Could not create new user session", http.StatusInternalServerError)
+			return
+		}
+		logSignInEvent(r, db, a.UID, creds.Username, database.SecurityEventNameSignInSucceeded)
+	}
+}
+
+func logSignInEvent(r *http.Request, db database.DB, userID int32, username string, name database.SecurityEventName) {
+	argument, _ := json.Marshal(map[string]string{"provider": providerType, "username": username})
+	event := &database.SecurityEvent{
+		Name:      name,
+		URL:       r.URL.Path,
+		UserID:    uint32(userID),
+		Argument:  argument,
+		Source:    "BACKEND",
+		Timestamp: time.Now(),
+	}
+	// Failed sign-ins have no user, which would violate the
+	// security_event_logs_check_has_user constraint.
+	if userID == 0 {
+		event.AnonymousUserID = "anonymous"
+	}
+	database.SecurityEventLogs(db).LogEvent(r.Context(), event)
+}
@@ -0,0 +1,262 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const providerType = "ldap"
+
+// errInvalidCredentials is returned when a user can't be authenticated with the
+// given username and password. It deliberately doesn't say why, so that sign-in
+// attempts can't be used to probe the directory.
+var errInvalidCredentials = errors.New("invalid username or password")
+
+type provider struct {
+	config schema.LDAPAuthProvider
+}
+
+// ConfigID implements providers.Provider.
+func (p *provider) ConfigID() providers.ConfigID {
+	return providers.ConfigID{
+		Type: providerType,
+		ID:   providerConfigID(&p.config),
+	}
+}
+
+// Config implements providers.Provider.
+func (p *provider) Config() schema.AuthProviders {
+	return schema.AuthProviders{Ldap: &p.config}
+}
+
+// Refresh implements providers.Provider.
+func (p *provider) Refresh(context.Context) error { return nil }
+
+// CachedInfo implements providers.Provider.
+func (p *provider) CachedInfo() *providers.Info {
+	info := &providers.Info{
+		ServiceID:   p.config.Url,
+		ClientID:    p.config.UserSearchBase,
+		DisplayName: p.config.DisplayName,
+		AuthenticationURL: (&url.URL{
+			Path:     path.Join(authPrefix, "login"),
+			RawQuery: (url.Values{"pc": []string{providerConfigID(&p.config)}}).Encode(),
+		}).String(),
+	}
+	if info.DisplayName == "" {
+		info.DisplayName = "LDAP"
+	}
+	return info
+}
+
+// userEntry is the part of an LDAP user entry that Sourcegraph uses.
+type userEntry struct {
+	DN          string   `json:"dn"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	DisplayName string   `json:"displayName"`
+	Groups      []string `json:"groups"`
+}
+
+// conn is the subset of *ldap.Conn used by the provider.
+type conn interface {
+	Bind(username, password string) error
+	Search(*ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close()
+}
+
+// dial connects to the LDAP server of the provider. It is a variable so that
+// tests can replace it.
+var dial = func(p *provider) (conn, error) {
+	tlsConfig := &tls.Config{}
+	if p.config.Certificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(p.config.Certificate)) {
+			return nil, errors.New("invalid LDAP server certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	u, err := url.Parse(p.config.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing LDAP server URL")
+	}
+	tlsConfig.ServerName = u.Hostname()
+
+	c, err := ldap.DialURL(p.config.Url, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to LDAP server")
+	}
+	if p.config.StartTLS && u.Scheme == "ldap" {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			c.Close()
+			return nil, errors.Wrap(err, "upgrading LDAP connection to TLS")
+		}
+	}
+	return c, nil
+}
+
+// authenticate verifies the username and password of a user by binding as the
+// user entry matched by the user search filter, and returns the entry.
+//
+// 🚨 SECURITY: An empty password would result in an unauthenticated bind, which
+// most servers accept for any DN, so it is rejected.
+func (p *provider) authenticate(username, password string) (*userEntry, error) {
+	if username == "" || password == "" {
+		return nil, errInvalidCredentials
+	}
+
+	c, err := dial(p)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := p.bindServiceAccount(c); err != nil {
+		return nil, err
+	}
+	filter := strings.ReplaceAll(p.userSearchFilter(), "{username}", ldap.EscapeFilter(username))
+	res, err := c.Search(p.userSearchRequest(p.config.UserSearchBase, ldap.ScopeWholeSubtree, filter))
+	// Refuse ambiguous filters rather than picking one of the entries.
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, errInvalidCredentials
+	} else if err != nil {
+		return nil, errors.Wrap(err, "searching for LDAP user")
+	}
+	if len(res.Entries) != 1 {
+		return nil, errInvalidCredentials
+	}
+	entry := res.Entries[0]
+
+	if err := c.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, errInvalidCredentials
+		}
+		return nil, errors.Wrap(err, "binding as LDAP user")
+	}
+
+	// Groups are read with the service account, since users often can't read
+	// group entries themselves.
+	if err := p.bindServiceAccount(c); err != nil {
+		return nil, err
+	}
+	return p.toUserEntry(c, entry)
+}
+
+// lookup returns the user entry with the given DN, or nil if it doesn't exist.
+func (p *provider) lookup(dn string) (*userEntry, error) {
+	c, err := dial(p)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := p.bindServiceAccount(c); err != nil {
+		return nil, err
+	}
+	res, err := c.Search(p.userSearchRequest(dn, ldap.ScopeBaseObject, "(objectClass=*)"))
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) || (err == nil && len(res.Entries) == 0) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "looking up LDAP user")
+	}
+	return p.toUserEntry(c, res.Entries[0])
+}
+
+func (p *provider) bindServiceAccount(c conn) error {
+	if p.config.BindDN == "" {
+		return nil
+	}
+	if err := c.Bind(p.config.BindDN, p.config.BindPassword); err != nil {
+		return errors.Wrap(err, "binding as LDAP service account")
+	}
+	return nil
+}
+
+func (p *provider) userSearchRequest(base string, scope int, filter string) *ldap.SearchRequest {
+	attrs := p.attributes()
+	return ldap.NewSearchRequest(
+		base, scope, ldap.NeverDerefAliases,
+		2, // size limit: enough to detect ambiguous filters
+		0, false,
+		filter,
+		[]string{attrs.Username, attrs.Email, attrs.DisplayName, "memberOf"},
+		nil,
+	)
+}
+
+func (p *provider) toUserEntry(c conn, e *ldap.Entry) (*userEntry, error) {
+	attrs := p.attributes()
+	u := &userEntry{
+		DN:          e.DN,
+		Username:    e.GetAttributeValue(attrs.Username),
+		Email:       e.GetAttributeValue(attrs.Email),
+		DisplayName: e.GetAttributeValue(attrs.DisplayName),
+	}
+
+	if p.config.GroupSearchBase == "" {
+		u.Groups = e.GetAttributeValues("memberOf")
+		return u, nil
+	}
+
+	filter := p.config.GroupSearchFilter
+	if filter == "" {
+		filter = "(|(member={dn})(uniqueMember={dn})(memberUid={username}))"
+	}
+	filter = strings.NewReplacer(
+		"{dn}", ldap.EscapeFilter(u.DN),
+		"{username}", ldap.EscapeFilter(u.Username),
+	).Replace(filter)
+	res, err := c.Search(ldap.NewSearchRequest(
+		p.config.GroupSearchBase, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		0, 0, false,
+		filter,
+		[]string{"1.1"}, // no attributes, only DNs
+		nil,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for LDAP groups")
+	}
+	for _, g := range res.Entries {
+		u.Groups = append(u.Groups, g.DN)
+	}
+	return u, nil
+}
+
+func (p *provider) userSearchFilter() string {
+	if p.config.UserSearchFilter != "" {
+		return p.config.UserSearchFilter
+	}
+	return "(uid={username})"
+}
+
+// attributes returns the attribute mapping of the provider with defaults
+// applied.
+func (p *provider) attributes() schema.LDAPAttributeMapping {
+	attrs := schema.LDAPAttributeMapping{Username: "uid", Email: "mail", DisplayName: "cn"}
+	if m := p.config.Attributes; m != nil {
+		if m.Username != "" {
+			attrs.Username = m.Username
+		}
+		if m.Email != "" {
+			attrs.Email = m.Email
+		}
+		if m.DisplayName != "" {
+			attrs.DisplayName = m.DisplayName
+		}
+	}
+	return attrs
+}
+
+func (p *provider) allowSignup() bool {
+	return p.config.AllowSignup == nil || *p.config.AllowSignup
+}
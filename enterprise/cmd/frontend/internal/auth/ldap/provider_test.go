@@ -0,0 +1,222 @@
+package ldap
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// fakeConn is an in-memory directory with a single user search result and
+// group search result.
+type fakeConn struct {
+	passwords map[string]string // DN -> password
+	users     []*ldap.Entry
+	groups    []*ldap.Entry
+
+	searches []string // filters of the searches, in order
+}
+
+func (c *fakeConn) Bind(dn, password string) error {
+	if pw, ok := c.passwords[dn]; !ok || pw != password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, nil)
+	}
+	return nil
+}
+
+func (c *fakeConn) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	c.searches = append(c.searches, req.Filter)
+	if strings.HasPrefix(req.BaseDN, "ou=groups") {
+		return &ldap.SearchResult{Entries: c.groups}, nil
+	}
+	if req.SizeLimit > 0 && len(c.users) > req.SizeLimit {
+		return nil, ldap.NewError(ldap.LDAPResultSizeLimitExceeded, nil)
+	}
+	return &ldap.SearchResult{Entries: c.users}, nil
+}
+
+func (c *fakeConn) Close() {}
+
+func mockDial(t *testing.T, c *fakeConn) {
+	orig := dial
+	dial = func(*provider) (conn, error) { return c, nil }
+	t.Cleanup(func() { dial = orig })
+}
+
+const aliceDN = "uid=alice,ou=people,dc=example,dc=org"
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{
+		passwords: map[string]string{
+			"cn=admin,dc=example,dc=org": "admin",
+			aliceDN:                      "hunter2",
+		},
+		users: []*ldap.Entry{ldap.NewEntry(aliceDN, map[string][]string{
+			"uid":      {"alice"},
+			"mail":     {"alice@example.org"},
+			"cn":       {"Alice Liddell"},
+			"memberOf": {"cn=admins,ou=groups,dc=example,dc=org"},
+		})},
+	}
+}
+
+func newTestProvider() *provider {
+	return &provider{config: schema.LDAPAuthProvider{
+		Type:           providerType,
+		Url:            "ldap://localhost:389",
+		BindDN:         "cn=admin,dc=example,dc=org",
+		BindPassword:   "admin",
+		UserSearchBase: "ou=people,dc=example,dc=org",
+	}}
+}
+
+func TestProvider_Authenticate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := newFakeConn()
+		mockDial(t, c)
+
+		entry, err := newTestProvider().authenticate("alice", "hunter2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &userEntry{
+			DN:          aliceDN,
+			Username:    "alice",
+			Email:       "alice@example.org",
+			DisplayName: "Alice Liddell",
+			Groups:      []string{"cn=admins,ou=groups,dc=example,dc=org"},
+		}
+		if diff := cmp.Diff(want, entry); diff != "" {
+			t.Fatalf("unexpected entry (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		mockDial(t, newFakeConn())
+
+		_, err := newTestProvider().authenticate("alice", "wrong")
+		if err != errInvalidCredentials {
+			t.Fatalf("want errInvalidCredentials, got %v", err)
+		}
+	})
+
+	t.Run("empty password", func(t *testing.T) {
+		c := newFakeConn()
+		c.passwords[aliceDN] = ""
+		mockDial(t, c)
+
+		_, err := newTestProvider().authenticate("alice", "")
+		if err != errInvalidCredentials {
+			t.Fatalf("want errInvalidCredentials, got %v", err)
+		}
+	})
+
+	t.Run("no such user", func(t *testing.T) {
+		c := newFakeConn()
+		c.users = nil
+		mockDial(t, c)
+
+		_, err := newTestProvider().authenticate("bob", "hunter2")
+		if err != errInvalidCredentials {
+			t.Fatalf("want errInvalidCredentials, got %v", err)
+		}
+	})
+
+	t.Run("ambiguous filter", func(t *testing.T) {
+		c := newFakeConn()
+		c.users = append(c.users, c.users[0], c.users[0])
+		mockDial(t, c)
+
+		_, err := newTestProvider().authenticate("alice", "hunter2")
+		if err != errInvalidCredentials {
+			t.Fatalf("want errInvalidCredentials, got %v", err)
+		}
+	})
+
+	t.Run("escapes username in filter", func(t *testing.T) {
+		c := newFakeConn()
+		mockDial(t, c)
+
+		_, _ = newTestProvider().authenticate("*)(uid=*", "hunter2")
+		if want := []string{`(uid=\2a\29\28uid=\2a)`}; !cmp.Equal(want, c.searches) {
+			t.Fatalf("want searches %q, got %q", want, c.searches)
+		}
+	})
+
+	t.Run("groups from group search", func(t *testing.T) {
+		c := newFakeConn()
+		c.groups = []*ldap.Entry{ldap.NewEntry("cn=developers,ou=groups,dc=example,dc=org", nil)}
+		mockDial(t, c)
+
+		p := newTestProvider()
+		p.config.GroupSearchBase = "ou=groups,dc=example,dc=org"
+		p.config.Attributes = &schema.LDAPAttributeMapping{DisplayName: "uid"}
+		entry, err := p.authenticate("alice", "hunter2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"cn=developers,ou=groups,dc=example,dc=org"}; !cmp.Equal(want, entry.Groups) {
+			t.Fatalf("want groups %q, got %q", want, entry.Groups)
+		}
+		if entry.DisplayName != "alice" {
+			t.Fatalf("want display name from mapped attribute, got %q", entry.DisplayName)
+		}
+		wantFilter := "(|(member=uid=alice,ou=people,dc=example,dc=org)(uniqueMember=uid=alice,ou=people,dc=example,dc=org)(memberUid=alice))"
+		if got := c.searches[len(c.searches)-1]; got != wantFilter {
+			t.Fatalf("want group filter %q, got %q", wantFilter, got)
+		}
+	})
+}
+
+// TestProvider_Integration runs against a real directory server. It is skipped
+// unless LDAP_TEST_URL is set, for example with an OpenLDAP container:
+//
+//	docker run --rm -p 389:389 -e LDAP_ORGANISATION=Example -e LDAP_DOMAIN=example.org \
+//	  -e LDAP_ADMIN_PASSWORD=admin osixia/openldap:1.5.0
+//	LDAP_TEST_URL=ldap://localhost:389 go test -run TestProvider_Integration .
+//
+// The test authenticates as the admin entry, which the container always
+// creates.
+func TestProvider_Integration(t *testing.T) {
+	u := os.Getenv("LDAP_TEST_URL")
+	if u == "" {
+		t.Skip("LDAP_TEST_URL not set")
+	}
+
+	p := &provider{config: schema.LDAPAuthProvider{
+		Type:             providerType,
+		Url:              u,
+		BindDN:           "cn=admin,dc=example,dc=org",
+		BindPassword:     "admin",
+		UserSearchBase:   "dc=example,dc=org",
+		UserSearchFilter: "(cn={username})",
+		Attributes:       &schema.LDAPAttributeMapping{Username: "cn", Email: "cn"},
+	}}
+
+	entry, err := p.authenticate("admin", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.DN != "cn=admin,dc=example,dc=org" {
+		t.Fatalf("unexpected DN %q", entry.DN)
+	}
+
+	if _, err := p.authenticate("admin", "wrong"); err != errInvalidCredentials {
+		t.Fatalf("want errInvalidCredentials, got %v", err)
+	}
+
+	got, err := p.lookup(entry.DN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.DN != entry.DN {
+		t.Fatalf("lookup returned %+v", got)
+	}
+	if got, err := p.lookup("cn=nobody,dc=example,dc=org"); err != nil || got != nil {
+		t.Fatalf("want nil entry for missing DN, got %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,101 @@
+package ldap
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// Init must be called by the frontend to start the periodic resync of LDAP
+// users.
+func Init(db database.DB) {
+	go func() {
+		lastSynced := map[string]time.Time{}
+		for range time.NewTicker(time.Minute).C {
+			syncDueProviders(context.Background(), db, lastSynced, time.Now())
+		}
+	}()
+}
+
+// syncDueProviders resyncs the users of every LDAP auth provider whose sync
+// interval has elapsed since the last sync recorded in lastSynced.
+func syncDueProviders(ctx context.Context, db database.DB, lastSynced map[string]time.Time, now time.Time) {
+	for _, p := range getProviders() {
+		interval := p.syncInterval()
+		if interval == 0 {
+			continue
+		}
+		id := p.ConfigID().ID
+		if last, ok := lastSynced[id]; ok && now.Sub(last) < interval {
+			continue
+		}
+		lastSynced[id] = now
+
+		if err := syncUsers(ctx, db, p); err != nil {
+			log15.Error("Failed to sync LDAP users.", "id", id, "error", err)
+		}
+	}
+}
+
+// syncInterval returns how often users of the provider are resynced, or 0 if
+// resyncing is disabled.
+func (p *provider) syncInterval() time.Duration {
+	if p.config.SyncIntervalMinutes == nil {
+		return 60 * time.Minute
+	}
+	return time.Duration(*p.config.SyncIntervalMinutes) * time.Minute
+}
+
+// syncUsers updates the display name, account data and organization
+// memberships of every user that signed in with the provider from the
+// directory. Users whose entry no longer exists are signed out.
+func syncUsers(ctx context.Context, db database.DB, p *provider) error {
+	accounts, err := db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		ServiceType: providerType,
+		ServiceID:   p.config.Url,
+		ClientID:    p.config.UserSearchBase,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing LDAP user accounts")
+	}
+
+	for _, acct := range accounts {
+		if err := syncUser(ctx, db, p, acct); err != nil {
+			// Keep going, one unreadable entry shouldn't stop the others from syncing.
+			log15.Error("Failed to sync LDAP user.", "userID", acct.UserID, "dn", acct.AccountID, "error", err)
+		}
+	}
+	return nil
+}
+
+func syncUser(ctx context.Context, db database.DB, p *provider, acct *extsvc.Account) error {
+	entry, err := p.lookup(acct.AccountID)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		// 🚨 SECURITY: The user was removed from the directory, so they must
+		// not keep using their existing sessions.
+		log15.Info("LDAP user entry no longer exists, invalidating sessions.", "userID", acct.UserID, "dn", acct.AccountID)
+		return db.Users().InvalidateSessionsByID(ctx, acct.UserID)
+	}
+
+	if entry.DisplayName != "" {
+		if err := db.Users().Update(ctx, acct.UserID, database.UserUpdate{DisplayName: &entry.DisplayName}); err != nil {
+			return errors.Wrap(err, "updating display name")
+		}
+	}
+
+	var data extsvc.AccountData
+	data.SetAccountData(entry)
+	if _, err := db.UserExternalAccounts().LookupUserAndSave(ctx, p.accountSpec(entry.DN), data); err != nil {
+		return errors.Wrap(err, "saving account data")
+	}
+
+	return syncOrgMemberships(ctx, db, p, acct.UserID, entry.Groups)
+}
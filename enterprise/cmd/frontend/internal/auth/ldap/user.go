@@ -0,0 +1,117 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// accountSpec returns the external account of the user entry. Accounts are
+// identified by the DN of the user entry.
+func (p *provider) accountSpec(dn string) extsvc.AccountSpec {
+	return extsvc.AccountSpec{
+		ServiceType: providerType,
+		ServiceID:   p.config.Url,
+		ClientID:    p.config.UserSearchBase,
+		AccountID:   dn,
+	}
+}
+
+// getOrCreateUser gets or creates a user account based on the LDAP user entry. It returns the
+// authenticated actor if successful; otherwise it returns an friendly error message (safeErrMsg)
+// that is safe to display to users, and a non-nil err with lower-level error details.
+func getOrCreateUser(ctx context.Context, db database.DB, p *provider, entry *userEntry) (_ *actor.Actor, safeErrMsg string, err error) {
+	if entry.Email == "" {
+		return nil, "Only users with an email address may authenticate to Sourcegraph.", errors.Errorf("no email address in LDAP entry %q", entry.DN)
+	}
+
+	username, err := auth.NormalizeUsername(entry.Username)
+	if err != nil {
+		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", entry.Username), err
+	}
+
+	var data extsvc.AccountData
+	data.SetAccountData(entry)
+
+	userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, db, auth.GetAndSaveUserOp{
+		UserProps: database.NewUser{
+			Username:        username,
+			Email:           entry.Email,
+			EmailIsVerified: true, // the directory is trusted, like SAML identity providers
+			DisplayName:     entry.DisplayName,
+		},
+		ExternalAccount:     p.accountSpec(entry.DN),
+		ExternalAccountData: data,
+		CreateIfNotExist:    p.allowSignup(),
+	})
+	if err != nil {
+		return nil, safeErrMsg, err
+	}
+
+	if err := syncOrgMemberships(ctx, db, p, userID, entry.Groups); err != nil {
+		// Don't fail the sign-in, the next resync retries.
+		log15.Error("Failed to sync organization memberships of LDAP user.", "userID", userID, "error", err)
+	}
+	return actor.FromUser(userID), "", nil
+}
+
+// syncOrgMemberships makes the user a member of the organizations mapped to the
+// given groups by the groupOrgMap of the provider, and removes the user from
+// the other organizations in the map.
+func syncOrgMemberships(ctx context.Context, db database.DB, p *provider, userID int32, groups []string) error {
+	if len(p.config.GroupOrgMap) == 0 {
+		return nil
+	}
+
+	// DNs are case-insensitive.
+	inGroup := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		inGroup[strings.ToLower(g)] = true
+	}
+	wantOrgs := map[string]bool{}
+	for group, orgs := range p.config.GroupOrgMap {
+		for _, org := range orgs {
+			wantOrgs[org] = wantOrgs[org] || inGroup[strings.ToLower(group)]
+		}
+	}
+
+	memberships, err := db.OrgMembers().GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	isMember := make(map[int32]bool, len(memberships))
+	for _, m := range memberships {
+		isMember[m.OrgID] = true
+	}
+
+	for name, want := range wantOrgs {
+		org, err := db.Orgs().GetByName(ctx, name)
+		if errcode.IsNotFound(err) {
+			log15.Warn("Organization in LDAP groupOrgMap does not exist.", "org", name)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		switch {
+		case want && !isMember[org.ID]:
+			if _, err := db.OrgMembers().Create(ctx, org.ID, userID); err != nil {
+				return errors.Wrapf(err, "adding user to organization %q", name)
+			}
+		case !want && isMember[org.ID]:
+			if err := db.OrgMembers().Remove(ctx, org.ID, userID); err != nil {
+				return errors.Wrapf(err, "removing user from organization %q", name)
+			}
+		}
+	}
+	return nil
+}
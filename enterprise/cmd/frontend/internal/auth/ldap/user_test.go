@@ -0,0 +1,62 @@
+package ldap
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSyncOrgMemberships(t *testing.T) {
+	orgs := map[string]int32{"admins": 1, "developers": 2, "designers": 3}
+
+	orgStore := database.NewMockOrgStore()
+	orgStore.GetByNameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.Org, error) {
+		id, ok := orgs[name]
+		if !ok {
+			return nil, &database.OrgNotFoundError{Message: name}
+		}
+		return &types.Org{ID: id, Name: name}, nil
+	})
+
+	var added, removed []int32
+	orgMemberStore := database.NewMockOrgMemberStore()
+	orgMemberStore.GetByUserIDFunc.SetDefaultReturn([]*types.OrgMembership{
+		{OrgID: 1, UserID: 42}, // in the map, but no longer in the group
+		{OrgID: 3, UserID: 42}, // not in the map, left alone
+	}, nil)
+	orgMemberStore.CreateFunc.SetDefaultHook(func(_ context.Context, orgID, userID int32) (*types.OrgMembership, error) {
+		added = append(added, orgID)
+		return &types.OrgMembership{OrgID: orgID, UserID: userID}, nil
+	})
+	orgMemberStore.RemoveFunc.SetDefaultHook(func(_ context.Context, orgID, _ int32) error {
+		removed = append(removed, orgID)
+		return nil
+	})
+
+	db := database.NewMockDB()
+	db.OrgsFunc.SetDefaultReturn(orgStore)
+	db.OrgMembersFunc.SetDefaultReturn(orgMemberStore)
+
+	p := newTestProvider()
+	p.config.GroupOrgMap = map[string][]string{
+		"cn=admins,ou=groups,dc=example,dc=org":     {"admins"},
+		"cn=developers,ou=groups,dc=example,dc=org": {"developers", "missing"},
+	}
+
+	err := syncOrgMemberships(context.Background(), db, p, 42, []string{"CN=Developers,OU=Groups,DC=example,DC=org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i] < added[j] })
+	if diff := cmp.Diff([]int32{2}, added); diff != "" {
+		t.Errorf("unexpected added orgs (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int32{1}, removed); diff != "" {
+		t.Errorf("unexpected removed orgs (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,310 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (r *Resolver) ExplainRepositoryPermissions(ctx context.Context, args *graphqlbackend.ExplainRepositoryPermissionsArgs) (graphqlbackend.RepositoryPermissionsExplanationResolver, error) {
+	// 🚨 SECURITY: Only site admins can query repository permissions.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	userID, err := graphqlbackend.UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+	user, err := r.db.Users().GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := r.db.Repos().Get(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &permissionsExplanationResolver{
+		db:     r.db,
+		user:   user,
+		repo:   repo,
+		public: !repo.Private,
+	}
+
+	// Whether the user can access the repository is determined by the same
+	// query that enforces it, the rest only explains the outcome.
+	_, err = r.db.Repos().Get(actor.WithActor(ctx, actor.FromUser(user.ID)), repo.ID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return nil, errors.Wrap(err, "checking access as user")
+	}
+	e.accessible = err == nil
+
+	allowByDefault, providers := authz.GetProviders()
+	userMapping := globals.PermissionsUserMapping().Enabled
+	if userMapping {
+		allowByDefault = false
+	}
+	e.bypassedAsSiteAdmin = user.SiteAdmin && !conf.Get().AuthzEnforceForSiteAdmins
+
+	for _, p := range providers {
+		if p.ServiceType() == repo.ExternalRepo.ServiceType && p.ServiceID() == repo.ExternalRepo.ServiceID {
+			e.provider = &authorizationProviderResolver{provider: p}
+			break
+		}
+	}
+
+	if ids := repo.ExternalServiceIDs(); len(ids) > 0 {
+		svcs, err := r.db.ExternalServices().List(ctx, database.ExternalServicesListOptions{IDs: ids})
+		if err != nil {
+			return nil, errors.Wrap(err, "listing external services")
+		}
+		for _, svc := range svcs {
+			e.unrestricted = e.unrestricted || svc.Unrestricted
+		}
+	}
+
+	accounts, err := r.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		UserID:      user.ID,
+		ServiceType: repo.ExternalRepo.ServiceType,
+		ServiceID:   repo.ExternalRepo.ServiceID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing external accounts")
+	}
+	if len(accounts) > 0 {
+		e.account = graphqlbackend.NewExternalAccountResolver(r.db, *accounts[0])
+	}
+
+	userPerms := &authz.UserPermissions{UserID: user.ID, Perm: authz.Read, Type: authz.PermRepos}
+	if err := r.db.Perms().LoadUserPermissions(ctx, userPerms); err == nil {
+		e.userPermissionsInfo = &permissionsInfoResolver{perms: userPerms.Perm, syncedAt: userPerms.SyncedAt, updatedAt: userPerms.UpdatedAt}
+		e.granted = userPerms.IDs != nil && userPerms.IDs.Contains(uint32(repo.ID))
+	} else if err != authz.ErrPermsNotFound {
+		return nil, errors.Wrap(err, "loading user permissions")
+	}
+
+	repoPerms := &authz.RepoPermissions{RepoID: int32(repo.ID), Perm: authz.Read}
+	if err := r.db.Perms().LoadRepoPermissions(ctx, repoPerms); err == nil {
+		e.repoPermissionsInfo = &permissionsInfoResolver{perms: repoPerms.Perm, syncedAt: repoPerms.SyncedAt, updatedAt: repoPerms.UpdatedAt}
+	} else if err != authz.ErrPermsNotFound {
+		return nil, errors.Wrap(err, "loading repository permissions")
+	}
+
+	var userSync, repoSync *edb.PermsSyncOutcome
+	if userSync, err = r.loadSyncOutcome(ctx, edb.PermsSyncObjectUser, user.ID); err != nil {
+		return nil, err
+	} else if userSync != nil {
+		e.lastUserSync = &permissionsSyncOutcomeResolver{outcome: userSync}
+	}
+	if repoSync, err = r.loadSyncOutcome(ctx, edb.PermsSyncObjectRepo, int32(repo.ID)); err != nil {
+		return nil, err
+	} else if repoSync != nil {
+		e.lastRepoSync = &permissionsSyncOutcomeResolver{outcome: repoSync}
+	}
+
+	subRepoPerms, err := r.db.SubRepoPerms().GetByUser(ctx, user.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting sub-repository permissions")
+	}
+	if perms, ok := subRepoPerms[repo.Name]; ok {
+		e.subRepoPerms = &subRepositoryPermissionsResolver{
+			enabled: authz.SubRepoEnabled(authz.DefaultSubRepoPermsChecker),
+			perms:   perms,
+		}
+	}
+
+	// The order of the reasons follows the conditions of
+	// database.AuthzQueryConds.
+	switch {
+	case allowByDefault && len(providers) == 0:
+		e.addReason("No authorization providers are configured, so all users can access all repositories.")
+	case e.bypassedAsSiteAdmin:
+		e.addReason("The user is a site admin, and site admins can access all repositories unless authz.enforceForSiteAdmins is set.")
+	case e.public && !userMapping:
+		e.addReason("The repository is public.")
+	case e.unrestricted && !userMapping:
+		e.addReason("The repository belongs to a code host connection without authorization, so all users can access it.")
+	case e.granted && e.accessible:
+		e.addReason("The stored permissions of the user include the repository.")
+	case e.granted:
+		e.addReason("The stored permissions of the user include the repository, but it was added by a user or organization the user doesn't belong to.")
+	default:
+		e.addReason("The stored permissions of the user don't include the repository.")
+		if userMapping {
+			e.addReason("Permissions are set explicitly with the GraphQL API (permissions.userMapping), so only repositories they were set for are accessible.")
+			break
+		}
+		if e.provider == nil {
+			e.addReason("No authorization provider is configured for the code host %s, so permissions of its private repositories can't be synced.", repo.ExternalRepo.ServiceID)
+		} else if e.account == nil {
+			e.addReason("The user has no external account on %s, so their permissions can't be synced from it.", repo.ExternalRepo.ServiceID)
+		}
+		e.addSyncReason("user", userSync)
+		e.addSyncReason("repository", repoSync)
+	}
+	if e.subRepoPerms != nil && e.subRepoPerms.enabled && e.accessible {
+		e.addReason("Sub-repository permissions restrict the paths of the repository the user can access.")
+	}
+	return e, nil
+}
+
+// loadSyncOutcome returns the outcome of the last permissions sync of the
+// object, or nil if it was never synced.
+func (r *Resolver) loadSyncOutcome(ctx context.Context, objectType string, objectID int32) (*edb.PermsSyncOutcome, error) {
+	o, err := r.db.Perms().LoadSyncOutcome(ctx, objectType, objectID)
+	if err == authz.ErrPermsNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading sync outcome of %s %d", objectType, objectID)
+	}
+	return o, nil
+}
+
+type permissionsExplanationResolver struct {
+	db   database.DB
+	user *types.User
+	repo *types.Repo
+
+	accessible          bool
+	reasons             []string
+	bypassedAsSiteAdmin bool
+	public              bool
+	unrestricted        bool
+	granted             bool
+
+	provider            *authorizationProviderResolver
+	account             graphqlbackend.ExternalAccountResolver
+	userPermissionsInfo *permissionsInfoResolver
+	repoPermissionsInfo *permissionsInfoResolver
+	lastUserSync        *permissionsSyncOutcomeResolver
+	lastRepoSync        *permissionsSyncOutcomeResolver
+	subRepoPerms        *subRepositoryPermissionsResolver
+}
+
+func (r *permissionsExplanationResolver) addReason(format string, args ...interface{}) {
+	r.reasons = append(r.reasons, fmt.Sprintf(format, args...))
+}
+
+func (r *permissionsExplanationResolver) addSyncReason(object string, o *edb.PermsSyncOutcome) {
+	switch {
+	case o == nil:
+		r.addReason("The permissions of the %s have never been synced.", object)
+	case o.Error != "":
+		r.addReason("The last permissions sync of the %s failed at %s: %s", object, o.FinishedAt.Format("2006-01-02 15:04:05 MST"), o.Error)
+	}
+}
+
+func (r *permissionsExplanationResolver) User() *graphqlbackend.UserResolver {
+	return graphqlbackend.NewUserResolver(r.db, r.user)
+}
+
+func (r *permissionsExplanationResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return graphqlbackend.NewRepositoryResolver(r.db, r.repo)
+}
+
+func (r *permissionsExplanationResolver) Accessible() bool           { return r.accessible }
+func (r *permissionsExplanationResolver) Reasons() []string          { return r.reasons }
+func (r *permissionsExplanationResolver) BypassedAsSiteAdmin() bool  { return r.bypassedAsSiteAdmin }
+func (r *permissionsExplanationResolver) Public() bool               { return r.public }
+func (r *permissionsExplanationResolver) Unrestricted() bool         { return r.unrestricted }
+func (r *permissionsExplanationResolver) GrantedByPermissions() bool { return r.granted }
+
+func (r *permissionsExplanationResolver) AuthorizationProvider() graphqlbackend.AuthorizationProviderResolver {
+	if r.provider == nil {
+		return nil
+	}
+	return r.provider
+}
+
+func (r *permissionsExplanationResolver) ExternalAccount() graphqlbackend.ExternalAccountResolver {
+	return r.account
+}
+
+func (r *permissionsExplanationResolver) UserPermissionsInfo() graphqlbackend.PermissionsInfoResolver {
+	if r.userPermissionsInfo == nil {
+		return nil
+	}
+	return r.userPermissionsInfo
+}
+
+func (r *permissionsExplanationResolver) RepositoryPermissionsInfo() graphqlbackend.PermissionsInfoResolver {
+	if r.repoPermissionsInfo == nil {
+		return nil
+	}
+	return r.repoPermissionsInfo
+}
+
+func (r *permissionsExplanationResolver) LastUserPermissionsSync() graphqlbackend.PermissionsSyncOutcomeResolver {
+	if r.lastUserSync == nil {
+		return nil
+	}
+	return r.lastUserSync
+}
+
+func (r *permissionsExplanationResolver) LastRepositoryPermissionsSync() graphqlbackend.PermissionsSyncOutcomeResolver {
+	if r.lastRepoSync == nil {
+		return nil
+	}
+	return r.lastRepoSync
+}
+
+func (r *permissionsExplanationResolver) SubRepositoryPermissions() graphqlbackend.SubRepositoryPermissionsResolver {
+	if r.subRepoPerms == nil {
+		return nil
+	}
+	return r.subRepoPerms
+}
+
+type authorizationProviderResolver struct {
+	provider authz.Provider
+}
+
+func (r *authorizationProviderResolver) ServiceType() string { return r.provider.ServiceType() }
+func (r *authorizationProviderResolver) ServiceID() string   { return r.provider.ServiceID() }
+func (r *authorizationProviderResolver) URN() string         { return r.provider.URN() }
+
+type permissionsSyncOutcomeResolver struct {
+	outcome *edb.PermsSyncOutcome
+}
+
+func (r *permissionsSyncOutcomeResolver) StartedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.outcome.StartedAt}
+}
+
+func (r *permissionsSyncOutcomeResolver) FinishedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.outcome.FinishedAt}
+}
+
+func (r *permissionsSyncOutcomeResolver) Error() *string {
+	if r.outcome.Error == "" {
+		return nil
+	}
+	return &r.outcome.Error
+}
+
+type subRepositoryPermissionsResolver struct {
+	enabled bool
+	perms   authz.SubRepoPermissions
+}
+
+func (r *subRepositoryPermissionsResolver) Enabled() bool          { return r.enabled }
+func (r *subRepositoryPermissionsResolver) PathIncludes() []string { return r.perms.PathIncludes }
+func (r *subRepositoryPermissionsResolver) PathExcludes() []string { return r.perms.PathExcludes }
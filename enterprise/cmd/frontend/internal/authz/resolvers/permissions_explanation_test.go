@@ -0,0 +1,169 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type fakeAuthzProvider struct {
+	authz.Provider
+}
+
+func (fakeAuthzProvider) ServiceType() string { return extsvc.TypeGitHub }
+func (fakeAuthzProvider) ServiceID() string   { return "https://github.com/" }
+func (fakeAuthzProvider) URN() string         { return "extsvc:github:1" }
+
+func TestResolver_ExplainRepositoryPermissions(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		users := database.NewStrictMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+
+		db := edb.NewStrictMockEnterpriseDB()
+		db.UsersFunc.SetDefaultReturn(users)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&Resolver{db: db}).ExplainRepositoryPermissions(ctx, &graphqlbackend.ExplainRepositoryPermissionsArgs{})
+		if want := backend.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+	})
+
+	t.Run("private repository the user can't access", func(t *testing.T) {
+		authz.SetProviders(false, []authz.Provider{fakeAuthzProvider{}})
+		t.Cleanup(func() { authz.SetProviders(true, nil) })
+
+		users := database.NewStrictMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultHook(func(ctx context.Context) (*types.User, error) {
+			uid := actor.FromContext(ctx).UID
+			return &types.User{ID: uid, SiteAdmin: uid == 1}, nil
+		})
+		users.GetByIDFunc.SetDefaultReturn(&types.User{ID: 2, Username: "alice"}, nil)
+
+		repos := database.NewStrictMockRepoStore()
+		repos.GetFunc.SetDefaultHook(func(ctx context.Context, id api.RepoID) (*types.Repo, error) {
+			if actor.FromContext(ctx).UID != 1 {
+				return nil, &database.RepoNotFoundErr{ID: id}
+			}
+			return &types.Repo{
+				ID:      id,
+				Name:    "github.com/a/private",
+				Private: true,
+				ExternalRepo: api.ExternalRepoSpec{
+					ServiceType: extsvc.TypeGitHub,
+					ServiceID:   "https://github.com/",
+				},
+				Sources: map[string]*types.SourceInfo{"extsvc:github:1": {ID: "extsvc:github:1"}},
+			}, nil
+		})
+
+		externalServices := database.NewStrictMockExternalServiceStore()
+		externalServices.ListFunc.SetDefaultReturn([]*types.ExternalService{{ID: 1, Kind: extsvc.KindGitHub}}, nil)
+
+		externalAccounts := database.NewStrictMockUserExternalAccountsStore()
+		externalAccounts.ListFunc.SetDefaultReturn([]*extsvc.Account{{ID: 3, UserID: 2}}, nil)
+
+		finishedAt := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+		perms := edb.NewStrictMockPermsStore()
+		perms.LoadUserPermissionsFunc.SetDefaultHook(func(_ context.Context, p *authz.UserPermissions) error {
+			p.IDs = roaring.BitmapOf(2)
+			p.SyncedAt = finishedAt
+			return nil
+		})
+		perms.LoadRepoPermissionsFunc.SetDefaultReturn(authz.ErrPermsNotFound)
+		perms.LoadSyncOutcomeFunc.SetDefaultHook(func(_ context.Context, objectType string, objectID int32) (*edb.PermsSyncOutcome, error) {
+			if objectType == edb.PermsSyncObjectRepo {
+				return nil, authz.ErrPermsNotFound
+			}
+			return &edb.PermsSyncOutcome{
+				ObjectType: objectType,
+				ObjectID:   objectID,
+				StartedAt:  finishedAt.Add(-time.Minute),
+				FinishedAt: finishedAt,
+				Error:      "rate limit exceeded",
+			}, nil
+		})
+
+		subRepoPerms := database.NewStrictMockSubRepoPermsStore()
+		subRepoPerms.GetByUserFunc.SetDefaultReturn(nil, nil)
+
+		db := edb.NewStrictMockEnterpriseDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.ReposFunc.SetDefaultReturn(repos)
+		db.ExternalServicesFunc.SetDefaultReturn(externalServices)
+		db.UserExternalAccountsFunc.SetDefaultReturn(externalAccounts)
+		db.PermsFunc.SetDefaultReturn(perms)
+		db.SubRepoPermsFunc.SetDefaultReturn(subRepoPerms)
+
+		gqltesting.RunTests(t, []*gqltesting.Test{{
+			Context: actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+{
+  explainRepositoryPermissions(user: "VXNlcjoy", repository: "UmVwb3NpdG9yeTox") {
+    accessible
+    reasons
+    bypassedAsSiteAdmin
+    public
+    unrestricted
+    authorizationProvider { serviceType serviceID urn }
+    externalAccount { id }
+    grantedByPermissions
+    userPermissionsInfo { syncedAt }
+    repositoryPermissionsInfo { syncedAt }
+    lastUserPermissionsSync { startedAt finishedAt error }
+    lastRepositoryPermissionsSync { error }
+    subRepositoryPermissions { enabled }
+  }
+}
+`,
+			ExpectedResult: `
+{
+	"explainRepositoryPermissions": {
+		"accessible": false,
+		"reasons": [
+			"The stored permissions of the user don't include the repository.",
+			"The last permissions sync of the user failed at 2021-12-01 10:00:00 UTC: rate limit exceeded",
+			"The permissions of the repository have never been synced."
+		],
+		"bypassedAsSiteAdmin": false,
+		"public": false,
+		"unrestricted": false,
+		"authorizationProvider": {
+			"serviceType": "github",
+			"serviceID": "https://github.com/",
+			"urn": "extsvc:github:1"
+		},
+		"externalAccount": {"id": "RXh0ZXJuYWxBY2NvdW50OjM="},
+		"grantedByPermissions": false,
+		"userPermissionsInfo": {"syncedAt": "2021-12-01T10:00:00Z"},
+		"repositoryPermissionsInfo": null,
+		"lastUserPermissionsSync": {
+			"startedAt": "2021-12-01T09:59:00Z",
+			"finishedAt": "2021-12-01T10:00:00Z",
+			"error": "rate limit exceeded"
+		},
+		"lastRepositoryPermissionsSync": null,
+		"subRepositoryPermissions": null
+	}
+}
+`,
+		}})
+	})
+}
@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
@@ -149,15 +151,44 @@ func (r *Resolver) SetRepositoryPermissionsForUsers(ctx context.Context, args *g
 		AccountIDs:  pendingBindIDs,
 	}
 
+	// The previous permissions are only needed for the audit log.
+	before := &authz.RepoPermissions{RepoID: p.RepoID, Perm: p.Perm}
+	if conf.AuditLogEnabled() {
+		if err = txs.LoadRepoPermissions(ctx, before); err != nil && err != authz.ErrPermsNotFound {
+			return nil, errors.Wrap(err, "load repository permissions")
+		}
+	}
+
 	if err = txs.SetRepoPermissions(ctx, p); err != nil {
 		return nil, errors.Wrap(err, "set repository permissions")
 	} else if err = txs.SetRepoPendingPermissions(ctx, accounts, p); err != nil {
 		return nil, errors.Wrap(err, "set repository pending permissions")
 	}
 
+	database.SecurityEventLogs(r.db).LogAuditEvent(ctx, database.SecurityEventNameRepoPermissionsUpdated, nil,
+		newRepoPermissionsAuditState(before, nil),
+		newRepoPermissionsAuditState(p, pendingBindIDs),
+	)
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+// repoPermissionsAuditState is the state of explicit repository permissions
+// recorded in the audit log.
+type repoPermissionsAuditState struct {
+	RepoID         int32    `json:"repoID"`
+	UserIDs        []uint32 `json:"userIDs"`
+	PendingBindIDs []string `json:"pendingBindIDs,omitempty"`
+}
+
+func newRepoPermissionsAuditState(p *authz.RepoPermissions, pendingBindIDs []string) repoPermissionsAuditState {
+	state := repoPermissionsAuditState{RepoID: p.RepoID, UserIDs: []uint32{}, PendingBindIDs: pendingBindIDs}
+	if p.UserIDs != nil {
+		state.UserIDs = p.UserIDs.ToArray()
+	}
+	sort.Strings(state.PendingBindIDs)
+	return state
+}
+
 func (r *Resolver) ScheduleRepositoryPermissionsSync(ctx context.Context, args *graphqlbackend.RepositoryIDArgs) (*graphqlbackend.EmptyResponse, error) {
 	if err := r.checkLicense(); err != nil {
 		return nil, err
@@ -0,0 +1,163 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func (r *Resolver) SetSubRepositoryPathRules(ctx context.Context, args *graphqlbackend.SubRepoPathRulesArgs) (*graphqlbackend.EmptyResponse, error) {
+	if envvar.SourcegraphDotComMode() {
+		return nil, errDisabledSourcegraphDotCom
+	}
+
+	if err := r.checkLicense(); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins can mutate repository permissions.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := r.db.Repos().Get(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	if !subRepoPathRulesSupported(repo.ExternalRepo.ServiceType) {
+		return nil, errors.Errorf("path rules are not supported for repositories of code host type %q", repo.ExternalRepo.ServiceType)
+	}
+
+	rules := make([]*database.SubRepoPathRule, len(args.Rules))
+	for i, input := range args.Rules {
+		rule := &database.SubRepoPathRule{
+			Pattern: input.Pattern,
+			Action:  database.SubRepoPathRuleAction(strings.ToLower(input.Action)),
+		}
+		if input.User != nil {
+			if rule.UserID, err = graphqlbackend.UnmarshalUserID(*input.User); err != nil {
+				return nil, err
+			}
+		}
+		if input.Organization != nil {
+			if rule.OrgID, err = graphqlbackend.UnmarshalOrgID(*input.Organization); err != nil {
+				return nil, err
+			}
+		}
+		if err := rule.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "rule %d", i+1)
+		}
+		rules[i] = rule
+	}
+
+	// The previous rules are only needed for the audit log.
+	var before []*database.SubRepoPathRule
+	if conf.AuditLogEnabled() {
+		if before, err = r.db.SubRepoPathRules().ListByRepo(ctx, repoID); err != nil {
+			return nil, errors.Wrap(err, "listing sub-repo path rules")
+		}
+	}
+
+	if err := r.db.SubRepoPathRules().SetForRepo(ctx, repoID, rules); err != nil {
+		return nil, errors.Wrap(err, "setting sub-repo path rules")
+	}
+
+	database.SecurityEventLogs(r.db).LogAuditEvent(ctx, database.SecurityEventNameSubRepoPathRulesUpdated, nil,
+		newSubRepoPathRulesAuditState(repoID, before),
+		newSubRepoPathRulesAuditState(repoID, rules),
+	)
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+// subRepoPathRulesAuditState is the state of the path rules of a repository
+// recorded in the audit log.
+type subRepoPathRulesAuditState struct {
+	RepoID int32                  `json:"repoID"`
+	Rules  []subRepoPathRuleAudit `json:"rules"`
+}
+
+type subRepoPathRuleAudit struct {
+	Pattern string `json:"pattern"`
+	Action  string `json:"action"`
+	UserID  int32  `json:"userID,omitempty"`
+	OrgID   int32  `json:"orgID,omitempty"`
+}
+
+func newSubRepoPathRulesAuditState(repoID api.RepoID, rules []*database.SubRepoPathRule) subRepoPathRulesAuditState {
+	state := subRepoPathRulesAuditState{RepoID: int32(repoID), Rules: make([]subRepoPathRuleAudit, len(rules))}
+	for i, r := range rules {
+		state.Rules[i] = subRepoPathRuleAudit{Pattern: r.Pattern, Action: string(r.Action), UserID: r.UserID, OrgID: r.OrgID}
+	}
+	return state
+}
+
+func (r *Resolver) SubRepositoryPathRules(ctx context.Context, id graphql.ID) ([]graphqlbackend.SubRepositoryPathRuleResolver, error) {
+	// 🚨 SECURITY: Only site admins can query repository permissions.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(id)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := r.db.SubRepoPathRules().ListByRepo(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.SubRepositoryPathRuleResolver, len(rules))
+	for i, rule := range rules {
+		resolvers[i] = &subRepoPathRuleResolver{db: r.db, rule: rule}
+	}
+	return resolvers, nil
+}
+
+// subRepoPathRulesSupported returns whether path rules can be set for
+// repositories of the given external service type.
+func subRepoPathRulesSupported(serviceType string) bool {
+	kind := extsvc.TypeToKind(serviceType)
+	for _, supported := range database.SubRepoPathRulesSupportedCodeHostKinds {
+		if kind == supported {
+			return true
+		}
+	}
+	return false
+}
+
+type subRepoPathRuleResolver struct {
+	db   database.DB
+	rule *database.SubRepoPathRule
+}
+
+func (r *subRepoPathRuleResolver) Pattern() string { return r.rule.Pattern }
+
+func (r *subRepoPathRuleResolver) Action() string { return strings.ToUpper(string(r.rule.Action)) }
+
+func (r *subRepoPathRuleResolver) User(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.rule.UserID == 0 {
+		return nil, nil
+	}
+	return graphqlbackend.UserByIDInt32(ctx, r.db, r.rule.UserID)
+}
+
+func (r *subRepoPathRuleResolver) Organization(ctx context.Context) (*graphqlbackend.OrgResolver, error) {
+	if r.rule.OrgID == 0 {
+		return nil, nil
+	}
+	return graphqlbackend.OrgByIDInt32(ctx, r.db, r.rule.OrgID)
+}
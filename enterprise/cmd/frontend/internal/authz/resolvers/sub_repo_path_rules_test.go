@@ -0,0 +1,141 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestResolver_SetSubRepositoryPathRules(t *testing.T) {
+	newDB := func(user *types.User, serviceType string) (*edb.MockEnterpriseDB, *database.MockSubRepoPathRuleStore) {
+		users := database.NewStrictMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(user, nil)
+
+		repos := database.NewStrictMockRepoStore()
+		repos.GetFunc.SetDefaultHook(func(ctx context.Context, id api.RepoID) (*types.Repo, error) {
+			return &types.Repo{
+				ID:           id,
+				Name:         "github.com/a/mono",
+				ExternalRepo: api.ExternalRepoSpec{ServiceType: serviceType},
+			}, nil
+		})
+
+		rules := database.NewMockSubRepoPathRuleStore()
+
+		db := edb.NewStrictMockEnterpriseDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.ReposFunc.SetDefaultReturn(repos)
+		db.SubRepoPathRulesFunc.SetDefaultReturn(rules)
+		return db, rules
+	}
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		db, rules := newDB(&types.User{ID: 1}, extsvc.TypeGitHub)
+
+		result, err := (&Resolver{db: db}).SetSubRepositoryPathRules(ctx, &graphqlbackend.SubRepoPathRulesArgs{})
+		if want := backend.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+		if len(rules.SetForRepoFunc.History()) != 0 {
+			t.Fatal("rules were set")
+		}
+	})
+
+	t.Run("unsupported code host", func(t *testing.T) {
+		db, rules := newDB(&types.User{ID: 1, SiteAdmin: true}, extsvc.TypePerforce)
+
+		_, err := (&Resolver{db: db}).SetSubRepositoryPathRules(ctx, &graphqlbackend.SubRepoPathRulesArgs{
+			Repository: graphqlbackend.MarshalRepositoryID(1),
+		})
+		if err == nil {
+			t.Fatal("want error for Perforce repository")
+		}
+		if len(rules.SetForRepoFunc.History()) != 0 {
+			t.Fatal("rules were set")
+		}
+	})
+
+	t.Run("set path rules", func(t *testing.T) {
+		db, rules := newDB(&types.User{ID: 1, SiteAdmin: true}, extsvc.TypeGitLab)
+
+		test := &gqltesting.Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+mutation {
+  setSubRepositoryPathRules(
+    repository: "UmVwb3NpdG9yeTox"
+    rules: [
+      {pattern: "**", action: ALLOW},
+      {pattern: "secret/**", action: DENY},
+      {pattern: "secret/**", action: ALLOW, organization: "T3JnOjI="},
+    ]
+  ) {
+    alwaysNil
+  }
+}
+`,
+			ExpectedResult: `
+{
+	"setSubRepositoryPathRules": {
+		"alwaysNil": null
+	}
+}
+`,
+		}
+		gqltesting.RunTests(t, []*gqltesting.Test{test})
+
+		h := rules.SetForRepoFunc.History()
+		if len(h) != 1 {
+			t.Fatalf("want 1 call to SetForRepo, got %d", len(h))
+		}
+		want := []*database.SubRepoPathRule{
+			{Pattern: "**", Action: database.SubRepoPathRuleAllow},
+			{Pattern: "secret/**", Action: database.SubRepoPathRuleDeny},
+			{Pattern: "secret/**", Action: database.SubRepoPathRuleAllow, OrgID: 2},
+		}
+		if h[0].Arg1 != 1 {
+			t.Errorf("want repo 1, got %d", h[0].Arg1)
+		}
+		if diff := cmp.Diff(want, h[0].Arg2); diff != "" {
+			t.Fatalf("unexpected rules (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		db, rules := newDB(&types.User{ID: 1, SiteAdmin: true}, extsvc.TypeGitHub)
+
+		_, err := (&Resolver{db: db}).SetSubRepositoryPathRules(ctx, &graphqlbackend.SubRepoPathRulesArgs{
+			Repository: graphqlbackend.MarshalRepositoryID(1),
+			Rules: []struct {
+				Pattern      string
+				Action       string
+				User         *graphql.ID
+				Organization *graphql.ID
+			}{{Pattern: "[", Action: "DENY"}},
+		})
+		if err == nil {
+			t.Fatal("want error for invalid pattern")
+		}
+		if len(rules.SetForRepoFunc.History()) != 0 {
+			t.Fatal("rules were set")
+		}
+	})
+}
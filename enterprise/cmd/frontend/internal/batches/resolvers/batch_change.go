@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 )
 
@@ -124,7 +125,7 @@ func (r *batchChangeResolver) SpecCreator(ctx context.Context) (*graphqlbackend.
 }
 
 func (r *batchChangeResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
-	return checkSiteAdminOrSameUser(ctx, r.store.DatabaseDB(), r.batchChange.CreatorID)
+	return checkBatchChangesAdminOrSameUser(ctx, r.store.DatabaseDB(), r.batchChange.CreatorID)
 }
 
 func (r *batchChangeResolver) URL(ctx context.Context) (string, error) {
@@ -188,6 +189,20 @@ func (r *batchChangeResolver) ClosedAt() *graphqlbackend.DateTime {
 	return &graphqlbackend.DateTime{Time: r.batchChange.ClosedAt}
 }
 
+func (r *batchChangeResolver) Schedule() *string {
+	if !r.batchChange.Scheduled() {
+		return nil
+	}
+	return &r.batchChange.Schedule
+}
+
+func (r *batchChangeResolver) NextScheduledRunAt() *graphqlbackend.DateTime {
+	if !r.batchChange.Scheduled() || r.batchChange.Closed() || r.batchChange.NextScheduledRunAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.batchChange.NextScheduledRunAt}
+}
+
 func (r *batchChangeResolver) ChangesetsStats(ctx context.Context) (graphqlbackend.ChangesetsStatsResolver, error) {
 	stats, err := r.store.GetChangesetsStats(ctx, r.batchChange.ID)
 	if err != nil {
@@ -322,6 +337,30 @@ func (r *batchChangeResolver) BulkOperations(
 	}, nil
 }
 
+func (r *batchChangeResolver) ScheduledRuns(
+	ctx context.Context,
+	args *graphqlbackend.ListBatchChangeScheduledRunsArgs,
+) (graphqlbackend.BatchChangeScheduledRunConnectionResolver, error) {
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+	opts := store.ListBatchChangeScheduledRunsOpts{
+		LimitOpts: store.LimitOpts{
+			Limit: int(args.First),
+		},
+		BatchChangeID: r.batchChange.ID,
+	}
+	if args.After != nil {
+		id, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = int64(id)
+	}
+
+	return &batchChangeScheduledRunConnectionResolver{store: r.store, opts: opts}, nil
+}
+
 func (r *batchChangeResolver) BatchSpecs(
 	ctx context.Context,
 	args *graphqlbackend.ListBatchSpecArgs,
@@ -338,7 +377,7 @@ func (r *batchChangeResolver) BatchSpecs(
 		NewestFirst: true,
 	}
 
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB()); err != nil {
+	if err := backend.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), database.PermissionBatchChangesAdmin); err != nil {
 		opts.ExcludeCreatedFromRawNotOwnedByUser = actor.FromContext(ctx).UID
 	}
 
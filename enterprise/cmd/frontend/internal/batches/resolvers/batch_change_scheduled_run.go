@@ -0,0 +1,51 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+type batchChangeScheduledRunResolver struct {
+	store *store.Store
+	run   *btypes.BatchChangeScheduledRun
+}
+
+var _ graphqlbackend.BatchChangeScheduledRunResolver = &batchChangeScheduledRunResolver{}
+
+func (r *batchChangeScheduledRunResolver) State() string {
+	return r.run.State.ToGraphQL()
+}
+
+func (r *batchChangeScheduledRunResolver) BatchSpec(ctx context.Context) (graphqlbackend.BatchSpecResolver, error) {
+	if r.run.BatchSpecID == 0 {
+		return nil, nil
+	}
+
+	batchSpec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: r.run.BatchSpecID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &batchSpecResolver{store: r.store, batchSpec: batchSpec}, nil
+}
+
+func (r *batchChangeScheduledRunResolver) FailureMessage() *string {
+	return r.run.FailureMessage
+}
+
+func (r *batchChangeScheduledRunResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.run.CreatedAt}
+}
+
+func (r *batchChangeScheduledRunResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.run.FinishedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.run.FinishedAt}
+}
@@ -0,0 +1,70 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+type batchChangeScheduledRunConnectionResolver struct {
+	store *store.Store
+	opts  store.ListBatchChangeScheduledRunsOpts
+
+	// Cache results because they are used by multiple fields
+	once sync.Once
+	runs []*btypes.BatchChangeScheduledRun
+	next int64
+	err  error
+}
+
+var _ graphqlbackend.BatchChangeScheduledRunConnectionResolver = &batchChangeScheduledRunConnectionResolver{}
+
+func (r *batchChangeScheduledRunConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountBatchChangeScheduledRuns(ctx, store.CountBatchChangeScheduledRunsOpts{
+		BatchChangeID: r.opts.BatchChangeID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
+func (r *batchChangeScheduledRunConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *batchChangeScheduledRunConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.BatchChangeScheduledRunResolver, error) {
+	runs, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchChangeScheduledRunResolver, 0, len(runs))
+	for _, run := range runs {
+		resolvers = append(resolvers, &batchChangeScheduledRunResolver{store: r.store, run: run})
+	}
+
+	return resolvers, nil
+}
+
+func (r *batchChangeScheduledRunConnectionResolver) compute(ctx context.Context) ([]*btypes.BatchChangeScheduledRun, int64, error) {
+	r.once.Do(func() {
+		r.runs, r.next, r.err = r.store.ListBatchChangeScheduledRuns(ctx, r.opts)
+	})
+
+	return r.runs, r.next, r.err
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/lib/batches"
@@ -239,6 +240,61 @@ func (r *batchSpecResolver) DiffStat(ctx context.Context) (*graphqlbackend.DiffS
 	return totalStat, nil
 }
 
+func (r *batchSpecResolver) DiffAnalytics(ctx context.Context) (graphqlbackend.BatchSpecDiffAnalyticsResolver, error) {
+	state, err := r.computeState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if state != btypes.BatchSpecStateCompleted {
+		return nil, nil
+	}
+
+	scoring, err := btypes.NewRiskScoring(conf.Get().BatchChangesRiskScoring)
+	if err != nil {
+		return nil, err
+	}
+
+	specsConnection := &changesetSpecConnectionResolver{
+		store: r.store,
+		opts:  store.ListChangesetSpecsOpts{BatchSpecID: r.batchSpec.ID},
+	}
+	specs, err := specsConnection.Nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	visibleSpecs := make([]*btypes.ChangesetSpec, 0, len(specs))
+	specResolvers := make(map[int64]graphqlbackend.VisibleChangesetSpecResolver, len(specs))
+	for _, spec := range specs {
+		// Changeset specs in repositories the user can't see are left out of
+		// the analytics, so that they don't leak anything about the diff.
+		visible, ok := spec.ToVisibleChangesetSpec()
+		if !ok {
+			continue
+		}
+
+		resolver, ok := spec.(*changesetSpecResolver)
+		if !ok {
+			// This should never happen.
+			continue
+		}
+
+		visibleSpecs = append(visibleSpecs, resolver.changesetSpec)
+		specResolvers[resolver.changesetSpec.ID] = visible
+	}
+
+	analytics, err := btypes.AnalyzeBatchSpecDiffs(visibleSpecs, scoring)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecDiffAnalyticsResolver{
+		analytics:     analytics,
+		scoring:       scoring,
+		specResolvers: specResolvers,
+	}, nil
+}
+
 func (r *batchSpecResolver) AppliesToBatchChange(ctx context.Context) (graphqlbackend.BatchChangeResolver, error) {
 	svc := service.New(r.store)
 	batchChange, err := svc.GetBatchChangeMatchingBatchSpec(ctx, r.batchSpec)
@@ -611,7 +667,7 @@ func (r *batchSpecResolver) computeState(ctx context.Context) (btypes.BatchSpecS
 
 func (r *batchSpecResolver) computeCanAdminister(ctx context.Context) (bool, error) {
 	r.canAdministerOnce.Do(func() {
-		r.canAdminister, r.canAdministerErr = checkSiteAdminOrSameUser(ctx, r.store.DatabaseDB(), r.batchSpec.UserID)
+		r.canAdminister, r.canAdministerErr = checkBatchChangesAdminOrSameUser(ctx, r.store.DatabaseDB(), r.batchSpec.UserID)
 	})
 	return r.canAdminister, r.canAdministerErr
 }
@@ -0,0 +1,116 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+var _ graphqlbackend.BatchSpecDiffAnalyticsResolver = &batchSpecDiffAnalyticsResolver{}
+
+type batchSpecDiffAnalyticsResolver struct {
+	analytics *btypes.BatchSpecDiffAnalytics
+	scoring   btypes.RiskScoring
+
+	// specResolvers are the resolvers of the analyzed changeset specs, by ID.
+	specResolvers map[int64]graphqlbackend.VisibleChangesetSpecResolver
+}
+
+func (r *batchSpecDiffAnalyticsResolver) LinesChanged() graphqlbackend.DiffDistributionResolver {
+	return &diffDistributionResolver{r.analytics.LinesChanged}
+}
+
+func (r *batchSpecDiffAnalyticsResolver) FilesChanged() graphqlbackend.DiffDistributionResolver {
+	return &diffDistributionResolver{r.analytics.FilesChanged}
+}
+
+func (r *batchSpecDiffAnalyticsResolver) AverageRiskScore() float64 {
+	return r.analytics.AverageRiskScore
+}
+
+func (r *batchSpecDiffAnalyticsResolver) MaxRiskScore() float64 {
+	return r.analytics.MaxRiskScore
+}
+
+func (r *batchSpecDiffAnalyticsResolver) HighRiskThreshold() float64 {
+	return r.scoring.HighRiskThreshold
+}
+
+func (r *batchSpecDiffAnalyticsResolver) HighRiskCount() int32 {
+	return r.analytics.HighRiskCount
+}
+
+func (r *batchSpecDiffAnalyticsResolver) LargestDiffs(args *graphqlbackend.BatchSpecDiffAnalyticsArgs) []graphqlbackend.ChangesetSpecDiffAnalyticsResolver {
+	return r.resolvers(r.analytics.LargestDiffs(int(args.First)))
+}
+
+func (r *batchSpecDiffAnalyticsResolver) RiskiestChangesetSpecs(args *graphqlbackend.BatchSpecDiffAnalyticsArgs) []graphqlbackend.ChangesetSpecDiffAnalyticsResolver {
+	specs := r.analytics.ChangesetSpecs
+	if args.First >= 0 && int(args.First) < len(specs) {
+		specs = specs[:args.First]
+	}
+	return r.resolvers(specs)
+}
+
+func (r *batchSpecDiffAnalyticsResolver) UnusualChangesetSpecs(args *graphqlbackend.BatchSpecDiffAnalyticsArgs) []graphqlbackend.ChangesetSpecDiffAnalyticsResolver {
+	return r.resolvers(r.analytics.Unusual(int(args.First)))
+}
+
+func (r *batchSpecDiffAnalyticsResolver) resolvers(specs []*btypes.ChangesetSpecDiffAnalytics) []graphqlbackend.ChangesetSpecDiffAnalyticsResolver {
+	resolvers := make([]graphqlbackend.ChangesetSpecDiffAnalyticsResolver, 0, len(specs))
+	for _, a := range specs {
+		resolvers = append(resolvers, &changesetSpecDiffAnalyticsResolver{
+			analytics:    a,
+			specResolver: r.specResolvers[a.ChangesetSpec.ID],
+		})
+	}
+	return resolvers
+}
+
+type diffDistributionResolver struct {
+	distribution btypes.DiffDistribution
+}
+
+func (r *diffDistributionResolver) Total() int32  { return r.distribution.Total }
+func (r *diffDistributionResolver) Median() int32 { return r.distribution.Median }
+func (r *diffDistributionResolver) P90() int32    { return r.distribution.P90 }
+func (r *diffDistributionResolver) P99() int32    { return r.distribution.P99 }
+func (r *diffDistributionResolver) Max() int32    { return r.distribution.Max }
+
+var _ graphqlbackend.ChangesetSpecDiffAnalyticsResolver = &changesetSpecDiffAnalyticsResolver{}
+
+type changesetSpecDiffAnalyticsResolver struct {
+	analytics    *btypes.ChangesetSpecDiffAnalytics
+	specResolver graphqlbackend.VisibleChangesetSpecResolver
+}
+
+func (r *changesetSpecDiffAnalyticsResolver) ChangesetSpec() graphqlbackend.VisibleChangesetSpecResolver {
+	return r.specResolver
+}
+
+func (r *changesetSpecDiffAnalyticsResolver) LinesChanged() int32 {
+	return r.analytics.LinesChanged()
+}
+
+func (r *changesetSpecDiffAnalyticsResolver) FilesChanged() int32 {
+	return r.analytics.FilesChanged
+}
+
+func (r *changesetSpecDiffAnalyticsResolver) BinaryFiles() []string {
+	return r.analytics.BinaryFiles
+}
+
+func (r *changesetSpecDiffAnalyticsResolver) DeletedFiles() []string {
+	return r.analytics.DeletedFiles
+}
+
+func (r *changesetSpecDiffAnalyticsResolver) Lockfiles() []string {
+	return r.analytics.Lockfiles
+}
+
+func (r *changesetSpecDiffAnalyticsResolver) RiskScore() float64 {
+	return r.analytics.RiskScore
+}
+
+func (r *changesetSpecDiffAnalyticsResolver) HighRisk() bool {
+	return r.analytics.HighRisk
+}
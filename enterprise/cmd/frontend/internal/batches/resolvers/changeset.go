@@ -22,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/config"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
@@ -166,12 +167,12 @@ func (r *changesetResolver) BatchChanges(ctx context.Context, args *graphqlbacke
 		opts.Cursor = cursor
 	}
 
-	authErr := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB())
-	if authErr != nil && authErr != backend.ErrMustBeSiteAdmin {
-		return nil, err
+	authErr := backend.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), database.PermissionBatchChangesAdmin)
+	if authErr != nil && !errors.HasType(authErr, &backend.PermissionError{}) {
+		return nil, authErr
 	}
-	isSiteAdmin := authErr != backend.ErrMustBeSiteAdmin
-	if !isSiteAdmin {
+	isBatchChangesAdmin := authErr == nil
+	if !isBatchChangesAdmin {
 		if args.ViewerCanAdminister != nil && *args.ViewerCanAdminister {
 			actor := actor.FromContext(ctx)
 			opts.CreatorID = actor.UID
@@ -386,6 +387,27 @@ func (r *changesetResolver) CheckState() *string {
 	return &state
 }
 
+func (r *changesetResolver) AutoMergeBlockedReason() *string {
+	if r.changeset.AutoMergeBlockedReason == "" {
+		return nil
+	}
+
+	switch r.changeset.ExternalState {
+	case btypes.ChangesetExternalStateOpen, btypes.ChangesetExternalStateDraft:
+		return &r.changeset.AutoMergeBlockedReason
+	default:
+		return nil
+	}
+}
+
+func (r *changesetResolver) Rebasing() bool {
+	return r.changeset.Rebasing
+}
+
+func (r *changesetResolver) RebaseFailureMessage() *string {
+	return r.changeset.RebaseFailureMessage
+}
+
 func (r *changesetResolver) Error() *string { return r.changeset.FailureMessage }
 
 func (r *changesetResolver) SyncerError() *string { return r.changeset.SyncErrorMessage }
@@ -368,3 +368,12 @@ func (c *changesetSpecDeltaResolver) AuthorNameChanged() bool {
 func (c *changesetSpecDeltaResolver) AuthorEmailChanged() bool {
 	return c.delta.AuthorEmailChanged
 }
+func (c *changesetSpecDeltaResolver) LabelsChanged() bool {
+	return c.delta.LabelsChanged
+}
+func (c *changesetSpecDeltaResolver) AssigneesChanged() bool {
+	return c.delta.AssigneesChanged
+}
+func (c *changesetSpecDeltaResolver) MilestoneChanged() bool {
+	return c.delta.MilestoneChanged
+}
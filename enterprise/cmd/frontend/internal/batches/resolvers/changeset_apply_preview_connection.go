@@ -112,6 +112,8 @@ type changesetApplyPreviewConnectionStatsResolver struct {
 	sleep        int32
 	detach       int32
 	archive      int32
+	merge        int32
+	reexecute    int32
 
 	added    int32
 	modified int32
@@ -154,6 +156,12 @@ func (r *changesetApplyPreviewConnectionStatsResolver) Detach() int32 {
 func (r *changesetApplyPreviewConnectionStatsResolver) Archive() int32 {
 	return r.archive
 }
+func (r *changesetApplyPreviewConnectionStatsResolver) Merge() int32 {
+	return r.merge
+}
+func (r *changesetApplyPreviewConnectionStatsResolver) Reexecute() int32 {
+	return r.reexecute
+}
 func (r *changesetApplyPreviewConnectionStatsResolver) Added() int32 {
 	return r.added
 }
@@ -227,6 +235,10 @@ func (r *changesetApplyPreviewConnectionResolver) Stats(ctx context.Context) (gr
 				stats.detach++
 			case string(btypes.ReconcilerOperationArchive):
 				stats.archive++
+			case string(btypes.ReconcilerOperationMerge):
+				stats.merge++
+			case string(btypes.ReconcilerOperationReexecute):
+				stats.reexecute++
 			}
 		}
 	}
@@ -90,6 +90,16 @@ func (e ErrDuplicateCredential) Extensions() map[string]interface{} {
 	return map[string]interface{}{"code": "ErrDuplicateCredential"}
 }
 
+type ErrDuplicateSigningKey struct{}
+
+func (e ErrDuplicateSigningKey) Error() string {
+	return "a signing key already exists"
+}
+
+func (e ErrDuplicateSigningKey) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": "ErrDuplicateSigningKey"}
+}
+
 type ErrVerifyCredentialFailed struct {
 	SourceErr error
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go"
@@ -19,6 +20,7 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/commitsigning"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/deviceid"
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
@@ -224,7 +226,7 @@ func (r *Resolver) batchSpecByID(ctx context.Context, id graphql.ID) (graphqlbac
 	}
 
 	opts := store.GetBatchSpecOpts{RandID: batchSpecRandID}
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB()); err != nil {
+	if err := backend.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), database.PermissionBatchChangesAdmin); err != nil {
 		opts.ExcludeCreatedFromRawNotOwnedByUser = actor.FromContext(ctx).UID
 	}
 
@@ -304,7 +306,7 @@ func (r *Resolver) batchChangesUserCredentialByID(ctx context.Context, id int64)
 
 func (r *Resolver) batchChangesSiteCredentialByID(ctx context.Context, id int64) (graphqlbackend.BatchChangesCredentialResolver, error) {
 	// Todo: Is this required? Should everyone be able to see there are _some_ credentials?
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB()); err != nil {
+	if err := backend.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), database.PermissionBatchChangesAdmin); err != nil {
 		return nil, err
 	}
 
@@ -695,12 +697,12 @@ func (r *Resolver) BatchChanges(ctx context.Context, args *graphqlbackend.ListBa
 		opts.Cursor = cursor
 	}
 
-	authErr := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB())
-	if authErr != nil && authErr != backend.ErrMustBeSiteAdmin {
+	authErr := backend.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), database.PermissionBatchChangesAdmin)
+	if authErr != nil && !errors.HasType(authErr, &backend.PermissionError{}) {
 		return nil, authErr
 	}
-	isSiteAdmin := authErr != backend.ErrMustBeSiteAdmin
-	if !isSiteAdmin {
+	isBatchChangesAdmin := authErr == nil
+	if !isBatchChangesAdmin {
 		actor := actor.FromContext(ctx)
 		if args.ViewerCanAdminister != nil && *args.ViewerCanAdminister {
 			opts.CreatorID = actor.UID
@@ -957,6 +959,41 @@ func (r *Resolver) CloseBatchChange(ctx context.Context, args *graphqlbackend.Cl
 	return &batchChangeResolver{store: r.store, batchChange: batchChange}, nil
 }
 
+func (r *Resolver) SetBatchChangeSchedule(ctx context.Context, args *graphqlbackend.SetBatchChangeScheduleArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetBatchChangeSchedule", fmt.Sprintf("BatchChange: %q", args.BatchChange))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling batch change id")
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	var schedule string
+	if args.Schedule != nil {
+		schedule = *args.Schedule
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: SetBatchChangeSchedule checks whether current user is authorized.
+	batchChange, err := svc.SetBatchChangeSchedule(ctx, batchChangeID, schedule)
+	if err != nil {
+		return nil, errors.Wrap(err, "setting batch change schedule")
+	}
+
+	return &batchChangeResolver{store: r.store, batchChange: batchChange}, nil
+}
+
 func (r *Resolver) SyncChangeset(ctx context.Context, args *graphqlbackend.SyncChangesetArgs) (_ *graphqlbackend.EmptyResponse, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.SyncChangeset", fmt.Sprintf("Changeset: %q", args.Changeset))
 	defer func() {
@@ -1046,14 +1083,22 @@ func (r *Resolver) CreateBatchChangesCredential(ctx context.Context, args *graph
 		return nil, errors.New("empty credential not allowed")
 	}
 
+	var username string
+	if args.Username != nil {
+		username = *args.Username
+	}
+	if kind == extsvc.KindBitbucketCloud && username == "" {
+		return nil, errors.New("a username is required for Bitbucket Cloud credentials")
+	}
+
 	if userID != 0 {
-		return r.createBatchChangesUserCredential(ctx, args.ExternalServiceURL, extsvc.KindToType(kind), userID, args.Credential)
+		return r.createBatchChangesUserCredential(ctx, args.ExternalServiceURL, extsvc.KindToType(kind), userID, username, args.Credential)
 	}
 
-	return r.createBatchChangesSiteCredential(ctx, args.ExternalServiceURL, extsvc.KindToType(kind), args.Credential)
+	return r.createBatchChangesSiteCredential(ctx, args.ExternalServiceURL, extsvc.KindToType(kind), username, args.Credential)
 }
 
-func (r *Resolver) createBatchChangesUserCredential(ctx context.Context, externalServiceURL, externalServiceType string, userID int32, credential string) (graphqlbackend.BatchChangesCredentialResolver, error) {
+func (r *Resolver) createBatchChangesUserCredential(ctx context.Context, externalServiceURL, externalServiceType string, userID int32, username, credential string) (graphqlbackend.BatchChangesCredentialResolver, error) {
 	// 🚨 SECURITY: Check that the requesting user can create the credential.
 	if err := backend.CheckSiteAdminOrSameUser(ctx, r.store.DatabaseDB(), userID); err != nil {
 		return nil, err
@@ -1074,7 +1119,7 @@ func (r *Resolver) createBatchChangesUserCredential(ctx context.Context, externa
 		return nil, ErrDuplicateCredential{}
 	}
 
-	a, err := r.generateAuthenticatorForCredential(ctx, externalServiceType, externalServiceURL, credential)
+	a, err := r.generateAuthenticatorForCredential(ctx, externalServiceType, externalServiceURL, username, credential)
 	if err != nil {
 		return nil, err
 	}
@@ -1086,10 +1131,10 @@ func (r *Resolver) createBatchChangesUserCredential(ctx context.Context, externa
 	return &batchChangesUserCredentialResolver{credential: cred}, nil
 }
 
-func (r *Resolver) createBatchChangesSiteCredential(ctx context.Context, externalServiceURL, externalServiceType string, credential string) (graphqlbackend.BatchChangesCredentialResolver, error) {
+func (r *Resolver) createBatchChangesSiteCredential(ctx context.Context, externalServiceURL, externalServiceType, username, credential string) (graphqlbackend.BatchChangesCredentialResolver, error) {
 	// 🚨 SECURITY: Check that a site credential can only be created
-	// by a site-admin.
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB()); err != nil {
+	// by a site-admin or a batch changes admin.
+	if err := backend.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), database.PermissionBatchChangesAdmin); err != nil {
 		return nil, err
 	}
 
@@ -1105,7 +1150,7 @@ func (r *Resolver) createBatchChangesSiteCredential(ctx context.Context, externa
 		return nil, ErrDuplicateCredential{}
 	}
 
-	a, err := r.generateAuthenticatorForCredential(ctx, externalServiceType, externalServiceURL, credential)
+	a, err := r.generateAuthenticatorForCredential(ctx, externalServiceType, externalServiceURL, username, credential)
 	if err != nil {
 		return nil, err
 	}
@@ -1120,7 +1165,7 @@ func (r *Resolver) createBatchChangesSiteCredential(ctx context.Context, externa
 	return &batchChangesSiteCredentialResolver{credential: cred}, nil
 }
 
-func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, externalServiceType, externalServiceURL, credential string) (auth.Authenticator, error) {
+func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, externalServiceType, externalServiceURL, username, credential string) (auth.Authenticator, error) {
 	svc := service.New(r.store)
 
 	var a auth.Authenticator
@@ -1128,7 +1173,8 @@ func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, exter
 	if err != nil {
 		return nil, err
 	}
-	if externalServiceType == extsvc.TypeBitbucketServer {
+	switch externalServiceType {
+	case extsvc.TypeBitbucketServer:
 		// We need to fetch the username for the token, as just an OAuth token isn't enough for some reason..
 		username, err := svc.FetchUsernameForBitbucketServerToken(ctx, externalServiceURL, externalServiceType, credential)
 		if err != nil {
@@ -1143,7 +1189,16 @@ func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, exter
 			PublicKey:  keypair.PublicKey,
 			Passphrase: keypair.Passphrase,
 		}
-	} else {
+	case extsvc.TypeBitbucketCloud:
+		// Bitbucket Cloud app passwords can only be used together with the
+		// username of their owner.
+		a = &auth.BasicAuthWithSSH{
+			BasicAuth:  auth.BasicAuth{Username: username, Password: credential},
+			PrivateKey: keypair.PrivateKey,
+			PublicKey:  keypair.PublicKey,
+			Passphrase: keypair.Passphrase,
+		}
+	default:
 		a = &auth.OAuthBearerTokenWithSSH{
 			OAuthBearerToken: auth.OAuthBearerToken{Token: credential},
 			PrivateKey:       keypair.PrivateKey,
@@ -1207,7 +1262,7 @@ func (r *Resolver) deleteBatchChangesUserCredential(ctx context.Context, credent
 
 func (r *Resolver) deleteBatchChangesSiteCredential(ctx context.Context, credentialDBID int64) (*graphqlbackend.EmptyResponse, error) {
 	// 🚨 SECURITY: Check that the requesting user may delete the credential.
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB()); err != nil {
+	if err := backend.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), database.PermissionBatchChangesAdmin); err != nil {
 		return nil, err
 	}
 
@@ -1219,6 +1274,144 @@ func (r *Resolver) deleteBatchChangesSiteCredential(ctx context.Context, credent
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) CreateBatchChangesSigningKey(ctx context.Context, args *graphqlbackend.CreateBatchChangesSigningKeyArgs) (_ graphqlbackend.BatchChangesSigningKeyResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchChangesSigningKey", fmt.Sprintf("Format: %q", args.Format))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	userID, err := r.checkCanAdministerSigningKey(ctx, args.User)
+	if err != nil {
+		return nil, err
+	}
+
+	format := commitsigning.Format(strings.ToLower(args.Format))
+	if !format.Valid() {
+		return nil, errors.Errorf("invalid signing key format %q", args.Format)
+	}
+	if args.PrivateKey == "" {
+		return nil, errors.New("empty private key not allowed")
+	}
+	if args.CommitterName == "" || args.CommitterEmail == "" {
+		return nil, errors.New("a committer name and email are required")
+	}
+
+	// Throw error documented in schema.graphql.
+	existing, err := r.store.GetSigningKey(ctx, store.GetSigningKeyOpts{UserID: userID, Site: userID == 0})
+	if err != nil && err != store.ErrNoResults {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrDuplicateSigningKey{}
+	}
+
+	var passphrase string
+	if args.Passphrase != nil {
+		passphrase = *args.Passphrase
+	}
+
+	key := &btypes.SigningKey{
+		UserID:         userID,
+		Format:         format,
+		CommitterName:  args.CommitterName,
+		CommitterEmail: args.CommitterEmail,
+	}
+	if err := r.store.CreateSigningKey(ctx, key, args.PrivateKey, passphrase); err != nil {
+		return nil, err
+	}
+
+	return &batchChangesSigningKeyResolver{key: key}, nil
+}
+
+func (r *Resolver) DeleteBatchChangesSigningKey(ctx context.Context, args *graphqlbackend.DeleteBatchChangesSigningKeyArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchChangesSigningKey", fmt.Sprintf("SigningKey: %q", args.SigningKey))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalBatchChangesSigningKeyID(args.SigningKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	key, err := r.store.GetSigningKey(ctx, store.GetSigningKeyOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	var user *graphql.ID
+	if !key.IsSiteKey() {
+		userID := graphqlbackend.MarshalUserID(key.UserID)
+		user = &userID
+	}
+	if _, err := r.checkCanAdministerSigningKey(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// This also fails if the signing key was not found.
+	if err := r.store.DeleteSigningKey(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) BatchChangesSigningKey(ctx context.Context, args *graphqlbackend.BatchChangesSigningKeyArgs) (graphqlbackend.BatchChangesSigningKeyResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	userID, err := r.checkCanAdministerSigningKey(ctx, args.User)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := r.store.GetSigningKey(ctx, store.GetSigningKeyOpts{UserID: userID, Site: userID == 0})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &batchChangesSigningKeyResolver{key: key}, nil
+}
+
+// checkCanAdministerSigningKey checks that the current user may administer the
+// signing key of the given user, or the site-wide signing key if user is nil,
+// and returns the ID of the given user.
+func (r *Resolver) checkCanAdministerSigningKey(ctx context.Context, user *graphql.ID) (int32, error) {
+	if user == nil {
+		// 🚨 SECURITY: Check that the site-wide signing key can only be
+		// administered by a site-admin or a batch changes admin.
+		return 0, backend.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), database.PermissionBatchChangesAdmin)
+	}
+
+	userID, err := graphqlbackend.UnmarshalUserID(*user)
+	if err != nil {
+		return 0, err
+	}
+
+	if userID == 0 {
+		return 0, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: Check that the requesting user can administer the signing key.
+	return userID, backend.CheckSiteAdminOrSameUser(ctx, r.store.DatabaseDB(), userID)
+}
+
 func (r *Resolver) DetachChangesets(ctx context.Context, args *graphqlbackend.DetachChangesetsArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.DetachChangesets", fmt.Sprintf("BatchChange: %q, len(Changesets): %d", args.BatchChange, len(args.Changesets)))
 	defer func() {
@@ -1465,7 +1658,7 @@ func (r *Resolver) BatchSpecs(ctx context.Context, args *graphqlbackend.ListBatc
 	// 🚨 SECURITY: If the user is not an admin, we don't want to include
 	// BatchSpecs that were created with CreateBatchSpecFromRaw and not owned
 	// by the user
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB()); err != nil {
+	if err := backend.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), database.PermissionBatchChangesAdmin); err != nil {
 		opts.ExcludeCreatedFromRawNotOwnedByUser = actor.FromContext(ctx).UID
 	}
 
@@ -1774,10 +1967,10 @@ func parseBatchChangeState(s *string) (btypes.BatchChangeState, error) {
 	}
 }
 
-func checkSiteAdminOrSameUser(ctx context.Context, db database.DB, userID int32) (bool, error) {
-	// 🚨 SECURITY: Only site admins or the authors of a batch change have batch change
-	// admin rights.
-	if err := backend.CheckSiteAdminOrSameUser(ctx, db, userID); err != nil {
+func checkBatchChangesAdminOrSameUser(ctx context.Context, db database.DB, userID int32) (bool, error) {
+	// 🚨 SECURITY: Only site admins, batch changes admins or the authors of a
+	// batch change have batch change admin rights.
+	if err := backend.CheckPermissionOrSameUser(ctx, db, database.PermissionBatchChangesAdmin, userID); err != nil {
 		if errors.HasType(err, &backend.InsufficientAuthorizationError{}) {
 			return false, nil
 		}
@@ -29,6 +29,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -1356,6 +1357,142 @@ mutation($batchChangesCredential: ID!) {
 }
 `
 
+func TestBatchChangesSigningKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := database.NewDB(dbtest.NewDB(t))
+
+	adminID := ct.CreateTestUser(t, db, true).ID
+	userID := ct.CreateTestUser(t, db, false).ID
+
+	cstore := store.New(db, &observation.TestContext, nil)
+
+	r := &Resolver{store: cstore}
+	s, err := newSchema(database.NewDB(db), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keypair, err := encryption.GenerateRSAKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type signingKey struct {
+		ID             string
+		Format         string
+		PublicKey      string
+		CommitterEmail string
+		IsSiteKey      bool
+	}
+
+	createInput := func(user interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"user":           user,
+			"format":         "SSH",
+			"privateKey":     keypair.PrivateKey,
+			"passphrase":     keypair.Passphrase,
+			"committerName":  "Batch Changes",
+			"committerEmail": "batch-changes@example.com",
+		}
+	}
+
+	userCtx := actor.WithActor(ctx, actor.FromUser(userID))
+	adminCtx := actor.WithActor(ctx, actor.FromUser(adminID))
+
+	var userKeyID string
+	t.Run("User signing key", func(t *testing.T) {
+		input := createInput(graphqlbackend.MarshalUserID(userID))
+
+		var response struct{ CreateBatchChangesSigningKey signingKey }
+		apitest.MustExec(userCtx, t, s, input, &response, mutationCreateSigningKey)
+
+		have := response.CreateBatchChangesSigningKey
+		if have.ID == "" || have.Format != "SSH" || have.IsSiteKey {
+			t.Fatalf("wrong signing key created: %+v", have)
+		}
+		if have.PublicKey != keypair.PublicKey {
+			t.Fatalf("wrong public key. want=%q, have=%q", keypair.PublicKey, have.PublicKey)
+		}
+		userKeyID = have.ID
+
+		// Second time it should fail.
+		errs := apitest.Exec(userCtx, t, s, input, &response, mutationCreateSigningKey)
+		if len(errs) != 1 {
+			t.Fatalf("expected single error, but got %d", len(errs))
+		}
+		if have, want := errs[0].Extensions["code"], "ErrDuplicateSigningKey"; have != want {
+			t.Fatalf("wrong error code. want=%q, have=%q", want, have)
+		}
+
+		var queryResponse struct{ BatchChangesSigningKey *signingKey }
+		apitest.MustExec(userCtx, t, s, map[string]interface{}{"user": graphqlbackend.MarshalUserID(userID)}, &queryResponse, queryBatchChangesSigningKey)
+		if queryResponse.BatchChangesSigningKey == nil || queryResponse.BatchChangesSigningKey.ID != userKeyID {
+			t.Fatalf("wrong signing key returned: %+v", queryResponse.BatchChangesSigningKey)
+		}
+	})
+
+	t.Run("Site signing key", func(t *testing.T) {
+		input := createInput(nil)
+
+		var response struct{ CreateBatchChangesSigningKey signingKey }
+		if errs := apitest.Exec(userCtx, t, s, input, &response, mutationCreateSigningKey); len(errs) == 0 {
+			t.Fatal("no error for non-admin creating the site signing key")
+		}
+
+		apitest.MustExec(adminCtx, t, s, input, &response, mutationCreateSigningKey)
+		if !response.CreateBatchChangesSigningKey.IsSiteKey {
+			t.Fatalf("signing key is not a site key: %+v", response.CreateBatchChangesSigningKey)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		input := map[string]interface{}{"signingKey": userKeyID}
+
+		var response struct{ DeleteBatchChangesSigningKey apitest.EmptyResponse }
+		apitest.MustExec(userCtx, t, s, input, &response, mutationDeleteSigningKey)
+
+		var queryResponse struct{ BatchChangesSigningKey *signingKey }
+		apitest.MustExec(userCtx, t, s, map[string]interface{}{"user": graphqlbackend.MarshalUserID(userID)}, &queryResponse, queryBatchChangesSigningKey)
+		if queryResponse.BatchChangesSigningKey != nil {
+			t.Fatalf("signing key was not deleted: %+v", queryResponse.BatchChangesSigningKey)
+		}
+	})
+}
+
+const mutationCreateSigningKey = `
+mutation($user: ID, $format: BatchChangesSigningKeyFormat!, $privateKey: String!, $passphrase: String, $committerName: String!, $committerEmail: String!) {
+  createBatchChangesSigningKey(user: $user, format: $format, privateKey: $privateKey, passphrase: $passphrase, committerName: $committerName, committerEmail: $committerEmail) {
+    id
+    format
+    publicKey
+    committerEmail
+    isSiteKey
+  }
+}
+`
+
+const mutationDeleteSigningKey = `
+mutation($signingKey: ID!) {
+  deleteBatchChangesSigningKey(signingKey: $signingKey) { alwaysNil }
+}
+`
+
+const queryBatchChangesSigningKey = `
+query($user: ID) {
+  batchChangesSigningKey(user: $user) {
+    id
+    format
+    publicKey
+    committerEmail
+    isSiteKey
+  }
+}
+`
+
 func TestCreateChangesetComments(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
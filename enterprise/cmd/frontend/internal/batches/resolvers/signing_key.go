@@ -0,0 +1,56 @@
+package resolvers
+
+import (
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+const batchChangesSigningKeyIDKind = "BatchChangesSigningKey"
+
+func marshalBatchChangesSigningKeyID(id int64) graphql.ID {
+	return relay.MarshalID(batchChangesSigningKeyIDKind, id)
+}
+
+func unmarshalBatchChangesSigningKeyID(id graphql.ID) (signingKeyID int64, err error) {
+	err = relay.UnmarshalSpec(id, &signingKeyID)
+	return
+}
+
+type batchChangesSigningKeyResolver struct {
+	key *btypes.SigningKey
+}
+
+var _ graphqlbackend.BatchChangesSigningKeyResolver = &batchChangesSigningKeyResolver{}
+
+func (r *batchChangesSigningKeyResolver) ID() graphql.ID {
+	return marshalBatchChangesSigningKeyID(r.key.ID)
+}
+
+func (r *batchChangesSigningKeyResolver) Format() string {
+	return strings.ToUpper(string(r.key.Format))
+}
+
+func (r *batchChangesSigningKeyResolver) PublicKey() string {
+	return r.key.PublicKey
+}
+
+func (r *batchChangesSigningKeyResolver) CommitterName() string {
+	return r.key.CommitterName
+}
+
+func (r *batchChangesSigningKeyResolver) CommitterEmail() string {
+	return r.key.CommitterEmail
+}
+
+func (r *batchChangesSigningKeyResolver) IsSiteKey() bool {
+	return r.key.IsSiteKey()
+}
+
+func (r *batchChangesSigningKeyResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.key.CreatedAt}
+}
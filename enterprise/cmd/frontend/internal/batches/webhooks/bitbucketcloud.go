@@ -0,0 +1,218 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+
+	fewebhooks "github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// bitbucketCloudSecretParam is the query parameter carrying the webhook
+// secret. Bitbucket Cloud doesn't sign webhook payloads, so the secret is part
+// of the webhook URL instead.
+const bitbucketCloudSecretParam = "secret"
+
+type BitbucketCloudWebhook struct {
+	*Webhook
+}
+
+func NewBitbucketCloudWebhook(store *store.Store) *BitbucketCloudWebhook {
+	return &BitbucketCloudWebhook{
+		Webhook: &Webhook{store, extsvc.TypeBitbucketCloud},
+	}
+}
+
+func (h *BitbucketCloudWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e, extSvc, hErr := h.parseEvent(r)
+	if hErr != nil {
+		respond(w, hErr.code, hErr)
+		return
+	}
+
+	fewebhooks.SetExternalServiceID(r.Context(), extSvc.ID)
+
+	// 🚨 SECURITY: now that the shared secret has been validated, we can use an
+	// internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	externalServiceID, err := extractExternalServiceID(extSvc)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	prs, ev, err := h.convertEvent(ctx, externalServiceID, e)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	m := new(multierror.Error)
+	for _, pr := range prs {
+		if pr == (PR{}) {
+			log15.Warn("Dropping Bitbucket Cloud webhook event", "type", fmt.Sprintf("%T", e))
+			continue
+		}
+
+		err := h.upsertChangesetEvent(ctx, externalServiceID, pr, ev)
+		if err != nil {
+			m = multierror.Append(m, err)
+		}
+	}
+	if m.ErrorOrNil() != nil {
+		respond(w, http.StatusInternalServerError, m)
+	}
+}
+
+func (h *BitbucketCloudWebhook) parseEvent(r *http.Request) (interface{}, *types.ExternalService, *httpError) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	secret := r.URL.Query().Get(bitbucketCloudSecretParam)
+	if secret == "" {
+		return nil, nil, &httpError{http.StatusUnauthorized, errors.New("missing webhook secret")}
+	}
+
+	externalServiceID, err := strconv.ParseInt(r.URL.Query().Get(extsvc.IDParam), 10, 64)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "invalid external service id")}
+	}
+
+	es, err := h.Store.ExternalServices().List(r.Context(), database.ExternalServicesListOptions{
+		Kinds: []string{extsvc.KindBitbucketCloud},
+		IDs:   []int64{externalServiceID},
+	})
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	var extSvc *types.ExternalService
+	for _, e := range es {
+		c, _ := e.Configuration()
+		con, ok := c.(*schema.BitbucketCloudConnection)
+		if !ok {
+			continue
+		}
+
+		for _, hook := range con.Webhooks {
+			if subtle.ConstantTimeCompare([]byte(hook.Secret), []byte(secret)) == 1 {
+				extSvc = e
+				break
+			}
+		}
+	}
+
+	if extSvc == nil {
+		return nil, nil, &httpError{http.StatusUnauthorized, errors.New("invalid webhook secret")}
+	}
+
+	e, err := bitbucketcloud.ParseWebhookEvent(bitbucketcloud.WebhookEventType(r), payload)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "parsing webhook")}
+	}
+	return e, extSvc, nil
+}
+
+func (h *BitbucketCloudWebhook) convertEvent(ctx context.Context, externalServiceID string, theirs interface{}) (prs []PR, ours keyer, err error) {
+	log15.Debug("Bitbucket Cloud webhook received", "type", fmt.Sprintf("%T", theirs))
+
+	prFor := func(e bitbucketcloud.PullRequestEvent) []PR {
+		return []PR{{
+			ID:             int64(e.PullRequest.ID),
+			RepoExternalID: e.PullRequest.Destination.Repo.UUID,
+		}}
+	}
+
+	switch e := theirs.(type) {
+	case *bitbucketcloud.PullRequestApprovedEvent:
+		return prFor(e.PullRequestEvent), participantStatusEvent(e.Approval, bitbucketcloud.ParticipantActionApproved), nil
+	case *bitbucketcloud.PullRequestUnapprovedEvent:
+		return prFor(e.PullRequestEvent), participantStatusEvent(e.Approval, bitbucketcloud.ParticipantActionUnapproved), nil
+	case *bitbucketcloud.PullRequestChangesRequestCreatedEvent:
+		return prFor(e.PullRequestEvent), participantStatusEvent(e.ChangesRequest, bitbucketcloud.ParticipantActionChangesRequestCreated), nil
+	case *bitbucketcloud.PullRequestChangesRequestRemovedEvent:
+		return prFor(e.PullRequestEvent), participantStatusEvent(e.ChangesRequest, bitbucketcloud.ParticipantActionChangesRequestRemoved), nil
+	case *bitbucketcloud.PullRequestFulfilledEvent:
+		return prFor(e.PullRequestEvent), pullRequestStateEvent(e.PullRequestEvent), nil
+	case *bitbucketcloud.PullRequestRejectedEvent:
+		return prFor(e.PullRequestEvent), pullRequestStateEvent(e.PullRequestEvent), nil
+	case *bitbucketcloud.RepoCommitStatusEvent:
+		// Commit status events don't reference the pull requests of the
+		// commit, so we have to look for open changesets whose head is the
+		// commit.
+		status := &bitbucketcloud.CommitStatus{
+			Commit: e.CommitStatus.CommitHash(),
+			Status: e.CommitStatus,
+		}
+		prs, err := h.prsForCommit(ctx, externalServiceID, e.Repository.UUID, status.Commit)
+		return prs, status, err
+	}
+
+	return nil, nil, nil
+}
+
+// prsForCommit returns the open Bitbucket Cloud changesets in the given
+// repository whose head is the given commit.
+func (h *BitbucketCloudWebhook) prsForCommit(ctx context.Context, externalServiceID, repoExternalID, commit string) ([]PR, error) {
+	repo, err := h.getRepoForPR(ctx, h.Store, PR{RepoExternalID: repoExternalID}, externalServiceID)
+	if err != nil {
+		log15.Warn("Webhook event could not be matched to repo", "err", err)
+		return nil, nil
+	}
+
+	cs, _, err := h.Store.ListChangesets(ctx, store.ListChangesetsOpts{
+		RepoID:         repo.ID,
+		ExternalStates: []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changesets")
+	}
+
+	var prs []PR
+	for _, c := range cs {
+		pr, ok := c.Metadata.(*bitbucketcloud.PullRequest)
+		if !ok || pr.Source.Commit.Hash == "" {
+			continue
+		}
+		// The pull request only has the abbreviated hash of its head.
+		if strings.HasPrefix(commit, pr.Source.Commit.Hash) {
+			prs = append(prs, PR{ID: int64(pr.ID), RepoExternalID: repoExternalID})
+		}
+	}
+	return prs, nil
+}
+
+func participantStatusEvent(r bitbucketcloud.Review, action bitbucketcloud.ParticipantAction) *bitbucketcloud.ParticipantStatusEvent {
+	return &bitbucketcloud.ParticipantStatusEvent{
+		Date:   r.Date,
+		User:   r.User,
+		Action: action,
+	}
+}
+
+func pullRequestStateEvent(e bitbucketcloud.PullRequestEvent) *bitbucketcloud.PullRequestStateEvent {
+	return &bitbucketcloud.PullRequestStateEvent{
+		Date:  e.PullRequest.UpdatedOn,
+		Actor: e.Actor,
+		State: e.PullRequest.State,
+	}
+}
@@ -252,14 +252,14 @@ func (r *Resolver) CommitGraph(ctx context.Context, id graphql.ID) (_ gql.CodeIn
 	return r.resolver.CommitGraph(ctx, int(repositoryID))
 }
 
-// 🚨 SECURITY: Only site admins may queue auto-index jobs
+// 🚨 SECURITY: Only site admins and repository admins may queue auto-index jobs
 func (r *Resolver) QueueAutoIndexJobsForRepo(ctx context.Context, args *gql.QueueAutoIndexJobsForRepoArgs) (_ []gql.LSIFIndexResolver, err error) {
 	ctx, traceErrs, endObservation := r.observationContext.queueAutoIndexJobsForRepo.WithErrors(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("repoID", string(args.Repository)),
 	}})
 	endObservation.OnCancel(ctx, 1, observation.Args{})
 
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	if err := backend.CheckCurrentUserHasPermission(ctx, r.db, database.PermissionRepoAdmin); err != nil {
 		return nil, err
 	}
 	if !autoIndexingEnabled() {
@@ -503,14 +503,14 @@ func (r *Resolver) IndexConfiguration(ctx context.Context, id graphql.ID) (_ gql
 	return NewIndexConfigurationResolver(r.resolver, int(repositoryID), traceErrs), nil
 }
 
-// 🚨 SECURITY: Only site admins may modify code intelligence indexing configuration
+// 🚨 SECURITY: Only site admins and repository admins may modify code intelligence indexing configuration
 func (r *Resolver) UpdateRepositoryIndexConfiguration(ctx context.Context, args *gql.UpdateRepositoryIndexConfigurationArgs) (_ *gql.EmptyResponse, err error) {
 	ctx, endObservation := r.observationContext.updateIndexConfiguration.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("repoID", string(args.Repository)),
 	}})
 	defer endObservation(1, observation.Args{})
 
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	if err := backend.CheckCurrentUserHasPermission(ctx, r.db, database.PermissionRepoAdmin); err != nil {
 		return nil, err
 	}
 	if !autoIndexingEnabled() {
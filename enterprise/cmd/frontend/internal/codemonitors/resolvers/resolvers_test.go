@@ -118,6 +118,11 @@ func TestIsAllowedToEdit(t *testing.T) {
 	owner := insertTestUser(t, db, "cm-user1", false)
 	notOwner := insertTestUser(t, db, "cm-user2", false)
 	siteAdmin := insertTestUser(t, db, "cm-user3", true)
+	manager := insertTestUser(t, db, "cm-user4", false)
+
+	role, err := db.Roles().GetByName(context.Background(), "code_monitor_manager")
+	require.NoError(t, err)
+	require.NoError(t, db.Roles().Assign(context.Background(), &database.RoleAssignment{RoleID: role.ID, UserID: manager.ID}))
 
 	r := newTestResolver(t, db)
 
@@ -131,6 +136,10 @@ func TestIsAllowedToEdit(t *testing.T) {
 		user    int32
 		allowed bool
 	}{
+		{
+			user:    manager.ID,
+			allowed: true,
+		},
 		{
 			user:    owner.ID,
 			allowed: true,
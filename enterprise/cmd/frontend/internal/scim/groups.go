@@ -0,0 +1,328 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// group is a SCIM group resource (RFC 7643, section 4.2). Groups are mapped to
+// organizations: the organization name is derived from the group's display
+// name, and the group members are the organization members.
+type group struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []member `json:"members,omitempty"`
+	Meta        *meta    `json:"meta,omitempty"`
+}
+
+type member struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+func (h *handler) listGroups(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.doListGroups(r)
+	h.writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (h *handler) doListGroups(r *http.Request) (*listResponse, error) {
+	ctx := r.Context()
+
+	p, err := parsePagination(r)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		orgs  []*types.Org
+		total int
+	)
+	if f == nil {
+		if total, err = h.db.Orgs().Count(ctx, database.OrgsListOptions{}); err != nil {
+			return nil, err
+		}
+		if p.count > 0 {
+			if orgs, err = h.db.Orgs().List(ctx, &database.OrgsListOptions{LimitOffset: p.limitOffset()}); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if !strings.EqualFold(f.attribute, "displayName") {
+			return nil, errBadRequest("invalidFilter", "unsupported filter attribute %q: only displayName is supported", f.attribute)
+		}
+		org, err := h.getOrgByDisplayName(ctx, f.value)
+		if err != nil {
+			return nil, err
+		}
+		if org != nil {
+			total = 1
+			if p.startIndex == 1 && p.count > 0 {
+				orgs = []*types.Org{org}
+			}
+		}
+	}
+
+	withMembers := !excludesAttribute(r, "members")
+	resources := make([]*group, 0, len(orgs))
+	for _, org := range orgs {
+		res, err := h.groupResource(ctx, org, withMembers)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, res)
+	}
+	return &listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   p.startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+func (h *handler) getOrgByDisplayName(ctx context.Context, displayName string) (*types.Org, error) {
+	orgName, err := auth.NormalizeUsername(displayName)
+	if err != nil {
+		return nil, nil
+	}
+	org, err := h.db.Orgs().GetByName(ctx, orgName)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return org, err
+}
+
+// excludesAttribute reports whether the request asks to leave attr out of the
+// returned resources, which identity providers do to avoid listing the
+// members of large groups.
+func excludesAttribute(r *http.Request, attr string) bool {
+	for _, a := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(a), attr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *handler) getGroup(w http.ResponseWriter, r *http.Request) {
+	res, err := h.doGetGroup(r)
+	h.writeResponse(w, r, http.StatusOK, res, err)
+}
+
+func (h *handler) doGetGroup(r *http.Request) (*group, error) {
+	org, err := h.lookupOrg(r)
+	if err != nil {
+		return nil, err
+	}
+	return h.groupResource(r.Context(), org, !excludesAttribute(r, "members"))
+}
+
+func (h *handler) createGroup(w http.ResponseWriter, r *http.Request) {
+	res, err := h.doCreateGroup(r)
+	h.writeResponse(w, r, http.StatusCreated, res, err)
+}
+
+func (h *handler) doCreateGroup(r *http.Request) (*group, error) {
+	ctx := r.Context()
+
+	var res group
+	if err := decodeBody(r, &res); err != nil {
+		return nil, err
+	}
+	if res.DisplayName == "" {
+		return nil, errBadRequest("invalidValue", "displayName is required")
+	}
+	orgName, err := auth.NormalizeUsername(res.DisplayName)
+	if err != nil {
+		return nil, errBadRequest("invalidValue", "invalid displayName: %s", err)
+	}
+
+	org, err := h.db.Orgs().Create(ctx, orgName, &res.DisplayName)
+	if errors.Is(err, database.ErrOrgNameAlreadyExists) {
+		return nil, errConflict("organization %q already exists", orgName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.syncMembers(ctx, org.ID, res.Members); err != nil {
+		return nil, err
+	}
+	return h.groupResource(ctx, org, true)
+}
+
+func (h *handler) replaceGroup(w http.ResponseWriter, r *http.Request) {
+	res, err := h.doReplaceGroup(r)
+	h.writeResponse(w, r, http.StatusOK, res, err)
+}
+
+func (h *handler) doReplaceGroup(r *http.Request) (*group, error) {
+	org, err := h.lookupOrg(r)
+	if err != nil {
+		return nil, err
+	}
+	var res group
+	if err := decodeBody(r, &res); err != nil {
+		return nil, err
+	}
+	return h.updateGroup(r.Context(), org, &res)
+}
+
+func (h *handler) patchGroup(w http.ResponseWriter, r *http.Request) {
+	res, err := h.doPatchGroup(r)
+	h.writeResponse(w, r, http.StatusOK, res, err)
+}
+
+func (h *handler) doPatchGroup(r *http.Request) (*group, error) {
+	ctx := r.Context()
+
+	org, err := h.lookupOrg(r)
+	if err != nil {
+		return nil, err
+	}
+	var req patchRequest
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+	res, err := h.groupResource(ctx, org, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyPatch(res, req.Operations); err != nil {
+		return nil, err
+	}
+	return h.updateGroup(ctx, org, res)
+}
+
+// updateGroup updates the organization to match the desired state of res and
+// returns the updated resource. The organization name is never changed, as it
+// is part of URLs.
+func (h *handler) updateGroup(ctx context.Context, org *types.Org, res *group) (*group, error) {
+	if res.DisplayName != "" && (org.DisplayName == nil || *org.DisplayName != res.DisplayName) {
+		updated, err := h.db.Orgs().Update(ctx, org.ID, &res.DisplayName)
+		if err != nil {
+			return nil, err
+		}
+		org = updated
+	}
+	if err := h.syncMembers(ctx, org.ID, res.Members); err != nil {
+		return nil, err
+	}
+	return h.groupResource(ctx, org, true)
+}
+
+func (h *handler) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	err := h.doDeleteGroup(r)
+	h.writeResponse(w, r, http.StatusNoContent, nil, err)
+}
+
+func (h *handler) doDeleteGroup(r *http.Request) error {
+	org, err := h.lookupOrg(r)
+	if err != nil {
+		return err
+	}
+	return h.db.Orgs().Delete(r.Context(), org.ID)
+}
+
+// syncMembers makes the given users the members of the organization. Members
+// that aren't active users are ignored.
+func (h *handler) syncMembers(ctx context.Context, orgID int32, members []member) error {
+	ids := make([]int32, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseInt(m.Value, 10, 32)
+		if err != nil {
+			return errBadRequest("invalidValue", "invalid member %q", m.Value)
+		}
+		ids = append(ids, int32(id))
+	}
+	users, err := h.db.Users().List(ctx, &database.UsersListOptions{UserIDs: ids})
+	if err != nil {
+		return err
+	}
+	want := make(map[int32]bool, len(users))
+	for _, u := range users {
+		want[u.ID] = true
+	}
+
+	memberships, err := h.db.OrgMembers().GetByOrgID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	have := make(map[int32]bool, len(memberships))
+	for _, m := range memberships {
+		have[m.UserID] = true
+		if !want[m.UserID] {
+			if err := h.db.OrgMembers().Remove(ctx, orgID, m.UserID); err != nil {
+				return err
+			}
+		}
+	}
+	for id := range want {
+		if !have[id] {
+			if _, err := h.db.OrgMembers().Create(ctx, orgID, id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (h *handler) lookupOrg(r *http.Request) (*types.Org, error) {
+	id, err := parseID(r, "group")
+	if err != nil {
+		return nil, err
+	}
+	org, err := h.db.Orgs().GetByID(r.Context(), id)
+	if errcode.IsNotFound(err) {
+		return nil, errNotFound("group", strconv.Itoa(int(id)))
+	}
+	return org, err
+}
+
+// groupResource returns the SCIM representation of org.
+func (h *handler) groupResource(ctx context.Context, org *types.Org, withMembers bool) (*group, error) {
+	id := strconv.Itoa(int(org.ID))
+	res := &group{
+		Schemas:     []string{schemaGroup},
+		ID:          id,
+		DisplayName: org.Name,
+		Meta:        newMeta("Group", id, org.CreatedAt, org.UpdatedAt),
+	}
+	if org.DisplayName != nil && *org.DisplayName != "" {
+		res.DisplayName = *org.DisplayName
+	}
+	if !withMembers {
+		return res, nil
+	}
+
+	memberships, err := h.db.OrgMembers().GetByOrgID(ctx, org.ID)
+	if err != nil || len(memberships) == 0 {
+		return res, err
+	}
+	ids := make([]int32, 0, len(memberships))
+	for _, m := range memberships {
+		ids = append(ids, m.UserID)
+	}
+	users, err := h.db.Users().List(ctx, &database.UsersListOptions{UserIDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		res.Members = append(res.Members, member{Value: strconv.Itoa(int(u.ID)), Display: u.Username})
+	}
+	return res, nil
+}
@@ -0,0 +1,290 @@
+// Package scim implements the SCIM 2.0 (RFC 7643, RFC 7644) user and group
+// provisioning API. Identity providers use it to create, update, deactivate
+// and delete users, and to manage organizations through SCIM groups.
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+const (
+	schemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+	schemaSPConfig     = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	contentType = "application/scim+json"
+
+	// maxResults is the maximum number of resources returned in a single list
+	// response.
+	maxResults = 100
+)
+
+// NewHandler returns the handler serving the SCIM API under /.api/scim/v2.
+//
+// 🚨 SECURITY: All routes are protected by the bearer token returned by
+// accessToken. The API is disabled when no token is configured.
+func NewHandler(db database.DB, accessToken func() string) http.Handler {
+	h := &handler{db: db}
+
+	r := mux.NewRouter().PathPrefix("/.api/scim/v2").Subrouter()
+	r.Path("/ServiceProviderConfig").Methods("GET").HandlerFunc(h.serviceProviderConfig)
+
+	r.Path("/Users").Methods("GET").HandlerFunc(h.listUsers)
+	r.Path("/Users").Methods("POST").HandlerFunc(h.createUser)
+	r.Path("/Users/{id}").Methods("GET").HandlerFunc(h.getUser)
+	r.Path("/Users/{id}").Methods("PUT").HandlerFunc(h.replaceUser)
+	r.Path("/Users/{id}").Methods("PATCH").HandlerFunc(h.patchUser)
+	r.Path("/Users/{id}").Methods("DELETE").HandlerFunc(h.deleteUser)
+
+	r.Path("/Groups").Methods("GET").HandlerFunc(h.listGroups)
+	r.Path("/Groups").Methods("POST").HandlerFunc(h.createGroup)
+	r.Path("/Groups/{id}").Methods("GET").HandlerFunc(h.getGroup)
+	r.Path("/Groups/{id}").Methods("PUT").HandlerFunc(h.replaceGroup)
+	r.Path("/Groups/{id}").Methods("PATCH").HandlerFunc(h.patchGroup)
+	r.Path("/Groups/{id}").Methods("DELETE").HandlerFunc(h.deleteGroup)
+
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "", "resource not found")
+	})
+	r.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusMethodNotAllowed, "", "method not allowed")
+	})
+
+	return authMiddleware(accessToken, r)
+}
+
+type handler struct {
+	db database.DB
+}
+
+// authMiddleware rejects requests that do not have an Authorization header
+// set to "Bearer <token>" with the configured token. Requests that pass are
+// performed as an internal actor.
+func authMiddleware(accessToken func() string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := accessToken()
+		if expected == "" {
+			writeError(w, http.StatusNotFound, "", "SCIM provisioning is not enabled on this instance. Ask a site admin to set 'scim.accessToken' in the site configuration.")
+			return
+		}
+
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == r.Header.Get("Authorization") || token == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="SCIM"`)
+			writeError(w, http.StatusUnauthorized, "", `HTTP Authorization request header value must be of the following form: 'Bearer "TOKEN"'`)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			writeError(w, http.StatusForbidden, "", "invalid SCIM access token")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(actor.WithInternalActor(r.Context())))
+	})
+}
+
+// scimError is a SCIM error response (RFC 7644, section 3.12).
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string { return e.detail }
+
+func errBadRequest(scimType, format string, args ...interface{}) error {
+	return &scimError{status: http.StatusBadRequest, scimType: scimType, detail: fmt.Sprintf(format, args...)}
+}
+
+func errNotFound(resource, id string) error {
+	return &scimError{status: http.StatusNotFound, detail: fmt.Sprintf("%s %s not found", resource, id)}
+}
+
+func errConflict(format string, args ...interface{}) error {
+	return &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: fmt.Sprintf(format, args...)}
+}
+
+// writeResponse writes the result of an operation: v if err is nil, otherwise
+// the SCIM error describing err.
+func (h *handler) writeResponse(w http.ResponseWriter, r *http.Request, status int, v interface{}, err error) {
+	if err != nil {
+		var e *scimError
+		if errors.As(err, &e) {
+			writeError(w, e.status, e.scimType, e.detail)
+			return
+		}
+		log15.Error("SCIM request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+		writeError(w, http.StatusInternalServerError, "", "internal error")
+		return
+	}
+	if v == nil {
+		w.WriteHeader(status)
+		return
+	}
+	writeJSON(w, status, v)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, scimType, detail string) {
+	writeJSON(w, status, struct {
+		Schemas  []string `json:"schemas"`
+		Status   string   `json:"status"`
+		ScimType string   `json:"scimType,omitempty"`
+		Detail   string   `json:"detail"`
+	}{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+func decodeBody(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errBadRequest("invalidSyntax", "invalid request body: %s", err)
+	}
+	return nil
+}
+
+// listResponse is a SCIM list response (RFC 7644, section 3.4.2).
+type listResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// meta is the common "meta" attribute of SCIM resources.
+type meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+func newMeta(resourceType, id string, created, lastModified time.Time) *meta {
+	location := ""
+	if u := globals.ExternalURL(); u != nil {
+		location = fmt.Sprintf("%s/.api/scim/v2/%ss/%s", strings.TrimSuffix(u.String(), "/"), resourceType, id)
+	}
+	return &meta{
+		ResourceType: resourceType,
+		Created:      &created,
+		LastModified: &lastModified,
+		Location:     location,
+	}
+}
+
+// pagination holds the 1-based startIndex and count query parameters of a list
+// request.
+type pagination struct {
+	startIndex int
+	count      int
+}
+
+func parsePagination(r *http.Request) (pagination, error) {
+	p := pagination{startIndex: 1, count: maxResults}
+	if v := r.URL.Query().Get("startIndex"); v != "" {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return p, errBadRequest("invalidValue", "invalid startIndex %q", v)
+		}
+		if i > 1 {
+			p.startIndex = i
+		}
+	}
+	if v := r.URL.Query().Get("count"); v != "" {
+		c, err := strconv.Atoi(v)
+		if err != nil {
+			return p, errBadRequest("invalidValue", "invalid count %q", v)
+		}
+		if c < 0 {
+			c = 0
+		}
+		if c < maxResults {
+			p.count = c
+		}
+	}
+	return p, nil
+}
+
+func (p pagination) limitOffset() *database.LimitOffset {
+	return &database.LimitOffset{Limit: p.count, Offset: p.startIndex - 1}
+}
+
+// filter is an attribute equality filter such as `userName eq "alice"`, which
+// is the only kind of filter identity providers use when provisioning.
+type filter struct {
+	attribute string
+	value     string
+}
+
+var filterPattern = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+func parseFilter(s string) (*filter, error) {
+	if s == "" {
+		return nil, nil
+	}
+	m := filterPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, errBadRequest("invalidFilter", "unsupported filter %q: only equality filters are supported", s)
+	}
+	var value string
+	if err := json.Unmarshal([]byte(`"`+m[2]+`"`), &value); err != nil {
+		return nil, errBadRequest("invalidFilter", "invalid filter value in %q", s)
+	}
+	return &filter{attribute: m[1], value: value}, nil
+}
+
+func (h *handler) serviceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	supported := func(b bool) map[string]interface{} { return map[string]interface{}{"supported": b} }
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{schemaSPConfig},
+		"patch":          supported(true),
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": maxResults},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with the token set in the 'scim.accessToken' site configuration.",
+		}},
+		"meta": &meta{ResourceType: "ServiceProviderConfig"},
+	})
+}
+
+// parseID parses the numeric ID of a user or group from the request path.
+func parseID(r *http.Request, resource string) (int32, error) {
+	id := mux.Vars(r)["id"]
+	i, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return 0, errNotFound(resource, id)
+	}
+	return int32(i), nil
+}
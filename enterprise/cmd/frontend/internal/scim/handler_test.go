@@ -0,0 +1,333 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const testAccessToken = "scim-test-token-0123456789"
+
+func TestAuthMiddleware(t *testing.T) {
+	var isInternal bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isInternal = actor.FromContext(r.Context()).IsInternal()
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	for _, tc := range []struct {
+		name          string
+		accessToken   string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "not configured", accessToken: "", authorization: "Bearer " + testAccessToken, wantStatus: http.StatusNotFound},
+		{name: "no auth", accessToken: testAccessToken, wantStatus: http.StatusUnauthorized},
+		{name: "wrong scheme", accessToken: testAccessToken, authorization: "token " + testAccessToken, wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", accessToken: testAccessToken, authorization: "Bearer " + strings.ToUpper(testAccessToken), wantStatus: http.StatusForbidden},
+		{name: "correct token", accessToken: testAccessToken, authorization: "Bearer " + testAccessToken, wantStatus: http.StatusTeapot},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			isInternal = false
+			h := authMiddleware(func() string { return tc.accessToken }, next)
+
+			req := httptest.NewRequest("GET", "/.api/scim/v2/Users", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("unexpected status code: want %d, have %d", tc.wantStatus, rec.Code)
+			}
+			if want := tc.wantStatus == http.StatusTeapot; isInternal != want {
+				t.Fatalf("unexpected internal actor: want %v, have %v", want, isInternal)
+			}
+		})
+	}
+}
+
+// fakeUsers is an in-memory user store backing the mocked database.
+type fakeUsers struct {
+	users map[int32]*types.User
+}
+
+func (f *fakeUsers) list(_ context.Context, opt *database.UsersListOptions) ([]*types.User, error) {
+	var users []*types.User
+	for id := int32(1); id <= int32(len(f.users)); id++ {
+		u, ok := f.users[id]
+		if !ok || (u.DeletedAt != nil && !opt.IncludeDeleted) {
+			continue
+		}
+		if opt.Query != "" && !strings.Contains(u.Username, opt.Query) {
+			continue
+		}
+		if opt.UserIDs != nil && !containsID(opt.UserIDs, id) {
+			continue
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+func containsID(ids []int32, id int32) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestDB(users ...*types.User) (*database.MockDB, *database.MockUserStore, *fakeUsers) {
+	fake := &fakeUsers{users: map[int32]*types.User{}}
+	for _, u := range users {
+		fake.users[u.ID] = u
+	}
+
+	userStore := database.NewMockUserStore()
+	userStore.ListFunc.SetDefaultHook(fake.list)
+	userStore.DeleteFunc.SetDefaultHook(func(_ context.Context, id int32) error {
+		now := time.Now()
+		fake.users[id].DeletedAt = &now
+		return nil
+	})
+	userStore.RecoverFunc.SetDefaultHook(func(_ context.Context, id int32) error {
+		fake.users[id].DeletedAt = nil
+		return nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(userStore)
+	db.UserEmailsFunc.SetDefaultReturn(database.NewMockUserEmailsStore())
+	db.UserExternalAccountsFunc.SetDefaultReturn(database.NewMockUserExternalAccountsStore())
+	return db, userStore, fake
+}
+
+func serve(t *testing.T, db database.DB, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testAccessToken)
+	rec := httptest.NewRecorder()
+	NewHandler(db, func() string { return testAccessToken }).ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeResponse(t *testing.T, rec *httptest.ResponseRecorder, wantStatus int, v interface{}) {
+	t.Helper()
+
+	if rec.Code != wantStatus {
+		t.Fatalf("unexpected status code: want %d, have %d: %s", wantStatus, rec.Code, rec.Body.String())
+	}
+	if have := rec.Header().Get("Content-Type"); have != contentType {
+		t.Fatalf("unexpected content type %q", have)
+	}
+	if err := json.NewDecoder(rec.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	db, _, fake := newTestDB()
+
+	accounts := database.NewMockUserExternalAccountsStore()
+	accounts.CreateUserAndSaveFunc.SetDefaultHook(func(_ context.Context, newUser database.NewUser, spec extsvc.AccountSpec, _ extsvc.AccountData) (int32, error) {
+		want := database.NewUser{
+			Username:        "alice",
+			DisplayName:     "Alice Smith",
+			Email:           "alice@example.com",
+			EmailIsVerified: true,
+		}
+		if diff := cmp.Diff(want, newUser); diff != "" {
+			t.Errorf("unexpected new user (-want +have):\n%s", diff)
+		}
+		if diff := cmp.Diff(accountSpec("00u1"), spec); diff != "" {
+			t.Errorf("unexpected account spec (-want +have):\n%s", diff)
+		}
+		fake.users[1] = &types.User{ID: 1, Username: newUser.Username, DisplayName: newUser.DisplayName}
+		return 1, nil
+	})
+	accounts.ListBySQLFunc.SetDefaultReturn([]*extsvc.Account{{AccountSpec: accountSpec("00u1")}}, nil)
+	db.UserExternalAccountsFunc.SetDefaultReturn(accounts)
+
+	emails := database.NewMockUserEmailsStore()
+	verifiedAt := time.Now()
+	emails.ListByUserFunc.SetDefaultReturn([]*database.UserEmail{{UserID: 1, Email: "alice@example.com", VerifiedAt: &verifiedAt, Primary: true}}, nil)
+	db.UserEmailsFunc.SetDefaultReturn(emails)
+
+	rec := serve(t, db, "POST", "/.api/scim/v2/Users", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"externalId": "00u1",
+		"userName": "alice@example.com",
+		"name": {"givenName": "Alice", "familyName": "Smith"},
+		"emails": [{"value": "alice@example.com", "type": "work", "primary": true}],
+		"active": true
+	}`)
+
+	var have user
+	decodeResponse(t, rec, http.StatusCreated, &have)
+	have.Meta = nil
+
+	active := boolean(true)
+	want := user{
+		Schemas:     []string{schemaUser},
+		ID:          "1",
+		ExternalID:  "00u1",
+		UserName:    "alice",
+		Name:        &name{Formatted: "Alice Smith"},
+		DisplayName: "Alice Smith",
+		Emails:      []email{{Value: "alice@example.com", Type: "work", Primary: true}},
+		Active:      &active,
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected user (-want +have):\n%s", diff)
+	}
+	if calls := len(emails.AddFunc.History()); calls != 0 {
+		t.Fatalf("unexpected calls to add emails: %d", calls)
+	}
+}
+
+func TestCreateUser_Conflict(t *testing.T) {
+	db, _, _ := newTestDB()
+
+	emails := database.NewMockUserEmailsStore()
+	emails.GetVerifiedEmailsFunc.SetDefaultReturn([]*database.UserEmail{{UserID: 7, Email: "alice@example.com"}}, nil)
+	db.UserEmailsFunc.SetDefaultReturn(emails)
+
+	rec := serve(t, db, "POST", "/.api/scim/v2/Users", `{"userName": "alice", "emails": [{"value": "alice@example.com"}]}`)
+
+	var have map[string]interface{}
+	decodeResponse(t, rec, http.StatusConflict, &have)
+	if have["scimType"] != "uniqueness" {
+		t.Fatalf("unexpected error: %v", have)
+	}
+}
+
+func TestListUsers_Filter(t *testing.T) {
+	deletedAt := time.Now()
+	db, _, _ := newTestDB(
+		&types.User{ID: 1, Username: "alice", DeletedAt: &deletedAt},
+		&types.User{ID: 2, Username: "alice"},
+		&types.User{ID: 3, Username: "alicea"},
+	)
+
+	rec := serve(t, db, "GET", `/.api/scim/v2/Users?filter=userName+eq+"alice@example.com"`, "")
+
+	var have struct {
+		TotalResults int
+		Resources    []user
+	}
+	decodeResponse(t, rec, http.StatusOK, &have)
+	if have.TotalResults != 1 || len(have.Resources) != 1 || have.Resources[0].ID != "2" {
+		t.Fatalf("unexpected response: %+v", have)
+	}
+
+	rec = serve(t, db, "GET", `/.api/scim/v2/Users?filter=emails+co+"example.com"`, "")
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status code for unsupported filter: %d", rec.Code)
+	}
+}
+
+func TestPatchUser_Deactivate(t *testing.T) {
+	db, users, _ := newTestDB(&types.User{ID: 1, Username: "alice"})
+
+	rec := serve(t, db, "PATCH", "/.api/scim/v2/Users/1", `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [{"op": "replace", "value": {"active": false}}]
+	}`)
+
+	var have user
+	decodeResponse(t, rec, http.StatusOK, &have)
+	if have.active() {
+		t.Fatal("expected the user to be inactive")
+	}
+	if calls := users.InvalidateSessionsByIDFunc.History(); len(calls) != 1 || calls[0].Arg1 != 1 {
+		t.Fatalf("expected sessions of user 1 to be invalidated, got %+v", calls)
+	}
+	if calls := users.DeleteFunc.History(); len(calls) != 1 || calls[0].Arg1 != 1 {
+		t.Fatalf("expected user 1 to be soft-deleted, got %+v", calls)
+	}
+
+	// Reactivating the user recovers it.
+	rec = serve(t, db, "PATCH", "/.api/scim/v2/Users/1", `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [{"op": "replace", "path": "active", "value": "True"}]
+	}`)
+	decodeResponse(t, rec, http.StatusOK, &have)
+	if !have.active() {
+		t.Fatal("expected the user to be active")
+	}
+	if calls := users.RecoverFunc.History(); len(calls) != 1 || calls[0].Arg1 != 1 {
+		t.Fatalf("expected user 1 to be recovered, got %+v", calls)
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	db, users, _ := newTestDB(&types.User{ID: 1, Username: "alice"})
+
+	rec := serve(t, db, "DELETE", "/.api/scim/v2/Users/1", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("unexpected status code: %d", rec.Code)
+	}
+	if calls := users.InvalidateSessionsByIDFunc.History(); len(calls) != 1 {
+		t.Fatalf("expected sessions to be invalidated, got %+v", calls)
+	}
+	if calls := users.HardDeleteFunc.History(); len(calls) != 1 || calls[0].Arg1 != 1 {
+		t.Fatalf("expected user 1 to be deleted, got %+v", calls)
+	}
+
+	rec = serve(t, db, "DELETE", "/.api/scim/v2/Users/2", "")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unexpected status code for unknown user: %d", rec.Code)
+	}
+}
+
+func TestPatchGroup_Members(t *testing.T) {
+	db, _, _ := newTestDB(
+		&types.User{ID: 1, Username: "alice"},
+		&types.User{ID: 2, Username: "bob"},
+		&types.User{ID: 3, Username: "carol"},
+	)
+
+	displayName := "Engineering"
+	orgs := database.NewMockOrgStore()
+	orgs.GetByIDFunc.SetDefaultReturn(&types.Org{ID: 5, Name: "Engineering", DisplayName: &displayName}, nil)
+	db.OrgsFunc.SetDefaultReturn(orgs)
+
+	members := database.NewMockOrgMemberStore()
+	members.GetByOrgIDFunc.SetDefaultReturn([]*types.OrgMembership{{OrgID: 5, UserID: 1}, {OrgID: 5, UserID: 2}}, nil)
+	db.OrgMembersFunc.SetDefaultReturn(members)
+
+	rec := serve(t, db, "PATCH", "/.api/scim/v2/Groups/5", `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "remove", "path": "members[value eq \"1\"]"},
+			{"op": "add", "path": "members", "value": [{"value": "3"}]}
+		]
+	}`)
+	var have group
+	decodeResponse(t, rec, http.StatusOK, &have)
+
+	if calls := members.RemoveFunc.History(); len(calls) != 1 || calls[0].Arg1 != 5 || calls[0].Arg2 != 1 {
+		t.Fatalf("expected user 1 to be removed from org 5, got %+v", calls)
+	}
+	if calls := members.CreateFunc.History(); len(calls) != 1 || calls[0].Arg1 != 5 || calls[0].Arg2 != 3 {
+		t.Fatalf("expected user 3 to be added to org 5, got %+v", calls)
+	}
+	if calls := orgs.UpdateFunc.History(); len(calls) != 0 {
+		t.Fatalf("unexpected org update: %+v", calls)
+	}
+}
@@ -0,0 +1,21 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// Init registers the SCIM 2.0 user and group provisioning API.
+func Init(ctx context.Context, db database.DB, conf conftypes.UnifiedWatchable, enterpriseServices *enterprise.Services, observationContext *observation.Context) error {
+	accessToken := func() string { return conf.SiteConfig().ScimAccessToken }
+
+	enterpriseServices.NewSCIMHandler = func() http.Handler {
+		return NewHandler(db, accessToken)
+	}
+	return nil
+}
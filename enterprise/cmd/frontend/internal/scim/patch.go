@@ -0,0 +1,218 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// patchRequest is a SCIM PATCH request (RFC 7644, section 3.5.2).
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// applyPatch applies the operations of a PATCH request to the JSON
+// representation of resource, and decodes the result back into it.
+func applyPatch(resource interface{}, ops []patchOperation) error {
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		if err := applyOperation(doc, op); err != nil {
+			return err
+		}
+	}
+
+	if b, err = json.Marshal(doc); err != nil {
+		return err
+	}
+	// Decode into a zero value, so that removed attributes don't survive.
+	v := reflect.ValueOf(resource).Elem()
+	v.Set(reflect.Zero(v.Type()))
+	if err := json.Unmarshal(b, resource); err != nil {
+		return errBadRequest("invalidValue", "invalid patched resource: %s", err)
+	}
+	return nil
+}
+
+func applyOperation(doc map[string]interface{}, op patchOperation) error {
+	kind := strings.ToLower(op.Op)
+	switch kind {
+	case "add", "replace", "remove":
+	default:
+		return errBadRequest("invalidSyntax", "unsupported patch operation %q", op.Op)
+	}
+
+	if op.Path == "" {
+		// Without a path the value holds the attributes to add or replace.
+		values, ok := op.Value.(map[string]interface{})
+		if kind == "remove" || !ok {
+			return errBadRequest("noTarget", "patch operation %q requires a path or an object value", op.Op)
+		}
+		for attr, v := range values {
+			if err := applyOperation(doc, patchOperation{Op: kind, Path: attr, Value: v}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	p, err := parsePath(op.Path)
+	if err != nil {
+		return err
+	}
+	if p.filter == nil {
+		target := doc
+		attr := p.attribute
+		if p.subAttribute != "" {
+			target = childObject(doc, p.attribute)
+			attr = p.subAttribute
+		}
+		setAttribute(target, kind, attr, op.Value)
+		return nil
+	}
+
+	// Operations on the elements of a multi-valued attribute matched by a filter,
+	// such as `members[value eq "42"]` or `emails[type eq "work"].value`.
+	key := lookupKey(doc, p.attribute)
+	elems, _ := doc[key].([]interface{})
+	var (
+		result  []interface{}
+		matched bool
+	)
+	for _, e := range elems {
+		obj, ok := e.(map[string]interface{})
+		if !ok || !p.filter.matches(obj) {
+			result = append(result, e)
+			continue
+		}
+		matched = true
+		switch {
+		case kind == "remove" && p.subAttribute == "":
+			// Drop the element.
+		case p.subAttribute != "":
+			setAttribute(obj, kind, p.subAttribute, op.Value)
+			result = append(result, obj)
+		default:
+			result = append(result, op.Value)
+		}
+	}
+	if !matched && kind != "remove" {
+		elem := map[string]interface{}{p.filter.attribute: p.filter.value}
+		if p.subAttribute != "" {
+			elem[p.subAttribute] = op.Value
+		} else if obj, ok := op.Value.(map[string]interface{}); ok {
+			for k, v := range obj {
+				elem[k] = v
+			}
+		}
+		result = append(result, elem)
+	}
+	doc[key] = result
+	return nil
+}
+
+// setAttribute performs an add, replace or remove operation on the attribute
+// attr of obj. Adding to a multi-valued attribute appends the given values.
+func setAttribute(obj map[string]interface{}, kind, attr string, value interface{}) {
+	key := lookupKey(obj, attr)
+	switch kind {
+	case "remove":
+		delete(obj, key)
+	case "add":
+		if existing, ok := obj[key].([]interface{}); ok {
+			if values, ok := value.([]interface{}); ok {
+				obj[key] = append(existing, values...)
+			} else {
+				obj[key] = append(existing, value)
+			}
+			return
+		}
+		obj[key] = value
+	default:
+		obj[key] = value
+	}
+}
+
+// childObject returns the complex attribute attr of obj, creating it if
+// necessary.
+func childObject(obj map[string]interface{}, attr string) map[string]interface{} {
+	key := lookupKey(obj, attr)
+	child, ok := obj[key].(map[string]interface{})
+	if !ok {
+		child = map[string]interface{}{}
+		obj[key] = child
+	}
+	return child
+}
+
+// lookupKey returns the key of obj that matches attr. SCIM attribute names are
+// case-insensitive.
+func lookupKey(obj map[string]interface{}, attr string) string {
+	if _, ok := obj[attr]; ok {
+		return attr
+	}
+	for k := range obj {
+		if strings.EqualFold(k, attr) {
+			return k
+		}
+	}
+	return attr
+}
+
+type path struct {
+	attribute    string
+	filter       *filter
+	subAttribute string
+}
+
+var pathPattern = regexp.MustCompile(`^([A-Za-z][\w]*)(?:\[(.+)\])?(?:\.([A-Za-z][\w]*))?$`)
+
+// parsePath parses the supported subset of SCIM attribute paths: "attr",
+// "attr.sub", `attr[sub eq "value"]` and `attr[sub eq "value"].sub`, optionally
+// prefixed by a schema URN.
+func parsePath(s string) (*path, error) {
+	if strings.HasPrefix(strings.ToLower(s), "urn:") {
+		// Only the core schemas are supported, so the schema URN can be dropped.
+		// It is separated from the attribute by the last colon outside a filter.
+		prefix := s
+		if i := strings.Index(prefix, "["); i != -1 {
+			prefix = prefix[:i]
+		}
+		s = s[strings.LastIndex(prefix, ":")+1:]
+	}
+
+	m := pathPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, errBadRequest("invalidPath", "unsupported path %q", s)
+	}
+	p := &path{attribute: m[1], subAttribute: m[3]}
+	if m[2] != "" {
+		f, err := parseFilter(m[2])
+		if err != nil {
+			return nil, errBadRequest("invalidPath", "unsupported path %q", s)
+		}
+		p.filter = f
+	}
+	return p, nil
+}
+
+func (f *filter) matches(obj map[string]interface{}) bool {
+	v, ok := obj[lookupKey(obj, f.attribute)]
+	return ok && fmt.Sprint(v) == f.value
+}
@@ -0,0 +1,102 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestApplyPatch(t *testing.T) {
+	active := boolean(true)
+	newUser := func() *user {
+		return &user{
+			Schemas:  []string{schemaUser},
+			ID:       "1",
+			UserName: "alice",
+			Emails:   []email{{Value: "alice@example.com", Type: "work", Primary: true}},
+			Active:   &active,
+		}
+	}
+	inactive := boolean(false)
+
+	for _, tc := range []struct {
+		name string
+		ops  []patchOperation
+		want func(u *user)
+	}{
+		{
+			name: "replace active without path (Okta)",
+			ops:  []patchOperation{{Op: "replace", Value: map[string]interface{}{"active": false}}},
+			want: func(u *user) { u.Active = &inactive },
+		},
+		{
+			name: "replace active with string value (Azure AD)",
+			ops:  []patchOperation{{Op: "Replace", Path: "active", Value: "False"}},
+			want: func(u *user) { u.Active = &inactive },
+		},
+		{
+			name: "replace sub-attribute",
+			ops:  []patchOperation{{Op: "replace", Path: "name.givenName", Value: "Alice"}},
+			want: func(u *user) { u.Name = &name{GivenName: "Alice"} },
+		},
+		{
+			name: "replace filtered multi-valued attribute",
+			ops:  []patchOperation{{Op: "replace", Path: `emails[type eq "work"].value`, Value: "alice@example.org"}},
+			want: func(u *user) { u.Emails[0].Value = "alice@example.org" },
+		},
+		{
+			name: "add to multi-valued attribute",
+			ops:  []patchOperation{{Op: "add", Path: "emails", Value: []interface{}{map[string]interface{}{"value": "a@example.net"}}}},
+			want: func(u *user) { u.Emails = append(u.Emails, email{Value: "a@example.net"}) },
+		},
+		{
+			name: "schema URN prefix",
+			ops:  []patchOperation{{Op: "replace", Path: schemaUser + ":userName", Value: "bob"}},
+			want: func(u *user) { u.UserName = "bob" },
+		},
+		{
+			name: "remove attribute",
+			ops:  []patchOperation{{Op: "remove", Path: "emails"}},
+			want: func(u *user) { u.Emails = nil },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have := newUser()
+			if err := applyPatch(have, tc.ops); err != nil {
+				t.Fatal(err)
+			}
+			want := newUser()
+			tc.want(want)
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Fatalf("unexpected user (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyPatch_GroupMembers(t *testing.T) {
+	g := &group{DisplayName: "Engineering", Members: []member{{Value: "1"}, {Value: "2"}}}
+
+	err := applyPatch(g, []patchOperation{
+		{Op: "remove", Path: `members[value eq "1"]`},
+		{Op: "add", Path: "members", Value: []interface{}{map[string]interface{}{"value": "3"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]member{{Value: "2"}, {Value: "3"}}, g.Members); diff != "" {
+		t.Fatalf("unexpected members (-want +have):\n%s", diff)
+	}
+}
+
+func TestApplyPatch_Errors(t *testing.T) {
+	for _, op := range []patchOperation{
+		{Op: "move", Path: "userName"},
+		{Op: "remove"},
+		{Op: "replace", Path: "emails[type co \"work\"]", Value: "x"},
+	} {
+		if err := applyPatch(&user{}, []patchOperation{op}); err == nil {
+			t.Errorf("expected an error for %+v", op)
+		}
+	}
+}
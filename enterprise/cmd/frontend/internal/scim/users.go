@@ -0,0 +1,605 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// The external account that records the identity provider's ID of a
+// provisioned user (its SCIM externalId).
+const (
+	serviceType = "scim"
+	serviceID   = "scim"
+	clientID    = "scim"
+)
+
+// user is a SCIM user resource (RFC 7643, section 4.1).
+//
+// Provisioned users are regular Sourcegraph users with verified email
+// addresses, so the first SAML or OpenID Connect sign-in of a provisioned user
+// is associated with the existing account instead of creating a new one.
+type user struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	UserName    string   `json:"userName"`
+	Name        *name    `json:"name,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Emails      []email  `json:"emails,omitempty"`
+	Active      *boolean `json:"active,omitempty"`
+	Meta        *meta    `json:"meta,omitempty"`
+}
+
+type name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type email struct {
+	Value   string  `json:"value"`
+	Type    string  `json:"type,omitempty"`
+	Primary boolean `json:"primary,omitempty"`
+}
+
+// boolean is a SCIM boolean attribute. Some identity providers send booleans
+// as strings such as "False", which are accepted too.
+type boolean bool
+
+func (b *boolean) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		v, err := strconv.ParseBool(strings.ToLower(s))
+		if err != nil {
+			return errors.Errorf("invalid boolean %q", s)
+		}
+		*b = boolean(v)
+		return nil
+	}
+	var v bool
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*b = boolean(v)
+	return nil
+}
+
+// active reports whether the user should be active. Users are active unless
+// stated otherwise.
+func (u *user) active() bool {
+	return u.Active == nil || bool(*u.Active)
+}
+
+func (u *user) displayName() string {
+	if u.DisplayName != "" || u.Name == nil {
+		return u.DisplayName
+	}
+	if u.Name.Formatted != "" {
+		return u.Name.Formatted
+	}
+	return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+}
+
+// primaryEmail returns the primary email address of the user, or the first
+// one if none is marked as primary.
+func (u *user) primaryEmail() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+func (h *handler) listUsers(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.doListUsers(r)
+	h.writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (h *handler) doListUsers(r *http.Request) (*listResponse, error) {
+	ctx := r.Context()
+
+	p, err := parsePagination(r)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		users []*types.User
+		total int
+	)
+	if f == nil {
+		opt := &database.UsersListOptions{IncludeDeleted: true}
+		if total, err = h.db.Users().Count(ctx, opt); err != nil {
+			return nil, err
+		}
+		if p.count > 0 {
+			opt.LimitOffset = p.limitOffset()
+			if users, err = h.db.Users().List(ctx, opt); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if users, err = h.findUsers(ctx, f); err != nil {
+			return nil, err
+		}
+		total = len(users)
+		users = paginate(users, p)
+	}
+
+	resources := make([]*user, 0, len(users))
+	for _, u := range users {
+		res, err := h.userResource(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, res)
+	}
+	return &listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   p.startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+// findUsers returns the users matching a userName or externalId filter.
+func (h *handler) findUsers(ctx context.Context, f *filter) ([]*types.User, error) {
+	switch strings.ToLower(f.attribute) {
+	case "username":
+		username, err := auth.NormalizeUsername(f.value)
+		if err != nil {
+			return nil, nil
+		}
+		candidates, err := h.db.Users().List(ctx, &database.UsersListOptions{Query: username, IncludeDeleted: true})
+		if err != nil {
+			return nil, err
+		}
+		// Deactivated users keep their username, so several users can share it.
+		// The active one takes precedence over the most recent deactivated one.
+		var found *types.User
+		for _, u := range candidates {
+			if !strings.EqualFold(u.Username, username) {
+				continue
+			}
+			if found == nil || found.DeletedAt != nil && (u.DeletedAt == nil || u.ID > found.ID) {
+				found = u
+			}
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return []*types.User{found}, nil
+
+	case "externalid":
+		// The accounts of deactivated users are soft-deleted along with them.
+		accounts, err := h.db.UserExternalAccounts().ListBySQL(ctx, sqlf.Sprintf(
+			"WHERE service_type=%s AND service_id=%s AND client_id=%s AND account_id=%s",
+			serviceType, serviceID, clientID, f.value,
+		))
+		if err != nil || len(accounts) == 0 {
+			return nil, err
+		}
+		ids := make([]int32, 0, len(accounts))
+		for _, a := range accounts {
+			ids = append(ids, a.UserID)
+		}
+		return h.db.Users().List(ctx, &database.UsersListOptions{UserIDs: ids, IncludeDeleted: true})
+	}
+	return nil, errBadRequest("invalidFilter", "unsupported filter attribute %q: only userName and externalId are supported", f.attribute)
+}
+
+func paginate(users []*types.User, p pagination) []*types.User {
+	if p.startIndex > len(users) {
+		return nil
+	}
+	users = users[p.startIndex-1:]
+	if len(users) > p.count {
+		users = users[:p.count]
+	}
+	return users
+}
+
+func (h *handler) getUser(w http.ResponseWriter, r *http.Request) {
+	res, err := h.doGetUser(r)
+	h.writeResponse(w, r, http.StatusOK, res, err)
+}
+
+func (h *handler) doGetUser(r *http.Request) (*user, error) {
+	u, err := h.lookupUser(r)
+	if err != nil {
+		return nil, err
+	}
+	return h.userResource(r.Context(), u)
+}
+
+func (h *handler) createUser(w http.ResponseWriter, r *http.Request) {
+	res, err := h.doCreateUser(r)
+	h.writeResponse(w, r, http.StatusCreated, res, err)
+}
+
+func (h *handler) doCreateUser(r *http.Request) (*user, error) {
+	ctx := r.Context()
+
+	var res user
+	if err := decodeBody(r, &res); err != nil {
+		return nil, err
+	}
+	username, err := normalizeUsername(res.UserName)
+	if err != nil {
+		return nil, err
+	}
+	email := res.primaryEmail()
+	if err := h.checkEmailsAvailable(ctx, 0, res.Emails); err != nil {
+		return nil, err
+	}
+
+	newUser := database.NewUser{
+		Username:        username,
+		DisplayName:     res.displayName(),
+		Email:           email,
+		EmailIsVerified: email != "",
+	}
+
+	var id int32
+	if res.ExternalID != "" {
+		id, err = h.db.UserExternalAccounts().CreateUserAndSave(ctx, newUser, accountSpec(res.ExternalID), extsvc.AccountData{})
+	} else {
+		var created *types.User
+		if created, err = h.db.Users().Create(ctx, newUser); err == nil {
+			id = created.ID
+		}
+	}
+	if database.IsUsernameExists(err) || database.IsEmailExists(err) {
+		return nil, errConflict("user %q already exists", res.UserName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.syncEmails(ctx, id, res.Emails); err != nil {
+		return nil, err
+	}
+	if !res.active() {
+		if err := h.deactivateUser(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	u, err := h.getUserIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return h.userResource(ctx, u)
+}
+
+func (h *handler) replaceUser(w http.ResponseWriter, r *http.Request) {
+	res, err := h.doReplaceUser(r)
+	h.writeResponse(w, r, http.StatusOK, res, err)
+}
+
+func (h *handler) doReplaceUser(r *http.Request) (*user, error) {
+	u, err := h.lookupUser(r)
+	if err != nil {
+		return nil, err
+	}
+	var res user
+	if err := decodeBody(r, &res); err != nil {
+		return nil, err
+	}
+	return h.updateUser(r.Context(), u, &res)
+}
+
+func (h *handler) patchUser(w http.ResponseWriter, r *http.Request) {
+	res, err := h.doPatchUser(r)
+	h.writeResponse(w, r, http.StatusOK, res, err)
+}
+
+func (h *handler) doPatchUser(r *http.Request) (*user, error) {
+	ctx := r.Context()
+
+	u, err := h.lookupUser(r)
+	if err != nil {
+		return nil, err
+	}
+	var req patchRequest
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+	res, err := h.userResource(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyPatch(res, req.Operations); err != nil {
+		return nil, err
+	}
+	return h.updateUser(ctx, u, res)
+}
+
+// updateUser updates u to match the desired state of res and returns the
+// updated resource. Deactivating a user deprovisions it; all other attributes
+// are then left unchanged.
+func (h *handler) updateUser(ctx context.Context, u *types.User, res *user) (*user, error) {
+	if !res.active() {
+		if u.DeletedAt == nil {
+			if err := h.deactivateUser(ctx, u.ID); err != nil {
+				return nil, err
+			}
+		}
+		u, err := h.getUserIncludingDeleted(ctx, u.ID)
+		if err != nil {
+			return nil, err
+		}
+		return h.userResource(ctx, u)
+	}
+
+	if u.DeletedAt != nil {
+		err := h.db.Users().Recover(ctx, u.ID)
+		if database.IsUsernameExists(err) {
+			return nil, errConflict("cannot reactivate user: username %q is taken by another user", u.Username)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	username, err := normalizeUsername(res.UserName)
+	if err != nil {
+		return nil, err
+	}
+	update := database.UserUpdate{}
+	if username != u.Username {
+		update.Username = username
+	}
+	if displayName := res.displayName(); displayName != u.DisplayName {
+		update.DisplayName = &displayName
+	}
+	if update != (database.UserUpdate{}) {
+		err := h.db.Users().Update(ctx, u.ID, update)
+		if database.IsUsernameExists(err) {
+			return nil, errConflict("username %q is taken by another user", username)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := h.checkEmailsAvailable(ctx, u.ID, res.Emails); err != nil {
+		return nil, err
+	}
+	if err := h.syncEmails(ctx, u.ID, res.Emails); err != nil {
+		return nil, err
+	}
+	if err := h.syncExternalID(ctx, u.ID, res.ExternalID); err != nil {
+		return nil, err
+	}
+
+	if u, err = h.getUserIncludingDeleted(ctx, u.ID); err != nil {
+		return nil, err
+	}
+	return h.userResource(ctx, u)
+}
+
+func (h *handler) deleteUser(w http.ResponseWriter, r *http.Request) {
+	err := h.doDeleteUser(r)
+	h.writeResponse(w, r, http.StatusNoContent, nil, err)
+}
+
+func (h *handler) doDeleteUser(r *http.Request) error {
+	ctx := r.Context()
+
+	u, err := h.lookupUser(r)
+	if err != nil {
+		return err
+	}
+	if u.DeletedAt == nil {
+		if err := h.db.Users().InvalidateSessionsByID(ctx, u.ID); err != nil {
+			return err
+		}
+	}
+	// Hard deletion removes the user's access tokens, too.
+	return h.db.Users().HardDelete(ctx, u.ID)
+}
+
+// deactivateUser deprovisions a user: all of its sessions are invalidated, and
+// the user is soft-deleted, which revokes its access tokens. Deactivated users
+// can be reactivated later on.
+func (h *handler) deactivateUser(ctx context.Context, id int32) error {
+	if err := h.db.Users().InvalidateSessionsByID(ctx, id); err != nil {
+		return err
+	}
+	return h.db.Users().Delete(ctx, id)
+}
+
+// lookupUser returns the user identified by the request path, including
+// deactivated users.
+func (h *handler) lookupUser(r *http.Request) (*types.User, error) {
+	id, err := parseID(r, "user")
+	if err != nil {
+		return nil, err
+	}
+	return h.getUserIncludingDeleted(r.Context(), id)
+}
+
+func (h *handler) getUserIncludingDeleted(ctx context.Context, id int32) (*types.User, error) {
+	users, err := h.db.Users().List(ctx, &database.UsersListOptions{UserIDs: []int32{id}, IncludeDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, errNotFound("user", strconv.Itoa(int(id)))
+	}
+	return users[0], nil
+}
+
+// userResource returns the SCIM representation of u.
+func (h *handler) userResource(ctx context.Context, u *types.User) (*user, error) {
+	id := strconv.Itoa(int(u.ID))
+	active := boolean(u.DeletedAt == nil)
+	res := &user{
+		Schemas:     []string{schemaUser},
+		ID:          id,
+		UserName:    u.Username,
+		DisplayName: u.DisplayName,
+		Active:      &active,
+		Meta:        newMeta("User", id, u.CreatedAt, u.UpdatedAt),
+	}
+	if u.DisplayName != "" {
+		res.Name = &name{Formatted: u.DisplayName}
+	}
+
+	emails, err := h.db.UserEmails().ListByUser(ctx, database.UserEmailsListOptions{UserID: u.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range emails {
+		res.Emails = append(res.Emails, email{Value: e.Email, Type: "work", Primary: boolean(e.Primary)})
+	}
+
+	// The external accounts of deactivated users were soft-deleted along with
+	// them.
+	deleted := sqlf.Sprintf("deleted_at IS NULL")
+	if u.DeletedAt != nil {
+		deleted = sqlf.Sprintf("deleted_at=%s", *u.DeletedAt)
+	}
+	accounts, err := h.db.UserExternalAccounts().ListBySQL(ctx, sqlf.Sprintf(
+		"WHERE user_id=%s AND service_type=%s AND service_id=%s AND client_id=%s AND %s ORDER BY id DESC",
+		u.ID, serviceType, serviceID, clientID, deleted,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) > 0 {
+		res.ExternalID = accounts[0].AccountID
+	}
+	return res, nil
+}
+
+// checkEmailsAvailable returns a conflict error if any of the given email
+// addresses is verified by another user than userID.
+func (h *handler) checkEmailsAvailable(ctx context.Context, userID int32, emails []email) error {
+	if len(emails) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(emails))
+	for _, e := range emails {
+		values = append(values, e.Value)
+	}
+	verified, err := h.db.UserEmails().GetVerifiedEmails(ctx, values...)
+	if err != nil {
+		return err
+	}
+	for _, e := range verified {
+		if e.UserID != userID {
+			return errConflict("email address %q belongs to another user", e.Email)
+		}
+	}
+	return nil
+}
+
+// syncEmails adds the provisioned email addresses that the user doesn't have
+// yet as verified addresses, and sets its primary email address. Addresses
+// that the user added themselves are left unchanged.
+func (h *handler) syncEmails(ctx context.Context, userID int32, emails []email) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	existing, err := h.db.UserEmails().ListByUser(ctx, database.UserEmailsListOptions{UserID: userID})
+	if err != nil {
+		return err
+	}
+	have := make(map[string]*database.UserEmail, len(existing))
+	for _, e := range existing {
+		have[strings.ToLower(e.Email)] = e
+	}
+
+	for _, e := range emails {
+		current, ok := have[strings.ToLower(e.Value)]
+		if !ok {
+			if err := h.db.UserEmails().Add(ctx, userID, e.Value, nil); err != nil {
+				return err
+			}
+		}
+		if !ok || current.VerifiedAt == nil {
+			if err := h.db.UserEmails().SetVerified(ctx, userID, e.Value, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	primary := (&user{Emails: emails}).primaryEmail()
+	if current, ok := have[strings.ToLower(primary)]; ok && current.Primary {
+		return nil
+	}
+	return h.db.UserEmails().SetPrimaryEmail(ctx, userID, primary)
+}
+
+// syncExternalID records the identity provider's ID of the user.
+func (h *handler) syncExternalID(ctx context.Context, userID int32, externalID string) error {
+	accounts, err := h.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		UserID:      userID,
+		ServiceType: serviceType,
+		ServiceID:   serviceID,
+		ClientID:    clientID,
+	})
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, a := range accounts {
+		if a.AccountID == externalID {
+			found = true
+			continue
+		}
+		if err := h.db.UserExternalAccounts().Delete(ctx, a.ID); err != nil {
+			return err
+		}
+	}
+	if found || externalID == "" {
+		return nil
+	}
+	return h.db.UserExternalAccounts().AssociateUserAndSave(ctx, userID, accountSpec(externalID), extsvc.AccountData{})
+}
+
+func accountSpec(externalID string) extsvc.AccountSpec {
+	return extsvc.AccountSpec{
+		ServiceType: serviceType,
+		ServiceID:   serviceID,
+		ClientID:    clientID,
+		AccountID:   externalID,
+	}
+}
+
+// normalizeUsername converts a SCIM userName, which is often an email address,
+// into a Sourcegraph username the same way SSO sign-ins do.
+func normalizeUsername(userName string) (string, error) {
+	if userName == "" {
+		return "", errBadRequest("invalidValue", "userName is required")
+	}
+	username, err := auth.NormalizeUsername(userName)
+	if err != nil {
+		return "", errBadRequest("invalidValue", "invalid userName: %s", err)
+	}
+	return username, nil
+}
@@ -32,6 +32,11 @@ var (
 		Name: "src_repoupdater_perms_syncer_sync_errors_total",
 		Help: "Total number of permissions sync errors",
 	}, []string{"type"})
+	metricsEventSyncLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_repoupdater_perms_syncer_event_sync_latency_seconds",
+		Help:    "Time from receiving a code host event to finishing the permissions sync it triggered",
+		Buckets: []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+	}, []string{"type"})
 	metricsQueueSize = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "src_repoupdater_perms_syncer_queue_size",
 		Help: "The size of the sync request queue",
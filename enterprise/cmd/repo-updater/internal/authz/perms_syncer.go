@@ -53,7 +53,7 @@ type PermsSyncer struct {
 	// The mockable function to return the current time.
 	clock func() time.Time
 	// The rate limit registry for code hosts.
-	rateLimiterRegistry *ratelimit.Registry
+	rateLimiterRegistry ratelimit.Registry
 	// The time duration of how often to re-compute schedule for users and repositories.
 	scheduleInterval time.Duration
 }
@@ -64,7 +64,7 @@ func NewPermsSyncer(
 	reposStore *repos.Store,
 	permsStore edb.PermsStore,
 	clock func() time.Time,
-	rateLimiterRegistry *ratelimit.Registry,
+	rateLimiterRegistry ratelimit.Registry,
 ) *PermsSyncer {
 	return &PermsSyncer{
 		queue:               newRequestQueue(),
@@ -128,7 +128,7 @@ func (s *PermsSyncer) scheduleUsers(ctx context.Context, users ...scheduledUser)
 // By design, all schedules triggered by user actions are in high priority.
 //
 // This method implements the repoupdater.Server.PermsSyncer in the OSS namespace.
-func (s *PermsSyncer) ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID) {
+func (s *PermsSyncer) ScheduleRepos(ctx context.Context, opts authz.FetchPermsOptions, repoIDs ...api.RepoID) {
 	numberOfRepos := len(repoIDs)
 	if numberOfRepos == 0 {
 		return
@@ -142,6 +142,7 @@ func (s *PermsSyncer) ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID)
 		repos[i] = scheduledRepo{
 			priority: priorityHigh,
 			repoID:   repoIDs[i],
+			options:  opts,
 			// NOTE: Have nextSyncAt with zero value (i.e. not set) gives it higher priority,
 			// as the request is most likely triggered by a user action from OSS namespace.
 		}
@@ -164,6 +165,7 @@ func (s *PermsSyncer) scheduleRepos(ctx context.Context, repos ...scheduledRepo)
 			Priority:   r.priority,
 			Type:       requestTypeRepo,
 			ID:         int32(r.repoID),
+			Options:    r.options,
 			NextSyncAt: r.nextSyncAt,
 			NoPerms:    r.noPerms,
 		})
@@ -743,6 +745,17 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 			return errors.Wrap(s.permsStore.TouchRepoPermissions(ctx, int32(repoID)), "touch repository permissions")
 		}
 
+		// Bitbucket Cloud only exposes repository permissions to workspace
+		// administrators. Like above, we don't want the scheduler to keep retrying.
+		if provider.ServiceType() == extsvc.TypeBitbucketCloud && errcode.IsForbidden(err) {
+			log15.Warn("PermsSyncer.syncRepoPerms.ignoreForbiddenAPIError",
+				"repoID", repo.ID,
+				"err", err,
+				"suggestion", "Bitbucket Cloud app password user must be an administrator of the workspace of the repository",
+			)
+			return errors.Wrap(s.permsStore.TouchRepoPermissions(ctx, int32(repoID)), "touch repository permissions")
+		}
+
 		// Skip repo if unimplemented
 		if errors.Is(err, &authz.ErrUnimplemented{}) {
 			log15.Debug("PermsSyncer.syncRepoPerms.unimplemented", "repoID", repo.ID, "err", err)
@@ -856,14 +869,49 @@ func (s *PermsSyncer) waitForRateLimit(ctx context.Context, serviceID string, n
 func (s *PermsSyncer) syncPerms(ctx context.Context, request *syncRequest) error {
 	defer s.queue.remove(request.Type, request.ID, true)
 
-	var err error
+	// Requests not explicitly triggered by a user must leave part of the code
+	// host rate limits to interactive requests.
+	if request.Priority == priorityLow {
+		ctx = ratelimit.WithPriority(ctx, ratelimit.PriorityBackground)
+	}
+
+	var objectType string
 	switch request.Type {
 	case requestTypeUser:
-		err = s.syncUserPerms(ctx, request.ID, request.NoPerms, request.Options)
+		objectType = edb.PermsSyncObjectUser
 	case requestTypeRepo:
-		err = s.syncRepoPerms(ctx, api.RepoID(request.ID), request.NoPerms, request.Options)
+		objectType = edb.PermsSyncObjectRepo
 	default:
-		err = errors.Errorf("unexpected request type: %v", request.Type)
+		return errors.Errorf("unexpected request type: %v", request.Type)
+	}
+
+	var err error
+	startedAt := s.clock()
+	if request.Type == requestTypeUser {
+		err = s.syncUserPerms(ctx, request.ID, request.NoPerms, request.Options)
+	} else {
+		err = s.syncRepoPerms(ctx, api.RepoID(request.ID), request.NoPerms, request.Options)
+	}
+
+	// Record the outcome so that site admins can find out why a user can or
+	// can't access a repository.
+	outcome := &edb.PermsSyncOutcome{
+		ObjectType: objectType,
+		ObjectID:   request.ID,
+		StartedAt:  startedAt,
+		FinishedAt: s.clock(),
+	}
+	if err != nil {
+		outcome.Error = err.Error()
+	}
+	if recordErr := s.permsStore.RecordSyncOutcome(ctx, outcome); recordErr != nil {
+		log15.Warn("PermsSyncer.syncPerms.recordSyncOutcome", "type", request.Type, "id", request.ID, "err", recordErr)
+	}
+
+	// Measure how long it takes for a change on the code host, such as a removed
+	// collaborator, to be reflected in the stored permissions.
+	if err == nil && !request.Options.TriggeredAt.IsZero() {
+		metricsEventSyncLatency.WithLabelValues(objectType).Observe(outcome.FinishedAt.Sub(request.Options.TriggeredAt).Seconds())
 	}
 
 	return err
@@ -1034,6 +1082,7 @@ type scheduledUser struct {
 type scheduledRepo struct {
 	priority   priority
 	repoID     api.RepoID
+	options    authz.FetchPermsOptions
 	nextSyncAt time.Time
 
 	// Whether the repository has no permissions when scheduled. Currently used
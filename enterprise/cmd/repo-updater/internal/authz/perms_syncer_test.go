@@ -51,7 +51,7 @@ func TestPermsSyncer_ScheduleRepos(t *testing.T) {
 	defer authz.SetProviders(true, nil)
 
 	s := NewPermsSyncer(nil, nil, nil, nil, nil)
-	s.ScheduleRepos(context.Background(), 1)
+	s.ScheduleRepos(context.Background(), authz.FetchPermsOptions{}, 1)
 
 	expHeap := []*syncRequest{
 		{requestMeta: &requestMeta{
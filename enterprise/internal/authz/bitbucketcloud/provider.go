@@ -0,0 +1,245 @@
+// Package bitbucketcloud contains an authorization provider for Bitbucket Cloud.
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// determined from the Bitbucket Cloud API.
+type Provider struct {
+	urn      string
+	client   *bitbucketcloud.Client
+	codeHost *extsvc.CodeHost
+	pageSize int // Page size to use in paginated requests.
+
+	// workspaces are the workspaces whose permissions are synced.
+	workspaces []string
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Bitbucket Cloud authorization provider that uses
+// the given bitbucketcloud.Client to read the permissions of the given
+// workspaces. The client's credentials must belong to an administrator of all
+// workspaces. It assumes usernames of Sourcegraph accounts match 1-1 with
+// nicknames of Bitbucket Cloud users.
+func NewProvider(cli *bitbucketcloud.Client, urn string, baseURL *url.URL, workspaces []string) *Provider {
+	return &Provider{
+		urn:        urn,
+		client:     cli,
+		codeHost:   extsvc.NewCodeHost(baseURL, extsvc.TypeBitbucketCloud),
+		pageSize:   100,
+		workspaces: workspaces,
+	}
+}
+
+// Validate validates that the Provider has administrative access to the
+// permissions of all workspaces it is configured with.
+func (p *Provider) Validate() (problems []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, workspace := range p.workspaces {
+		// The permissions endpoint is only accessible to workspace
+		// administrators. We don't care about the results.
+		if _, _, err := p.client.UserRepoPermissions(ctx, &bitbucketcloud.PageToken{Pagelen: 1}, workspace, ""); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	return problems
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the absolute URL that identifies the Bitbucket Cloud instance
+// this provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "bitbucketCloud".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount satisfies the authz.Provider interface. It looks for a member of
+// the configured workspaces whose nickname matches the username of the user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account, _ []string) (acct *extsvc.Account, err error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	tr, ctx := trace.New(ctx, "bitbucketcloud.authz.provider.FetchAccount", "")
+	defer func() {
+		tr.LogFields(
+			otlog.String("user.name", user.Username),
+			otlog.Int32("user.id", user.ID),
+		)
+
+		if err != nil {
+			tr.SetError(err)
+		}
+
+		tr.Finish()
+	}()
+
+	account, err := p.member(ctx, user.Username)
+	if err != nil || account == nil {
+		return nil, err
+	}
+
+	accountData, err := json.Marshal(account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   account.UUID,
+		},
+		AccountData: extsvc.AccountData{
+			Data: (*json.RawMessage)(&accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns a list of repository IDs (on code host) that the given account
+// has read access on the code host. The repository ID has the same value as it would be
+// used as api.ExternalRepoSpec.ID.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case account.Data == nil:
+		return nil, errors.New("no account data provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	var user bitbucketcloud.Account
+	if err := json.Unmarshal(*account.Data, &user); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling account data")
+	}
+
+	perms := &authz.ExternalUserPermissions{}
+	for _, workspace := range p.workspaces {
+		t := &bitbucketcloud.PageToken{Pagelen: p.pageSize}
+		for first := true; first || t.HasMore(); first = false {
+			repoPerms, next, err := p.client.UserRepoPermissions(ctx, t, workspace, user.UUID)
+			if err != nil {
+				return perms, err
+			}
+
+			for _, perm := range repoPerms {
+				if perm.Repository != nil {
+					perms.Exacts = append(perms.Exacts, extsvc.RepoID(perm.Repository.UUID))
+				}
+			}
+
+			t = next
+		}
+	}
+
+	return perms, nil
+}
+
+// FetchUserPermsByToken is the same as FetchUserPerms, but it only requires a
+// token.
+func (p *Provider) FetchUserPermsByToken(ctx context.Context, token string, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	return nil, &authz.ErrUnimplemented{Feature: "bitbucketcloud.FetchUserPermsByToken"}
+}
+
+// FetchRepoPerms returns a list of user IDs (on code host) who have read access to
+// the given repo on the code host. The user ID has the same value as it would
+// be used as extsvc.Account.AccountID. The returned list includes both direct access
+// and access inherited from groups.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, errors.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	workspace, slug, err := workspaceAndSlug(repo.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []extsvc.AccountID
+	t := &bitbucketcloud.PageToken{Pagelen: p.pageSize}
+	for first := true; first || t.HasMore(); first = false {
+		repoPerms, next, err := p.client.RepoPermissions(ctx, t, workspace, slug)
+		if err != nil {
+			return ids, err
+		}
+
+		for _, perm := range repoPerms {
+			if perm.User != nil {
+				ids = append(ids, extsvc.AccountID(perm.User.UUID))
+			}
+		}
+
+		t = next
+	}
+
+	return ids, nil
+}
+
+// member returns the member of the configured workspaces with the given
+// nickname, or nil if there is none.
+func (p *Provider) member(ctx context.Context, nickname string) (*bitbucketcloud.Account, error) {
+	for _, workspace := range p.workspaces {
+		t := &bitbucketcloud.PageToken{Pagelen: p.pageSize}
+		for first := true; first || t.HasMore(); first = false {
+			members, next, err := p.client.WorkspaceMembers(ctx, t, workspace)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, m := range members {
+				if m.User != nil && m.User.Nickname == nickname {
+					return m.User, nil
+				}
+			}
+
+			t = next
+		}
+	}
+
+	return nil, nil
+}
+
+// workspaceAndSlug extracts the workspace and repository slug from the URI of
+// a Bitbucket Cloud repository, e.g. "bitbucket.org/workspace/slug".
+func workspaceAndSlug(uri string) (workspace, slug string, err error) {
+	parts := strings.Split(uri, "/")
+	if len(parts) < 3 {
+		return "", "", errors.Errorf("unexpected Bitbucket Cloud repository URI %q", uri)
+	}
+	return parts[len(parts)-2], parts[len(parts)-1], nil
+}
@@ -0,0 +1,164 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *Provider {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	apiURL, _ := url.Parse(srv.URL)
+	cli := bitbucketcloud.NewClient(apiURL, http.DefaultClient)
+
+	baseURL, _ := url.Parse("https://bitbucket.org")
+	return NewProvider(cli, "extsvc:bitbucketcloud:1", baseURL, []string{"acme"})
+}
+
+// writePages serves the given values in pages of one, linking to the next page
+// through the "next" field like the Bitbucket Cloud API.
+func writePages(w http.ResponseWriter, r *http.Request, values ...interface{}) {
+	page := 0
+	if p := r.URL.Query().Get("page"); p != "" {
+		fmt.Sscanf(p, "%d", &page)
+	}
+
+	resp := map[string]interface{}{"values": values[page : page+1]}
+	if page+1 < len(values) {
+		next := *r.URL
+		next.Scheme = "http"
+		next.Host = r.Host
+		q := next.Query()
+		q.Set("page", fmt.Sprint(page+1))
+		next.RawQuery = q.Encode()
+		resp["next"] = next.String()
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2.0/workspaces/acme/members" {
+			http.NotFound(w, r)
+			return
+		}
+		writePages(w, r,
+			bitbucketcloud.WorkspaceMembership{User: &bitbucketcloud.Account{UUID: "{1}", Nickname: "alice"}},
+			bitbucketcloud.WorkspaceMembership{User: &bitbucketcloud.Account{UUID: "{2}", Nickname: "bob"}},
+		)
+	})
+
+	acct, err := p.FetchAccount(context.Background(), &types.User{ID: 42, Username: "bob"}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct == nil {
+		t.Fatal("expected an account")
+	}
+	want := extsvc.AccountSpec{
+		ServiceType: extsvc.TypeBitbucketCloud,
+		ServiceID:   "https://bitbucket.org/",
+		AccountID:   "{2}",
+	}
+	if diff := cmp.Diff(want, acct.AccountSpec); diff != "" {
+		t.Fatalf("unexpected account spec (-want +have):\n%s", diff)
+	}
+
+	acct, err = p.FetchAccount(context.Background(), &types.User{ID: 43, Username: "carol"}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct != nil {
+		t.Fatalf("unexpected account for unknown user: %+v", acct)
+	}
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2.0/workspaces/acme/permissions/repositories" {
+			http.NotFound(w, r)
+			return
+		}
+		if have, want := r.URL.Query().Get("q"), `user.uuid="{1}"`; have != want {
+			t.Errorf("unexpected query: have %q, want %q", have, want)
+		}
+		writePages(w, r,
+			bitbucketcloud.RepoPermission{Permission: "read", Repository: &bitbucketcloud.Repo{UUID: "{a}"}},
+			bitbucketcloud.RepoPermission{Permission: "admin", Repository: &bitbucketcloud.Repo{UUID: "{b}"}},
+		)
+	})
+
+	data := json.RawMessage(`{"uuid": "{1}", "nickname": "alice"}`)
+	account := &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: extsvc.TypeBitbucketCloud,
+			ServiceID:   "https://bitbucket.org/",
+			AccountID:   "{1}",
+		},
+		AccountData: extsvc.AccountData{Data: &data},
+	}
+
+	perms, err := p.FetchUserPerms(context.Background(), account, authz.FetchPermsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]extsvc.RepoID{"{a}", "{b}"}, perms.Exacts); diff != "" {
+		t.Fatalf("unexpected permissions (-want +have):\n%s", diff)
+	}
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2.0/workspaces/acme/permissions/repositories/widgets":
+			writePages(w, r,
+				bitbucketcloud.RepoPermission{Permission: "write", User: &bitbucketcloud.Account{UUID: "{1}"}},
+				bitbucketcloud.RepoPermission{Permission: "read", User: &bitbucketcloud.Account{UUID: "{2}"}},
+			)
+		case "/2.0/workspaces/acme/permissions/repositories/secret":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	repo := func(uri string) *extsvc.Repository {
+		return &extsvc.Repository{
+			URI: uri,
+			ExternalRepoSpec: api.ExternalRepoSpec{
+				ID:          "{a}",
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   "https://bitbucket.org/",
+			},
+		}
+	}
+
+	ids, err := p.FetchRepoPerms(context.Background(), repo("bitbucket.org/acme/widgets"), authz.FetchPermsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]extsvc.AccountID{"{1}", "{2}"}, ids); diff != "" {
+		t.Fatalf("unexpected account IDs (-want +have):\n%s", diff)
+	}
+
+	_, err = p.FetchRepoPerms(context.Background(), repo("bitbucket.org/acme/secret"), authz.FetchPermsOptions{})
+	if err == nil {
+		t.Fatal("expected an error for a forbidden repository")
+	}
+}
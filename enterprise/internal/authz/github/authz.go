@@ -9,6 +9,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -41,7 +42,7 @@ func NewAuthzProviders(
 
 	for _, c := range conns {
 		// Initialize authz (permissions) provider.
-		p, err := newAuthzProvider(c.URN, c.Authorization, c.Url, c.Token)
+		p, err := newAuthzProvider(c.URN, c.GitHubConnection)
 		if err != nil {
 			problems = append(problems, err.Error())
 		} else if p == nil {
@@ -83,14 +84,15 @@ func NewAuthzProviders(
 
 // newAuthzProvider instantiates a provider, or returns nil if authorization is disabled.
 // Errors returned are "serious problems".
-func newAuthzProvider(urn string, a *schema.GitHubAuthorization, instanceURL, token string) (*Provider, error) {
+func newAuthzProvider(urn string, c *schema.GitHubConnection) (*Provider, error) {
+	a := c.Authorization
 	if a == nil {
 		return nil, nil
 	}
 
-	ghURL, err := url.Parse(instanceURL)
+	ghURL, err := url.Parse(c.Url)
 	if err != nil {
-		return nil, errors.Errorf("Could not parse URL for GitHub instance %q: %s", instanceURL, err)
+		return nil, errors.Errorf("Could not parse URL for GitHub instance %q: %s", c.Url, err)
 	}
 
 	// Disable by default for now
@@ -100,16 +102,26 @@ func newAuthzProvider(urn string, a *schema.GitHubAuthorization, instanceURL, to
 
 	ttl := time.Duration(a.GroupsCacheTTL) * time.Hour
 
-	return NewProvider(urn, ProviderOptions{
+	opts := ProviderOptions{
 		GitHubURL:      ghURL,
-		BaseToken:      token,
+		BaseToken:      c.Token,
 		GroupsCacheTTL: ttl,
-	}), nil
+	}
+
+	if c.GithubAppID != "" && c.GithubAppPrivateKey != "" && c.GithubAppInstallationID != "" {
+		apiURL, _ := github.APIRoot(ghURL)
+		opts.BaseAuther, err = github.NewInstallationAuthenticatorFromConfig(apiURL, c.GithubAppID, c.GithubAppPrivateKey, c.GithubAppInstallationID, nil)
+		if err != nil {
+			return nil, errors.Errorf("Could not authenticate as GitHub App installation for GitHub instance %q: %s", c.Url, err)
+		}
+	}
+
+	return NewProvider(urn, opts), nil
 }
 
 // ValidateAuthz validates the authorization fields of the given GitHub external
 // service config.
 func ValidateAuthz(cfg *schema.GitHubConnection) error {
-	_, err := newAuthzProvider("", cfg.Authorization, cfg.Url, cfg.Token)
+	_, err := newAuthzProvider("", cfg)
 	return err
 }
@@ -25,6 +25,9 @@ type Provider struct {
 	codeHost *extsvc.CodeHost
 	// groupsCache may be nil if group caching is disabled (negative TTL)
 	groupsCache *cachedGroups
+	// installationAuth is true if the provider authenticates as a GitHub App
+	// installation, whose permissions are not expressed as OAuth scopes.
+	installationAuth bool
 }
 
 type ProviderOptions struct {
@@ -32,14 +35,22 @@ type ProviderOptions struct {
 	GitHubClient *github.V3Client
 	GitHubURL    *url.URL
 
-	BaseToken      string
+	BaseToken string
+	// BaseAuther, if set, takes precedence over BaseToken to authenticate
+	// requests that are not made on behalf of a user, e.g. as a GitHub App
+	// installation.
+	BaseAuther     auth.Authenticator
 	GroupsCacheTTL time.Duration
 }
 
 func NewProvider(urn string, opts ProviderOptions) *Provider {
 	if opts.GitHubClient == nil {
+		var auther auth.Authenticator = &auth.OAuthBearerToken{Token: opts.BaseToken}
+		if opts.BaseAuther != nil {
+			auther = opts.BaseAuther
+		}
 		apiURL, _ := github.APIRoot(opts.GitHubURL)
-		opts.GitHubClient = github.NewV3Client(apiURL, &auth.OAuthBearerToken{Token: opts.BaseToken}, nil)
+		opts.GitHubClient = github.NewV3Client(apiURL, auther, nil)
 	}
 
 	codeHost := extsvc.NewCodeHost(opts.GitHubURL, extsvc.TypeGitHub)
@@ -53,11 +64,14 @@ func NewProvider(urn string, opts ProviderOptions) *Provider {
 		}
 	}
 
+	_, installationAuth := opts.BaseAuther.(*github.InstallationAuthenticator)
+
 	return &Provider{
-		urn:         urn,
-		codeHost:    codeHost,
-		groupsCache: cg,
-		client:      &ClientAdapter{V3Client: opts.GitHubClient},
+		urn:              urn,
+		codeHost:         codeHost,
+		groupsCache:      cg,
+		client:           &ClientAdapter{V3Client: opts.GitHubClient},
+		installationAuth: installationAuth,
 	}
 }
 
@@ -121,6 +135,12 @@ type requiredAuthScope struct {
 func (p *Provider) requiredAuthScopes() []requiredAuthScope {
 	scopes := []requiredAuthScope{}
 
+	if p.installationAuth {
+		// GitHub App installation tokens carry the permissions granted to the
+		// App rather than OAuth scopes.
+		return scopes
+	}
+
 	if p.groupsCache != nil {
 		// Needs extra scope to pull group permissions
 		scopes = append(scopes, requiredAuthScope{
@@ -28,6 +28,7 @@ func Routines(ctx context.Context, batchesStore *store.Store, cf *httpcli.Factor
 
 		newSpecExpireJob(ctx, batchesStore),
 		newCacheEntryCleanerJob(ctx, batchesStore),
+		newScheduledRunsJob(ctx, batchesStore),
 
 		scheduler.NewScheduler(ctx, batchesStore),
 
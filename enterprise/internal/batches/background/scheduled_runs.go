@@ -0,0 +1,312 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+const scheduledRunsInterval = 1 * time.Minute
+
+// newScheduledRunsJob creates a background routine that starts the runs of
+// scheduled batch changes once they are due, and moves the started runs
+// through workspace resolution, execution and finally applying the new batch
+// spec.
+func newScheduledRunsJob(ctx context.Context, s *store.Store) goroutine.BackgroundRoutine {
+	r := &scheduledRunner{store: s}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		scheduledRunsInterval,
+		goroutine.NewHandlerWithErrorMessage("run scheduled batch changes", func(ctx context.Context) error {
+			if err := r.startDueRuns(ctx); err != nil {
+				return errors.Wrap(err, "starting scheduled runs")
+			}
+			if err := r.advanceRuns(ctx); err != nil {
+				return errors.Wrap(err, "advancing scheduled runs")
+			}
+			return nil
+		}),
+	)
+}
+
+type scheduledRunner struct {
+	store *store.Store
+}
+
+// startDueRuns creates a new server-side batch spec from the current batch
+// spec of every scheduled batch change whose next run is due.
+func (r *scheduledRunner) startDueRuns(ctx context.Context) error {
+	now := r.store.Clock()()
+
+	due, _, err := r.store.ListBatchChanges(ctx, store.ListBatchChangesOpts{NextScheduledRunBefore: now})
+	if err != nil {
+		return err
+	}
+
+	for _, batchChange := range due {
+		if err := r.startRun(ctx, batchChange, now); err != nil {
+			log15.Error("failed to start scheduled batch change run", "batchChange", batchChange.ID, "err", err)
+		}
+	}
+	return nil
+}
+
+func (r *scheduledRunner) startRun(ctx context.Context, batchChange *btypes.BatchChange, now time.Time) (err error) {
+	tx, err := r.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Schedule the next run first, so that a run that can't be started isn't
+	// retried every minute.
+	schedule, err := btypes.ParseBatchChangeSchedule(batchChange.Schedule)
+	if err != nil {
+		batchChange.NextScheduledRunAt = time.Time{}
+	} else {
+		batchChange.NextScheduledRunAt = schedule.Next(now)
+	}
+	if err := tx.UpdateBatchChange(ctx, batchChange); err != nil {
+		return err
+	}
+
+	unfinished, err := tx.CountBatchChangeScheduledRuns(ctx, store.CountBatchChangeScheduledRunsOpts{
+		BatchChangeID:  batchChange.ID,
+		OnlyUnfinished: true,
+	})
+	if err != nil {
+		return err
+	}
+	if unfinished > 0 {
+		log15.Info("skipping scheduled batch change run, since the previous run hasn't finished yet", "batchChange", batchChange.ID)
+		return nil
+	}
+
+	run := &btypes.BatchChangeScheduledRun{
+		BatchChangeID: batchChange.ID,
+		State:         btypes.BatchChangeScheduledRunStateResolving,
+	}
+
+	if batchChange.ScheduleUserID == 0 {
+		failScheduledRun(run, now, "The user who scheduled the batch change no longer exists.")
+		return tx.CreateBatchChangeScheduledRun(ctx, run)
+	}
+
+	current, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return err
+	}
+
+	// The batch spec is created on behalf of the user who scheduled the batch
+	// change, so that it's executed with their credentials.
+	userCtx := actor.WithActor(ctx, actor.FromUser(batchChange.ScheduleUserID))
+	spec, err := service.New(tx).CreateBatchSpecFromRaw(userCtx, service.CreateBatchSpecFromRawOpts{
+		RawSpec:          current.RawSpec,
+		NamespaceUserID:  batchChange.NamespaceUserID,
+		NamespaceOrgID:   batchChange.NamespaceOrgID,
+		AllowIgnored:     current.AllowIgnored,
+		AllowUnsupported: current.AllowUnsupported,
+		// The steps are re-executed even if the repositories haven't
+		// changed, since their results can depend on the outside world.
+		NoCache: true,
+	})
+	if err != nil {
+		failScheduledRun(run, now, fmt.Sprintf("Creating the batch spec failed: %s", err))
+		return tx.CreateBatchChangeScheduledRun(ctx, run)
+	}
+
+	run.BatchSpecID = spec.ID
+	return tx.CreateBatchChangeScheduledRun(ctx, run)
+}
+
+// advanceRuns moves all unfinished runs to their next state, if the batch
+// spec of the run is ready for it.
+func (r *scheduledRunner) advanceRuns(ctx context.Context) error {
+	runs, _, err := r.store.ListBatchChangeScheduledRuns(ctx, store.ListBatchChangeScheduledRunsOpts{OnlyUnfinished: true})
+	if err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		if err := r.advanceRun(ctx, run); err != nil {
+			log15.Error("failed to advance scheduled batch change run", "run", run.ID, "err", err)
+		}
+	}
+	return nil
+}
+
+func (r *scheduledRunner) advanceRun(ctx context.Context, run *btypes.BatchChangeScheduledRun) (err error) {
+	tx, err := r.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	now := tx.Clock()()
+	fail := func(msg string) error {
+		failScheduledRun(run, now, msg)
+		return tx.UpdateBatchChangeScheduledRun(ctx, run)
+	}
+
+	if run.BatchSpecID == 0 {
+		return fail("The batch spec of the run has been deleted.")
+	}
+	spec, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: run.BatchSpecID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return fail("The batch spec of the run has been deleted.")
+		}
+		return err
+	}
+
+	batchChange, err := tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: run.BatchChangeID})
+	if err != nil {
+		return err
+	}
+	if batchChange.Closed() {
+		return fail("The batch change has been closed.")
+	}
+	if batchChange.ScheduleUserID == 0 {
+		return fail("The user who scheduled the batch change no longer exists.")
+	}
+
+	userCtx := actor.WithActor(ctx, actor.FromUser(batchChange.ScheduleUserID))
+	svc := service.New(tx)
+
+	switch run.State {
+	case btypes.BatchChangeScheduledRunStateResolving:
+		job, err := tx.GetBatchSpecResolutionJob(ctx, store.GetBatchSpecResolutionJobOpts{BatchSpecID: spec.ID})
+		if err != nil {
+			return err
+		}
+
+		switch job.State {
+		case btypes.BatchSpecResolutionJobStateErrored, btypes.BatchSpecResolutionJobStateFailed:
+			msg := "Resolving the workspaces of the batch spec failed."
+			if job.FailureMessage != nil {
+				msg = fmt.Sprintf("Resolving the workspaces of the batch spec failed: %s", *job.FailureMessage)
+			}
+			return fail(msg)
+
+		case btypes.BatchSpecResolutionJobStateCompleted:
+			if _, err := svc.ExecuteBatchSpec(userCtx, service.ExecuteBatchSpecOpts{BatchSpecRandID: spec.RandID}); err != nil {
+				return fail(fmt.Sprintf("Executing the batch spec failed: %s", err))
+			}
+			run.State = btypes.BatchChangeScheduledRunStateExecuting
+			return tx.UpdateBatchChangeScheduledRun(ctx, run)
+		}
+
+	case btypes.BatchChangeScheduledRunStateExecuting:
+		stats, err := tx.GetBatchSpecStats(ctx, []int64{spec.ID})
+		if err != nil {
+			return err
+		}
+
+		state := btypes.ComputeBatchSpecState(spec, stats[spec.ID])
+		if !state.Finished() {
+			return nil
+		}
+		if state != btypes.BatchSpecStateCompleted {
+			return fail(fmt.Sprintf("The execution of the batch spec has %s.", state))
+		}
+
+		current, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+		if err != nil {
+			return err
+		}
+		if current.RawSpec != spec.RawSpec {
+			return fail("The batch change has been applied with a different batch spec since the run started.")
+		}
+
+		currentSpecs, _, err := tx.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{BatchSpecID: current.ID})
+		if err != nil {
+			return err
+		}
+		newSpecs, _, err := tx.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{BatchSpecID: spec.ID})
+		if err != nil {
+			return err
+		}
+		changed, err := changesetSpecsChanged(currentSpecs, newSpecs)
+		if err != nil {
+			return err
+		}
+
+		if !changed {
+			run.State = btypes.BatchChangeScheduledRunStateUnchanged
+			run.FinishedAt = now
+			return tx.UpdateBatchChangeScheduledRun(ctx, run)
+		}
+
+		if _, err := svc.ApplyBatchChange(userCtx, service.ApplyBatchChangeOpts{
+			BatchSpecRandID:     spec.RandID,
+			EnsureBatchChangeID: batchChange.ID,
+		}); err != nil {
+			return fail(fmt.Sprintf("Applying the batch spec failed: %s", err))
+		}
+
+		run.State = btypes.BatchChangeScheduledRunStateApplied
+		run.FinishedAt = now
+		return tx.UpdateBatchChangeScheduledRun(ctx, run)
+	}
+
+	return nil
+}
+
+func failScheduledRun(run *btypes.BatchChangeScheduledRun, now time.Time, msg string) {
+	run.State = btypes.BatchChangeScheduledRunStateFailed
+	run.FailureMessage = &msg
+	run.FinishedAt = now
+}
+
+// changesetSpecsChanged returns true if the given sets of changeset specs
+// would result in different changesets. The base revisions of the specs are
+// ignored, so that new commits on the base branches alone don't cause a new
+// batch spec to be applied.
+func changesetSpecsChanged(current, next btypes.ChangesetSpecs) (bool, error) {
+	if len(current) != len(next) {
+		return true, nil
+	}
+
+	keys := func(specs btypes.ChangesetSpecs) ([]string, error) {
+		ks := make([]string, 0, len(specs))
+		for _, s := range specs {
+			desc := *s.Spec
+			desc.BaseRev = ""
+			k, err := json.Marshal(&desc)
+			if err != nil {
+				return nil, err
+			}
+			ks = append(ks, fmt.Sprintf("%d:%s", s.RepoID, k))
+		}
+		sort.Strings(ks)
+		return ks, nil
+	}
+
+	currentKeys, err := keys(current)
+	if err != nil {
+		return false, err
+	}
+	nextKeys, err := keys(next)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range currentKeys {
+		if currentKeys[i] != nextKeys[i] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
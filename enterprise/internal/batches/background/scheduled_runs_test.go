@@ -0,0 +1,73 @@
+package background
+
+import (
+	"testing"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestChangesetSpecsChanged(t *testing.T) {
+	spec := func(repo int32, baseRev, diff string) *btypes.ChangesetSpec {
+		return &btypes.ChangesetSpec{
+			RepoID: api.RepoID(repo),
+			Spec: &batcheslib.ChangesetSpec{
+				BaseRef: "refs/heads/main",
+				BaseRev: baseRev,
+				HeadRef: "refs/heads/my-branch",
+				Title:   "Bump dependencies",
+				Commits: []batcheslib.GitCommitDescription{{Message: "Bump dependencies", Diff: diff}},
+			},
+		}
+	}
+
+	tcs := map[string]struct {
+		current btypes.ChangesetSpecs
+		next    btypes.ChangesetSpecs
+		want    bool
+	}{
+		"same specs": {
+			current: btypes.ChangesetSpecs{spec(1, "a", "diff-1"), spec(2, "a", "diff-2")},
+			next:    btypes.ChangesetSpecs{spec(1, "a", "diff-1"), spec(2, "a", "diff-2")},
+			want:    false,
+		},
+		"different order": {
+			current: btypes.ChangesetSpecs{spec(1, "a", "diff-1"), spec(2, "a", "diff-2")},
+			next:    btypes.ChangesetSpecs{spec(2, "a", "diff-2"), spec(1, "a", "diff-1")},
+			want:    false,
+		},
+		"only new base revisions": {
+			current: btypes.ChangesetSpecs{spec(1, "a", "diff-1")},
+			next:    btypes.ChangesetSpecs{spec(1, "b", "diff-1")},
+			want:    false,
+		},
+		"diff changed": {
+			current: btypes.ChangesetSpecs{spec(1, "a", "diff-1")},
+			next:    btypes.ChangesetSpecs{spec(1, "b", "diff-2")},
+			want:    true,
+		},
+		"changeset added": {
+			current: btypes.ChangesetSpecs{spec(1, "a", "diff-1")},
+			next:    btypes.ChangesetSpecs{spec(1, "a", "diff-1"), spec(2, "a", "diff-2")},
+			want:    true,
+		},
+		"changeset moved to another repository": {
+			current: btypes.ChangesetSpecs{spec(1, "a", "diff-1")},
+			next:    btypes.ChangesetSpecs{spec(2, "a", "diff-1")},
+			want:    true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			have, err := changesetSpecsChanged(tc.current, tc.next)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Errorf("wrong result: have %t, want %t", have, tc.want)
+			}
+		})
+	}
+}
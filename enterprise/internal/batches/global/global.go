@@ -14,3 +14,22 @@ func DefaultReconcilerEnqueueState() btypes.ReconcilerState {
 	}
 	return btypes.ReconcilerStateQueued
 }
+
+// ReconcilerEnqueueState returns the reconciler state that should be used when
+// enqueuing the given changeset with the given current spec. Changesets that
+// are about to be published and whose spec has a rollout policy are always
+// scheduled, so that the scheduler can hold them back according to the policy.
+// Otherwise, this is the same as DefaultReconcilerEnqueueState.
+func ReconcilerEnqueueState(c *btypes.Changeset, spec *btypes.ChangesetSpec) btypes.ReconcilerState {
+	if spec != nil && spec.Spec.Rollout != nil && !c.Published() && publicationRequested(c, spec) {
+		return btypes.ReconcilerStateScheduled
+	}
+	return DefaultReconcilerEnqueueState()
+}
+
+func publicationRequested(c *btypes.Changeset, spec *btypes.ChangesetSpec) bool {
+	if !spec.Spec.Published.Nil() {
+		return !spec.Spec.Published.False()
+	}
+	return c.UiPublicationState != nil && *c.UiPublicationState != btypes.ChangesetUiPublicationStateUnpublished
+}
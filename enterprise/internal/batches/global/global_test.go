@@ -6,6 +6,7 @@ import (
 	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -36,3 +37,62 @@ func TestDefaultReconcilerEnqueueState(t *testing.T) {
 		}
 	})
 }
+
+func TestReconcilerEnqueueState(t *testing.T) {
+	bt.MockConfig(t, &conf.Unified{})
+
+	rollout := &batches.RolloutPolicy{Rate: "10/hour"}
+	published := btypes.ChangesetUiPublicationStatePublished
+
+	for name, tc := range map[string]struct {
+		changeset *btypes.Changeset
+		spec      *btypes.ChangesetSpec
+		want      btypes.ReconcilerState
+	}{
+		"no spec": {
+			changeset: &btypes.Changeset{PublicationState: btypes.ChangesetPublicationStateUnpublished},
+			want:      btypes.ReconcilerStateQueued,
+		},
+		"no rollout policy": {
+			changeset: &btypes.Changeset{PublicationState: btypes.ChangesetPublicationStateUnpublished},
+			spec:      &btypes.ChangesetSpec{Spec: &batches.ChangesetSpec{Published: batches.PublishedValue{Val: true}}},
+			want:      btypes.ReconcilerStateQueued,
+		},
+		"published in spec": {
+			changeset: &btypes.Changeset{PublicationState: btypes.ChangesetPublicationStateUnpublished},
+			spec:      &btypes.ChangesetSpec{Spec: &batches.ChangesetSpec{Published: batches.PublishedValue{Val: true}, Rollout: rollout}},
+			want:      btypes.ReconcilerStateScheduled,
+		},
+		"draft in spec": {
+			changeset: &btypes.Changeset{PublicationState: btypes.ChangesetPublicationStateUnpublished},
+			spec:      &btypes.ChangesetSpec{Spec: &batches.ChangesetSpec{Published: batches.PublishedValue{Val: "draft"}, Rollout: rollout}},
+			want:      btypes.ReconcilerStateScheduled,
+		},
+		"unpublished in spec": {
+			changeset: &btypes.Changeset{PublicationState: btypes.ChangesetPublicationStateUnpublished},
+			spec:      &btypes.ChangesetSpec{Spec: &batches.ChangesetSpec{Published: batches.PublishedValue{Val: false}, Rollout: rollout}},
+			want:      btypes.ReconcilerStateQueued,
+		},
+		"published in UI": {
+			changeset: &btypes.Changeset{PublicationState: btypes.ChangesetPublicationStateUnpublished, UiPublicationState: &published},
+			spec:      &btypes.ChangesetSpec{Spec: &batches.ChangesetSpec{Rollout: rollout}},
+			want:      btypes.ReconcilerStateScheduled,
+		},
+		"not published in UI": {
+			changeset: &btypes.Changeset{PublicationState: btypes.ChangesetPublicationStateUnpublished},
+			spec:      &btypes.ChangesetSpec{Spec: &batches.ChangesetSpec{Rollout: rollout}},
+			want:      btypes.ReconcilerStateQueued,
+		},
+		"already published": {
+			changeset: &btypes.Changeset{PublicationState: btypes.ChangesetPublicationStatePublished},
+			spec:      &btypes.ChangesetSpec{Spec: &batches.ChangesetSpec{Published: batches.PublishedValue{Val: true}, Rollout: rollout}},
+			want:      btypes.ReconcilerStateQueued,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := ReconcilerEnqueueState(tc.changeset, tc.spec); have != tc.want {
+				t.Errorf("unexpected state: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
@@ -259,7 +259,7 @@ func (b *bulkProcessor) publishChangeset(ctx context.Context, job *btypes.Change
 		return errcode.MakeNonRetryable(err)
 	}
 
-	if err := b.tx.EnqueueChangeset(ctx, b.ch, global.DefaultReconcilerEnqueueState(), ""); err != nil {
+	if err := b.tx.EnqueueChangeset(ctx, b.ch, global.ReconcilerEnqueueState(b.ch, spec), ""); err != nil {
 		log15.Error("EnqueueChangeset", "err", err)
 		return errcode.MakeNonRetryable(err)
 	}
@@ -0,0 +1,62 @@
+package reconciler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// autoMergeBlockedReason evaluates the given auto-merge policy against the
+// current state of the changeset. It returns an empty string if the changeset
+// can be merged, and otherwise an explanation why it isn't merged yet.
+func autoMergeBlockedReason(policy *batcheslib.AutoMergePolicy, ch *btypes.Changeset, now time.Time) string {
+	if ch.ExternalState == btypes.ChangesetExternalStateDraft {
+		return "The changeset is a draft."
+	}
+
+	if ch.ExternalReviewState == btypes.ChangesetReviewStateChangesRequested {
+		return "Changes have been requested by a reviewer."
+	}
+
+	if policy.RequiredApprovals > 0 {
+		events, err := ch.Events()
+		if err != nil {
+			return fmt.Sprintf("The reviews of the changeset could not be loaded: %s", err)
+		}
+		approvals, err := state.ComputeApprovals(events)
+		if err != nil {
+			return fmt.Sprintf("The reviews of the changeset could not be loaded: %s", err)
+		}
+		if approvals < policy.RequiredApprovals {
+			return fmt.Sprintf("Waiting for approvals: %d of %d required approvals.", approvals, policy.RequiredApprovals)
+		}
+	}
+
+	if policy.CheckState() == batcheslib.AutoMergeCheckStatePassed {
+		switch ch.ExternalCheckState {
+		case btypes.ChangesetCheckStatePassed:
+		case btypes.ChangesetCheckStatePending:
+			return "Waiting for checks to pass."
+		case btypes.ChangesetCheckStateFailed:
+			return "Checks have failed."
+		default:
+			return "No checks have reported a state yet."
+		}
+	}
+
+	if w := policy.MergeWindow; w != nil {
+		mergeWindow, err := window.ParseWindow(w.Days, w.Start, w.End)
+		if err != nil {
+			return fmt.Sprintf("The merge window is invalid: %s", err)
+		}
+		if !mergeWindow.IsOpen(now) {
+			return fmt.Sprintf("Outside of the merge window. It opens next at %s.", mergeWindow.NextOpenAfter(now).Format(time.RFC3339))
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,156 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestAutoMergeBlockedReason(t *testing.T) {
+	t.Parallel()
+
+	// A Monday.
+	now := time.Date(2021, 10, 4, 12, 0, 0, 0, time.UTC)
+
+	approvedBy := func(logins ...string) *github.PullRequest {
+		pr := &github.PullRequest{}
+		for i, login := range logins {
+			pr.TimelineItems = append(pr.TimelineItems, github.TimelineItem{
+				Type: "PullRequestReview",
+				Item: &github.PullRequestReview{
+					DatabaseID: int64(i + 1),
+					State:      "APPROVED",
+					UpdatedAt:  now.Add(-time.Hour),
+					Author:     github.Actor{Login: login},
+				},
+			})
+		}
+		return pr
+	}
+
+	tcs := []struct {
+		name      string
+		policy    batcheslib.AutoMergePolicy
+		changeset btypes.Changeset
+		want      string
+	}{
+		{
+			name:   "mergeable",
+			policy: batcheslib.AutoMergePolicy{},
+			changeset: btypes.Changeset{
+				ExternalState:      btypes.ChangesetExternalStateOpen,
+				ExternalCheckState: btypes.ChangesetCheckStatePassed,
+			},
+			want: "",
+		},
+		{
+			name:   "draft",
+			policy: batcheslib.AutoMergePolicy{},
+			changeset: btypes.Changeset{
+				ExternalState:      btypes.ChangesetExternalStateDraft,
+				ExternalCheckState: btypes.ChangesetCheckStatePassed,
+			},
+			want: "The changeset is a draft.",
+		},
+		{
+			name:   "changes requested",
+			policy: batcheslib.AutoMergePolicy{},
+			changeset: btypes.Changeset{
+				ExternalState:       btypes.ChangesetExternalStateOpen,
+				ExternalReviewState: btypes.ChangesetReviewStateChangesRequested,
+				ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			},
+			want: "Changes have been requested by a reviewer.",
+		},
+		{
+			name:   "not enough approvals",
+			policy: batcheslib.AutoMergePolicy{RequiredApprovals: 2},
+			changeset: btypes.Changeset{
+				ExternalState:      btypes.ChangesetExternalStateOpen,
+				ExternalCheckState: btypes.ChangesetCheckStatePassed,
+				Metadata:           approvedBy("alice"),
+			},
+			want: "Waiting for approvals: 1 of 2 required approvals.",
+		},
+		{
+			name:   "enough approvals",
+			policy: batcheslib.AutoMergePolicy{RequiredApprovals: 2},
+			changeset: btypes.Changeset{
+				ExternalState:      btypes.ChangesetExternalStateOpen,
+				ExternalCheckState: btypes.ChangesetCheckStatePassed,
+				Metadata:           approvedBy("alice", "bob"),
+			},
+			want: "",
+		},
+		{
+			name:   "checks pending",
+			policy: batcheslib.AutoMergePolicy{},
+			changeset: btypes.Changeset{
+				ExternalState:      btypes.ChangesetExternalStateOpen,
+				ExternalCheckState: btypes.ChangesetCheckStatePending,
+			},
+			want: "Waiting for checks to pass.",
+		},
+		{
+			name:   "checks failed",
+			policy: batcheslib.AutoMergePolicy{},
+			changeset: btypes.Changeset{
+				ExternalState:      btypes.ChangesetExternalStateOpen,
+				ExternalCheckState: btypes.ChangesetCheckStateFailed,
+			},
+			want: "Checks have failed.",
+		},
+		{
+			name:   "no checks",
+			policy: batcheslib.AutoMergePolicy{},
+			changeset: btypes.Changeset{
+				ExternalState:      btypes.ChangesetExternalStateOpen,
+				ExternalCheckState: btypes.ChangesetCheckStateUnknown,
+			},
+			want: "No checks have reported a state yet.",
+		},
+		{
+			name:   "any check state",
+			policy: batcheslib.AutoMergePolicy{RequiredCheckState: batcheslib.AutoMergeCheckStateAny},
+			changeset: btypes.Changeset{
+				ExternalState:      btypes.ChangesetExternalStateOpen,
+				ExternalCheckState: btypes.ChangesetCheckStateFailed,
+			},
+			want: "",
+		},
+		{
+			name: "inside merge window",
+			policy: batcheslib.AutoMergePolicy{
+				MergeWindow: &batcheslib.AutoMergeWindow{Days: []string{"monday"}, Start: "09:00", End: "17:00"},
+			},
+			changeset: btypes.Changeset{
+				ExternalState:      btypes.ChangesetExternalStateOpen,
+				ExternalCheckState: btypes.ChangesetCheckStatePassed,
+			},
+			want: "",
+		},
+		{
+			name: "outside merge window",
+			policy: batcheslib.AutoMergePolicy{
+				MergeWindow: &batcheslib.AutoMergeWindow{Days: []string{"tuesday"}, Start: "09:00", End: "17:00"},
+			},
+			changeset: btypes.Changeset{
+				ExternalState:      btypes.ChangesetExternalStateOpen,
+				ExternalCheckState: btypes.ChangesetCheckStatePassed,
+			},
+			want: "Outside of the merge window. It opens next at 2021-10-05T09:00:00Z.",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if have := autoMergeBlockedReason(&tc.policy, &tc.changeset, now); have != tc.want {
+				t.Errorf("wrong reason: have %q, want %q", have, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package reconciler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// codeOwnersPaths are the locations in which code hosts look for a CODEOWNERS
+// file, in the order in which they are checked.
+var codeOwnersPaths = []string{
+	"CODEOWNERS",
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// codeOwnersRule is a single line of a CODEOWNERS file.
+type codeOwnersRule struct {
+	pattern codeOwnersPattern
+	owners  []string
+}
+
+// codeOwners is a parsed CODEOWNERS file.
+type codeOwners []codeOwnersRule
+
+// readCodeOwners reads and parses the CODEOWNERS file of the repository at the
+// given commit. If the repository doesn't have a CODEOWNERS file, nil is
+// returned.
+func readCodeOwners(ctx context.Context, repo api.RepoName, commit api.CommitID) (codeOwners, error) {
+	for _, path := range codeOwnersPaths {
+		content, err := git.ReadFile(ctx, repo, commit, path, 0, authz.DefaultSubRepoPermsChecker)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "reading %s", path)
+		}
+		return parseCodeOwners(content)
+	}
+	return nil, nil
+}
+
+// codeOwnersSectionHeader matches the header of a GitLab section, such as
+// "[Section]", "^[Optional section]" or "[Section][2]". The owners following
+// the header are the default owners of the rules in the section.
+var codeOwnersSectionHeader = lazyregexp.New(`^\^?\[[^\]]*\](\[\d+\])?`)
+
+// parseCodeOwners parses the content of a CODEOWNERS file. Patterns follow
+// the gitignore syntax that GitHub and GitLab use.
+func parseCodeOwners(content []byte) (codeOwners, error) {
+	var (
+		rules         codeOwners
+		sectionOwners []string
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+
+		// GitLab sections group rules, but don't change how they match.
+		// Rules without owners in a section have its default owners.
+		if header := codeOwnersSectionHeader.FindString(line); header != "" {
+			sectionOwners = strings.Fields(line[len(header):])
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pattern, err := compileCodeOwnersPattern(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid CODEOWNERS pattern %q", fields[0])
+		}
+		owners := fields[1:]
+		if len(owners) == 0 {
+			owners = sectionOwners
+		}
+		rules = append(rules, codeOwnersRule{pattern: pattern, owners: owners})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// codeOwnersPattern matches a path if any of its globs matches it. The globs
+// are compiled separately, since alternatives in a single glob don't match
+// "**" reliably.
+type codeOwnersPattern []glob.Glob
+
+func (p codeOwnersPattern) Match(path string) bool {
+	for _, g := range p {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileCodeOwnersPattern compiles a gitignore-style pattern into globs that
+// match paths relative to the root of the repository.
+func compileCodeOwnersPattern(pattern string) (codeOwnersPattern, error) {
+	// A pattern with a slash at the beginning or in the middle is relative to
+	// the root, otherwise it matches at any depth.
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var alternatives []string
+	switch last := pattern[strings.LastIndex(strings.TrimSuffix(pattern, "/"), "/")+1:]; {
+	case strings.HasSuffix(pattern, "/"):
+		// A pattern that ends with a slash only matches directories, and
+		// everything in them.
+		alternatives = []string{pattern + "**"}
+	case strings.ContainsAny(last, "*?["):
+		// A wildcard in the last segment only matches the entries
+		// themselves, so "docs/*" doesn't match "docs/a/b.md".
+		alternatives = []string{pattern}
+	default:
+		// A name matches a file or a directory and everything in it.
+		alternatives = []string{pattern, pattern + "/**"}
+	}
+	for _, alt := range alternatives {
+		if !anchored {
+			alternatives = append(alternatives, "**/"+alt)
+		} else if strings.HasPrefix(alt, "**/") {
+			// A leading "**/" also matches at the root.
+			alternatives = append(alternatives, strings.TrimPrefix(alt, "**/"))
+		}
+	}
+
+	compiled := make(codeOwnersPattern, 0, len(alternatives))
+	for _, alt := range alternatives {
+		g, err := glob.Compile(alt, '/')
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, g)
+	}
+	return compiled, nil
+}
+
+// Owners returns the owners of the given path. The last matching rule wins,
+// as on the code hosts.
+func (o codeOwners) Owners(path string) []string {
+	for i := len(o) - 1; i >= 0; i-- {
+		if o[i].pattern.Match(path) {
+			return o[i].owners
+		}
+	}
+	return nil
+}
+
+// reviewersForDiff returns the users and teams owning the files changed in
+// the given diff. Owners given as email addresses are skipped, since they
+// can't be requested as reviewers by name.
+func (o codeOwners) reviewersForDiff(rawDiff string) (users, teams []string, err error) {
+	seen := map[string]struct{}{}
+
+	reader := diff.NewMultiFileDiffReader(strings.NewReader(rawDiff))
+	for {
+		fileDiff, err := reader.ReadFile()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "parsing diff")
+		}
+
+		for _, name := range []string{
+			strings.TrimPrefix(fileDiff.OrigName, "a/"),
+			strings.TrimPrefix(fileDiff.NewName, "b/"),
+		} {
+			if name == "/dev/null" || name == "" {
+				continue
+			}
+
+			for _, owner := range o.Owners(name) {
+				if !strings.HasPrefix(owner, "@") {
+					continue
+				}
+				owner = strings.TrimPrefix(owner, "@")
+				if _, ok := seen[owner]; ok {
+					continue
+				}
+				seen[owner] = struct{}{}
+
+				if strings.Contains(owner, "/") {
+					teams = append(teams, owner)
+				} else {
+					users = append(users, owner)
+				}
+			}
+		}
+	}
+
+	return users, teams, nil
+}
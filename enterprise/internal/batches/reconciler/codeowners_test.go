@@ -0,0 +1,91 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCodeOwners(t *testing.T) {
+	owners, err := parseCodeOwners([]byte(`# Default owners
+*       @global-owner
+
+*.js    @js-owner # inline comment
+/docs/  @sourcegraph/docs-team
+build/  @build-owner
+apps/*/main.go @apps-owner
+config/* @config-owner
+**/logs @logs-owner
+[Section]
+/README.md owner@example.com
+^[Optional section][2] @section-owner
+/scripts/
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]string{
+		"main.go":              {"@global-owner"},
+		"web/index.js":         {"@js-owner"},
+		"docs/index.md":        {"@sourcegraph/docs-team"},
+		"docs/nested/index.js": {"@sourcegraph/docs-team"},
+		"sub/docs/index.md":    {"@global-owner"},
+		"build/Makefile":       {"@build-owner"},
+		"sub/build/Makefile":   {"@build-owner"},
+		"apps/web/main.go":     {"@apps-owner"},
+		"apps/web/sub/main.go": {"@global-owner"},
+		"sub/apps/web/main.go": {"@global-owner"},
+		"README.md":            {"owner@example.com"},
+		"sub/README.md":        {"@global-owner"},
+		"config/app.yaml":      {"@config-owner"},
+		"config/sub/app.yaml":  {"@global-owner"},
+		"logs":                 {"@logs-owner"},
+		"a/logs/today.log":     {"@logs-owner"},
+		"scripts/build.sh":     {"@section-owner"},
+	} {
+		if have := owners.Owners(path); !cmp.Equal(have, want) {
+			t.Errorf("wrong owners for %q: %s", path, cmp.Diff(want, have))
+		}
+	}
+
+	const testDiff = `diff --git a/docs/index.md b/docs/index.md
+index 1111111..2222222 100644
+--- a/docs/index.md
++++ b/docs/index.md
+@@ -1 +1 @@
+-foo
++bar
+diff --git a/web/index.js b/web/index.js
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/web/index.js
+@@ -0,0 +1 @@
++baz
+diff --git a/README.md b/README.md
+index 4444444..5555555 100644
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-foo
++bar
+diff --git a/main.go b/main.go
+index 6666666..7777777 100644
+--- a/main.go
++++ b/main.go
+@@ -1 +1 @@
+-foo
++bar
+`
+	users, teams, err := owners.reviewersForDiff(testDiff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"js-owner", "global-owner"}; !cmp.Equal(users, want) {
+		t.Errorf("wrong users: %s", cmp.Diff(want, users))
+	}
+	if want := []string{"sourcegraph/docs-team"}; !cmp.Equal(teams, want) {
+		t.Errorf("wrong teams: %s", cmp.Diff(want, teams))
+	}
+}
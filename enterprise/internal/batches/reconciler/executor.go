@@ -17,10 +17,12 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
 // executePlan executes the given reconciler plan.
@@ -32,6 +34,7 @@ func executePlan(ctx context.Context, gitserverClient GitserverClient, sourcer s
 		tx:                tx,
 		ch:                plan.Changeset,
 		spec:              plan.ChangesetSpec,
+		delta:             plan.Delta,
 	}
 
 	return e.Run(ctx, plan)
@@ -44,6 +47,7 @@ type executor struct {
 	tx                *store.Store
 	ch                *btypes.Changeset
 	spec              *btypes.ChangesetSpec
+	delta             *ChangesetSpecDelta
 
 	css     sources.ChangesetSource
 	cssErr  error
@@ -57,7 +61,15 @@ type executor struct {
 }
 
 func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
+	autoMergeBlockedReasonChanged := e.ch.AutoMergeBlockedReason != plan.AutoMergeBlockedReason
+	e.ch.AutoMergeBlockedReason = plan.AutoMergeBlockedReason
+
 	if plan.Ops.IsNone() {
+		// Even if there's nothing to do, evaluating the auto-merge policy may
+		// have yielded a new reason for not merging the changeset.
+		if autoMergeBlockedReasonChanged {
+			return e.tx.UpdateChangesetAutoMergeBlockedReason(ctx, e.ch)
+		}
 		return nil
 	}
 
@@ -108,6 +120,12 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationArchive:
 			e.archiveChangeset()
 
+		case btypes.ReconcilerOperationMerge:
+			err = e.mergeChangeset(ctx)
+
+		case btypes.ReconcilerOperationReexecute:
+			err = e.reexecuteChangeset(ctx)
+
 		default:
 			err = errors.Errorf("executor operation %q not implemented", op)
 		}
@@ -164,19 +182,33 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	return e.pushCommit(ctx, opts)
+	if err := e.signCommit(ctx, &opts); err != nil {
+		return err
+	}
+	if err := e.pushCommit(ctx, opts); err != nil {
+		return err
+	}
+
+	// New changes have been pushed, so a previous failure to re-execute the
+	// changeset no longer applies.
+	e.ch.RebaseFailureMessage = nil
+	return nil
 }
 
 // publishChangeset creates the given changeset on its code host.
 func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err error) {
 	cs := &sources.Changeset{
-		Title:      e.spec.Spec.Title,
-		Body:       e.spec.Spec.Body,
-		BaseRef:    e.spec.Spec.BaseRef,
-		HeadRef:    e.spec.Spec.HeadRef,
-		RemoteRepo: e.remoteRepo,
-		TargetRepo: e.targetRepo,
-		Changeset:  e.ch,
+		Title:        e.spec.Spec.Title,
+		Body:         e.spec.Spec.Body,
+		BaseRef:      e.spec.Spec.BaseRef,
+		HeadRef:      e.spec.Spec.HeadRef,
+		RemoteRepo:   e.remoteRepo,
+		TargetRepo:   e.targetRepo,
+		Labels:       e.spec.Spec.Labels,
+		Assignees:    e.spec.Spec.Assignees,
+		Milestone:    e.spec.Spec.Milestone,
+		SyncMetadata: e.syncMetadata(),
+		Changeset:    e.ch,
 	}
 
 	// Depending on the changeset, we may want to add to the body (for example,
@@ -185,6 +217,8 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err erro
 		return errors.Wrapf(err, "decorating body for changeset %d", e.ch.ID)
 	}
 
+	cs.Reviewers = e.reviewers(ctx)
+
 	css, err := e.changesetSource(ctx)
 	if err != nil {
 		return err
@@ -230,6 +264,19 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err erro
 	return nil
 }
 
+// syncMetadata returns whether updating the changeset should replace its
+// labels, assignees and milestone on the code host with those of its spec.
+// That's the case if the spec sets any of them, or if they changed since the
+// previous spec, which includes all of them being removed. Changesets whose
+// specs never set them keep whatever was set on the code host.
+func (e *executor) syncMetadata() bool {
+	spec := e.spec.Spec
+	if len(spec.Labels) > 0 || len(spec.Assignees) > 0 || spec.Milestone != "" {
+		return true
+	}
+	return e.delta != nil && e.delta.MetadataChanged()
+}
+
 func (e *executor) syncChangeset(ctx context.Context) error {
 	if err := e.loadChangeset(ctx); err != nil {
 		if !errors.HasType(err, sources.ChangesetNotFoundError{}) {
@@ -270,17 +317,77 @@ func (e *executor) loadChangeset(ctx context.Context) error {
 	return css.LoadChangeset(ctx, repoChangeset)
 }
 
+// reviewers returns the reviewers that are requested for the changeset when
+// it's published: the users and teams listed in the changeset spec, plus the
+// code owners of the changed files, if requested. Requesting reviewers is
+// best-effort, so code owners that can't be determined are only logged.
+func (e *executor) reviewers(ctx context.Context) sources.Reviewers {
+	var r sources.Reviewers
+
+	spec := e.spec.Spec.Reviewers
+	if spec == nil {
+		return r
+	}
+	r.Users = append(r.Users, spec.Users...)
+	r.Teams = append(r.Teams, spec.Teams...)
+
+	if spec.FromCodeOwners {
+		users, teams, err := e.codeOwners(ctx)
+		if err != nil {
+			log15.Warn("Determining code owners of changeset failed", "changeset", e.ch.ID, "err", err)
+		}
+		r.Users = append(r.Users, users...)
+		r.Teams = append(r.Teams, teams...)
+	}
+
+	r.Users = dedupStrings(r.Users)
+	r.Teams = dedupStrings(r.Teams)
+	return r
+}
+
+// codeOwners returns the users and teams owning the files changed by the
+// changeset, according to the CODEOWNERS file on its base revision.
+func (e *executor) codeOwners(ctx context.Context) (users, teams []string, err error) {
+	owners, err := readCodeOwners(ctx, e.targetRepo.Name, api.CommitID(e.spec.Spec.BaseRev))
+	if err != nil || owners == nil {
+		return nil, nil, err
+	}
+	d, err := e.spec.Spec.Diff()
+	if err != nil {
+		return nil, nil, err
+	}
+	return owners.reviewersForDiff(d)
+}
+
+// dedupStrings removes duplicates from the given slice, keeping the order.
+func dedupStrings(ss []string) []string {
+	seen := make(map[string]struct{}, len(ss))
+	deduped := ss[:0]
+	for _, s := range ss {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		deduped = append(deduped, s)
+	}
+	return deduped
+}
+
 // updateChangeset updates the given changeset's attribute on the code host
 // according to its ChangesetSpec and the delta previously computed.
 func (e *executor) updateChangeset(ctx context.Context) (err error) {
 	cs := sources.Changeset{
-		Title:      e.spec.Spec.Title,
-		Body:       e.spec.Spec.Body,
-		BaseRef:    e.spec.Spec.BaseRef,
-		HeadRef:    e.spec.Spec.HeadRef,
-		RemoteRepo: e.remoteRepo,
-		TargetRepo: e.targetRepo,
-		Changeset:  e.ch,
+		Title:        e.spec.Spec.Title,
+		Body:         e.spec.Spec.Body,
+		BaseRef:      e.spec.Spec.BaseRef,
+		HeadRef:      e.spec.Spec.HeadRef,
+		RemoteRepo:   e.remoteRepo,
+		TargetRepo:   e.targetRepo,
+		Labels:       e.spec.Spec.Labels,
+		Assignees:    e.spec.Spec.Assignees,
+		Milestone:    e.spec.Spec.Milestone,
+		SyncMetadata: e.syncMetadata(),
+		Changeset:    e.ch,
 	}
 
 	// Depending on the changeset, we may want to add to the body (for example,
@@ -375,13 +482,17 @@ func (e *executor) undraftChangeset(ctx context.Context) (err error) {
 	}
 
 	cs := &sources.Changeset{
-		Title:      e.spec.Spec.Title,
-		Body:       e.spec.Spec.Body,
-		BaseRef:    e.spec.Spec.BaseRef,
-		HeadRef:    e.spec.Spec.HeadRef,
-		RemoteRepo: e.remoteRepo,
-		TargetRepo: e.targetRepo,
-		Changeset:  e.ch,
+		Title:        e.spec.Spec.Title,
+		Body:         e.spec.Spec.Body,
+		BaseRef:      e.spec.Spec.BaseRef,
+		HeadRef:      e.spec.Spec.HeadRef,
+		RemoteRepo:   e.remoteRepo,
+		TargetRepo:   e.targetRepo,
+		Labels:       e.spec.Spec.Labels,
+		Assignees:    e.spec.Spec.Assignees,
+		Milestone:    e.spec.Spec.Milestone,
+		SyncMetadata: e.syncMetadata(),
+		Changeset:    e.ch,
 	}
 
 	if err := draftCss.UndraftChangeset(ctx, cs); err != nil {
@@ -390,6 +501,97 @@ func (e *executor) undraftChangeset(ctx context.Context) (err error) {
 	return nil
 }
 
+// mergeChangeset merges the changeset on its code host, as allowed by the
+// auto-merge policy of its changeset spec.
+func (e *executor) mergeChangeset(ctx context.Context) (err error) {
+	css, err := e.changesetSource(ctx)
+	if err != nil {
+		return err
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  e.ch,
+		RemoteRepo: e.remoteRepo,
+		TargetRepo: e.targetRepo,
+	}
+
+	if err := css.MergeChangeset(ctx, cs, e.spec.Spec.AutoMerge.Squash()); err != nil {
+		if errors.HasType(err, sources.ChangesetNotMergeableError{}) {
+			// The code host has requirements of its own that aren't met yet.
+			// Retrying won't help, so we record them as the reason and try
+			// again when the changeset has been synced the next time.
+			e.ch.AutoMergeBlockedReason = err.Error()
+			return nil
+		}
+		return errors.Wrap(err, "merging changeset")
+	}
+	return nil
+}
+
+// reexecuteChangeset re-executes the steps of the workspace that produced the
+// current changeset spec on the latest commit of the base branch. Once the
+// execution completes, the resulting changeset spec becomes the current spec
+// of the changeset, and the new commit is force-pushed by a regular PUSH
+// operation.
+func (e *executor) reexecuteChangeset(ctx context.Context) (err error) {
+	fail := func(msg string) error {
+		e.ch.RebaseFailureMessage = &msg
+		return nil
+	}
+
+	workspace, err := e.tx.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ChangesetSpecID: e.spec.ID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return fail("The changeset was not created by a batch spec executed on Sourcegraph, so its steps cannot be re-executed.")
+		}
+		return errors.Wrap(err, "loading batch spec workspace")
+	}
+
+	jobs, err := e.tx.ListBatchSpecWorkspaceExecutionJobs(ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{
+		BatchSpecWorkspaceIDs: []int64{workspace.ID},
+	})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec workspace execution jobs")
+	}
+
+	jobIDs := make([]int64, 0, len(jobs))
+	for _, j := range jobs {
+		if !j.State.Retryable() {
+			// The workspace is already being re-executed on behalf of another
+			// changeset it produced, so we only need to wait for it.
+			e.ch.Rebasing = true
+			return nil
+		}
+		jobIDs = append(jobIDs, j.ID)
+	}
+
+	commit, err := git.ResolveRevision(ctx, e.targetRepo.Name, e.spec.Spec.BaseRef, git.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrap(err, "resolving base branch")
+	}
+	if string(commit) == workspace.Commit {
+		// Sourcegraph hasn't fetched the new commits of the base branch yet.
+		// The policy is evaluated again when the changeset is synced the next
+		// time.
+		return nil
+	}
+
+	if len(jobIDs) > 0 {
+		if err := e.tx.DeleteBatchSpecWorkspaceExecutionJobs(ctx, jobIDs); err != nil {
+			return errors.Wrap(err, "deleting batch spec workspace execution jobs")
+		}
+	}
+	if err := e.tx.ResetBatchSpecWorkspaceCommit(ctx, workspace.ID, string(commit)); err != nil {
+		return errors.Wrap(err, "updating batch spec workspace")
+	}
+	if err := e.tx.CreateBatchSpecWorkspaceExecutionJobsForWorkspaces(ctx, []int64{workspace.ID}); err != nil {
+		return errors.Wrap(err, "creating batch spec workspace execution job")
+	}
+
+	e.ch.Rebasing = true
+	return nil
+}
+
 // sleep sleeps for 3 seconds.
 func (e *executor) sleep() {
 	if !e.noSleepBeforeSync {
@@ -565,6 +767,68 @@ func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *pro
 	return opts, nil
 }
 
+// signCommit configures the commit to be signed with the signing key of the
+// user that last applied the owning batch change or, if they don't have one,
+// the site-wide signing key. The committer is set to the identity of the key,
+// so that code hosts can verify the signature against the account the public
+// key was added to.
+func (e *executor) signCommit(ctx context.Context, opts *protocol.CreateCommitFromPatchRequest) error {
+	var userID int32
+	if e.ch.OwnedByBatchChangeID != 0 {
+		batchChange, err := loadBatchChange(ctx, e.tx, e.ch.OwnedByBatchChangeID)
+		if err != nil {
+			return errors.Wrap(err, "failed to load owning batch change")
+		}
+		userID = batchChange.LastApplierID
+	}
+
+	key, err := loadSigningKey(ctx, e.tx, userID)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		if conf.Get().BatchChangesRequireSignedCommits {
+			return errMissingSigningKey{}
+		}
+		return nil
+	}
+
+	privateKey, passphrase, err := key.PrivateKey(ctx)
+	if err != nil {
+		return errors.Wrap(err, "loading signing key")
+	}
+
+	opts.Signing = &protocol.CommitSigningConfig{
+		Format:     string(key.Format),
+		PrivateKey: privateKey,
+		Passphrase: passphrase,
+	}
+	opts.CommitInfo.CommitterName = key.CommitterName
+	opts.CommitInfo.CommitterEmail = key.CommitterEmail
+	return nil
+}
+
+// loadSigningKey returns the signing key of the given user, falling back to the
+// site-wide signing key. It returns nil if neither exists.
+func loadSigningKey(ctx context.Context, tx *store.Store, userID int32) (*btypes.SigningKey, error) {
+	if userID != 0 {
+		key, err := tx.GetSigningKey(ctx, store.GetSigningKeyOpts{UserID: userID})
+		if err == nil {
+			return key, nil
+		} else if err != store.ErrNoResults {
+			return nil, errors.Wrap(err, "retrieving user signing key")
+		}
+	}
+
+	key, err := tx.GetSigningKey(ctx, store.GetSigningKeyOpts{Site: true})
+	if err == store.ErrNoResults {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "retrieving site signing key")
+	}
+	return key, nil
+}
+
 type getBatchChanger interface {
 	GetBatchChange(ctx context.Context, opts store.GetBatchChangeOpts) (*btypes.BatchChange, error)
 }
@@ -699,3 +963,13 @@ func (e errNoPushCredentials) Error() string {
 }
 
 func (e errNoPushCredentials) NonRetryable() bool { return true }
+
+// errMissingSigningKey is returned if signed commits are required, but neither
+// the user that applied the last batch change nor the site has a signing key.
+type errMissingSigningKey struct{}
+
+func (e errMissingSigningKey) Error() string {
+	return "commit signing is required by the site configuration (batchChanges.requireSignedCommits), but neither the user that applied the batch change nor the site has a signing key configured"
+}
+
+func (e errMissingSigningKey) NonRetryable() bool { return true }
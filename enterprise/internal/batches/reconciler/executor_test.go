@@ -18,8 +18,10 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/commitsigning"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
 	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
@@ -29,6 +31,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 func TestExecutor_ExecutePlan(t *testing.T) {
@@ -1121,6 +1124,113 @@ func TestLoadRemoteRepo(t *testing.T) {
 	})
 }
 
+func TestLoadSigningKey(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewDB(dbtest.NewDB(t))
+	cstore := store.New(db, &observation.TestContext, et.TestKey{})
+
+	user := ct.CreateTestUser(t, db, false)
+	otherUser := ct.CreateTestUser(t, db, false)
+
+	keypair, err := encryption.GenerateRSAKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	createKey := func(t *testing.T, userID int32) *btypes.SigningKey {
+		t.Helper()
+		key := &btypes.SigningKey{UserID: userID, Format: commitsigning.FormatSSH}
+		if err := cstore.CreateSigningKey(ctx, key, keypair.PrivateKey, keypair.Passphrase); err != nil {
+			t.Fatal(err)
+		}
+		return key
+	}
+
+	t.Run("no keys", func(t *testing.T) {
+		key, err := loadSigningKey(ctx, cstore, user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key != nil {
+			t.Fatalf("unexpected key returned: %+v", key)
+		}
+	})
+
+	userKey := createKey(t, user.ID)
+	siteKey := createKey(t, 0)
+
+	t.Run("user key", func(t *testing.T) {
+		key, err := loadSigningKey(ctx, cstore, user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key == nil || key.ID != userKey.ID {
+			t.Fatalf("wrong key returned: %+v", key)
+		}
+	})
+
+	t.Run("site key fallback", func(t *testing.T) {
+		key, err := loadSigningKey(ctx, cstore, otherUser.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key == nil || key.ID != siteKey.ID {
+			t.Fatalf("wrong key returned: %+v", key)
+		}
+	})
+}
+
+func TestExecutor_SyncMetadata(t *testing.T) {
+	for name, tc := range map[string]struct {
+		spec  batcheslib.ChangesetSpec
+		delta *ChangesetSpecDelta
+		want  bool
+	}{
+		"no metadata, no previous spec": {
+			spec: batcheslib.ChangesetSpec{},
+			want: false,
+		},
+		"no metadata, unchanged": {
+			spec:  batcheslib.ChangesetSpec{},
+			delta: &ChangesetSpecDelta{TitleChanged: true},
+			want:  false,
+		},
+		"labels set": {
+			spec: batcheslib.ChangesetSpec{Labels: []string{"a"}},
+			want: true,
+		},
+		"milestone set": {
+			spec:  batcheslib.ChangesetSpec{Milestone: "v1"},
+			delta: &ChangesetSpecDelta{},
+			want:  true,
+		},
+		"labels removed": {
+			spec:  batcheslib.ChangesetSpec{},
+			delta: &ChangesetSpecDelta{LabelsChanged: true},
+			want:  true,
+		},
+		"assignees removed": {
+			spec:  batcheslib.ChangesetSpec{},
+			delta: &ChangesetSpecDelta{AssigneesChanged: true},
+			want:  true,
+		},
+		"milestone removed": {
+			spec:  batcheslib.ChangesetSpec{},
+			delta: &ChangesetSpecDelta{MilestoneChanged: true},
+			want:  true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := &executor{
+				spec:  &btypes.ChangesetSpec{Spec: &tc.spec},
+				delta: tc.delta,
+			}
+			if have := e.syncMetadata(); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestDecorateChangesetBody(t *testing.T) {
 	ns := database.NewMockNamespaceStore()
 	ns.GetByIDFunc.SetDefaultHook(func(_ context.Context, _ int32, user int32) (*database.Namespace, error) {
@@ -8,6 +8,7 @@ import (
 	"github.com/cockroachdb/errors"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 )
 
 var operationPrecedence = map[btypes.ReconcilerOperation]int{
@@ -23,6 +24,8 @@ var operationPrecedence = map[btypes.ReconcilerOperation]int{
 	btypes.ReconcilerOperationUpdate:       4,
 	btypes.ReconcilerOperationSleep:        5,
 	btypes.ReconcilerOperationSync:         6,
+	btypes.ReconcilerOperationMerge:        7,
+	btypes.ReconcilerOperationReexecute:    8,
 }
 
 type Operations []btypes.ReconcilerOperation
@@ -97,6 +100,10 @@ type Plan struct {
 	// The Delta between a possible previous ChangesetSpec and the current
 	// ChangesetSpec.
 	Delta *ChangesetSpecDelta
+
+	// AutoMergeBlockedReason explains why the changeset isn't merged yet, if
+	// the ChangesetSpec has an auto-merge policy.
+	AutoMergeBlockedReason string
 }
 
 func (p *Plan) AddOp(op btypes.ReconcilerOperation) { p.Ops = append(p.Ops, op) }
@@ -235,6 +242,31 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, ch *btypes.C
 			}
 		}
 
+		isOpen := ch.ExternalState == btypes.ChangesetExternalStateOpen || ch.ExternalState == btypes.ChangesetExternalStateDraft
+
+		// Only re-execute changesets that are otherwise up to date, and don't
+		// retry failed re-executions until new changes have been pushed.
+		if policy := currentSpec.Spec.Rebase; policy != nil && isOpen && pl.Ops.IsNone() && !ch.Rebasing && ch.RebaseFailureMessage == nil {
+			switch ch.Mergeability(currentSpec.Spec.BaseRev) {
+			case btypes.ChangesetMergeabilityConflicting:
+				pl.AddOp(btypes.ReconcilerOperationReexecute)
+			case btypes.ChangesetMergeabilityBehind:
+				if policy.OnBehind() {
+					pl.AddOp(btypes.ReconcilerOperationReexecute)
+				}
+			}
+		}
+
+		if policy := currentSpec.Spec.AutoMerge; policy != nil && isOpen {
+			if !pl.Ops.IsNone() || ch.Rebasing {
+				// The changeset is synced again after it has been updated or
+				// re-executed, which is when the policy is evaluated again.
+				pl.AutoMergeBlockedReason = "The changeset is being updated."
+			} else if pl.AutoMergeBlockedReason = autoMergeBlockedReason(policy, ch, timeutil.Now()); pl.AutoMergeBlockedReason == "" {
+				pl.AddOp(btypes.ReconcilerOperationMerge)
+			}
+		}
+
 	default:
 		return pl, errors.Errorf("unknown changeset publication state: %s", ch.PublicationState)
 	}
@@ -279,6 +311,21 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 	if previous.Spec.BaseRef != current.Spec.BaseRef {
 		delta.BaseRefChanged = true
 	}
+	if !sameStringSet(previous.Spec.Labels, current.Spec.Labels) {
+		delta.LabelsChanged = true
+	}
+	if !sameStringSet(previous.Spec.Assignees, current.Spec.Assignees) {
+		delta.AssigneesChanged = true
+	}
+	if previous.Spec.Milestone != current.Spec.Milestone {
+		delta.MilestoneChanged = true
+	}
+	// Changesets with a rebase policy are pushed again once they have been
+	// executed on a new commit of the base branch, even if the diff is the
+	// same.
+	if current.Spec.Rebase != nil && previous.Spec.BaseRev != current.Spec.BaseRev {
+		delta.Rebased = true
+	}
 
 	// If was set to "draft" and now "true", need to undraft the changeset.
 	// We currently ignore going from "true" to "draft".
@@ -343,6 +390,25 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 	return delta, nil
 }
 
+// sameStringSet returns true if a and b contain the same strings, regardless
+// of their order.
+func sameStringSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]int, len(a))
+	for _, s := range a {
+		set[s]++
+	}
+	for _, s := range b {
+		if set[s] == 0 {
+			return false
+		}
+		set[s]--
+	}
+	return true
+}
+
 type ChangesetSpecDelta struct {
 	TitleChanged         bool
 	BodyChanged          bool
@@ -352,16 +418,24 @@ type ChangesetSpecDelta struct {
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	Rebased              bool
+	LabelsChanged        bool
+	AssigneesChanged     bool
+	MilestoneChanged     bool
 }
 
 func (d *ChangesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
 
 func (d *ChangesetSpecDelta) NeedCommitUpdate() bool {
-	return d.DiffChanged || d.CommitMessageChanged || d.AuthorNameChanged || d.AuthorEmailChanged
+	return d.DiffChanged || d.CommitMessageChanged || d.AuthorNameChanged || d.AuthorEmailChanged || d.Rebased
 }
 
 func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
-	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged
+	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged || d.MetadataChanged()
+}
+
+func (d *ChangesetSpecDelta) MetadataChanged() bool {
+	return d.LabelsChanged || d.AssigneesChanged || d.MilestoneChanged
 }
 
 func (d *ChangesetSpecDelta) AttributesChanged() bool {
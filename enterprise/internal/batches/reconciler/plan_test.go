@@ -6,6 +6,8 @@ import (
 	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 func TestDetermineReconcilerPlan(t *testing.T) {
@@ -206,6 +208,53 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 			},
 			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
 		},
+		{
+			name:         "labels changed on published changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true, Labels: []string{"a", "b"}},
+			currentSpec:  &ct.TestSpecOpts{Published: true, Labels: []string{"a", "c"}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "labels reordered on published changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true, Labels: []string{"a", "b"}},
+			currentSpec:  &ct.TestSpecOpts{Published: true, Labels: []string{"b", "a"}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "assignees and milestone changed on published changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true, Assignees: []string{"alice"}, Milestone: "v1"},
+			currentSpec:  &ct.TestSpecOpts{Published: true, Assignees: []string{"bob"}, Milestone: "v2"},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "labels, assignees and milestone removed on published changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true, Labels: []string{"a"}, Assignees: []string{"alice"}, Milestone: "v1"},
+			currentSpec:  &ct.TestSpecOpts{Published: true},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			// Reviewers are only requested when a changeset is published, so
+			// changing them doesn't update published changesets.
+			name:         "reviewers changed on published changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true, Reviewers: &batcheslib.ChangesetReviewers{Users: []string{"alice"}}},
+			currentSpec:  &ct.TestSpecOpts{Published: true, Reviewers: &batcheslib.ChangesetReviewers{Users: []string{"bob"}, FromCodeOwners: true}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{},
+		},
 		{
 			name:         "commit diff changed on published changeset",
 			previousSpec: &ct.TestSpecOpts{Published: true, CommitDiff: "testDiff"},
@@ -243,6 +292,75 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 			// should be a noop
 			wantOperations: Operations{},
 		},
+		{
+			name:        "conflicting changeset with rebase policy",
+			currentSpec: &ct.TestSpecOpts{Published: true, BaseRev: "new", Rebase: &batcheslib.RebasePolicy{}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata:         &github.PullRequest{Mergeable: "CONFLICTING", BaseRefOid: "new"},
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationReexecute},
+		},
+		{
+			name:        "behind changeset with rebase policy on conflicts",
+			currentSpec: &ct.TestSpecOpts{Published: true, BaseRev: "new", Rebase: &batcheslib.RebasePolicy{}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata:         &github.PullRequest{Mergeable: "MERGEABLE", BaseRefOid: "old"},
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name: "behind changeset with rebase policy when behind",
+			currentSpec: &ct.TestSpecOpts{
+				Published: true,
+				BaseRev:   "new",
+				Rebase:    &batcheslib.RebasePolicy{When: batcheslib.RebaseTriggerBehind},
+			},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata:         &github.PullRequest{Mergeable: "MERGEABLE", BaseRefOid: "old"},
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationReexecute},
+		},
+		{
+			name:        "conflicting changeset with rebase policy already rebasing",
+			currentSpec: &ct.TestSpecOpts{Published: true, BaseRev: "new", Rebase: &batcheslib.RebasePolicy{}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Rebasing:         true,
+				Metadata:         &github.PullRequest{Mergeable: "CONFLICTING", BaseRefOid: "new"},
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "rebased changeset with unchanged diff",
+			previousSpec: &ct.TestSpecOpts{Published: true, BaseRev: "old", Rebase: &batcheslib.RebasePolicy{}},
+			currentSpec:  &ct.TestSpecOpts{Published: true, BaseRev: "new", Rebase: &batcheslib.RebasePolicy{}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:         "new base revision without rebase policy",
+			previousSpec: &ct.TestSpecOpts{Published: true, BaseRev: "old"},
+			currentSpec:  &ct.TestSpecOpts{Published: true, BaseRev: "new"},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+			},
+			wantOperations: Operations{},
+		},
 		{
 			name:         "changeset closed-and-detached will reopen",
 			previousSpec: &ct.TestSpecOpts{Published: true},
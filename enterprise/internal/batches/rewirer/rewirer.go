@@ -101,7 +101,7 @@ func (r *ChangesetRewirer) createChangesetForSpec(repo *types.Repo, spec *btypes
 	newChangeset.SetCurrentSpec(spec)
 
 	// Set up the initial queue state of the changeset.
-	newChangeset.ResetReconcilerState(global.DefaultReconcilerEnqueueState())
+	newChangeset.ResetReconcilerState(global.ReconcilerEnqueueState(newChangeset, spec))
 
 	return newChangeset
 }
@@ -119,7 +119,7 @@ func (r *ChangesetRewirer) updateChangesetToNewSpec(c *btypes.Changeset, spec *b
 	// We need to enqueue it for the changeset reconciler, so the
 	// reconciler wakes up, compares old and new spec and, if
 	// necessary, updates the changesets accordingly.
-	c.ResetReconcilerState(global.DefaultReconcilerEnqueueState())
+	c.ResetReconcilerState(global.ReconcilerEnqueueState(c, spec))
 }
 
 func (r *ChangesetRewirer) createTrackingChangeset(repo *types.Repo, externalID string) *btypes.Changeset {
@@ -0,0 +1,156 @@
+package scheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// heldChangesets enforces the rollout policies of batch changes on top of the
+// global rollout windows: it returns the IDs of the scheduled changesets that
+// must not be enqueued at the given time, because their batch change has
+// published too many changesets recently, too many of its published
+// changesets have failing checks, or the changesets of a previous wave haven't
+// been published yet.
+//
+// Rollout policies only pace the publication of changesets, so scheduled
+// updates to changesets that have already been published are never held.
+// The given changesets are expected to be all changesets owned by batch
+// changes that have scheduled changesets with a rollout policy. Everything is
+// derived from them, so the policies are enforced across scheduler restarts.
+func heldChangesets(changesets []*btypes.RolloutChangeset, now time.Time) []int64 {
+	byBatchChange := map[int64][]*btypes.RolloutChangeset{}
+	for _, c := range changesets {
+		byBatchChange[c.BatchChangeID] = append(byBatchChange[c.BatchChangeID], c)
+	}
+
+	var held []int64
+	for id, cs := range byBatchChange {
+		policy := rolloutPolicy(cs)
+		if policy == nil {
+			continue
+		}
+
+		held = append(held, heldInBatchChange(id, policy, cs, now)...)
+	}
+
+	sort.Slice(held, func(i, j int) bool { return held[i] < held[j] })
+	return held
+}
+
+func heldInBatchChange(batchChangeID int64, policy *batches.RolloutPolicy, cs []*btypes.RolloutChangeset, now time.Time) []int64 {
+	var scheduled []*btypes.RolloutChangeset
+	for _, c := range cs {
+		if c.ReconcilerState == btypes.ReconcilerStateScheduled && c.PendingPublication() {
+			scheduled = append(scheduled, c)
+		}
+	}
+	if len(scheduled) == 0 {
+		return nil
+	}
+
+	all := func() []int64 {
+		ids := make([]int64, 0, len(scheduled))
+		for _, c := range scheduled {
+			ids = append(ids, c.ID)
+		}
+		return ids
+	}
+
+	if rateExceeded(batchChangeID, policy, cs, now) {
+		return all()
+	}
+
+	if failingChecksExceeded(policy, cs) {
+		return all()
+	}
+
+	// The current wave is the first wave that still has changesets waiting to
+	// be published. Changesets in later waves have to wait for it.
+	waves := policy.CompileWaves()
+	current := -1
+	for _, c := range cs {
+		if !c.PendingPublication() {
+			continue
+		}
+		if wave := waves.Wave(c.RepoName); current == -1 || wave < current {
+			current = wave
+		}
+	}
+
+	var held []int64
+	for _, c := range scheduled {
+		if waves.Wave(c.RepoName) > current {
+			held = append(held, c.ID)
+		}
+	}
+	return held
+}
+
+// rateExceeded returns whether the given batch change has published as many
+// changesets as its rollout policy allows in the current period. Changesets
+// that have been enqueued, but not published yet, count as published now.
+func rateExceeded(batchChangeID int64, policy *batches.RolloutPolicy, cs []*btypes.RolloutChangeset, now time.Time) bool {
+	n, per, err := policy.ParseRate()
+	if err != nil {
+		log15.Warn("invalid rollout rate", "batchChange", batchChangeID, "err", err)
+		return false
+	}
+	if n == -1 {
+		return false
+	}
+
+	var published int
+	for _, c := range cs {
+		switch {
+		case c.PendingPublication() && c.ReconcilerState != btypes.ReconcilerStateScheduled:
+			published++
+		case c.PublicationState.Published() && c.PublishedAt.After(now.Add(-per)):
+			published++
+		}
+	}
+
+	return published >= n
+}
+
+// failingChecksExceeded returns whether more of the published changesets have
+// failing checks than the rollout policy allows.
+func failingChecksExceeded(policy *batches.RolloutPolicy, cs []*btypes.RolloutChangeset) bool {
+	if policy.MaxFailingChecks == nil {
+		return false
+	}
+
+	var published, failing int
+	for _, c := range cs {
+		if !c.PublicationState.Published() {
+			continue
+		}
+		switch c.ExternalCheckState {
+		case btypes.ChangesetCheckStateFailed:
+			failing++
+			published++
+		case btypes.ChangesetCheckStatePassed, btypes.ChangesetCheckStatePending:
+			published++
+		}
+	}
+	if published == 0 {
+		return false
+	}
+
+	return failing*100 > *policy.MaxFailingChecks*published
+}
+
+// rolloutPolicy returns the rollout policy of the scheduled changesets among
+// the given changesets, if any.
+func rolloutPolicy(cs []*btypes.RolloutChangeset) *batches.RolloutPolicy {
+	for _, c := range cs {
+		if c.ReconcilerState == btypes.ReconcilerStateScheduled && c.Rollout != nil {
+			return c.Rollout
+		}
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestRolloutHeldChangesets(t *testing.T) {
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	twenty := 20
+
+	changeset := func(id, batchChangeID int64, repo string, state btypes.ReconcilerState, policy *batches.RolloutPolicy) *btypes.RolloutChangeset {
+		return &btypes.RolloutChangeset{
+			ID:               id,
+			BatchChangeID:    batchChangeID,
+			RepoName:         repo,
+			ReconcilerState:  state,
+			PublicationState: btypes.ChangesetPublicationStateUnpublished,
+			Rollout:          policy,
+		}
+	}
+	published := func(id, batchChangeID int64, repo string, checks btypes.ChangesetCheckState, policy *batches.RolloutPolicy) *btypes.RolloutChangeset {
+		c := changeset(id, batchChangeID, repo, btypes.ReconcilerStateCompleted, policy)
+		c.PublicationState = btypes.ChangesetPublicationStatePublished
+		c.ExternalCheckState = checks
+		return c
+	}
+
+	t.Run("waves", func(t *testing.T) {
+		policy := &batches.RolloutPolicy{Waves: []string{"github.com/sourcegraph/canary-*", "github.com/sourcegraph/*"}}
+
+		for name, tc := range map[string]struct {
+			changesets []*btypes.RolloutChangeset
+			want       []int64
+		}{
+			"first wave pending": {
+				changesets: []*btypes.RolloutChangeset{
+					changeset(1, 1, "github.com/sourcegraph/canary-a", btypes.ReconcilerStateScheduled, policy),
+					changeset(2, 1, "github.com/sourcegraph/sourcegraph", btypes.ReconcilerStateScheduled, policy),
+					changeset(3, 1, "github.com/other/repo", btypes.ReconcilerStateScheduled, policy),
+				},
+				want: []int64{2, 3},
+			},
+			"first wave processing": {
+				changesets: []*btypes.RolloutChangeset{
+					changeset(1, 1, "github.com/sourcegraph/canary-a", btypes.ReconcilerStateProcessing, policy),
+					changeset(2, 1, "github.com/sourcegraph/sourcegraph", btypes.ReconcilerStateScheduled, policy),
+				},
+				want: []int64{2},
+			},
+			"first wave published": {
+				changesets: []*btypes.RolloutChangeset{
+					published(1, 1, "github.com/sourcegraph/canary-a", btypes.ChangesetCheckStatePassed, policy),
+					changeset(2, 1, "github.com/sourcegraph/sourcegraph", btypes.ReconcilerStateScheduled, policy),
+					changeset(3, 1, "github.com/other/repo", btypes.ReconcilerStateScheduled, policy),
+				},
+				want: []int64{3},
+			},
+			"first wave failed": {
+				changesets: []*btypes.RolloutChangeset{
+					changeset(1, 1, "github.com/sourcegraph/canary-a", btypes.ReconcilerStateFailed, policy),
+					changeset(2, 1, "github.com/sourcegraph/sourcegraph", btypes.ReconcilerStateScheduled, policy),
+				},
+				want: nil,
+			},
+			"other batch changes unaffected": {
+				changesets: []*btypes.RolloutChangeset{
+					changeset(1, 1, "github.com/sourcegraph/canary-a", btypes.ReconcilerStateScheduled, policy),
+					changeset(2, 1, "github.com/sourcegraph/sourcegraph", btypes.ReconcilerStateScheduled, policy),
+					changeset(3, 2, "github.com/sourcegraph/sourcegraph", btypes.ReconcilerStateScheduled, &batches.RolloutPolicy{}),
+				},
+				want: []int64{2},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have := heldChangesets(tc.changesets, now)
+				if diff := cmp.Diff(tc.want, have); diff != "" {
+					t.Errorf("unexpected held changesets (-want +have):\n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("failing checks", func(t *testing.T) {
+		policy := &batches.RolloutPolicy{MaxFailingChecks: &twenty}
+
+		for name, tc := range map[string]struct {
+			changesets []*btypes.RolloutChangeset
+			want       []int64
+		}{
+			"below maximum": {
+				changesets: []*btypes.RolloutChangeset{
+					published(1, 1, "a", btypes.ChangesetCheckStateFailed, policy),
+					published(2, 1, "b", btypes.ChangesetCheckStatePassed, policy),
+					published(3, 1, "c", btypes.ChangesetCheckStatePassed, policy),
+					published(4, 1, "d", btypes.ChangesetCheckStatePending, policy),
+					published(5, 1, "e", btypes.ChangesetCheckStatePassed, policy),
+					changeset(6, 1, "f", btypes.ReconcilerStateScheduled, policy),
+				},
+				want: nil,
+			},
+			"above maximum": {
+				changesets: []*btypes.RolloutChangeset{
+					published(1, 1, "a", btypes.ChangesetCheckStateFailed, policy),
+					published(2, 1, "b", btypes.ChangesetCheckStatePassed, policy),
+					published(3, 1, "c", btypes.ChangesetCheckStateFailed, policy),
+					published(4, 1, "d", btypes.ChangesetCheckStateUnknown, policy),
+					changeset(5, 1, "e", btypes.ReconcilerStateScheduled, policy),
+					changeset(6, 1, "f", btypes.ReconcilerStateScheduled, policy),
+				},
+				want: []int64{5, 6},
+			},
+			"nothing published": {
+				changesets: []*btypes.RolloutChangeset{
+					changeset(1, 1, "a", btypes.ReconcilerStateScheduled, policy),
+				},
+				want: nil,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have := heldChangesets(tc.changesets, now)
+				if diff := cmp.Diff(tc.want, have); diff != "" {
+					t.Errorf("unexpected held changesets (-want +have):\n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("rate", func(t *testing.T) {
+		policy := &batches.RolloutPolicy{Rate: "2/hour"}
+		publishedAt := func(id int64, at time.Time) *btypes.RolloutChangeset {
+			c := published(id, 1, "x", btypes.ChangesetCheckStatePassed, policy)
+			c.PublishedAt = at
+			return c
+		}
+
+		for name, tc := range map[string]struct {
+			changesets []*btypes.RolloutChangeset
+			want       []int64
+		}{
+			"nothing published": {
+				changesets: []*btypes.RolloutChangeset{
+					changeset(1, 1, "a", btypes.ReconcilerStateScheduled, policy),
+					changeset(2, 1, "b", btypes.ReconcilerStateScheduled, policy),
+				},
+				want: nil,
+			},
+			"enqueued changesets count": {
+				changesets: []*btypes.RolloutChangeset{
+					changeset(1, 1, "a", btypes.ReconcilerStateQueued, policy),
+					changeset(2, 1, "b", btypes.ReconcilerStateProcessing, policy),
+					changeset(3, 1, "c", btypes.ReconcilerStateScheduled, policy),
+					changeset(4, 2, "d", btypes.ReconcilerStateScheduled, &batches.RolloutPolicy{}),
+				},
+				want: []int64{3},
+			},
+			"published in the last period": {
+				changesets: []*btypes.RolloutChangeset{
+					publishedAt(1, now.Add(-59*time.Minute)),
+					changeset(2, 1, "b", btypes.ReconcilerStateQueued, policy),
+					changeset(3, 1, "c", btypes.ReconcilerStateScheduled, policy),
+				},
+				want: []int64{3},
+			},
+			"published before the last period": {
+				changesets: []*btypes.RolloutChangeset{
+					publishedAt(1, now.Add(-time.Hour)),
+					publishedAt(2, now.Add(-2*time.Hour)),
+					changeset(3, 1, "b", btypes.ReconcilerStateQueued, policy),
+					changeset(4, 1, "c", btypes.ReconcilerStateScheduled, policy),
+				},
+				want: nil,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have := heldChangesets(tc.changesets, now)
+				if diff := cmp.Diff(tc.want, have); diff != "" {
+					t.Errorf("unexpected held changesets (-want +have):\n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("updates to published changesets", func(t *testing.T) {
+		policy := &batches.RolloutPolicy{
+			Rate:             "1/hour",
+			Waves:            []string{"github.com/sourcegraph/canary-*"},
+			MaxFailingChecks: &twenty,
+		}
+		update := func(id int64, repo string) *btypes.RolloutChangeset {
+			c := published(id, 1, repo, btypes.ChangesetCheckStateFailed, policy)
+			c.ReconcilerState = btypes.ReconcilerStateScheduled
+			c.PublishedAt = now.Add(-time.Minute)
+			return c
+		}
+
+		// The rate is used up, the checks are failing and no changesets are
+		// waiting to be published, but updates are never held.
+		changesets := []*btypes.RolloutChangeset{
+			update(1, "github.com/sourcegraph/canary-a"),
+			update(2, "github.com/sourcegraph/sourcegraph"),
+		}
+		if have := heldChangesets(changesets, now); len(have) != 0 {
+			t.Errorf("unexpected held changesets: %v", have)
+		}
+
+		// Unpublished changesets next to updates are still held.
+		changesets = append(changesets, changeset(3, 1, "github.com/sourcegraph/canary-b", btypes.ReconcilerStateScheduled, policy))
+		if diff := cmp.Diff([]int64{3}, heldChangesets(changesets, now)); diff != "" {
+			t.Errorf("unexpected held changesets (-want +have):\n%s", diff)
+		}
+	})
+}
@@ -93,7 +93,16 @@ func (s *Scheduler) Stop() {
 }
 
 func (s *Scheduler) enqueueChangeset() error {
-	_, err := s.store.EnqueueNextScheduledChangeset(s.ctx)
+	// Changesets held back by the rollout policy of their batch change must
+	// not be enqueued, even if the global schedule allows it.
+	rolloutChangesets, err := s.store.ListRolloutChangesets(s.ctx)
+	if err != nil {
+		log15.Warn("error listing the changesets of batch changes with a rollout policy", "err", err)
+		return err
+	}
+	_, err = s.store.EnqueueNextScheduledChangeset(s.ctx, store.EnqueueNextScheduledChangesetOpts{
+		ExcludeIDs: heldChangesets(rolloutChangesets, s.store.Clock()()),
+	})
 
 	// Let's see if this is an error caused by there being no changesets to
 	// enqueue (which is fine), or something less expected, in which case we
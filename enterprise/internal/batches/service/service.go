@@ -74,6 +74,7 @@ type operations struct {
 	getNewestBatchSpec                   *observation.Operation
 	moveBatchChange                      *observation.Operation
 	closeBatchChange                     *observation.Operation
+	setBatchChangeSchedule               *observation.Operation
 	deleteBatchChange                    *observation.Operation
 	enqueueChangesetSync                 *observation.Operation
 	reenqueueChangeset                   *observation.Operation
@@ -124,6 +125,7 @@ func newOperations(observationContext *observation.Context) *operations {
 			getNewestBatchSpec:                   op("GetNewestBatchSpec"),
 			moveBatchChange:                      op("MoveBatchChange"),
 			closeBatchChange:                     op("CloseBatchChange"),
+			setBatchChangeSchedule:               op("SetBatchChangeSchedule"),
 			deleteBatchChange:                    op("DeleteBatchChange"),
 			enqueueChangesetSync:                 op("EnqueueChangesetSync"),
 			reenqueueChangeset:                   op("ReenqueueChangeset"),
@@ -718,7 +720,7 @@ func (s *Service) MoveBatchChange(ctx context.Context, opts MoveBatchChangeOpts)
 	}
 
 	// 🚨 SECURITY: Only the Author of the batch change can move it.
-	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+	if err := backend.CheckPermissionOrSameUser(ctx, s.store.DatabaseDB(), database.PermissionBatchChangesAdmin, batchChange.CreatorID); err != nil {
 		return nil, err
 	}
 	// Check if current user has access to target namespace if set.
@@ -758,7 +760,7 @@ func (s *Service) CloseBatchChange(ctx context.Context, id int64, closeChangeset
 		return batchChange, nil
 	}
 
-	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+	if err := backend.CheckPermissionOrSameUser(ctx, s.store.DatabaseDB(), database.PermissionBatchChangesAdmin, batchChange.CreatorID); err != nil {
 		return nil, err
 	}
 
@@ -789,6 +791,53 @@ func (s *Service) CloseBatchChange(ctx context.Context, id int64, closeChangeset
 	return batchChange, nil
 }
 
+var (
+	ErrScheduleDraftBatchChange  = errors.New("cannot schedule a batch change that hasn't been applied yet")
+	ErrScheduleClosedBatchChange = errors.New("cannot schedule a closed batch change")
+)
+
+// SetBatchChangeSchedule sets the cron expression on which the BatchChange
+// with the given ID is re-executed and re-applied. Scheduled runs are executed
+// on behalf of the current user. An empty schedule removes the schedule.
+func (s *Service) SetBatchChangeSchedule(ctx context.Context, id int64, schedule string) (batchChange *btypes.BatchChange, err error) {
+	ctx, endObservation := s.operations.setBatchChangeSchedule.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err = s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: id})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+
+	if err := backend.CheckPermissionOrSameUser(ctx, s.store.DatabaseDB(), database.PermissionBatchChangesAdmin, batchChange.CreatorID); err != nil {
+		return nil, err
+	}
+
+	if schedule == "" {
+		batchChange.Schedule = ""
+		batchChange.ScheduleUserID = 0
+		batchChange.NextScheduledRunAt = time.Time{}
+		return batchChange, s.store.UpdateBatchChange(ctx, batchChange)
+	}
+
+	if batchChange.IsDraft() {
+		return nil, ErrScheduleDraftBatchChange
+	}
+	if batchChange.Closed() {
+		return nil, ErrScheduleClosedBatchChange
+	}
+
+	parsed, err := btypes.ParseBatchChangeSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	batchChange.Schedule = schedule
+	batchChange.ScheduleUserID = actor.FromContext(ctx).UID
+	batchChange.NextScheduledRunAt = parsed.Next(s.clock())
+
+	return batchChange, s.store.UpdateBatchChange(ctx, batchChange)
+}
+
 // DeleteBatchChange deletes the BatchChange with the given ID if it hasn't been
 // deleted yet.
 func (s *Service) DeleteBatchChange(ctx context.Context, id int64) (err error) {
@@ -800,7 +849,7 @@ func (s *Service) DeleteBatchChange(ctx context.Context, id int64) (err error) {
 		return err
 	}
 
-	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+	if err := backend.CheckPermissionOrSameUser(ctx, s.store.DatabaseDB(), database.PermissionBatchChangesAdmin, batchChange.CreatorID); err != nil {
 		return err
 	}
 
@@ -838,7 +887,7 @@ func (s *Service) EnqueueChangesetSync(ctx context.Context, id int64) (err error
 	)
 
 	for _, c := range batchChanges {
-		err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), c.CreatorID)
+		err := backend.CheckPermissionOrSameUser(ctx, s.store.DatabaseDB(), database.PermissionBatchChangesAdmin, c.CreatorID)
 		if err != nil {
 			authErr = err
 		} else {
@@ -889,7 +938,7 @@ func (s *Service) ReenqueueChangeset(ctx context.Context, id int64) (changeset *
 	)
 
 	for _, c := range attachedBatchChanges {
-		err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), c.CreatorID)
+		err := backend.CheckPermissionOrSameUser(ctx, s.store.DatabaseDB(), database.PermissionBatchChangesAdmin, c.CreatorID)
 		if err != nil {
 			authErr = err
 		} else {
@@ -1030,7 +1079,7 @@ func (s *Service) CreateChangesetJobs(ctx context.Context, batchChangeID int64,
 	}
 
 	// 🚨 SECURITY: Only the author of the batch change can create jobs.
-	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+	if err := backend.CheckPermissionOrSameUser(ctx, s.store.DatabaseDB(), database.PermissionBatchChangesAdmin, batchChange.CreatorID); err != nil {
 		return bulkGroupID, err
 	}
 
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/locker"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -62,8 +63,9 @@ func (s *Service) ApplyBatchChange(
 		return nil, err
 	}
 
-	// 🚨 SECURITY: Only site-admins or the creator of batchSpec can apply it.
-	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchSpec.UserID); err != nil {
+	// 🚨 SECURITY: Only site-admins, batch changes admins or the creator of
+	// batchSpec can apply it.
+	if err := backend.CheckPermissionOrSameUser(ctx, s.store.DatabaseDB(), database.PermissionBatchChangesAdmin, batchSpec.UserID); err != nil {
 		return nil, err
 	}
 
@@ -94,6 +96,30 @@ func (s *Service) ApplyBatchChange(
 		return batchChange, nil
 	}
 
+	// Record the applied batch spec in the audit log once the transaction
+	// below is done. CreateBatchChange sets the ID of new batch changes.
+	applied := batchChange
+	defer func() {
+		if err != nil {
+			return
+		}
+		var before interface{}
+		if previousSpecID != 0 {
+			before = map[string]interface{}{"batchSpecID": previousSpecID}
+		}
+		database.SecurityEventLogs(s.store.DatabaseDB()).LogAuditEvent(ctx, database.SecurityEventNameBatchChangeApplied,
+			map[string]interface{}{
+				"batchChangeID":   applied.ID,
+				"name":            applied.Name,
+				"namespaceUserID": applied.NamespaceUserID,
+				"namespaceOrgID":  applied.NamespaceOrgID,
+				"batchSpec":       batchSpec.RandID,
+			},
+			before,
+			map[string]interface{}{"batchSpecID": batchSpec.ID},
+		)
+	}()
+
 	// Before we write to the database in a transaction, we cancel all
 	// currently enqueued/errored-and-retryable changesets the batch change might
 	// have.
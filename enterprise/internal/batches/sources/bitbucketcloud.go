@@ -0,0 +1,328 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type BitbucketCloudSource struct {
+	client *bitbucketcloud.Client
+	au     auth.Authenticator
+}
+
+var _ ForkableChangesetSource = BitbucketCloudSource{}
+
+// NewBitbucketCloudSource returns a new BitbucketCloudSource from the given external service.
+func NewBitbucketCloudSource(svc *types.ExternalService, cf *httpcli.Factory) (*BitbucketCloudSource, error) {
+	var c schema.BitbucketCloudConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newBitbucketCloudSource(&c, cf)
+}
+
+func newBitbucketCloudSource(c *schema.BitbucketCloudConnection, cf *httpcli.Factory) (*BitbucketCloudSource, error) {
+	if c.ApiURL == "" {
+		c.ApiURL = "https://api.bitbucket.org"
+	}
+	apiURL, err := url.Parse(c.ApiURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing Bitbucket Cloud API URL")
+	}
+	apiURL = extsvc.NormalizeBaseURL(apiURL)
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	client := bitbucketcloud.NewClient(apiURL, cli)
+	client.Username = c.Username
+	client.AppPassword = c.AppPassword
+
+	return &BitbucketCloudSource{
+		client: client,
+		au:     &auth.BasicAuth{Username: c.Username, Password: c.AppPassword},
+	}, nil
+}
+
+func (s BitbucketCloudSource) GitserverPushConfig(ctx context.Context, store database.ExternalServiceStore, repo *types.Repo) (*protocol.PushConfig, error) {
+	return gitserverPushConfig(ctx, store, repo, s.au)
+}
+
+func (s BitbucketCloudSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("BitbucketCloudSource", a)
+	}
+
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BitbucketCloudSource{
+		client: client,
+		au:     a,
+	}, nil
+}
+
+func (s BitbucketCloudSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.CurrentUser(ctx)
+	return err
+}
+
+// CreateChangeset creates the given *Changeset in the code host.
+//
+// Bitbucket Cloud doesn't report whether a pull request already existed for
+// the source branch: it updates the existing pull request with the given
+// title and description and returns it instead, so exists is always false.
+func (s BitbucketCloudSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	remoteRepo := c.RemoteRepo.Metadata.(*bitbucketcloud.Repo)
+	targetRepo := c.TargetRepo.Metadata.(*bitbucketcloud.Repo)
+
+	pr, err := s.client.CreatePullRequest(ctx, targetRepo, s.pullRequestInput(c, remoteRepo, targetRepo))
+	if err != nil {
+		return false, errors.Wrap(err, "creating pull request")
+	}
+
+	if err := s.setChangesetMetadata(ctx, targetRepo, pr, c); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// CloseChangeset declines the given *Changeset on the code host and updates
+// the Metadata column in the *batches.Changeset to the declined pull request.
+func (s BitbucketCloudSource) CloseChangeset(ctx context.Context, c *Changeset) error {
+	targetRepo := c.TargetRepo.Metadata.(*bitbucketcloud.Repo)
+	pr, err := s.pullRequest(c)
+	if err != nil {
+		return err
+	}
+
+	declined, err := s.client.DeclinePullRequest(ctx, targetRepo, int64(pr.ID))
+	if err != nil {
+		return errors.Wrap(err, "declining pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, targetRepo, declined, c)
+}
+
+// LoadChangeset loads the latest state of the given Changeset from the codehost.
+func (s BitbucketCloudSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	targetRepo := cs.TargetRepo.Metadata.(*bitbucketcloud.Repo)
+	id, err := strconv.ParseInt(cs.ExternalID, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "parsing changeset external ID")
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, targetRepo, id)
+	if err != nil {
+		if bitbucketcloud.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, targetRepo, pr, cs)
+}
+
+// UpdateChangeset updates the title, description and base branch of the pull
+// request of the given Changeset.
+func (s BitbucketCloudSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
+	remoteRepo := c.RemoteRepo.Metadata.(*bitbucketcloud.Repo)
+	targetRepo := c.TargetRepo.Metadata.(*bitbucketcloud.Repo)
+	pr, err := s.pullRequest(c)
+	if err != nil {
+		return err
+	}
+
+	updated, err := s.client.UpdatePullRequest(ctx, targetRepo, int64(pr.ID), s.pullRequestInput(c, remoteRepo, targetRepo))
+	if err != nil {
+		return errors.Wrap(err, "updating pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, targetRepo, updated, c)
+}
+
+// ReopenChangeset reopens the *Changeset on the code host and updates the
+// Metadata column in the *batches.Changeset.
+//
+// Bitbucket Cloud doesn't support reopening declined pull requests, so a new
+// pull request is opened from the same branch instead. Its ID replaces the
+// external ID of the changeset.
+func (s BitbucketCloudSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
+	pr, err := s.pullRequest(c)
+	if err != nil {
+		return err
+	}
+	if pr.State == bitbucketcloud.PullRequestStateOpen {
+		return nil
+	}
+
+	if _, err := s.CreateChangeset(ctx, c); err != nil {
+		return errors.Wrap(err, "reopening pull request")
+	}
+	return nil
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s BitbucketCloudSource) CreateComment(ctx context.Context, c *Changeset, text string) error {
+	targetRepo := c.TargetRepo.Metadata.(*bitbucketcloud.Repo)
+	pr, err := s.pullRequest(c)
+	if err != nil {
+		return err
+	}
+
+	return s.client.CreatePullRequestComment(ctx, targetRepo, int64(pr.ID), text)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, a squash merge is performed.
+func (s BitbucketCloudSource) MergeChangeset(ctx context.Context, c *Changeset, squash bool) error {
+	targetRepo := c.TargetRepo.Metadata.(*bitbucketcloud.Repo)
+	pr, err := s.pullRequest(c)
+	if err != nil {
+		return err
+	}
+
+	strategy := bitbucketcloud.MergeStrategyMergeCommit
+	if squash {
+		strategy = bitbucketcloud.MergeStrategySquash
+	}
+
+	merged, err := s.client.MergePullRequest(ctx, targetRepo, int64(pr.ID), bitbucketcloud.MergePullRequestOpts{
+		MergeStrategy: &strategy,
+	})
+	if err != nil {
+		// Bitbucket Cloud responds with a 400 if merge checks fail and a 409
+		// if the pull request can't be merged cleanly.
+		if code := bitbucketcloud.HTTPErrorCode(err); code == http.StatusBadRequest || code == http.StatusConflict {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "merging pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, targetRepo, merged, c)
+}
+
+func (s BitbucketCloudSource) GetUserFork(ctx context.Context, targetRepo *types.Repo) (*types.Repo, error) {
+	// Every Bitbucket Cloud user has a personal workspace named after their
+	// username.
+	user, err := s.client.CurrentUser(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting the current user")
+	}
+
+	return s.GetNamespaceFork(ctx, targetRepo, user.Username)
+}
+
+func (s BitbucketCloudSource) GetNamespaceFork(ctx context.Context, targetRepo *types.Repo, namespace string) (*types.Repo, error) {
+	parent := targetRepo.Metadata.(*bitbucketcloud.Repo)
+
+	// See if we already have a fork.
+	fork, err := s.getFork(ctx, parent, namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting fork in %q", namespace)
+	}
+
+	// If not, then we need to create a fork.
+	if fork == nil {
+		fork, err = s.client.ForkRepository(ctx, parent, bitbucketcloud.ForkInput{
+			Workspace: bitbucketcloud.ForkInputWorkspace{Slug: namespace},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating fork in %q", namespace)
+		}
+	}
+
+	return createRemoteRepo(targetRepo, fork), nil
+}
+
+func (s BitbucketCloudSource) getFork(ctx context.Context, parent *bitbucketcloud.Repo, namespace string) (*bitbucketcloud.Repo, error) {
+	repo, err := s.client.Repo(ctx, namespace, parent.Slug)
+	if err != nil {
+		if bitbucketcloud.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// Sanity check: is the returned repo _actually_ a fork of the original?
+	if repo.Parent == nil {
+		return nil, errNotAFork
+	} else if repo.Parent.UUID != parent.UUID {
+		return nil, errNotForkedFromParent
+	}
+
+	return repo, nil
+}
+
+func (s BitbucketCloudSource) pullRequestInput(c *Changeset, remoteRepo, targetRepo *bitbucketcloud.Repo) bitbucketcloud.PullRequestInput {
+	input := bitbucketcloud.PullRequestInput{
+		Title:             c.Title,
+		Description:       c.Body,
+		SourceBranch:      git.AbbreviateRef(c.HeadRef),
+		DestinationBranch: git.AbbreviateRef(c.BaseRef),
+	}
+	// The source repository only needs to be given if it's a fork.
+	if remoteRepo.UUID != targetRepo.UUID {
+		input.SourceRepo = remoteRepo
+	}
+	return input
+}
+
+func (s BitbucketCloudSource) pullRequest(c *Changeset) (*bitbucketcloud.PullRequest, error) {
+	pr, ok := c.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+	if !ok {
+		return nil, errors.New("Changeset is not a Bitbucket Cloud pull request")
+	}
+	return pr, nil
+}
+
+// setChangesetMetadata loads the build statuses of the given pull request,
+// which the API doesn't include in the pull request itself, and sets the pull
+// request as the metadata of the changeset.
+func (s BitbucketCloudSource) setChangesetMetadata(ctx context.Context, repo *bitbucketcloud.Repo, pr *bitbucketcloud.PullRequest, c *Changeset) error {
+	statuses, err := s.client.GetPullRequestStatuses(ctx, repo, int64(pr.ID))
+	if err != nil {
+		return errors.Wrap(err, "loading pull request build statuses")
+	}
+
+	pr.Statuses = make([]*bitbucketcloud.CommitStatus, 0, len(statuses))
+	for _, status := range statuses {
+		pr.Statuses = append(pr.Statuses, &bitbucketcloud.CommitStatus{
+			Commit: status.CommitHash(),
+			Status: *status,
+		})
+	}
+
+	if err := c.Changeset.SetMetadata(pr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
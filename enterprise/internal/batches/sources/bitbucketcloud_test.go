@@ -0,0 +1,221 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestBitbucketCloudSource_CreateChangeset(t *testing.T) {
+	target := &types.Repo{Metadata: &bitbucketcloud.Repo{Slug: "repo", FullName: "upstream/repo", UUID: "{upstream}"}}
+	fork := &types.Repo{Metadata: &bitbucketcloud.Repo{Slug: "repo", FullName: "fork/repo", UUID: "{fork}"}}
+
+	for name, tc := range map[string]struct {
+		remote     *types.Repo
+		wantSource string
+	}{
+		"same repository": {remote: target, wantSource: ""},
+		"fork":            {remote: fork, wantSource: "fork/repo"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := newTestBitbucketCloudSource(t, func(r *http.Request) (*http.Response, error) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/2.0/repositories/upstream/repo/pullrequests":
+					var body struct {
+						Title  string `json:"title"`
+						Source struct {
+							Branch struct {
+								Name string `json:"name"`
+							} `json:"branch"`
+							Repository *struct {
+								FullName string `json:"full_name"`
+							} `json:"repository"`
+						} `json:"source"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Fatal(err)
+					}
+					assert.Equal(t, "title", body.Title)
+					assert.Equal(t, "branch", body.Source.Branch.Name)
+					var source string
+					if body.Source.Repository != nil {
+						source = body.Source.Repository.FullName
+					}
+					assert.Equal(t, tc.wantSource, source)
+					return bitbucketCloudResponse(201, `{"id":3,"state":"OPEN","source":{"branch":{"name":"branch"}}}`), nil
+
+				case r.URL.Path == "/2.0/repositories/upstream/repo/pullrequests/3/statuses":
+					return bitbucketCloudResponse(200, `{"values":[{"key":"ci","state":"SUCCESSFUL","links":{"commit":{"href":"https://api.bitbucket.org/2.0/repositories/upstream/repo/commit/deadbeef"}}}]}`), nil
+				}
+				return bitbucketCloudResponse(404, ""), nil
+			})
+
+			cs := &Changeset{
+				Title:      "title",
+				HeadRef:    "refs/heads/branch",
+				BaseRef:    "refs/heads/main",
+				RemoteRepo: tc.remote,
+				TargetRepo: target,
+				Changeset:  &btypes.Changeset{},
+			}
+			exists, err := s.CreateChangeset(context.Background(), cs)
+			assert.Nil(t, err)
+			assert.False(t, exists)
+			assert.Equal(t, "3", cs.ExternalID)
+			assert.Equal(t, "refs/heads/branch", cs.ExternalBranch)
+
+			pr := cs.Metadata.(*bitbucketcloud.PullRequest)
+			assert.Len(t, pr.Statuses, 1)
+			assert.Equal(t, "deadbeef", pr.Statuses[0].Commit)
+		})
+	}
+}
+
+func TestBitbucketCloudSource_LoadChangeset(t *testing.T) {
+	s := newTestBitbucketCloudSource(t, func(r *http.Request) (*http.Response, error) {
+		return bitbucketCloudResponse(404, `{"type":"error"}`), nil
+	})
+
+	repo := &types.Repo{Metadata: &bitbucketcloud.Repo{FullName: "upstream/repo"}}
+	cs := &Changeset{
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Changeset:  &btypes.Changeset{ExternalID: "42"},
+	}
+
+	err := s.LoadChangeset(context.Background(), cs)
+	var notFound ChangesetNotFoundError
+	assert.True(t, errors.As(err, &notFound))
+}
+
+func TestBitbucketCloudSource_MergeChangeset(t *testing.T) {
+	for name, tc := range map[string]struct {
+		status       int
+		notMergeable bool
+	}{
+		"not mergeable": {status: 400, notMergeable: true},
+		"other error":   {status: 500},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := newTestBitbucketCloudSource(t, func(r *http.Request) (*http.Response, error) {
+				var body struct {
+					MergeStrategy string `json:"merge_strategy"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, "squash", body.MergeStrategy)
+				return bitbucketCloudResponse(tc.status, `{"type":"error"}`), nil
+			})
+
+			repo := &types.Repo{Metadata: &bitbucketcloud.Repo{FullName: "upstream/repo"}}
+			cs := &Changeset{
+				RemoteRepo: repo,
+				TargetRepo: repo,
+				Changeset:  &btypes.Changeset{Metadata: &bitbucketcloud.PullRequest{ID: 1}},
+			}
+
+			err := s.MergeChangeset(context.Background(), cs, true)
+			assert.NotNil(t, err)
+			var e ChangesetNotMergeableError
+			assert.Equal(t, tc.notMergeable, errors.As(err, &e))
+		})
+	}
+}
+
+func TestBitbucketCloudSource_GetNamespaceFork(t *testing.T) {
+	upstream := &bitbucketcloud.Repo{Slug: "repo", FullName: "upstream/repo", UUID: "{upstream}"}
+	target := &types.Repo{Metadata: upstream}
+
+	t.Run("existing fork", func(t *testing.T) {
+		s := newTestBitbucketCloudSource(t, func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/2.0/repositories/fork/repo", r.URL.Path)
+			return bitbucketCloudResponse(200, `{"full_name":"fork/repo","uuid":"{fork}","parent":{"uuid":"{upstream}"}}`), nil
+		})
+
+		remote, err := s.GetNamespaceFork(context.Background(), target, "fork")
+		assert.Nil(t, err)
+		assert.Equal(t, "fork/repo", remote.Metadata.(*bitbucketcloud.Repo).FullName)
+	})
+
+	t.Run("not a fork", func(t *testing.T) {
+		s := newTestBitbucketCloudSource(t, func(r *http.Request) (*http.Response, error) {
+			return bitbucketCloudResponse(200, `{"full_name":"fork/repo","uuid":"{fork}"}`), nil
+		})
+
+		_, err := s.GetNamespaceFork(context.Background(), target, "fork")
+		assert.ErrorIs(t, err, errNotAFork)
+	})
+
+	t.Run("new fork", func(t *testing.T) {
+		s := newTestBitbucketCloudSource(t, func(r *http.Request) (*http.Response, error) {
+			if r.Method == "GET" {
+				return bitbucketCloudResponse(404, ""), nil
+			}
+			assert.Equal(t, "/2.0/repositories/upstream/repo/forks", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"workspace":{"slug":"fork"}}`, string(body))
+			return bitbucketCloudResponse(201, `{"full_name":"fork/repo","uuid":"{fork}","parent":{"uuid":"{upstream}"}}`), nil
+		})
+
+		remote, err := s.GetNamespaceFork(context.Background(), target, "fork")
+		assert.Nil(t, err)
+		assert.Equal(t, "fork/repo", remote.Metadata.(*bitbucketcloud.Repo).FullName)
+	})
+}
+
+func TestBitbucketCloudSource_WithAuthenticator(t *testing.T) {
+	s, err := newBitbucketCloudSource(&schema.BitbucketCloudConnection{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, a := range map[string]auth.Authenticator{
+		"BasicAuth":        &auth.BasicAuth{},
+		"BasicAuthWithSSH": &auth.BasicAuthWithSSH{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.WithAuthenticator(a)
+			assert.Nil(t, err)
+		})
+	}
+
+	_, err = s.WithAuthenticator(&auth.OAuthBearerToken{})
+	var e UnsupportedAuthenticatorError
+	assert.True(t, errors.As(err, &e))
+}
+
+func newTestBitbucketCloudSource(t *testing.T, doer httpcli.DoerFunc) *BitbucketCloudSource {
+	t.Helper()
+	cf := httpcli.NewFactory(func(httpcli.Doer) httpcli.Doer { return doer })
+	s, err := newBitbucketCloudSource(&schema.BitbucketCloudConnection{
+		Url:         "https://bitbucket.org",
+		Username:    "user",
+		AppPassword: "password",
+	}, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func bitbucketCloudResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+	}
+}
@@ -107,6 +107,10 @@ func (s BitbucketServerSource) CreateChangeset(ctx context.Context, c *Changeset
 	targetRepo := c.TargetRepo.Metadata.(*bitbucketserver.Repo)
 
 	pr := &bitbucketserver.PullRequest{Title: c.Title, Description: c.Body}
+	// Bitbucket Server has no team review requests, so teams are ignored.
+	for _, name := range c.Reviewers.Users {
+		pr.Reviewers = append(pr.Reviewers, bitbucketserver.Reviewer{User: &bitbucketserver.User{Name: name}})
+	}
 
 	pr.ToRef.Repository.Slug = targetRepo.Slug
 	pr.ToRef.Repository.ID = targetRepo.ID
@@ -274,7 +278,7 @@ func (s BitbucketServerSource) MergeChangeset(ctx context.Context, c *Changeset,
 	merged, err := s.callAndRetryIfOutdated(ctx, c, s.client.MergePullRequest)
 	if err != nil {
 		if bitbucketserver.IsMergePreconditionFailedException(err) {
-			return &ChangesetNotMergeableError{ErrorMsg: err.Error()}
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
 		}
 		return err
 	}
@@ -366,11 +370,13 @@ func (s BitbucketServerSource) GetNamespaceFork(ctx context.Context, targetRepo
 	return createRemoteRepo(targetRepo, fork), nil
 }
 
-func createRemoteRepo(targetRepo *types.Repo, fork *bitbucketserver.Repo) *types.Repo {
+// createRemoteRepo returns a copy of targetRepo with the given fork, which
+// must be a *bitbucketserver.Repo or a *bitbucketcloud.Repo, as its metadata.
+func createRemoteRepo(targetRepo *types.Repo, fork interface{}) *types.Repo {
 	// We have to make a legitimate seeming *types.Repo.
-	// bitbucketServerCloneURL() ultimately only looks at the
-	// bitbucketserver.Repo in the Metadata field, so we'll replace that with
-	// the fork's metadata, and all should be well.
+	// bitbucketServerCloneURL() and bitbucketCloudCloneURL() ultimately only
+	// look at the repo in the Metadata field, so we'll replace that with the
+	// fork's metadata, and all should be well.
 	remoteRepo := *targetRepo
 	remoteRepo.Metadata = fork
 
@@ -119,9 +119,34 @@ type Changeset struct {
 	// opened.
 	TargetRepo *types.Repo
 
+	// Reviewers are requested to review the changeset when it is created.
+	Reviewers Reviewers
+
+	// Labels, Assignees and Milestone are set on the changeset when it is
+	// created.
+	Labels    []string
+	Assignees []string
+	Milestone string
+	// SyncMetadata makes updating the changeset also replace its labels,
+	// assignees and milestone on the code host with Labels, Assignees and
+	// Milestone, removing those that aren't listed.
+	SyncMetadata bool
+
 	*btypes.Changeset
 }
 
+// Reviewers are the users and teams that are requested to review a
+// changeset. Code hosts without team review requests ignore Teams.
+type Reviewers struct {
+	Users []string
+	Teams []string
+}
+
+// IsEmpty returns true if no reviewers are set.
+func (r Reviewers) IsEmpty() bool {
+	return len(r.Users) == 0 && len(r.Teams) == 0
+}
+
 // IsOutdated returns true when the attributes of the nested
 // batches.Changeset do not match the attributes (title, body, ...) set on
 // the Changeset.
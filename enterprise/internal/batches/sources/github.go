@@ -4,9 +4,11 @@ import (
 	"context"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
@@ -143,16 +145,17 @@ func buildCreatePullRequestInput(c *Changeset) (*github.CreatePullRequestInput,
 
 func (s GithubSource) createChangeset(ctx context.Context, c *Changeset, prInput *github.CreatePullRequestInput) (bool, error) {
 	var exists bool
+	repo := c.TargetRepo.Metadata.(*github.Repository)
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return exists, errors.Wrap(err, "getting repo owner and name")
+	}
+
 	pr, err := s.client.CreatePullRequest(ctx, prInput)
 	if err != nil {
 		if err != github.ErrPullRequestAlreadyExists {
 			return exists, err
 		}
-		repo := c.TargetRepo.Metadata.(*github.Repository)
-		owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
-		if err != nil {
-			return exists, errors.Wrap(err, "getting repo owner and name")
-		}
 		pr, err = s.client.GetOpenPullRequestByRefs(ctx, owner, name, c.BaseRef, c.HeadRef)
 		if err != nil {
 			return exists, errors.Wrap(err, "fetching existing PR")
@@ -160,6 +163,28 @@ func (s GithubSource) createChangeset(ctx context.Context, c *Changeset, prInput
 		exists = true
 	}
 
+	if !c.Reviewers.IsEmpty() {
+		// Requesting reviews is idempotent, so we also do it for pull requests
+		// that already existed, in case a previous attempt failed. It's
+		// best-effort, though: the pull request exists at this point, and
+		// failing here would only make every retry fail the same way.
+		skipped, err := s.client.RequestPullRequestReviewers(ctx, pr, c.Reviewers.Users, githubTeams(owner, c.Reviewers.Teams))
+		if err != nil {
+			log15.Warn("Requesting reviewers for GitHub pull request failed", "repo", repo.NameWithOwner, "number", pr.Number, "err", err)
+		} else if len(skipped) > 0 {
+			log15.Warn("Skipped reviewers that can't be requested for GitHub pull request", "repo", repo.NameWithOwner, "number", pr.Number, "reviewers", skipped)
+		}
+	}
+
+	if len(c.Labels) > 0 || len(c.Assignees) > 0 || c.Milestone != "" {
+		// Like requesting reviewers, this is best-effort: labels, assignees
+		// or milestones that don't exist on the code host would otherwise
+		// keep the existing pull request from ever being tracked.
+		if err := s.client.SetPullRequestMetadata(ctx, owner, name, pr, c.Labels, c.Assignees, c.Milestone); err != nil {
+			log15.Warn("Setting labels, assignees and milestone of GitHub pull request failed", "repo", repo.NameWithOwner, "number", pr.Number, "err", err)
+		}
+	}
+
 	if err := c.SetMetadata(pr); err != nil {
 		return false, errors.Wrap(err, "setting changeset metadata")
 	}
@@ -167,6 +192,19 @@ func (s GithubSource) createChangeset(ctx context.Context, c *Changeset, prInput
 	return exists, nil
 }
 
+// githubTeams qualifies team slugs without an organization with the owner of
+// the repository.
+func githubTeams(owner string, teams []string) []string {
+	qualified := make([]string, 0, len(teams))
+	for _, t := range teams {
+		if !strings.Contains(t, "/") {
+			t = owner + "/" + t
+		}
+		qualified = append(qualified, t)
+	}
+	return qualified
+}
+
 // CloseChangeset closes the given *Changeset on the code host and updates the
 // Metadata column in the *batches.Changeset to the newly closed pull request.
 func (s GithubSource) CloseChangeset(ctx context.Context, c *Changeset) error {
@@ -243,6 +281,17 @@ func (s GithubSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
 		return err
 	}
 
+	if c.SyncMetadata {
+		repo := c.TargetRepo.Metadata.(*github.Repository)
+		owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+		if err != nil {
+			return errors.Wrap(err, "getting repo owner and name")
+		}
+		if err := s.client.SetPullRequestMetadata(ctx, owner, name, updated, c.Labels, c.Assignees, c.Milestone); err != nil {
+			return errors.Wrap(err, "setting labels, assignees and milestone")
+		}
+	}
+
 	return c.Changeset.SetMetadata(updated)
 }
 
@@ -3,6 +3,9 @@ package sources
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
@@ -15,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/testutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -130,6 +134,65 @@ func TestGithubSource_CreateChangeset(t *testing.T) {
 	}
 }
 
+func TestGithubSource_CreateChangeset_MetadataBestEffort(t *testing.T) {
+	repo := &types.Repo{
+		Metadata: &github.Repository{
+			ID:            "MDEwOlJlcG9zaXRvcnkyMjExNDc1MTM=",
+			NameWithOwner: "sourcegraph/automation-testing",
+		},
+	}
+
+	var requests []string
+	cli := httpcli.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, string(body))
+
+		// Creating the pull request succeeds, but the label can't be
+		// resolved.
+		response := `{"errors": [{"message": "label not found"}]}`
+		if strings.Contains(string(body), "createPullRequest") {
+			response = `{"data": {"createPullRequest": {"pullRequest": {"id": "PR_1", "number": 42, "title": "Test"}}}}`
+		}
+		return &http.Response{
+			Request:    req,
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(response)),
+		}, nil
+	})
+
+	apiURL, _ := url.Parse("https://api.github.com")
+	src := &GithubSource{client: github.NewV4Client(apiURL, &auth.OAuthBearerToken{Token: "token"}, cli)}
+
+	cs := &Changeset{
+		Title:      "Test",
+		HeadRef:    "refs/heads/test",
+		BaseRef:    "refs/heads/main",
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Labels:     []string{"does-not-exist"},
+		Changeset:  &btypes.Changeset{},
+	}
+
+	exists, err := src.CreateChangeset(context.Background(), cs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exists {
+		t.Fatal("unexpected existing changeset")
+	}
+	if len(requests) < 2 {
+		t.Fatalf("expected the labels to be resolved after creating the pull request, got %d requests", len(requests))
+	}
+	// The pull request is tracked even though its labels couldn't be set.
+	if have, want := cs.Changeset.ExternalID, "42"; have != want {
+		t.Fatalf("unexpected external ID: have %q, want %q", have, want)
+	}
+}
+
 func TestGithubSource_CloseChangeset(t *testing.T) {
 	// Repository used: sourcegraph/automation-testing
 	//
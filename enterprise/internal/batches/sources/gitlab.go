@@ -4,8 +4,10 @@ import (
 	"context"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
@@ -116,6 +118,17 @@ func (s *GitLabSource) CreateChangeset(ctx context.Context, c *Changeset) (bool,
 		targetProjectID = c.TargetRepo.Metadata.(*gitlab.Project).ID
 	}
 
+	// GitLab has no team review requests, so teams are ignored.
+	reviewerIDs := s.reviewerIDs(ctx, c.Reviewers.Users)
+	assigneeIDs, err := s.userIDs(ctx, c.Assignees)
+	if err != nil {
+		return exists, errors.Wrap(err, "looking up assignees")
+	}
+	milestoneID, err := s.milestoneID(ctx, targetProject, c.Milestone)
+	if err != nil {
+		return exists, err
+	}
+
 	// We have to create the merge request against the remote project, not the
 	// target project, because that's how GitLab's API works: you provide the
 	// target project ID as one of the parameters. Yes, this is weird.
@@ -128,6 +141,10 @@ func (s *GitLabSource) CreateChangeset(ctx context.Context, c *Changeset) (bool,
 		TargetProjectID: targetProjectID,
 		Title:           c.Title,
 		Description:     c.Body,
+		ReviewerIDs:     reviewerIDs,
+		AssigneeIDs:     assigneeIDs,
+		Labels:          strings.Join(c.Labels, ","),
+		MilestoneID:     milestoneID,
 	})
 	if err != nil {
 		if err == gitlab.ErrMergeRequestAlreadyExists {
@@ -153,6 +170,55 @@ func (s *GitLabSource) CreateChangeset(ctx context.Context, c *Changeset) (bool,
 	return exists, nil
 }
 
+// userIDs looks up the IDs of the users with the given usernames.
+func (s *GitLabSource) userIDs(ctx context.Context, usernames []string) ([]int32, error) {
+	ids := make([]int32, 0, len(usernames))
+	for _, username := range usernames {
+		user, err := s.client.GetUserByUsername(ctx, username)
+		if err != nil {
+			return nil, errors.Wrapf(err, "looking up user %q", username)
+		}
+		if user == nil {
+			return nil, errors.Errorf("user %q not found", username)
+		}
+		ids = append(ids, user.ID)
+	}
+	return ids, nil
+}
+
+// reviewerIDs looks up the IDs of the users with the given usernames on a
+// best-effort basis: names that can't be resolved to a user, such as groups
+// listed in a CODEOWNERS file or deleted accounts, are skipped, so that they
+// don't keep the merge request from being created.
+func (s *GitLabSource) reviewerIDs(ctx context.Context, usernames []string) []int32 {
+	ids := make([]int32, 0, len(usernames))
+	for _, username := range usernames {
+		user, err := s.client.GetUserByUsername(ctx, username)
+		if err != nil || user == nil {
+			log15.Warn("Skipping GitLab reviewer that can't be resolved", "username", username, "err", err)
+			continue
+		}
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
+
+// milestoneID looks up the ID of the active milestone of the project with the
+// given title. If title is empty, 0 is returned.
+func (s *GitLabSource) milestoneID(ctx context.Context, project *gitlab.Project, title string) (gitlab.ID, error) {
+	if title == "" {
+		return 0, nil
+	}
+	milestone, err := s.client.GetActiveMilestoneByTitle(ctx, project, title)
+	if err != nil {
+		return 0, errors.Wrapf(err, "looking up milestone %q", title)
+	}
+	if milestone == nil {
+		return 0, errors.Errorf("active milestone %q not found", title)
+	}
+	return milestone.ID, nil
+}
+
 // CreateDraftChangeset creates a GitLab merge request. If it already exists,
 // *Changeset will be populated and the return value will be true.
 func (s *GitLabSource) CreateDraftChangeset(ctx context.Context, c *Changeset) (bool, error) {
@@ -430,11 +496,29 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 		title = gitlab.SetWIP(c.Title)
 	}
 
-	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, gitlab.UpdateMergeRequestOpts{
+	opts := gitlab.UpdateMergeRequestOpts{
 		Title:        title,
 		Description:  c.Body,
 		TargetBranch: git.AbbreviateRef(c.BaseRef),
-	})
+	}
+	if c.SyncMetadata {
+		// Always send all three, so that assignees, labels and the milestone
+		// that were removed from the changeset are also removed on GitLab.
+		assigneeIDs, err := s.userIDs(ctx, c.Assignees)
+		if err != nil {
+			return errors.Wrap(err, "looking up assignees")
+		}
+		milestoneID, err := s.milestoneID(ctx, project, c.Milestone)
+		if err != nil {
+			return err
+		}
+		labels := strings.Join(c.Labels, ",")
+		opts.AssigneeIDs = &assigneeIDs
+		opts.Labels = &labels
+		opts.MilestoneID = &milestoneID
+	}
+
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, opts)
 	if err != nil {
 		return errors.Wrap(err, "updating GitLab merge request")
 	}
@@ -121,6 +121,37 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 				t.Errorf("unexpected metadata: have %+v; want %+v", p.changeset.Changeset.Metadata, p.mr)
 			}
 		})
+
+		t.Run("unknown reviewers are skipped", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Reviewers = Reviewers{Users: []string{"alice", "some-group", "deleted"}}
+
+			oldMock := gitlab.MockGetUserByUsername
+			t.Cleanup(func() { gitlab.MockGetUserByUsername = oldMock })
+			gitlab.MockGetUserByUsername = func(c *gitlab.Client, ctx context.Context, username string) (*gitlab.User, error) {
+				switch username {
+				case "alice":
+					return &gitlab.User{ID: 1, Username: username}, nil
+				case "deleted":
+					return nil, errors.New("lookup failed")
+				}
+				return nil, nil
+			}
+
+			gitlab.MockCreateMergeRequest = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, opts gitlab.CreateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+				if diff := cmp.Diff([]int32{1}, opts.ReviewerIDs); diff != "" {
+					t.Errorf("unexpected reviewer IDs (-want +got):\n%s", diff)
+				}
+				return p.mr, nil
+			}
+			p.mockGetMergeRequestNotes(p.mr.IID, nil, 20, nil)
+			p.mockGetMergeRequestResourceStateEvents(p.mr.IID, nil, 20, nil)
+			p.mockGetMergeRequestPipelines(p.mr.IID, nil, 20, nil)
+
+			if _, err := p.source.CreateChangeset(p.ctx, p.changeset); err != nil {
+				t.Errorf("unexpected non-nil err: %+v", err)
+			}
+		})
 	})
 
 	t.Run("CloseChangeset", func(t *testing.T) {
@@ -648,6 +679,54 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 		}
 	})
 
+	t.Run("UpdateChangeset metadata", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			syncMetadata bool
+			wantCleared  bool
+		}{
+			"not synced": {syncMetadata: false, wantCleared: false},
+			"removed":    {syncMetadata: true, wantCleared: true},
+		} {
+			t.Run(name, func(t *testing.T) {
+				in := &gitlab.MergeRequest{IID: 2}
+				out := &gitlab.MergeRequest{}
+
+				p := newGitLabChangesetSourceTestProvider(t)
+				p.changeset.Changeset.Metadata = in
+				p.changeset.SyncMetadata = tc.syncMetadata
+
+				oldMock := gitlab.MockUpdateMergeRequest
+				t.Cleanup(func() { gitlab.MockUpdateMergeRequest = oldMock })
+				gitlab.MockUpdateMergeRequest = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+					if !tc.wantCleared {
+						if opts.Labels != nil || opts.AssigneeIDs != nil || opts.MilestoneID != nil {
+							t.Errorf("unexpected metadata in update: %+v", opts)
+						}
+						return out, nil
+					}
+					if opts.Labels == nil || *opts.Labels != "" {
+						t.Errorf("labels not cleared: %v", opts.Labels)
+					}
+					if opts.AssigneeIDs == nil || len(*opts.AssigneeIDs) != 0 {
+						t.Errorf("assignees not cleared: %v", opts.AssigneeIDs)
+					}
+					if opts.MilestoneID == nil || *opts.MilestoneID != 0 {
+						t.Errorf("milestone not cleared: %v", opts.MilestoneID)
+					}
+					return out, nil
+				}
+
+				p.mockGetMergeRequestNotes(in.IID, nil, 20, nil)
+				p.mockGetMergeRequestResourceStateEvents(in.IID, nil, 20, nil)
+				p.mockGetMergeRequestPipelines(in.IID, nil, 20, nil)
+
+				if err := p.source.UpdateChangeset(p.ctx, p.changeset); err != nil {
+					t.Errorf("unexpected non-nil error: %+v", err)
+				}
+			})
+		}
+	})
+
 	t.Run("UndraftChangeset", func(t *testing.T) {
 		in := &gitlab.MergeRequest{IID: 2, WorkInProgress: true}
 		out := &gitlab.MergeRequest{}
@@ -67,6 +67,12 @@ var RequiredEventTypesForHistory = []btypes.ChangesetEventKind{
 	btypes.ChangesetEventKindBitbucketServerUnapproved,
 	btypes.ChangesetEventKindBitbucketServerDismissed,
 	btypes.ChangesetEventKindGitLabUnapproved,
+	btypes.ChangesetEventKindBitbucketCloudDeclined,
+	btypes.ChangesetEventKindBitbucketCloudMerged,
+	btypes.ChangesetEventKindBitbucketCloudApproved,
+	btypes.ChangesetEventKindBitbucketCloudChangesRequestCreated,
+	btypes.ChangesetEventKindBitbucketCloudUnapproved,
+	btypes.ChangesetEventKindBitbucketCloudChangesRequestRemoved,
 }
 
 type changesetStatesAtTime struct {
@@ -89,7 +95,7 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 		currentExtState    = initialExternalState(ch, ce)
 		currentReviewState = btypes.ChangesetReviewStatePending
 
-		lastReviewByAuthor = map[string]btypes.ChangesetReviewState{}
+		lastReviewByAuthor = reviewsByAuthor{}
 		// The draft state is tracked alongside the "external state" on GitHub and GitLab,
 		// that means we need to take changes to this state into account separately. On reopen,
 		// we cannot simply say it's open, because it could be it was converted to a draft while
@@ -121,7 +127,8 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 		switch e.Kind {
 		case btypes.ChangesetEventKindGitHubClosed,
 			btypes.ChangesetEventKindBitbucketServerDeclined,
-			btypes.ChangesetEventKindGitLabClosed:
+			btypes.ChangesetEventKindGitLabClosed,
+			btypes.ChangesetEventKindBitbucketCloudDeclined:
 			// Merged is a final state. We can ignore everything after.
 			if currentExtState != btypes.ChangesetExternalStateMerged {
 				currentExtState = btypes.ChangesetExternalStateClosed
@@ -130,7 +137,8 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 
 		case btypes.ChangesetEventKindGitHubMerged,
 			btypes.ChangesetEventKindBitbucketServerMerged,
-			btypes.ChangesetEventKindGitLabMerged:
+			btypes.ChangesetEventKindGitLabMerged,
+			btypes.ChangesetEventKindBitbucketCloudMerged:
 			currentExtState = btypes.ChangesetExternalStateMerged
 			pushStates(et)
 
@@ -175,79 +183,25 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 		case btypes.ChangesetEventKindGitHubReviewed,
 			btypes.ChangesetEventKindBitbucketServerApproved,
 			btypes.ChangesetEventKindBitbucketServerReviewed,
-			btypes.ChangesetEventKindGitLabApproved:
+			btypes.ChangesetEventKindGitLabApproved,
+			btypes.ChangesetEventKindBitbucketCloudApproved,
+			btypes.ChangesetEventKindBitbucketCloudChangesRequestCreated,
+			btypes.ChangesetEventKindBitbucketServerUnapproved,
+			btypes.ChangesetEventKindBitbucketServerDismissed,
+			btypes.ChangesetEventKindGitLabUnapproved,
+			btypes.ChangesetEventKindBitbucketCloudUnapproved,
+			btypes.ChangesetEventKindBitbucketCloudChangesRequestRemoved:
 
-			s, err := e.ReviewState()
+			changed, err := lastReviewByAuthor.apply(e)
 			if err != nil {
 				return nil, err
 			}
-
-			// We only care about "Approved", "ChangesRequested" or "Dismissed" reviews
-			if s != btypes.ChangesetReviewStateApproved &&
-				s != btypes.ChangesetReviewStateChangesRequested &&
-				s != btypes.ChangesetReviewStateDismissed {
+			if !changed {
 				continue
 			}
 
-			author := e.ReviewAuthor()
-			// If the user has been deleted, skip their reviews, as they don't count towards the final state anymore.
-			if author == "" {
-				continue
-			}
-
-			// Save current review state, then insert new review or delete
-			// dismissed review, then recompute overall review state
+			// Save current review state, then recompute overall review state
 			oldReviewState := currentReviewState
-
-			if s == btypes.ChangesetReviewStateDismissed {
-				// In case of a dismissed review we dismiss _all_ of the
-				// previous reviews by the author, since that is what GitHub
-				// does in its UI.
-				delete(lastReviewByAuthor, author)
-			} else {
-				lastReviewByAuthor[author] = s
-			}
-
-			newReviewState := reduceReviewStates(lastReviewByAuthor)
-
-			if newReviewState != oldReviewState {
-				currentReviewState = newReviewState
-				pushStates(et)
-			}
-
-		case btypes.ChangesetEventKindBitbucketServerUnapproved,
-			btypes.ChangesetEventKindBitbucketServerDismissed,
-			btypes.ChangesetEventKindGitLabUnapproved:
-			author := e.ReviewAuthor()
-			// If the user has been deleted, skip their reviews, as they don't count towards the final state anymore.
-			if author == "" {
-				continue
-			}
-
-			if e.Type() == btypes.ChangesetEventKindBitbucketServerUnapproved {
-				// A BitbucketServer Unapproved can only follow a previous Approved by
-				// the same author.
-				lastReview, ok := lastReviewByAuthor[author]
-				if !ok || lastReview != btypes.ChangesetReviewStateApproved {
-					log15.Warn("Bitbucket Server Unapproval not following an Approval", "event", e)
-					continue
-				}
-			}
-
-			if e.Type() == btypes.ChangesetEventKindBitbucketServerDismissed {
-				// A BitbucketServer Dismissed event can only follow a previous "Changes Requested" review by
-				// the same author.
-				lastReview, ok := lastReviewByAuthor[author]
-				if !ok || lastReview != btypes.ChangesetReviewStateChangesRequested {
-					log15.Warn("Bitbucket Server Dismissal not following a Review", "event", e)
-					continue
-				}
-			}
-
-			// Save current review state, then remove last approval and
-			// recompute overall review state
-			oldReviewState := currentReviewState
-			delete(lastReviewByAuthor, author)
 			newReviewState := reduceReviewStates(lastReviewByAuthor)
 
 			if newReviewState != oldReviewState {
@@ -268,6 +222,115 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 	return states, nil
 }
 
+// reviewsByAuthor keeps track of the last review of each author of a
+// changeset.
+type reviewsByAuthor map[string]btypes.ChangesetReviewState
+
+// apply adds, replaces or removes the review of the author of the given review
+// event. It returns false if the event didn't change the reviews.
+func (r reviewsByAuthor) apply(e *btypes.ChangesetEvent) (bool, error) {
+	author := e.ReviewAuthor()
+	// If the user has been deleted, skip their reviews, as they don't count towards the final state anymore.
+	if author == "" {
+		return false, nil
+	}
+
+	switch e.Kind {
+	case btypes.ChangesetEventKindGitHubReviewed,
+		btypes.ChangesetEventKindBitbucketServerApproved,
+		btypes.ChangesetEventKindBitbucketServerReviewed,
+		btypes.ChangesetEventKindGitLabApproved,
+		btypes.ChangesetEventKindBitbucketCloudApproved,
+		btypes.ChangesetEventKindBitbucketCloudChangesRequestCreated:
+
+		s, err := e.ReviewState()
+		if err != nil {
+			return false, err
+		}
+
+		// We only care about "Approved", "ChangesRequested" or "Dismissed" reviews
+		if s != btypes.ChangesetReviewStateApproved &&
+			s != btypes.ChangesetReviewStateChangesRequested &&
+			s != btypes.ChangesetReviewStateDismissed {
+			return false, nil
+		}
+
+		if s == btypes.ChangesetReviewStateDismissed {
+			// In case of a dismissed review we dismiss _all_ of the
+			// previous reviews by the author, since that is what GitHub
+			// does in its UI.
+			delete(r, author)
+		} else {
+			r[author] = s
+		}
+		return true, nil
+
+	case btypes.ChangesetEventKindBitbucketServerUnapproved,
+		btypes.ChangesetEventKindBitbucketServerDismissed,
+		btypes.ChangesetEventKindGitLabUnapproved,
+		btypes.ChangesetEventKindBitbucketCloudUnapproved,
+		btypes.ChangesetEventKindBitbucketCloudChangesRequestRemoved:
+
+		if e.Type() == btypes.ChangesetEventKindBitbucketServerUnapproved ||
+			e.Type() == btypes.ChangesetEventKindBitbucketCloudUnapproved {
+			// A Bitbucket Unapproved can only follow a previous Approved by
+			// the same author.
+			lastReview, ok := r[author]
+			if !ok || lastReview != btypes.ChangesetReviewStateApproved {
+				log15.Warn("Bitbucket Unapproval not following an Approval", "event", e)
+				return false, nil
+			}
+		}
+
+		if e.Type() == btypes.ChangesetEventKindBitbucketServerDismissed ||
+			e.Type() == btypes.ChangesetEventKindBitbucketCloudChangesRequestRemoved {
+			// A Bitbucket Dismissed event can only follow a previous "Changes Requested" review by
+			// the same author.
+			lastReview, ok := r[author]
+			if !ok || lastReview != btypes.ChangesetReviewStateChangesRequested {
+				log15.Warn("Bitbucket Dismissal not following a Review", "event", e)
+				return false, nil
+			}
+		}
+
+		// Remove the last review of the author.
+		delete(r, author)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// approvals returns the number of authors whose last review is an approval.
+func (r reviewsByAuthor) approvals() int {
+	n := 0
+	for _, s := range r {
+		if s == btypes.ChangesetReviewStateApproved {
+			n++
+		}
+	}
+	return n
+}
+
+// ComputeApprovals returns the number of reviewers whose last review of the
+// changeset approves it.
+func ComputeApprovals(es []*btypes.ChangesetEvent) (int, error) {
+	events := make(ChangesetEvents, len(es))
+	copy(events, es)
+	sort.Sort(events)
+
+	reviews := reviewsByAuthor{}
+	for _, e := range events {
+		if e.Timestamp().IsZero() {
+			continue
+		}
+		if _, err := reviews.apply(e); err != nil {
+			return 0, err
+		}
+	}
+	return reviews.approvals(), nil
+}
+
 // reduceReviewStates reduces the given a map of review per author down to a
 // single overall ChangesetReviewState.
 func reduceReviewStates(statesByAuthor map[string]btypes.ChangesetReviewState) btypes.ChangesetReviewState {
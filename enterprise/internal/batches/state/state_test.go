@@ -9,6 +9,7 @@ import (
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -276,6 +277,88 @@ func TestComputeBitbucketBuildStatus(t *testing.T) {
 	}
 }
 
+func TestComputeBitbucketCloudBuildStatus(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	lastSynced := now.Add(-1 * time.Minute)
+
+	// The pull request only has the abbreviated hash of its head commit.
+	pr := &bitbucketcloud.PullRequest{}
+	pr.Source.Commit.Hash = "abcdef"
+
+	status := func(commit, key string, state bitbucketcloud.BuildStatusState, updatedOn time.Time) *bitbucketcloud.CommitStatus {
+		return &bitbucketcloud.CommitStatus{
+			Commit: commit,
+			Status: bitbucketcloud.BuildStatus{Key: key, State: state, UpdatedOn: updatedOn},
+		}
+	}
+	statusEvent := func(commit, key string, state bitbucketcloud.BuildStatusState, updatedOn time.Time) *btypes.ChangesetEvent {
+		return &btypes.ChangesetEvent{
+			Kind:     btypes.ChangesetEventKindBitbucketCloudCommitStatus,
+			Metadata: status(commit, key, state, updatedOn),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		statuses []*bitbucketcloud.CommitStatus
+		events   []*btypes.ChangesetEvent
+		want     btypes.ChangesetCheckState
+	}{
+		{
+			name: "no statuses",
+			want: btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name:     "synced success",
+			statuses: []*bitbucketcloud.CommitStatus{status("abcdef123456", "ci", bitbucketcloud.BuildStatusStateSuccessful, lastSynced)},
+			want:     btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name:     "synced status of other commit",
+			statuses: []*bitbucketcloud.CommitStatus{status("123456abcdef", "ci", bitbucketcloud.BuildStatusStateFailed, lastSynced)},
+			want:     btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name:     "stopped is failed",
+			statuses: []*bitbucketcloud.CommitStatus{status("abcdef123456", "ci", bitbucketcloud.BuildStatusStateStopped, lastSynced)},
+			want:     btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name:     "newer event has precedence",
+			statuses: []*bitbucketcloud.CommitStatus{status("abcdef123456", "ci", bitbucketcloud.BuildStatusStateInProgress, lastSynced)},
+			events:   []*btypes.ChangesetEvent{statusEvent("abcdef123456", "ci", bitbucketcloud.BuildStatusStateSuccessful, now)},
+			want:     btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name:     "events before last sync are ignored",
+			statuses: []*bitbucketcloud.CommitStatus{status("abcdef123456", "ci", bitbucketcloud.BuildStatusStateSuccessful, lastSynced)},
+			events:   []*btypes.ChangesetEvent{statusEvent("abcdef123456", "ci", bitbucketcloud.BuildStatusStateFailed, lastSynced.Add(-time.Minute))},
+			want:     btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name: "pending + success",
+			events: []*btypes.ChangesetEvent{
+				statusEvent("abcdef123456", "ci1", bitbucketcloud.BuildStatusStateInProgress, now),
+				statusEvent("abcdef123456", "ci2", bitbucketcloud.BuildStatusStateSuccessful, now),
+			},
+			want: btypes.ChangesetCheckStatePending,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := *pr
+			pr.Statuses = tc.statuses
+			have := computeBitbucketCloudBuildStatus(lastSynced, &pr, tc.events)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestComputeGitLabCheckState(t *testing.T) {
 	t.Parallel()
 
@@ -553,6 +636,71 @@ func TestComputeReviewState(t *testing.T) {
 	}
 }
 
+func TestComputeApprovals(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	tests := []struct {
+		name   string
+		events []*btypes.ChangesetEvent
+		want   int
+	}{
+		{
+			name: "no events",
+			want: 0,
+		},
+		{
+			name: "github approvals by distinct authors",
+			events: []*btypes.ChangesetEvent{
+				ghReview(1, daysAgo(3), "user1", "APPROVED"),
+				ghReview(1, daysAgo(2), "user1", "APPROVED"),
+				ghReview(1, daysAgo(1), "user2", "APPROVED"),
+				ghReview(1, daysAgo(1), "user3", "COMMENTED"),
+			},
+			want: 2,
+		},
+		{
+			name: "github approval replaced by changes requested",
+			events: []*btypes.ChangesetEvent{
+				ghReview(1, daysAgo(2), "user1", "APPROVED"),
+				ghReview(1, daysAgo(1), "user1", "CHANGES_REQUESTED"),
+			},
+			want: 0,
+		},
+		{
+			name: "bitbucket unapproval",
+			events: []*btypes.ChangesetEvent{
+				bbsActivity(1, daysAgo(3), "user1", btypes.ChangesetEventKindBitbucketServerApproved),
+				bbsActivity(1, daysAgo(3), "user2", btypes.ChangesetEventKindBitbucketServerApproved),
+				bbsParticipantEvent(1, daysAgo(1), "user1", btypes.ChangesetEventKindBitbucketServerUnapproved),
+			},
+			want: 1,
+		},
+		{
+			name: "events out of order",
+			events: []*btypes.ChangesetEvent{
+				bbsParticipantEvent(1, daysAgo(1), "user1", btypes.ChangesetEventKindBitbucketServerUnapproved),
+				bbsActivity(1, daysAgo(3), "user1", btypes.ChangesetEventKindBitbucketServerApproved),
+			},
+			want: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := ComputeApprovals(tc.events)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Errorf("wrong number of approvals. have=%d, want=%d", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestComputeExternalState(t *testing.T) {
 	t.Parallel()
 
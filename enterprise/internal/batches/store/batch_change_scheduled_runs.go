@@ -0,0 +1,250 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// batchChangeScheduledRunInsertColumns is the list of
+// batch_change_scheduled_runs columns that are modified in
+// CreateBatchChangeScheduledRun and UpdateBatchChangeScheduledRun.
+var batchChangeScheduledRunInsertColumns = SQLColumns{
+	"batch_change_id",
+	"batch_spec_id",
+
+	"state",
+	"failure_message",
+
+	"created_at",
+	"updated_at",
+	"finished_at",
+}
+
+// batchChangeScheduledRunColumns are used by the scheduled run related Store
+// methods to query and create scheduled runs.
+var batchChangeScheduledRunColumns = SQLColumns{
+	"batch_change_scheduled_runs.id",
+
+	"batch_change_scheduled_runs.batch_change_id",
+	"batch_change_scheduled_runs.batch_spec_id",
+
+	"batch_change_scheduled_runs.state",
+	"batch_change_scheduled_runs.failure_message",
+
+	"batch_change_scheduled_runs.created_at",
+	"batch_change_scheduled_runs.updated_at",
+	"batch_change_scheduled_runs.finished_at",
+}
+
+// CreateBatchChangeScheduledRun creates the given scheduled run.
+func (s *Store) CreateBatchChangeScheduledRun(ctx context.Context, run *btypes.BatchChangeScheduledRun) (err error) {
+	ctx, endObservation := s.operations.createBatchChangeScheduledRun.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("BatchChangeID", int(run.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = s.now()
+	}
+
+	if run.UpdatedAt.IsZero() {
+		run.UpdatedAt = run.CreatedAt
+	}
+
+	if run.State == "" {
+		run.State = btypes.BatchChangeScheduledRunStateResolving
+	}
+
+	q := sqlf.Sprintf(
+		createBatchChangeScheduledRunQueryFmtstr,
+		sqlf.Join(batchChangeScheduledRunInsertColumns.ToSqlf(), ", "),
+		run.BatchChangeID,
+		nullInt64Column(run.BatchSpecID),
+		run.State,
+		run.FailureMessage,
+		run.CreatedAt,
+		run.UpdatedAt,
+		nullTimeColumn(run.FinishedAt),
+		sqlf.Join(batchChangeScheduledRunColumns.ToSqlf(), ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchChangeScheduledRun(run, sc)
+	})
+}
+
+var createBatchChangeScheduledRunQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_scheduled_runs.go:CreateBatchChangeScheduledRun
+INSERT INTO batch_change_scheduled_runs (%s)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// UpdateBatchChangeScheduledRun updates the given scheduled run.
+func (s *Store) UpdateBatchChangeScheduledRun(ctx context.Context, run *btypes.BatchChangeScheduledRun) (err error) {
+	ctx, endObservation := s.operations.updateBatchChangeScheduledRun.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(run.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	run.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateBatchChangeScheduledRunQueryFmtstr,
+		sqlf.Join(batchChangeScheduledRunInsertColumns.ToSqlf(), ", "),
+		run.BatchChangeID,
+		nullInt64Column(run.BatchSpecID),
+		run.State,
+		run.FailureMessage,
+		run.CreatedAt,
+		run.UpdatedAt,
+		nullTimeColumn(run.FinishedAt),
+		run.ID,
+		sqlf.Join(batchChangeScheduledRunColumns.ToSqlf(), ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchChangeScheduledRun(run, sc)
+	})
+}
+
+var updateBatchChangeScheduledRunQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_scheduled_runs.go:UpdateBatchChangeScheduledRun
+UPDATE batch_change_scheduled_runs
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING %s
+`
+
+// ListBatchChangeScheduledRunsOpts captures the query options needed for
+// listing scheduled runs.
+type ListBatchChangeScheduledRunsOpts struct {
+	LimitOpts
+	Cursor int64
+
+	BatchChangeID int64
+	// OnlyUnfinished limits the runs to the ones that are still resolving or
+	// executing.
+	OnlyUnfinished bool
+}
+
+// ListBatchChangeScheduledRuns lists scheduled runs with the given filters,
+// newest first.
+func (s *Store) ListBatchChangeScheduledRuns(ctx context.Context, opts ListBatchChangeScheduledRunsOpts) (runs []*btypes.BatchChangeScheduledRun, next int64, err error) {
+	ctx, endObservation := s.operations.listBatchChangeScheduledRuns.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := listBatchChangeScheduledRunsQuery(opts)
+
+	runs = make([]*btypes.BatchChangeScheduledRun, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var run btypes.BatchChangeScheduledRun
+		if err := scanBatchChangeScheduledRun(&run, sc); err != nil {
+			return err
+		}
+		runs = append(runs, &run)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(runs) == opts.DBLimit() {
+		next = runs[len(runs)-1].ID
+		runs = runs[:len(runs)-1]
+	}
+
+	return runs, next, err
+}
+
+var listBatchChangeScheduledRunsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_scheduled_runs.go:ListBatchChangeScheduledRuns
+SELECT %s FROM batch_change_scheduled_runs
+WHERE %s
+ORDER BY id DESC
+`
+
+func listBatchChangeScheduledRunsQuery(opts ListBatchChangeScheduledRunsOpts) *sqlf.Query {
+	preds := batchChangeScheduledRunsPreds(opts.BatchChangeID, opts.OnlyUnfinished)
+
+	if opts.Cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_scheduled_runs.id <= %s", opts.Cursor))
+	}
+
+	return sqlf.Sprintf(
+		listBatchChangeScheduledRunsQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(batchChangeScheduledRunColumns.ToSqlf(), ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// CountBatchChangeScheduledRunsOpts captures the query options needed for
+// counting scheduled runs.
+type CountBatchChangeScheduledRunsOpts struct {
+	BatchChangeID  int64
+	OnlyUnfinished bool
+}
+
+// CountBatchChangeScheduledRuns returns the number of scheduled runs matching
+// the given options.
+func (s *Store) CountBatchChangeScheduledRuns(ctx context.Context, opts CountBatchChangeScheduledRunsOpts) (count int, err error) {
+	ctx, endObservation := s.operations.countBatchChangeScheduledRuns.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	preds := batchChangeScheduledRunsPreds(opts.BatchChangeID, opts.OnlyUnfinished)
+
+	return s.queryCount(ctx, sqlf.Sprintf(countBatchChangeScheduledRunsQueryFmtstr, sqlf.Join(preds, "\n AND ")))
+}
+
+var countBatchChangeScheduledRunsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_scheduled_runs.go:CountBatchChangeScheduledRuns
+SELECT COUNT(batch_change_scheduled_runs.id)
+FROM batch_change_scheduled_runs
+WHERE %s
+`
+
+func batchChangeScheduledRunsPreds(batchChangeID int64, onlyUnfinished bool) []*sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+
+	if batchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_scheduled_runs.batch_change_id = %s", batchChangeID))
+	}
+
+	if onlyUnfinished {
+		preds = append(preds, sqlf.Sprintf(
+			"batch_change_scheduled_runs.state IN (%s, %s)",
+			btypes.BatchChangeScheduledRunStateResolving,
+			btypes.BatchChangeScheduledRunStateExecuting,
+		))
+	}
+
+	return preds
+}
+
+func scanBatchChangeScheduledRun(run *btypes.BatchChangeScheduledRun, s dbutil.Scanner) error {
+	var failureMessage string
+
+	if err := s.Scan(
+		&run.ID,
+		&run.BatchChangeID,
+		&dbutil.NullInt64{N: &run.BatchSpecID},
+		&run.State,
+		&dbutil.NullString{S: &failureMessage},
+		&run.CreatedAt,
+		&run.UpdatedAt,
+		&dbutil.NullTime{Time: &run.FinishedAt},
+	); err != nil {
+		return err
+	}
+
+	if failureMessage != "" {
+		run.FailureMessage = &failureMessage
+	} else {
+		run.FailureMessage = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func testStoreBatchChangeScheduledRuns(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	scheduled := &btypes.BatchChange{
+		Name:               "scheduled",
+		BatchSpecID:        1234,
+		NamespaceUserID:    42,
+		LastApplierID:      42,
+		LastAppliedAt:      clock.Now(),
+		Schedule:           "@daily",
+		ScheduleUserID:     42,
+		NextScheduledRunAt: clock.Now().Add(-time.Minute),
+	}
+	notDue := &btypes.BatchChange{
+		Name:               "not-due",
+		BatchSpecID:        1235,
+		NamespaceUserID:    42,
+		LastApplierID:      42,
+		LastAppliedAt:      clock.Now(),
+		Schedule:           "@daily",
+		ScheduleUserID:     42,
+		NextScheduledRunAt: clock.Now().Add(time.Hour),
+	}
+	unscheduled := &btypes.BatchChange{
+		Name:            "unscheduled",
+		BatchSpecID:     1236,
+		NamespaceUserID: 42,
+		LastApplierID:   42,
+		LastAppliedAt:   clock.Now(),
+	}
+	for _, bc := range []*btypes.BatchChange{scheduled, notDue, unscheduled} {
+		if err := s.CreateBatchChange(ctx, bc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("ListBatchChanges due for a run", func(t *testing.T) {
+		have, _, err := s.ListBatchChanges(ctx, ListBatchChangesOpts{NextScheduledRunBefore: clock.Now()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, []*btypes.BatchChange{scheduled}); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	runs := make([]*btypes.BatchChangeScheduledRun, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(runs); i++ {
+			run := &btypes.BatchChangeScheduledRun{
+				BatchChangeID: scheduled.ID,
+				BatchSpecID:   int64(i + 567),
+			}
+			if i == 2 {
+				run.BatchChangeID = notDue.ID
+			}
+
+			if err := s.CreateBatchChangeScheduledRun(ctx, run); err != nil {
+				t.Fatal(err)
+			}
+
+			if run.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want := &btypes.BatchChangeScheduledRun{
+				ID:            run.ID,
+				BatchChangeID: run.BatchChangeID,
+				BatchSpecID:   run.BatchSpecID,
+				State:         btypes.BatchChangeScheduledRunStateResolving,
+				CreatedAt:     clock.Now(),
+				UpdatedAt:     clock.Now(),
+			}
+			if diff := cmp.Diff(run, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			runs = append(runs, run)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		clock.Add(1 * time.Second)
+
+		msg := "execution failed"
+		run := runs[0]
+		run.State = btypes.BatchChangeScheduledRunStateFailed
+		run.FailureMessage = &msg
+		run.FinishedAt = clock.Now()
+
+		want := *run
+		want.UpdatedAt = clock.Now()
+
+		if err := s.UpdateBatchChangeScheduledRun(ctx, run); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(run, &want); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("all", func(t *testing.T) {
+			have, next, err := s.ListBatchChangeScheduledRuns(ctx, ListBatchChangeScheduledRunsOpts{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if next != 0 {
+				t.Fatalf("unexpected next cursor: %d", next)
+			}
+			want := []*btypes.BatchChangeScheduledRun{runs[2], runs[1], runs[0]}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("by batch change", func(t *testing.T) {
+			have, _, err := s.ListBatchChangeScheduledRuns(ctx, ListBatchChangeScheduledRunsOpts{BatchChangeID: scheduled.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []*btypes.BatchChangeScheduledRun{runs[1], runs[0]}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("only unfinished", func(t *testing.T) {
+			have, _, err := s.ListBatchChangeScheduledRuns(ctx, ListBatchChangeScheduledRunsOpts{OnlyUnfinished: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []*btypes.BatchChangeScheduledRun{runs[2], runs[1]}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("with limit and cursor", func(t *testing.T) {
+			have, next, err := s.ListBatchChangeScheduledRuns(ctx, ListBatchChangeScheduledRunsOpts{LimitOpts: LimitOpts{Limit: 1}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, []*btypes.BatchChangeScheduledRun{runs[2]}); diff != "" {
+				t.Fatal(diff)
+			}
+			if next != runs[1].ID {
+				t.Fatalf("wrong next cursor: have %d, want %d", next, runs[1].ID)
+			}
+
+			have, _, err = s.ListBatchChangeScheduledRuns(ctx, ListBatchChangeScheduledRunsOpts{LimitOpts: LimitOpts{Limit: 1}, Cursor: next})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, []*btypes.BatchChangeScheduledRun{runs[1]}); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			opts CountBatchChangeScheduledRunsOpts
+			want int
+		}{
+			"all":             {opts: CountBatchChangeScheduledRunsOpts{}, want: 3},
+			"by batch change": {opts: CountBatchChangeScheduledRunsOpts{BatchChangeID: scheduled.ID}, want: 2},
+			"only unfinished": {opts: CountBatchChangeScheduledRunsOpts{BatchChangeID: scheduled.ID, OnlyUnfinished: true}, want: 1},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, err := s.CountBatchChangeScheduledRuns(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have != tc.want {
+					t.Fatalf("wrong count: have %d, want %d", have, tc.want)
+				}
+			})
+		}
+	})
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"strconv"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/keegancsmith/sqlf"
@@ -31,6 +32,9 @@ var batchChangeColumns = []*sqlf.Query{
 	sqlf.Sprintf("batch_changes.updated_at"),
 	sqlf.Sprintf("batch_changes.closed_at"),
 	sqlf.Sprintf("batch_changes.batch_spec_id"),
+	sqlf.Sprintf("batch_changes.schedule"),
+	sqlf.Sprintf("batch_changes.schedule_user_id"),
+	sqlf.Sprintf("batch_changes.next_scheduled_run_at"),
 }
 
 // batchChangeInsertColumns is the list of batch changes columns that are
@@ -48,6 +52,9 @@ var batchChangeInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("updated_at"),
 	sqlf.Sprintf("closed_at"),
 	sqlf.Sprintf("batch_spec_id"),
+	sqlf.Sprintf("schedule"),
+	sqlf.Sprintf("schedule_user_id"),
+	sqlf.Sprintf("next_scheduled_run_at"),
 }
 
 // CreateBatchChange creates the given batch change.
@@ -65,7 +72,7 @@ func (s *Store) CreateBatchChange(ctx context.Context, c *btypes.BatchChange) (e
 var createBatchChangeQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:CreateBatchChange
 INSERT INTO batch_changes (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -92,6 +99,9 @@ func (s *Store) createBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		c.UpdatedAt,
 		nullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
+		nullStringColumn(c.Schedule),
+		nullInt32Column(c.ScheduleUserID),
+		nullTimeColumn(c.NextScheduledRunAt),
 		sqlf.Join(batchChangeColumns, ", "),
 	)
 }
@@ -111,7 +121,7 @@ func (s *Store) UpdateBatchChange(ctx context.Context, c *btypes.BatchChange) (e
 var updateBatchChangeQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:UpdateBatchChange
 UPDATE batch_changes
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING %s
 `
@@ -133,6 +143,9 @@ func (s *Store) updateBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		c.UpdatedAt,
 		nullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
+		nullStringColumn(c.Schedule),
+		nullInt32Column(c.ScheduleUserID),
+		nullTimeColumn(c.NextScheduledRunAt),
 		c.ID,
 		sqlf.Join(batchChangeColumns, ", "),
 	)
@@ -444,6 +457,10 @@ type ListBatchChangesOpts struct {
 
 	RepoID api.RepoID
 
+	// NextScheduledRunBefore limits the batch changes to open, scheduled
+	// batch changes whose next run is due before the given time.
+	NextScheduledRunBefore time.Time
+
 	ExcludeDraftsNotOwnedByUserID int32
 }
 
@@ -551,6 +568,13 @@ func listBatchChangesQuery(opts *ListBatchChangesOpts, repoAuthzConds *sqlf.Quer
 		)`, opts.RepoID, repoAuthzConds))
 	}
 
+	if !opts.NextScheduledRunBefore.IsZero() {
+		preds = append(preds, sqlf.Sprintf(
+			"batch_changes.schedule IS NOT NULL AND batch_changes.closed_at IS NULL AND batch_changes.last_applied_at IS NOT NULL AND batch_changes.next_scheduled_run_at <= %s",
+			opts.NextScheduledRunBefore,
+		))
+	}
+
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
@@ -577,5 +601,8 @@ func scanBatchChange(c *btypes.BatchChange, s dbutil.Scanner) error {
 		&c.UpdatedAt,
 		&dbutil.NullTime{Time: &c.ClosedAt},
 		&c.BatchSpecID,
+		&dbutil.NullString{S: &c.Schedule},
+		&dbutil.NullInt32{N: &c.ScheduleUserID},
+		&dbutil.NullTime{Time: &c.NextScheduledRunAt},
 	)
 }
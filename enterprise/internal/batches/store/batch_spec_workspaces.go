@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"sort"
+	"strconv"
 
 	"github.com/cockroachdb/errors"
 	"github.com/keegancsmith/sqlf"
@@ -164,7 +165,8 @@ func (s *Store) CreateBatchSpecWorkspace(ctx context.Context, ws ...*btypes.Batc
 
 // GetBatchSpecWorkspaceOpts captures the query options needed for getting a BatchSpecWorkspace
 type GetBatchSpecWorkspaceOpts struct {
-	ID int64
+	ID              int64
+	ChangesetSpecID int64
 }
 
 // GetBatchSpecWorkspace gets a BatchSpecWorkspace matching the given options.
@@ -201,7 +203,14 @@ LIMIT 1
 func getBatchSpecWorkspaceQuery(opts *GetBatchSpecWorkspaceOpts) *sqlf.Query {
 	preds := []*sqlf.Query{
 		sqlf.Sprintf("repo.deleted_at IS NULL"),
-		sqlf.Sprintf("batch_spec_workspaces.id = %s", opts.ID),
+	}
+
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.id = %s", opts.ID))
+	}
+
+	if opts.ChangesetSpecID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.changeset_spec_ids ? %s", strconv.Itoa(int(opts.ChangesetSpecID))))
 	}
 
 	return sqlf.Sprintf(
@@ -338,6 +347,32 @@ func (s *Store) MarkSkippedBatchSpecWorkspaces(ctx context.Context, batchSpecID
 	return s.Exec(ctx, q)
 }
 
+const resetBatchSpecWorkspaceCommitQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspaces.go:ResetBatchSpecWorkspaceCommit
+UPDATE
+	batch_spec_workspaces
+SET
+	commit = %s,
+	skipped = FALSE,
+	cached_result_found = FALSE,
+	step_cache_results = '{}',
+	updated_at = %s
+WHERE
+	id = %s
+`
+
+// ResetBatchSpecWorkspaceCommit moves the given workspace to the given commit
+// of its branch, so that its steps can be executed again on that commit. The
+// cached results of the previous execution no longer apply and are dropped.
+func (s *Store) ResetBatchSpecWorkspaceCommit(ctx context.Context, id int64, commit string) (err error) {
+	ctx, endObservation := s.operations.resetBatchSpecWorkspaceCommit.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(resetBatchSpecWorkspaceCommitQueryFmtstr, commit, s.now(), id))
+}
+
 func scanBatchSpecWorkspace(wj *btypes.BatchSpecWorkspace, s dbutil.Scanner) error {
 	var steps json.RawMessage
 	var stepCacheResults json.RawMessage
@@ -452,6 +452,10 @@ WHERE
   AND
   -- and it was never attached to a batch_spec
   batch_spec_id IS NULL
+  AND
+  -- and it is not attached to a changeset, which is the case for specs that
+  -- were replaced by re-executing a workspace
+  NOT EXISTS (SELECT 1 FROM changesets WHERE current_spec_id = cspecs.id OR previous_spec_id = cspecs.id)
 )
 OR
 (
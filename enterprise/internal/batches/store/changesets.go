@@ -22,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -65,6 +66,9 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.num_failures"),
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
+	sqlf.Sprintf("changesets.auto_merge_blocked_reason"),
+	sqlf.Sprintf("changesets.rebasing"),
+	sqlf.Sprintf("changesets.rebase_failure_message"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -102,6 +106,9 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("closing"),
 	sqlf.Sprintf("syncer_error"),
+	sqlf.Sprintf("auto_merge_blocked_reason"),
+	sqlf.Sprintf("rebasing"),
+	sqlf.Sprintf("rebase_failure_message"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
@@ -185,6 +192,9 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.NumFailures,
 		c.Closing,
 		c.SyncErrorMessage,
+		nullStringColumn(c.AutoMergeBlockedReason),
+		c.Rebasing,
+		c.RebaseFailureMessage,
 		nullStringColumn(title),
 	}
 
@@ -229,7 +239,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *btypes.Changeset) (err e
 var createChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:CreateChangeset
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -523,6 +533,8 @@ type ListChangesetsOpts struct {
 	TextSearch           []search.TextSearchTerm
 	EnforceAuthz         bool
 	RepoID               api.RepoID
+	CurrentSpecIDs       []int64
+	OnlyRebasing         bool
 }
 
 // ListChangesets lists Changesets with the given filters.
@@ -612,6 +624,12 @@ func listChangesetsQuery(opts *ListChangesetsOpts, authzConds *sqlf.Query) *sqlf
 	if opts.RepoID != 0 {
 		preds = append(preds, sqlf.Sprintf("repo.id = %s", opts.RepoID))
 	}
+	if len(opts.CurrentSpecIDs) > 0 {
+		preds = append(preds, sqlf.Sprintf("changesets.current_spec_id = ANY (%s)", pq.Array(opts.CurrentSpecIDs)))
+	}
+	if opts.OnlyRebasing {
+		preds = append(preds, sqlf.Sprintf("changesets.rebasing"))
+	}
 
 	join := sqlf.Sprintf("")
 	if len(opts.TextSearch) != 0 {
@@ -695,6 +713,63 @@ func (s *Store) enqueueChangesetQuery(cs *btypes.Changeset, resetState, currentS
 	)
 }
 
+// EnqueueChangesetForPolicies enqueues the given changeset for the reconciler
+// to evaluate the auto-merge and rebase policies of its current changeset
+// spec, but *only if* the changeset is published, open and fully reconciled,
+// and its current changeset spec has one of these policies.
+//
+// Since the changeset has been fully reconciled with its current spec, its
+// previous spec is reset to the current one so that the reconciler doesn't
+// redo the operations that were needed to get there.
+func (s *Store) EnqueueChangesetForPolicies(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, endObservation := s.operations.enqueueChangesetForPolicies.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		enqueueChangesetForPoliciesQueryFmtstr,
+		btypes.ReconcilerStateQueued.ToDB(),
+		s.now(),
+		cs.ID,
+		btypes.ReconcilerStateCompleted.ToDB(),
+		btypes.ChangesetPublicationStatePublished,
+		btypes.ChangesetExternalStateOpen,
+		btypes.ChangesetExternalStateDraft,
+		sqlf.Join(changesetColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) (err error) {
+		return scanChangeset(cs, sc)
+	})
+}
+
+var enqueueChangesetForPoliciesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changesets.go:EnqueueChangesetForPolicies
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	previous_spec_id = current_spec_id,
+	num_resets = 0,
+	num_failures = 0,
+	failure_message = NULL,
+	updated_at = %s
+WHERE
+	id = %s
+	AND reconciler_state = %s
+	AND publication_state = %s
+	AND external_state IN (%s, %s)
+	AND NOT closing
+	AND EXISTS (
+		SELECT 1 FROM changeset_specs
+		WHERE
+			changeset_specs.id = changesets.current_spec_id
+			AND changeset_specs.spec ?| array['autoMerge', 'rebase']
+	)
+RETURNING
+	%s
+`
+
 // UpdateChangeset updates the given Changeset.
 func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) (err error) {
 	ctx, endObservation := s.operations.updateChangeset.With(ctx, &err, observation.Args{LogFields: []log.Field{
@@ -717,7 +792,7 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) (err
 var updateChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store_changesets.go:UpdateChangeset
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -751,6 +826,17 @@ func (s *Store) UpdateChangesetUiPublicationState(ctx context.Context, cs *btype
 	return s.updateChangesetColumn(ctx, cs, "ui_publication_state", uiPublicationState)
 }
 
+// UpdateChangesetAutoMergeBlockedReason updates only the
+// `auto_merge_blocked_reason` & `updated_at` columns of the given Changeset.
+func (s *Store) UpdateChangesetAutoMergeBlockedReason(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, endObservation := s.operations.updateChangesetAutoMergeBlockedReason.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.updateChangesetColumn(ctx, cs, "auto_merge_blocked_reason", nullStringColumn(cs.AutoMergeBlockedReason))
+}
+
 // updateChangesetColumn updates the column with the given name, setting it to
 // the given value, and updating the updated_at column.
 func (s *Store) updateChangesetColumn(ctx context.Context, cs *btypes.Changeset, name string, val interface{}) error {
@@ -1120,6 +1206,7 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		failureMessage      string
 		syncErrorMessage    string
 		reconcilerState     string
+		rebaseFailure       string
 	)
 	err := s.Scan(
 		&t.ID,
@@ -1155,6 +1242,9 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&t.NumFailures,
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
+		&dbutil.NullString{S: &t.AutoMergeBlockedReason},
+		&t.Rebasing,
+		&dbutil.NullString{S: &rebaseFailure},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1169,26 +1259,40 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 	if syncErrorMessage != "" {
 		t.SyncErrorMessage = &syncErrorMessage
 	}
+	if rebaseFailure != "" {
+		t.RebaseFailureMessage = &rebaseFailure
+	}
 	t.ReconcilerState = btypes.ReconcilerState(strings.ToUpper(reconcilerState))
 
-	switch t.ExternalServiceType {
+	if err = unmarshalChangesetMetadata(t, metadata); err != nil {
+		return errors.Wrap(err, "scanChangeset")
+	}
+	if err = json.Unmarshal(syncState, &t.SyncState); err != nil {
+		return errors.Wrapf(err, "scanChangeset: failed to unmarshal sync state: %s", syncState)
+	}
+
+	return nil
+}
+
+// unmarshalChangesetMetadata sets the metadata of the changeset, whose type
+// depends on its external service type.
+func unmarshalChangesetMetadata(c *btypes.Changeset, metadata json.RawMessage) error {
+	switch c.ExternalServiceType {
 	case extsvc.TypeGitHub:
-		t.Metadata = new(github.PullRequest)
+		c.Metadata = new(github.PullRequest)
 	case extsvc.TypeBitbucketServer:
-		t.Metadata = new(bitbucketserver.PullRequest)
+		c.Metadata = new(bitbucketserver.PullRequest)
 	case extsvc.TypeGitLab:
-		t.Metadata = new(gitlab.MergeRequest)
+		c.Metadata = new(gitlab.MergeRequest)
+	case extsvc.TypeBitbucketCloud:
+		c.Metadata = new(bitbucketcloud.PullRequest)
 	default:
 		return errors.New("unknown external service type")
 	}
 
-	if err = json.Unmarshal(metadata, t.Metadata); err != nil {
-		return errors.Wrapf(err, "scanChangeset: failed to unmarshal %q metadata", t.ExternalServiceType)
-	}
-	if err = json.Unmarshal(syncState, &t.SyncState); err != nil {
-		return errors.Wrapf(err, "scanChangeset: failed to unmarshal sync state: %s", syncState)
+	if err := json.Unmarshal(metadata, c.Metadata); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal %q metadata", c.ExternalServiceType)
 	}
-
 	return nil
 }
 
@@ -1280,13 +1384,28 @@ func (s *Store) GetRepoChangesetsStats(ctx context.Context, repoID api.RepoID) (
 	return stats, nil
 }
 
-func (s *Store) EnqueueNextScheduledChangeset(ctx context.Context) (ch *btypes.Changeset, err error) {
+// EnqueueNextScheduledChangesetOpts captures the query options needed for
+// enqueueing the next scheduled changeset.
+type EnqueueNextScheduledChangesetOpts struct {
+	// ExcludeIDs are the IDs of scheduled changesets that must not be
+	// enqueued, such as the ones held back by a rollout policy.
+	ExcludeIDs []int64
+}
+
+func (s *Store) EnqueueNextScheduledChangeset(ctx context.Context, opts EnqueueNextScheduledChangesetOpts) (ch *btypes.Changeset, err error) {
 	ctx, endObservation := s.operations.enqueueNextScheduledChangeset.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("reconciler_state = %s", btypes.ReconcilerStateScheduled.ToDB()),
+	}
+	if len(opts.ExcludeIDs) > 0 {
+		preds = append(preds, sqlf.Sprintf("NOT (id = ANY (%s))", pq.Array(opts.ExcludeIDs)))
+	}
+
 	q := sqlf.Sprintf(
 		enqueueNextScheduledChangesetFmtstr,
-		btypes.ReconcilerStateScheduled.ToDB(),
+		sqlf.Join(preds, "\n AND "),
 		btypes.ReconcilerStateQueued.ToDB(),
 		sqlf.Join(changesetColumns, ","),
 	)
@@ -1311,7 +1430,7 @@ const enqueueNextScheduledChangesetFmtstr = `
 WITH c AS (
 	SELECT *
 	FROM changesets
-	WHERE reconciler_state = %s
+	WHERE %s
 	ORDER BY updated_at ASC
 	LIMIT 1
 )
@@ -1322,6 +1441,85 @@ WHERE c.id = changesets.id
 RETURNING %s
 `
 
+// ListRolloutChangesets lists all changesets owned by batch changes that have
+// scheduled changesets with a rollout policy, together with the rollout
+// policies of their current specs.
+func (s *Store) ListRolloutChangesets(ctx context.Context) (cs []*btypes.RolloutChangeset, err error) {
+	ctx, endObservation := s.operations.listRolloutChangesets.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listRolloutChangesetsFmtstr,
+		btypes.ReconcilerStateScheduled.ToDB(),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var (
+			c        btypes.RolloutChangeset
+			ch       btypes.Changeset
+			metadata json.RawMessage
+			rollout  []byte
+		)
+		if err := sc.Scan(
+			&c.ID,
+			&c.BatchChangeID,
+			&c.RepoName,
+			&c.ReconcilerState,
+			&c.PublicationState,
+			&dbutil.NullString{S: (*string)(&c.ExternalCheckState)},
+			&ch.ExternalServiceType,
+			&metadata,
+			&rollout,
+		); err != nil {
+			return err
+		}
+		c.ReconcilerState = btypes.ReconcilerState(strings.ToUpper(string(c.ReconcilerState)))
+		if c.PublicationState.Published() {
+			if err := unmarshalChangesetMetadata(&ch, metadata); err != nil {
+				return errors.Wrap(err, "ListRolloutChangesets")
+			}
+			c.PublishedAt = ch.ExternalCreatedAt()
+		}
+		if len(rollout) > 0 && string(rollout) != "null" {
+			if err := json.Unmarshal(rollout, &c.Rollout); err != nil {
+				return err
+			}
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	return cs, err
+}
+
+const listRolloutChangesetsFmtstr = `
+-- source: enterprise/internal/batches/store/changesets.go:ListRolloutChangesets
+SELECT
+	changesets.id,
+	changesets.owned_by_batch_change_id,
+	repo.name,
+	changesets.reconciler_state,
+	changesets.publication_state,
+	changesets.external_check_state,
+	changesets.external_service_type,
+	changesets.metadata,
+	changeset_specs.spec->'rollout'
+FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+INNER JOIN changeset_specs ON changeset_specs.id = changesets.current_spec_id
+WHERE
+	repo.deleted_at IS NULL
+	AND changesets.owned_by_batch_change_id IN (
+		SELECT scheduled.owned_by_batch_change_id
+		FROM changesets scheduled
+		INNER JOIN changeset_specs scheduled_specs ON scheduled_specs.id = scheduled.current_spec_id
+		WHERE
+			scheduled.reconciler_state = %s
+			AND scheduled_specs.spec ? 'rollout'
+	)
+ORDER BY changesets.id ASC
+`
+
 func (s *Store) GetChangesetPlaceInSchedulerQueue(ctx context.Context, id int64) (place int, err error) {
 	ctx, endObservation := s.operations.getChangesetPlaceInSchedulerQueue.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.Int("ID", int(id)),
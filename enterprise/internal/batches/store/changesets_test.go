@@ -2090,7 +2090,7 @@ func testStoreChangesetScheduling(t *testing.T, ctx context.Context, s *Store, c
 
 	// By definition, the first changeset should be next, since it has the
 	// earliest update time and is in the right state.
-	have, err := s.EnqueueNextScheduledChangeset(ctx)
+	have, err := s.EnqueueNextScheduledChangeset(ctx, EnqueueNextScheduledChangesetOpts{})
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -2122,7 +2122,7 @@ func testStoreChangesetScheduling(t *testing.T, ctx context.Context, s *Store, c
 	}
 
 	// Given the updated state, second should be the next scheduled changeset.
-	have, err = s.EnqueueNextScheduledChangeset(ctx)
+	have, err = s.EnqueueNextScheduledChangeset(ctx, EnqueueNextScheduledChangesetOpts{})
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -2139,7 +2139,7 @@ func testStoreChangesetScheduling(t *testing.T, ctx context.Context, s *Store, c
 
 	// Now we've enqueued the two scheduled changesets, we shouldn't be able to
 	// enqueue another.
-	if _, err = s.EnqueueNextScheduledChangeset(ctx); err != ErrNoResults {
+	if _, err = s.EnqueueNextScheduledChangeset(ctx, EnqueueNextScheduledChangesetOpts{}); err != ErrNoResults {
 		t.Errorf("unexpected error: have=%v want=%v", err, ErrNoResults)
 	}
 
@@ -2152,6 +2152,92 @@ func testStoreChangesetScheduling(t *testing.T, ctx context.Context, s *Store, c
 	}
 }
 
+// testStoreListRolloutChangesets provides tests for the rollout-related methods
+// on the Store.
+func testStoreListRolloutChangesets(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	user := ct.CreateTestUser(t, s.DatabaseDB(), true)
+	repo, _ := ct.CreateTestRepo(t, ctx, s.DatabaseDB())
+	otherRepo, _ := ct.CreateTestRepo(t, ctx, s.DatabaseDB())
+
+	rollout := &batcheslib.RolloutPolicy{Rate: "1/hour", Waves: []string{string(repo.Name)}}
+
+	createBatchChange := func(name string, policy *batcheslib.RolloutPolicy, states ...btypes.ReconcilerState) []*btypes.Changeset {
+		batchSpec := ct.CreateBatchSpec(t, ctx, s, name, user.ID)
+		batchChange := ct.CreateBatchChange(t, ctx, s, name, user.ID, batchSpec.ID)
+
+		var changesets []*btypes.Changeset
+		for i, state := range states {
+			r := repo
+			if i%2 == 1 {
+				r = otherRepo
+			}
+			spec := ct.CreateChangesetSpec(t, ctx, s, ct.TestSpecOpts{
+				User:      user.ID,
+				Repo:      r.ID,
+				BatchSpec: batchSpec.ID,
+				HeadRef:   "refs/heads/" + name,
+				Published: true,
+				Rollout:   policy,
+			})
+			changesets = append(changesets, ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+				Repo:               r.ID,
+				BatchChange:        batchChange.ID,
+				OwnedByBatchChange: batchChange.ID,
+				CurrentSpec:        spec.ID,
+				PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+				ReconcilerState:    state,
+			}))
+		}
+		return changesets
+	}
+
+	rolledOut := createBatchChange("rolled-out", rollout, btypes.ReconcilerStateScheduled, btypes.ReconcilerStateScheduled)
+	// Batch changes without a rollout policy and without scheduled
+	// changesets aren't returned.
+	createBatchChange("no-policy", nil, btypes.ReconcilerStateScheduled)
+	createBatchChange("done", rollout, btypes.ReconcilerStateCompleted)
+
+	have, err := s.ListRolloutChangesets(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*btypes.RolloutChangeset{
+		{
+			ID:                 rolledOut[0].ID,
+			BatchChangeID:      rolledOut[0].OwnedByBatchChangeID,
+			RepoName:           string(repo.Name),
+			ReconcilerState:    btypes.ReconcilerStateScheduled,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+			ExternalCheckState: rolledOut[0].ExternalCheckState,
+			Rollout:            rollout,
+		},
+		{
+			ID:                 rolledOut[1].ID,
+			BatchChangeID:      rolledOut[1].OwnedByBatchChangeID,
+			RepoName:           string(otherRepo.Name),
+			ReconcilerState:    btypes.ReconcilerStateScheduled,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+			ExternalCheckState: rolledOut[1].ExternalCheckState,
+			Rollout:            rollout,
+		},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected rollout changesets (-want +have):\n%s", diff)
+	}
+
+	// Excluding the first changeset should enqueue the second one.
+	enqueued, err := s.EnqueueNextScheduledChangeset(ctx, EnqueueNextScheduledChangesetOpts{
+		ExcludeIDs: []int64{rolledOut[0].ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enqueued.ID == rolledOut[0].ID {
+		t.Fatalf("excluded changeset %d was enqueued", rolledOut[0].ID)
+	}
+}
+
 func TestCancelQueuedBatchChangeChangesets(t *testing.T) {
 	// We use a separate test for CancelQueuedBatchChangeChangesets because we
 	// want to access the database from different connections and the other
@@ -22,6 +22,7 @@ func TestIntegration(t *testing.T) {
 		t.Run("Changesets", storeTest(db, nil, testStoreChangesets))
 		t.Run("ChangesetEvents", storeTest(db, nil, testStoreChangesetEvents))
 		t.Run("ChangesetScheduling", storeTest(db, nil, testStoreChangesetScheduling))
+		t.Run("ListRolloutChangesets", storeTest(db, nil, testStoreListRolloutChangesets))
 		t.Run("ListChangesetSyncData", storeTest(db, nil, testStoreListChangesetSyncData))
 		t.Run("ListChangesetsTextSearch", storeTest(db, nil, testStoreListChangesetsTextSearch))
 		t.Run("BatchSpecs", storeTest(db, nil, testStoreBatchSpecs))
@@ -38,6 +39,7 @@ func TestIntegration(t *testing.T) {
 		t.Run("BatchSpecWorkspaceExecutionJobs", storeTest(db, nil, testStoreBatchSpecWorkspaceExecutionJobs))
 		t.Run("BatchSpecResolutionJobs", storeTest(db, nil, testStoreBatchSpecResolutionJobs))
 		t.Run("BatchSpecExecutionCacheEntries", storeTest(db, nil, testStoreBatchSpecExecutionCacheEntries))
+		t.Run("BatchChangeScheduledRuns", storeTest(db, nil, testStoreBatchChangeScheduledRuns))
 
 		for name, key := range map[string]encryption.Key{
 			"no key":   nil,
@@ -45,6 +47,7 @@ func TestIntegration(t *testing.T) {
 		} {
 			t.Run(name, func(t *testing.T) {
 				t.Run("SiteCredentials", storeTest(db, key, testStoreSiteCredentials))
+				t.Run("SigningKeys", storeTest(db, key, testStoreSigningKeys))
 			})
 		}
 	})
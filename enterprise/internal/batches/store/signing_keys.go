@@ -0,0 +1,197 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// CreateSigningKey encrypts the given private key and its passphrase and
+// creates the signing key.
+func (s *Store) CreateSigningKey(ctx context.Context, k *btypes.SigningKey, privateKey, passphrase string) (err error) {
+	ctx, endObservation := s.operations.createSigningKey.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("UserID", int(k.UserID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if k.CreatedAt.IsZero() {
+		k.CreatedAt = s.now()
+	}
+
+	if k.UpdatedAt.IsZero() {
+		k.UpdatedAt = k.CreatedAt
+	}
+
+	k.Key = s.key
+	if err := k.SetPrivateKey(ctx, privateKey, passphrase); err != nil {
+		return err
+	}
+
+	q := createSigningKeyQuery(k)
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanSigningKey(k, sc)
+	})
+}
+
+var createSigningKeyQueryFmtstr = `
+-- source: enterprise/internal/batches/store/signing_keys.go:CreateSigningKey
+INSERT INTO batch_changes_signing_keys (
+	user_id,
+	format,
+	private_key,
+	public_key,
+	committer_name,
+	committer_email,
+	encryption_key_id,
+	created_at,
+	updated_at
+)
+VALUES
+	(%s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING
+	%s
+`
+
+func createSigningKeyQuery(k *btypes.SigningKey) *sqlf.Query {
+	return sqlf.Sprintf(
+		createSigningKeyQueryFmtstr,
+		nullInt32Column(k.UserID),
+		k.Format,
+		k.EncryptedPrivateKey,
+		k.PublicKey,
+		k.CommitterName,
+		k.CommitterEmail,
+		k.EncryptionKeyID,
+		k.CreatedAt,
+		k.UpdatedAt,
+		sqlf.Join(signingKeyColumns, ","),
+	)
+}
+
+func (s *Store) DeleteSigningKey(ctx context.Context, id int64) (err error) {
+	ctx, endObservation := s.operations.deleteSigningKey.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	res, err := s.ExecResult(ctx, deleteSigningKeyQuery(id))
+	if err != nil {
+		return err
+	}
+
+	// Check the signing key existed before.
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var deleteSigningKeyQueryFmtstr = `
+-- source: enterprise/internal/batches/store/signing_keys.go:DeleteSigningKey
+DELETE FROM
+	batch_changes_signing_keys
+WHERE
+	%s
+`
+
+func deleteSigningKeyQuery(id int64) *sqlf.Query {
+	return sqlf.Sprintf(
+		deleteSigningKeyQueryFmtstr,
+		sqlf.Sprintf("id = %d", id),
+	)
+}
+
+// GetSigningKeyOpts captures the query options needed for getting a signing
+// key.
+type GetSigningKeyOpts struct {
+	ID     int64
+	UserID int32
+	// Site returns the site-wide signing key.
+	Site bool
+}
+
+func (s *Store) GetSigningKey(ctx context.Context, opts GetSigningKeyOpts) (k *btypes.SigningKey, err error) {
+	ctx, endObservation := s.operations.getSigningKey.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(opts.ID)),
+		log.Int("UserID", int(opts.UserID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := getSigningKeyQuery(opts)
+
+	key := btypes.SigningKey{Key: s.key}
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error { return scanSigningKey(&key, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if key.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &key, nil
+}
+
+var getSigningKeyQueryFmtstr = `
+-- source: enterprise/internal/batches/store/signing_keys.go:GetSigningKey
+SELECT
+	%s
+FROM batch_changes_signing_keys
+WHERE
+	%s
+LIMIT 1
+`
+
+func getSigningKeyQuery(opts GetSigningKeyOpts) *sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("id = %d", opts.ID))
+	}
+	if opts.UserID != 0 {
+		preds = append(preds, sqlf.Sprintf("user_id = %d", opts.UserID))
+	}
+	if opts.Site {
+		preds = append(preds, sqlf.Sprintf("user_id IS NULL"))
+	}
+
+	return sqlf.Sprintf(
+		getSigningKeyQueryFmtstr,
+		sqlf.Join(signingKeyColumns, ","),
+		sqlf.Join(preds, "AND"),
+	)
+}
+
+var signingKeyColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("user_id"),
+	sqlf.Sprintf("format"),
+	sqlf.Sprintf("private_key"),
+	sqlf.Sprintf("public_key"),
+	sqlf.Sprintf("committer_name"),
+	sqlf.Sprintf("committer_email"),
+	sqlf.Sprintf("encryption_key_id"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+func scanSigningKey(k *btypes.SigningKey, sc dbutil.Scanner) error {
+	return sc.Scan(
+		&k.ID,
+		&dbutil.NullInt32{N: &k.UserID},
+		&k.Format,
+		&k.EncryptedPrivateKey,
+		&k.PublicKey,
+		&k.CommitterName,
+		&k.CommitterEmail,
+		&k.EncryptionKeyID,
+		&dbutil.NullTime{Time: &k.CreatedAt},
+		&dbutil.NullTime{Time: &k.UpdatedAt},
+	)
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/commitsigning"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+)
+
+func testStoreSigningKeys(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	user := ct.CreateTestUser(t, s.DatabaseDB(), false)
+
+	keypair, err := encryption.GenerateRSAKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := make([]*btypes.SigningKey, 0, 2)
+
+	t.Run("Create", func(t *testing.T) {
+		for _, userID := range []int32{0, user.ID} {
+			k := &btypes.SigningKey{
+				UserID:         userID,
+				Format:         commitsigning.FormatSSH,
+				CommitterName:  "Batch Changes",
+				CommitterEmail: "batch-changes@example.com",
+			}
+			if err := s.CreateSigningKey(ctx, k, keypair.PrivateKey, keypair.Passphrase); err != nil {
+				t.Fatal(err)
+			}
+			if k.ID == 0 {
+				t.Fatal("id should not be zero")
+			}
+			if k.PublicKey != keypair.PublicKey {
+				t.Fatalf("wrong public key. want=%q, have=%q", keypair.PublicKey, k.PublicKey)
+			}
+			keys = append(keys, k)
+		}
+
+		t.Run("Duplicate", func(t *testing.T) {
+			k := &btypes.SigningKey{Format: commitsigning.FormatSSH}
+			if err := s.CreateSigningKey(ctx, k, keypair.PrivateKey, keypair.Passphrase); err == nil {
+				t.Fatal("no error for second site signing key")
+			}
+		})
+
+		t.Run("Invalid", func(t *testing.T) {
+			k := &btypes.SigningKey{Format: commitsigning.FormatSSH}
+			if err := s.CreateSigningKey(ctx, k, keypair.PrivateKey, "wrong"); err == nil {
+				t.Fatal("no error for invalid private key")
+			}
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			opts GetSigningKeyOpts
+			want *btypes.SigningKey
+		}{
+			"ByID":     {opts: GetSigningKeyOpts{ID: keys[1].ID}, want: keys[1]},
+			"ByUserID": {opts: GetSigningKeyOpts{UserID: user.ID}, want: keys[1]},
+			"Site":     {opts: GetSigningKeyOpts{Site: true}, want: keys[0]},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, err := s.GetSigningKey(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(have, tc.want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				privateKey, passphrase, err := have.PrivateKey(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if privateKey != keypair.PrivateKey || passphrase != keypair.Passphrase {
+					t.Fatal("wrong private key returned")
+				}
+			})
+		}
+
+		t.Run("NoResults", func(t *testing.T) {
+			if _, err := s.GetSigningKey(ctx, GetSigningKeyOpts{UserID: user.ID + 1}); err != ErrNoResults {
+				t.Fatalf("unexpected error, want=%q have=%q", ErrNoResults, err)
+			}
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		for _, k := range keys {
+			if err := s.DeleteSigningKey(ctx, k.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.GetSigningKey(ctx, GetSigningKeyOpts{ID: k.ID}); err != ErrNoResults {
+				t.Fatalf("unexpected error, want=%q have=%q", ErrNoResults, err)
+			}
+		}
+
+		if err := s.DeleteSigningKey(ctx, keys[0].ID); err != ErrNoResults {
+			t.Fatalf("unexpected error, want=%q have=%q", ErrNoResults, err)
+		}
+	})
+}
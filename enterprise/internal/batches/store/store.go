@@ -177,6 +177,11 @@ type operations struct {
 	getRepoDiffStat        *observation.Operation
 	listBatchChanges       *observation.Operation
 
+	createBatchChangeScheduledRun *observation.Operation
+	updateBatchChangeScheduledRun *observation.Operation
+	listBatchChangeScheduledRuns  *observation.Operation
+	countBatchChangeScheduledRuns *observation.Operation
+
 	createBatchSpecExecution *observation.Operation
 	getBatchSpecExecution    *observation.Operation
 	cancelBatchSpecExecution *observation.Operation
@@ -215,24 +220,27 @@ type operations struct {
 	listChangesetSpecsWithConflictingHeadRef *observation.Operation
 	deleteChangesetSpecs                     *observation.Operation
 
-	createChangeset                   *observation.Operation
-	deleteChangeset                   *observation.Operation
-	countChangesets                   *observation.Operation
-	getChangeset                      *observation.Operation
-	listChangesetSyncData             *observation.Operation
-	listChangesets                    *observation.Operation
-	enqueueChangeset                  *observation.Operation
-	updateChangeset                   *observation.Operation
-	updateChangesetBatchChanges       *observation.Operation
-	updateChangesetUIPublicationState *observation.Operation
-	updateChangesetCodeHostState      *observation.Operation
-	getChangesetExternalIDs           *observation.Operation
-	cancelQueuedBatchChangeChangesets *observation.Operation
-	enqueueChangesetsToClose          *observation.Operation
-	getChangesetsStats                *observation.Operation
-	getRepoChangesetsStats            *observation.Operation
-	enqueueNextScheduledChangeset     *observation.Operation
-	getChangesetPlaceInSchedulerQueue *observation.Operation
+	createChangeset                       *observation.Operation
+	deleteChangeset                       *observation.Operation
+	countChangesets                       *observation.Operation
+	getChangeset                          *observation.Operation
+	listChangesetSyncData                 *observation.Operation
+	listChangesets                        *observation.Operation
+	enqueueChangeset                      *observation.Operation
+	updateChangeset                       *observation.Operation
+	updateChangesetBatchChanges           *observation.Operation
+	updateChangesetUIPublicationState     *observation.Operation
+	updateChangesetAutoMergeBlockedReason *observation.Operation
+	enqueueChangesetForPolicies           *observation.Operation
+	updateChangesetCodeHostState          *observation.Operation
+	getChangesetExternalIDs               *observation.Operation
+	cancelQueuedBatchChangeChangesets     *observation.Operation
+	enqueueChangesetsToClose              *observation.Operation
+	getChangesetsStats                    *observation.Operation
+	getRepoChangesetsStats                *observation.Operation
+	enqueueNextScheduledChangeset         *observation.Operation
+	getChangesetPlaceInSchedulerQueue     *observation.Operation
+	listRolloutChangesets                 *observation.Operation
 
 	listCodeHosts         *observation.Operation
 	getExternalServiceIDs *observation.Operation
@@ -243,11 +251,16 @@ type operations struct {
 	listSiteCredentials  *observation.Operation
 	updateSiteCredential *observation.Operation
 
+	createSigningKey *observation.Operation
+	deleteSigningKey *observation.Operation
+	getSigningKey    *observation.Operation
+
 	createBatchSpecWorkspace       *observation.Operation
 	getBatchSpecWorkspace          *observation.Operation
 	listBatchSpecWorkspaces        *observation.Operation
 	countBatchSpecWorkspaces       *observation.Operation
 	markSkippedBatchSpecWorkspaces *observation.Operation
+	resetBatchSpecWorkspaceCommit  *observation.Operation
 
 	createBatchSpecWorkspaceExecutionJobs              *observation.Operation
 	createBatchSpecWorkspaceExecutionJobsForWorkspaces *observation.Operation
@@ -310,6 +323,11 @@ func newOperations(observationContext *observation.Context) *operations {
 			getBatchChangeDiffStat: op("GetBatchChangeDiffStat"),
 			getRepoDiffStat:        op("GetRepoDiffStat"),
 
+			createBatchChangeScheduledRun: op("CreateBatchChangeScheduledRun"),
+			updateBatchChangeScheduledRun: op("UpdateBatchChangeScheduledRun"),
+			listBatchChangeScheduledRuns:  op("ListBatchChangeScheduledRuns"),
+			countBatchChangeScheduledRuns: op("CountBatchChangeScheduledRuns"),
+
 			createBatchSpecExecution: op("CreateBatchSpecExecution"),
 			getBatchSpecExecution:    op("GetBatchSpecExecution"),
 			cancelBatchSpecExecution: op("CancelBatchSpecExecution"),
@@ -348,24 +366,27 @@ func newOperations(observationContext *observation.Context) *operations {
 			getRewirerMappings:                       op("GetRewirerMappings"),
 			listChangesetSpecsWithConflictingHeadRef: op("ListChangesetSpecsWithConflictingHeadRef"),
 
-			createChangeset:                   op("CreateChangeset"),
-			deleteChangeset:                   op("DeleteChangeset"),
-			countChangesets:                   op("CountChangesets"),
-			getChangeset:                      op("GetChangeset"),
-			listChangesetSyncData:             op("ListChangesetSyncData"),
-			listChangesets:                    op("ListChangesets"),
-			enqueueChangeset:                  op("EnqueueChangeset"),
-			updateChangeset:                   op("UpdateChangeset"),
-			updateChangesetBatchChanges:       op("UpdateChangesetBatchChanges"),
-			updateChangesetUIPublicationState: op("UpdateChangesetUIPublicationState"),
-			updateChangesetCodeHostState:      op("UpdateChangesetCodeHostState"),
-			getChangesetExternalIDs:           op("GetChangesetExternalIDs"),
-			cancelQueuedBatchChangeChangesets: op("CancelQueuedBatchChangeChangesets"),
-			enqueueChangesetsToClose:          op("EnqueueChangesetsToClose"),
-			getChangesetsStats:                op("GetChangesetsStats"),
-			getRepoChangesetsStats:            op("GetRepoChangesetsStats"),
-			enqueueNextScheduledChangeset:     op("EnqueueNextScheduledChangeset"),
-			getChangesetPlaceInSchedulerQueue: op("GetChangesetPlaceInSchedulerQueue"),
+			createChangeset:                       op("CreateChangeset"),
+			deleteChangeset:                       op("DeleteChangeset"),
+			countChangesets:                       op("CountChangesets"),
+			getChangeset:                          op("GetChangeset"),
+			listChangesetSyncData:                 op("ListChangesetSyncData"),
+			listChangesets:                        op("ListChangesets"),
+			enqueueChangeset:                      op("EnqueueChangeset"),
+			updateChangeset:                       op("UpdateChangeset"),
+			updateChangesetBatchChanges:           op("UpdateChangesetBatchChanges"),
+			updateChangesetUIPublicationState:     op("UpdateChangesetUIPublicationState"),
+			updateChangesetAutoMergeBlockedReason: op("UpdateChangesetAutoMergeBlockedReason"),
+			enqueueChangesetForPolicies:           op("EnqueueChangesetForPolicies"),
+			updateChangesetCodeHostState:          op("UpdateChangesetCodeHostState"),
+			getChangesetExternalIDs:               op("GetChangesetExternalIDs"),
+			cancelQueuedBatchChangeChangesets:     op("CancelQueuedBatchChangeChangesets"),
+			enqueueChangesetsToClose:              op("EnqueueChangesetsToClose"),
+			getChangesetsStats:                    op("GetChangesetsStats"),
+			getRepoChangesetsStats:                op("GetRepoChangesetsStats"),
+			enqueueNextScheduledChangeset:         op("EnqueueNextScheduledChangeset"),
+			getChangesetPlaceInSchedulerQueue:     op("GetChangesetPlaceInSchedulerQueue"),
+			listRolloutChangesets:                 op("ListRolloutChangesets"),
 
 			listCodeHosts:         op("ListCodeHosts"),
 			getExternalServiceIDs: op("GetExternalServiceIDs"),
@@ -376,11 +397,16 @@ func newOperations(observationContext *observation.Context) *operations {
 			listSiteCredentials:  op("ListSiteCredentials"),
 			updateSiteCredential: op("UpdateSiteCredential"),
 
+			createSigningKey: op("CreateSigningKey"),
+			deleteSigningKey: op("DeleteSigningKey"),
+			getSigningKey:    op("GetSigningKey"),
+
 			createBatchSpecWorkspace:       op("CreateBatchSpecWorkspace"),
 			getBatchSpecWorkspace:          op("GetBatchSpecWorkspace"),
 			listBatchSpecWorkspaces:        op("ListBatchSpecWorkspaces"),
 			countBatchSpecWorkspaces:       op("CountBatchSpecWorkspaces"),
 			markSkippedBatchSpecWorkspaces: op("MarkSkippedBatchSpecWorkspaces"),
+			resetBatchSpecWorkspaceCommit:  op("ResetBatchSpecWorkspaceCommit"),
 
 			createBatchSpecWorkspaceExecutionJobs:              op("CreateBatchSpecWorkspaceExecutionJobs"),
 			createBatchSpecWorkspaceExecutionJobsForWorkspaces: op("CreateBatchSpecWorkspaceExecutionJobsForWorkspaces"),
@@ -113,7 +113,7 @@ func deleteAccessToken(ctx context.Context, batchesStore *Store, tokenID int64)
 
 type markFinal func(ctx context.Context, tx dbworkerstore.Store) (_ bool, err error)
 
-func (s *batchSpecWorkspaceExecutionWorkerStore) markFinal(ctx context.Context, id int, fn markFinal) (ok bool, err error) {
+func (s *batchSpecWorkspaceExecutionWorkerStore) markFinal(ctx context.Context, id int, failureMessage string, fn markFinal) (ok bool, err error) {
 	batchesStore := New(s.Store.Handle().DB(), s.observationContext, nil)
 	tx, err := batchesStore.Transact(ctx)
 	if err != nil {
@@ -161,6 +161,10 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) markFinal(ctx context.Context,
 		return false, err
 	}
 
+	if err := failChangesetRebases(ctx, tx, workspace.ChangesetSpecIDs, failureMessage); err != nil {
+		return false, err
+	}
+
 	for _, entry := range append(executionResults, stepResults...) {
 		entry.UserID = spec.UserID
 		if err := tx.CreateBatchSpecExecutionCacheEntry(ctx, entry); err != nil {
@@ -172,13 +176,13 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) markFinal(ctx context.Context,
 }
 
 func (s *batchSpecWorkspaceExecutionWorkerStore) MarkErrored(ctx context.Context, id int, failureMessage string, options dbworkerstore.MarkFinalOptions) (_ bool, err error) {
-	return s.markFinal(ctx, id, func(ctx context.Context, tx dbworkerstore.Store) (bool, error) {
+	return s.markFinal(ctx, id, failureMessage, func(ctx context.Context, tx dbworkerstore.Store) (bool, error) {
 		return tx.MarkErrored(ctx, id, failureMessage, options)
 	})
 }
 
 func (s *batchSpecWorkspaceExecutionWorkerStore) MarkFailed(ctx context.Context, id int, failureMessage string, options dbworkerstore.MarkFinalOptions) (_ bool, err error) {
-	return s.markFinal(ctx, id, func(ctx context.Context, tx dbworkerstore.Store) (bool, error) {
+	return s.markFinal(ctx, id, failureMessage, func(ctx context.Context, tx dbworkerstore.Store) (bool, error) {
 		return tx.MarkFailed(ctx, id, failureMessage, options)
 	})
 }
@@ -222,7 +226,11 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 	rollbackAndMarkFailed := func(err error, fmtStr string, args ...interface{}) (bool, error) {
 		// Rollback transaction but ignore rollback errors
 		tx.Done(err)
-		return s.Store.MarkFailed(ctx, id, fmt.Sprintf(fmtStr, args...), options)
+		failureMessage := fmt.Sprintf(fmtStr, args...)
+		if err := failChangesetRebases(ctx, batchesStore, workspace.ChangesetSpecIDs, failureMessage); err != nil {
+			log15.Error("failed to mark changeset rebases as failed", "err", err)
+		}
+		return s.Store.MarkFailed(ctx, id, failureMessage, options)
 	}
 
 	executionResults, stepResults, err := extractCacheEntries(ctx, events)
@@ -238,7 +246,7 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 		}
 	}
 
-	changesetSpecIDs := []int64{}
+	changesetSpecs := []*btypes.ChangesetSpec{}
 	for _, entry := range executionResults {
 		// Store the cache entry.
 		entry.UserID = batchSpec.UserID
@@ -284,12 +292,19 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 			if err := tx.CreateChangesetSpec(ctx, specs...); err != nil {
 				return rollbackAndMarkFailed(err, fmt.Sprintf("failed to store changeset specs: %s", err))
 			}
-			for _, spec := range specs {
-				changesetSpecIDs = append(changesetSpecIDs, spec.ID)
-			}
+			changesetSpecs = append(changesetSpecs, specs...)
 		}
 	}
 
+	if err := completeChangesetRebases(ctx, tx, workspace.ChangesetSpecIDs, changesetSpecs); err != nil {
+		return rollbackAndMarkFailed(err, fmt.Sprintf("failed to update re-executed changesets: %s", err))
+	}
+
+	changesetSpecIDs := make([]int64, 0, len(changesetSpecs))
+	for _, spec := range changesetSpecs {
+		changesetSpecIDs = append(changesetSpecIDs, spec.ID)
+	}
+
 	err = deleteAccessToken(ctx, tx, job.AccessTokenID)
 	if err != nil {
 		return rollbackAndMarkFailed(err, fmt.Sprintf("failed to delete internal access token: %s", err))
@@ -355,6 +370,102 @@ SET
 WHERE id = %s
 `
 
+// completeChangesetRebases makes the given changeset specs, which were created
+// by re-executing a workspace on a new commit of its base branch, the current
+// specs of the changesets that were waiting for the re-execution. Changesets
+// are matched to the new specs by their branch, and enqueued for the
+// reconciler to push the new commit. The previous specs that are replaced by
+// the new specs are detached from the batch spec, so that it doesn't contain
+// two specs for the same branch.
+func completeChangesetRebases(ctx context.Context, tx *Store, previousSpecIDs []int64, specs []*btypes.ChangesetSpec) error {
+	if len(previousSpecIDs) == 0 {
+		return nil
+	}
+
+	if len(specs) > 0 {
+		specIDs := make([]int64, 0, len(specs))
+		for _, spec := range specs {
+			specIDs = append(specIDs, spec.ID)
+		}
+		q := sqlf.Sprintf(detachReplacedChangesetSpecsQueryFmtstr, pq.Array(previousSpecIDs), pq.Array(specIDs))
+		if err := tx.Exec(ctx, q); err != nil {
+			return errors.Wrap(err, "detaching replaced changeset specs")
+		}
+	}
+
+	cs, _, err := tx.ListChangesets(ctx, ListChangesetsOpts{CurrentSpecIDs: previousSpecIDs, OnlyRebasing: true})
+	if err != nil {
+		return err
+	}
+
+	for _, c := range cs {
+		c.Rebasing = false
+
+		var found bool
+		for _, spec := range specs {
+			if spec.Spec.HeadRef == c.ExternalBranch {
+				c.PreviousSpecID = c.CurrentSpecID
+				c.SetCurrentSpec(spec)
+				c.ResetReconcilerState(btypes.ReconcilerStateQueued)
+				found = true
+				break
+			}
+		}
+		if !found {
+			msg := "Re-executing the steps on the latest commit of the base branch did not produce any changes for this changeset."
+			c.RebaseFailureMessage = &msg
+		}
+
+		if err := tx.UpdateChangeset(ctx, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const detachReplacedChangesetSpecsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/worker_workspace_execution.go:completeChangesetRebases
+UPDATE
+	changeset_specs
+SET
+	batch_spec_id = NULL
+WHERE
+	changeset_specs.id = ANY (%s)
+AND
+	(changeset_specs.repo_id, changeset_specs.spec->>'headRef') IN (
+		SELECT
+			new_specs.repo_id, new_specs.spec->>'headRef'
+		FROM
+			changeset_specs new_specs
+		WHERE
+			new_specs.id = ANY (%s)
+	)
+`
+
+// failChangesetRebases records the given failure on the changesets that were
+// waiting for a re-execution of the workspace that produced their current
+// specs.
+func failChangesetRebases(ctx context.Context, tx *Store, specIDs []int64, failureMessage string) error {
+	if len(specIDs) == 0 {
+		return nil
+	}
+
+	cs, _, err := tx.ListChangesets(ctx, ListChangesetsOpts{CurrentSpecIDs: specIDs, OnlyRebasing: true})
+	if err != nil {
+		return err
+	}
+
+	for _, c := range cs {
+		msg := fmt.Sprintf("Re-executing the steps on the latest commit of the base branch failed: %s", failureMessage)
+		c.Rebasing = false
+		c.RebaseFailureMessage = &msg
+		if err := tx.UpdateChangeset(ctx, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func extractCacheEntries(ctx context.Context, events []*batcheslib.LogEvent) (executionResults, stepResults []*btypes.BatchSpecExecutionCacheEntry, err error) {
 	for _, e := range events {
 		switch m := e.Metadata.(type) {
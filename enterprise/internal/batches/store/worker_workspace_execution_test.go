@@ -311,3 +311,92 @@ stdout: {"operation":"CACHE_AFTER_STEP_RESULT","timestamp":"2021-11-04T12:43:19.
 }
 
 func intptr(i int) *int { return &i }
+
+func TestCompleteChangesetRebases(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewDB(dbtest.NewDB(t))
+	user := ct.CreateTestUser(t, db, true)
+
+	repo, _ := ct.CreateTestRepo(t, ctx, db)
+
+	now := time.Now()
+	s := NewWithClock(db, &observation.TestContext, nil, func() time.Time { return now })
+
+	batchSpec := ct.CreateBatchSpec(t, ctx, s, "rebase", user.ID)
+	batchChange := ct.CreateBatchChange(t, ctx, s, "rebase", user.ID, batchSpec.ID)
+
+	previousSpec := ct.CreateChangesetSpec(t, ctx, s, ct.TestSpecOpts{
+		User:      user.ID,
+		Repo:      repo.ID,
+		BatchSpec: batchSpec.ID,
+		HeadRef:   "refs/heads/rebase",
+		Published: true,
+	})
+	changeset := ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+		Repo:               repo.ID,
+		BatchChange:        batchChange.ID,
+		CurrentSpec:        previousSpec.ID,
+		OwnedByBatchChange: batchChange.ID,
+		ExternalBranch:     "refs/heads/rebase",
+		Rebasing:           true,
+	})
+
+	// The re-executed workspace creates a new spec for the same branch in the
+	// same batch spec.
+	newSpec := ct.CreateChangesetSpec(t, ctx, s, ct.TestSpecOpts{
+		User:      user.ID,
+		Repo:      repo.ID,
+		BatchSpec: batchSpec.ID,
+		HeadRef:   "refs/heads/rebase",
+		Published: true,
+	})
+
+	if err := completeChangesetRebases(ctx, s, []int64{previousSpec.ID}, []*btypes.ChangesetSpec{newSpec}); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := s.GetChangeset(ctx, GetChangesetOpts{ID: changeset.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.CurrentSpecID != newSpec.ID || reloaded.PreviousSpecID != previousSpec.ID {
+		t.Fatalf("wrong specs. want current=%d previous=%d, have current=%d previous=%d", newSpec.ID, previousSpec.ID, reloaded.CurrentSpecID, reloaded.PreviousSpecID)
+	}
+	if reloaded.Rebasing || reloaded.ReconcilerState != btypes.ReconcilerStateQueued {
+		t.Fatalf("changeset not enqueued after rebase: rebasing=%t, state=%s", reloaded.Rebasing, reloaded.ReconcilerState)
+	}
+
+	// Re-applying the batch spec requires that it has only one spec per
+	// branch.
+	conflicts, err := s.ListChangesetSpecsWithConflictingHeadRef(ctx, batchSpec.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("batch spec has conflicting changeset specs: %+v", conflicts)
+	}
+	specs, _, err := s.ListChangesetSpecs(ctx, ListChangesetSpecsOpts{BatchSpecID: batchSpec.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) != 1 || specs[0].ID != newSpec.ID {
+		t.Fatalf("wrong changeset specs attached to batch spec: %+v", specs)
+	}
+	mappings, err := s.GetRewirerMappings(ctx, GetRewirerMappingsOpts{BatchSpecID: batchSpec.ID, BatchChangeID: batchChange.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mappings) != 1 || mappings[0].ChangesetSpecID != newSpec.ID || mappings[0].ChangesetID != changeset.ID {
+		t.Fatalf("wrong rewirer mappings: %+v", mappings)
+	}
+
+	// The detached spec is still the previous spec of the changeset, so it
+	// must not expire.
+	now = now.Add(btypes.ChangesetSpecTTL + time.Hour)
+	if err := s.DeleteExpiredChangesetSpecs(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetChangesetSpecByID(ctx, previousSpec.ID); err != nil {
+		t.Fatalf("previous spec was deleted: %s", err)
+	}
+}
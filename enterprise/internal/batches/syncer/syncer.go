@@ -491,7 +491,13 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, source sources.Chan
 		return err
 	}
 
-	return tx.UpsertChangesetEvents(ctx, events...)
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		return err
+	}
+
+	// If the changeset has an auto-merge or rebase policy, the reconciler
+	// needs to re-evaluate it against the new code host state.
+	return tx.EnqueueChangesetForPolicies(ctx, c)
 }
 
 func loadChangesetSource(ctx context.Context, cf *httpcli.Factory, syncStore SyncStore, repo *types.Repo) (sources.ChangesetSource, error) {
@@ -48,6 +48,7 @@ type TestChangesetOpts struct {
 	Closing    bool
 	IsArchived bool
 	Archive    bool
+	Rebasing   bool
 
 	Metadata interface{}
 }
@@ -95,7 +96,8 @@ func BuildChangeset(opts TestChangesetOpts) *btypes.Changeset {
 
 		OwnedByBatchChangeID: opts.OwnedByBatchChange,
 
-		Closing: opts.Closing,
+		Closing:  opts.Closing,
+		Rebasing: opts.Rebasing,
 
 		ReconcilerState: opts.ReconcilerState,
 		NumFailures:     opts.NumFailures,
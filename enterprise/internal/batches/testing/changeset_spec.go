@@ -40,6 +40,15 @@ type TestSpecOpts struct {
 
 	BaseRev string
 	BaseRef string
+
+	Rebase  *batcheslib.RebasePolicy
+	Rollout *batcheslib.RolloutPolicy
+
+	Labels    []string
+	Assignees []string
+	Milestone string
+
+	Reviewers *batcheslib.ChangesetReviewers
 }
 
 var TestChangsetSpecDiffStat = &diff.Stat{Added: 10, Changed: 5, Deleted: 2}
@@ -70,6 +79,14 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 			Title: opts.Title,
 			Body:  opts.Body,
 
+			Labels:    opts.Labels,
+			Assignees: opts.Assignees,
+			Milestone: opts.Milestone,
+			Reviewers: opts.Reviewers,
+
+			Rebase:  opts.Rebase,
+			Rollout: opts.Rollout,
+
 			Commits: []batcheslib.GitCommitDescription{
 				{
 					Message:     opts.CommitMessage,
@@ -3,6 +3,9 @@ package types
 import (
 	"strings"
 	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/robfig/cron/v3"
 )
 
 // BatchChangeState defines the possible states of a BatchChange
@@ -31,6 +34,14 @@ type BatchChange struct {
 
 	ClosedAt time.Time
 
+	// Schedule is the cron expression on which the batch change is re-executed
+	// and re-applied. It's empty if the batch change isn't scheduled.
+	Schedule string
+	// ScheduleUserID is the user on whose behalf scheduled runs are executed
+	// and applied.
+	ScheduleUserID     int32
+	NextScheduledRunAt time.Time
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -49,5 +60,37 @@ func (c *BatchChange) Closed() bool { return !c.ClosedAt.IsZero() }
 // yet.
 func (c *BatchChange) IsDraft() bool { return c.LastAppliedAt.IsZero() }
 
+// Scheduled returns true when the batch change has a schedule.
+func (c *BatchChange) Scheduled() bool { return c.Schedule != "" }
+
+// MinScheduleInterval is the minimum amount of time between two scheduled runs
+// of a batch change.
+const MinScheduleInterval = time.Hour
+
+// ParseBatchChangeSchedule parses the given cron expression. Next times are
+// computed in UTC, unless the expression is prefixed with CRON_TZ=.
+//
+// Schedules that run more often than MinScheduleInterval are rejected, since
+// a run usually takes longer than that to execute.
+func ParseBatchChangeSchedule(expr string) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule %q", expr)
+	}
+
+	// Check the intervals between the upcoming runs, starting at a fixed point
+	// in time, so that the result doesn't depend on when the schedule is set.
+	t := schedule.Next(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	for i := 0; i < 100 && !t.IsZero(); i++ {
+		next := schedule.Next(t)
+		if !next.IsZero() && next.Sub(t) < MinScheduleInterval {
+			return nil, errors.Errorf("invalid schedule %q: runs must be at least %s apart", expr, MinScheduleInterval)
+		}
+		t = next
+	}
+
+	return schedule, nil
+}
+
 // ToGraphQL returns the GraphQL representation of the state.
 func (s BatchChangeState) ToGraphQL() string { return strings.ToUpper(string(s)) }
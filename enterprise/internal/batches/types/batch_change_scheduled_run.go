@@ -0,0 +1,62 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// BatchChangeScheduledRunState defines the possible states of a
+// BatchChangeScheduledRun.
+type BatchChangeScheduledRunState string
+
+// BatchChangeScheduledRunState constants.
+const (
+	BatchChangeScheduledRunStateResolving BatchChangeScheduledRunState = "resolving"
+	BatchChangeScheduledRunStateExecuting BatchChangeScheduledRunState = "executing"
+	BatchChangeScheduledRunStateApplied   BatchChangeScheduledRunState = "applied"
+	BatchChangeScheduledRunStateUnchanged BatchChangeScheduledRunState = "unchanged"
+	BatchChangeScheduledRunStateFailed    BatchChangeScheduledRunState = "failed"
+)
+
+// Valid returns true if the given BatchChangeScheduledRunState is valid.
+func (s BatchChangeScheduledRunState) Valid() bool {
+	switch s {
+	case BatchChangeScheduledRunStateResolving,
+		BatchChangeScheduledRunStateExecuting,
+		BatchChangeScheduledRunStateApplied,
+		BatchChangeScheduledRunStateUnchanged,
+		BatchChangeScheduledRunStateFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Finished returns whether the run has finished.
+func (s BatchChangeScheduledRunState) Finished() bool {
+	return s == BatchChangeScheduledRunStateApplied ||
+		s == BatchChangeScheduledRunStateUnchanged ||
+		s == BatchChangeScheduledRunStateFailed
+}
+
+// ToGraphQL returns the GraphQL representation of the state.
+func (s BatchChangeScheduledRunState) ToGraphQL() string { return strings.ToUpper(string(s)) }
+
+// A BatchChangeScheduledRun is a single run of a scheduled batch change. Each
+// run executes the batch spec of the batch change again server-side, and
+// applies the resulting batch spec if its changeset specs differ from the
+// ones currently applied.
+type BatchChangeScheduledRun struct {
+	ID            int64
+	BatchChangeID int64
+	// BatchSpecID is the batch spec created for the run. It's zero once the
+	// batch spec has expired without having been applied.
+	BatchSpecID int64
+
+	State          BatchChangeScheduledRunState
+	FailureMessage *string
+
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt time.Time
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBatchChangeSchedule(t *testing.T) {
+	now := time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC)
+
+	for expr, want := range map[string]time.Time{
+		"0 9 * * 1":                        time.Date(2022, 2, 7, 9, 0, 0, 0, time.UTC),
+		"@daily":                           time.Date(2022, 2, 2, 0, 0, 0, 0, time.UTC),
+		"0 * * * *":                        time.Date(2022, 2, 1, 11, 0, 0, 0, time.UTC),
+		"CRON_TZ=Europe/Berlin 0 12 * * *": time.Date(2022, 2, 1, 11, 0, 0, 0, time.UTC),
+	} {
+		t.Run(expr, func(t *testing.T) {
+			schedule, err := ParseBatchChangeSchedule(expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have := schedule.Next(now); !have.Equal(want) {
+				t.Errorf("wrong next run: have %s, want %s", have, want)
+			}
+		})
+	}
+
+	for _, expr := range []string{
+		"",
+		"not a schedule",
+		"* * * * *",
+		"*/30 * * * *",
+		"0,15 9 * * *",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := ParseBatchChangeSchedule(expr); err == nil {
+				t.Error("unexpected nil error")
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -229,6 +230,17 @@ func (s ChangesetCheckState) Valid() bool {
 	}
 }
 
+// ChangesetMergeability describes whether a changeset can be merged into its
+// base branch.
+type ChangesetMergeability string
+
+const (
+	ChangesetMergeabilityUnknown     ChangesetMergeability = "UNKNOWN"
+	ChangesetMergeabilityMergeable   ChangesetMergeability = "MERGEABLE"
+	ChangesetMergeabilityBehind      ChangesetMergeability = "BEHIND"
+	ChangesetMergeabilityConflicting ChangesetMergeability = "CONFLICTING"
+)
+
 // BatchChangeAssoc stores the details of a association to a BatchChange.
 type BatchChangeAssoc struct {
 	BatchChangeID int64 `json:"-"`
@@ -286,6 +298,17 @@ type Changeset struct {
 	// Closing is set to true (along with the ReocncilerState) when the
 	// reconciler should close the changeset.
 	Closing bool
+
+	// AutoMergeBlockedReason is set by the reconciler when the changeset spec
+	// has an auto-merge policy, but the changeset can't be merged yet.
+	AutoMergeBlockedReason string
+
+	// Rebasing is set by the reconciler while the steps of the changeset are
+	// re-executed on the latest commit of its base branch, as requested by
+	// the rebase policy of its changeset spec.
+	Rebasing bool
+	// RebaseFailureMessage is set if the last re-execution failed.
+	RebaseFailureMessage *string
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -394,6 +417,18 @@ func (c *Changeset) SetMetadata(meta interface{}) error {
 		c.ExternalBranch = git.EnsureRefPrefix(pr.SourceBranch)
 		c.ExternalUpdatedAt = pr.UpdatedAt.Time
 		c.ExternalForkNamespace = pr.SourceProjectNamespace
+	case *bitbucketcloud.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.FormatInt(int64(pr.ID), 10)
+		c.ExternalServiceType = extsvc.TypeBitbucketCloud
+		c.ExternalBranch = git.EnsureRefPrefix(pr.Source.Branch.Name)
+		c.ExternalUpdatedAt = pr.UpdatedOn
+
+		if pr.Source.Repo.UUID != pr.Destination.Repo.UUID {
+			c.ExternalForkNamespace = strings.SplitN(pr.Source.Repo.FullName, "/", 2)[0]
+		} else {
+			c.ExternalForkNamespace = ""
+		}
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -419,6 +454,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *gitlab.MergeRequest:
 		return m.Title, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Title, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -436,6 +473,8 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.User.Name, nil
 	case *gitlab.MergeRequest:
 		return m.Author.Username, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Author.Nickname, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -459,6 +498,9 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		return m.Author.User.EmailAddress, nil
 	case *gitlab.MergeRequest:
 		return m.Author.Email, nil
+	case *bitbucketcloud.PullRequest:
+		// Bitbucket Cloud doesn't expose the email addresses of other users.
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -475,6 +517,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return unixMilliToTime(int64(m.CreatedDate))
 	case *gitlab.MergeRequest:
 		return m.CreatedAt.Time
+	case *bitbucketcloud.PullRequest:
+		return m.CreatedOn
 	default:
 		return time.Time{}
 	}
@@ -489,6 +533,8 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *gitlab.MergeRequest:
 		return m.Description, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Description, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -526,6 +572,8 @@ func (c *Changeset) URL() (s string, err error) {
 		return selfLink.Href, nil
 	case *gitlab.MergeRequest:
 		return m.WebURL, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Links.HTML.Href, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -658,6 +706,50 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 				Metadata:    pipeline,
 			})
 		}
+
+	case *bitbucketcloud.PullRequest:
+		events = make([]*ChangesetEvent, 0, len(m.Participants)+len(m.Statuses))
+
+		addEvent := func(e Keyer) error {
+			kind, err := ChangesetEventKindFor(e)
+			if err != nil {
+				return err
+			}
+
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         e.Key(),
+				Kind:        kind,
+				Metadata:    e,
+			})
+			return nil
+		}
+		// The API only returns the current review state of each participant,
+		// so we synthesise a review event for it. Events for earlier reviews
+		// are only received through webhooks.
+		for _, p := range m.Participants {
+			var action bitbucketcloud.ParticipantAction
+			switch p.State {
+			case bitbucketcloud.ParticipantStateApproved:
+				action = bitbucketcloud.ParticipantActionApproved
+			case bitbucketcloud.ParticipantStateChangesRequested:
+				action = bitbucketcloud.ParticipantActionChangesRequestCreated
+			default:
+				continue
+			}
+			if err = addEvent(&bitbucketcloud.ParticipantStatusEvent{
+				Date:   p.ParticipatedOn,
+				User:   p.User,
+				Action: action,
+			}); err != nil {
+				return
+			}
+		}
+		for _, s := range m.Statuses {
+			if err = addEvent(s); err != nil {
+				return
+			}
+		}
 	}
 	return events, nil
 }
@@ -673,6 +765,9 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return "", nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.HeadSHA, nil
+	case *bitbucketcloud.PullRequest:
+		// The API only returns abbreviated commit hashes.
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -688,6 +783,8 @@ func (c *Changeset) HeadRef() (string, error) {
 		return m.FromRef.ID, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.SourceBranch, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Source.Branch.Name, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -704,6 +801,9 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return "", nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.BaseSHA, nil
+	case *bitbucketcloud.PullRequest:
+		// The API only returns abbreviated commit hashes.
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -719,11 +819,60 @@ func (c *Changeset) BaseRef() (string, error) {
 		return m.ToRef.ID, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.TargetBranch, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Destination.Branch.Name, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
 }
 
+// Mergeability returns whether the changeset can be merged into its base
+// branch, as last reported by the code host. baseRev is the commit the changes
+// of the changeset were computed on: if the code host reports a different
+// commit as the head of the base branch, the changeset is behind.
+//
+// Bitbucket Server doesn't report the head of the base branch and Bitbucket
+// Cloud doesn't report conflicts, so those changesets are never reported as
+// behind and conflicting, respectively.
+func (c *Changeset) Mergeability(baseRev string) ChangesetMergeability {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		if m.Mergeable == "CONFLICTING" {
+			return ChangesetMergeabilityConflicting
+		}
+		if baseRev != "" && m.BaseRefOid != "" && m.BaseRefOid != baseRev {
+			return ChangesetMergeabilityBehind
+		}
+		if m.Mergeable == "MERGEABLE" {
+			return ChangesetMergeabilityMergeable
+		}
+	case *gitlab.MergeRequest:
+		if m.HasConflicts {
+			return ChangesetMergeabilityConflicting
+		}
+		if m.DivergedCommitsCount > 0 {
+			return ChangesetMergeabilityBehind
+		}
+		return ChangesetMergeabilityMergeable
+	case *bitbucketserver.PullRequest:
+		if m.Properties == nil || m.Properties.MergeResult == nil {
+			break
+		}
+		switch m.Properties.MergeResult.Outcome {
+		case "CONFLICTED":
+			return ChangesetMergeabilityConflicting
+		case "CLEAN":
+			return ChangesetMergeabilityMergeable
+		}
+	case *bitbucketcloud.PullRequest:
+		// The API only returns abbreviated commit hashes.
+		if hash := m.Destination.Commit.Hash; baseRev != "" && hash != "" && !strings.HasPrefix(baseRev, hash) {
+			return ChangesetMergeabilityBehind
+		}
+	}
+	return ChangesetMergeabilityUnknown
+}
+
 // AttachedTo returns true if the changeset is currently attached to the batch
 // change with the given batchChangeID.
 func (c *Changeset) AttachedTo(batchChangeID int64) bool {
@@ -996,6 +1145,12 @@ func ChangesetEventKindFor(e interface{}) (ChangesetEventKind, error) {
 		return ChangesetEventKindGitLabReopened, nil
 	case *gitlab.MergeRequestMergedEvent:
 		return ChangesetEventKindGitLabMerged, nil
+	case *bitbucketcloud.ParticipantStatusEvent:
+		return ChangesetEventKind("bitbucketcloud:participant_status:" + string(e.Action)), nil
+	case *bitbucketcloud.PullRequestStateEvent:
+		return ChangesetEventKind("bitbucketcloud:pullrequest_state:" + strings.ToLower(string(e.State))), nil
+	case *bitbucketcloud.CommitStatus:
+		return ChangesetEventKindBitbucketCloudCommitStatus, nil
 	}
 
 	return ChangesetEventKindInvalid, errors.Errorf("unknown changeset event kind for %T", e)
@@ -1014,6 +1169,15 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		default:
 			return new(bitbucketserver.Activity), nil
 		}
+	case strings.HasPrefix(string(k), "bitbucketcloud"):
+		switch {
+		case k == ChangesetEventKindBitbucketCloudCommitStatus:
+			return new(bitbucketcloud.CommitStatus), nil
+		case strings.HasPrefix(string(k), "bitbucketcloud:pullrequest_state"):
+			return new(bitbucketcloud.PullRequestStateEvent), nil
+		default:
+			return new(bitbucketcloud.ParticipantStatusEvent), nil
+		}
 	case strings.HasPrefix(string(k), "github"):
 		switch k {
 		case ChangesetEventKindGitHubAssigned:
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// RolloutChangeset is the subset of a changeset owned by a batch change with a
+// rollout policy that the scheduler needs to decide whether the changeset can
+// be published now.
+type RolloutChangeset struct {
+	ID                 int64
+	BatchChangeID      int64
+	RepoName           string
+	ReconcilerState    ReconcilerState
+	PublicationState   ChangesetPublicationState
+	ExternalCheckState ChangesetCheckState
+	// PublishedAt is when the changeset was created on the code host, or the
+	// zero time if it hasn't been published or the time is unknown.
+	PublishedAt time.Time
+
+	// Rollout is the rollout policy of the current spec of the changeset.
+	Rollout *batches.RolloutPolicy
+}
+
+// PendingPublication returns whether the changeset hasn't been published yet,
+// but is still being processed by the reconciler.
+func (c *RolloutChangeset) PendingPublication() bool {
+	if c.PublicationState.Published() {
+		return false
+	}
+
+	switch c.ReconcilerState {
+	case ReconcilerStateScheduled, ReconcilerStateQueued, ReconcilerStateProcessing, ReconcilerStateErrored:
+		return true
+	default:
+		return false
+	}
+}
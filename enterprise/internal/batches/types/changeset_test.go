@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/go-diff/diff"
 
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -461,6 +462,83 @@ func TestChangeset_BaseRef(t *testing.T) {
 	})
 }
 
+func TestChangeset_Mergeability(t *testing.T) {
+	const baseRev = "deadbeef0123"
+
+	for name, tc := range map[string]struct {
+		meta interface{}
+		want ChangesetMergeability
+	}{
+		"unknown changeset type": {
+			meta: nil,
+			want: ChangesetMergeabilityUnknown,
+		},
+		"GitHub mergeable": {
+			meta: &github.PullRequest{Mergeable: "MERGEABLE", BaseRefOid: baseRev},
+			want: ChangesetMergeabilityMergeable,
+		},
+		"GitHub conflicting": {
+			meta: &github.PullRequest{Mergeable: "CONFLICTING", BaseRefOid: baseRev},
+			want: ChangesetMergeabilityConflicting,
+		},
+		"GitHub behind": {
+			meta: &github.PullRequest{Mergeable: "MERGEABLE", BaseRefOid: "cafe"},
+			want: ChangesetMergeabilityBehind,
+		},
+		"GitHub not yet computed": {
+			meta: &github.PullRequest{Mergeable: "UNKNOWN", BaseRefOid: baseRev},
+			want: ChangesetMergeabilityUnknown,
+		},
+		"GitLab mergeable": {
+			meta: &gitlab.MergeRequest{},
+			want: ChangesetMergeabilityMergeable,
+		},
+		"GitLab conflicting": {
+			meta: &gitlab.MergeRequest{HasConflicts: true, DivergedCommitsCount: 2},
+			want: ChangesetMergeabilityConflicting,
+		},
+		"GitLab behind": {
+			meta: &gitlab.MergeRequest{DivergedCommitsCount: 2},
+			want: ChangesetMergeabilityBehind,
+		},
+		"Bitbucket Server without merge result": {
+			meta: &bitbucketserver.PullRequest{},
+			want: ChangesetMergeabilityUnknown,
+		},
+		"Bitbucket Server clean": {
+			meta: &bitbucketserver.PullRequest{Properties: &bitbucketserver.PullRequestProperties{
+				MergeResult: &bitbucketserver.PullRequestMergeResult{Outcome: "CLEAN"},
+			}},
+			want: ChangesetMergeabilityMergeable,
+		},
+		"Bitbucket Server conflicted": {
+			meta: &bitbucketserver.PullRequest{Properties: &bitbucketserver.PullRequestProperties{
+				MergeResult: &bitbucketserver.PullRequestMergeResult{Outcome: "CONFLICTED"},
+			}},
+			want: ChangesetMergeabilityConflicting,
+		},
+		"Bitbucket Cloud up to date": {
+			meta: &bitbucketcloud.PullRequest{Destination: bitbucketcloud.PullRequestEndpoint{
+				Commit: bitbucketcloud.PullRequestCommit{Hash: "deadbeef"},
+			}},
+			want: ChangesetMergeabilityUnknown,
+		},
+		"Bitbucket Cloud behind": {
+			meta: &bitbucketcloud.PullRequest{Destination: bitbucketcloud.PullRequestEndpoint{
+				Commit: bitbucketcloud.PullRequestCommit{Hash: "cafe"},
+			}},
+			want: ChangesetMergeabilityBehind,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := &Changeset{Metadata: tc.meta}
+			if have := c.Mergeability(baseRev); have != tc.want {
+				t.Errorf("unexpected mergeability: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestChangeset_Labels(t *testing.T) {
 	for name, tc := range map[string]struct {
 		meta interface{}
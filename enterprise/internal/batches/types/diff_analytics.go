@@ -0,0 +1,319 @@
+package types
+
+import (
+	"io"
+	"math"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// RiskScoring configures how the risk score of a changeset spec is computed
+// from its diff. The score is the sum of each weight multiplied by the
+// corresponding count.
+type RiskScoring struct {
+	LinesChangedWeight float64
+	FilesChangedWeight float64
+	BinaryFileWeight   float64
+	DeletedFileWeight  float64
+	LockfileWeight     float64
+
+	// Lockfiles are glob patterns of lockfiles. Patterns without a slash
+	// match the file name in any directory.
+	Lockfiles []string
+
+	// HighRiskThreshold is the risk score from which on a changeset spec is
+	// considered high risk.
+	HighRiskThreshold float64
+}
+
+// DefaultRiskScoring is used for the settings that are not configured in the
+// batchChanges.riskScoring site configuration.
+var DefaultRiskScoring = RiskScoring{
+	LinesChangedWeight: 0.1,
+	FilesChangedWeight: 1,
+	BinaryFileWeight:   10,
+	DeletedFileWeight:  5,
+	LockfileWeight:     5,
+	Lockfiles: []string{
+		"package-lock.json",
+		"yarn.lock",
+		"pnpm-lock.yaml",
+		"go.sum",
+		"Cargo.lock",
+		"Gemfile.lock",
+		"composer.lock",
+		"poetry.lock",
+		"Pipfile.lock",
+	},
+	HighRiskThreshold: 50,
+}
+
+// NewRiskScoring returns the RiskScoring described by the given site
+// configuration, falling back to DefaultRiskScoring for settings that are not
+// configured. An error is returned if a lockfile pattern is invalid.
+func NewRiskScoring(c *schema.BatchChangesRiskScoring) (RiskScoring, error) {
+	s := DefaultRiskScoring
+	if c == nil {
+		return s, nil
+	}
+
+	for _, w := range []struct {
+		from *float64
+		to   *float64
+	}{
+		{c.LinesChangedWeight, &s.LinesChangedWeight},
+		{c.FilesChangedWeight, &s.FilesChangedWeight},
+		{c.BinaryFileWeight, &s.BinaryFileWeight},
+		{c.DeletedFileWeight, &s.DeletedFileWeight},
+		{c.LockfileWeight, &s.LockfileWeight},
+		{c.HighRiskThreshold, &s.HighRiskThreshold},
+	} {
+		if w.from != nil {
+			*w.to = *w.from
+		}
+	}
+
+	if c.Lockfiles != nil {
+		for _, pattern := range c.Lockfiles {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return s, errors.Wrapf(err, "batchChanges.riskScoring: invalid lockfile pattern %q", pattern)
+			}
+		}
+		s.Lockfiles = c.Lockfiles
+	}
+
+	return s, nil
+}
+
+// isLockfile returns whether the file at the given path matches one of the
+// lockfile patterns.
+func (s RiskScoring) isLockfile(name string) bool {
+	for _, pattern := range s.Lockfiles {
+		target := name
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(name)
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangesetSpecDiffAnalytics summarizes the diff of a single changeset spec.
+type ChangesetSpecDiffAnalytics struct {
+	ChangesetSpec *ChangesetSpec
+
+	FilesChanged int32
+	// BinaryFiles, DeletedFiles and Lockfiles are the paths of the changed
+	// files of the respective kind.
+	BinaryFiles  []string
+	DeletedFiles []string
+	Lockfiles    []string
+
+	RiskScore float64
+	HighRisk  bool
+}
+
+// LinesChanged returns the number of added, changed and deleted lines.
+func (a *ChangesetSpecDiffAnalytics) LinesChanged() int32 {
+	return a.ChangesetSpec.DiffStatAdded + a.ChangesetSpec.DiffStatChanged + a.ChangesetSpec.DiffStatDeleted
+}
+
+// Unusual returns whether the diff changes binary files or lockfiles, or
+// deletes files.
+func (a *ChangesetSpecDiffAnalytics) Unusual() bool {
+	return len(a.BinaryFiles) > 0 || len(a.DeletedFiles) > 0 || len(a.Lockfiles) > 0
+}
+
+// AnalyzeChangesetSpecDiff parses the diff of the given changeset spec and
+// computes its risk score. The number of changed lines is taken from the diff
+// stat stored on the changeset spec.
+func AnalyzeChangesetSpecDiff(spec *ChangesetSpec, scoring RiskScoring) (*ChangesetSpecDiffAnalytics, error) {
+	a := &ChangesetSpecDiffAnalytics{ChangesetSpec: spec}
+	if spec.Spec.IsImportingExisting() {
+		return a, nil
+	}
+
+	d, err := spec.Spec.Diff()
+	if err != nil {
+		return nil, err
+	}
+
+	reader := diff.NewMultiFileDiffReader(strings.NewReader(d))
+	for {
+		fileDiff, err := reader.ReadFile()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing diff")
+		}
+
+		a.FilesChanged++
+
+		name := strings.TrimPrefix(fileDiff.NewName, "b/")
+		deleted := fileDiff.NewName == "/dev/null"
+		if deleted {
+			name = strings.TrimPrefix(fileDiff.OrigName, "a/")
+		}
+
+		for _, line := range fileDiff.Extended {
+			if strings.HasPrefix(line, "deleted file mode") {
+				deleted = true
+			}
+			if strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch" {
+				a.BinaryFiles = append(a.BinaryFiles, name)
+			}
+		}
+		if deleted {
+			a.DeletedFiles = append(a.DeletedFiles, name)
+		}
+		if scoring.isLockfile(name) {
+			a.Lockfiles = append(a.Lockfiles, name)
+		}
+	}
+
+	a.RiskScore = scoring.LinesChangedWeight*float64(a.LinesChanged()) +
+		scoring.FilesChangedWeight*float64(a.FilesChanged) +
+		scoring.BinaryFileWeight*float64(len(a.BinaryFiles)) +
+		scoring.DeletedFileWeight*float64(len(a.DeletedFiles)) +
+		scoring.LockfileWeight*float64(len(a.Lockfiles))
+	a.HighRisk = a.RiskScore >= scoring.HighRiskThreshold
+
+	return a, nil
+}
+
+// DiffDistribution describes how a number, such as the number of changed
+// lines, is distributed over the changeset specs of a batch spec.
+type DiffDistribution struct {
+	Total  int32
+	Median int32
+	P90    int32
+	P99    int32
+	Max    int32
+}
+
+// newDiffDistribution computes the distribution of the given values, using
+// the nearest-rank method for percentiles.
+func newDiffDistribution(values []int32) DiffDistribution {
+	var d DiffDistribution
+	if len(values) == 0 {
+		return d
+	}
+
+	sorted := make([]int32, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	for _, v := range sorted {
+		d.Total += v
+	}
+	percentile := func(p float64) int32 {
+		rank := int(math.Ceil(p * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1]
+	}
+	d.Median = percentile(0.5)
+	d.P90 = percentile(0.9)
+	d.P99 = percentile(0.99)
+	d.Max = sorted[len(sorted)-1]
+
+	return d
+}
+
+// BatchSpecDiffAnalytics summarizes the diffs of all changeset specs of a
+// batch spec, so that large batch changes can be reviewed before they are
+// applied.
+type BatchSpecDiffAnalytics struct {
+	// ChangesetSpecs are the analytics of the individual changeset specs,
+	// ordered by descending risk score.
+	ChangesetSpecs []*ChangesetSpecDiffAnalytics
+
+	LinesChanged DiffDistribution
+	FilesChanged DiffDistribution
+
+	AverageRiskScore float64
+	MaxRiskScore     float64
+	HighRiskCount    int32
+}
+
+// AnalyzeBatchSpecDiffs analyzes the diffs of the given changeset specs.
+// Changeset specs that import existing changesets are skipped.
+func AnalyzeBatchSpecDiffs(specs []*ChangesetSpec, scoring RiskScoring) (*BatchSpecDiffAnalytics, error) {
+	a := &BatchSpecDiffAnalytics{}
+
+	var lines, files []int32
+	var totalRiskScore float64
+	for _, spec := range specs {
+		if spec.Spec.IsImportingExisting() {
+			continue
+		}
+
+		sa, err := AnalyzeChangesetSpecDiff(spec, scoring)
+		if err != nil {
+			return nil, errors.Wrapf(err, "analyzing changeset spec %d", spec.ID)
+		}
+		a.ChangesetSpecs = append(a.ChangesetSpecs, sa)
+
+		lines = append(lines, sa.LinesChanged())
+		files = append(files, sa.FilesChanged)
+		totalRiskScore += sa.RiskScore
+		if sa.RiskScore > a.MaxRiskScore {
+			a.MaxRiskScore = sa.RiskScore
+		}
+		if sa.HighRisk {
+			a.HighRiskCount++
+		}
+	}
+
+	a.LinesChanged = newDiffDistribution(lines)
+	a.FilesChanged = newDiffDistribution(files)
+	if len(a.ChangesetSpecs) > 0 {
+		a.AverageRiskScore = totalRiskScore / float64(len(a.ChangesetSpecs))
+	}
+
+	sort.SliceStable(a.ChangesetSpecs, func(i, j int) bool {
+		return a.ChangesetSpecs[i].RiskScore > a.ChangesetSpecs[j].RiskScore
+	})
+
+	return a, nil
+}
+
+// LargestDiffs returns the first n changeset specs with the most changed
+// lines.
+func (a *BatchSpecDiffAnalytics) LargestDiffs(n int) []*ChangesetSpecDiffAnalytics {
+	largest := make([]*ChangesetSpecDiffAnalytics, len(a.ChangesetSpecs))
+	copy(largest, a.ChangesetSpecs)
+	sort.SliceStable(largest, func(i, j int) bool {
+		return largest[i].LinesChanged() > largest[j].LinesChanged()
+	})
+
+	if n >= 0 && n < len(largest) {
+		largest = largest[:n]
+	}
+	return largest
+}
+
+// Unusual returns the first n changeset specs, ordered by descending risk
+// score, whose diffs change binary files or lockfiles, or delete files.
+func (a *BatchSpecDiffAnalytics) Unusual(n int) []*ChangesetSpecDiffAnalytics {
+	var unusual []*ChangesetSpecDiffAnalytics
+	for _, sa := range a.ChangesetSpecs {
+		if n >= 0 && len(unusual) == n {
+			break
+		}
+		if sa.Unusual() {
+			unusual = append(unusual, sa)
+		}
+	}
+	return unusual
+}
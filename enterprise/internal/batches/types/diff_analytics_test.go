@@ -0,0 +1,132 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const analyticsTestDiff = `diff --git a/README.md b/README.md
+index 1111111..2222222 100644
+--- a/README.md
++++ b/README.md
+@@ -1,2 +1,2 @@
+-foo
++bar
+ baz
+diff --git a/web/yarn.lock b/web/yarn.lock
+index 3333333..4444444 100644
+--- a/web/yarn.lock
++++ b/web/yarn.lock
+@@ -1 +1,2 @@
+ foo
++bar
+diff --git a/old.go b/old.go
+deleted file mode 100644
+index 5555555..0000000
+--- a/old.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package old
+diff --git a/logo.png b/logo.png
+index 6666666..7777777 100644
+Binary files a/logo.png and b/logo.png differ
+`
+
+func TestNewRiskScoring(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		s, err := NewRiskScoring(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultRiskScoring, s)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		weight := 2.0
+		s, err := NewRiskScoring(&schema.BatchChangesRiskScoring{
+			BinaryFileWeight: &weight,
+			Lockfiles:        []string{"*.lock"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2.0, s.BinaryFileWeight)
+		assert.Equal(t, DefaultRiskScoring.LinesChangedWeight, s.LinesChangedWeight)
+		assert.Equal(t, []string{"*.lock"}, s.Lockfiles)
+	})
+
+	t.Run("invalid lockfile pattern", func(t *testing.T) {
+		_, err := NewRiskScoring(&schema.BatchChangesRiskScoring{Lockfiles: []string{"[yarn.lock"}})
+		assert.Error(t, err)
+	})
+}
+
+func TestAnalyzeBatchSpecDiffs(t *testing.T) {
+	specs := []*ChangesetSpec{
+		{
+			ID:   1,
+			Spec: &batcheslib.ChangesetSpec{Commits: []batcheslib.GitCommitDescription{{Diff: analyticsTestDiff}}},
+			// 1 changed, 1 added and 1 deleted line.
+			DiffStatAdded:   1,
+			DiffStatChanged: 1,
+			DiffStatDeleted: 1,
+		},
+		{
+			ID: 2,
+			Spec: &batcheslib.ChangesetSpec{Commits: []batcheslib.GitCommitDescription{{Diff: `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1 +1 @@
+-foo
++bar
+`}}},
+			DiffStatChanged: 1,
+		},
+		{
+			ID:   3,
+			Spec: &batcheslib.ChangesetSpec{ExternalID: "123"},
+		},
+	}
+
+	a, err := AnalyzeBatchSpecDiffs(specs, DefaultRiskScoring)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !assert.Len(t, a.ChangesetSpecs, 2) {
+		return
+	}
+	risky := a.ChangesetSpecs[0]
+	assert.Equal(t, int64(1), risky.ChangesetSpec.ID)
+	assert.Equal(t, int32(4), risky.FilesChanged)
+	assert.Equal(t, int32(3), risky.LinesChanged())
+	assert.Equal(t, []string{"logo.png"}, risky.BinaryFiles)
+	assert.Equal(t, []string{"old.go"}, risky.DeletedFiles)
+	assert.Equal(t, []string{"web/yarn.lock"}, risky.Lockfiles)
+	// 3 lines * 0.1 + 4 files * 1 + 10 for the binary, 5 for the deleted file
+	// and 5 for the lockfile.
+	assert.InDelta(t, 24.3, risky.RiskScore, 0.001)
+	assert.False(t, risky.HighRisk)
+
+	assert.InDelta(t, 1.1, a.ChangesetSpecs[1].RiskScore, 0.001)
+	assert.InDelta(t, 24.3, a.MaxRiskScore, 0.001)
+	assert.InDelta(t, 12.7, a.AverageRiskScore, 0.001)
+	assert.Equal(t, int32(0), a.HighRiskCount)
+
+	assert.Equal(t, DiffDistribution{Total: 4, Median: 1, P90: 3, P99: 3, Max: 3}, a.LinesChanged)
+	assert.Equal(t, DiffDistribution{Total: 5, Median: 1, P90: 4, P99: 4, Max: 4}, a.FilesChanged)
+
+	assert.Len(t, a.LargestDiffs(1), 1)
+	assert.Equal(t, int64(1), a.LargestDiffs(1)[0].ChangesetSpec.ID)
+	assert.Len(t, a.Unusual(10), 1)
+
+	scoring := DefaultRiskScoring
+	scoring.HighRiskThreshold = 20
+	a, err = AnalyzeBatchSpecDiffs(specs, scoring)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int32(1), a.HighRiskCount)
+	assert.True(t, a.ChangesetSpecs[0].HighRisk)
+}
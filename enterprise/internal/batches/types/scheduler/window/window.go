@@ -60,6 +60,22 @@ func (w *Window) NextOpenAfter(after time.Time) time.Time {
 	}
 }
 
+// ParseWindow parses a window that isn't rate limited, such as the merge window
+// of an auto-merge policy. Days and times use the same format as rollout
+// windows.
+func ParseWindow(days []string, start, end string) (*Window, error) {
+	w, err := parseWindow(&schema.BatchChangeRolloutWindow{
+		Days:  days,
+		Start: start,
+		End:   end,
+		Rate:  "unlimited",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
 func parseWindowTime(raw string) (*timeOfDay, error) {
 	// An empty time is valid.
 	if raw == "" {
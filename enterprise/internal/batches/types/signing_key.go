@@ -0,0 +1,99 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/commitsigning"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+)
+
+// SigningKey is a key used to sign the commits that Batch Changes creates. A
+// SigningKey without a UserID is the site-wide key, which is used when the
+// user that applied a batch change hasn't configured a key of their own.
+type SigningKey struct {
+	ID     int64
+	UserID int32
+
+	Format         commitsigning.Format
+	PublicKey      string
+	CommitterName  string
+	CommitterEmail string
+
+	EncryptedPrivateKey []byte
+	EncryptionKeyID     string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Key encryption.Key
+}
+
+// IsSiteKey returns whether the key is the site-wide signing key.
+func (sk *SigningKey) IsSiteKey() bool {
+	return sk.UserID == 0
+}
+
+type signingKeySecret struct {
+	PrivateKey string `json:"privateKey"`
+	Passphrase string `json:"passphrase"`
+}
+
+// SetPrivateKey validates the private key, derives the public key from it, and
+// encrypts and sets the private key and its passphrase within the signing key.
+func (sk *SigningKey) SetPrivateKey(ctx context.Context, privateKey, passphrase string) error {
+	signer, err := commitsigning.NewSigner(sk.Format, privateKey, passphrase)
+	if err != nil {
+		return err
+	}
+	publicKey, err := signer.PublicKey()
+	if err != nil {
+		return errors.Wrap(err, "getting public key")
+	}
+
+	id, err := keyID(ctx, sk.Key)
+	if err != nil {
+		return errors.Wrap(err, "getting key version")
+	}
+
+	raw, err := json.Marshal(signingKeySecret{PrivateKey: privateKey, Passphrase: passphrase})
+	if err != nil {
+		return err
+	}
+	if sk.Key != nil {
+		raw, err = sk.Key.Encrypt(ctx, raw)
+		if err != nil {
+			return errors.Wrap(err, "encrypting private key")
+		}
+	}
+
+	sk.PublicKey = publicKey
+	sk.EncryptedPrivateKey = raw
+	sk.EncryptionKeyID = id
+
+	return nil
+}
+
+// PrivateKey decrypts and returns the private key and its passphrase.
+func (sk *SigningKey) PrivateKey(ctx context.Context) (privateKey, passphrase string, err error) {
+	raw := string(sk.EncryptedPrivateKey)
+	if sk.EncryptionKeyID != "" {
+		if sk.Key == nil {
+			return "", "", errors.New("signing key is encrypted, but no key is available to decrypt it")
+		}
+		secret, err := sk.Key.Decrypt(ctx, sk.EncryptedPrivateKey)
+		if err != nil {
+			return "", "", errors.Wrap(err, "decrypting private key")
+		}
+		raw = secret.Secret()
+	}
+
+	var s signingKeySecret
+	if err := json.Unmarshal([]byte(raw), &s); err != nil {
+		return "", "", errors.Wrap(err, "unmarshalling private key")
+	}
+	return s.PrivateKey, s.Passphrase, nil
+}
@@ -58,6 +58,7 @@ func TestIntegration_PermsStore(t *testing.T) {
 		{"ReposIDsWithOldestPerms", testPermsStore_ReposIDsWithOldestPerms(db)},
 		{"UserIsMemberOfOrgHasCodeHostConnection", testPermsStore_UserIsMemberOfOrgHasCodeHostConnection(db)},
 		{"Metrics", testPermsStore_Metrics(db)},
+		{"SyncOutcomes", testPermsStore_SyncOutcomes(db)},
 	} {
 		t.Run(tc.name, tc.test)
 	}
@@ -6648,6 +6648,9 @@ type MockEnterpriseDB struct {
 	// ReposFunc is an instance of a mock function object controlling the
 	// behavior of the method Repos.
 	ReposFunc *EnterpriseDBReposFunc
+	// RolesFunc is an instance of a mock function object controlling the
+	// behavior of the method Roles.
+	RolesFunc *EnterpriseDBRolesFunc
 	// SavedSearchesFunc is an instance of a mock function object
 	// controlling the behavior of the method SavedSearches.
 	SavedSearchesFunc *EnterpriseDBSavedSearchesFunc
@@ -6657,6 +6660,9 @@ type MockEnterpriseDB struct {
 	// SettingsFunc is an instance of a mock function object controlling the
 	// behavior of the method Settings.
 	SettingsFunc *EnterpriseDBSettingsFunc
+	// SubRepoPathRulesFunc is an instance of a mock function object
+	// controlling the behavior of the method SubRepoPathRules.
+	SubRepoPathRulesFunc *EnterpriseDBSubRepoPathRulesFunc
 	// SubRepoPermsFunc is an instance of a mock function object controlling
 	// the behavior of the method SubRepoPerms.
 	SubRepoPermsFunc *EnterpriseDBSubRepoPermsFunc
@@ -6678,6 +6684,9 @@ type MockEnterpriseDB struct {
 	// UserPublicReposFunc is an instance of a mock function object
 	// controlling the behavior of the method UserPublicRepos.
 	UserPublicReposFunc *EnterpriseDBUserPublicReposFunc
+	// UserSessionsFunc is an instance of a mock function object controlling
+	// the behavior of the method UserSessions.
+	UserSessionsFunc *EnterpriseDBUserSessionsFunc
 	// UsersFunc is an instance of a mock function object controlling the
 	// behavior of the method Users.
 	UsersFunc *EnterpriseDBUsersFunc
@@ -6800,6 +6809,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return nil
 			},
 		},
+		RolesFunc: &EnterpriseDBRolesFunc{
+			defaultHook: func() database.RoleStore {
+				return nil
+			},
+		},
 		SavedSearchesFunc: &EnterpriseDBSavedSearchesFunc{
 			defaultHook: func() database.SavedSearchStore {
 				return nil
@@ -6815,6 +6829,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return nil
 			},
 		},
+		SubRepoPathRulesFunc: &EnterpriseDBSubRepoPathRulesFunc{
+			defaultHook: func() database.SubRepoPathRuleStore {
+				return nil
+			},
+		},
 		SubRepoPermsFunc: &EnterpriseDBSubRepoPermsFunc{
 			defaultHook: func() database.SubRepoPermsStore {
 				return nil
@@ -6850,6 +6869,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return nil
 			},
 		},
+		UserSessionsFunc: &EnterpriseDBUserSessionsFunc{
+			defaultHook: func() database.UserSessionStore {
+				return nil
+			},
+		},
 		UsersFunc: &EnterpriseDBUsersFunc{
 			defaultHook: func() database.UserStore {
 				return nil
@@ -6977,6 +7001,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.Repos")
 			},
 		},
+		RolesFunc: &EnterpriseDBRolesFunc{
+			defaultHook: func() database.RoleStore {
+				panic("unexpected invocation of MockEnterpriseDB.Roles")
+			},
+		},
 		SavedSearchesFunc: &EnterpriseDBSavedSearchesFunc{
 			defaultHook: func() database.SavedSearchStore {
 				panic("unexpected invocation of MockEnterpriseDB.SavedSearches")
@@ -6992,6 +7021,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.Settings")
 			},
 		},
+		SubRepoPathRulesFunc: &EnterpriseDBSubRepoPathRulesFunc{
+			defaultHook: func() database.SubRepoPathRuleStore {
+				panic("unexpected invocation of MockEnterpriseDB.SubRepoPathRules")
+			},
+		},
 		SubRepoPermsFunc: &EnterpriseDBSubRepoPermsFunc{
 			defaultHook: func() database.SubRepoPermsStore {
 				panic("unexpected invocation of MockEnterpriseDB.SubRepoPerms")
@@ -7027,6 +7061,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.UserPublicRepos")
 			},
 		},
+		UserSessionsFunc: &EnterpriseDBUserSessionsFunc{
+			defaultHook: func() database.UserSessionStore {
+				panic("unexpected invocation of MockEnterpriseDB.UserSessions")
+			},
+		},
 		UsersFunc: &EnterpriseDBUsersFunc{
 			defaultHook: func() database.UserStore {
 				panic("unexpected invocation of MockEnterpriseDB.Users")
@@ -7111,6 +7150,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		ReposFunc: &EnterpriseDBReposFunc{
 			defaultHook: i.Repos,
 		},
+		RolesFunc: &EnterpriseDBRolesFunc{
+			defaultHook: i.Roles,
+		},
 		SavedSearchesFunc: &EnterpriseDBSavedSearchesFunc{
 			defaultHook: i.SavedSearches,
 		},
@@ -7120,6 +7162,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		SettingsFunc: &EnterpriseDBSettingsFunc{
 			defaultHook: i.Settings,
 		},
+		SubRepoPathRulesFunc: &EnterpriseDBSubRepoPathRulesFunc{
+			defaultHook: i.SubRepoPathRules,
+		},
 		SubRepoPermsFunc: &EnterpriseDBSubRepoPermsFunc{
 			defaultHook: i.SubRepoPerms,
 		},
@@ -7141,6 +7186,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		UserPublicReposFunc: &EnterpriseDBUserPublicReposFunc{
 			defaultHook: i.UserPublicRepos,
 		},
+		UserSessionsFunc: &EnterpriseDBUserSessionsFunc{
+			defaultHook: i.UserSessions,
+		},
 		UsersFunc: &EnterpriseDBUsersFunc{
 			defaultHook: i.Users,
 		},
@@ -9402,6 +9450,105 @@ func (c EnterpriseDBReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBRolesFunc describes the behavior when the Roles method of the
+// parent MockEnterpriseDB instance is invoked.
+type EnterpriseDBRolesFunc struct {
+	defaultHook func() database.RoleStore
+	hooks       []func() database.RoleStore
+	history     []EnterpriseDBRolesFuncCall
+	mutex       sync.Mutex
+}
+
+// Roles delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEnterpriseDB) Roles() database.RoleStore {
+	r0 := m.RolesFunc.nextHook()()
+	m.RolesFunc.appendCall(EnterpriseDBRolesFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Roles method of the
+// parent MockEnterpriseDB instance is invoked and the hook queue is empty.
+func (f *EnterpriseDBRolesFunc) SetDefaultHook(hook func() database.RoleStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Roles method of the parent MockEnterpriseDB instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *EnterpriseDBRolesFunc) PushHook(hook func() database.RoleStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EnterpriseDBRolesFunc) SetDefaultReturn(r0 database.RoleStore) {
+	f.SetDefaultHook(func() database.RoleStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EnterpriseDBRolesFunc) PushReturn(r0 database.RoleStore) {
+	f.PushHook(func() database.RoleStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBRolesFunc) nextHook() func() database.RoleStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBRolesFunc) appendCall(r0 EnterpriseDBRolesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBRolesFuncCall objects
+// describing the invocations of this function.
+func (f *EnterpriseDBRolesFunc) History() []EnterpriseDBRolesFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBRolesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBRolesFuncCall is an object that describes an invocation of
+// method Roles on an instance of MockEnterpriseDB.
+type EnterpriseDBRolesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.RoleStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBRolesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBRolesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBSavedSearchesFunc describes the behavior when the
 // SavedSearches method of the parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBSavedSearchesFunc struct {
@@ -9702,6 +9849,107 @@ func (c EnterpriseDBSettingsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBSubRepoPathRulesFunc describes the behavior when the
+// SubRepoPathRules method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBSubRepoPathRulesFunc struct {
+	defaultHook func() database.SubRepoPathRuleStore
+	hooks       []func() database.SubRepoPathRuleStore
+	history     []EnterpriseDBSubRepoPathRulesFuncCall
+	mutex       sync.Mutex
+}
+
+// SubRepoPathRules delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) SubRepoPathRules() database.SubRepoPathRuleStore {
+	r0 := m.SubRepoPathRulesFunc.nextHook()()
+	m.SubRepoPathRulesFunc.appendCall(EnterpriseDBSubRepoPathRulesFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SubRepoPathRules
+// method of the parent MockEnterpriseDB instance is invoked and the hook
+// queue is empty.
+func (f *EnterpriseDBSubRepoPathRulesFunc) SetDefaultHook(hook func() database.SubRepoPathRuleStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SubRepoPathRules method of the parent MockEnterpriseDB instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *EnterpriseDBSubRepoPathRulesFunc) PushHook(hook func() database.SubRepoPathRuleStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EnterpriseDBSubRepoPathRulesFunc) SetDefaultReturn(r0 database.SubRepoPathRuleStore) {
+	f.SetDefaultHook(func() database.SubRepoPathRuleStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EnterpriseDBSubRepoPathRulesFunc) PushReturn(r0 database.SubRepoPathRuleStore) {
+	f.PushHook(func() database.SubRepoPathRuleStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBSubRepoPathRulesFunc) nextHook() func() database.SubRepoPathRuleStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBSubRepoPathRulesFunc) appendCall(r0 EnterpriseDBSubRepoPathRulesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBSubRepoPathRulesFuncCall
+// objects describing the invocations of this function.
+func (f *EnterpriseDBSubRepoPathRulesFunc) History() []EnterpriseDBSubRepoPathRulesFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBSubRepoPathRulesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBSubRepoPathRulesFuncCall is an object that describes an
+// invocation of method SubRepoPathRules on an instance of MockEnterpriseDB.
+type EnterpriseDBSubRepoPathRulesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.SubRepoPathRuleStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBSubRepoPathRulesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBSubRepoPathRulesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBSubRepoPermsFunc describes the behavior when the SubRepoPerms
 // method of the parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBSubRepoPermsFunc struct {
@@ -10418,6 +10666,106 @@ func (c EnterpriseDBUserPublicReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBUserSessionsFunc describes the behavior when the UserSessions
+// method of the parent MockEnterpriseDB instance is invoked.
+type EnterpriseDBUserSessionsFunc struct {
+	defaultHook func() database.UserSessionStore
+	hooks       []func() database.UserSessionStore
+	history     []EnterpriseDBUserSessionsFuncCall
+	mutex       sync.Mutex
+}
+
+// UserSessions delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) UserSessions() database.UserSessionStore {
+	r0 := m.UserSessionsFunc.nextHook()()
+	m.UserSessionsFunc.appendCall(EnterpriseDBUserSessionsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UserSessions method
+// of the parent MockEnterpriseDB instance is invoked and the hook queue is
+// empty.
+func (f *EnterpriseDBUserSessionsFunc) SetDefaultHook(hook func() database.UserSessionStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UserSessions method of the parent MockEnterpriseDB instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *EnterpriseDBUserSessionsFunc) PushHook(hook func() database.UserSessionStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EnterpriseDBUserSessionsFunc) SetDefaultReturn(r0 database.UserSessionStore) {
+	f.SetDefaultHook(func() database.UserSessionStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EnterpriseDBUserSessionsFunc) PushReturn(r0 database.UserSessionStore) {
+	f.PushHook(func() database.UserSessionStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBUserSessionsFunc) nextHook() func() database.UserSessionStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBUserSessionsFunc) appendCall(r0 EnterpriseDBUserSessionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBUserSessionsFuncCall objects
+// describing the invocations of this function.
+func (f *EnterpriseDBUserSessionsFunc) History() []EnterpriseDBUserSessionsFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBUserSessionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBUserSessionsFuncCall is an object that describes an
+// invocation of method UserSessions on an instance of MockEnterpriseDB.
+type EnterpriseDBUserSessionsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.UserSessionStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBUserSessionsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBUserSessionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBUsersFunc describes the behavior when the Users method of the
 // parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBUsersFunc struct {
@@ -10654,6 +11002,9 @@ type MockPermsStore struct {
 	// LoadRepoPermissionsFunc is an instance of a mock function object
 	// controlling the behavior of the method LoadRepoPermissions.
 	LoadRepoPermissionsFunc *PermsStoreLoadRepoPermissionsFunc
+	// LoadSyncOutcomeFunc is an instance of a mock function object
+	// controlling the behavior of the method LoadSyncOutcome.
+	LoadSyncOutcomeFunc *PermsStoreLoadSyncOutcomeFunc
 	// LoadUserPendingPermissionsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// LoadUserPendingPermissions.
@@ -10664,6 +11015,9 @@ type MockPermsStore struct {
 	// MetricsFunc is an instance of a mock function object controlling the
 	// behavior of the method Metrics.
 	MetricsFunc *PermsStoreMetricsFunc
+	// RecordSyncOutcomeFunc is an instance of a mock function object
+	// controlling the behavior of the method RecordSyncOutcome.
+	RecordSyncOutcomeFunc *PermsStoreRecordSyncOutcomeFunc
 	// RepoIDsWithNoPermsFunc is an instance of a mock function object
 	// controlling the behavior of the method RepoIDsWithNoPerms.
 	RepoIDsWithNoPermsFunc *PermsStoreRepoIDsWithNoPermsFunc
@@ -10753,6 +11107,11 @@ func NewMockPermsStore() *MockPermsStore {
 				return nil
 			},
 		},
+		LoadSyncOutcomeFunc: &PermsStoreLoadSyncOutcomeFunc{
+			defaultHook: func(context.Context, string, int32) (*PermsSyncOutcome, error) {
+				return nil, nil
+			},
+		},
 		LoadUserPendingPermissionsFunc: &PermsStoreLoadUserPendingPermissionsFunc{
 			defaultHook: func(context.Context, *authz.UserPendingPermissions) error {
 				return nil
@@ -10768,6 +11127,11 @@ func NewMockPermsStore() *MockPermsStore {
 				return nil, nil
 			},
 		},
+		RecordSyncOutcomeFunc: &PermsStoreRecordSyncOutcomeFunc{
+			defaultHook: func(context.Context, *PermsSyncOutcome) error {
+				return nil
+			},
+		},
 		RepoIDsWithNoPermsFunc: &PermsStoreRepoIDsWithNoPermsFunc{
 			defaultHook: func(context.Context) ([]api.RepoID, error) {
 				return nil, nil
@@ -10880,6 +11244,11 @@ func NewStrictMockPermsStore() *MockPermsStore {
 				panic("unexpected invocation of MockPermsStore.LoadRepoPermissions")
 			},
 		},
+		LoadSyncOutcomeFunc: &PermsStoreLoadSyncOutcomeFunc{
+			defaultHook: func(context.Context, string, int32) (*PermsSyncOutcome, error) {
+				panic("unexpected invocation of MockPermsStore.LoadSyncOutcome")
+			},
+		},
 		LoadUserPendingPermissionsFunc: &PermsStoreLoadUserPendingPermissionsFunc{
 			defaultHook: func(context.Context, *authz.UserPendingPermissions) error {
 				panic("unexpected invocation of MockPermsStore.LoadUserPendingPermissions")
@@ -10895,6 +11264,11 @@ func NewStrictMockPermsStore() *MockPermsStore {
 				panic("unexpected invocation of MockPermsStore.Metrics")
 			},
 		},
+		RecordSyncOutcomeFunc: &PermsStoreRecordSyncOutcomeFunc{
+			defaultHook: func(context.Context, *PermsSyncOutcome) error {
+				panic("unexpected invocation of MockPermsStore.RecordSyncOutcome")
+			},
+		},
 		RepoIDsWithNoPermsFunc: &PermsStoreRepoIDsWithNoPermsFunc{
 			defaultHook: func(context.Context) ([]api.RepoID, error) {
 				panic("unexpected invocation of MockPermsStore.RepoIDsWithNoPerms")
@@ -10989,6 +11363,9 @@ func NewMockPermsStoreFrom(i PermsStore) *MockPermsStore {
 		LoadRepoPermissionsFunc: &PermsStoreLoadRepoPermissionsFunc{
 			defaultHook: i.LoadRepoPermissions,
 		},
+		LoadSyncOutcomeFunc: &PermsStoreLoadSyncOutcomeFunc{
+			defaultHook: i.LoadSyncOutcome,
+		},
 		LoadUserPendingPermissionsFunc: &PermsStoreLoadUserPendingPermissionsFunc{
 			defaultHook: i.LoadUserPendingPermissions,
 		},
@@ -10998,6 +11375,9 @@ func NewMockPermsStoreFrom(i PermsStore) *MockPermsStore {
 		MetricsFunc: &PermsStoreMetricsFunc{
 			defaultHook: i.Metrics,
 		},
+		RecordSyncOutcomeFunc: &PermsStoreRecordSyncOutcomeFunc{
+			defaultHook: i.RecordSyncOutcome,
+		},
 		RepoIDsWithNoPermsFunc: &PermsStoreRepoIDsWithNoPermsFunc{
 			defaultHook: i.RepoIDsWithNoPerms,
 		},
@@ -12013,6 +12393,118 @@ func (c PermsStoreLoadRepoPermissionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// PermsStoreLoadSyncOutcomeFunc describes the behavior when the
+// LoadSyncOutcome method of the parent MockPermsStore instance is invoked.
+type PermsStoreLoadSyncOutcomeFunc struct {
+	defaultHook func(context.Context, string, int32) (*PermsSyncOutcome, error)
+	hooks       []func(context.Context, string, int32) (*PermsSyncOutcome, error)
+	history     []PermsStoreLoadSyncOutcomeFuncCall
+	mutex       sync.Mutex
+}
+
+// LoadSyncOutcome delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPermsStore) LoadSyncOutcome(v0 context.Context, v1 string, v2 int32) (*PermsSyncOutcome, error) {
+	r0, r1 := m.LoadSyncOutcomeFunc.nextHook()(v0, v1, v2)
+	m.LoadSyncOutcomeFunc.appendCall(PermsStoreLoadSyncOutcomeFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the LoadSyncOutcome
+// method of the parent MockPermsStore instance is invoked and the hook
+// queue is empty.
+func (f *PermsStoreLoadSyncOutcomeFunc) SetDefaultHook(hook func(context.Context, string, int32) (*PermsSyncOutcome, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// LoadSyncOutcome method of the parent MockPermsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *PermsStoreLoadSyncOutcomeFunc) PushHook(hook func(context.Context, string, int32) (*PermsSyncOutcome, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *PermsStoreLoadSyncOutcomeFunc) SetDefaultReturn(r0 *PermsSyncOutcome, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int32) (*PermsSyncOutcome, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *PermsStoreLoadSyncOutcomeFunc) PushReturn(r0 *PermsSyncOutcome, r1 error) {
+	f.PushHook(func(context.Context, string, int32) (*PermsSyncOutcome, error) {
+		return r0, r1
+	})
+}
+
+func (f *PermsStoreLoadSyncOutcomeFunc) nextHook() func(context.Context, string, int32) (*PermsSyncOutcome, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PermsStoreLoadSyncOutcomeFunc) appendCall(r0 PermsStoreLoadSyncOutcomeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of PermsStoreLoadSyncOutcomeFuncCall objects
+// describing the invocations of this function.
+func (f *PermsStoreLoadSyncOutcomeFunc) History() []PermsStoreLoadSyncOutcomeFuncCall {
+	f.mutex.Lock()
+	history := make([]PermsStoreLoadSyncOutcomeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PermsStoreLoadSyncOutcomeFuncCall is an object that describes an
+// invocation of method LoadSyncOutcome on an instance of MockPermsStore.
+type PermsStoreLoadSyncOutcomeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *PermsSyncOutcome
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PermsStoreLoadSyncOutcomeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PermsStoreLoadSyncOutcomeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // PermsStoreLoadUserPendingPermissionsFunc describes the behavior when the
 // LoadUserPendingPermissions method of the parent MockPermsStore instance
 // is invoked.
@@ -12340,6 +12832,113 @@ func (c PermsStoreMetricsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// PermsStoreRecordSyncOutcomeFunc describes the behavior when the
+// RecordSyncOutcome method of the parent MockPermsStore instance is
+// invoked.
+type PermsStoreRecordSyncOutcomeFunc struct {
+	defaultHook func(context.Context, *PermsSyncOutcome) error
+	hooks       []func(context.Context, *PermsSyncOutcome) error
+	history     []PermsStoreRecordSyncOutcomeFuncCall
+	mutex       sync.Mutex
+}
+
+// RecordSyncOutcome delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPermsStore) RecordSyncOutcome(v0 context.Context, v1 *PermsSyncOutcome) error {
+	r0 := m.RecordSyncOutcomeFunc.nextHook()(v0, v1)
+	m.RecordSyncOutcomeFunc.appendCall(PermsStoreRecordSyncOutcomeFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RecordSyncOutcome
+// method of the parent MockPermsStore instance is invoked and the hook
+// queue is empty.
+func (f *PermsStoreRecordSyncOutcomeFunc) SetDefaultHook(hook func(context.Context, *PermsSyncOutcome) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RecordSyncOutcome method of the parent MockPermsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *PermsStoreRecordSyncOutcomeFunc) PushHook(hook func(context.Context, *PermsSyncOutcome) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *PermsStoreRecordSyncOutcomeFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *PermsSyncOutcome) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *PermsStoreRecordSyncOutcomeFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *PermsSyncOutcome) error {
+		return r0
+	})
+}
+
+func (f *PermsStoreRecordSyncOutcomeFunc) nextHook() func(context.Context, *PermsSyncOutcome) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PermsStoreRecordSyncOutcomeFunc) appendCall(r0 PermsStoreRecordSyncOutcomeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of PermsStoreRecordSyncOutcomeFuncCall objects
+// describing the invocations of this function.
+func (f *PermsStoreRecordSyncOutcomeFunc) History() []PermsStoreRecordSyncOutcomeFuncCall {
+	f.mutex.Lock()
+	history := make([]PermsStoreRecordSyncOutcomeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PermsStoreRecordSyncOutcomeFuncCall is an object that describes an
+// invocation of method RecordSyncOutcome on an instance of MockPermsStore.
+type PermsStoreRecordSyncOutcomeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *PermsSyncOutcome
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PermsStoreRecordSyncOutcomeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PermsStoreRecordSyncOutcomeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // PermsStoreRepoIDsWithNoPermsFunc describes the behavior when the
 // RepoIDsWithNoPerms method of the parent MockPermsStore instance is
 // invoked.
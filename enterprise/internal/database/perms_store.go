@@ -54,6 +54,13 @@ type PermsStore interface {
 	UserIDsWithOldestPerms(ctx context.Context, limit int) (map[int32]time.Time, error)
 	ReposIDsWithOldestPerms(ctx context.Context, limit int) (map[api.RepoID]time.Time, error)
 	UserIsMemberOfOrgHasCodeHostConnection(ctx context.Context, userID int32) (has bool, err error)
+	// RecordSyncOutcome stores the outcome of a permissions sync, replacing the
+	// outcome of the previous sync of the same user or repository.
+	RecordSyncOutcome(ctx context.Context, o *PermsSyncOutcome) error
+	// LoadSyncOutcome returns the outcome of the most recent permissions sync of
+	// the user or repository. An ErrPermsNotFound is returned when it was never
+	// synced.
+	LoadSyncOutcome(ctx context.Context, objectType string, objectID int32) (*PermsSyncOutcome, error)
 	Metrics(ctx context.Context, staleDur time.Duration) (*PermsMetrics, error) // PermsStore is the unified interface for managing permissions explicitly in the database.
 }
 
@@ -1613,6 +1620,73 @@ SELECT EXISTS (
 	return has, nil
 }
 
+// The object types of permissions sync outcomes.
+const (
+	PermsSyncObjectUser = "user"
+	PermsSyncObjectRepo = "repo"
+)
+
+// PermsSyncOutcome is the outcome of a user-centric or repository-centric
+// permissions sync.
+type PermsSyncOutcome struct {
+	ObjectType string // PermsSyncObjectUser or PermsSyncObjectRepo
+	ObjectID   int32
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Error      string // Empty if the sync succeeded
+}
+
+func (s *permsStore) RecordSyncOutcome(ctx context.Context, o *PermsSyncOutcome) (err error) {
+	ctx, save := s.observe(ctx, "RecordSyncOutcome", "")
+	defer func() {
+		save(&err, otlog.String("objectType", o.ObjectType), otlog.Int32("objectID", o.ObjectID))
+	}()
+
+	var syncErr *string
+	if o.Error != "" {
+		syncErr = &o.Error
+	}
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/perms_store.go:RecordSyncOutcome
+INSERT INTO perms_sync_outcomes
+	(object_type, object_id, started_at, finished_at, error)
+VALUES
+	(%s, %s, %s, %s, %s)
+ON CONFLICT (object_type, object_id)
+DO UPDATE SET
+	started_at = excluded.started_at,
+	finished_at = excluded.finished_at,
+	error = excluded.error
+`, o.ObjectType, o.ObjectID, o.StartedAt.UTC(), o.FinishedAt.UTC(), syncErr)
+	if err = s.execute(ctx, q); err != nil {
+		return errors.Wrap(err, "execute upsert sync outcome query")
+	}
+	return nil
+}
+
+func (s *permsStore) LoadSyncOutcome(ctx context.Context, objectType string, objectID int32) (_ *PermsSyncOutcome, err error) {
+	ctx, save := s.observe(ctx, "LoadSyncOutcome", "")
+	defer func() {
+		save(&err, otlog.String("objectType", objectType), otlog.Int32("objectID", objectID))
+	}()
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/perms_store.go:LoadSyncOutcome
+SELECT started_at, finished_at, error
+FROM perms_sync_outcomes
+WHERE object_type = %s
+AND object_id = %s
+`, objectType, objectID)
+
+	o := &PermsSyncOutcome{ObjectType: objectType, ObjectID: objectID}
+	var syncErr sql.NullString
+	if err = s.execute(ctx, q, &o.StartedAt, &o.FinishedAt, &syncErr); err != nil {
+		return nil, err
+	}
+	o.Error = syncErr.String
+	return o, nil
+}
+
 // PermsMetrics contains metrics values calculated by querying the database.
 type PermsMetrics struct {
 	// The number of users with stale permissions.
@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/gitchander/permutation"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
@@ -2996,3 +2997,66 @@ func testPermsStore_Metrics(db *sql.DB) func(*testing.T) {
 		}
 	}
 }
+
+func testPermsStore_SyncOutcomes(db *sql.DB) func(*testing.T) {
+	return func(t *testing.T) {
+		s := perms(db, clock)
+		ctx := context.Background()
+		t.Cleanup(func() {
+			if err := s.execute(ctx, sqlf.Sprintf(`TRUNCATE TABLE perms_sync_outcomes`)); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		if _, err := s.LoadSyncOutcome(ctx, PermsSyncObjectUser, 1); err != authz.ErrPermsNotFound {
+			t.Fatalf("err: want %q but got %v", authz.ErrPermsNotFound, err)
+		}
+
+		failed := &PermsSyncOutcome{
+			ObjectType: PermsSyncObjectUser,
+			ObjectID:   1,
+			StartedAt:  clock().Add(-time.Minute),
+			FinishedAt: clock().Add(-time.Minute),
+			Error:      "rate limit exceeded",
+		}
+		if err := s.RecordSyncOutcome(ctx, failed); err != nil {
+			t.Fatal(err)
+		}
+		// The outcome of a repository with the same ID is kept apart.
+		if err := s.RecordSyncOutcome(ctx, &PermsSyncOutcome{
+			ObjectType: PermsSyncObjectRepo,
+			ObjectID:   1,
+			StartedAt:  clock(),
+			FinishedAt: clock(),
+			Error:      "not found",
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := s.LoadSyncOutcome(ctx, PermsSyncObjectUser, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(failed, got, cmpopts.EquateApproxTime(0)); diff != "" {
+			t.Fatalf("mismatch (-want +got):\n%s", diff)
+		}
+
+		// A later outcome replaces the previous one.
+		succeeded := &PermsSyncOutcome{
+			ObjectType: PermsSyncObjectUser,
+			ObjectID:   1,
+			StartedAt:  clock(),
+			FinishedAt: clock(),
+		}
+		if err := s.RecordSyncOutcome(ctx, succeeded); err != nil {
+			t.Fatal(err)
+		}
+		got, err = s.LoadSyncOutcome(ctx, PermsSyncObjectUser, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(succeeded, got, cmpopts.EquateApproxTime(0)); diff != "" {
+			t.Fatalf("mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
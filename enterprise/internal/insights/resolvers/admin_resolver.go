@@ -11,7 +11,6 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
-	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 )
 
@@ -20,8 +19,7 @@ var _ graphqlbackend.InsightSeriesMetadataResolver = &insightSeriesMetadataResol
 var _ graphqlbackend.InsightSeriesQueryStatusResolver = &insightSeriesQueryStatusResolver{}
 
 func (r *Resolver) UpdateInsightSeries(ctx context.Context, args *graphqlbackend.UpdateInsightSeriesArgs) (graphqlbackend.InsightSeriesMetadataPayloadResolver, error) {
-	actr := actor.FromContext(ctx)
-	if err := backend.CheckUserIsSiteAdmin(ctx, database.NewDB(r.postgresDB), actr.UID); err != nil {
+	if err := backend.CheckCurrentUserHasPermission(ctx, database.NewDB(r.postgresDB), database.PermissionInsightsEdit); err != nil {
 		return nil, err
 	}
 
@@ -43,8 +41,7 @@ func (r *Resolver) UpdateInsightSeries(ctx context.Context, args *graphqlbackend
 }
 
 func (r *Resolver) InsightSeriesQueryStatus(ctx context.Context) ([]graphqlbackend.InsightSeriesQueryStatusResolver, error) {
-	actr := actor.FromContext(ctx)
-	if err := backend.CheckUserIsSiteAdmin(ctx, database.NewDB(r.postgresDB), actr.UID); err != nil {
+	if err := backend.CheckCurrentUserHasPermission(ctx, database.NewDB(r.postgresDB), database.PermissionInsightsEdit); err != nil {
 		return nil, err
 	}
 
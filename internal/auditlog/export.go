@@ -0,0 +1,119 @@
+// Package auditlog streams security audit events to the destinations
+// configured in the `auditLog.export` site configuration.
+package auditlog
+
+import (
+	"log/syslog"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// destination is a place that events are exported to.
+type destination interface {
+	write(line []byte) error
+	Close() error
+}
+
+var (
+	mu sync.Mutex
+	// exportConfig is the configuration the destinations were opened for.
+	exportConfig *schema.AuditLogExport
+	destinations []destination
+)
+
+// Export writes the JSON encoded event as a single line to all configured
+// destinations. Errors are logged, and failing destinations are reopened for
+// the next event.
+func Export(event []byte) {
+	var cfg *schema.AuditLogExport
+	if auditLog := conf.Get().AuditLog; auditLog != nil {
+		cfg = auditLog.Export
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !reflect.DeepEqual(cfg, exportConfig) {
+		closeDestinations()
+		exportConfig = cfg
+	}
+	if cfg == nil {
+		return
+	}
+	if destinations == nil {
+		var err error
+		if destinations, err = openDestinations(cfg); err != nil {
+			log15.Error("auditlog: failed to open export destinations", "error", err)
+			closeDestinations()
+			return
+		}
+	}
+
+	for _, d := range destinations {
+		if err := d.write(event); err != nil {
+			log15.Error("auditlog: failed to export event", "error", err)
+			closeDestinations()
+			return
+		}
+	}
+}
+
+func openDestinations(cfg *schema.AuditLogExport) ([]destination, error) {
+	var ds []destination
+	if cfg.File != "" {
+		f, err := os.OpenFile(cfg.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return ds, errors.Wrap(err, "opening audit log file")
+		}
+		ds = append(ds, &fileDestination{f})
+	}
+	if cfg.Syslog != nil {
+		network, tag := cfg.Syslog.Network, cfg.Syslog.Tag
+		if network == "" {
+			network = "udp"
+		}
+		if tag == "" {
+			tag = "sourcegraph-audit"
+		}
+		w, err := syslog.Dial(network, cfg.Syslog.Address, syslog.LOG_INFO|syslog.LOG_AUTH, tag)
+		if err != nil {
+			return ds, errors.Wrap(err, "connecting to syslog")
+		}
+		ds = append(ds, &syslogDestination{w})
+	}
+	return ds, nil
+}
+
+// closeDestinations closes all destinations. The caller must hold mu.
+func closeDestinations() {
+	for _, d := range destinations {
+		if err := d.Close(); err != nil {
+			log15.Warn("auditlog: failed to close export destination", "error", err)
+		}
+	}
+	destinations = nil
+}
+
+type fileDestination struct {
+	*os.File
+}
+
+func (d *fileDestination) write(line []byte) error {
+	_, err := d.Write(append(line, '\n'))
+	return err
+}
+
+type syslogDestination struct {
+	*syslog.Writer
+}
+
+func (d *syslogDestination) write(line []byte) error {
+	return d.Info(string(line))
+}
@@ -0,0 +1,40 @@
+package auditlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestExport_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		AuditLog: &schema.AuditLog{Enabled: true, Export: &schema.AuditLogExport{File: path}},
+	}})
+	t.Cleanup(func() {
+		conf.Mock(nil)
+		Export([]byte(`{}`)) // closes the file
+	})
+
+	Export([]byte(`{"name":"first"}`))
+	Export([]byte(`{"name":"second"}`))
+
+	have, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\"name\":\"first\"}\n{\"name\":\"second\"}\n"; string(have) != want {
+		t.Fatalf("unexpected file contents: have %q, want %q", have, want)
+	}
+}
+
+func TestExport_Disabled(t *testing.T) {
+	conf.Mock(&conf.Unified{})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	// Must not fail without any configured destination.
+	Export([]byte(`{"name":"event"}`))
+}
@@ -5,6 +5,7 @@ package authz
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -68,6 +69,9 @@ type FetchPermsOptions struct {
 	// InvalidateCaches indicates that caches added for optimization encountered during
 	// this fetch should be invalidated.
 	InvalidateCaches bool `json:"invalidate_caches"`
+	// TriggeredAt is the time when the code host event that triggered this fetch was
+	// received. It is zero for fetches that weren't triggered by a code host event.
+	TriggeredAt time.Time `json:"triggered_at"`
 }
 
 // Provider defines a source of truth of which repositories a user is authorized to view. The
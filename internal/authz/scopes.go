@@ -3,11 +3,26 @@ package authz
 const (
 	// Access token scopes.
 	ScopeUserAll       = "user:all"        // Full control of all resources accessible to the user account.
+	ScopeUserRead      = "user:read"       // Read-only access to the GraphQL API and a few read-only endpoints.
+	ScopeSearch        = "search"          // Ability to run searches as the user.
+	ScopeBatchChanges  = "batch-changes"   // Full control of the batch changes accessible to the user account.
 	ScopeSiteAdminSudo = "site-admin:sudo" // Ability to perform any action as any other user.
 )
 
 // AllScopes is a list of all known access token scopes.
 var AllScopes = []string{
 	ScopeUserAll,
+	ScopeUserRead,
+	ScopeSearch,
+	ScopeBatchChanges,
 	ScopeSiteAdminSudo,
 }
+
+// UserScopes is a list of the access token scopes that grant access to the
+// subject user's account. Every access token must have at least one of them.
+var UserScopes = []string{
+	ScopeUserAll,
+	ScopeUserRead,
+	ScopeSearch,
+	ScopeBatchChanges,
+}
@@ -0,0 +1,218 @@
+// Package commitsigning signs git commits with OpenPGP or SSH keys, in the
+// formats that git and the code hosts verify.
+package commitsigning
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/cockroachdb/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+// Format is the format of a signing key and the signatures it creates.
+type Format string
+
+const (
+	FormatOpenPGP Format = "openpgp"
+	FormatSSH     Format = "ssh"
+)
+
+// Valid returns whether the format is one of the supported formats.
+func (f Format) Valid() bool {
+	return f == FormatOpenPGP || f == FormatSSH
+}
+
+// Signer signs commits.
+type Signer interface {
+	// Sign returns the armored signature of the given commit payload, which is
+	// the raw commit object without a signature.
+	Sign(payload []byte) (string, error)
+	// PublicKey returns the public key in the format in which it is added to
+	// a code host account, so that the code host can verify the signatures.
+	PublicKey() (string, error)
+}
+
+// NewSigner parses the given private key, decrypting it with the passphrase
+// if it is encrypted, and returns a Signer for it.
+func NewSigner(format Format, privateKey, passphrase string) (Signer, error) {
+	switch format {
+	case FormatOpenPGP:
+		return newOpenPGPSigner(privateKey, passphrase)
+	case FormatSSH:
+		return newSSHSigner(privateKey, passphrase)
+	default:
+		return nil, errors.Errorf("unsupported signing key format %q", format)
+	}
+}
+
+type openPGPSigner struct {
+	entity *openpgp.Entity
+}
+
+func newOpenPGPSigner(privateKey, passphrase string) (*openPGPSigner, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(privateKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing OpenPGP private key")
+	}
+
+	for _, entity := range entities {
+		if entity.PrivateKey == nil {
+			continue
+		}
+
+		if err := entity.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+			return nil, errors.Wrap(err, "decrypting OpenPGP private key")
+		}
+		for _, subkey := range entity.Subkeys {
+			if subkey.PrivateKey == nil {
+				continue
+			}
+			if err := subkey.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+				return nil, errors.Wrap(err, "decrypting OpenPGP private subkey")
+			}
+		}
+
+		return &openPGPSigner{entity: entity}, nil
+	}
+
+	return nil, errors.New("OpenPGP key doesn't contain a private key")
+}
+
+func (s *openPGPSigner) Sign(payload []byte) (string, error) {
+	var buf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&buf, s.entity, bytes.NewReader(payload), nil); err != nil {
+		return "", errors.Wrap(err, "creating OpenPGP signature")
+	}
+	return buf.String() + "\n", nil
+}
+
+func (s *openPGPSigner) PublicKey() (string, error) {
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := s.entity.Serialize(w); err != nil {
+		return "", errors.Wrap(err, "serializing OpenPGP public key")
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return buf.String() + "\n", nil
+}
+
+// The constants of the SSH signature format git uses, which is described in
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig.
+const (
+	sshSignatureMagic     = "SSHSIG"
+	sshSignatureVersion   = 1
+	sshSignatureNamespace = "git"
+	sshSignatureHash      = "sha512"
+)
+
+type sshSigner struct {
+	signer ssh.Signer
+}
+
+func newSSHSigner(privateKey, passphrase string) (*sshSigner, error) {
+	var (
+		signer ssh.Signer
+		err    error
+	)
+	if passphrase == "" {
+		signer, err = ssh.ParsePrivateKey([]byte(privateKey))
+	} else {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing SSH private key")
+	}
+	return &sshSigner{signer: signer}, nil
+}
+
+func (s *sshSigner) Sign(payload []byte) (string, error) {
+	hash := sha512.Sum512(payload)
+
+	signedData := []byte(sshSignatureMagic)
+	signedData = appendSSHString(signedData, []byte(sshSignatureNamespace))
+	signedData = appendSSHString(signedData, nil) // reserved
+	signedData = appendSSHString(signedData, []byte(sshSignatureHash))
+	signedData = appendSSHString(signedData, hash[:])
+
+	var (
+		sig *ssh.Signature
+		err error
+	)
+	// RSA keys have to use SHA-512 instead of the default SHA-1, which git
+	// doesn't accept.
+	if as, ok := s.signer.(ssh.AlgorithmSigner); ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		sig, err = as.SignWithAlgorithm(rand.Reader, signedData, ssh.SigAlgoRSASHA2512)
+	} else {
+		sig, err = s.signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "creating SSH signature")
+	}
+
+	blob := []byte(sshSignatureMagic)
+	blob = appendUint32(blob, sshSignatureVersion)
+	blob = appendSSHString(blob, s.signer.PublicKey().Marshal())
+	blob = appendSSHString(blob, []byte(sshSignatureNamespace))
+	blob = appendSSHString(blob, nil) // reserved
+	blob = appendSSHString(blob, []byte(sshSignatureHash))
+	blob = appendSSHString(blob, ssh.Marshal(sig))
+
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	var b strings.Builder
+	b.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(encoded) > 70 {
+		b.WriteString(encoded[:70])
+		b.WriteString("\n")
+		encoded = encoded[70:]
+	}
+	b.WriteString(encoded)
+	b.WriteString("\n-----END SSH SIGNATURE-----\n")
+	return b.String(), nil
+}
+
+func (s *sshSigner) PublicKey() (string, error) {
+	return string(ssh.MarshalAuthorizedKey(s.signer.PublicKey())), nil
+}
+
+// appendSSHString appends s in the SSH wire format for strings: its length as
+// a 32 bit integer, followed by its bytes.
+func appendSSHString(b, s []byte) []byte {
+	b = appendUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// AddSignature returns the raw commit object with the given signature added
+// as the gpgsig header, which git uses for both OpenPGP and SSH signatures.
+// The signature must have been created over the given commit.
+func AddSignature(commit []byte, signature string) ([]byte, error) {
+	end := bytes.Index(commit, []byte("\n\n"))
+	if end < 0 {
+		return nil, errors.New("invalid commit object: no end of headers")
+	}
+
+	header := "gpgsig " + strings.ReplaceAll(strings.TrimSuffix(signature, "\n"), "\n", "\n ") + "\n"
+
+	signed := make([]byte, 0, len(commit)+len(header))
+	signed = append(signed, commit[:end+1]...)
+	signed = append(signed, header...)
+	signed = append(signed, commit[end+1:]...)
+	return signed, nil
+}
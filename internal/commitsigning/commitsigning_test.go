@@ -0,0 +1,181 @@
+package commitsigning
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+)
+
+const testCommit = `tree 2561a62d4223eb7660d3b6b02b707048382f4019
+author A <a@example.com> 1640995200 +0000
+committer A <a@example.com> 1640995200 +0000
+
+Commit message
+
+With a body
+`
+
+func TestOpenPGPSigner(t *testing.T) {
+	entity, err := openpgp.NewEntity("A", "", "a@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.PrivateKey.Encrypt([]byte("hunter2")); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.SerializePrivateWithoutSigning(w, nil); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	privateKey := buf.String()
+
+	if _, err := NewSigner(FormatOpenPGP, privateKey, "wrong"); err == nil {
+		t.Fatal("no error for wrong passphrase")
+	}
+
+	s, err := NewSigner(FormatOpenPGP, privateKey, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := s.Sign([]byte(testCommit))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := s.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openpgp.CheckArmoredDetachedSignature(keyring, strings.NewReader(testCommit), strings.NewReader(sig), nil); err != nil {
+		t.Fatalf("signature doesn't verify: %s", err)
+	}
+}
+
+func TestSSHSigner(t *testing.T) {
+	keypair, err := encryption.GenerateRSAKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewSigner(FormatSSH, keypair.PrivateKey, "wrong"); err == nil {
+		t.Fatal("no error for wrong passphrase")
+	}
+
+	s, err := NewSigner(FormatSSH, keypair.PrivateKey, keypair.Passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if publicKey, err := s.PublicKey(); err != nil {
+		t.Fatal(err)
+	} else if publicKey != keypair.PublicKey {
+		t.Fatalf("wrong public key. want=%q, have=%q", keypair.PublicKey, publicKey)
+	}
+
+	sig, err := s.Sign([]byte(testCommit))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sig, "-----BEGIN SSH SIGNATURE-----\n") || !strings.HasSuffix(sig, "\n-----END SSH SIGNATURE-----\n") {
+		t.Fatalf("signature isn't armored: %q", sig)
+	}
+
+	encoded := strings.TrimPrefix(strings.TrimSuffix(sig, "\n-----END SSH SIGNATURE-----\n"), "-----BEGIN SSH SIGNATURE-----\n")
+	blob, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encoded, "\n", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Read the fields of the signature blob back.
+	if !bytes.HasPrefix(blob, []byte(sshSignatureMagic)) {
+		t.Fatal("missing magic preamble")
+	}
+	blob = blob[len(sshSignatureMagic):]
+	if v := binary.BigEndian.Uint32(blob); v != sshSignatureVersion {
+		t.Fatalf("wrong version %d", v)
+	}
+	blob = blob[4:]
+	var fields [][]byte
+	for len(blob) > 0 {
+		n := binary.BigEndian.Uint32(blob)
+		fields = append(fields, blob[4:4+n])
+		blob = blob[4+n:]
+	}
+	if len(fields) != 5 {
+		t.Fatalf("wrong number of fields: %d", len(fields))
+	}
+
+	publicKey, err := ssh.ParsePublicKey(fields[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(fields[1]) != "git" || string(fields[3]) != "sha512" {
+		t.Fatalf("wrong namespace or hash algorithm: %q, %q", fields[1], fields[3])
+	}
+	var signature ssh.Signature
+	if err := ssh.Unmarshal(fields[4], &signature); err != nil {
+		t.Fatal(err)
+	}
+	if signature.Format != ssh.SigAlgoRSASHA2512 {
+		t.Fatalf("wrong signature algorithm %q", signature.Format)
+	}
+
+	hash := sha512.Sum512([]byte(testCommit))
+	signedData := []byte(sshSignatureMagic)
+	signedData = appendSSHString(signedData, []byte("git"))
+	signedData = appendSSHString(signedData, nil)
+	signedData = appendSSHString(signedData, []byte("sha512"))
+	signedData = appendSSHString(signedData, hash[:])
+	if err := publicKey.Verify(signedData, &signature); err != nil {
+		t.Fatalf("signature doesn't verify: %s", err)
+	}
+}
+
+func TestNewSigner_UnsupportedFormat(t *testing.T) {
+	if _, err := NewSigner("x509", "", ""); err == nil {
+		t.Fatal("no error returned")
+	}
+}
+
+func TestAddSignature(t *testing.T) {
+	signed, err := AddSignature([]byte(testCommit), "-----BEGIN SSH SIGNATURE-----\nabc\n-----END SSH SIGNATURE-----\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `tree 2561a62d4223eb7660d3b6b02b707048382f4019
+author A <a@example.com> 1640995200 +0000
+committer A <a@example.com> 1640995200 +0000
+gpgsig -----BEGIN SSH SIGNATURE-----
+ abc
+ -----END SSH SIGNATURE-----
+
+Commit message
+
+With a body
+`
+	if string(signed) != want {
+		t.Fatalf("wrong commit. want=%q, have=%q", want, signed)
+	}
+
+	if _, err := AddSignature([]byte("tree abc\n"), "sig"); err == nil {
+		t.Fatal("no error for commit without message")
+	}
+}
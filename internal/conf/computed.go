@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
 	"github.com/sourcegraph/sourcegraph/internal/conf/confdefaults"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -159,6 +160,16 @@ func BatchChangesRestrictedToAdmins() bool {
 	return false
 }
 
+// AuditLogEnabled reports whether security events are recorded in the audit
+// log. The audit log is always enabled on Sourcegraph.com.
+func AuditLogEnabled() bool {
+	if envvar.SourcegraphDotComMode() {
+		return true
+	}
+	cfg := Get().AuditLog
+	return cfg != nil && cfg.Enabled
+}
+
 func ExecutorsEnabled() bool {
 	return Get().ExecutorsAccessToken != ""
 }
@@ -225,6 +225,9 @@ func UnredactSecrets(input string, raw conftypes.RawUnified) (string, error) {
 		if ap.Gitlab != nil && ap.Gitlab.ClientSecret == RedactedSecret {
 			ap.Gitlab.ClientSecret = oldSecrets[ap.Gitlab.ClientID]
 		}
+		if ap.Ldap != nil && ap.Ldap.BindPassword == RedactedSecret {
+			ap.Ldap.BindPassword = oldSecrets[ldapSecretsKey(ap.Ldap)]
+		}
 	}
 	unredactedSite, err := jsonc.Edit(input, newCfg.AuthProviders, "auth.providers")
 	if err != nil {
@@ -232,6 +235,12 @@ func UnredactSecrets(input string, raw conftypes.RawUnified) (string, error) {
 	}
 	if newCfg.ExecutorsAccessToken == RedactedSecret {
 		unredactedSite, err = jsonc.Edit(unredactedSite, oldCfg.ExecutorsAccessToken, "executors.accessToken")
+		if err != nil {
+			return input, err
+		}
+	}
+	if newCfg.ScimAccessToken == RedactedSecret {
+		unredactedSite, err = jsonc.Edit(unredactedSite, oldCfg.ScimAccessToken, "scim.accessToken")
 	}
 	return unredactedSite, err
 }
@@ -248,10 +257,24 @@ func getSecretsMap(cfg *Unified) map[string]string {
 		if ap.Gitlab != nil {
 			secretsMap[ap.Gitlab.ClientID] = ap.Gitlab.ClientSecret
 		}
+		if ap.Ldap != nil {
+			secretsMap[ldapSecretsKey(ap.Ldap)] = ap.Ldap.BindPassword
+		}
 	}
 	return secretsMap
 }
 
+// ldapSecretsKey returns the key of the bind password of an LDAP auth provider
+// in the map returned by getSecretsMap. It only depends on the provider's
+// configID (if set) and the bind DN the password belongs to, so that editing
+// other fields like the server URL while the password is redacted keeps it.
+func ldapSecretsKey(p *schema.LDAPAuthProvider) string {
+	if p.ConfigID != "" {
+		return "ldap:configID:" + p.ConfigID
+	}
+	return "ldap:bindDN:" + p.BindDN
+}
+
 func RedactSecrets(raw conftypes.RawUnified) (conftypes.RawUnified, error) {
 	cfg, err := ParseConfig(raw)
 	if err != nil {
@@ -267,6 +290,9 @@ func RedactSecrets(raw conftypes.RawUnified) (conftypes.RawUnified, error) {
 		if ap.Gitlab != nil {
 			ap.Gitlab.ClientSecret = RedactedSecret
 		}
+		if ap.Ldap != nil && ap.Ldap.BindPassword != "" {
+			ap.Ldap.BindPassword = RedactedSecret
+		}
 	}
 	newSite, err := jsonc.Edit(raw.Site, cfg.AuthProviders, "auth.providers")
 	if err != nil {
@@ -274,6 +300,12 @@ func RedactSecrets(raw conftypes.RawUnified) (conftypes.RawUnified, error) {
 	}
 	if cfg.ExecutorsAccessToken != "" {
 		newSite, err = jsonc.Edit(newSite, RedactedSecret, "executors.accessToken")
+		if err != nil {
+			return raw, err
+		}
+	}
+	if cfg.ScimAccessToken != "" {
+		newSite, err = jsonc.Edit(newSite, RedactedSecret, "scim.accessToken")
 	}
 	return conftypes.RawUnified{
 		Site: newSite,
@@ -70,6 +70,15 @@ func validateCustom(cfg Unified) (problems Problems) {
 		}
 	}
 
+	for _, policy := range cfg.GitUpdatePolicies {
+		if policy.Pattern == "" {
+			continue
+		}
+		if _, err := regexp.Compile(policy.Pattern); err != nil {
+			invalid(NewSiteProblem(fmt.Sprintf("GitUpdatePolicy pattern is not valid regex: %q", policy.Pattern)))
+		}
+	}
+
 	for _, f := range contributedValidators {
 		problems = append(problems, f(cfg)...)
 	}
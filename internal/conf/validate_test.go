@@ -194,6 +194,72 @@ func TestUnredact(t *testing.T) {
 	})
 }
 
+func TestRedactUnredactSCIMAccessToken(t *testing.T) {
+	const scimAccessToken = "scim-access-token-0123456789"
+	site := fmt.Sprintf(`{"auth.providers": [], "scim.accessToken": %q}`, scimAccessToken)
+
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: site})
+	if err != nil {
+		t.Fatalf("unexpected error redacting secrets: %s", err)
+	}
+	if strings.Contains(redacted.Site, scimAccessToken) {
+		t.Errorf("expected redacted site to not contain the SCIM access token")
+	}
+
+	unredactedSite, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: site})
+	if err != nil {
+		t.Fatalf("unexpected error unredacting secrets: %s", err)
+	}
+	if !strings.Contains(unredactedSite, scimAccessToken) {
+		t.Errorf("expected unredacted site to contain the SCIM access token, got %s", unredactedSite)
+	}
+}
+
+func TestRedactUnredactLDAPBindPassword(t *testing.T) {
+	const bindPassword = "ldap-bind-password"
+	site := fmt.Sprintf(`{"auth.providers": [{"type": "ldap", "url": "ldaps://ldap.example.com", "userSearchBase": "dc=example,dc=com", "bindDN": "cn=sourcegraph,dc=example,dc=com", "bindPassword": %q}]}`, bindPassword)
+
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: site})
+	if err != nil {
+		t.Fatalf("unexpected error redacting secrets: %s", err)
+	}
+	if strings.Contains(redacted.Site, bindPassword) {
+		t.Errorf("expected redacted site to not contain the LDAP bind password")
+	}
+
+	unredactedSite, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: site})
+	if err != nil {
+		t.Fatalf("unexpected error unredacting secrets: %s", err)
+	}
+	if !strings.Contains(unredactedSite, bindPassword) {
+		t.Errorf("expected unredacted site to contain the LDAP bind password, got %s", unredactedSite)
+	}
+}
+
+func TestUnredactLDAPBindPasswordAfterEdit(t *testing.T) {
+	const bindPassword = "ldap-bind-password"
+	ldapSite := func(configID, url, password string) string {
+		return fmt.Sprintf(`{"auth.providers": [{"type": "ldap", "configID": %q, "url": %q, "userSearchBase": "dc=example,dc=com", "bindDN": "cn=sourcegraph,dc=example,dc=com", "bindPassword": %q}]}`, configID, url, password)
+	}
+
+	for _, configID := range []string{"", "corp-ldap"} {
+		old := ldapSite(configID, "ldaps://ldap.example.com", bindPassword)
+		// The admin changes the URL while the password is redacted.
+		edited := ldapSite(configID, "ldaps://ldap2.example.com", RedactedSecret)
+
+		unredactedSite, err := UnredactSecrets(edited, conftypes.RawUnified{Site: old})
+		if err != nil {
+			t.Fatalf("configID=%q: unexpected error unredacting secrets: %s", configID, err)
+		}
+		if !strings.Contains(unredactedSite, bindPassword) {
+			t.Errorf("configID=%q: expected unredacted site to contain the LDAP bind password, got %s", configID, unredactedSite)
+		}
+		if !strings.Contains(unredactedSite, "ldaps://ldap2.example.com") {
+			t.Errorf("configID=%q: expected unredacted site to keep the new URL, got %s", configID, unredactedSite)
+		}
+	}
+}
+
 func getTestSiteWithRedactedSecrets() string {
 	return getTestSiteWithSecrets(RedactedSecret, RedactedSecret, RedactedSecret, RedactedSecret)
 }
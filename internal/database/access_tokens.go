@@ -15,6 +15,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 )
 
 // AccessToken describes an access token. The actual token (that a caller must supply to
@@ -30,6 +31,13 @@ type AccessToken struct {
 	Internal   bool
 	CreatedAt  time.Time
 	LastUsedAt *time.Time
+	LastUsedIP string // the IP address of the client that last used the token, if known
+	// ExpiresAt is the time after which the token is no longer valid. Tokens
+	// without an expiry are valid until they are deleted.
+	ExpiresAt *time.Time
+	// ExpiryNotifiedAt is when the subject user was notified of the upcoming
+	// expiry of the token.
+	ExpiryNotifiedAt *time.Time
 }
 
 // ErrAccessTokenNotFound occurs when a database operation expects a specific access token to exist
@@ -66,9 +74,12 @@ type AccessTokenStore interface {
 	// space; also bcrypt is slow and would add noticeable latency to each request that supplied a
 	// token.
 	//
+	// If expiresAt is non-nil, the token is no longer valid after that time and is revoked by
+	// DeleteExpired.
+	//
 	// 🚨 SECURITY: The caller must ensure that the actor is permitted to create tokens for the
 	// specified user (i.e., that the actor is either the user or a site admin).
-	Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32) (id int64, token string, err error)
+	Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt *time.Time) (id int64, token string, err error)
 
 	// CreateInternal creates an *internal* access token for the specified user. An
 	// internal access token will be used by Sourcegraph to talk to its API from
@@ -89,6 +100,9 @@ type AccessTokenStore interface {
 	// that an API client would use to authenticate).
 	DeleteByToken(ctx context.Context, tokenHexEncoded string) error
 
+	// DeleteExpired deletes all access tokens whose expiry has passed and returns them.
+	DeleteExpired(context.Context) ([]*AccessToken, error)
+
 	// GetByID retrieves the access token (if any) given its ID.
 	//
 	// 🚨 SECURITY: The caller must ensure that the actor is permitted to view this access token.
@@ -110,14 +124,24 @@ type AccessTokenStore interface {
 	// options.
 	List(context.Context, AccessTokensListOptions) ([]*AccessToken, error)
 
-	// Lookup looks up the access token. If it's valid and contains the required scope, it returns the
-	// subject's user ID. Otherwise ErrAccessTokenNotFound is returned.
+	// ListExpiring lists the access tokens, except internal tokens, that expire before the given
+	// time and whose subject user has not yet been notified of the expiry.
+	ListExpiring(ctx context.Context, before time.Time) ([]*AccessToken, error)
+
+	// Lookup looks up the access token. If it's valid, unexpired and contains at least one of the
+	// scopes in opts, it returns the access token. Otherwise ErrAccessTokenNotFound is returned.
 	//
-	// Calling Lookup also updates the access token's last-used-at date.
+	// Calling Lookup also updates the access token's last-used-at date and IP address.
 	//
-	// 🚨 SECURITY: This returns a user ID if and only if the tokenHexEncoded corresponds to a valid,
-	// non-deleted access token.
-	Lookup(ctx context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error)
+	// 🚨 SECURITY: This returns an access token if and only if the tokenHexEncoded corresponds to a
+	// valid, non-deleted, unexpired access token. The caller must ensure that the scopes of the
+	// returned token permit the operation it is used for.
+	Lookup(ctx context.Context, tokenHexEncoded string, opts AccessTokenLookupOptions) (*AccessToken, error)
+
+	// MarkExpiryNotified records that the subject user of the access token is notified of its
+	// upcoming expiry. It returns ErrAccessTokenNotFound if the token does not exist or was
+	// already marked, so that concurrent callers notify the user only once.
+	MarkExpiryNotified(context.Context, int64) error
 
 	Transact(context.Context) (AccessTokenStore, error)
 	With(basestore.ShareableStore) AccessTokenStore
@@ -149,15 +173,15 @@ func (s *accessTokenStore) Transact(ctx context.Context) (AccessTokenStore, erro
 	return &accessTokenStore{Store: txBase}, err
 }
 
-func (s *accessTokenStore) Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32) (id int64, token string, err error) {
-	return s.createToken(ctx, subjectUserID, scopes, note, creatorUserID, false)
+func (s *accessTokenStore) Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt *time.Time) (id int64, token string, err error) {
+	return s.createToken(ctx, subjectUserID, scopes, note, creatorUserID, expiresAt, false)
 }
 
 func (s *accessTokenStore) CreateInternal(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32) (id int64, token string, err error) {
-	return s.createToken(ctx, subjectUserID, scopes, note, creatorUserID, true)
+	return s.createToken(ctx, subjectUserID, scopes, note, creatorUserID, nil, true)
 }
 
-func (s *accessTokenStore) createToken(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, internal bool) (id int64, token string, err error) {
+func (s *accessTokenStore) createToken(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt *time.Time, internal bool) (id int64, token string, err error) {
 	var b [20]byte
 	if _, err := rand.Read(b[:]); err != nil {
 		return 0, "", err
@@ -170,6 +194,10 @@ func (s *accessTokenStore) createToken(ctx context.Context, subjectUserID int32,
 		return 0, "", errors.New("access tokens without scopes are not supported")
 	}
 
+	if expiresAt != nil && !expiresAt.After(timeutil.Now()) {
+		return 0, "", errors.New("access tokens must expire in the future")
+	}
+
 	if err := s.Handle().DB().QueryRowContext(ctx,
 		// Include users table query (with "FOR UPDATE") to ensure that subject/creator users have
 		// not been deleted. If they were deleted, the query will return an error.
@@ -181,49 +209,61 @@ creator_user AS (
   SELECT id FROM users WHERE id=$5 AND deleted_at IS NULL FOR UPDATE
 ),
 insert_values AS (
-  SELECT subject_user.id AS subject_user_id, $2::text[] AS scopes, $3::bytea AS value_sha256, $4::text AS note, creator_user.id AS creator_user_id, $6::boolean AS internal
+  SELECT subject_user.id AS subject_user_id, $2::text[] AS scopes, $3::bytea AS value_sha256, $4::text AS note, creator_user.id AS creator_user_id, $6::boolean AS internal, $7::timestamp with time zone AS expires_at
   FROM subject_user, creator_user
 )
-INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_user_id, internal) SELECT * FROM insert_values RETURNING id
+INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_user_id, internal, expires_at) SELECT * FROM insert_values RETURNING id
 `,
-		subjectUserID, pq.Array(scopes), toSHA256Bytes(b[:]), note, creatorUserID, internal,
+		subjectUserID, pq.Array(scopes), toSHA256Bytes(b[:]), note, creatorUserID, internal, dbutil.NullTime{Time: expiresAt},
 	).Scan(&id); err != nil {
 		return 0, "", err
 	}
 	return id, token, nil
 }
 
-func (s *accessTokenStore) Lookup(ctx context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
-	if requiredScope == "" {
-		return 0, errors.New("no scope provided in access token lookup")
+// AccessTokenLookupOptions contains options for looking up access tokens.
+type AccessTokenLookupOptions struct {
+	// Scopes are the scopes accepted for the lookup. The access token must have at least one of
+	// them.
+	Scopes []string
+	// RemoteIP is the IP address of the client using the access token. It is recorded as the
+	// token's last-used IP address.
+	RemoteIP string
+}
+
+func (s *accessTokenStore) Lookup(ctx context.Context, tokenHexEncoded string, opts AccessTokenLookupOptions) (*AccessToken, error) {
+	if len(opts.Scopes) == 0 {
+		return nil, errors.New("no scope provided in access token lookup")
 	}
 
 	token, err := decodeToken(tokenHexEncoded)
 	if err != nil {
-		return 0, errors.Wrap(err, "AccessTokens.Lookup")
+		return nil, errors.Wrap(err, "AccessTokens.Lookup")
 	}
 
-	if err := s.Handle().DB().QueryRowContext(ctx,
-		// Ensure that subject and creator users still exist.
-		`
-UPDATE access_tokens t SET last_used_at=now()
+	// Ensure that subject and creator users still exist.
+	q := sqlf.Sprintf(`
+UPDATE access_tokens t SET last_used_at=now(), last_used_ip=%s
 WHERE t.id IN (
 	SELECT t2.id FROM access_tokens t2
 	JOIN users subject_user ON t2.subject_user_id=subject_user.id AND subject_user.deleted_at IS NULL
 	JOIN users creator_user ON t2.creator_user_id=creator_user.id AND creator_user.deleted_at IS NULL
-	WHERE t2.value_sha256=$1 AND t2.deleted_at IS NULL AND
-	$2 = ANY (t2.scopes)
+	WHERE t2.value_sha256=%s AND t2.deleted_at IS NULL AND
+	(t2.expires_at IS NULL OR t2.expires_at > now()) AND
+	t2.scopes && %s
 )
-RETURNING t.subject_user_id
-`,
-		toSHA256Bytes(token), requiredScope,
-	).Scan(&subjectUserID); err != nil {
+RETURNING `+accessTokenColumns,
+		dbutil.NewNullString(opts.RemoteIP), toSHA256Bytes(token), pq.Array(opts.Scopes),
+	)
+
+	t, err := scanAccessToken(s.QueryRow(ctx, q))
+	if err != nil {
 		if err == sql.ErrNoRows {
-			return 0, ErrAccessTokenNotFound
+			return nil, ErrAccessTokenNotFound
 		}
-		return 0, err
+		return nil, err
 	}
-	return subjectUserID, nil
+	return t, nil
 }
 
 func (s *accessTokenStore) GetByID(ctx context.Context, id int64) (*AccessToken, error) {
@@ -280,9 +320,21 @@ func (s *accessTokenStore) List(ctx context.Context, opt AccessTokensListOptions
 	return s.list(ctx, opt.sqlConditions(), opt.LimitOffset)
 }
 
+func (s *accessTokenStore) ListExpiring(ctx context.Context, before time.Time) ([]*AccessToken, error) {
+	conds := []*sqlf.Query{
+		sqlf.Sprintf("deleted_at IS NULL"),
+		sqlf.Sprintf("internal IS FALSE"),
+		sqlf.Sprintf("expires_at < %s", before),
+		sqlf.Sprintf("expiry_notified_at IS NULL"),
+	}
+	return s.list(ctx, conds, nil)
+}
+
+const accessTokenColumns = "id, subject_user_id, scopes, note, creator_user_id, internal, created_at, last_used_at, last_used_ip, expires_at, expiry_notified_at"
+
 func (s *accessTokenStore) list(ctx context.Context, conds []*sqlf.Query, limitOffset *LimitOffset) ([]*AccessToken, error) {
 	q := sqlf.Sprintf(`
-SELECT id, subject_user_id, scopes, note, creator_user_id, internal, created_at, last_used_at FROM access_tokens
+SELECT `+accessTokenColumns+` FROM access_tokens
 WHERE (%s)
 ORDER BY now() - created_at < interval '5 minutes' DESC, -- show recently created tokens first
 last_used_at DESC NULLS FIRST, -- ensure newly created tokens show first
@@ -300,11 +352,11 @@ created_at DESC
 
 	var results []*AccessToken
 	for rows.Next() {
-		var t AccessToken
-		if err := rows.Scan(&t.ID, &t.SubjectUserID, pq.Array(&t.Scopes), &t.Note, &t.CreatorUserID, &t.Internal, &t.CreatedAt, &t.LastUsedAt); err != nil {
+		t, err := scanAccessToken(rows)
+		if err != nil {
 			return nil, err
 		}
-		results = append(results, &t)
+		results = append(results, t)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -313,6 +365,26 @@ created_at DESC
 	return results, nil
 }
 
+func scanAccessToken(sc dbutil.Scanner) (*AccessToken, error) {
+	var t AccessToken
+	if err := sc.Scan(
+		&t.ID,
+		&t.SubjectUserID,
+		pq.Array(&t.Scopes),
+		&t.Note,
+		&t.CreatorUserID,
+		&t.Internal,
+		&t.CreatedAt,
+		&t.LastUsedAt,
+		&dbutil.NullString{S: &t.LastUsedIP},
+		&t.ExpiresAt,
+		&t.ExpiryNotifiedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
 func (s *accessTokenStore) Count(ctx context.Context, opt AccessTokensListOptions) (int, error) {
 	q := sqlf.Sprintf("SELECT COUNT(*) FROM access_tokens WHERE (%s)", sqlf.Join(opt.sqlConditions(), ") AND ("))
 	var count int
@@ -353,6 +425,45 @@ func (s *accessTokenStore) DeleteByToken(ctx context.Context, tokenHexEncoded st
 	return s.delete(ctx, sqlf.Sprintf("value_sha256=%s", toSHA256Bytes(token)))
 }
 
+func (s *accessTokenStore) DeleteExpired(ctx context.Context) ([]*AccessToken, error) {
+	q := sqlf.Sprintf(`
+UPDATE access_tokens SET deleted_at=now()
+WHERE deleted_at IS NULL AND expires_at <= now()
+RETURNING ` + accessTokenColumns,
+	)
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*AccessToken
+	for rows.Next() {
+		t, err := scanAccessToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, t)
+	}
+	return results, rows.Err()
+}
+
+func (s *accessTokenStore) MarkExpiryNotified(ctx context.Context, id int64) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf("UPDATE access_tokens SET expiry_notified_at=now() WHERE id=%d AND deleted_at IS NULL AND expiry_notified_at IS NULL", id))
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return ErrAccessTokenNotFound
+	}
+	return nil
+}
+
 func (s *accessTokenStore) delete(ctx context.Context, cond *sqlf.Query) error {
 	conds := []*sqlf.Query{cond, sqlf.Sprintf("deleted_at IS NULL")}
 	q := sqlf.Sprintf("UPDATE access_tokens SET deleted_at=now() WHERE (%s)", sqlf.Join(conds, ") AND ("))
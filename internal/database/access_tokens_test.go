@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 )
@@ -35,7 +36,7 @@ func TestAccessTokens_Create(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	tid0, tv0, err := AccessTokens(db).Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	tid0, tv0, err := AccessTokens(db).Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -54,12 +55,15 @@ func TestAccessTokens_Create(t *testing.T) {
 		t.Errorf("got %q, want %q", got.Note, want)
 	}
 
-	gotSubjectUserID, err := AccessTokens(db).Lookup(ctx, tv0, "a")
+	gotToken, err := AccessTokens(db).Lookup(ctx, tv0, AccessTokenLookupOptions{Scopes: []string{"a"}, RemoteIP: "127.0.0.1"})
 	if err != nil {
 		t.Fatal(err)
 	}
-	if want := subject.ID; gotSubjectUserID != want {
-		t.Errorf("got %v, want %v", gotSubjectUserID, want)
+	if want := subject.ID; gotToken.SubjectUserID != want {
+		t.Errorf("got %v, want %v", gotToken.SubjectUserID, want)
+	}
+	if want := "127.0.0.1"; gotToken.LastUsedIP != want {
+		t.Errorf("got %q, want %q", gotToken.LastUsedIP, want)
 	}
 
 	ts, err := AccessTokens(db).List(ctx, AccessTokensListOptions{SubjectUserID: subject.ID})
@@ -110,11 +114,11 @@ func TestAccessTokens_List(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	_, _, err = AccessTokens(db).Create(ctx, subject1.ID, []string{"a", "b"}, "n0", subject1.ID)
+	_, _, err = AccessTokens(db).Create(ctx, subject1.ID, []string{"a", "b"}, "n0", subject1.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, _, err = AccessTokens(db).Create(ctx, subject1.ID, []string{"a", "b"}, "n1", subject1.ID)
+	_, _, err = AccessTokens(db).Create(ctx, subject1.ID, []string{"a", "b"}, "n1", subject1.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -190,28 +194,33 @@ func TestAccessTokens_Lookup(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	tid0, tv0, err := AccessTokens(db).Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	tid0, tv0, err := AccessTokens(db).Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	for _, scope := range []string{"a", "b"} {
-		gotSubjectUserID, err := AccessTokens(db).Lookup(ctx, tv0, scope)
+		gotToken, err := AccessTokens(db).Lookup(ctx, tv0, AccessTokenLookupOptions{Scopes: []string{scope}})
 		if err != nil {
 			t.Fatal(err)
 		}
-		if want := subject.ID; gotSubjectUserID != want {
-			t.Errorf("got %v, want %v", gotSubjectUserID, want)
+		if want := subject.ID; gotToken.SubjectUserID != want {
+			t.Errorf("got %v, want %v", gotToken.SubjectUserID, want)
 		}
 	}
 
 	// Lookup with a nonexistent scope and ensure it fails.
-	if _, err := AccessTokens(db).Lookup(ctx, tv0, "x"); err == nil {
+	if _, err := AccessTokens(db).Lookup(ctx, tv0, AccessTokenLookupOptions{Scopes: []string{"x"}}); err == nil {
+		t.Fatal(err)
+	}
+
+	// Lookup with any of several scopes and ensure it succeeds.
+	if _, err := AccessTokens(db).Lookup(ctx, tv0, AccessTokenLookupOptions{Scopes: []string{"x", "b"}}); err != nil {
 		t.Fatal(err)
 	}
 
 	// Lookup with an empty scope and ensure it fails.
-	if _, err := AccessTokens(db).Lookup(ctx, tv0, ""); err == nil {
+	if _, err := AccessTokens(db).Lookup(ctx, tv0, AccessTokenLookupOptions{}); err == nil {
 		t.Fatal(err)
 	}
 
@@ -219,12 +228,12 @@ func TestAccessTokens_Lookup(t *testing.T) {
 	if err := AccessTokens(db).DeleteByID(ctx, tid0); err != nil {
 		t.Fatal(err)
 	}
-	if _, err := AccessTokens(db).Lookup(ctx, tv0, "a"); err == nil {
+	if _, err := AccessTokens(db).Lookup(ctx, tv0, AccessTokenLookupOptions{Scopes: []string{"a"}}); err == nil {
 		t.Fatal(err)
 	}
 
 	// Try to Lookup a token that was never created.
-	if _, err := AccessTokens(db).Lookup(ctx, "abcdefg" /* this token value was never created */, "a"); err == nil {
+	if _, err := AccessTokens(db).Lookup(ctx, "abcdefg" /* this token value was never created */, AccessTokenLookupOptions{Scopes: []string{"a"}}); err == nil {
 		t.Fatal(err)
 	}
 }
@@ -259,18 +268,18 @@ func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		_, tv0, err := AccessTokens(db).Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID)
+		_, tv0, err := AccessTokens(db).Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
 		if err := Users(db).Delete(ctx, subject.ID); err != nil {
 			t.Fatal(err)
 		}
-		if _, err := AccessTokens(db).Lookup(ctx, tv0, "a"); err == nil {
+		if _, err := AccessTokens(db).Lookup(ctx, tv0, AccessTokenLookupOptions{Scopes: []string{"a"}}); err == nil {
 			t.Fatal("Lookup: want error looking up token for deleted subject user")
 		}
 
-		if _, _, err := AccessTokens(db).Create(ctx, subject.ID, nil, "n0", creator.ID); err == nil {
+		if _, _, err := AccessTokens(db).Create(ctx, subject.ID, nil, "n0", creator.ID, nil); err == nil {
 			t.Fatal("Create: want error creating token for deleted subject user")
 		}
 	})
@@ -295,19 +304,95 @@ func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		_, tv0, err := AccessTokens(db).Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID)
+		_, tv0, err := AccessTokens(db).Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
 		if err := Users(db).Delete(ctx, creator.ID); err != nil {
 			t.Fatal(err)
 		}
-		if _, err := AccessTokens(db).Lookup(ctx, tv0, "a"); err == nil {
+		if _, err := AccessTokens(db).Lookup(ctx, tv0, AccessTokenLookupOptions{Scopes: []string{"a"}}); err == nil {
 			t.Fatal("Lookup: want error looking up token for deleted creator user")
 		}
 
-		if _, _, err := AccessTokens(db).Create(ctx, subject.ID, nil, "n0", creator.ID); err == nil {
+		if _, _, err := AccessTokens(db).Create(ctx, subject.ID, nil, "n0", creator.ID, nil); err == nil {
 			t.Fatal("Create: want error creating token for deleted creator user")
 		}
 	})
 }
+
+func TestAccessTokens_Expiry(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	subject, err := Users(db).Create(ctx, NewUser{
+		Email:                 "a@example.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	past := time.Now().Add(-time.Hour)
+	if _, _, err := AccessTokens(db).Create(ctx, subject.ID, []string{"a"}, "n0", subject.ID, &past); err == nil {
+		t.Fatal("Create: want error creating token that already expired")
+	}
+
+	expiresAt := time.Now().Add(time.Hour)
+	tid0, tv0, err := AccessTokens(db).Create(ctx, subject.ID, []string{"a"}, "n0", subject.ID, &expiresAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := AccessTokens(db).Create(ctx, subject.ID, []string{"a"}, "n1", subject.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expiring, err := AccessTokens(db).ListExpiring(ctx, time.Now().Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expiring) != 1 || expiring[0].ID != tid0 {
+		t.Fatalf("got expiring tokens %+v, want only %d", expiring, tid0)
+	}
+
+	if err := AccessTokens(db).MarkExpiryNotified(ctx, tid0); err != nil {
+		t.Fatal(err)
+	}
+	if err := AccessTokens(db).MarkExpiryNotified(ctx, tid0); err != ErrAccessTokenNotFound {
+		t.Fatalf("got err %v, want %v", err, ErrAccessTokenNotFound)
+	}
+	expiring, err = AccessTokens(db).ListExpiring(ctx, time.Now().Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expiring) != 0 {
+		t.Fatalf("got expiring tokens %+v, want none", expiring)
+	}
+
+	// Let the token expire and ensure Lookup fails on it.
+	if _, err := db.ExecContext(ctx, "UPDATE access_tokens SET expires_at = now() - interval '1 minute' WHERE id = $1", tid0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AccessTokens(db).Lookup(ctx, tv0, AccessTokenLookupOptions{Scopes: []string{"a"}}); err != ErrAccessTokenNotFound {
+		t.Fatalf("got err %v, want %v", err, ErrAccessTokenNotFound)
+	}
+
+	revoked, err := AccessTokens(db).DeleteExpired(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revoked) != 1 || revoked[0].ID != tid0 {
+		t.Fatalf("got revoked tokens %+v, want only %d", revoked, tid0)
+	}
+	if n, err := AccessTokens(db).Count(ctx, AccessTokensListOptions{SubjectUserID: subject.ID}); err != nil {
+		t.Fatal(err)
+	} else if n != 1 {
+		t.Fatalf("got %d access tokens, want 1", n)
+	}
+}
@@ -32,15 +32,18 @@ type DB interface {
 	OrgStats() OrgStatsStore
 	Phabricator() PhabricatorStore
 	Repos() RepoStore
+	Roles() RoleStore
 	SavedSearches() SavedSearchStore
 	SearchContexts() SearchContextsStore
 	Settings() SettingsStore
+	SubRepoPathRules() SubRepoPathRuleStore
 	SubRepoPerms() SubRepoPermsStore
 	TemporarySettings() TemporarySettingsStore
 	UserCredentials(encryption.Key) UserCredentialsStore
 	UserEmails() UserEmailsStore
 	UserExternalAccounts() UserExternalAccountsStore
 	UserPublicRepos() UserPublicRepoStore
+	UserSessions() UserSessionStore
 	Users() UserStore
 	WebhookLogs(encryption.Key) WebhookLogStore
 
@@ -149,6 +152,10 @@ func (d *db) Repos() RepoStore {
 	return ReposWith(d.Store)
 }
 
+func (d *db) Roles() RoleStore {
+	return RolesWith(d.Store)
+}
+
 func (d *db) SavedSearches() SavedSearchStore {
 	return SavedSearchesWith(d.Store)
 }
@@ -161,6 +168,10 @@ func (d *db) Settings() SettingsStore {
 	return SettingsWith(d.Store)
 }
 
+func (d *db) SubRepoPathRules() SubRepoPathRuleStore {
+	return SubRepoPathRulesWith(d.Store)
+}
+
 func (d *db) SubRepoPerms() SubRepoPermsStore {
 	return SubRepoPermsWith(d.Store)
 }
@@ -185,6 +196,10 @@ func (d *db) UserPublicRepos() UserPublicRepoStore {
 	return UserPublicReposWith(d.Store)
 }
 
+func (d *db) UserSessions() UserSessionStore {
+	return UserSessionsWith(d.Store)
+}
+
 func (d *db) Users() UserStore {
 	return UsersWith(d.Store)
 }
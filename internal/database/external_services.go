@@ -149,6 +149,7 @@ type externalServiceStore struct {
 	gitHubValidators          []func(*schema.GitHubConnection) error
 	gitLabValidators          []func(*schema.GitLabConnection, []schema.AuthProviders) error
 	bitbucketServerValidators []func(*schema.BitbucketServerConnection) error
+	bitbucketCloudValidators  []func(*schema.BitbucketCloudConnection) error
 	perforceValidators        []func(*schema.PerforceConnection) error
 
 	key encryption.Key
@@ -161,6 +162,7 @@ func (e *externalServiceStore) copy() *externalServiceStore {
 		gitHubValidators:          e.gitHubValidators,
 		gitLabValidators:          e.gitLabValidators,
 		bitbucketServerValidators: e.bitbucketServerValidators,
+		bitbucketCloudValidators:  e.bitbucketCloudValidators,
 		perforceValidators:        e.perforceValidators,
 	}
 }
@@ -177,6 +179,7 @@ func NewExternalServiceStoreWithValidators(
 	gitHubValidators []func(*schema.GitHubConnection) error,
 	gitLabValidators []func(*schema.GitLabConnection, []schema.AuthProviders) error,
 	bitbucketServerValidators []func(*schema.BitbucketServerConnection) error,
+	bitbucketCloudValidators []func(*schema.BitbucketCloudConnection) error,
 	perforceValidators []func(*schema.PerforceConnection) error,
 ) ExternalServiceStore {
 	return &externalServiceStore{
@@ -184,6 +187,7 @@ func NewExternalServiceStoreWithValidators(
 		gitHubValidators:          gitHubValidators,
 		gitLabValidators:          gitLabValidators,
 		bitbucketServerValidators: bitbucketServerValidators,
+		bitbucketCloudValidators:  bitbucketCloudValidators,
 		perforceValidators:        perforceValidators,
 	}
 }
@@ -512,6 +516,12 @@ func (e *externalServiceStore) validateGitHubConnection(ctx context.Context, id
 	if c.Token == "" && c.GithubAppInstallationID == "" {
 		err = multierror.Append(err, errors.New("at least one of token or githubAppInstallationID must be set"))
 	}
+	if (c.GithubAppID == "") != (c.GithubAppPrivateKey == "") {
+		err = multierror.Append(err, errors.New("githubAppID and githubAppPrivateKey must be set together"))
+	}
+	if c.GithubAppID != "" && c.GithubAppInstallationID == "" {
+		err = multierror.Append(err, errors.New("githubAppInstallationID must be set when githubAppID is set"))
+	}
 	if c.Repos == nil && c.RepositoryQuery == nil && c.Orgs == nil {
 		err = multierror.Append(err, errors.New("at least one of repositoryQuery, repos or orgs must be set"))
 	}
@@ -548,7 +558,14 @@ func (e *externalServiceStore) validateBitbucketServerConnection(ctx context.Con
 }
 
 func (e *externalServiceStore) validateBitbucketCloudConnection(ctx context.Context, id int64, c *schema.BitbucketCloudConnection) error {
-	return e.validateDuplicateRateLimits(ctx, id, extsvc.KindBitbucketCloud, c)
+	err := new(multierror.Error)
+	for _, validate := range e.bitbucketCloudValidators {
+		err = multierror.Append(err, validate(c))
+	}
+
+	err = multierror.Append(err, e.validateDuplicateRateLimits(ctx, id, extsvc.KindBitbucketCloud, c))
+
+	return err.ErrorOrNil()
 }
 
 func (e *externalServiceStore) validatePerforceConnection(ctx context.Context, id int64, c *schema.PerforceConnection) error {
@@ -1480,6 +1497,8 @@ func configurationHasWebhooks(config interface{}) bool {
 		return len(v.Webhooks) > 0
 	case *schema.BitbucketServerConnection:
 		return v.WebhookSecret() != ""
+	case *schema.BitbucketCloudConnection:
+		return len(v.Webhooks) > 0
 	}
 
 	return false
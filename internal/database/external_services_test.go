@@ -166,6 +166,12 @@ func TestExternalServicesStore_ValidateConfig(t *testing.T) {
 			config:  `{"url": "https://github.com", "repositoryQuery": ["none"], "token": ""}`,
 			wantErr: "2 errors occurred:\n\t* token: String length must be greater than or equal to 1\n\t* at least one of token or githubAppInstallationID must be set\n\n",
 		},
+		{
+			name:    "GitHub App without private key",
+			kind:    extsvc.KindGitHub,
+			config:  `{"url": "https://github.com", "repositoryQuery": ["none"], "githubAppID": "1234", "githubAppInstallationID": "5678"}`,
+			wantErr: "1 error occurred:\n\t* githubAppID and githubAppPrivateKey must be set together\n\n",
+		},
 		{
 			name:   "no conflicting rate limit",
 			kind:   extsvc.KindGitHub,
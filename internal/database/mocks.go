@@ -42,6 +42,9 @@ type MockAccessTokenStore struct {
 	// DeleteByTokenFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteByToken.
 	DeleteByTokenFunc *AccessTokenStoreDeleteByTokenFunc
+	// DeleteExpiredFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteExpired.
+	DeleteExpiredFunc *AccessTokenStoreDeleteExpiredFunc
 	// GetByIDFunc is an instance of a mock function object controlling the
 	// behavior of the method GetByID.
 	GetByIDFunc *AccessTokenStoreGetByIDFunc
@@ -57,9 +60,15 @@ type MockAccessTokenStore struct {
 	// ListFunc is an instance of a mock function object controlling the
 	// behavior of the method List.
 	ListFunc *AccessTokenStoreListFunc
+	// ListExpiringFunc is an instance of a mock function object controlling
+	// the behavior of the method ListExpiring.
+	ListExpiringFunc *AccessTokenStoreListExpiringFunc
 	// LookupFunc is an instance of a mock function object controlling the
 	// behavior of the method Lookup.
 	LookupFunc *AccessTokenStoreLookupFunc
+	// MarkExpiryNotifiedFunc is an instance of a mock function object
+	// controlling the behavior of the method MarkExpiryNotified.
+	MarkExpiryNotifiedFunc *AccessTokenStoreMarkExpiryNotifiedFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *AccessTokenStoreTransactFunc
@@ -79,7 +88,7 @@ func NewMockAccessTokenStore() *MockAccessTokenStore {
 			},
 		},
 		CreateFunc: &AccessTokenStoreCreateFunc{
-			defaultHook: func(context.Context, int32, []string, string, int32) (int64, string, error) {
+			defaultHook: func(context.Context, int32, []string, string, int32, *time.Time) (int64, string, error) {
 				return 0, "", nil
 			},
 		},
@@ -98,6 +107,11 @@ func NewMockAccessTokenStore() *MockAccessTokenStore {
 				return nil
 			},
 		},
+		DeleteExpiredFunc: &AccessTokenStoreDeleteExpiredFunc{
+			defaultHook: func(context.Context) ([]*AccessToken, error) {
+				return nil, nil
+			},
+		},
 		GetByIDFunc: &AccessTokenStoreGetByIDFunc{
 			defaultHook: func(context.Context, int64) (*AccessToken, error) {
 				return nil, nil
@@ -123,9 +137,19 @@ func NewMockAccessTokenStore() *MockAccessTokenStore {
 				return nil, nil
 			},
 		},
+		ListExpiringFunc: &AccessTokenStoreListExpiringFunc{
+			defaultHook: func(context.Context, time.Time) ([]*AccessToken, error) {
+				return nil, nil
+			},
+		},
 		LookupFunc: &AccessTokenStoreLookupFunc{
-			defaultHook: func(context.Context, string, string) (int32, error) {
-				return 0, nil
+			defaultHook: func(context.Context, string, AccessTokenLookupOptions) (*AccessToken, error) {
+				return nil, nil
+			},
+		},
+		MarkExpiryNotifiedFunc: &AccessTokenStoreMarkExpiryNotifiedFunc{
+			defaultHook: func(context.Context, int64) error {
+				return nil
 			},
 		},
 		TransactFunc: &AccessTokenStoreTransactFunc{
@@ -151,7 +175,7 @@ func NewStrictMockAccessTokenStore() *MockAccessTokenStore {
 			},
 		},
 		CreateFunc: &AccessTokenStoreCreateFunc{
-			defaultHook: func(context.Context, int32, []string, string, int32) (int64, string, error) {
+			defaultHook: func(context.Context, int32, []string, string, int32, *time.Time) (int64, string, error) {
 				panic("unexpected invocation of MockAccessTokenStore.Create")
 			},
 		},
@@ -170,6 +194,11 @@ func NewStrictMockAccessTokenStore() *MockAccessTokenStore {
 				panic("unexpected invocation of MockAccessTokenStore.DeleteByToken")
 			},
 		},
+		DeleteExpiredFunc: &AccessTokenStoreDeleteExpiredFunc{
+			defaultHook: func(context.Context) ([]*AccessToken, error) {
+				panic("unexpected invocation of MockAccessTokenStore.DeleteExpired")
+			},
+		},
 		GetByIDFunc: &AccessTokenStoreGetByIDFunc{
 			defaultHook: func(context.Context, int64) (*AccessToken, error) {
 				panic("unexpected invocation of MockAccessTokenStore.GetByID")
@@ -195,11 +224,21 @@ func NewStrictMockAccessTokenStore() *MockAccessTokenStore {
 				panic("unexpected invocation of MockAccessTokenStore.List")
 			},
 		},
+		ListExpiringFunc: &AccessTokenStoreListExpiringFunc{
+			defaultHook: func(context.Context, time.Time) ([]*AccessToken, error) {
+				panic("unexpected invocation of MockAccessTokenStore.ListExpiring")
+			},
+		},
 		LookupFunc: &AccessTokenStoreLookupFunc{
-			defaultHook: func(context.Context, string, string) (int32, error) {
+			defaultHook: func(context.Context, string, AccessTokenLookupOptions) (*AccessToken, error) {
 				panic("unexpected invocation of MockAccessTokenStore.Lookup")
 			},
 		},
+		MarkExpiryNotifiedFunc: &AccessTokenStoreMarkExpiryNotifiedFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockAccessTokenStore.MarkExpiryNotified")
+			},
+		},
 		TransactFunc: &AccessTokenStoreTransactFunc{
 			defaultHook: func(context.Context) (AccessTokenStore, error) {
 				panic("unexpected invocation of MockAccessTokenStore.Transact")
@@ -233,6 +272,9 @@ func NewMockAccessTokenStoreFrom(i AccessTokenStore) *MockAccessTokenStore {
 		DeleteByTokenFunc: &AccessTokenStoreDeleteByTokenFunc{
 			defaultHook: i.DeleteByToken,
 		},
+		DeleteExpiredFunc: &AccessTokenStoreDeleteExpiredFunc{
+			defaultHook: i.DeleteExpired,
+		},
 		GetByIDFunc: &AccessTokenStoreGetByIDFunc{
 			defaultHook: i.GetByID,
 		},
@@ -248,9 +290,15 @@ func NewMockAccessTokenStoreFrom(i AccessTokenStore) *MockAccessTokenStore {
 		ListFunc: &AccessTokenStoreListFunc{
 			defaultHook: i.List,
 		},
+		ListExpiringFunc: &AccessTokenStoreListExpiringFunc{
+			defaultHook: i.ListExpiring,
+		},
 		LookupFunc: &AccessTokenStoreLookupFunc{
 			defaultHook: i.Lookup,
 		},
+		MarkExpiryNotifiedFunc: &AccessTokenStoreMarkExpiryNotifiedFunc{
+			defaultHook: i.MarkExpiryNotified,
+		},
 		TransactFunc: &AccessTokenStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
@@ -372,24 +420,24 @@ func (c AccessTokenStoreCountFuncCall) Results() []interface{} {
 // AccessTokenStoreCreateFunc describes the behavior when the Create method
 // of the parent MockAccessTokenStore instance is invoked.
 type AccessTokenStoreCreateFunc struct {
-	defaultHook func(context.Context, int32, []string, string, int32) (int64, string, error)
-	hooks       []func(context.Context, int32, []string, string, int32) (int64, string, error)
+	defaultHook func(context.Context, int32, []string, string, int32, *time.Time) (int64, string, error)
+	hooks       []func(context.Context, int32, []string, string, int32, *time.Time) (int64, string, error)
 	history     []AccessTokenStoreCreateFuncCall
 	mutex       sync.Mutex
 }
 
 // Create delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockAccessTokenStore) Create(v0 context.Context, v1 int32, v2 []string, v3 string, v4 int32) (int64, string, error) {
-	r0, r1, r2 := m.CreateFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.CreateFunc.appendCall(AccessTokenStoreCreateFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+func (m *MockAccessTokenStore) Create(v0 context.Context, v1 int32, v2 []string, v3 string, v4 int32, v5 *time.Time) (int64, string, error) {
+	r0, r1, r2 := m.CreateFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.CreateFunc.appendCall(AccessTokenStoreCreateFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
 	return r0, r1, r2
 }
 
 // SetDefaultHook sets function that is called when the Create method of the
 // parent MockAccessTokenStore instance is invoked and the hook queue is
 // empty.
-func (f *AccessTokenStoreCreateFunc) SetDefaultHook(hook func(context.Context, int32, []string, string, int32) (int64, string, error)) {
+func (f *AccessTokenStoreCreateFunc) SetDefaultHook(hook func(context.Context, int32, []string, string, int32, *time.Time) (int64, string, error)) {
 	f.defaultHook = hook
 }
 
@@ -397,7 +445,7 @@ func (f *AccessTokenStoreCreateFunc) SetDefaultHook(hook func(context.Context, i
 // Create method of the parent MockAccessTokenStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *AccessTokenStoreCreateFunc) PushHook(hook func(context.Context, int32, []string, string, int32) (int64, string, error)) {
+func (f *AccessTokenStoreCreateFunc) PushHook(hook func(context.Context, int32, []string, string, int32, *time.Time) (int64, string, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -406,7 +454,7 @@ func (f *AccessTokenStoreCreateFunc) PushHook(hook func(context.Context, int32,
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
 func (f *AccessTokenStoreCreateFunc) SetDefaultReturn(r0 int64, r1 string, r2 error) {
-	f.SetDefaultHook(func(context.Context, int32, []string, string, int32) (int64, string, error) {
+	f.SetDefaultHook(func(context.Context, int32, []string, string, int32, *time.Time) (int64, string, error) {
 		return r0, r1, r2
 	})
 }
@@ -414,12 +462,12 @@ func (f *AccessTokenStoreCreateFunc) SetDefaultReturn(r0 int64, r1 string, r2 er
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
 func (f *AccessTokenStoreCreateFunc) PushReturn(r0 int64, r1 string, r2 error) {
-	f.PushHook(func(context.Context, int32, []string, string, int32) (int64, string, error) {
+	f.PushHook(func(context.Context, int32, []string, string, int32, *time.Time) (int64, string, error) {
 		return r0, r1, r2
 	})
 }
 
-func (f *AccessTokenStoreCreateFunc) nextHook() func(context.Context, int32, []string, string, int32) (int64, string, error) {
+func (f *AccessTokenStoreCreateFunc) nextHook() func(context.Context, int32, []string, string, int32, *time.Time) (int64, string, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -467,6 +515,9 @@ type AccessTokenStoreCreateFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 int32
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 *time.Time
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 int64
@@ -481,7 +532,7 @@ type AccessTokenStoreCreateFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c AccessTokenStoreCreateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -827,6 +878,114 @@ func (c AccessTokenStoreDeleteByTokenFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// AccessTokenStoreDeleteExpiredFunc describes the behavior when the
+// DeleteExpired method of the parent MockAccessTokenStore instance is
+// invoked.
+type AccessTokenStoreDeleteExpiredFunc struct {
+	defaultHook func(context.Context) ([]*AccessToken, error)
+	hooks       []func(context.Context) ([]*AccessToken, error)
+	history     []AccessTokenStoreDeleteExpiredFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteExpired delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAccessTokenStore) DeleteExpired(v0 context.Context) ([]*AccessToken, error) {
+	r0, r1 := m.DeleteExpiredFunc.nextHook()(v0)
+	m.DeleteExpiredFunc.appendCall(AccessTokenStoreDeleteExpiredFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DeleteExpired method
+// of the parent MockAccessTokenStore instance is invoked and the hook queue
+// is empty.
+func (f *AccessTokenStoreDeleteExpiredFunc) SetDefaultHook(hook func(context.Context) ([]*AccessToken, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteExpired method of the parent MockAccessTokenStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AccessTokenStoreDeleteExpiredFunc) PushHook(hook func(context.Context) ([]*AccessToken, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *AccessTokenStoreDeleteExpiredFunc) SetDefaultReturn(r0 []*AccessToken, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]*AccessToken, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *AccessTokenStoreDeleteExpiredFunc) PushReturn(r0 []*AccessToken, r1 error) {
+	f.PushHook(func(context.Context) ([]*AccessToken, error) {
+		return r0, r1
+	})
+}
+
+func (f *AccessTokenStoreDeleteExpiredFunc) nextHook() func(context.Context) ([]*AccessToken, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AccessTokenStoreDeleteExpiredFunc) appendCall(r0 AccessTokenStoreDeleteExpiredFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AccessTokenStoreDeleteExpiredFuncCall
+// objects describing the invocations of this function.
+func (f *AccessTokenStoreDeleteExpiredFunc) History() []AccessTokenStoreDeleteExpiredFuncCall {
+	f.mutex.Lock()
+	history := make([]AccessTokenStoreDeleteExpiredFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AccessTokenStoreDeleteExpiredFuncCall is an object that describes an
+// invocation of method DeleteExpired on an instance of
+// MockAccessTokenStore.
+type AccessTokenStoreDeleteExpiredFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*AccessToken
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AccessTokenStoreDeleteExpiredFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AccessTokenStoreDeleteExpiredFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // AccessTokenStoreGetByIDFunc describes the behavior when the GetByID
 // method of the parent MockAccessTokenStore instance is invoked.
 type AccessTokenStoreGetByIDFunc struct {
@@ -1362,18 +1521,128 @@ func (c AccessTokenStoreListFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// AccessTokenStoreListExpiringFunc describes the behavior when the
+// ListExpiring method of the parent MockAccessTokenStore instance is
+// invoked.
+type AccessTokenStoreListExpiringFunc struct {
+	defaultHook func(context.Context, time.Time) ([]*AccessToken, error)
+	hooks       []func(context.Context, time.Time) ([]*AccessToken, error)
+	history     []AccessTokenStoreListExpiringFuncCall
+	mutex       sync.Mutex
+}
+
+// ListExpiring delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAccessTokenStore) ListExpiring(v0 context.Context, v1 time.Time) ([]*AccessToken, error) {
+	r0, r1 := m.ListExpiringFunc.nextHook()(v0, v1)
+	m.ListExpiringFunc.appendCall(AccessTokenStoreListExpiringFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListExpiring method
+// of the parent MockAccessTokenStore instance is invoked and the hook queue
+// is empty.
+func (f *AccessTokenStoreListExpiringFunc) SetDefaultHook(hook func(context.Context, time.Time) ([]*AccessToken, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListExpiring method of the parent MockAccessTokenStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AccessTokenStoreListExpiringFunc) PushHook(hook func(context.Context, time.Time) ([]*AccessToken, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *AccessTokenStoreListExpiringFunc) SetDefaultReturn(r0 []*AccessToken, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Time) ([]*AccessToken, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *AccessTokenStoreListExpiringFunc) PushReturn(r0 []*AccessToken, r1 error) {
+	f.PushHook(func(context.Context, time.Time) ([]*AccessToken, error) {
+		return r0, r1
+	})
+}
+
+func (f *AccessTokenStoreListExpiringFunc) nextHook() func(context.Context, time.Time) ([]*AccessToken, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AccessTokenStoreListExpiringFunc) appendCall(r0 AccessTokenStoreListExpiringFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AccessTokenStoreListExpiringFuncCall
+// objects describing the invocations of this function.
+func (f *AccessTokenStoreListExpiringFunc) History() []AccessTokenStoreListExpiringFuncCall {
+	f.mutex.Lock()
+	history := make([]AccessTokenStoreListExpiringFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AccessTokenStoreListExpiringFuncCall is an object that describes an
+// invocation of method ListExpiring on an instance of MockAccessTokenStore.
+type AccessTokenStoreListExpiringFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*AccessToken
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AccessTokenStoreListExpiringFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AccessTokenStoreListExpiringFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // AccessTokenStoreLookupFunc describes the behavior when the Lookup method
 // of the parent MockAccessTokenStore instance is invoked.
 type AccessTokenStoreLookupFunc struct {
-	defaultHook func(context.Context, string, string) (int32, error)
-	hooks       []func(context.Context, string, string) (int32, error)
+	defaultHook func(context.Context, string, AccessTokenLookupOptions) (*AccessToken, error)
+	hooks       []func(context.Context, string, AccessTokenLookupOptions) (*AccessToken, error)
 	history     []AccessTokenStoreLookupFuncCall
 	mutex       sync.Mutex
 }
 
 // Lookup delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockAccessTokenStore) Lookup(v0 context.Context, v1 string, v2 string) (int32, error) {
+func (m *MockAccessTokenStore) Lookup(v0 context.Context, v1 string, v2 AccessTokenLookupOptions) (*AccessToken, error) {
 	r0, r1 := m.LookupFunc.nextHook()(v0, v1, v2)
 	m.LookupFunc.appendCall(AccessTokenStoreLookupFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
@@ -1382,7 +1651,7 @@ func (m *MockAccessTokenStore) Lookup(v0 context.Context, v1 string, v2 string)
 // SetDefaultHook sets function that is called when the Lookup method of the
 // parent MockAccessTokenStore instance is invoked and the hook queue is
 // empty.
-func (f *AccessTokenStoreLookupFunc) SetDefaultHook(hook func(context.Context, string, string) (int32, error)) {
+func (f *AccessTokenStoreLookupFunc) SetDefaultHook(hook func(context.Context, string, AccessTokenLookupOptions) (*AccessToken, error)) {
 	f.defaultHook = hook
 }
 
@@ -1390,7 +1659,7 @@ func (f *AccessTokenStoreLookupFunc) SetDefaultHook(hook func(context.Context, s
 // Lookup method of the parent MockAccessTokenStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *AccessTokenStoreLookupFunc) PushHook(hook func(context.Context, string, string) (int32, error)) {
+func (f *AccessTokenStoreLookupFunc) PushHook(hook func(context.Context, string, AccessTokenLookupOptions) (*AccessToken, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1398,21 +1667,21 @@ func (f *AccessTokenStoreLookupFunc) PushHook(hook func(context.Context, string,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *AccessTokenStoreLookupFunc) SetDefaultReturn(r0 int32, r1 error) {
-	f.SetDefaultHook(func(context.Context, string, string) (int32, error) {
+func (f *AccessTokenStoreLookupFunc) SetDefaultReturn(r0 *AccessToken, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, AccessTokenLookupOptions) (*AccessToken, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *AccessTokenStoreLookupFunc) PushReturn(r0 int32, r1 error) {
-	f.PushHook(func(context.Context, string, string) (int32, error) {
+func (f *AccessTokenStoreLookupFunc) PushReturn(r0 *AccessToken, r1 error) {
+	f.PushHook(func(context.Context, string, AccessTokenLookupOptions) (*AccessToken, error) {
 		return r0, r1
 	})
 }
 
-func (f *AccessTokenStoreLookupFunc) nextHook() func(context.Context, string, string) (int32, error) {
+func (f *AccessTokenStoreLookupFunc) nextHook() func(context.Context, string, AccessTokenLookupOptions) (*AccessToken, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1453,10 +1722,10 @@ type AccessTokenStoreLookupFuncCall struct {
 	Arg1 string
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 string
+	Arg2 AccessTokenLookupOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int32
+	Result0 *AccessToken
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -1474,6 +1743,115 @@ func (c AccessTokenStoreLookupFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// AccessTokenStoreMarkExpiryNotifiedFunc describes the behavior when the
+// MarkExpiryNotified method of the parent MockAccessTokenStore instance is
+// invoked.
+type AccessTokenStoreMarkExpiryNotifiedFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []AccessTokenStoreMarkExpiryNotifiedFuncCall
+	mutex       sync.Mutex
+}
+
+// MarkExpiryNotified delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAccessTokenStore) MarkExpiryNotified(v0 context.Context, v1 int64) error {
+	r0 := m.MarkExpiryNotifiedFunc.nextHook()(v0, v1)
+	m.MarkExpiryNotifiedFunc.appendCall(AccessTokenStoreMarkExpiryNotifiedFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the MarkExpiryNotified
+// method of the parent MockAccessTokenStore instance is invoked and the
+// hook queue is empty.
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MarkExpiryNotified method of the parent MockAccessTokenStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) appendCall(r0 AccessTokenStoreMarkExpiryNotifiedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AccessTokenStoreMarkExpiryNotifiedFuncCall
+// objects describing the invocations of this function.
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) History() []AccessTokenStoreMarkExpiryNotifiedFuncCall {
+	f.mutex.Lock()
+	history := make([]AccessTokenStoreMarkExpiryNotifiedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AccessTokenStoreMarkExpiryNotifiedFuncCall is an object that describes an
+// invocation of method MarkExpiryNotified on an instance of
+// MockAccessTokenStore.
+type AccessTokenStoreMarkExpiryNotifiedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AccessTokenStoreMarkExpiryNotifiedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AccessTokenStoreMarkExpiryNotifiedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // AccessTokenStoreTransactFunc describes the behavior when the Transact
 // method of the parent MockAccessTokenStore instance is invoked.
 type AccessTokenStoreTransactFunc struct {
@@ -2782,6 +3160,9 @@ type MockDB struct {
 	// ReposFunc is an instance of a mock function object controlling the
 	// behavior of the method Repos.
 	ReposFunc *DBReposFunc
+	// RolesFunc is an instance of a mock function object controlling the
+	// behavior of the method Roles.
+	RolesFunc *DBRolesFunc
 	// SavedSearchesFunc is an instance of a mock function object
 	// controlling the behavior of the method SavedSearches.
 	SavedSearchesFunc *DBSavedSearchesFunc
@@ -2791,6 +3172,9 @@ type MockDB struct {
 	// SettingsFunc is an instance of a mock function object controlling the
 	// behavior of the method Settings.
 	SettingsFunc *DBSettingsFunc
+	// SubRepoPathRulesFunc is an instance of a mock function object
+	// controlling the behavior of the method SubRepoPathRules.
+	SubRepoPathRulesFunc *DBSubRepoPathRulesFunc
 	// SubRepoPermsFunc is an instance of a mock function object controlling
 	// the behavior of the method SubRepoPerms.
 	SubRepoPermsFunc *DBSubRepoPermsFunc
@@ -2812,6 +3196,9 @@ type MockDB struct {
 	// UserPublicReposFunc is an instance of a mock function object
 	// controlling the behavior of the method UserPublicRepos.
 	UserPublicReposFunc *DBUserPublicReposFunc
+	// UserSessionsFunc is an instance of a mock function object controlling
+	// the behavior of the method UserSessions.
+	UserSessionsFunc *DBUserSessionsFunc
 	// UsersFunc is an instance of a mock function object controlling the
 	// behavior of the method Users.
 	UsersFunc *DBUsersFunc
@@ -2924,6 +3311,11 @@ func NewMockDB() *MockDB {
 				return nil
 			},
 		},
+		RolesFunc: &DBRolesFunc{
+			defaultHook: func() RoleStore {
+				return nil
+			},
+		},
 		SavedSearchesFunc: &DBSavedSearchesFunc{
 			defaultHook: func() SavedSearchStore {
 				return nil
@@ -2939,6 +3331,11 @@ func NewMockDB() *MockDB {
 				return nil
 			},
 		},
+		SubRepoPathRulesFunc: &DBSubRepoPathRulesFunc{
+			defaultHook: func() SubRepoPathRuleStore {
+				return nil
+			},
+		},
 		SubRepoPermsFunc: &DBSubRepoPermsFunc{
 			defaultHook: func() SubRepoPermsStore {
 				return nil
@@ -2974,6 +3371,11 @@ func NewMockDB() *MockDB {
 				return nil
 			},
 		},
+		UserSessionsFunc: &DBUserSessionsFunc{
+			defaultHook: func() UserSessionStore {
+				return nil
+			},
+		},
 		UsersFunc: &DBUsersFunc{
 			defaultHook: func() UserStore {
 				return nil
@@ -3091,6 +3493,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.Repos")
 			},
 		},
+		RolesFunc: &DBRolesFunc{
+			defaultHook: func() RoleStore {
+				panic("unexpected invocation of MockDB.Roles")
+			},
+		},
 		SavedSearchesFunc: &DBSavedSearchesFunc{
 			defaultHook: func() SavedSearchStore {
 				panic("unexpected invocation of MockDB.SavedSearches")
@@ -3106,6 +3513,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.Settings")
 			},
 		},
+		SubRepoPathRulesFunc: &DBSubRepoPathRulesFunc{
+			defaultHook: func() SubRepoPathRuleStore {
+				panic("unexpected invocation of MockDB.SubRepoPathRules")
+			},
+		},
 		SubRepoPermsFunc: &DBSubRepoPermsFunc{
 			defaultHook: func() SubRepoPermsStore {
 				panic("unexpected invocation of MockDB.SubRepoPerms")
@@ -3141,6 +3553,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.UserPublicRepos")
 			},
 		},
+		UserSessionsFunc: &DBUserSessionsFunc{
+			defaultHook: func() UserSessionStore {
+				panic("unexpected invocation of MockDB.UserSessions")
+			},
+		},
 		UsersFunc: &DBUsersFunc{
 			defaultHook: func() UserStore {
 				panic("unexpected invocation of MockDB.Users")
@@ -3218,6 +3635,9 @@ func NewMockDBFrom(i DB) *MockDB {
 		ReposFunc: &DBReposFunc{
 			defaultHook: i.Repos,
 		},
+		RolesFunc: &DBRolesFunc{
+			defaultHook: i.Roles,
+		},
 		SavedSearchesFunc: &DBSavedSearchesFunc{
 			defaultHook: i.SavedSearches,
 		},
@@ -3227,6 +3647,9 @@ func NewMockDBFrom(i DB) *MockDB {
 		SettingsFunc: &DBSettingsFunc{
 			defaultHook: i.Settings,
 		},
+		SubRepoPathRulesFunc: &DBSubRepoPathRulesFunc{
+			defaultHook: i.SubRepoPathRules,
+		},
 		SubRepoPermsFunc: &DBSubRepoPermsFunc{
 			defaultHook: i.SubRepoPerms,
 		},
@@ -3248,6 +3671,9 @@ func NewMockDBFrom(i DB) *MockDB {
 		UserPublicReposFunc: &DBUserPublicReposFunc{
 			defaultHook: i.UserPublicRepos,
 		},
+		UserSessionsFunc: &DBUserSessionsFunc{
+			defaultHook: i.UserSessions,
+		},
 		UsersFunc: &DBUsersFunc{
 			defaultHook: i.Users,
 		},
@@ -5298,6 +5724,105 @@ func (c DBReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBRolesFunc describes the behavior when the Roles method of the parent
+// MockDB instance is invoked.
+type DBRolesFunc struct {
+	defaultHook func() RoleStore
+	hooks       []func() RoleStore
+	history     []DBRolesFuncCall
+	mutex       sync.Mutex
+}
+
+// Roles delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockDB) Roles() RoleStore {
+	r0 := m.RolesFunc.nextHook()()
+	m.RolesFunc.appendCall(DBRolesFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Roles method of the
+// parent MockDB instance is invoked and the hook queue is empty.
+func (f *DBRolesFunc) SetDefaultHook(hook func() RoleStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Roles method of the parent MockDB instance invokes the hook at the front
+// of the queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *DBRolesFunc) PushHook(hook func() RoleStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBRolesFunc) SetDefaultReturn(r0 RoleStore) {
+	f.SetDefaultHook(func() RoleStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBRolesFunc) PushReturn(r0 RoleStore) {
+	f.PushHook(func() RoleStore {
+		return r0
+	})
+}
+
+func (f *DBRolesFunc) nextHook() func() RoleStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBRolesFunc) appendCall(r0 DBRolesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBRolesFuncCall objects describing the
+// invocations of this function.
+func (f *DBRolesFunc) History() []DBRolesFuncCall {
+	f.mutex.Lock()
+	history := make([]DBRolesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBRolesFuncCall is an object that describes an invocation of method Roles
+// on an instance of MockDB.
+type DBRolesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 RoleStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBRolesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBRolesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBSavedSearchesFunc describes the behavior when the SavedSearches method
 // of the parent MockDB instance is invoked.
 type DBSavedSearchesFunc struct {
@@ -5596,6 +6121,106 @@ func (c DBSettingsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBSubRepoPathRulesFunc describes the behavior when the SubRepoPathRules
+// method of the parent MockDB instance is invoked.
+type DBSubRepoPathRulesFunc struct {
+	defaultHook func() SubRepoPathRuleStore
+	hooks       []func() SubRepoPathRuleStore
+	history     []DBSubRepoPathRulesFuncCall
+	mutex       sync.Mutex
+}
+
+// SubRepoPathRules delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDB) SubRepoPathRules() SubRepoPathRuleStore {
+	r0 := m.SubRepoPathRulesFunc.nextHook()()
+	m.SubRepoPathRulesFunc.appendCall(DBSubRepoPathRulesFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SubRepoPathRules
+// method of the parent MockDB instance is invoked and the hook queue is
+// empty.
+func (f *DBSubRepoPathRulesFunc) SetDefaultHook(hook func() SubRepoPathRuleStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SubRepoPathRules method of the parent MockDB instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBSubRepoPathRulesFunc) PushHook(hook func() SubRepoPathRuleStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBSubRepoPathRulesFunc) SetDefaultReturn(r0 SubRepoPathRuleStore) {
+	f.SetDefaultHook(func() SubRepoPathRuleStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBSubRepoPathRulesFunc) PushReturn(r0 SubRepoPathRuleStore) {
+	f.PushHook(func() SubRepoPathRuleStore {
+		return r0
+	})
+}
+
+func (f *DBSubRepoPathRulesFunc) nextHook() func() SubRepoPathRuleStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBSubRepoPathRulesFunc) appendCall(r0 DBSubRepoPathRulesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBSubRepoPathRulesFuncCall objects
+// describing the invocations of this function.
+func (f *DBSubRepoPathRulesFunc) History() []DBSubRepoPathRulesFuncCall {
+	f.mutex.Lock()
+	history := make([]DBSubRepoPathRulesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBSubRepoPathRulesFuncCall is an object that describes an invocation of
+// method SubRepoPathRules on an instance of MockDB.
+type DBSubRepoPathRulesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 SubRepoPathRuleStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBSubRepoPathRulesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBSubRepoPathRulesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBSubRepoPermsFunc describes the behavior when the SubRepoPerms method of
 // the parent MockDB instance is invoked.
 type DBSubRepoPermsFunc struct {
@@ -6302,6 +6927,105 @@ func (c DBUserPublicReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBUserSessionsFunc describes the behavior when the UserSessions method of
+// the parent MockDB instance is invoked.
+type DBUserSessionsFunc struct {
+	defaultHook func() UserSessionStore
+	hooks       []func() UserSessionStore
+	history     []DBUserSessionsFuncCall
+	mutex       sync.Mutex
+}
+
+// UserSessions delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDB) UserSessions() UserSessionStore {
+	r0 := m.UserSessionsFunc.nextHook()()
+	m.UserSessionsFunc.appendCall(DBUserSessionsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UserSessions method
+// of the parent MockDB instance is invoked and the hook queue is empty.
+func (f *DBUserSessionsFunc) SetDefaultHook(hook func() UserSessionStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UserSessions method of the parent MockDB instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *DBUserSessionsFunc) PushHook(hook func() UserSessionStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBUserSessionsFunc) SetDefaultReturn(r0 UserSessionStore) {
+	f.SetDefaultHook(func() UserSessionStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBUserSessionsFunc) PushReturn(r0 UserSessionStore) {
+	f.PushHook(func() UserSessionStore {
+		return r0
+	})
+}
+
+func (f *DBUserSessionsFunc) nextHook() func() UserSessionStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBUserSessionsFunc) appendCall(r0 DBUserSessionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBUserSessionsFuncCall objects describing
+// the invocations of this function.
+func (f *DBUserSessionsFunc) History() []DBUserSessionsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBUserSessionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBUserSessionsFuncCall is an object that describes an invocation of
+// method UserSessions on an instance of MockDB.
+type DBUserSessionsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 UserSessionStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBUserSessionsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBUserSessionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBUsersFunc describes the behavior when the Users method of the parent
 // MockDB instance is invoked.
 type DBUsersFunc struct {
@@ -24612,242 +25336,209 @@ func (c RepoStoreWithFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// MockSavedSearchStore is a mock implementation of the SavedSearchStore
-// interface (from the package
+// MockRoleStore is a mock implementation of the
+// RoleStore interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/database) used for unit
 // testing.
-type MockSavedSearchStore struct {
-	// CreateFunc is an instance of a mock function object controlling the
-	// behavior of the method Create.
-	CreateFunc *SavedSearchStoreCreateFunc
-	// DeleteFunc is an instance of a mock function object controlling the
-	// behavior of the method Delete.
-	DeleteFunc *SavedSearchStoreDeleteFunc
+type MockRoleStore struct {
+	// AssignFunc is an instance of a mock function object controlling the
+	// behavior of the method Assign.
+	AssignFunc *RoleStoreAssignFunc
 	// GetByIDFunc is an instance of a mock function object controlling the
 	// behavior of the method GetByID.
-	GetByIDFunc *SavedSearchStoreGetByIDFunc
+	GetByIDFunc *RoleStoreGetByIDFunc
+	// GetByNameFunc is an instance of a mock function object controlling
+	// the behavior of the method GetByName.
+	GetByNameFunc *RoleStoreGetByNameFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
-	HandleFunc *SavedSearchStoreHandleFunc
-	// IsEmptyFunc is an instance of a mock function object controlling the
-	// behavior of the method IsEmpty.
-	IsEmptyFunc *SavedSearchStoreIsEmptyFunc
-	// ListAllFunc is an instance of a mock function object controlling the
-	// behavior of the method ListAll.
-	ListAllFunc *SavedSearchStoreListAllFunc
-	// ListSavedSearchesByOrgIDFunc is an instance of a mock function object
-	// controlling the behavior of the method ListSavedSearchesByOrgID.
-	ListSavedSearchesByOrgIDFunc *SavedSearchStoreListSavedSearchesByOrgIDFunc
-	// ListSavedSearchesByUserIDFunc is an instance of a mock function
-	// object controlling the behavior of the method
-	// ListSavedSearchesByUserID.
-	ListSavedSearchesByUserIDFunc *SavedSearchStoreListSavedSearchesByUserIDFunc
-	// TransactFunc is an instance of a mock function object controlling the
-	// behavior of the method Transact.
-	TransactFunc *SavedSearchStoreTransactFunc
-	// UpdateFunc is an instance of a mock function object controlling the
-	// behavior of the method Update.
-	UpdateFunc *SavedSearchStoreUpdateFunc
-	// WithFunc is an instance of a mock function object controlling the
-	// behavior of the method With.
-	WithFunc *SavedSearchStoreWithFunc
-}
-
-// NewMockSavedSearchStore creates a new mock of the SavedSearchStore
+	HandleFunc *RoleStoreHandleFunc
+	// ListFunc is an instance of a mock function object controlling the
+	// behavior of the method List.
+	ListFunc *RoleStoreListFunc
+	// ListAssignmentsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListAssignments.
+	ListAssignmentsFunc *RoleStoreListAssignmentsFunc
+	// ListPermissionsForUserFunc is an instance of a mock function object
+	// controlling the behavior of the method ListPermissionsForUser.
+	ListPermissionsForUserFunc *RoleStoreListPermissionsForUserFunc
+	// UnassignFunc is an instance of a mock function object controlling the
+	// behavior of the method Unassign.
+	UnassignFunc *RoleStoreUnassignFunc
+	// UserHasPermissionFunc is an instance of a mock function object
+	// controlling the behavior of the method UserHasPermission.
+	UserHasPermissionFunc *RoleStoreUserHasPermissionFunc
+}
+
+// NewMockRoleStore creates a new mock of the RoleStore
 // interface. All methods return zero values for all results, unless
 // overwritten.
-func NewMockSavedSearchStore() *MockSavedSearchStore {
-	return &MockSavedSearchStore{
-		CreateFunc: &SavedSearchStoreCreateFunc{
-			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-				return nil, nil
+func NewMockRoleStore() *MockRoleStore {
+	return &MockRoleStore{
+		AssignFunc: &RoleStoreAssignFunc{
+			defaultHook: func(context.Context, *RoleAssignment) error {
+				return nil
 			},
 		},
-		DeleteFunc: &SavedSearchStoreDeleteFunc{
-			defaultHook: func(context.Context, int32) error {
-				return nil
+		GetByIDFunc: &RoleStoreGetByIDFunc{
+			defaultHook: func(context.Context, int32) (*Role, error) {
+				return nil, nil
 			},
 		},
-		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
-			defaultHook: func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+		GetByNameFunc: &RoleStoreGetByNameFunc{
+			defaultHook: func(context.Context, string) (*Role, error) {
 				return nil, nil
 			},
 		},
-		HandleFunc: &SavedSearchStoreHandleFunc{
+		HandleFunc: &RoleStoreHandleFunc{
 			defaultHook: func() *basestore.TransactableHandle {
 				return nil
 			},
 		},
-		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
-			defaultHook: func(context.Context) (bool, error) {
-				return false, nil
-			},
-		},
-		ListAllFunc: &SavedSearchStoreListAllFunc{
-			defaultHook: func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
-				return nil, nil
-			},
-		},
-		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
-			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
+		ListFunc: &RoleStoreListFunc{
+			defaultHook: func(context.Context) ([]*Role, error) {
 				return nil, nil
 			},
 		},
-		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
-			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
+		ListAssignmentsFunc: &RoleStoreListAssignmentsFunc{
+			defaultHook: func(context.Context, ListRoleAssignmentsOpts) ([]*RoleAssignment, error) {
 				return nil, nil
 			},
 		},
-		TransactFunc: &SavedSearchStoreTransactFunc{
-			defaultHook: func(context.Context) (SavedSearchStore, error) {
+		ListPermissionsForUserFunc: &RoleStoreListPermissionsForUserFunc{
+			defaultHook: func(context.Context, int32) ([]Permission, error) {
 				return nil, nil
 			},
 		},
-		UpdateFunc: &SavedSearchStoreUpdateFunc{
-			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-				return nil, nil
+		UnassignFunc: &RoleStoreUnassignFunc{
+			defaultHook: func(context.Context, *RoleAssignment) error {
+				return nil
 			},
 		},
-		WithFunc: &SavedSearchStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) SavedSearchStore {
-				return nil
+		UserHasPermissionFunc: &RoleStoreUserHasPermissionFunc{
+			defaultHook: func(context.Context, int32, Permission) (bool, error) {
+				return false, nil
 			},
 		},
 	}
 }
 
-// NewStrictMockSavedSearchStore creates a new mock of the SavedSearchStore
-// interface. All methods panic on invocation, unless overwritten.
-func NewStrictMockSavedSearchStore() *MockSavedSearchStore {
-	return &MockSavedSearchStore{
-		CreateFunc: &SavedSearchStoreCreateFunc{
-			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-				panic("unexpected invocation of MockSavedSearchStore.Create")
+// NewStrictMockRoleStore creates a new mock of the
+// RoleStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockRoleStore() *MockRoleStore {
+	return &MockRoleStore{
+		AssignFunc: &RoleStoreAssignFunc{
+			defaultHook: func(context.Context, *RoleAssignment) error {
+				panic("unexpected invocation of MockRoleStore.Assign")
 			},
 		},
-		DeleteFunc: &SavedSearchStoreDeleteFunc{
-			defaultHook: func(context.Context, int32) error {
-				panic("unexpected invocation of MockSavedSearchStore.Delete")
+		GetByIDFunc: &RoleStoreGetByIDFunc{
+			defaultHook: func(context.Context, int32) (*Role, error) {
+				panic("unexpected invocation of MockRoleStore.GetByID")
 			},
 		},
-		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
-			defaultHook: func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
-				panic("unexpected invocation of MockSavedSearchStore.GetByID")
+		GetByNameFunc: &RoleStoreGetByNameFunc{
+			defaultHook: func(context.Context, string) (*Role, error) {
+				panic("unexpected invocation of MockRoleStore.GetByName")
 			},
 		},
-		HandleFunc: &SavedSearchStoreHandleFunc{
+		HandleFunc: &RoleStoreHandleFunc{
 			defaultHook: func() *basestore.TransactableHandle {
-				panic("unexpected invocation of MockSavedSearchStore.Handle")
-			},
-		},
-		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
-			defaultHook: func(context.Context) (bool, error) {
-				panic("unexpected invocation of MockSavedSearchStore.IsEmpty")
-			},
-		},
-		ListAllFunc: &SavedSearchStoreListAllFunc{
-			defaultHook: func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
-				panic("unexpected invocation of MockSavedSearchStore.ListAll")
+				panic("unexpected invocation of MockRoleStore.Handle")
 			},
 		},
-		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
-			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
-				panic("unexpected invocation of MockSavedSearchStore.ListSavedSearchesByOrgID")
+		ListFunc: &RoleStoreListFunc{
+			defaultHook: func(context.Context) ([]*Role, error) {
+				panic("unexpected invocation of MockRoleStore.List")
 			},
 		},
-		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
-			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
-				panic("unexpected invocation of MockSavedSearchStore.ListSavedSearchesByUserID")
+		ListAssignmentsFunc: &RoleStoreListAssignmentsFunc{
+			defaultHook: func(context.Context, ListRoleAssignmentsOpts) ([]*RoleAssignment, error) {
+				panic("unexpected invocation of MockRoleStore.ListAssignments")
 			},
 		},
-		TransactFunc: &SavedSearchStoreTransactFunc{
-			defaultHook: func(context.Context) (SavedSearchStore, error) {
-				panic("unexpected invocation of MockSavedSearchStore.Transact")
+		ListPermissionsForUserFunc: &RoleStoreListPermissionsForUserFunc{
+			defaultHook: func(context.Context, int32) ([]Permission, error) {
+				panic("unexpected invocation of MockRoleStore.ListPermissionsForUser")
 			},
 		},
-		UpdateFunc: &SavedSearchStoreUpdateFunc{
-			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-				panic("unexpected invocation of MockSavedSearchStore.Update")
+		UnassignFunc: &RoleStoreUnassignFunc{
+			defaultHook: func(context.Context, *RoleAssignment) error {
+				panic("unexpected invocation of MockRoleStore.Unassign")
 			},
 		},
-		WithFunc: &SavedSearchStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) SavedSearchStore {
-				panic("unexpected invocation of MockSavedSearchStore.With")
+		UserHasPermissionFunc: &RoleStoreUserHasPermissionFunc{
+			defaultHook: func(context.Context, int32, Permission) (bool, error) {
+				panic("unexpected invocation of MockRoleStore.UserHasPermission")
 			},
 		},
 	}
 }
 
-// NewMockSavedSearchStoreFrom creates a new mock of the
-// MockSavedSearchStore interface. All methods delegate to the given
+// NewMockRoleStoreFrom creates a new mock of the
+// MockRoleStore interface. All methods delegate to the given
 // implementation, unless overwritten.
-func NewMockSavedSearchStoreFrom(i SavedSearchStore) *MockSavedSearchStore {
-	return &MockSavedSearchStore{
-		CreateFunc: &SavedSearchStoreCreateFunc{
-			defaultHook: i.Create,
-		},
-		DeleteFunc: &SavedSearchStoreDeleteFunc{
-			defaultHook: i.Delete,
+func NewMockRoleStoreFrom(i RoleStore) *MockRoleStore {
+	return &MockRoleStore{
+		AssignFunc: &RoleStoreAssignFunc{
+			defaultHook: i.Assign,
 		},
-		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
+		GetByIDFunc: &RoleStoreGetByIDFunc{
 			defaultHook: i.GetByID,
 		},
-		HandleFunc: &SavedSearchStoreHandleFunc{
-			defaultHook: i.Handle,
-		},
-		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
-			defaultHook: i.IsEmpty,
+		GetByNameFunc: &RoleStoreGetByNameFunc{
+			defaultHook: i.GetByName,
 		},
-		ListAllFunc: &SavedSearchStoreListAllFunc{
-			defaultHook: i.ListAll,
+		HandleFunc: &RoleStoreHandleFunc{
+			defaultHook: i.Handle,
 		},
-		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
-			defaultHook: i.ListSavedSearchesByOrgID,
+		ListFunc: &RoleStoreListFunc{
+			defaultHook: i.List,
 		},
-		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
-			defaultHook: i.ListSavedSearchesByUserID,
+		ListAssignmentsFunc: &RoleStoreListAssignmentsFunc{
+			defaultHook: i.ListAssignments,
 		},
-		TransactFunc: &SavedSearchStoreTransactFunc{
-			defaultHook: i.Transact,
+		ListPermissionsForUserFunc: &RoleStoreListPermissionsForUserFunc{
+			defaultHook: i.ListPermissionsForUser,
 		},
-		UpdateFunc: &SavedSearchStoreUpdateFunc{
-			defaultHook: i.Update,
+		UnassignFunc: &RoleStoreUnassignFunc{
+			defaultHook: i.Unassign,
 		},
-		WithFunc: &SavedSearchStoreWithFunc{
-			defaultHook: i.With,
+		UserHasPermissionFunc: &RoleStoreUserHasPermissionFunc{
+			defaultHook: i.UserHasPermission,
 		},
 	}
 }
 
-// SavedSearchStoreCreateFunc describes the behavior when the Create method
-// of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreCreateFunc struct {
-	defaultHook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
-	hooks       []func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
-	history     []SavedSearchStoreCreateFuncCall
+// RoleStoreAssignFunc describes the behavior when the Assign method of the
+// parent MockRoleStore instance is invoked.
+type RoleStoreAssignFunc struct {
+	defaultHook func(context.Context, *RoleAssignment) error
+	hooks       []func(context.Context, *RoleAssignment) error
+	history     []RoleStoreAssignFuncCall
 	mutex       sync.Mutex
 }
 
-// Create delegates to the next hook function in the queue and stores the
+// Assign delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Create(v0 context.Context, v1 *types.SavedSearch) (*types.SavedSearch, error) {
-	r0, r1 := m.CreateFunc.nextHook()(v0, v1)
-	m.CreateFunc.appendCall(SavedSearchStoreCreateFuncCall{v0, v1, r0, r1})
-	return r0, r1
+func (m *MockRoleStore) Assign(v0 context.Context, v1 *RoleAssignment) error {
+	r0 := m.AssignFunc.nextHook()(v0, v1)
+	m.AssignFunc.appendCall(RoleStoreAssignFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Create method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreCreateFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+// SetDefaultHook sets function that is called when the Assign method of the
+// parent MockRoleStore instance is invoked and the hook queue is empty.
+func (f *RoleStoreAssignFunc) SetDefaultHook(hook func(context.Context, *RoleAssignment) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Create method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreCreateFunc) PushHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+// Assign method of the parent MockRoleStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RoleStoreAssignFunc) PushHook(hook func(context.Context, *RoleAssignment) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -24855,21 +25546,21 @@ func (f *SavedSearchStoreCreateFunc) PushHook(hook func(context.Context, *types.
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SavedSearchStoreCreateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-		return r0, r1
+func (f *RoleStoreAssignFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *RoleAssignment) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SavedSearchStoreCreateFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-		return r0, r1
+func (f *RoleStoreAssignFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *RoleAssignment) error {
+		return r0
 	})
 }
 
-func (f *SavedSearchStoreCreateFunc) nextHook() func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+func (f *RoleStoreAssignFunc) nextHook() func(context.Context, *RoleAssignment) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -24882,81 +25573,77 @@ func (f *SavedSearchStoreCreateFunc) nextHook() func(context.Context, *types.Sav
 	return hook
 }
 
-func (f *SavedSearchStoreCreateFunc) appendCall(r0 SavedSearchStoreCreateFuncCall) {
+func (f *RoleStoreAssignFunc) appendCall(r0 RoleStoreAssignFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreCreateFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreCreateFunc) History() []SavedSearchStoreCreateFuncCall {
+// History returns a sequence of RoleStoreAssignFuncCall objects describing
+// the invocations of this function.
+func (f *RoleStoreAssignFunc) History() []RoleStoreAssignFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreCreateFuncCall, len(f.history))
+	history := make([]RoleStoreAssignFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreCreateFuncCall is an object that describes an invocation
-// of method Create on an instance of MockSavedSearchStore.
-type SavedSearchStoreCreateFuncCall struct {
+// RoleStoreAssignFuncCall is an object that describes an invocation of
+// method Assign on an instance of MockRoleStore.
+type RoleStoreAssignFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *types.SavedSearch
+	Arg1 *RoleAssignment
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.SavedSearch
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreCreateFuncCall) Args() []interface{} {
+func (c RoleStoreAssignFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreCreateFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c RoleStoreAssignFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SavedSearchStoreDeleteFunc describes the behavior when the Delete method
-// of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreDeleteFunc struct {
-	defaultHook func(context.Context, int32) error
-	hooks       []func(context.Context, int32) error
-	history     []SavedSearchStoreDeleteFuncCall
+// RoleStoreGetByIDFunc describes the behavior when the GetByID method of
+// the parent MockRoleStore instance is invoked.
+type RoleStoreGetByIDFunc struct {
+	defaultHook func(context.Context, int32) (*Role, error)
+	hooks       []func(context.Context, int32) (*Role, error)
+	history     []RoleStoreGetByIDFuncCall
 	mutex       sync.Mutex
 }
 
-// Delete delegates to the next hook function in the queue and stores the
+// GetByID delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Delete(v0 context.Context, v1 int32) error {
-	r0 := m.DeleteFunc.nextHook()(v0, v1)
-	m.DeleteFunc.appendCall(SavedSearchStoreDeleteFuncCall{v0, v1, r0})
-	return r0
+func (m *MockRoleStore) GetByID(v0 context.Context, v1 int32) (*Role, error) {
+	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
+	m.GetByIDFunc.appendCall(RoleStoreGetByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Delete method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int32) error) {
+// SetDefaultHook sets function that is called when the GetByID method of
+// the parent MockRoleStore instance is invoked and the hook queue is empty.
+func (f *RoleStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int32) (*Role, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Delete method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreDeleteFunc) PushHook(hook func(context.Context, int32) error) {
+// GetByID method of the parent MockRoleStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RoleStoreGetByIDFunc) PushHook(hook func(context.Context, int32) (*Role, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -24964,21 +25651,21 @@ func (f *SavedSearchStoreDeleteFunc) PushHook(hook func(context.Context, int32)
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SavedSearchStoreDeleteFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32) error {
-		return r0
+func (f *RoleStoreGetByIDFunc) SetDefaultReturn(r0 *Role, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*Role, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SavedSearchStoreDeleteFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32) error {
-		return r0
+func (f *RoleStoreGetByIDFunc) PushReturn(r0 *Role, r1 error) {
+	f.PushHook(func(context.Context, int32) (*Role, error) {
+		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreDeleteFunc) nextHook() func(context.Context, int32) error {
+func (f *RoleStoreGetByIDFunc) nextHook() func(context.Context, int32) (*Role, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -24991,26 +25678,26 @@ func (f *SavedSearchStoreDeleteFunc) nextHook() func(context.Context, int32) err
 	return hook
 }
 
-func (f *SavedSearchStoreDeleteFunc) appendCall(r0 SavedSearchStoreDeleteFuncCall) {
+func (f *RoleStoreGetByIDFunc) appendCall(r0 RoleStoreGetByIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreDeleteFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreDeleteFunc) History() []SavedSearchStoreDeleteFuncCall {
+// History returns a sequence of RoleStoreGetByIDFuncCall objects describing
+// the invocations of this function.
+func (f *RoleStoreGetByIDFunc) History() []RoleStoreGetByIDFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreDeleteFuncCall, len(f.history))
+	history := make([]RoleStoreGetByIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreDeleteFuncCall is an object that describes an invocation
-// of method Delete on an instance of MockSavedSearchStore.
-type SavedSearchStoreDeleteFuncCall struct {
+// RoleStoreGetByIDFuncCall is an object that describes an invocation of
+// method GetByID on an instance of MockRoleStore.
+type RoleStoreGetByIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -25019,50 +25706,52 @@ type SavedSearchStoreDeleteFuncCall struct {
 	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *Role
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreDeleteFuncCall) Args() []interface{} {
+func (c RoleStoreGetByIDFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreDeleteFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c RoleStoreGetByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreGetByIDFunc describes the behavior when the GetByID
-// method of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreGetByIDFunc struct {
-	defaultHook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
-	hooks       []func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
-	history     []SavedSearchStoreGetByIDFuncCall
+// RoleStoreGetByNameFunc describes the behavior when the GetByName method
+// of the parent MockRoleStore instance is invoked.
+type RoleStoreGetByNameFunc struct {
+	defaultHook func(context.Context, string) (*Role, error)
+	hooks       []func(context.Context, string) (*Role, error)
+	history     []RoleStoreGetByNameFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByID delegates to the next hook function in the queue and stores the
+// GetByName delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) GetByID(v0 context.Context, v1 int32) (*api.SavedQuerySpecAndConfig, error) {
-	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
-	m.GetByIDFunc.appendCall(SavedSearchStoreGetByIDFuncCall{v0, v1, r0, r1})
+func (m *MockRoleStore) GetByName(v0 context.Context, v1 string) (*Role, error) {
+	r0, r1 := m.GetByNameFunc.nextHook()(v0, v1)
+	m.GetByNameFunc.appendCall(RoleStoreGetByNameFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByID method of
-// the parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)) {
+// SetDefaultHook sets function that is called when the GetByName method of
+// the parent MockRoleStore instance is invoked and the hook queue is empty.
+func (f *RoleStoreGetByNameFunc) SetDefaultHook(hook func(context.Context, string) (*Role, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByID method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreGetByIDFunc) PushHook(hook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)) {
+// GetByName method of the parent MockRoleStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RoleStoreGetByNameFunc) PushHook(hook func(context.Context, string) (*Role, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -25070,21 +25759,21 @@ func (f *SavedSearchStoreGetByIDFunc) PushHook(hook func(context.Context, int32)
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SavedSearchStoreGetByIDFunc) SetDefaultReturn(r0 *api.SavedQuerySpecAndConfig, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+func (f *RoleStoreGetByNameFunc) SetDefaultReturn(r0 *Role, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (*Role, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SavedSearchStoreGetByIDFunc) PushReturn(r0 *api.SavedQuerySpecAndConfig, r1 error) {
-	f.PushHook(func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+func (f *RoleStoreGetByNameFunc) PushReturn(r0 *Role, r1 error) {
+	f.PushHook(func(context.Context, string) (*Role, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreGetByIDFunc) nextHook() func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+func (f *RoleStoreGetByNameFunc) nextHook() func(context.Context, string) (*Role, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -25097,35 +25786,35 @@ func (f *SavedSearchStoreGetByIDFunc) nextHook() func(context.Context, int32) (*
 	return hook
 }
 
-func (f *SavedSearchStoreGetByIDFunc) appendCall(r0 SavedSearchStoreGetByIDFuncCall) {
+func (f *RoleStoreGetByNameFunc) appendCall(r0 RoleStoreGetByNameFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreGetByIDFuncCall objects
+// History returns a sequence of RoleStoreGetByNameFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreGetByIDFunc) History() []SavedSearchStoreGetByIDFuncCall {
+func (f *RoleStoreGetByNameFunc) History() []RoleStoreGetByNameFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreGetByIDFuncCall, len(f.history))
+	history := make([]RoleStoreGetByNameFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreGetByIDFuncCall is an object that describes an invocation
-// of method GetByID on an instance of MockSavedSearchStore.
-type SavedSearchStoreGetByIDFuncCall struct {
+// RoleStoreGetByNameFuncCall is an object that describes an invocation of
+// method GetByName on an instance of MockRoleStore.
+type RoleStoreGetByNameFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *api.SavedQuerySpecAndConfig
+	Result0 *Role
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -25133,45 +25822,45 @@ type SavedSearchStoreGetByIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreGetByIDFuncCall) Args() []interface{} {
+func (c RoleStoreGetByNameFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreGetByIDFuncCall) Results() []interface{} {
+func (c RoleStoreGetByNameFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreHandleFunc describes the behavior when the Handle method
-// of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreHandleFunc struct {
+// RoleStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockRoleStore instance is invoked.
+type RoleStoreHandleFunc struct {
 	defaultHook func() *basestore.TransactableHandle
 	hooks       []func() *basestore.TransactableHandle
-	history     []SavedSearchStoreHandleFuncCall
+	history     []RoleStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
 // Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Handle() *basestore.TransactableHandle {
+func (m *MockRoleStore) Handle() *basestore.TransactableHandle {
 	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(SavedSearchStoreHandleFuncCall{r0})
+	m.HandleFunc.appendCall(RoleStoreHandleFuncCall{r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the Handle method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
+// parent MockRoleStore instance is invoked and the hook queue is
 // empty.
-func (f *SavedSearchStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
+func (f *RoleStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockSavedSearchStore instance invokes the
+// Handle method of the parent MockRoleStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *SavedSearchStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
+func (f *RoleStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -25179,7 +25868,7 @@ func (f *SavedSearchStoreHandleFunc) PushHook(hook func() *basestore.Transactabl
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SavedSearchStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+func (f *RoleStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
 	f.SetDefaultHook(func() *basestore.TransactableHandle {
 		return r0
 	})
@@ -25187,13 +25876,13 @@ func (f *SavedSearchStoreHandleFunc) SetDefaultReturn(r0 *basestore.Transactable
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SavedSearchStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+func (f *RoleStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
 	f.PushHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
-func (f *SavedSearchStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
+func (f *RoleStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -25206,26 +25895,26 @@ func (f *SavedSearchStoreHandleFunc) nextHook() func() *basestore.TransactableHa
 	return hook
 }
 
-func (f *SavedSearchStoreHandleFunc) appendCall(r0 SavedSearchStoreHandleFuncCall) {
+func (f *RoleStoreHandleFunc) appendCall(r0 RoleStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreHandleFuncCall objects
+// History returns a sequence of RoleStoreHandleFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreHandleFunc) History() []SavedSearchStoreHandleFuncCall {
+func (f *RoleStoreHandleFunc) History() []RoleStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreHandleFuncCall, len(f.history))
+	history := make([]RoleStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreHandleFuncCall is an object that describes an invocation
-// of method Handle on an instance of MockSavedSearchStore.
-type SavedSearchStoreHandleFuncCall struct {
+// RoleStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockRoleStore.
+type RoleStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *basestore.TransactableHandle
@@ -25233,45 +25922,44 @@ type SavedSearchStoreHandleFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreHandleFuncCall) Args() []interface{} {
+func (c RoleStoreHandleFuncCall) Args() []interface{} {
 	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreHandleFuncCall) Results() []interface{} {
+func (c RoleStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// SavedSearchStoreIsEmptyFunc describes the behavior when the IsEmpty
-// method of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreIsEmptyFunc struct {
-	defaultHook func(context.Context) (bool, error)
-	hooks       []func(context.Context) (bool, error)
-	history     []SavedSearchStoreIsEmptyFuncCall
+// RoleStoreListFunc describes the behavior when the List method of the
+// parent MockRoleStore instance is invoked.
+type RoleStoreListFunc struct {
+	defaultHook func(context.Context) ([]*Role, error)
+	hooks       []func(context.Context) ([]*Role, error)
+	history     []RoleStoreListFuncCall
 	mutex       sync.Mutex
 }
 
-// IsEmpty delegates to the next hook function in the queue and stores the
+// List delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) IsEmpty(v0 context.Context) (bool, error) {
-	r0, r1 := m.IsEmptyFunc.nextHook()(v0)
-	m.IsEmptyFunc.appendCall(SavedSearchStoreIsEmptyFuncCall{v0, r0, r1})
+func (m *MockRoleStore) List(v0 context.Context) ([]*Role, error) {
+	r0, r1 := m.ListFunc.nextHook()(v0)
+	m.ListFunc.appendCall(RoleStoreListFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the IsEmpty method of
-// the parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreIsEmptyFunc) SetDefaultHook(hook func(context.Context) (bool, error)) {
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockRoleStore instance is invoked and the hook queue is empty.
+func (f *RoleStoreListFunc) SetDefaultHook(hook func(context.Context) ([]*Role, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// IsEmpty method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreIsEmptyFunc) PushHook(hook func(context.Context) (bool, error)) {
+// List method of the parent MockRoleStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *RoleStoreListFunc) PushHook(hook func(context.Context) ([]*Role, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -25279,21 +25967,21 @@ func (f *SavedSearchStoreIsEmptyFunc) PushHook(hook func(context.Context) (bool,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SavedSearchStoreIsEmptyFunc) SetDefaultReturn(r0 bool, r1 error) {
-	f.SetDefaultHook(func(context.Context) (bool, error) {
+func (f *RoleStoreListFunc) SetDefaultReturn(r0 []*Role, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]*Role, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SavedSearchStoreIsEmptyFunc) PushReturn(r0 bool, r1 error) {
-	f.PushHook(func(context.Context) (bool, error) {
+func (f *RoleStoreListFunc) PushReturn(r0 []*Role, r1 error) {
+	f.PushHook(func(context.Context) ([]*Role, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreIsEmptyFunc) nextHook() func(context.Context) (bool, error) {
+func (f *RoleStoreListFunc) nextHook() func(context.Context) ([]*Role, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -25306,32 +25994,32 @@ func (f *SavedSearchStoreIsEmptyFunc) nextHook() func(context.Context) (bool, er
 	return hook
 }
 
-func (f *SavedSearchStoreIsEmptyFunc) appendCall(r0 SavedSearchStoreIsEmptyFuncCall) {
+func (f *RoleStoreListFunc) appendCall(r0 RoleStoreListFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreIsEmptyFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreIsEmptyFunc) History() []SavedSearchStoreIsEmptyFuncCall {
+// History returns a sequence of RoleStoreListFuncCall objects describing
+// the invocations of this function.
+func (f *RoleStoreListFunc) History() []RoleStoreListFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreIsEmptyFuncCall, len(f.history))
+	history := make([]RoleStoreListFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreIsEmptyFuncCall is an object that describes an invocation
-// of method IsEmpty on an instance of MockSavedSearchStore.
-type SavedSearchStoreIsEmptyFuncCall struct {
+// RoleStoreListFuncCall is an object that describes an invocation of method
+// List on an instance of MockRoleStore.
+type RoleStoreListFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 bool
+	Result0 []*Role
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -25339,45 +26027,45 @@ type SavedSearchStoreIsEmptyFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreIsEmptyFuncCall) Args() []interface{} {
+func (c RoleStoreListFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreIsEmptyFuncCall) Results() []interface{} {
+func (c RoleStoreListFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreListAllFunc describes the behavior when the ListAll
-// method of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreListAllFunc struct {
-	defaultHook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)
-	hooks       []func(context.Context) ([]api.SavedQuerySpecAndConfig, error)
-	history     []SavedSearchStoreListAllFuncCall
+// RoleStoreListAssignmentsFunc describes the behavior when the
+// ListAssignments method of the parent MockRoleStore instance is invoked.
+type RoleStoreListAssignmentsFunc struct {
+	defaultHook func(context.Context, ListRoleAssignmentsOpts) ([]*RoleAssignment, error)
+	hooks       []func(context.Context, ListRoleAssignmentsOpts) ([]*RoleAssignment, error)
+	history     []RoleStoreListAssignmentsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListAll delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSavedSearchStore) ListAll(v0 context.Context) ([]api.SavedQuerySpecAndConfig, error) {
-	r0, r1 := m.ListAllFunc.nextHook()(v0)
-	m.ListAllFunc.appendCall(SavedSearchStoreListAllFuncCall{v0, r0, r1})
+// ListAssignments delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRoleStore) ListAssignments(v0 context.Context, v1 ListRoleAssignmentsOpts) ([]*RoleAssignment, error) {
+	r0, r1 := m.ListAssignmentsFunc.nextHook()(v0, v1)
+	m.ListAssignmentsFunc.appendCall(RoleStoreListAssignmentsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListAll method of
-// the parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreListAllFunc) SetDefaultHook(hook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)) {
+// SetDefaultHook sets function that is called when the ListAssignments
+// method of the parent MockRoleStore instance is invoked and the hook queue
+// is empty.
+func (f *RoleStoreListAssignmentsFunc) SetDefaultHook(hook func(context.Context, ListRoleAssignmentsOpts) ([]*RoleAssignment, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListAll method of the parent MockSavedSearchStore instance invokes the
+// ListAssignments method of the parent MockRoleStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *SavedSearchStoreListAllFunc) PushHook(hook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)) {
+func (f *RoleStoreListAssignmentsFunc) PushHook(hook func(context.Context, ListRoleAssignmentsOpts) ([]*RoleAssignment, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -25385,21 +26073,21 @@ func (f *SavedSearchStoreListAllFunc) PushHook(hook func(context.Context) ([]api
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SavedSearchStoreListAllFunc) SetDefaultReturn(r0 []api.SavedQuerySpecAndConfig, r1 error) {
-	f.SetDefaultHook(func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+func (f *RoleStoreListAssignmentsFunc) SetDefaultReturn(r0 []*RoleAssignment, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListRoleAssignmentsOpts) ([]*RoleAssignment, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SavedSearchStoreListAllFunc) PushReturn(r0 []api.SavedQuerySpecAndConfig, r1 error) {
-	f.PushHook(func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+func (f *RoleStoreListAssignmentsFunc) PushReturn(r0 []*RoleAssignment, r1 error) {
+	f.PushHook(func(context.Context, ListRoleAssignmentsOpts) ([]*RoleAssignment, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreListAllFunc) nextHook() func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+func (f *RoleStoreListAssignmentsFunc) nextHook() func(context.Context, ListRoleAssignmentsOpts) ([]*RoleAssignment, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -25412,32 +26100,35 @@ func (f *SavedSearchStoreListAllFunc) nextHook() func(context.Context) ([]api.Sa
 	return hook
 }
 
-func (f *SavedSearchStoreListAllFunc) appendCall(r0 SavedSearchStoreListAllFuncCall) {
+func (f *RoleStoreListAssignmentsFunc) appendCall(r0 RoleStoreListAssignmentsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreListAllFuncCall objects
+// History returns a sequence of RoleStoreListAssignmentsFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreListAllFunc) History() []SavedSearchStoreListAllFuncCall {
+func (f *RoleStoreListAssignmentsFunc) History() []RoleStoreListAssignmentsFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreListAllFuncCall, len(f.history))
+	history := make([]RoleStoreListAssignmentsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreListAllFuncCall is an object that describes an invocation
-// of method ListAll on an instance of MockSavedSearchStore.
-type SavedSearchStoreListAllFuncCall struct {
+// RoleStoreListAssignmentsFuncCall is an object that describes an
+// invocation of method ListAssignments on an instance of MockRoleStore.
+type RoleStoreListAssignmentsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ListRoleAssignmentsOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []api.SavedQuerySpecAndConfig
+	Result0 []*RoleAssignment
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -25445,47 +26136,47 @@ type SavedSearchStoreListAllFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreListAllFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c RoleStoreListAssignmentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreListAllFuncCall) Results() []interface{} {
+func (c RoleStoreListAssignmentsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreListSavedSearchesByOrgIDFunc describes the behavior when
-// the ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
-// instance is invoked.
-type SavedSearchStoreListSavedSearchesByOrgIDFunc struct {
-	defaultHook func(context.Context, int32) ([]*types.SavedSearch, error)
-	hooks       []func(context.Context, int32) ([]*types.SavedSearch, error)
-	history     []SavedSearchStoreListSavedSearchesByOrgIDFuncCall
+// RoleStoreListPermissionsForUserFunc describes the behavior when the
+// ListPermissionsForUser method of the parent MockRoleStore instance is
+// invoked.
+type RoleStoreListPermissionsForUserFunc struct {
+	defaultHook func(context.Context, int32) ([]Permission, error)
+	hooks       []func(context.Context, int32) ([]Permission, error)
+	history     []RoleStoreListPermissionsForUserFuncCall
 	mutex       sync.Mutex
 }
 
-// ListSavedSearchesByOrgID delegates to the next hook function in the queue
+// ListPermissionsForUser delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockSavedSearchStore) ListSavedSearchesByOrgID(v0 context.Context, v1 int32) ([]*types.SavedSearch, error) {
-	r0, r1 := m.ListSavedSearchesByOrgIDFunc.nextHook()(v0, v1)
-	m.ListSavedSearchesByOrgIDFunc.appendCall(SavedSearchStoreListSavedSearchesByOrgIDFuncCall{v0, v1, r0, r1})
+func (m *MockRoleStore) ListPermissionsForUser(v0 context.Context, v1 int32) ([]Permission, error) {
+	r0, r1 := m.ListPermissionsForUserFunc.nextHook()(v0, v1)
+	m.ListPermissionsForUserFunc.appendCall(RoleStoreListPermissionsForUserFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
-// instance is invoked and the hook queue is empty.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) SetDefaultHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+// ListPermissionsForUser method of the parent MockRoleStore instance is
+// invoked and the hook queue is empty.
+func (f *RoleStoreListPermissionsForUserFunc) SetDefaultHook(hook func(context.Context, int32) ([]Permission, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+// ListPermissionsForUser method of the parent MockRoleStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RoleStoreListPermissionsForUserFunc) PushHook(hook func(context.Context, int32) ([]Permission, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -25493,21 +26184,21 @@ func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushHook(hook func(contex
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+func (f *RoleStoreListPermissionsForUserFunc) SetDefaultReturn(r0 []Permission, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]Permission, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+func (f *RoleStoreListPermissionsForUserFunc) PushReturn(r0 []Permission, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]Permission, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) nextHook() func(context.Context, int32) ([]*types.SavedSearch, error) {
+func (f *RoleStoreListPermissionsForUserFunc) nextHook() func(context.Context, int32) ([]Permission, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -25520,28 +26211,27 @@ func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) nextHook() func(context.C
 	return hook
 }
 
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) appendCall(r0 SavedSearchStoreListSavedSearchesByOrgIDFuncCall) {
+func (f *RoleStoreListPermissionsForUserFunc) appendCall(r0 RoleStoreListPermissionsForUserFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SavedSearchStoreListSavedSearchesByOrgIDFuncCall objects describing the
-// invocations of this function.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) History() []SavedSearchStoreListSavedSearchesByOrgIDFuncCall {
+// History returns a sequence of RoleStoreListPermissionsForUserFuncCall
+// objects describing the invocations of this function.
+func (f *RoleStoreListPermissionsForUserFunc) History() []RoleStoreListPermissionsForUserFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreListSavedSearchesByOrgIDFuncCall, len(f.history))
+	history := make([]RoleStoreListPermissionsForUserFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreListSavedSearchesByOrgIDFuncCall is an object that
-// describes an invocation of method ListSavedSearchesByOrgID on an instance
-// of MockSavedSearchStore.
-type SavedSearchStoreListSavedSearchesByOrgIDFuncCall struct {
+// RoleStoreListPermissionsForUserFuncCall is an object that describes an
+// invocation of method ListPermissionsForUser on an instance of
+// MockRoleStore.
+type RoleStoreListPermissionsForUserFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -25550,7 +26240,7 @@ type SavedSearchStoreListSavedSearchesByOrgIDFuncCall struct {
 	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.SavedSearch
+	Result0 []Permission
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -25558,47 +26248,44 @@ type SavedSearchStoreListSavedSearchesByOrgIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreListSavedSearchesByOrgIDFuncCall) Args() []interface{} {
+func (c RoleStoreListPermissionsForUserFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreListSavedSearchesByOrgIDFuncCall) Results() []interface{} {
+func (c RoleStoreListPermissionsForUserFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreListSavedSearchesByUserIDFunc describes the behavior when
-// the ListSavedSearchesByUserID method of the parent MockSavedSearchStore
-// instance is invoked.
-type SavedSearchStoreListSavedSearchesByUserIDFunc struct {
-	defaultHook func(context.Context, int32) ([]*types.SavedSearch, error)
-	hooks       []func(context.Context, int32) ([]*types.SavedSearch, error)
-	history     []SavedSearchStoreListSavedSearchesByUserIDFuncCall
+// RoleStoreUnassignFunc describes the behavior when the Unassign method of
+// the parent MockRoleStore instance is invoked.
+type RoleStoreUnassignFunc struct {
+	defaultHook func(context.Context, *RoleAssignment) error
+	hooks       []func(context.Context, *RoleAssignment) error
+	history     []RoleStoreUnassignFuncCall
 	mutex       sync.Mutex
 }
 
-// ListSavedSearchesByUserID delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockSavedSearchStore) ListSavedSearchesByUserID(v0 context.Context, v1 int32) ([]*types.SavedSearch, error) {
-	r0, r1 := m.ListSavedSearchesByUserIDFunc.nextHook()(v0, v1)
-	m.ListSavedSearchesByUserIDFunc.appendCall(SavedSearchStoreListSavedSearchesByUserIDFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// Unassign delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRoleStore) Unassign(v0 context.Context, v1 *RoleAssignment) error {
+	r0 := m.UnassignFunc.nextHook()(v0, v1)
+	m.UnassignFunc.appendCall(RoleStoreUnassignFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// ListSavedSearchesByUserID method of the parent MockSavedSearchStore
-// instance is invoked and the hook queue is empty.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) SetDefaultHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+// SetDefaultHook sets function that is called when the Unassign method of
+// the parent MockRoleStore instance is invoked and the hook queue is empty.
+func (f *RoleStoreUnassignFunc) SetDefaultHook(hook func(context.Context, *RoleAssignment) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListSavedSearchesByUserID method of the parent MockSavedSearchStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+// Unassign method of the parent MockRoleStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RoleStoreUnassignFunc) PushHook(hook func(context.Context, *RoleAssignment) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -25606,21 +26293,21 @@ func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushHook(hook func(conte
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
-		return r0, r1
+func (f *RoleStoreUnassignFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *RoleAssignment) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
-		return r0, r1
+func (f *RoleStoreUnassignFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *RoleAssignment) error {
+		return r0
 	})
 }
 
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) nextHook() func(context.Context, int32) ([]*types.SavedSearch, error) {
+func (f *RoleStoreUnassignFunc) nextHook() func(context.Context, *RoleAssignment) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -25633,83 +26320,78 @@ func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) nextHook() func(context.
 	return hook
 }
 
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) appendCall(r0 SavedSearchStoreListSavedSearchesByUserIDFuncCall) {
+func (f *RoleStoreUnassignFunc) appendCall(r0 RoleStoreUnassignFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SavedSearchStoreListSavedSearchesByUserIDFuncCall objects describing the
-// invocations of this function.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) History() []SavedSearchStoreListSavedSearchesByUserIDFuncCall {
+// History returns a sequence of RoleStoreUnassignFuncCall objects
+// describing the invocations of this function.
+func (f *RoleStoreUnassignFunc) History() []RoleStoreUnassignFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreListSavedSearchesByUserIDFuncCall, len(f.history))
+	history := make([]RoleStoreUnassignFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreListSavedSearchesByUserIDFuncCall is an object that
-// describes an invocation of method ListSavedSearchesByUserID on an
-// instance of MockSavedSearchStore.
-type SavedSearchStoreListSavedSearchesByUserIDFuncCall struct {
+// RoleStoreUnassignFuncCall is an object that describes an invocation of
+// method Unassign on an instance of MockRoleStore.
+type RoleStoreUnassignFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 *RoleAssignment
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.SavedSearch
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreListSavedSearchesByUserIDFuncCall) Args() []interface{} {
+func (c RoleStoreUnassignFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreListSavedSearchesByUserIDFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c RoleStoreUnassignFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SavedSearchStoreTransactFunc describes the behavior when the Transact
-// method of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreTransactFunc struct {
-	defaultHook func(context.Context) (SavedSearchStore, error)
-	hooks       []func(context.Context) (SavedSearchStore, error)
-	history     []SavedSearchStoreTransactFuncCall
+// RoleStoreUserHasPermissionFunc describes the behavior when the
+// UserHasPermission method of the parent MockRoleStore instance is invoked.
+type RoleStoreUserHasPermissionFunc struct {
+	defaultHook func(context.Context, int32, Permission) (bool, error)
+	hooks       []func(context.Context, int32, Permission) (bool, error)
+	history     []RoleStoreUserHasPermissionFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Transact(v0 context.Context) (SavedSearchStore, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(SavedSearchStoreTransactFuncCall{v0, r0, r1})
+// UserHasPermission delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRoleStore) UserHasPermission(v0 context.Context, v1 int32, v2 Permission) (bool, error) {
+	r0, r1 := m.UserHasPermissionFunc.nextHook()(v0, v1, v2)
+	m.UserHasPermissionFunc.appendCall(RoleStoreUserHasPermissionFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
-// the parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SavedSearchStore, error)) {
+// SetDefaultHook sets function that is called when the UserHasPermission
+// method of the parent MockRoleStore instance is invoked and the hook queue
+// is empty.
+func (f *RoleStoreUserHasPermissionFunc) SetDefaultHook(hook func(context.Context, int32, Permission) (bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockSavedSearchStore instance invokes the
+// UserHasPermission method of the parent MockRoleStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *SavedSearchStoreTransactFunc) PushHook(hook func(context.Context) (SavedSearchStore, error)) {
+func (f *RoleStoreUserHasPermissionFunc) PushHook(hook func(context.Context, int32, Permission) (bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -25717,21 +26399,21 @@ func (f *SavedSearchStoreTransactFunc) PushHook(hook func(context.Context) (Save
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SavedSearchStoreTransactFunc) SetDefaultReturn(r0 SavedSearchStore, r1 error) {
-	f.SetDefaultHook(func(context.Context) (SavedSearchStore, error) {
+func (f *RoleStoreUserHasPermissionFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, Permission) (bool, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SavedSearchStoreTransactFunc) PushReturn(r0 SavedSearchStore, r1 error) {
-	f.PushHook(func(context.Context) (SavedSearchStore, error) {
+func (f *RoleStoreUserHasPermissionFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int32, Permission) (bool, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreTransactFunc) nextHook() func(context.Context) (SavedSearchStore, error) {
+func (f *RoleStoreUserHasPermissionFunc) nextHook() func(context.Context, int32, Permission) (bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -25744,32 +26426,38 @@ func (f *SavedSearchStoreTransactFunc) nextHook() func(context.Context) (SavedSe
 	return hook
 }
 
-func (f *SavedSearchStoreTransactFunc) appendCall(r0 SavedSearchStoreTransactFuncCall) {
+func (f *RoleStoreUserHasPermissionFunc) appendCall(r0 RoleStoreUserHasPermissionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreTransactFuncCall objects
+// History returns a sequence of RoleStoreUserHasPermissionFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreTransactFunc) History() []SavedSearchStoreTransactFuncCall {
+func (f *RoleStoreUserHasPermissionFunc) History() []RoleStoreUserHasPermissionFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreTransactFuncCall, len(f.history))
+	history := make([]RoleStoreUserHasPermissionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreTransactFuncCall is an object that describes an
-// invocation of method Transact on an instance of MockSavedSearchStore.
-type SavedSearchStoreTransactFuncCall struct {
+// RoleStoreUserHasPermissionFuncCall is an object that describes an
+// invocation of method UserHasPermission on an instance of MockRoleStore.
+type RoleStoreUserHasPermissionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 Permission
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 SavedSearchStore
+	Result0 bool
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -25777,45 +26465,252 @@ type SavedSearchStoreTransactFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreTransactFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c RoleStoreUserHasPermissionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreTransactFuncCall) Results() []interface{} {
+func (c RoleStoreUserHasPermissionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreUpdateFunc describes the behavior when the Update method
+// MockSavedSearchStore is a mock implementation of the SavedSearchStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockSavedSearchStore struct {
+	// CreateFunc is an instance of a mock function object controlling the
+	// behavior of the method Create.
+	CreateFunc *SavedSearchStoreCreateFunc
+	// DeleteFunc is an instance of a mock function object controlling the
+	// behavior of the method Delete.
+	DeleteFunc *SavedSearchStoreDeleteFunc
+	// GetByIDFunc is an instance of a mock function object controlling the
+	// behavior of the method GetByID.
+	GetByIDFunc *SavedSearchStoreGetByIDFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *SavedSearchStoreHandleFunc
+	// IsEmptyFunc is an instance of a mock function object controlling the
+	// behavior of the method IsEmpty.
+	IsEmptyFunc *SavedSearchStoreIsEmptyFunc
+	// ListAllFunc is an instance of a mock function object controlling the
+	// behavior of the method ListAll.
+	ListAllFunc *SavedSearchStoreListAllFunc
+	// ListSavedSearchesByOrgIDFunc is an instance of a mock function object
+	// controlling the behavior of the method ListSavedSearchesByOrgID.
+	ListSavedSearchesByOrgIDFunc *SavedSearchStoreListSavedSearchesByOrgIDFunc
+	// ListSavedSearchesByUserIDFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListSavedSearchesByUserID.
+	ListSavedSearchesByUserIDFunc *SavedSearchStoreListSavedSearchesByUserIDFunc
+	// TransactFunc is an instance of a mock function object controlling the
+	// behavior of the method Transact.
+	TransactFunc *SavedSearchStoreTransactFunc
+	// UpdateFunc is an instance of a mock function object controlling the
+	// behavior of the method Update.
+	UpdateFunc *SavedSearchStoreUpdateFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *SavedSearchStoreWithFunc
+}
+
+// NewMockSavedSearchStore creates a new mock of the SavedSearchStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockSavedSearchStore() *MockSavedSearchStore {
+	return &MockSavedSearchStore{
+		CreateFunc: &SavedSearchStoreCreateFunc{
+			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+				return nil, nil
+			},
+		},
+		DeleteFunc: &SavedSearchStoreDeleteFunc{
+			defaultHook: func(context.Context, int32) error {
+				return nil
+			},
+		},
+		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
+			defaultHook: func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+				return nil, nil
+			},
+		},
+		HandleFunc: &SavedSearchStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				return nil
+			},
+		},
+		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
+			defaultHook: func(context.Context) (bool, error) {
+				return false, nil
+			},
+		},
+		ListAllFunc: &SavedSearchStoreListAllFunc{
+			defaultHook: func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+				return nil, nil
+			},
+		},
+		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
+			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
+				return nil, nil
+			},
+		},
+		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
+			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
+				return nil, nil
+			},
+		},
+		TransactFunc: &SavedSearchStoreTransactFunc{
+			defaultHook: func(context.Context) (SavedSearchStore, error) {
+				return nil, nil
+			},
+		},
+		UpdateFunc: &SavedSearchStoreUpdateFunc{
+			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+				return nil, nil
+			},
+		},
+		WithFunc: &SavedSearchStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) SavedSearchStore {
+				return nil
+			},
+		},
+	}
+}
+
+// NewStrictMockSavedSearchStore creates a new mock of the SavedSearchStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockSavedSearchStore() *MockSavedSearchStore {
+	return &MockSavedSearchStore{
+		CreateFunc: &SavedSearchStoreCreateFunc{
+			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.Create")
+			},
+		},
+		DeleteFunc: &SavedSearchStoreDeleteFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockSavedSearchStore.Delete")
+			},
+		},
+		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
+			defaultHook: func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+				panic("unexpected invocation of MockSavedSearchStore.GetByID")
+			},
+		},
+		HandleFunc: &SavedSearchStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				panic("unexpected invocation of MockSavedSearchStore.Handle")
+			},
+		},
+		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
+			defaultHook: func(context.Context) (bool, error) {
+				panic("unexpected invocation of MockSavedSearchStore.IsEmpty")
+			},
+		},
+		ListAllFunc: &SavedSearchStoreListAllFunc{
+			defaultHook: func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListAll")
+			},
+		},
+		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
+			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListSavedSearchesByOrgID")
+			},
+		},
+		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
+			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListSavedSearchesByUserID")
+			},
+		},
+		TransactFunc: &SavedSearchStoreTransactFunc{
+			defaultHook: func(context.Context) (SavedSearchStore, error) {
+				panic("unexpected invocation of MockSavedSearchStore.Transact")
+			},
+		},
+		UpdateFunc: &SavedSearchStoreUpdateFunc{
+			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.Update")
+			},
+		},
+		WithFunc: &SavedSearchStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) SavedSearchStore {
+				panic("unexpected invocation of MockSavedSearchStore.With")
+			},
+		},
+	}
+}
+
+// NewMockSavedSearchStoreFrom creates a new mock of the
+// MockSavedSearchStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockSavedSearchStoreFrom(i SavedSearchStore) *MockSavedSearchStore {
+	return &MockSavedSearchStore{
+		CreateFunc: &SavedSearchStoreCreateFunc{
+			defaultHook: i.Create,
+		},
+		DeleteFunc: &SavedSearchStoreDeleteFunc{
+			defaultHook: i.Delete,
+		},
+		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
+			defaultHook: i.GetByID,
+		},
+		HandleFunc: &SavedSearchStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
+			defaultHook: i.IsEmpty,
+		},
+		ListAllFunc: &SavedSearchStoreListAllFunc{
+			defaultHook: i.ListAll,
+		},
+		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
+			defaultHook: i.ListSavedSearchesByOrgID,
+		},
+		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
+			defaultHook: i.ListSavedSearchesByUserID,
+		},
+		TransactFunc: &SavedSearchStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		UpdateFunc: &SavedSearchStoreUpdateFunc{
+			defaultHook: i.Update,
+		},
+		WithFunc: &SavedSearchStoreWithFunc{
+			defaultHook: i.With,
+		},
+	}
+}
+
+// SavedSearchStoreCreateFunc describes the behavior when the Create method
 // of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreUpdateFunc struct {
+type SavedSearchStoreCreateFunc struct {
 	defaultHook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
 	hooks       []func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
-	history     []SavedSearchStoreUpdateFuncCall
+	history     []SavedSearchStoreCreateFuncCall
 	mutex       sync.Mutex
 }
 
-// Update delegates to the next hook function in the queue and stores the
+// Create delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Update(v0 context.Context, v1 *types.SavedSearch) (*types.SavedSearch, error) {
-	r0, r1 := m.UpdateFunc.nextHook()(v0, v1)
-	m.UpdateFunc.appendCall(SavedSearchStoreUpdateFuncCall{v0, v1, r0, r1})
+func (m *MockSavedSearchStore) Create(v0 context.Context, v1 *types.SavedSearch) (*types.SavedSearch, error) {
+	r0, r1 := m.CreateFunc.nextHook()(v0, v1)
+	m.CreateFunc.appendCall(SavedSearchStoreCreateFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Update method of the
+// SetDefaultHook sets function that is called when the Create method of the
 // parent MockSavedSearchStore instance is invoked and the hook queue is
 // empty.
-func (f *SavedSearchStoreUpdateFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+func (f *SavedSearchStoreCreateFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Update method of the parent MockSavedSearchStore instance invokes the
+// Create method of the parent MockSavedSearchStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *SavedSearchStoreUpdateFunc) PushHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+func (f *SavedSearchStoreCreateFunc) PushHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -25823,7 +26718,7 @@ func (f *SavedSearchStoreUpdateFunc) PushHook(hook func(context.Context, *types.
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SavedSearchStoreUpdateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
+func (f *SavedSearchStoreCreateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
 	f.SetDefaultHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
 		return r0, r1
 	})
@@ -25831,13 +26726,13 @@ func (f *SavedSearchStoreUpdateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SavedSearchStoreUpdateFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
+func (f *SavedSearchStoreCreateFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
 	f.PushHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreUpdateFunc) nextHook() func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+func (f *SavedSearchStoreCreateFunc) nextHook() func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -25850,26 +26745,26 @@ func (f *SavedSearchStoreUpdateFunc) nextHook() func(context.Context, *types.Sav
 	return hook
 }
 
-func (f *SavedSearchStoreUpdateFunc) appendCall(r0 SavedSearchStoreUpdateFuncCall) {
+func (f *SavedSearchStoreCreateFunc) appendCall(r0 SavedSearchStoreCreateFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreUpdateFuncCall objects
+// History returns a sequence of SavedSearchStoreCreateFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreUpdateFunc) History() []SavedSearchStoreUpdateFuncCall {
+func (f *SavedSearchStoreCreateFunc) History() []SavedSearchStoreCreateFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreUpdateFuncCall, len(f.history))
+	history := make([]SavedSearchStoreCreateFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreUpdateFuncCall is an object that describes an invocation
-// of method Update on an instance of MockSavedSearchStore.
-type SavedSearchStoreUpdateFuncCall struct {
+// SavedSearchStoreCreateFuncCall is an object that describes an invocation
+// of method Create on an instance of MockSavedSearchStore.
+type SavedSearchStoreCreateFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -25886,45 +26781,45 @@ type SavedSearchStoreUpdateFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreUpdateFuncCall) Args() []interface{} {
+func (c SavedSearchStoreCreateFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreUpdateFuncCall) Results() []interface{} {
+func (c SavedSearchStoreCreateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreWithFunc describes the behavior when the With method of
-// the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreWithFunc struct {
-	defaultHook func(basestore.ShareableStore) SavedSearchStore
-	hooks       []func(basestore.ShareableStore) SavedSearchStore
-	history     []SavedSearchStoreWithFuncCall
-	mutex       sync.Mutex
-}
-
-// With delegates to the next hook function in the queue and stores the
+// SavedSearchStoreDeleteFunc describes the behavior when the Delete method
+// of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreDeleteFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []SavedSearchStoreDeleteFuncCall
+	mutex       sync.Mutex
+}
+
+// Delete delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) With(v0 basestore.ShareableStore) SavedSearchStore {
-	r0 := m.WithFunc.nextHook()(v0)
-	m.WithFunc.appendCall(SavedSearchStoreWithFuncCall{v0, r0})
+func (m *MockSavedSearchStore) Delete(v0 context.Context, v1 int32) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1)
+	m.DeleteFunc.appendCall(SavedSearchStoreDeleteFuncCall{v0, v1, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the With method of the
+// SetDefaultHook sets function that is called when the Delete method of the
 // parent MockSavedSearchStore instance is invoked and the hook queue is
 // empty.
-func (f *SavedSearchStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) SavedSearchStore) {
+func (f *SavedSearchStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int32) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// With method of the parent MockSavedSearchStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *SavedSearchStoreWithFunc) PushHook(hook func(basestore.ShareableStore) SavedSearchStore) {
+// Delete method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreDeleteFunc) PushHook(hook func(context.Context, int32) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -25932,21 +26827,21 @@ func (f *SavedSearchStoreWithFunc) PushHook(hook func(basestore.ShareableStore)
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SavedSearchStoreWithFunc) SetDefaultReturn(r0 SavedSearchStore) {
-	f.SetDefaultHook(func(basestore.ShareableStore) SavedSearchStore {
+func (f *SavedSearchStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SavedSearchStoreWithFunc) PushReturn(r0 SavedSearchStore) {
-	f.PushHook(func(basestore.ShareableStore) SavedSearchStore {
+func (f *SavedSearchStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
 		return r0
 	})
 }
 
-func (f *SavedSearchStoreWithFunc) nextHook() func(basestore.ShareableStore) SavedSearchStore {
+func (f *SavedSearchStoreDeleteFunc) nextHook() func(context.Context, int32) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -25959,336 +26854,78 @@ func (f *SavedSearchStoreWithFunc) nextHook() func(basestore.ShareableStore) Sav
 	return hook
 }
 
-func (f *SavedSearchStoreWithFunc) appendCall(r0 SavedSearchStoreWithFuncCall) {
+func (f *SavedSearchStoreDeleteFunc) appendCall(r0 SavedSearchStoreDeleteFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreWithFuncCall objects
+// History returns a sequence of SavedSearchStoreDeleteFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreWithFunc) History() []SavedSearchStoreWithFuncCall {
+func (f *SavedSearchStoreDeleteFunc) History() []SavedSearchStoreDeleteFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreWithFuncCall, len(f.history))
+	history := make([]SavedSearchStoreDeleteFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreWithFuncCall is an object that describes an invocation of
-// method With on an instance of MockSavedSearchStore.
-type SavedSearchStoreWithFuncCall struct {
+// SavedSearchStoreDeleteFuncCall is an object that describes an invocation
+// of method Delete on an instance of MockSavedSearchStore.
+type SavedSearchStoreDeleteFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 basestore.ShareableStore
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 SavedSearchStore
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreWithFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SavedSearchStoreDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreWithFuncCall) Results() []interface{} {
+func (c SavedSearchStoreDeleteFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// MockSearchContextsStore is a mock implementation of the
-// SearchContextsStore interface (from the package
-// github.com/sourcegraph/sourcegraph/internal/database) used for unit
-// testing.
-type MockSearchContextsStore struct {
-	// CountSearchContextsFunc is an instance of a mock function object
-	// controlling the behavior of the method CountSearchContexts.
-	CountSearchContextsFunc *SearchContextsStoreCountSearchContextsFunc
-	// CreateSearchContextWithRepositoryRevisionsFunc is an instance of a
-	// mock function object controlling the behavior of the method
-	// CreateSearchContextWithRepositoryRevisions.
-	CreateSearchContextWithRepositoryRevisionsFunc *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc
-	// DeleteSearchContextFunc is an instance of a mock function object
-	// controlling the behavior of the method DeleteSearchContext.
-	DeleteSearchContextFunc *SearchContextsStoreDeleteSearchContextFunc
-	// DoneFunc is an instance of a mock function object controlling the
-	// behavior of the method Done.
-	DoneFunc *SearchContextsStoreDoneFunc
-	// ExecFunc is an instance of a mock function object controlling the
-	// behavior of the method Exec.
-	ExecFunc *SearchContextsStoreExecFunc
-	// GetAllQueriesFunc is an instance of a mock function object
-	// controlling the behavior of the method GetAllQueries.
-	GetAllQueriesFunc *SearchContextsStoreGetAllQueriesFunc
-	// GetAllRevisionsForReposFunc is an instance of a mock function object
-	// controlling the behavior of the method GetAllRevisionsForRepos.
-	GetAllRevisionsForReposFunc *SearchContextsStoreGetAllRevisionsForReposFunc
-	// GetSearchContextFunc is an instance of a mock function object
-	// controlling the behavior of the method GetSearchContext.
-	GetSearchContextFunc *SearchContextsStoreGetSearchContextFunc
-	// GetSearchContextRepositoryRevisionsFunc is an instance of a mock
-	// function object controlling the behavior of the method
-	// GetSearchContextRepositoryRevisions.
-	GetSearchContextRepositoryRevisionsFunc *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc
-	// HandleFunc is an instance of a mock function object controlling the
-	// behavior of the method Handle.
-	HandleFunc *SearchContextsStoreHandleFunc
-	// ListSearchContextsFunc is an instance of a mock function object
-	// controlling the behavior of the method ListSearchContexts.
-	ListSearchContextsFunc *SearchContextsStoreListSearchContextsFunc
-	// SetSearchContextRepositoryRevisionsFunc is an instance of a mock
-	// function object controlling the behavior of the method
-	// SetSearchContextRepositoryRevisions.
-	SetSearchContextRepositoryRevisionsFunc *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc
-	// TransactFunc is an instance of a mock function object controlling the
-	// behavior of the method Transact.
-	TransactFunc *SearchContextsStoreTransactFunc
-	// UpdateSearchContextWithRepositoryRevisionsFunc is an instance of a
-	// mock function object controlling the behavior of the method
-	// UpdateSearchContextWithRepositoryRevisions.
-	UpdateSearchContextWithRepositoryRevisionsFunc *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc
-}
-
-// NewMockSearchContextsStore creates a new mock of the SearchContextsStore
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockSearchContextsStore() *MockSearchContextsStore {
-	return &MockSearchContextsStore{
-		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
-			defaultHook: func(context.Context, ListSearchContextsOptions) (int32, error) {
-				return 0, nil
-			},
-		},
-		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-				return nil, nil
-			},
-		},
-		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
-			defaultHook: func(context.Context, int64) error {
-				return nil
-			},
-		},
-		DoneFunc: &SearchContextsStoreDoneFunc{
-			defaultHook: func(error) error {
-				return nil
-			},
-		},
-		ExecFunc: &SearchContextsStoreExecFunc{
-			defaultHook: func(context.Context, *sqlf.Query) error {
-				return nil
-			},
-		},
-		GetAllQueriesFunc: &SearchContextsStoreGetAllQueriesFunc{
-			defaultHook: func(context.Context) ([]string, error) {
-				return nil, nil
-			},
-		},
-		GetAllRevisionsForReposFunc: &SearchContextsStoreGetAllRevisionsForReposFunc{
-			defaultHook: func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
-				return nil, nil
-			},
-		},
-		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
-			defaultHook: func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
-				return nil, nil
-			},
-		},
-		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
-				return nil, nil
-			},
-		},
-		HandleFunc: &SearchContextsStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				return nil
-			},
-		},
-		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
-			defaultHook: func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
-				return nil, nil
-			},
-		},
-		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
-				return nil
-			},
-		},
-		TransactFunc: &SearchContextsStoreTransactFunc{
-			defaultHook: func(context.Context) (SearchContextsStore, error) {
-				return nil, nil
-			},
-		},
-		UpdateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-				return nil, nil
-			},
-		},
-	}
-}
-
-// NewStrictMockSearchContextsStore creates a new mock of the
-// SearchContextsStore interface. All methods panic on invocation, unless
-// overwritten.
-func NewStrictMockSearchContextsStore() *MockSearchContextsStore {
-	return &MockSearchContextsStore{
-		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
-			defaultHook: func(context.Context, ListSearchContextsOptions) (int32, error) {
-				panic("unexpected invocation of MockSearchContextsStore.CountSearchContexts")
-			},
-		},
-		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-				panic("unexpected invocation of MockSearchContextsStore.CreateSearchContextWithRepositoryRevisions")
-			},
-		},
-		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
-			defaultHook: func(context.Context, int64) error {
-				panic("unexpected invocation of MockSearchContextsStore.DeleteSearchContext")
-			},
-		},
-		DoneFunc: &SearchContextsStoreDoneFunc{
-			defaultHook: func(error) error {
-				panic("unexpected invocation of MockSearchContextsStore.Done")
-			},
-		},
-		ExecFunc: &SearchContextsStoreExecFunc{
-			defaultHook: func(context.Context, *sqlf.Query) error {
-				panic("unexpected invocation of MockSearchContextsStore.Exec")
-			},
-		},
-		GetAllQueriesFunc: &SearchContextsStoreGetAllQueriesFunc{
-			defaultHook: func(context.Context) ([]string, error) {
-				panic("unexpected invocation of MockSearchContextsStore.GetAllQueries")
-			},
-		},
-		GetAllRevisionsForReposFunc: &SearchContextsStoreGetAllRevisionsForReposFunc{
-			defaultHook: func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
-				panic("unexpected invocation of MockSearchContextsStore.GetAllRevisionsForRepos")
-			},
-		},
-		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
-			defaultHook: func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
-				panic("unexpected invocation of MockSearchContextsStore.GetSearchContext")
-			},
-		},
-		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
-				panic("unexpected invocation of MockSearchContextsStore.GetSearchContextRepositoryRevisions")
-			},
-		},
-		HandleFunc: &SearchContextsStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				panic("unexpected invocation of MockSearchContextsStore.Handle")
-			},
-		},
-		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
-			defaultHook: func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
-				panic("unexpected invocation of MockSearchContextsStore.ListSearchContexts")
-			},
-		},
-		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
-				panic("unexpected invocation of MockSearchContextsStore.SetSearchContextRepositoryRevisions")
-			},
-		},
-		TransactFunc: &SearchContextsStoreTransactFunc{
-			defaultHook: func(context.Context) (SearchContextsStore, error) {
-				panic("unexpected invocation of MockSearchContextsStore.Transact")
-			},
-		},
-		UpdateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc{
-			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-				panic("unexpected invocation of MockSearchContextsStore.UpdateSearchContextWithRepositoryRevisions")
-			},
-		},
-	}
-}
-
-// NewMockSearchContextsStoreFrom creates a new mock of the
-// MockSearchContextsStore interface. All methods delegate to the given
-// implementation, unless overwritten.
-func NewMockSearchContextsStoreFrom(i SearchContextsStore) *MockSearchContextsStore {
-	return &MockSearchContextsStore{
-		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
-			defaultHook: i.CountSearchContexts,
-		},
-		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
-			defaultHook: i.CreateSearchContextWithRepositoryRevisions,
-		},
-		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
-			defaultHook: i.DeleteSearchContext,
-		},
-		DoneFunc: &SearchContextsStoreDoneFunc{
-			defaultHook: i.Done,
-		},
-		ExecFunc: &SearchContextsStoreExecFunc{
-			defaultHook: i.Exec,
-		},
-		GetAllQueriesFunc: &SearchContextsStoreGetAllQueriesFunc{
-			defaultHook: i.GetAllQueries,
-		},
-		GetAllRevisionsForReposFunc: &SearchContextsStoreGetAllRevisionsForReposFunc{
-			defaultHook: i.GetAllRevisionsForRepos,
-		},
-		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
-			defaultHook: i.GetSearchContext,
-		},
-		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
-			defaultHook: i.GetSearchContextRepositoryRevisions,
-		},
-		HandleFunc: &SearchContextsStoreHandleFunc{
-			defaultHook: i.Handle,
-		},
-		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
-			defaultHook: i.ListSearchContexts,
-		},
-		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
-			defaultHook: i.SetSearchContextRepositoryRevisions,
-		},
-		TransactFunc: &SearchContextsStoreTransactFunc{
-			defaultHook: i.Transact,
-		},
-		UpdateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc{
-			defaultHook: i.UpdateSearchContextWithRepositoryRevisions,
-		},
-	}
-}
-
-// SearchContextsStoreCountSearchContextsFunc describes the behavior when
-// the CountSearchContexts method of the parent MockSearchContextsStore
-// instance is invoked.
-type SearchContextsStoreCountSearchContextsFunc struct {
-	defaultHook func(context.Context, ListSearchContextsOptions) (int32, error)
-	hooks       []func(context.Context, ListSearchContextsOptions) (int32, error)
-	history     []SearchContextsStoreCountSearchContextsFuncCall
+// SavedSearchStoreGetByIDFunc describes the behavior when the GetByID
+// method of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreGetByIDFunc struct {
+	defaultHook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
+	hooks       []func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
+	history     []SavedSearchStoreGetByIDFuncCall
 	mutex       sync.Mutex
 }
 
-// CountSearchContexts delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) CountSearchContexts(v0 context.Context, v1 ListSearchContextsOptions) (int32, error) {
-	r0, r1 := m.CountSearchContextsFunc.nextHook()(v0, v1)
-	m.CountSearchContextsFunc.appendCall(SearchContextsStoreCountSearchContextsFuncCall{v0, v1, r0, r1})
+// GetByID delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) GetByID(v0 context.Context, v1 int32) (*api.SavedQuerySpecAndConfig, error) {
+	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
+	m.GetByIDFunc.appendCall(SavedSearchStoreGetByIDFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CountSearchContexts
-// method of the parent MockSearchContextsStore instance is invoked and the
-// hook queue is empty.
-func (f *SearchContextsStoreCountSearchContextsFunc) SetDefaultHook(hook func(context.Context, ListSearchContextsOptions) (int32, error)) {
+// SetDefaultHook sets function that is called when the GetByID method of
+// the parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CountSearchContexts method of the parent MockSearchContextsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *SearchContextsStoreCountSearchContextsFunc) PushHook(hook func(context.Context, ListSearchContextsOptions) (int32, error)) {
+// GetByID method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreGetByIDFunc) PushHook(hook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -26296,21 +26933,21 @@ func (f *SearchContextsStoreCountSearchContextsFunc) PushHook(hook func(context.
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreCountSearchContextsFunc) SetDefaultReturn(r0 int32, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListSearchContextsOptions) (int32, error) {
+func (f *SavedSearchStoreGetByIDFunc) SetDefaultReturn(r0 *api.SavedQuerySpecAndConfig, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreCountSearchContextsFunc) PushReturn(r0 int32, r1 error) {
-	f.PushHook(func(context.Context, ListSearchContextsOptions) (int32, error) {
+func (f *SavedSearchStoreGetByIDFunc) PushReturn(r0 *api.SavedQuerySpecAndConfig, r1 error) {
+	f.PushHook(func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreCountSearchContextsFunc) nextHook() func(context.Context, ListSearchContextsOptions) (int32, error) {
+func (f *SavedSearchStoreGetByIDFunc) nextHook() func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -26323,37 +26960,35 @@ func (f *SearchContextsStoreCountSearchContextsFunc) nextHook() func(context.Con
 	return hook
 }
 
-func (f *SearchContextsStoreCountSearchContextsFunc) appendCall(r0 SearchContextsStoreCountSearchContextsFuncCall) {
+func (f *SavedSearchStoreGetByIDFunc) appendCall(r0 SavedSearchStoreGetByIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SearchContextsStoreCountSearchContextsFuncCall objects describing the
-// invocations of this function.
-func (f *SearchContextsStoreCountSearchContextsFunc) History() []SearchContextsStoreCountSearchContextsFuncCall {
+// History returns a sequence of SavedSearchStoreGetByIDFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreGetByIDFunc) History() []SavedSearchStoreGetByIDFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreCountSearchContextsFuncCall, len(f.history))
+	history := make([]SavedSearchStoreGetByIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreCountSearchContextsFuncCall is an object that
-// describes an invocation of method CountSearchContexts on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreCountSearchContextsFuncCall struct {
+// SavedSearchStoreGetByIDFuncCall is an object that describes an invocation
+// of method GetByID on an instance of MockSavedSearchStore.
+type SavedSearchStoreGetByIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ListSearchContextsOptions
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int32
+	Result0 *api.SavedQuerySpecAndConfig
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -26361,49 +26996,45 @@ type SearchContextsStoreCountSearchContextsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreCountSearchContextsFuncCall) Args() []interface{} {
+func (c SavedSearchStoreGetByIDFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreCountSearchContextsFuncCall) Results() []interface{} {
+func (c SavedSearchStoreGetByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc
-// describes the behavior when the
-// CreateSearchContextWithRepositoryRevisions method of the parent
-// MockSearchContextsStore instance is invoked.
-type SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc struct {
-	defaultHook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
-	hooks       []func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
-	history     []SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall
+// SavedSearchStoreHandleFunc describes the behavior when the Handle method
+// of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreHandleFunc struct {
+	defaultHook func() *basestore.TransactableHandle
+	hooks       []func() *basestore.TransactableHandle
+	history     []SavedSearchStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
-// CreateSearchContextWithRepositoryRevisions delegates to the next hook
-// function in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockSearchContextsStore) CreateSearchContextWithRepositoryRevisions(v0 context.Context, v1 *types.SearchContext, v2 []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-	r0, r1 := m.CreateSearchContextWithRepositoryRevisionsFunc.nextHook()(v0, v1, v2)
-	m.CreateSearchContextWithRepositoryRevisionsFunc.appendCall(SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall{v0, v1, v2, r0, r1})
-	return r0, r1
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) Handle() *basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(SavedSearchStoreHandleFuncCall{r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// CreateSearchContextWithRepositoryRevisions method of the parent
-// MockSearchContextsStore instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CreateSearchContextWithRepositoryRevisions method of the parent
-// MockSearchContextsStore instance invokes the hook at the front of the
-// queue and discards it. After the queue is empty, the default hook
-// function is invoked for any future action.
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) PushHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
+// Handle method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -26411,21 +27042,21 @@ func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) Push
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
-	f.SetDefaultHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-		return r0, r1
+func (f *SavedSearchStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+	f.SetDefaultHook(func() *basestore.TransactableHandle {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) PushReturn(r0 *types.SearchContext, r1 error) {
-	f.PushHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-		return r0, r1
+func (f *SavedSearchStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+	f.PushHook(func() *basestore.TransactableHandle {
+		return r0
 	})
 }
 
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) nextHook() func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+func (f *SavedSearchStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -26438,89 +27069,72 @@ func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) next
 	return hook
 }
 
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) {
+func (f *SavedSearchStoreHandleFunc) appendCall(r0 SavedSearchStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall
-// objects describing the invocations of this function.
-func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) History() []SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall {
+// History returns a sequence of SavedSearchStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreHandleFunc) History() []SavedSearchStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall, len(f.history))
+	history := make([]SavedSearchStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall is
-// an object that describes an invocation of method
-// CreateSearchContextWithRepositoryRevisions on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 *types.SearchContext
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 []*types.SearchContextRepositoryRevisions
+// SavedSearchStoreHandleFuncCall is an object that describes an invocation
+// of method Handle on an instance of MockSavedSearchStore.
+type SavedSearchStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.SearchContext
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 *basestore.TransactableHandle
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c SavedSearchStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
-}
-
-// SearchContextsStoreDeleteSearchContextFunc describes the behavior when
-// the DeleteSearchContext method of the parent MockSearchContextsStore
-// instance is invoked.
-type SearchContextsStoreDeleteSearchContextFunc struct {
-	defaultHook func(context.Context, int64) error
-	hooks       []func(context.Context, int64) error
-	history     []SearchContextsStoreDeleteSearchContextFuncCall
-	mutex       sync.Mutex
+func (c SavedSearchStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// DeleteSearchContext delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) DeleteSearchContext(v0 context.Context, v1 int64) error {
-	r0 := m.DeleteSearchContextFunc.nextHook()(v0, v1)
-	m.DeleteSearchContextFunc.appendCall(SearchContextsStoreDeleteSearchContextFuncCall{v0, v1, r0})
-	return r0
+// SavedSearchStoreIsEmptyFunc describes the behavior when the IsEmpty
+// method of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreIsEmptyFunc struct {
+	defaultHook func(context.Context) (bool, error)
+	hooks       []func(context.Context) (bool, error)
+	history     []SavedSearchStoreIsEmptyFuncCall
+	mutex       sync.Mutex
 }
 
-// SetDefaultHook sets function that is called when the DeleteSearchContext
-// method of the parent MockSearchContextsStore instance is invoked and the
-// hook queue is empty.
-func (f *SearchContextsStoreDeleteSearchContextFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+// IsEmpty delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) IsEmpty(v0 context.Context) (bool, error) {
+	r0, r1 := m.IsEmptyFunc.nextHook()(v0)
+	m.IsEmptyFunc.appendCall(SavedSearchStoreIsEmptyFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the IsEmpty method of
+// the parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreIsEmptyFunc) SetDefaultHook(hook func(context.Context) (bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// DeleteSearchContext method of the parent MockSearchContextsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *SearchContextsStoreDeleteSearchContextFunc) PushHook(hook func(context.Context, int64) error) {
+// IsEmpty method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreIsEmptyFunc) PushHook(hook func(context.Context) (bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -26528,21 +27142,21 @@ func (f *SearchContextsStoreDeleteSearchContextFunc) PushHook(hook func(context.
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreDeleteSearchContextFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64) error {
-		return r0
+func (f *SavedSearchStoreIsEmptyFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context) (bool, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreDeleteSearchContextFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64) error {
-		return r0
+func (f *SavedSearchStoreIsEmptyFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context) (bool, error) {
+		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreDeleteSearchContextFunc) nextHook() func(context.Context, int64) error {
+func (f *SavedSearchStoreIsEmptyFunc) nextHook() func(context.Context) (bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -26555,80 +27169,78 @@ func (f *SearchContextsStoreDeleteSearchContextFunc) nextHook() func(context.Con
 	return hook
 }
 
-func (f *SearchContextsStoreDeleteSearchContextFunc) appendCall(r0 SearchContextsStoreDeleteSearchContextFuncCall) {
+func (f *SavedSearchStoreIsEmptyFunc) appendCall(r0 SavedSearchStoreIsEmptyFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SearchContextsStoreDeleteSearchContextFuncCall objects describing the
-// invocations of this function.
-func (f *SearchContextsStoreDeleteSearchContextFunc) History() []SearchContextsStoreDeleteSearchContextFuncCall {
+// History returns a sequence of SavedSearchStoreIsEmptyFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreIsEmptyFunc) History() []SavedSearchStoreIsEmptyFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreDeleteSearchContextFuncCall, len(f.history))
+	history := make([]SavedSearchStoreIsEmptyFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreDeleteSearchContextFuncCall is an object that
-// describes an invocation of method DeleteSearchContext on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreDeleteSearchContextFuncCall struct {
+// SavedSearchStoreIsEmptyFuncCall is an object that describes an invocation
+// of method IsEmpty on an instance of MockSavedSearchStore.
+type SavedSearchStoreIsEmptyFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreDeleteSearchContextFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c SavedSearchStoreIsEmptyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreDeleteSearchContextFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SavedSearchStoreIsEmptyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreDoneFunc describes the behavior when the Done method
-// of the parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []SearchContextsStoreDoneFuncCall
+// SavedSearchStoreListAllFunc describes the behavior when the ListAll
+// method of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreListAllFunc struct {
+	defaultHook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)
+	hooks       []func(context.Context) ([]api.SavedQuerySpecAndConfig, error)
+	history     []SavedSearchStoreListAllFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
+// ListAll delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSearchContextsStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(SearchContextsStoreDoneFuncCall{v0, r0})
-	return r0
+func (m *MockSavedSearchStore) ListAll(v0 context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+	r0, r1 := m.ListAllFunc.nextHook()(v0)
+	m.ListAllFunc.appendCall(SavedSearchStoreListAllFuncCall{v0, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
-// parent MockSearchContextsStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the ListAll method of
+// the parent MockSavedSearchStore instance is invoked and the hook queue is
 // empty.
-func (f *SearchContextsStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+func (f *SavedSearchStoreListAllFunc) SetDefaultHook(hook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Done method of the parent MockSearchContextsStore instance invokes the
+// ListAll method of the parent MockSavedSearchStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *SearchContextsStoreDoneFunc) PushHook(hook func(error) error) {
+func (f *SavedSearchStoreListAllFunc) PushHook(hook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -26636,21 +27248,21 @@ func (f *SearchContextsStoreDoneFunc) PushHook(hook func(error) error) {
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreDoneFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(error) error {
-		return r0
+func (f *SavedSearchStoreListAllFunc) SetDefaultReturn(r0 []api.SavedQuerySpecAndConfig, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreDoneFunc) PushReturn(r0 error) {
-	f.PushHook(func(error) error {
-		return r0
+func (f *SavedSearchStoreListAllFunc) PushReturn(r0 []api.SavedQuerySpecAndConfig, r1 error) {
+	f.PushHook(func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreDoneFunc) nextHook() func(error) error {
+func (f *SavedSearchStoreListAllFunc) nextHook() func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -26663,75 +27275,80 @@ func (f *SearchContextsStoreDoneFunc) nextHook() func(error) error {
 	return hook
 }
 
-func (f *SearchContextsStoreDoneFunc) appendCall(r0 SearchContextsStoreDoneFuncCall) {
+func (f *SavedSearchStoreListAllFunc) appendCall(r0 SavedSearchStoreListAllFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SearchContextsStoreDoneFuncCall objects
+// History returns a sequence of SavedSearchStoreListAllFuncCall objects
 // describing the invocations of this function.
-func (f *SearchContextsStoreDoneFunc) History() []SearchContextsStoreDoneFuncCall {
+func (f *SavedSearchStoreListAllFunc) History() []SavedSearchStoreListAllFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreDoneFuncCall, len(f.history))
+	history := make([]SavedSearchStoreListAllFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreDoneFuncCall is an object that describes an invocation
-// of method Done on an instance of MockSearchContextsStore.
-type SearchContextsStoreDoneFuncCall struct {
+// SavedSearchStoreListAllFuncCall is an object that describes an invocation
+// of method ListAll on an instance of MockSavedSearchStore.
+type SavedSearchStoreListAllFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 error
+	Arg0 context.Context
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 []api.SavedQuerySpecAndConfig
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreDoneFuncCall) Args() []interface{} {
+func (c SavedSearchStoreListAllFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreDoneFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SavedSearchStoreListAllFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreExecFunc describes the behavior when the Exec method
-// of the parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreExecFunc struct {
-	defaultHook func(context.Context, *sqlf.Query) error
-	hooks       []func(context.Context, *sqlf.Query) error
-	history     []SearchContextsStoreExecFuncCall
+// SavedSearchStoreListSavedSearchesByOrgIDFunc describes the behavior when
+// the ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
+// instance is invoked.
+type SavedSearchStoreListSavedSearchesByOrgIDFunc struct {
+	defaultHook func(context.Context, int32) ([]*types.SavedSearch, error)
+	hooks       []func(context.Context, int32) ([]*types.SavedSearch, error)
+	history     []SavedSearchStoreListSavedSearchesByOrgIDFuncCall
 	mutex       sync.Mutex
 }
 
-// Exec delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSearchContextsStore) Exec(v0 context.Context, v1 *sqlf.Query) error {
-	r0 := m.ExecFunc.nextHook()(v0, v1)
-	m.ExecFunc.appendCall(SearchContextsStoreExecFuncCall{v0, v1, r0})
-	return r0
+// ListSavedSearchesByOrgID delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) ListSavedSearchesByOrgID(v0 context.Context, v1 int32) ([]*types.SavedSearch, error) {
+	r0, r1 := m.ListSavedSearchesByOrgIDFunc.nextHook()(v0, v1)
+	m.ListSavedSearchesByOrgIDFunc.appendCall(SavedSearchStoreListSavedSearchesByOrgIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Exec method of the
-// parent MockSearchContextsStore instance is invoked and the hook queue is
-// empty.
-func (f *SearchContextsStoreExecFunc) SetDefaultHook(hook func(context.Context, *sqlf.Query) error) {
+// SetDefaultHook sets function that is called when the
+// ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
+// instance is invoked and the hook queue is empty.
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) SetDefaultHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Exec method of the parent MockSearchContextsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SearchContextsStoreExecFunc) PushHook(hook func(context.Context, *sqlf.Query) error) {
+// ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -26739,21 +27356,21 @@ func (f *SearchContextsStoreExecFunc) PushHook(hook func(context.Context, *sqlf.
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreExecFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, *sqlf.Query) error {
-		return r0
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreExecFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, *sqlf.Query) error {
-		return r0
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreExecFunc) nextHook() func(context.Context, *sqlf.Query) error {
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) nextHook() func(context.Context, int32) ([]*types.SavedSearch, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -26766,80 +27383,85 @@ func (f *SearchContextsStoreExecFunc) nextHook() func(context.Context, *sqlf.Que
 	return hook
 }
 
-func (f *SearchContextsStoreExecFunc) appendCall(r0 SearchContextsStoreExecFuncCall) {
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) appendCall(r0 SavedSearchStoreListSavedSearchesByOrgIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SearchContextsStoreExecFuncCall objects
-// describing the invocations of this function.
-func (f *SearchContextsStoreExecFunc) History() []SearchContextsStoreExecFuncCall {
+// History returns a sequence of
+// SavedSearchStoreListSavedSearchesByOrgIDFuncCall objects describing the
+// invocations of this function.
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) History() []SavedSearchStoreListSavedSearchesByOrgIDFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreExecFuncCall, len(f.history))
+	history := make([]SavedSearchStoreListSavedSearchesByOrgIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreExecFuncCall is an object that describes an invocation
-// of method Exec on an instance of MockSearchContextsStore.
-type SearchContextsStoreExecFuncCall struct {
+// SavedSearchStoreListSavedSearchesByOrgIDFuncCall is an object that
+// describes an invocation of method ListSavedSearchesByOrgID on an instance
+// of MockSavedSearchStore.
+type SavedSearchStoreListSavedSearchesByOrgIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *sqlf.Query
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 []*types.SavedSearch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreExecFuncCall) Args() []interface{} {
+func (c SavedSearchStoreListSavedSearchesByOrgIDFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreExecFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SavedSearchStoreListSavedSearchesByOrgIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreGetAllQueriesFunc describes the behavior when the
-// GetAllQueries method of the parent MockSearchContextsStore instance is
-// invoked.
-type SearchContextsStoreGetAllQueriesFunc struct {
-	defaultHook func(context.Context) ([]string, error)
-	hooks       []func(context.Context) ([]string, error)
-	history     []SearchContextsStoreGetAllQueriesFuncCall
+// SavedSearchStoreListSavedSearchesByUserIDFunc describes the behavior when
+// the ListSavedSearchesByUserID method of the parent MockSavedSearchStore
+// instance is invoked.
+type SavedSearchStoreListSavedSearchesByUserIDFunc struct {
+	defaultHook func(context.Context, int32) ([]*types.SavedSearch, error)
+	hooks       []func(context.Context, int32) ([]*types.SavedSearch, error)
+	history     []SavedSearchStoreListSavedSearchesByUserIDFuncCall
 	mutex       sync.Mutex
 }
 
-// GetAllQueries delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) GetAllQueries(v0 context.Context) ([]string, error) {
-	r0, r1 := m.GetAllQueriesFunc.nextHook()(v0)
-	m.GetAllQueriesFunc.appendCall(SearchContextsStoreGetAllQueriesFuncCall{v0, r0, r1})
+// ListSavedSearchesByUserID delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) ListSavedSearchesByUserID(v0 context.Context, v1 int32) ([]*types.SavedSearch, error) {
+	r0, r1 := m.ListSavedSearchesByUserIDFunc.nextHook()(v0, v1)
+	m.ListSavedSearchesByUserIDFunc.appendCall(SavedSearchStoreListSavedSearchesByUserIDFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetAllQueries method
-// of the parent MockSearchContextsStore instance is invoked and the hook
-// queue is empty.
-func (f *SearchContextsStoreGetAllQueriesFunc) SetDefaultHook(hook func(context.Context) ([]string, error)) {
+// SetDefaultHook sets function that is called when the
+// ListSavedSearchesByUserID method of the parent MockSavedSearchStore
+// instance is invoked and the hook queue is empty.
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) SetDefaultHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetAllQueries method of the parent MockSearchContextsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *SearchContextsStoreGetAllQueriesFunc) PushHook(hook func(context.Context) ([]string, error)) {
+// ListSavedSearchesByUserID method of the parent MockSavedSearchStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -26847,21 +27469,21 @@ func (f *SearchContextsStoreGetAllQueriesFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreGetAllQueriesFunc) SetDefaultReturn(r0 []string, r1 error) {
-	f.SetDefaultHook(func(context.Context) ([]string, error) {
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreGetAllQueriesFunc) PushReturn(r0 []string, r1 error) {
-	f.PushHook(func(context.Context) ([]string, error) {
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreGetAllQueriesFunc) nextHook() func(context.Context) ([]string, error) {
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) nextHook() func(context.Context, int32) ([]*types.SavedSearch, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -26874,33 +27496,37 @@ func (f *SearchContextsStoreGetAllQueriesFunc) nextHook() func(context.Context)
 	return hook
 }
 
-func (f *SearchContextsStoreGetAllQueriesFunc) appendCall(r0 SearchContextsStoreGetAllQueriesFuncCall) {
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) appendCall(r0 SavedSearchStoreListSavedSearchesByUserIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SearchContextsStoreGetAllQueriesFuncCall
-// objects describing the invocations of this function.
-func (f *SearchContextsStoreGetAllQueriesFunc) History() []SearchContextsStoreGetAllQueriesFuncCall {
+// History returns a sequence of
+// SavedSearchStoreListSavedSearchesByUserIDFuncCall objects describing the
+// invocations of this function.
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) History() []SavedSearchStoreListSavedSearchesByUserIDFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreGetAllQueriesFuncCall, len(f.history))
+	history := make([]SavedSearchStoreListSavedSearchesByUserIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreGetAllQueriesFuncCall is an object that describes an
-// invocation of method GetAllQueries on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreGetAllQueriesFuncCall struct {
+// SavedSearchStoreListSavedSearchesByUserIDFuncCall is an object that
+// describes an invocation of method ListSavedSearchesByUserID on an
+// instance of MockSavedSearchStore.
+type SavedSearchStoreListSavedSearchesByUserIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []string
+	Result0 []*types.SavedSearch
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -26908,47 +27534,45 @@ type SearchContextsStoreGetAllQueriesFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreGetAllQueriesFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SavedSearchStoreListSavedSearchesByUserIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreGetAllQueriesFuncCall) Results() []interface{} {
+func (c SavedSearchStoreListSavedSearchesByUserIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreGetAllRevisionsForReposFunc describes the behavior
-// when the GetAllRevisionsForRepos method of the parent
-// MockSearchContextsStore instance is invoked.
-type SearchContextsStoreGetAllRevisionsForReposFunc struct {
-	defaultHook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
-	hooks       []func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
-	history     []SearchContextsStoreGetAllRevisionsForReposFuncCall
+// SavedSearchStoreTransactFunc describes the behavior when the Transact
+// method of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreTransactFunc struct {
+	defaultHook func(context.Context) (SavedSearchStore, error)
+	hooks       []func(context.Context) (SavedSearchStore, error)
+	history     []SavedSearchStoreTransactFuncCall
 	mutex       sync.Mutex
 }
 
-// GetAllRevisionsForRepos delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) GetAllRevisionsForRepos(v0 context.Context, v1 []api.RepoID) (map[api.RepoID][]string, error) {
-	r0, r1 := m.GetAllRevisionsForReposFunc.nextHook()(v0, v1)
-	m.GetAllRevisionsForReposFunc.appendCall(SearchContextsStoreGetAllRevisionsForReposFuncCall{v0, v1, r0, r1})
+// Transact delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) Transact(v0 context.Context) (SavedSearchStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(SavedSearchStoreTransactFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// GetAllRevisionsForRepos method of the parent MockSearchContextsStore
-// instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) SetDefaultHook(hook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)) {
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SavedSearchStore, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetAllRevisionsForRepos method of the parent MockSearchContextsStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushHook(hook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)) {
+// Transact method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreTransactFunc) PushHook(hook func(context.Context) (SavedSearchStore, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -26956,21 +27580,21 @@ func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushHook(hook func(cont
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) SetDefaultReturn(r0 map[api.RepoID][]string, r1 error) {
-	f.SetDefaultHook(func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+func (f *SavedSearchStoreTransactFunc) SetDefaultReturn(r0 SavedSearchStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (SavedSearchStore, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushReturn(r0 map[api.RepoID][]string, r1 error) {
-	f.PushHook(func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+func (f *SavedSearchStoreTransactFunc) PushReturn(r0 SavedSearchStore, r1 error) {
+	f.PushHook(func(context.Context) (SavedSearchStore, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) nextHook() func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+func (f *SavedSearchStoreTransactFunc) nextHook() func(context.Context) (SavedSearchStore, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -26983,37 +27607,32 @@ func (f *SearchContextsStoreGetAllRevisionsForReposFunc) nextHook() func(context
 	return hook
 }
 
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) appendCall(r0 SearchContextsStoreGetAllRevisionsForReposFuncCall) {
+func (f *SavedSearchStoreTransactFunc) appendCall(r0 SavedSearchStoreTransactFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SearchContextsStoreGetAllRevisionsForReposFuncCall objects describing the
-// invocations of this function.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) History() []SearchContextsStoreGetAllRevisionsForReposFuncCall {
+// History returns a sequence of SavedSearchStoreTransactFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreTransactFunc) History() []SavedSearchStoreTransactFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreGetAllRevisionsForReposFuncCall, len(f.history))
+	history := make([]SavedSearchStoreTransactFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreGetAllRevisionsForReposFuncCall is an object that
-// describes an invocation of method GetAllRevisionsForRepos on an instance
-// of MockSearchContextsStore.
-type SearchContextsStoreGetAllRevisionsForReposFuncCall struct {
+// SavedSearchStoreTransactFuncCall is an object that describes an
+// invocation of method Transact on an instance of MockSavedSearchStore.
+type SavedSearchStoreTransactFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 []api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 map[api.RepoID][]string
+	Result0 SavedSearchStore
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -27021,47 +27640,45 @@ type SearchContextsStoreGetAllRevisionsForReposFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreGetAllRevisionsForReposFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c SavedSearchStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreGetAllRevisionsForReposFuncCall) Results() []interface{} {
+func (c SavedSearchStoreTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreGetSearchContextFunc describes the behavior when the
-// GetSearchContext method of the parent MockSearchContextsStore instance is
-// invoked.
-type SearchContextsStoreGetSearchContextFunc struct {
-	defaultHook func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)
-	hooks       []func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)
-	history     []SearchContextsStoreGetSearchContextFuncCall
+// SavedSearchStoreUpdateFunc describes the behavior when the Update method
+// of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreUpdateFunc struct {
+	defaultHook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	hooks       []func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	history     []SavedSearchStoreUpdateFuncCall
 	mutex       sync.Mutex
 }
 
-// GetSearchContext delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) GetSearchContext(v0 context.Context, v1 GetSearchContextOptions) (*types.SearchContext, error) {
-	r0, r1 := m.GetSearchContextFunc.nextHook()(v0, v1)
-	m.GetSearchContextFunc.appendCall(SearchContextsStoreGetSearchContextFuncCall{v0, v1, r0, r1})
+// Update delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) Update(v0 context.Context, v1 *types.SavedSearch) (*types.SavedSearch, error) {
+	r0, r1 := m.UpdateFunc.nextHook()(v0, v1)
+	m.UpdateFunc.appendCall(SavedSearchStoreUpdateFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetSearchContext
-// method of the parent MockSearchContextsStore instance is invoked and the
-// hook queue is empty.
-func (f *SearchContextsStoreGetSearchContextFunc) SetDefaultHook(hook func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)) {
+// SetDefaultHook sets function that is called when the Update method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreUpdateFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetSearchContext method of the parent MockSearchContextsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *SearchContextsStoreGetSearchContextFunc) PushHook(hook func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)) {
+// Update method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreUpdateFunc) PushHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -27069,21 +27686,21 @@ func (f *SearchContextsStoreGetSearchContextFunc) PushHook(hook func(context.Con
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreGetSearchContextFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
-	f.SetDefaultHook(func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
+func (f *SavedSearchStoreUpdateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreGetSearchContextFunc) PushReturn(r0 *types.SearchContext, r1 error) {
-	f.PushHook(func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
+func (f *SavedSearchStoreUpdateFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreGetSearchContextFunc) nextHook() func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
+func (f *SavedSearchStoreUpdateFunc) nextHook() func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -27096,36 +27713,35 @@ func (f *SearchContextsStoreGetSearchContextFunc) nextHook() func(context.Contex
 	return hook
 }
 
-func (f *SearchContextsStoreGetSearchContextFunc) appendCall(r0 SearchContextsStoreGetSearchContextFuncCall) {
+func (f *SavedSearchStoreUpdateFunc) appendCall(r0 SavedSearchStoreUpdateFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SearchContextsStoreGetSearchContextFuncCall
-// objects describing the invocations of this function.
-func (f *SearchContextsStoreGetSearchContextFunc) History() []SearchContextsStoreGetSearchContextFuncCall {
+// History returns a sequence of SavedSearchStoreUpdateFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreUpdateFunc) History() []SavedSearchStoreUpdateFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreGetSearchContextFuncCall, len(f.history))
+	history := make([]SavedSearchStoreUpdateFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreGetSearchContextFuncCall is an object that describes
-// an invocation of method GetSearchContext on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreGetSearchContextFuncCall struct {
+// SavedSearchStoreUpdateFuncCall is an object that describes an invocation
+// of method Update on an instance of MockSavedSearchStore.
+type SavedSearchStoreUpdateFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 GetSearchContextOptions
+	Arg1 *types.SavedSearch
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.SearchContext
+	Result0 *types.SavedSearch
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -27133,48 +27749,45 @@ type SearchContextsStoreGetSearchContextFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreGetSearchContextFuncCall) Args() []interface{} {
+func (c SavedSearchStoreUpdateFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreGetSearchContextFuncCall) Results() []interface{} {
+func (c SavedSearchStoreUpdateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreGetSearchContextRepositoryRevisionsFunc describes the
-// behavior when the GetSearchContextRepositoryRevisions method of the
-// parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreGetSearchContextRepositoryRevisionsFunc struct {
-	defaultHook func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)
-	hooks       []func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)
-	history     []SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall
+// SavedSearchStoreWithFunc describes the behavior when the With method of
+// the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) SavedSearchStore
+	hooks       []func(basestore.ShareableStore) SavedSearchStore
+	history     []SavedSearchStoreWithFuncCall
 	mutex       sync.Mutex
 }
 
-// GetSearchContextRepositoryRevisions delegates to the next hook function
-// in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockSearchContextsStore) GetSearchContextRepositoryRevisions(v0 context.Context, v1 int64) ([]*types.SearchContextRepositoryRevisions, error) {
-	r0, r1 := m.GetSearchContextRepositoryRevisionsFunc.nextHook()(v0, v1)
-	m.GetSearchContextRepositoryRevisionsFunc.appendCall(SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) With(v0 basestore.ShareableStore) SavedSearchStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(SavedSearchStoreWithFuncCall{v0, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// GetSearchContextRepositoryRevisions method of the parent
-// MockSearchContextsStore instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)) {
-	f.defaultHook = hook
-}
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) SavedSearchStore) {
+	f.defaultHook = hook
+}
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetSearchContextRepositoryRevisions method of the parent
-// MockSearchContextsStore instance invokes the hook at the front of the
-// queue and discards it. After the queue is empty, the default hook
-// function is invoked for any future action.
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) PushHook(hook func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)) {
+// With method of the parent MockSavedSearchStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SavedSearchStoreWithFunc) PushHook(hook func(basestore.ShareableStore) SavedSearchStore) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -27182,21 +27795,21 @@ func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) PushHook(ho
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) SetDefaultReturn(r0 []*types.SearchContextRepositoryRevisions, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
-		return r0, r1
+func (f *SavedSearchStoreWithFunc) SetDefaultReturn(r0 SavedSearchStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) SavedSearchStore {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) PushReturn(r0 []*types.SearchContextRepositoryRevisions, r1 error) {
-	f.PushHook(func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
-		return r0, r1
+func (f *SavedSearchStoreWithFunc) PushReturn(r0 SavedSearchStore) {
+	f.PushHook(func(basestore.ShareableStore) SavedSearchStore {
+		return r0
 	})
 }
 
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) nextHook() func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
+func (f *SavedSearchStoreWithFunc) nextHook() func(basestore.ShareableStore) SavedSearchStore {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -27209,84 +27822,336 @@ func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) nextHook()
 	return hook
 }
 
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) {
+func (f *SavedSearchStoreWithFunc) appendCall(r0 SavedSearchStoreWithFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall objects
+// History returns a sequence of SavedSearchStoreWithFuncCall objects
 // describing the invocations of this function.
-func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) History() []SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall {
+func (f *SavedSearchStoreWithFunc) History() []SavedSearchStoreWithFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall, len(f.history))
+	history := make([]SavedSearchStoreWithFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall is an
-// object that describes an invocation of method
-// GetSearchContextRepositoryRevisions on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall struct {
+// SavedSearchStoreWithFuncCall is an object that describes an invocation of
+// method With on an instance of MockSavedSearchStore.
+type SavedSearchStoreWithFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int64
+	Arg0 basestore.ShareableStore
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.SearchContextRepositoryRevisions
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 SavedSearchStore
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c SavedSearchStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SavedSearchStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SearchContextsStoreHandleFunc describes the behavior when the Handle
-// method of the parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreHandleFunc struct {
-	defaultHook func() *basestore.TransactableHandle
-	hooks       []func() *basestore.TransactableHandle
-	history     []SearchContextsStoreHandleFuncCall
+// MockSearchContextsStore is a mock implementation of the
+// SearchContextsStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockSearchContextsStore struct {
+	// CountSearchContextsFunc is an instance of a mock function object
+	// controlling the behavior of the method CountSearchContexts.
+	CountSearchContextsFunc *SearchContextsStoreCountSearchContextsFunc
+	// CreateSearchContextWithRepositoryRevisionsFunc is an instance of a
+	// mock function object controlling the behavior of the method
+	// CreateSearchContextWithRepositoryRevisions.
+	CreateSearchContextWithRepositoryRevisionsFunc *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc
+	// DeleteSearchContextFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteSearchContext.
+	DeleteSearchContextFunc *SearchContextsStoreDeleteSearchContextFunc
+	// DoneFunc is an instance of a mock function object controlling the
+	// behavior of the method Done.
+	DoneFunc *SearchContextsStoreDoneFunc
+	// ExecFunc is an instance of a mock function object controlling the
+	// behavior of the method Exec.
+	ExecFunc *SearchContextsStoreExecFunc
+	// GetAllQueriesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetAllQueries.
+	GetAllQueriesFunc *SearchContextsStoreGetAllQueriesFunc
+	// GetAllRevisionsForReposFunc is an instance of a mock function object
+	// controlling the behavior of the method GetAllRevisionsForRepos.
+	GetAllRevisionsForReposFunc *SearchContextsStoreGetAllRevisionsForReposFunc
+	// GetSearchContextFunc is an instance of a mock function object
+	// controlling the behavior of the method GetSearchContext.
+	GetSearchContextFunc *SearchContextsStoreGetSearchContextFunc
+	// GetSearchContextRepositoryRevisionsFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetSearchContextRepositoryRevisions.
+	GetSearchContextRepositoryRevisionsFunc *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *SearchContextsStoreHandleFunc
+	// ListSearchContextsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListSearchContexts.
+	ListSearchContextsFunc *SearchContextsStoreListSearchContextsFunc
+	// SetSearchContextRepositoryRevisionsFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// SetSearchContextRepositoryRevisions.
+	SetSearchContextRepositoryRevisionsFunc *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc
+	// TransactFunc is an instance of a mock function object controlling the
+	// behavior of the method Transact.
+	TransactFunc *SearchContextsStoreTransactFunc
+	// UpdateSearchContextWithRepositoryRevisionsFunc is an instance of a
+	// mock function object controlling the behavior of the method
+	// UpdateSearchContextWithRepositoryRevisions.
+	UpdateSearchContextWithRepositoryRevisionsFunc *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc
+}
+
+// NewMockSearchContextsStore creates a new mock of the SearchContextsStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockSearchContextsStore() *MockSearchContextsStore {
+	return &MockSearchContextsStore{
+		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
+			defaultHook: func(context.Context, ListSearchContextsOptions) (int32, error) {
+				return 0, nil
+			},
+		},
+		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+				return nil, nil
+			},
+		},
+		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
+			defaultHook: func(context.Context, int64) error {
+				return nil
+			},
+		},
+		DoneFunc: &SearchContextsStoreDoneFunc{
+			defaultHook: func(error) error {
+				return nil
+			},
+		},
+		ExecFunc: &SearchContextsStoreExecFunc{
+			defaultHook: func(context.Context, *sqlf.Query) error {
+				return nil
+			},
+		},
+		GetAllQueriesFunc: &SearchContextsStoreGetAllQueriesFunc{
+			defaultHook: func(context.Context) ([]string, error) {
+				return nil, nil
+			},
+		},
+		GetAllRevisionsForReposFunc: &SearchContextsStoreGetAllRevisionsForReposFunc{
+			defaultHook: func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+				return nil, nil
+			},
+		},
+		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
+			defaultHook: func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
+				return nil, nil
+			},
+		},
+		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
+				return nil, nil
+			},
+		},
+		HandleFunc: &SearchContextsStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				return nil
+			},
+		},
+		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
+			defaultHook: func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
+				return nil, nil
+			},
+		},
+		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
+				return nil
+			},
+		},
+		TransactFunc: &SearchContextsStoreTransactFunc{
+			defaultHook: func(context.Context) (SearchContextsStore, error) {
+				return nil, nil
+			},
+		},
+		UpdateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+				return nil, nil
+			},
+		},
+	}
+}
+
+// NewStrictMockSearchContextsStore creates a new mock of the
+// SearchContextsStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockSearchContextsStore() *MockSearchContextsStore {
+	return &MockSearchContextsStore{
+		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
+			defaultHook: func(context.Context, ListSearchContextsOptions) (int32, error) {
+				panic("unexpected invocation of MockSearchContextsStore.CountSearchContexts")
+			},
+		},
+		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+				panic("unexpected invocation of MockSearchContextsStore.CreateSearchContextWithRepositoryRevisions")
+			},
+		},
+		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockSearchContextsStore.DeleteSearchContext")
+			},
+		},
+		DoneFunc: &SearchContextsStoreDoneFunc{
+			defaultHook: func(error) error {
+				panic("unexpected invocation of MockSearchContextsStore.Done")
+			},
+		},
+		ExecFunc: &SearchContextsStoreExecFunc{
+			defaultHook: func(context.Context, *sqlf.Query) error {
+				panic("unexpected invocation of MockSearchContextsStore.Exec")
+			},
+		},
+		GetAllQueriesFunc: &SearchContextsStoreGetAllQueriesFunc{
+			defaultHook: func(context.Context) ([]string, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetAllQueries")
+			},
+		},
+		GetAllRevisionsForReposFunc: &SearchContextsStoreGetAllRevisionsForReposFunc{
+			defaultHook: func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetAllRevisionsForRepos")
+			},
+		},
+		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
+			defaultHook: func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetSearchContext")
+			},
+		},
+		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetSearchContextRepositoryRevisions")
+			},
+		},
+		HandleFunc: &SearchContextsStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				panic("unexpected invocation of MockSearchContextsStore.Handle")
+			},
+		},
+		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
+			defaultHook: func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
+				panic("unexpected invocation of MockSearchContextsStore.ListSearchContexts")
+			},
+		},
+		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
+				panic("unexpected invocation of MockSearchContextsStore.SetSearchContextRepositoryRevisions")
+			},
+		},
+		TransactFunc: &SearchContextsStoreTransactFunc{
+			defaultHook: func(context.Context) (SearchContextsStore, error) {
+				panic("unexpected invocation of MockSearchContextsStore.Transact")
+			},
+		},
+		UpdateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc{
+			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+				panic("unexpected invocation of MockSearchContextsStore.UpdateSearchContextWithRepositoryRevisions")
+			},
+		},
+	}
+}
+
+// NewMockSearchContextsStoreFrom creates a new mock of the
+// MockSearchContextsStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockSearchContextsStoreFrom(i SearchContextsStore) *MockSearchContextsStore {
+	return &MockSearchContextsStore{
+		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
+			defaultHook: i.CountSearchContexts,
+		},
+		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
+			defaultHook: i.CreateSearchContextWithRepositoryRevisions,
+		},
+		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
+			defaultHook: i.DeleteSearchContext,
+		},
+		DoneFunc: &SearchContextsStoreDoneFunc{
+			defaultHook: i.Done,
+		},
+		ExecFunc: &SearchContextsStoreExecFunc{
+			defaultHook: i.Exec,
+		},
+		GetAllQueriesFunc: &SearchContextsStoreGetAllQueriesFunc{
+			defaultHook: i.GetAllQueries,
+		},
+		GetAllRevisionsForReposFunc: &SearchContextsStoreGetAllRevisionsForReposFunc{
+			defaultHook: i.GetAllRevisionsForRepos,
+		},
+		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
+			defaultHook: i.GetSearchContext,
+		},
+		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
+			defaultHook: i.GetSearchContextRepositoryRevisions,
+		},
+		HandleFunc: &SearchContextsStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
+			defaultHook: i.ListSearchContexts,
+		},
+		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
+			defaultHook: i.SetSearchContextRepositoryRevisions,
+		},
+		TransactFunc: &SearchContextsStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		UpdateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc{
+			defaultHook: i.UpdateSearchContextWithRepositoryRevisions,
+		},
+	}
+}
+
+// SearchContextsStoreCountSearchContextsFunc describes the behavior when
+// the CountSearchContexts method of the parent MockSearchContextsStore
+// instance is invoked.
+type SearchContextsStoreCountSearchContextsFunc struct {
+	defaultHook func(context.Context, ListSearchContextsOptions) (int32, error)
+	hooks       []func(context.Context, ListSearchContextsOptions) (int32, error)
+	history     []SearchContextsStoreCountSearchContextsFuncCall
 	mutex       sync.Mutex
 }
 
-// Handle delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSearchContextsStore) Handle() *basestore.TransactableHandle {
-	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(SearchContextsStoreHandleFuncCall{r0})
-	return r0
+// CountSearchContexts delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) CountSearchContexts(v0 context.Context, v1 ListSearchContextsOptions) (int32, error) {
+	r0, r1 := m.CountSearchContextsFunc.nextHook()(v0, v1)
+	m.CountSearchContextsFunc.appendCall(SearchContextsStoreCountSearchContextsFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Handle method of the
-// parent MockSearchContextsStore instance is invoked and the hook queue is
-// empty.
-func (f *SearchContextsStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
+// SetDefaultHook sets function that is called when the CountSearchContexts
+// method of the parent MockSearchContextsStore instance is invoked and the
+// hook queue is empty.
+func (f *SearchContextsStoreCountSearchContextsFunc) SetDefaultHook(hook func(context.Context, ListSearchContextsOptions) (int32, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockSearchContextsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SearchContextsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
+// CountSearchContexts method of the parent MockSearchContextsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SearchContextsStoreCountSearchContextsFunc) PushHook(hook func(context.Context, ListSearchContextsOptions) (int32, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -27294,21 +28159,21 @@ func (f *SearchContextsStoreHandleFunc) PushHook(hook func() *basestore.Transact
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
-	f.SetDefaultHook(func() *basestore.TransactableHandle {
-		return r0
+func (f *SearchContextsStoreCountSearchContextsFunc) SetDefaultReturn(r0 int32, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListSearchContextsOptions) (int32, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
-	f.PushHook(func() *basestore.TransactableHandle {
-		return r0
+func (f *SearchContextsStoreCountSearchContextsFunc) PushReturn(r0 int32, r1 error) {
+	f.PushHook(func(context.Context, ListSearchContextsOptions) (int32, error) {
+		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
+func (f *SearchContextsStoreCountSearchContextsFunc) nextHook() func(context.Context, ListSearchContextsOptions) (int32, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -27321,74 +28186,87 @@ func (f *SearchContextsStoreHandleFunc) nextHook() func() *basestore.Transactabl
 	return hook
 }
 
-func (f *SearchContextsStoreHandleFunc) appendCall(r0 SearchContextsStoreHandleFuncCall) {
+func (f *SearchContextsStoreCountSearchContextsFunc) appendCall(r0 SearchContextsStoreCountSearchContextsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SearchContextsStoreHandleFuncCall objects
-// describing the invocations of this function.
-func (f *SearchContextsStoreHandleFunc) History() []SearchContextsStoreHandleFuncCall {
+// History returns a sequence of
+// SearchContextsStoreCountSearchContextsFuncCall objects describing the
+// invocations of this function.
+func (f *SearchContextsStoreCountSearchContextsFunc) History() []SearchContextsStoreCountSearchContextsFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreHandleFuncCall, len(f.history))
+	history := make([]SearchContextsStoreCountSearchContextsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreHandleFuncCall is an object that describes an
-// invocation of method Handle on an instance of MockSearchContextsStore.
-type SearchContextsStoreHandleFuncCall struct {
+// SearchContextsStoreCountSearchContextsFuncCall is an object that
+// describes an invocation of method CountSearchContexts on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreCountSearchContextsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ListSearchContextsOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *basestore.TransactableHandle
+	Result0 int32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreHandleFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c SearchContextsStoreCountSearchContextsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreHandleFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SearchContextsStoreCountSearchContextsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreListSearchContextsFunc describes the behavior when the
-// ListSearchContexts method of the parent MockSearchContextsStore instance
-// is invoked.
-type SearchContextsStoreListSearchContextsFunc struct {
-	defaultHook func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)
-	hooks       []func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)
-	history     []SearchContextsStoreListSearchContextsFuncCall
+// SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc
+// describes the behavior when the
+// CreateSearchContextWithRepositoryRevisions method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc struct {
+	defaultHook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
+	hooks       []func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
+	history     []SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListSearchContexts delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) ListSearchContexts(v0 context.Context, v1 ListSearchContextsPageOptions, v2 ListSearchContextsOptions) ([]*types.SearchContext, error) {
-	r0, r1 := m.ListSearchContextsFunc.nextHook()(v0, v1, v2)
-	m.ListSearchContextsFunc.appendCall(SearchContextsStoreListSearchContextsFuncCall{v0, v1, v2, r0, r1})
+// CreateSearchContextWithRepositoryRevisions delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockSearchContextsStore) CreateSearchContextWithRepositoryRevisions(v0 context.Context, v1 *types.SearchContext, v2 []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+	r0, r1 := m.CreateSearchContextWithRepositoryRevisionsFunc.nextHook()(v0, v1, v2)
+	m.CreateSearchContextWithRepositoryRevisionsFunc.appendCall(SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListSearchContexts
-// method of the parent MockSearchContextsStore instance is invoked and the
-// hook queue is empty.
-func (f *SearchContextsStoreListSearchContextsFunc) SetDefaultHook(hook func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)) {
+// SetDefaultHook sets function that is called when the
+// CreateSearchContextWithRepositoryRevisions method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListSearchContexts method of the parent MockSearchContextsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *SearchContextsStoreListSearchContextsFunc) PushHook(hook func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)) {
+// CreateSearchContextWithRepositoryRevisions method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) PushHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -27396,21 +28274,21 @@ func (f *SearchContextsStoreListSearchContextsFunc) PushHook(hook func(context.C
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreListSearchContextsFunc) SetDefaultReturn(r0 []*types.SearchContext, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreListSearchContextsFunc) PushReturn(r0 []*types.SearchContext, r1 error) {
-	f.PushHook(func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) PushReturn(r0 *types.SearchContext, r1 error) {
+	f.PushHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreListSearchContextsFunc) nextHook() func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) nextHook() func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -27423,40 +28301,41 @@ func (f *SearchContextsStoreListSearchContextsFunc) nextHook() func(context.Cont
 	return hook
 }
 
-func (f *SearchContextsStoreListSearchContextsFunc) appendCall(r0 SearchContextsStoreListSearchContextsFuncCall) {
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// SearchContextsStoreListSearchContextsFuncCall objects describing the
-// invocations of this function.
-func (f *SearchContextsStoreListSearchContextsFunc) History() []SearchContextsStoreListSearchContextsFuncCall {
+// SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall
+// objects describing the invocations of this function.
+func (f *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc) History() []SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreListSearchContextsFuncCall, len(f.history))
+	history := make([]SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreListSearchContextsFuncCall is an object that describes
-// an invocation of method ListSearchContexts on an instance of
+// SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall is
+// an object that describes an invocation of method
+// CreateSearchContextWithRepositoryRevisions on an instance of
 // MockSearchContextsStore.
-type SearchContextsStoreListSearchContextsFuncCall struct {
+type SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ListSearchContextsPageOptions
+	Arg1 *types.SearchContext
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 ListSearchContextsOptions
+	Arg2 []*types.SearchContextRepositoryRevisions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.SearchContext
+	Result0 *types.SearchContext
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -27464,48 +28343,47 @@ type SearchContextsStoreListSearchContextsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreListSearchContextsFuncCall) Args() []interface{} {
+func (c SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreListSearchContextsFuncCall) Results() []interface{} {
+func (c SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreSetSearchContextRepositoryRevisionsFunc describes the
-// behavior when the SetSearchContextRepositoryRevisions method of the
-// parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreSetSearchContextRepositoryRevisionsFunc struct {
-	defaultHook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error
-	hooks       []func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error
-	history     []SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall
+// SearchContextsStoreDeleteSearchContextFunc describes the behavior when
+// the DeleteSearchContext method of the parent MockSearchContextsStore
+// instance is invoked.
+type SearchContextsStoreDeleteSearchContextFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []SearchContextsStoreDeleteSearchContextFuncCall
 	mutex       sync.Mutex
 }
 
-// SetSearchContextRepositoryRevisions delegates to the next hook function
-// in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockSearchContextsStore) SetSearchContextRepositoryRevisions(v0 context.Context, v1 int64, v2 []*types.SearchContextRepositoryRevisions) error {
-	r0 := m.SetSearchContextRepositoryRevisionsFunc.nextHook()(v0, v1, v2)
-	m.SetSearchContextRepositoryRevisionsFunc.appendCall(SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall{v0, v1, v2, r0})
+// DeleteSearchContext delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) DeleteSearchContext(v0 context.Context, v1 int64) error {
+	r0 := m.DeleteSearchContextFunc.nextHook()(v0, v1)
+	m.DeleteSearchContextFunc.appendCall(SearchContextsStoreDeleteSearchContextFuncCall{v0, v1, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// SetSearchContextRepositoryRevisions method of the parent
-// MockSearchContextsStore instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error) {
+// SetDefaultHook sets function that is called when the DeleteSearchContext
+// method of the parent MockSearchContextsStore instance is invoked and the
+// hook queue is empty.
+func (f *SearchContextsStoreDeleteSearchContextFunc) SetDefaultHook(hook func(context.Context, int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// SetSearchContextRepositoryRevisions method of the parent
-// MockSearchContextsStore instance invokes the hook at the front of the
-// queue and discards it. After the queue is empty, the default hook
-// function is invoked for any future action.
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) PushHook(hook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error) {
+// DeleteSearchContext method of the parent MockSearchContextsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SearchContextsStoreDeleteSearchContextFunc) PushHook(hook func(context.Context, int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -27513,21 +28391,21 @@ func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) PushHook(ho
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
+func (f *SearchContextsStoreDeleteSearchContextFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
+func (f *SearchContextsStoreDeleteSearchContextFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
 		return r0
 	})
 }
 
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) nextHook() func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
+func (f *SearchContextsStoreDeleteSearchContextFunc) nextHook() func(context.Context, int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -27540,38 +28418,34 @@ func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) nextHook()
 	return hook
 }
 
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) {
+func (f *SearchContextsStoreDeleteSearchContextFunc) appendCall(r0 SearchContextsStoreDeleteSearchContextFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall objects
-// describing the invocations of this function.
-func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) History() []SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall {
+// SearchContextsStoreDeleteSearchContextFuncCall objects describing the
+// invocations of this function.
+func (f *SearchContextsStoreDeleteSearchContextFunc) History() []SearchContextsStoreDeleteSearchContextFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall, len(f.history))
+	history := make([]SearchContextsStoreDeleteSearchContextFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall is an
-// object that describes an invocation of method
-// SetSearchContextRepositoryRevisions on an instance of
+// SearchContextsStoreDeleteSearchContextFuncCall is an object that
+// describes an invocation of method DeleteSearchContext on an instance of
 // MockSearchContextsStore.
-type SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall struct {
+type SearchContextsStoreDeleteSearchContextFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 []*types.SearchContextRepositoryRevisions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -27579,45 +28453,45 @@ type SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c SearchContextsStoreDeleteSearchContextFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) Results() []interface{} {
+func (c SearchContextsStoreDeleteSearchContextFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// SearchContextsStoreTransactFunc describes the behavior when the Transact
-// method of the parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreTransactFunc struct {
-	defaultHook func(context.Context) (SearchContextsStore, error)
-	hooks       []func(context.Context) (SearchContextsStore, error)
-	history     []SearchContextsStoreTransactFuncCall
+// SearchContextsStoreDoneFunc describes the behavior when the Done method
+// of the parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []SearchContextsStoreDoneFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
+// Done delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSearchContextsStore) Transact(v0 context.Context) (SearchContextsStore, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(SearchContextsStoreTransactFuncCall{v0, r0, r1})
-	return r0, r1
+func (m *MockSearchContextsStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(SearchContextsStoreDoneFuncCall{v0, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
-// the parent MockSearchContextsStore instance is invoked and the hook queue
-// is empty.
-func (f *SearchContextsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SearchContextsStore, error)) {
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockSearchContextsStore instance is invoked and the hook queue is
+// empty.
+func (f *SearchContextsStoreDoneFunc) SetDefaultHook(hook func(error) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockSearchContextsStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *SearchContextsStoreTransactFunc) PushHook(hook func(context.Context) (SearchContextsStore, error)) {
+// Done method of the parent MockSearchContextsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SearchContextsStoreDoneFunc) PushHook(hook func(error) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -27625,21 +28499,21 @@ func (f *SearchContextsStoreTransactFunc) PushHook(hook func(context.Context) (S
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreTransactFunc) SetDefaultReturn(r0 SearchContextsStore, r1 error) {
-	f.SetDefaultHook(func(context.Context) (SearchContextsStore, error) {
-		return r0, r1
+func (f *SearchContextsStoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreTransactFunc) PushReturn(r0 SearchContextsStore, r1 error) {
-	f.PushHook(func(context.Context) (SearchContextsStore, error) {
-		return r0, r1
+func (f *SearchContextsStoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
+		return r0
 	})
 }
 
-func (f *SearchContextsStoreTransactFunc) nextHook() func(context.Context) (SearchContextsStore, error) {
+func (f *SearchContextsStoreDoneFunc) nextHook() func(error) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -27652,82 +28526,75 @@ func (f *SearchContextsStoreTransactFunc) nextHook() func(context.Context) (Sear
 	return hook
 }
 
-func (f *SearchContextsStoreTransactFunc) appendCall(r0 SearchContextsStoreTransactFuncCall) {
+func (f *SearchContextsStoreDoneFunc) appendCall(r0 SearchContextsStoreDoneFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SearchContextsStoreTransactFuncCall objects
+// History returns a sequence of SearchContextsStoreDoneFuncCall objects
 // describing the invocations of this function.
-func (f *SearchContextsStoreTransactFunc) History() []SearchContextsStoreTransactFuncCall {
+func (f *SearchContextsStoreDoneFunc) History() []SearchContextsStoreDoneFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreTransactFuncCall, len(f.history))
+	history := make([]SearchContextsStoreDoneFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreTransactFuncCall is an object that describes an
-// invocation of method Transact on an instance of MockSearchContextsStore.
-type SearchContextsStoreTransactFuncCall struct {
+// SearchContextsStoreDoneFuncCall is an object that describes an invocation
+// of method Done on an instance of MockSearchContextsStore.
+type SearchContextsStoreDoneFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 context.Context
+	Arg0 error
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 SearchContextsStore
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreTransactFuncCall) Args() []interface{} {
+func (c SearchContextsStoreDoneFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreTransactFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SearchContextsStoreDoneFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc
-// describes the behavior when the
-// UpdateSearchContextWithRepositoryRevisions method of the parent
-// MockSearchContextsStore instance is invoked.
-type SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc struct {
-	defaultHook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
-	hooks       []func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
-	history     []SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall
+// SearchContextsStoreExecFunc describes the behavior when the Exec method
+// of the parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreExecFunc struct {
+	defaultHook func(context.Context, *sqlf.Query) error
+	hooks       []func(context.Context, *sqlf.Query) error
+	history     []SearchContextsStoreExecFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateSearchContextWithRepositoryRevisions delegates to the next hook
-// function in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockSearchContextsStore) UpdateSearchContextWithRepositoryRevisions(v0 context.Context, v1 *types.SearchContext, v2 []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-	r0, r1 := m.UpdateSearchContextWithRepositoryRevisionsFunc.nextHook()(v0, v1, v2)
-	m.UpdateSearchContextWithRepositoryRevisionsFunc.appendCall(SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall{v0, v1, v2, r0, r1})
-	return r0, r1
+// Exec delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchContextsStore) Exec(v0 context.Context, v1 *sqlf.Query) error {
+	r0 := m.ExecFunc.nextHook()(v0, v1)
+	m.ExecFunc.appendCall(SearchContextsStoreExecFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// UpdateSearchContextWithRepositoryRevisions method of the parent
-// MockSearchContextsStore instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
+// SetDefaultHook sets function that is called when the Exec method of the
+// parent MockSearchContextsStore instance is invoked and the hook queue is
+// empty.
+func (f *SearchContextsStoreExecFunc) SetDefaultHook(hook func(context.Context, *sqlf.Query) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateSearchContextWithRepositoryRevisions method of the parent
-// MockSearchContextsStore instance invokes the hook at the front of the
-// queue and discards it. After the queue is empty, the default hook
-// function is invoked for any future action.
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) PushHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
+// Exec method of the parent MockSearchContextsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SearchContextsStoreExecFunc) PushHook(hook func(context.Context, *sqlf.Query) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -27735,21 +28602,21 @@ func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) Push
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
-	f.SetDefaultHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-		return r0, r1
+func (f *SearchContextsStoreExecFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *sqlf.Query) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) PushReturn(r0 *types.SearchContext, r1 error) {
-	f.PushHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
-		return r0, r1
+func (f *SearchContextsStoreExecFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *sqlf.Query) error {
+		return r0
 	})
 }
 
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) nextHook() func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+func (f *SearchContextsStoreExecFunc) nextHook() func(context.Context, *sqlf.Query) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -27762,244 +28629,80 @@ func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) next
 	return hook
 }
 
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall) {
+func (f *SearchContextsStoreExecFunc) appendCall(r0 SearchContextsStoreExecFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall
-// objects describing the invocations of this function.
-func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) History() []SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall {
+// History returns a sequence of SearchContextsStoreExecFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreExecFunc) History() []SearchContextsStoreExecFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall, len(f.history))
+	history := make([]SearchContextsStoreExecFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall is
-// an object that describes an invocation of method
-// UpdateSearchContextWithRepositoryRevisions on an instance of
-// MockSearchContextsStore.
-type SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall struct {
+// SearchContextsStoreExecFuncCall is an object that describes an invocation
+// of method Exec on an instance of MockSearchContextsStore.
+type SearchContextsStoreExecFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *types.SearchContext
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 []*types.SearchContextRepositoryRevisions
+	Arg1 *sqlf.Query
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.SearchContext
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c SearchContextsStoreExecFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
-}
-
-// MockSettingsStore is a mock implementation of the SettingsStore interface
-// (from the package github.com/sourcegraph/sourcegraph/internal/database)
-// used for unit testing.
-type MockSettingsStore struct {
-	// CreateIfUpToDateFunc is an instance of a mock function object
-	// controlling the behavior of the method CreateIfUpToDate.
-	CreateIfUpToDateFunc *SettingsStoreCreateIfUpToDateFunc
-	// DoneFunc is an instance of a mock function object controlling the
-	// behavior of the method Done.
-	DoneFunc *SettingsStoreDoneFunc
-	// GetLastestSchemaSettingsFunc is an instance of a mock function object
-	// controlling the behavior of the method GetLastestSchemaSettings.
-	GetLastestSchemaSettingsFunc *SettingsStoreGetLastestSchemaSettingsFunc
-	// GetLatestFunc is an instance of a mock function object controlling
-	// the behavior of the method GetLatest.
-	GetLatestFunc *SettingsStoreGetLatestFunc
-	// HandleFunc is an instance of a mock function object controlling the
-	// behavior of the method Handle.
-	HandleFunc *SettingsStoreHandleFunc
-	// ListAllFunc is an instance of a mock function object controlling the
-	// behavior of the method ListAll.
-	ListAllFunc *SettingsStoreListAllFunc
-	// TransactFunc is an instance of a mock function object controlling the
-	// behavior of the method Transact.
-	TransactFunc *SettingsStoreTransactFunc
-	// WithFunc is an instance of a mock function object controlling the
-	// behavior of the method With.
-	WithFunc *SettingsStoreWithFunc
-}
-
-// NewMockSettingsStore creates a new mock of the SettingsStore interface.
-// All methods return zero values for all results, unless overwritten.
-func NewMockSettingsStore() *MockSettingsStore {
-	return &MockSettingsStore{
-		CreateIfUpToDateFunc: &SettingsStoreCreateIfUpToDateFunc{
-			defaultHook: func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error) {
-				return nil, nil
-			},
-		},
-		DoneFunc: &SettingsStoreDoneFunc{
-			defaultHook: func(error) error {
-				return nil
-			},
-		},
-		GetLastestSchemaSettingsFunc: &SettingsStoreGetLastestSchemaSettingsFunc{
-			defaultHook: func(context.Context, api.SettingsSubject) (*schema.Settings, error) {
-				return nil, nil
-			},
-		},
-		GetLatestFunc: &SettingsStoreGetLatestFunc{
-			defaultHook: func(context.Context, api.SettingsSubject) (*api.Settings, error) {
-				return nil, nil
-			},
-		},
-		HandleFunc: &SettingsStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				return nil
-			},
-		},
-		ListAllFunc: &SettingsStoreListAllFunc{
-			defaultHook: func(context.Context, string) ([]*api.Settings, error) {
-				return nil, nil
-			},
-		},
-		TransactFunc: &SettingsStoreTransactFunc{
-			defaultHook: func(context.Context) (SettingsStore, error) {
-				return nil, nil
-			},
-		},
-		WithFunc: &SettingsStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) SettingsStore {
-				return nil
-			},
-		},
-	}
-}
-
-// NewStrictMockSettingsStore creates a new mock of the SettingsStore
-// interface. All methods panic on invocation, unless overwritten.
-func NewStrictMockSettingsStore() *MockSettingsStore {
-	return &MockSettingsStore{
-		CreateIfUpToDateFunc: &SettingsStoreCreateIfUpToDateFunc{
-			defaultHook: func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error) {
-				panic("unexpected invocation of MockSettingsStore.CreateIfUpToDate")
-			},
-		},
-		DoneFunc: &SettingsStoreDoneFunc{
-			defaultHook: func(error) error {
-				panic("unexpected invocation of MockSettingsStore.Done")
-			},
-		},
-		GetLastestSchemaSettingsFunc: &SettingsStoreGetLastestSchemaSettingsFunc{
-			defaultHook: func(context.Context, api.SettingsSubject) (*schema.Settings, error) {
-				panic("unexpected invocation of MockSettingsStore.GetLastestSchemaSettings")
-			},
-		},
-		GetLatestFunc: &SettingsStoreGetLatestFunc{
-			defaultHook: func(context.Context, api.SettingsSubject) (*api.Settings, error) {
-				panic("unexpected invocation of MockSettingsStore.GetLatest")
-			},
-		},
-		HandleFunc: &SettingsStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				panic("unexpected invocation of MockSettingsStore.Handle")
-			},
-		},
-		ListAllFunc: &SettingsStoreListAllFunc{
-			defaultHook: func(context.Context, string) ([]*api.Settings, error) {
-				panic("unexpected invocation of MockSettingsStore.ListAll")
-			},
-		},
-		TransactFunc: &SettingsStoreTransactFunc{
-			defaultHook: func(context.Context) (SettingsStore, error) {
-				panic("unexpected invocation of MockSettingsStore.Transact")
-			},
-		},
-		WithFunc: &SettingsStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) SettingsStore {
-				panic("unexpected invocation of MockSettingsStore.With")
-			},
-		},
-	}
-}
-
-// NewMockSettingsStoreFrom creates a new mock of the MockSettingsStore
-// interface. All methods delegate to the given implementation, unless
-// overwritten.
-func NewMockSettingsStoreFrom(i SettingsStore) *MockSettingsStore {
-	return &MockSettingsStore{
-		CreateIfUpToDateFunc: &SettingsStoreCreateIfUpToDateFunc{
-			defaultHook: i.CreateIfUpToDate,
-		},
-		DoneFunc: &SettingsStoreDoneFunc{
-			defaultHook: i.Done,
-		},
-		GetLastestSchemaSettingsFunc: &SettingsStoreGetLastestSchemaSettingsFunc{
-			defaultHook: i.GetLastestSchemaSettings,
-		},
-		GetLatestFunc: &SettingsStoreGetLatestFunc{
-			defaultHook: i.GetLatest,
-		},
-		HandleFunc: &SettingsStoreHandleFunc{
-			defaultHook: i.Handle,
-		},
-		ListAllFunc: &SettingsStoreListAllFunc{
-			defaultHook: i.ListAll,
-		},
-		TransactFunc: &SettingsStoreTransactFunc{
-			defaultHook: i.Transact,
-		},
-		WithFunc: &SettingsStoreWithFunc{
-			defaultHook: i.With,
-		},
-	}
+func (c SearchContextsStoreExecFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SettingsStoreCreateIfUpToDateFunc describes the behavior when the
-// CreateIfUpToDate method of the parent MockSettingsStore instance is
+// SearchContextsStoreGetAllQueriesFunc describes the behavior when the
+// GetAllQueries method of the parent MockSearchContextsStore instance is
 // invoked.
-type SettingsStoreCreateIfUpToDateFunc struct {
-	defaultHook func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error)
-	hooks       []func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error)
-	history     []SettingsStoreCreateIfUpToDateFuncCall
+type SearchContextsStoreGetAllQueriesFunc struct {
+	defaultHook func(context.Context) ([]string, error)
+	hooks       []func(context.Context) ([]string, error)
+	history     []SearchContextsStoreGetAllQueriesFuncCall
 	mutex       sync.Mutex
 }
 
-// CreateIfUpToDate delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockSettingsStore) CreateIfUpToDate(v0 context.Context, v1 api.SettingsSubject, v2 *int32, v3 *int32, v4 string) (*api.Settings, error) {
-	r0, r1 := m.CreateIfUpToDateFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.CreateIfUpToDateFunc.appendCall(SettingsStoreCreateIfUpToDateFuncCall{v0, v1, v2, v3, v4, r0, r1})
+// GetAllQueries delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) GetAllQueries(v0 context.Context) ([]string, error) {
+	r0, r1 := m.GetAllQueriesFunc.nextHook()(v0)
+	m.GetAllQueriesFunc.appendCall(SearchContextsStoreGetAllQueriesFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CreateIfUpToDate
-// method of the parent MockSettingsStore instance is invoked and the hook
+// SetDefaultHook sets function that is called when the GetAllQueries method
+// of the parent MockSearchContextsStore instance is invoked and the hook
 // queue is empty.
-func (f *SettingsStoreCreateIfUpToDateFunc) SetDefaultHook(hook func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error)) {
+func (f *SearchContextsStoreGetAllQueriesFunc) SetDefaultHook(hook func(context.Context) ([]string, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CreateIfUpToDate method of the parent MockSettingsStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *SettingsStoreCreateIfUpToDateFunc) PushHook(hook func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error)) {
+// GetAllQueries method of the parent MockSearchContextsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SearchContextsStoreGetAllQueriesFunc) PushHook(hook func(context.Context) ([]string, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -28007,21 +28710,21 @@ func (f *SettingsStoreCreateIfUpToDateFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SettingsStoreCreateIfUpToDateFunc) SetDefaultReturn(r0 *api.Settings, r1 error) {
-	f.SetDefaultHook(func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error) {
+func (f *SearchContextsStoreGetAllQueriesFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]string, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SettingsStoreCreateIfUpToDateFunc) PushReturn(r0 *api.Settings, r1 error) {
-	f.PushHook(func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error) {
+func (f *SearchContextsStoreGetAllQueriesFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context) ([]string, error) {
 		return r0, r1
 	})
 }
 
-func (f *SettingsStoreCreateIfUpToDateFunc) nextHook() func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error) {
+func (f *SearchContextsStoreGetAllQueriesFunc) nextHook() func(context.Context) ([]string, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -28034,45 +28737,33 @@ func (f *SettingsStoreCreateIfUpToDateFunc) nextHook() func(context.Context, api
 	return hook
 }
 
-func (f *SettingsStoreCreateIfUpToDateFunc) appendCall(r0 SettingsStoreCreateIfUpToDateFuncCall) {
+func (f *SearchContextsStoreGetAllQueriesFunc) appendCall(r0 SearchContextsStoreGetAllQueriesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SettingsStoreCreateIfUpToDateFuncCall
+// History returns a sequence of SearchContextsStoreGetAllQueriesFuncCall
 // objects describing the invocations of this function.
-func (f *SettingsStoreCreateIfUpToDateFunc) History() []SettingsStoreCreateIfUpToDateFuncCall {
+func (f *SearchContextsStoreGetAllQueriesFunc) History() []SearchContextsStoreGetAllQueriesFuncCall {
 	f.mutex.Lock()
-	history := make([]SettingsStoreCreateIfUpToDateFuncCall, len(f.history))
+	history := make([]SearchContextsStoreGetAllQueriesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SettingsStoreCreateIfUpToDateFuncCall is an object that describes an
-// invocation of method CreateIfUpToDate on an instance of
-// MockSettingsStore.
-type SettingsStoreCreateIfUpToDateFuncCall struct {
+// SearchContextsStoreGetAllQueriesFuncCall is an object that describes an
+// invocation of method GetAllQueries on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreGetAllQueriesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 api.SettingsSubject
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 *int32
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 *int32
-	// Arg4 is the value of the 5th argument passed to this method
-	// invocation.
-	Arg4 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *api.Settings
+	Result0 []string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -28080,44 +28771,47 @@ type SettingsStoreCreateIfUpToDateFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SettingsStoreCreateIfUpToDateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+func (c SearchContextsStoreGetAllQueriesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SettingsStoreCreateIfUpToDateFuncCall) Results() []interface{} {
+func (c SearchContextsStoreGetAllQueriesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SettingsStoreDoneFunc describes the behavior when the Done method of the
-// parent MockSettingsStore instance is invoked.
-type SettingsStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []SettingsStoreDoneFuncCall
+// SearchContextsStoreGetAllRevisionsForReposFunc describes the behavior
+// when the GetAllRevisionsForRepos method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreGetAllRevisionsForReposFunc struct {
+	defaultHook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
+	hooks       []func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
+	history     []SearchContextsStoreGetAllRevisionsForReposFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSettingsStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(SettingsStoreDoneFuncCall{v0, r0})
-	return r0
+// GetAllRevisionsForRepos delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) GetAllRevisionsForRepos(v0 context.Context, v1 []api.RepoID) (map[api.RepoID][]string, error) {
+	r0, r1 := m.GetAllRevisionsForReposFunc.nextHook()(v0, v1)
+	m.GetAllRevisionsForReposFunc.appendCall(SearchContextsStoreGetAllRevisionsForReposFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
-// parent MockSettingsStore instance is invoked and the hook queue is empty.
-func (f *SettingsStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+// SetDefaultHook sets function that is called when the
+// GetAllRevisionsForRepos method of the parent MockSearchContextsStore
+// instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) SetDefaultHook(hook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Done method of the parent MockSettingsStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *SettingsStoreDoneFunc) PushHook(hook func(error) error) {
+// GetAllRevisionsForRepos method of the parent MockSearchContextsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushHook(hook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -28125,21 +28819,21 @@ func (f *SettingsStoreDoneFunc) PushHook(hook func(error) error) {
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SettingsStoreDoneFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(error) error {
-		return r0
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) SetDefaultReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SettingsStoreDoneFunc) PushReturn(r0 error) {
-	f.PushHook(func(error) error {
-		return r0
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.PushHook(func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+		return r0, r1
 	})
 }
 
-func (f *SettingsStoreDoneFunc) nextHook() func(error) error {
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) nextHook() func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -28152,77 +28846,85 @@ func (f *SettingsStoreDoneFunc) nextHook() func(error) error {
 	return hook
 }
 
-func (f *SettingsStoreDoneFunc) appendCall(r0 SettingsStoreDoneFuncCall) {
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) appendCall(r0 SearchContextsStoreGetAllRevisionsForReposFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SettingsStoreDoneFuncCall objects
-// describing the invocations of this function.
-func (f *SettingsStoreDoneFunc) History() []SettingsStoreDoneFuncCall {
+// History returns a sequence of
+// SearchContextsStoreGetAllRevisionsForReposFuncCall objects describing the
+// invocations of this function.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) History() []SearchContextsStoreGetAllRevisionsForReposFuncCall {
 	f.mutex.Lock()
-	history := make([]SettingsStoreDoneFuncCall, len(f.history))
+	history := make([]SearchContextsStoreGetAllRevisionsForReposFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SettingsStoreDoneFuncCall is an object that describes an invocation of
-// method Done on an instance of MockSettingsStore.
-type SettingsStoreDoneFuncCall struct {
+// SearchContextsStoreGetAllRevisionsForReposFuncCall is an object that
+// describes an invocation of method GetAllRevisionsForRepos on an instance
+// of MockSearchContextsStore.
+type SearchContextsStoreGetAllRevisionsForReposFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 error
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 map[api.RepoID][]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SettingsStoreDoneFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SearchContextsStoreGetAllRevisionsForReposFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SettingsStoreDoneFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SearchContextsStoreGetAllRevisionsForReposFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SettingsStoreGetLastestSchemaSettingsFunc describes the behavior when the
-// GetLastestSchemaSettings method of the parent MockSettingsStore instance
-// is invoked.
-type SettingsStoreGetLastestSchemaSettingsFunc struct {
-	defaultHook func(context.Context, api.SettingsSubject) (*schema.Settings, error)
-	hooks       []func(context.Context, api.SettingsSubject) (*schema.Settings, error)
-	history     []SettingsStoreGetLastestSchemaSettingsFuncCall
+// SearchContextsStoreGetSearchContextFunc describes the behavior when the
+// GetSearchContext method of the parent MockSearchContextsStore instance is
+// invoked.
+type SearchContextsStoreGetSearchContextFunc struct {
+	defaultHook func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)
+	hooks       []func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)
+	history     []SearchContextsStoreGetSearchContextFuncCall
 	mutex       sync.Mutex
 }
 
-// GetLastestSchemaSettings delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockSettingsStore) GetLastestSchemaSettings(v0 context.Context, v1 api.SettingsSubject) (*schema.Settings, error) {
-	r0, r1 := m.GetLastestSchemaSettingsFunc.nextHook()(v0, v1)
-	m.GetLastestSchemaSettingsFunc.appendCall(SettingsStoreGetLastestSchemaSettingsFuncCall{v0, v1, r0, r1})
+// GetSearchContext delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) GetSearchContext(v0 context.Context, v1 GetSearchContextOptions) (*types.SearchContext, error) {
+	r0, r1 := m.GetSearchContextFunc.nextHook()(v0, v1)
+	m.GetSearchContextFunc.appendCall(SearchContextsStoreGetSearchContextFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// GetLastestSchemaSettings method of the parent MockSettingsStore instance
-// is invoked and the hook queue is empty.
-func (f *SettingsStoreGetLastestSchemaSettingsFunc) SetDefaultHook(hook func(context.Context, api.SettingsSubject) (*schema.Settings, error)) {
+// SetDefaultHook sets function that is called when the GetSearchContext
+// method of the parent MockSearchContextsStore instance is invoked and the
+// hook queue is empty.
+func (f *SearchContextsStoreGetSearchContextFunc) SetDefaultHook(hook func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetLastestSchemaSettings method of the parent MockSettingsStore instance
+// GetSearchContext method of the parent MockSearchContextsStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *SettingsStoreGetLastestSchemaSettingsFunc) PushHook(hook func(context.Context, api.SettingsSubject) (*schema.Settings, error)) {
+func (f *SearchContextsStoreGetSearchContextFunc) PushHook(hook func(context.Context, GetSearchContextOptions) (*types.SearchContext, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -28230,21 +28932,21 @@ func (f *SettingsStoreGetLastestSchemaSettingsFunc) PushHook(hook func(context.C
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SettingsStoreGetLastestSchemaSettingsFunc) SetDefaultReturn(r0 *schema.Settings, r1 error) {
-	f.SetDefaultHook(func(context.Context, api.SettingsSubject) (*schema.Settings, error) {
+func (f *SearchContextsStoreGetSearchContextFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
+	f.SetDefaultHook(func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SettingsStoreGetLastestSchemaSettingsFunc) PushReturn(r0 *schema.Settings, r1 error) {
-	f.PushHook(func(context.Context, api.SettingsSubject) (*schema.Settings, error) {
+func (f *SearchContextsStoreGetSearchContextFunc) PushReturn(r0 *types.SearchContext, r1 error) {
+	f.PushHook(func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
 		return r0, r1
 	})
 }
 
-func (f *SettingsStoreGetLastestSchemaSettingsFunc) nextHook() func(context.Context, api.SettingsSubject) (*schema.Settings, error) {
+func (f *SearchContextsStoreGetSearchContextFunc) nextHook() func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -28257,37 +28959,36 @@ func (f *SettingsStoreGetLastestSchemaSettingsFunc) nextHook() func(context.Cont
 	return hook
 }
 
-func (f *SettingsStoreGetLastestSchemaSettingsFunc) appendCall(r0 SettingsStoreGetLastestSchemaSettingsFuncCall) {
+func (f *SearchContextsStoreGetSearchContextFunc) appendCall(r0 SearchContextsStoreGetSearchContextFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SettingsStoreGetLastestSchemaSettingsFuncCall objects describing the
-// invocations of this function.
-func (f *SettingsStoreGetLastestSchemaSettingsFunc) History() []SettingsStoreGetLastestSchemaSettingsFuncCall {
+// History returns a sequence of SearchContextsStoreGetSearchContextFuncCall
+// objects describing the invocations of this function.
+func (f *SearchContextsStoreGetSearchContextFunc) History() []SearchContextsStoreGetSearchContextFuncCall {
 	f.mutex.Lock()
-	history := make([]SettingsStoreGetLastestSchemaSettingsFuncCall, len(f.history))
+	history := make([]SearchContextsStoreGetSearchContextFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SettingsStoreGetLastestSchemaSettingsFuncCall is an object that describes
-// an invocation of method GetLastestSchemaSettings on an instance of
-// MockSettingsStore.
-type SettingsStoreGetLastestSchemaSettingsFuncCall struct {
+// SearchContextsStoreGetSearchContextFuncCall is an object that describes
+// an invocation of method GetSearchContext on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreGetSearchContextFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 api.SettingsSubject
+	Arg1 GetSearchContextOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *schema.Settings
+	Result0 *types.SearchContext
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -28295,45 +28996,48 @@ type SettingsStoreGetLastestSchemaSettingsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SettingsStoreGetLastestSchemaSettingsFuncCall) Args() []interface{} {
+func (c SearchContextsStoreGetSearchContextFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SettingsStoreGetLastestSchemaSettingsFuncCall) Results() []interface{} {
+func (c SearchContextsStoreGetSearchContextFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SettingsStoreGetLatestFunc describes the behavior when the GetLatest
-// method of the parent MockSettingsStore instance is invoked.
-type SettingsStoreGetLatestFunc struct {
-	defaultHook func(context.Context, api.SettingsSubject) (*api.Settings, error)
-	hooks       []func(context.Context, api.SettingsSubject) (*api.Settings, error)
-	history     []SettingsStoreGetLatestFuncCall
+// SearchContextsStoreGetSearchContextRepositoryRevisionsFunc describes the
+// behavior when the GetSearchContextRepositoryRevisions method of the
+// parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreGetSearchContextRepositoryRevisionsFunc struct {
+	defaultHook func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)
+	hooks       []func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)
+	history     []SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetLatest delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSettingsStore) GetLatest(v0 context.Context, v1 api.SettingsSubject) (*api.Settings, error) {
-	r0, r1 := m.GetLatestFunc.nextHook()(v0, v1)
-	m.GetLatestFunc.appendCall(SettingsStoreGetLatestFuncCall{v0, v1, r0, r1})
+// GetSearchContextRepositoryRevisions delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockSearchContextsStore) GetSearchContextRepositoryRevisions(v0 context.Context, v1 int64) ([]*types.SearchContextRepositoryRevisions, error) {
+	r0, r1 := m.GetSearchContextRepositoryRevisionsFunc.nextHook()(v0, v1)
+	m.GetSearchContextRepositoryRevisionsFunc.appendCall(SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetLatest method of
-// the parent MockSettingsStore instance is invoked and the hook queue is
-// empty.
-func (f *SettingsStoreGetLatestFunc) SetDefaultHook(hook func(context.Context, api.SettingsSubject) (*api.Settings, error)) {
+// SetDefaultHook sets function that is called when the
+// GetSearchContextRepositoryRevisions method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetLatest method of the parent MockSettingsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SettingsStoreGetLatestFunc) PushHook(hook func(context.Context, api.SettingsSubject) (*api.Settings, error)) {
+// GetSearchContextRepositoryRevisions method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) PushHook(hook func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -28341,21 +29045,21 @@ func (f *SettingsStoreGetLatestFunc) PushHook(hook func(context.Context, api.Set
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SettingsStoreGetLatestFunc) SetDefaultReturn(r0 *api.Settings, r1 error) {
-	f.SetDefaultHook(func(context.Context, api.SettingsSubject) (*api.Settings, error) {
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) SetDefaultReturn(r0 []*types.SearchContextRepositoryRevisions, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SettingsStoreGetLatestFunc) PushReturn(r0 *api.Settings, r1 error) {
-	f.PushHook(func(context.Context, api.SettingsSubject) (*api.Settings, error) {
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) PushReturn(r0 []*types.SearchContextRepositoryRevisions, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
 		return r0, r1
 	})
 }
 
-func (f *SettingsStoreGetLatestFunc) nextHook() func(context.Context, api.SettingsSubject) (*api.Settings, error) {
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) nextHook() func(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -28368,35 +29072,38 @@ func (f *SettingsStoreGetLatestFunc) nextHook() func(context.Context, api.Settin
 	return hook
 }
 
-func (f *SettingsStoreGetLatestFunc) appendCall(r0 SettingsStoreGetLatestFuncCall) {
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SettingsStoreGetLatestFuncCall objects
+// History returns a sequence of
+// SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall objects
 // describing the invocations of this function.
-func (f *SettingsStoreGetLatestFunc) History() []SettingsStoreGetLatestFuncCall {
+func (f *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc) History() []SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall {
 	f.mutex.Lock()
-	history := make([]SettingsStoreGetLatestFuncCall, len(f.history))
+	history := make([]SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SettingsStoreGetLatestFuncCall is an object that describes an invocation
-// of method GetLatest on an instance of MockSettingsStore.
-type SettingsStoreGetLatestFuncCall struct {
+// SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall is an
+// object that describes an invocation of method
+// GetSearchContextRepositoryRevisions on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 api.SettingsSubject
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *api.Settings
+	Result0 []*types.SearchContextRepositoryRevisions
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -28404,44 +29111,45 @@ type SettingsStoreGetLatestFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SettingsStoreGetLatestFuncCall) Args() []interface{} {
+func (c SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SettingsStoreGetLatestFuncCall) Results() []interface{} {
+func (c SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SettingsStoreHandleFunc describes the behavior when the Handle method of
-// the parent MockSettingsStore instance is invoked.
-type SettingsStoreHandleFunc struct {
+// SearchContextsStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreHandleFunc struct {
 	defaultHook func() *basestore.TransactableHandle
 	hooks       []func() *basestore.TransactableHandle
-	history     []SettingsStoreHandleFuncCall
+	history     []SearchContextsStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
 // Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSettingsStore) Handle() *basestore.TransactableHandle {
+func (m *MockSearchContextsStore) Handle() *basestore.TransactableHandle {
 	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(SettingsStoreHandleFuncCall{r0})
+	m.HandleFunc.appendCall(SearchContextsStoreHandleFuncCall{r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the Handle method of the
-// parent MockSettingsStore instance is invoked and the hook queue is empty.
-func (f *SettingsStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
+// parent MockSearchContextsStore instance is invoked and the hook queue is
+// empty.
+func (f *SearchContextsStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockSettingsStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *SettingsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
+// Handle method of the parent MockSearchContextsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SearchContextsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -28449,7 +29157,7 @@ func (f *SettingsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHa
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SettingsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+func (f *SearchContextsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
 	f.SetDefaultHook(func() *basestore.TransactableHandle {
 		return r0
 	})
@@ -28457,13 +29165,13 @@ func (f *SettingsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHan
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SettingsStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+func (f *SearchContextsStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
 	f.PushHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
-func (f *SettingsStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
+func (f *SearchContextsStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -28476,26 +29184,26 @@ func (f *SettingsStoreHandleFunc) nextHook() func() *basestore.TransactableHandl
 	return hook
 }
 
-func (f *SettingsStoreHandleFunc) appendCall(r0 SettingsStoreHandleFuncCall) {
+func (f *SearchContextsStoreHandleFunc) appendCall(r0 SearchContextsStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SettingsStoreHandleFuncCall objects
+// History returns a sequence of SearchContextsStoreHandleFuncCall objects
 // describing the invocations of this function.
-func (f *SettingsStoreHandleFunc) History() []SettingsStoreHandleFuncCall {
+func (f *SearchContextsStoreHandleFunc) History() []SearchContextsStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]SettingsStoreHandleFuncCall, len(f.history))
+	history := make([]SearchContextsStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SettingsStoreHandleFuncCall is an object that describes an invocation of
-// method Handle on an instance of MockSettingsStore.
-type SettingsStoreHandleFuncCall struct {
+// SearchContextsStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockSearchContextsStore.
+type SearchContextsStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *basestore.TransactableHandle
@@ -28503,45 +29211,47 @@ type SettingsStoreHandleFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SettingsStoreHandleFuncCall) Args() []interface{} {
+func (c SearchContextsStoreHandleFuncCall) Args() []interface{} {
 	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SettingsStoreHandleFuncCall) Results() []interface{} {
+func (c SearchContextsStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// SettingsStoreListAllFunc describes the behavior when the ListAll method
-// of the parent MockSettingsStore instance is invoked.
-type SettingsStoreListAllFunc struct {
-	defaultHook func(context.Context, string) ([]*api.Settings, error)
-	hooks       []func(context.Context, string) ([]*api.Settings, error)
-	history     []SettingsStoreListAllFuncCall
+// SearchContextsStoreListSearchContextsFunc describes the behavior when the
+// ListSearchContexts method of the parent MockSearchContextsStore instance
+// is invoked.
+type SearchContextsStoreListSearchContextsFunc struct {
+	defaultHook func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)
+	hooks       []func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)
+	history     []SearchContextsStoreListSearchContextsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListAll delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSettingsStore) ListAll(v0 context.Context, v1 string) ([]*api.Settings, error) {
-	r0, r1 := m.ListAllFunc.nextHook()(v0, v1)
-	m.ListAllFunc.appendCall(SettingsStoreListAllFuncCall{v0, v1, r0, r1})
+// ListSearchContexts delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) ListSearchContexts(v0 context.Context, v1 ListSearchContextsPageOptions, v2 ListSearchContextsOptions) ([]*types.SearchContext, error) {
+	r0, r1 := m.ListSearchContextsFunc.nextHook()(v0, v1, v2)
+	m.ListSearchContextsFunc.appendCall(SearchContextsStoreListSearchContextsFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListAll method of
-// the parent MockSettingsStore instance is invoked and the hook queue is
-// empty.
-func (f *SettingsStoreListAllFunc) SetDefaultHook(hook func(context.Context, string) ([]*api.Settings, error)) {
+// SetDefaultHook sets function that is called when the ListSearchContexts
+// method of the parent MockSearchContextsStore instance is invoked and the
+// hook queue is empty.
+func (f *SearchContextsStoreListSearchContextsFunc) SetDefaultHook(hook func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListAll method of the parent MockSettingsStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *SettingsStoreListAllFunc) PushHook(hook func(context.Context, string) ([]*api.Settings, error)) {
+// ListSearchContexts method of the parent MockSearchContextsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SearchContextsStoreListSearchContextsFunc) PushHook(hook func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -28549,21 +29259,21 @@ func (f *SettingsStoreListAllFunc) PushHook(hook func(context.Context, string) (
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SettingsStoreListAllFunc) SetDefaultReturn(r0 []*api.Settings, r1 error) {
-	f.SetDefaultHook(func(context.Context, string) ([]*api.Settings, error) {
+func (f *SearchContextsStoreListSearchContextsFunc) SetDefaultReturn(r0 []*types.SearchContext, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SettingsStoreListAllFunc) PushReturn(r0 []*api.Settings, r1 error) {
-	f.PushHook(func(context.Context, string) ([]*api.Settings, error) {
+func (f *SearchContextsStoreListSearchContextsFunc) PushReturn(r0 []*types.SearchContext, r1 error) {
+	f.PushHook(func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
 		return r0, r1
 	})
 }
 
-func (f *SettingsStoreListAllFunc) nextHook() func(context.Context, string) ([]*api.Settings, error) {
+func (f *SearchContextsStoreListSearchContextsFunc) nextHook() func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -28576,35 +29286,40 @@ func (f *SettingsStoreListAllFunc) nextHook() func(context.Context, string) ([]*
 	return hook
 }
 
-func (f *SettingsStoreListAllFunc) appendCall(r0 SettingsStoreListAllFuncCall) {
+func (f *SearchContextsStoreListSearchContextsFunc) appendCall(r0 SearchContextsStoreListSearchContextsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SettingsStoreListAllFuncCall objects
-// describing the invocations of this function.
-func (f *SettingsStoreListAllFunc) History() []SettingsStoreListAllFuncCall {
+// History returns a sequence of
+// SearchContextsStoreListSearchContextsFuncCall objects describing the
+// invocations of this function.
+func (f *SearchContextsStoreListSearchContextsFunc) History() []SearchContextsStoreListSearchContextsFuncCall {
 	f.mutex.Lock()
-	history := make([]SettingsStoreListAllFuncCall, len(f.history))
+	history := make([]SearchContextsStoreListSearchContextsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SettingsStoreListAllFuncCall is an object that describes an invocation of
-// method ListAll on an instance of MockSettingsStore.
-type SettingsStoreListAllFuncCall struct {
+// SearchContextsStoreListSearchContextsFuncCall is an object that describes
+// an invocation of method ListSearchContexts on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreListSearchContextsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 string
+	Arg1 ListSearchContextsPageOptions
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 ListSearchContextsOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*api.Settings
+	Result0 []*types.SearchContext
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -28612,45 +29327,48 @@ type SettingsStoreListAllFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SettingsStoreListAllFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c SearchContextsStoreListSearchContextsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SettingsStoreListAllFuncCall) Results() []interface{} {
+func (c SearchContextsStoreListSearchContextsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SettingsStoreTransactFunc describes the behavior when the Transact method
-// of the parent MockSettingsStore instance is invoked.
-type SettingsStoreTransactFunc struct {
-	defaultHook func(context.Context) (SettingsStore, error)
-	hooks       []func(context.Context) (SettingsStore, error)
-	history     []SettingsStoreTransactFuncCall
+// SearchContextsStoreSetSearchContextRepositoryRevisionsFunc describes the
+// behavior when the SetSearchContextRepositoryRevisions method of the
+// parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreSetSearchContextRepositoryRevisionsFunc struct {
+	defaultHook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error
+	hooks       []func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error
+	history     []SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSettingsStore) Transact(v0 context.Context) (SettingsStore, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(SettingsStoreTransactFuncCall{v0, r0, r1})
-	return r0, r1
+// SetSearchContextRepositoryRevisions delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockSearchContextsStore) SetSearchContextRepositoryRevisions(v0 context.Context, v1 int64, v2 []*types.SearchContextRepositoryRevisions) error {
+	r0 := m.SetSearchContextRepositoryRevisionsFunc.nextHook()(v0, v1, v2)
+	m.SetSearchContextRepositoryRevisionsFunc.appendCall(SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall{v0, v1, v2, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
-// the parent MockSettingsStore instance is invoked and the hook queue is
-// empty.
-func (f *SettingsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SettingsStore, error)) {
+// SetDefaultHook sets function that is called when the
+// SetSearchContextRepositoryRevisions method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockSettingsStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *SettingsStoreTransactFunc) PushHook(hook func(context.Context) (SettingsStore, error)) {
+// SetSearchContextRepositoryRevisions method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) PushHook(hook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -28658,21 +29376,21 @@ func (f *SettingsStoreTransactFunc) PushHook(hook func(context.Context) (Setting
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SettingsStoreTransactFunc) SetDefaultReturn(r0 SettingsStore, r1 error) {
-	f.SetDefaultHook(func(context.Context) (SettingsStore, error) {
-		return r0, r1
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SettingsStoreTransactFunc) PushReturn(r0 SettingsStore, r1 error) {
-	f.PushHook(func(context.Context) (SettingsStore, error) {
-		return r0, r1
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
+		return r0
 	})
 }
 
-func (f *SettingsStoreTransactFunc) nextHook() func(context.Context) (SettingsStore, error) {
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) nextHook() func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -28685,77 +29403,84 @@ func (f *SettingsStoreTransactFunc) nextHook() func(context.Context) (SettingsSt
 	return hook
 }
 
-func (f *SettingsStoreTransactFunc) appendCall(r0 SettingsStoreTransactFuncCall) {
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SettingsStoreTransactFuncCall objects
+// History returns a sequence of
+// SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall objects
 // describing the invocations of this function.
-func (f *SettingsStoreTransactFunc) History() []SettingsStoreTransactFuncCall {
+func (f *SearchContextsStoreSetSearchContextRepositoryRevisionsFunc) History() []SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall {
 	f.mutex.Lock()
-	history := make([]SettingsStoreTransactFuncCall, len(f.history))
+	history := make([]SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SettingsStoreTransactFuncCall is an object that describes an invocation
-// of method Transact on an instance of MockSettingsStore.
-type SettingsStoreTransactFuncCall struct {
+// SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall is an
+// object that describes an invocation of method
+// SetSearchContextRepositoryRevisions on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Result0 is the value of the 1st result returned from this method
+	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Result0 SettingsStore
-	// Result1 is the value of the 2nd result returned from this method
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Result1 error
+	Arg2 []*types.SearchContextRepositoryRevisions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SettingsStoreTransactFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SettingsStoreTransactFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SettingsStoreWithFunc describes the behavior when the With method of the
-// parent MockSettingsStore instance is invoked.
-type SettingsStoreWithFunc struct {
-	defaultHook func(basestore.ShareableStore) SettingsStore
-	hooks       []func(basestore.ShareableStore) SettingsStore
-	history     []SettingsStoreWithFuncCall
+// SearchContextsStoreTransactFunc describes the behavior when the Transact
+// method of the parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreTransactFunc struct {
+	defaultHook func(context.Context) (SearchContextsStore, error)
+	hooks       []func(context.Context) (SearchContextsStore, error)
+	history     []SearchContextsStoreTransactFuncCall
 	mutex       sync.Mutex
 }
 
-// With delegates to the next hook function in the queue and stores the
+// Transact delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSettingsStore) With(v0 basestore.ShareableStore) SettingsStore {
-	r0 := m.WithFunc.nextHook()(v0)
-	m.WithFunc.appendCall(SettingsStoreWithFuncCall{v0, r0})
-	return r0
+func (m *MockSearchContextsStore) Transact(v0 context.Context) (SearchContextsStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(SearchContextsStoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the With method of the
-// parent MockSettingsStore instance is invoked and the hook queue is empty.
-func (f *SettingsStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) SettingsStore) {
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockSearchContextsStore instance is invoked and the hook queue
+// is empty.
+func (f *SearchContextsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SearchContextsStore, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// With method of the parent MockSettingsStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *SettingsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) SettingsStore) {
+// Transact method of the parent MockSearchContextsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SearchContextsStoreTransactFunc) PushHook(hook func(context.Context) (SearchContextsStore, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -28763,21 +29488,21 @@ func (f *SettingsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) Set
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SettingsStoreWithFunc) SetDefaultReturn(r0 SettingsStore) {
-	f.SetDefaultHook(func(basestore.ShareableStore) SettingsStore {
-		return r0
+func (f *SearchContextsStoreTransactFunc) SetDefaultReturn(r0 SearchContextsStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (SearchContextsStore, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SettingsStoreWithFunc) PushReturn(r0 SettingsStore) {
-	f.PushHook(func(basestore.ShareableStore) SettingsStore {
-		return r0
+func (f *SearchContextsStoreTransactFunc) PushReturn(r0 SearchContextsStore, r1 error) {
+	f.PushHook(func(context.Context) (SearchContextsStore, error) {
+		return r0, r1
 	})
 }
 
-func (f *SettingsStoreWithFunc) nextHook() func(basestore.ShareableStore) SettingsStore {
+func (f *SearchContextsStoreTransactFunc) nextHook() func(context.Context) (SearchContextsStore, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -28790,218 +29515,354 @@ func (f *SettingsStoreWithFunc) nextHook() func(basestore.ShareableStore) Settin
 	return hook
 }
 
-func (f *SettingsStoreWithFunc) appendCall(r0 SettingsStoreWithFuncCall) {
+func (f *SearchContextsStoreTransactFunc) appendCall(r0 SearchContextsStoreTransactFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SettingsStoreWithFuncCall objects
+// History returns a sequence of SearchContextsStoreTransactFuncCall objects
 // describing the invocations of this function.
-func (f *SettingsStoreWithFunc) History() []SettingsStoreWithFuncCall {
+func (f *SearchContextsStoreTransactFunc) History() []SearchContextsStoreTransactFuncCall {
 	f.mutex.Lock()
-	history := make([]SettingsStoreWithFuncCall, len(f.history))
+	history := make([]SearchContextsStoreTransactFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SettingsStoreWithFuncCall is an object that describes an invocation of
-// method With on an instance of MockSettingsStore.
-type SettingsStoreWithFuncCall struct {
+// SearchContextsStoreTransactFuncCall is an object that describes an
+// invocation of method Transact on an instance of MockSearchContextsStore.
+type SearchContextsStoreTransactFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 basestore.ShareableStore
+	Arg0 context.Context
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 SettingsStore
+	Result0 SearchContextsStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SettingsStoreWithFuncCall) Args() []interface{} {
+func (c SearchContextsStoreTransactFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SettingsStoreWithFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SearchContextsStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockSubRepoPermsStore is a mock implementation of the SubRepoPermsStore
-// interface (from the package
-// github.com/sourcegraph/sourcegraph/internal/database) used for unit
-// testing.
-type MockSubRepoPermsStore struct {
-	// DoneFunc is an instance of a mock function object controlling the
+// SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc
+// describes the behavior when the
+// UpdateSearchContextWithRepositoryRevisions method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc struct {
+	defaultHook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
+	hooks       []func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
+	history     []SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateSearchContextWithRepositoryRevisions delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockSearchContextsStore) UpdateSearchContextWithRepositoryRevisions(v0 context.Context, v1 *types.SearchContext, v2 []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+	r0, r1 := m.UpdateSearchContextWithRepositoryRevisionsFunc.nextHook()(v0, v1, v2)
+	m.UpdateSearchContextWithRepositoryRevisionsFunc.appendCall(SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateSearchContextWithRepositoryRevisions method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) SetDefaultHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateSearchContextWithRepositoryRevisions method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) PushHook(hook func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) PushReturn(r0 *types.SearchContext, r1 error) {
+	f.PushHook(func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) nextHook() func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) appendCall(r0 SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall
+// objects describing the invocations of this function.
+func (f *SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFunc) History() []SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall is
+// an object that describes an invocation of method
+// UpdateSearchContextWithRepositoryRevisions on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.SearchContext
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*types.SearchContextRepositoryRevisions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SearchContext
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreUpdateSearchContextWithRepositoryRevisionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// MockSettingsStore is a mock implementation of the SettingsStore interface
+// (from the package github.com/sourcegraph/sourcegraph/internal/database)
+// used for unit testing.
+type MockSettingsStore struct {
+	// CreateIfUpToDateFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateIfUpToDate.
+	CreateIfUpToDateFunc *SettingsStoreCreateIfUpToDateFunc
+	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
-	DoneFunc *SubRepoPermsStoreDoneFunc
-	// GetFunc is an instance of a mock function object controlling the
-	// behavior of the method Get.
-	GetFunc *SubRepoPermsStoreGetFunc
-	// GetByUserFunc is an instance of a mock function object controlling
-	// the behavior of the method GetByUser.
-	GetByUserFunc *SubRepoPermsStoreGetByUserFunc
+	DoneFunc *SettingsStoreDoneFunc
+	// GetLastestSchemaSettingsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetLastestSchemaSettings.
+	GetLastestSchemaSettingsFunc *SettingsStoreGetLastestSchemaSettingsFunc
+	// GetLatestFunc is an instance of a mock function object controlling
+	// the behavior of the method GetLatest.
+	GetLatestFunc *SettingsStoreGetLatestFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *SettingsStoreHandleFunc
+	// ListAllFunc is an instance of a mock function object controlling the
+	// behavior of the method ListAll.
+	ListAllFunc *SettingsStoreListAllFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
-	TransactFunc *SubRepoPermsStoreTransactFunc
-	// UpsertFunc is an instance of a mock function object controlling the
-	// behavior of the method Upsert.
-	UpsertFunc *SubRepoPermsStoreUpsertFunc
-	// UpsertWithSpecFunc is an instance of a mock function object
-	// controlling the behavior of the method UpsertWithSpec.
-	UpsertWithSpecFunc *SubRepoPermsStoreUpsertWithSpecFunc
+	TransactFunc *SettingsStoreTransactFunc
 	// WithFunc is an instance of a mock function object controlling the
 	// behavior of the method With.
-	WithFunc *SubRepoPermsStoreWithFunc
+	WithFunc *SettingsStoreWithFunc
 }
 
-// NewMockSubRepoPermsStore creates a new mock of the SubRepoPermsStore
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockSubRepoPermsStore() *MockSubRepoPermsStore {
-	return &MockSubRepoPermsStore{
-		DoneFunc: &SubRepoPermsStoreDoneFunc{
+// NewMockSettingsStore creates a new mock of the SettingsStore interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockSettingsStore() *MockSettingsStore {
+	return &MockSettingsStore{
+		CreateIfUpToDateFunc: &SettingsStoreCreateIfUpToDateFunc{
+			defaultHook: func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error) {
+				return nil, nil
+			},
+		},
+		DoneFunc: &SettingsStoreDoneFunc{
 			defaultHook: func(error) error {
 				return nil
 			},
 		},
-		GetFunc: &SubRepoPermsStoreGetFunc{
-			defaultHook: func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error) {
+		GetLastestSchemaSettingsFunc: &SettingsStoreGetLastestSchemaSettingsFunc{
+			defaultHook: func(context.Context, api.SettingsSubject) (*schema.Settings, error) {
 				return nil, nil
 			},
 		},
-		GetByUserFunc: &SubRepoPermsStoreGetByUserFunc{
-			defaultHook: func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+		GetLatestFunc: &SettingsStoreGetLatestFunc{
+			defaultHook: func(context.Context, api.SettingsSubject) (*api.Settings, error) {
 				return nil, nil
 			},
 		},
-		TransactFunc: &SubRepoPermsStoreTransactFunc{
-			defaultHook: func(context.Context) (SubRepoPermsStore, error) {
-				return nil, nil
+		HandleFunc: &SettingsStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				return nil
 			},
 		},
-		UpsertFunc: &SubRepoPermsStoreUpsertFunc{
-			defaultHook: func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error {
-				return nil
+		ListAllFunc: &SettingsStoreListAllFunc{
+			defaultHook: func(context.Context, string) ([]*api.Settings, error) {
+				return nil, nil
 			},
 		},
-		UpsertWithSpecFunc: &SubRepoPermsStoreUpsertWithSpecFunc{
-			defaultHook: func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error {
-				return nil
+		TransactFunc: &SettingsStoreTransactFunc{
+			defaultHook: func(context.Context) (SettingsStore, error) {
+				return nil, nil
 			},
 		},
-		WithFunc: &SubRepoPermsStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) SubRepoPermsStore {
+		WithFunc: &SettingsStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) SettingsStore {
 				return nil
 			},
 		},
 	}
 }
 
-// NewStrictMockSubRepoPermsStore creates a new mock of the
-// SubRepoPermsStore interface. All methods panic on invocation, unless
-// overwritten.
-func NewStrictMockSubRepoPermsStore() *MockSubRepoPermsStore {
-	return &MockSubRepoPermsStore{
-		DoneFunc: &SubRepoPermsStoreDoneFunc{
+// NewStrictMockSettingsStore creates a new mock of the SettingsStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockSettingsStore() *MockSettingsStore {
+	return &MockSettingsStore{
+		CreateIfUpToDateFunc: &SettingsStoreCreateIfUpToDateFunc{
+			defaultHook: func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error) {
+				panic("unexpected invocation of MockSettingsStore.CreateIfUpToDate")
+			},
+		},
+		DoneFunc: &SettingsStoreDoneFunc{
 			defaultHook: func(error) error {
-				panic("unexpected invocation of MockSubRepoPermsStore.Done")
+				panic("unexpected invocation of MockSettingsStore.Done")
 			},
 		},
-		GetFunc: &SubRepoPermsStoreGetFunc{
-			defaultHook: func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error) {
-				panic("unexpected invocation of MockSubRepoPermsStore.Get")
+		GetLastestSchemaSettingsFunc: &SettingsStoreGetLastestSchemaSettingsFunc{
+			defaultHook: func(context.Context, api.SettingsSubject) (*schema.Settings, error) {
+				panic("unexpected invocation of MockSettingsStore.GetLastestSchemaSettings")
 			},
 		},
-		GetByUserFunc: &SubRepoPermsStoreGetByUserFunc{
-			defaultHook: func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
-				panic("unexpected invocation of MockSubRepoPermsStore.GetByUser")
+		GetLatestFunc: &SettingsStoreGetLatestFunc{
+			defaultHook: func(context.Context, api.SettingsSubject) (*api.Settings, error) {
+				panic("unexpected invocation of MockSettingsStore.GetLatest")
 			},
 		},
-		TransactFunc: &SubRepoPermsStoreTransactFunc{
-			defaultHook: func(context.Context) (SubRepoPermsStore, error) {
-				panic("unexpected invocation of MockSubRepoPermsStore.Transact")
+		HandleFunc: &SettingsStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				panic("unexpected invocation of MockSettingsStore.Handle")
 			},
 		},
-		UpsertFunc: &SubRepoPermsStoreUpsertFunc{
-			defaultHook: func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error {
-				panic("unexpected invocation of MockSubRepoPermsStore.Upsert")
+		ListAllFunc: &SettingsStoreListAllFunc{
+			defaultHook: func(context.Context, string) ([]*api.Settings, error) {
+				panic("unexpected invocation of MockSettingsStore.ListAll")
 			},
 		},
-		UpsertWithSpecFunc: &SubRepoPermsStoreUpsertWithSpecFunc{
-			defaultHook: func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error {
-				panic("unexpected invocation of MockSubRepoPermsStore.UpsertWithSpec")
+		TransactFunc: &SettingsStoreTransactFunc{
+			defaultHook: func(context.Context) (SettingsStore, error) {
+				panic("unexpected invocation of MockSettingsStore.Transact")
 			},
 		},
-		WithFunc: &SubRepoPermsStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) SubRepoPermsStore {
-				panic("unexpected invocation of MockSubRepoPermsStore.With")
+		WithFunc: &SettingsStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) SettingsStore {
+				panic("unexpected invocation of MockSettingsStore.With")
 			},
 		},
 	}
 }
 
-// NewMockSubRepoPermsStoreFrom creates a new mock of the
-// MockSubRepoPermsStore interface. All methods delegate to the given
-// implementation, unless overwritten.
-func NewMockSubRepoPermsStoreFrom(i SubRepoPermsStore) *MockSubRepoPermsStore {
-	return &MockSubRepoPermsStore{
-		DoneFunc: &SubRepoPermsStoreDoneFunc{
+// NewMockSettingsStoreFrom creates a new mock of the MockSettingsStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockSettingsStoreFrom(i SettingsStore) *MockSettingsStore {
+	return &MockSettingsStore{
+		CreateIfUpToDateFunc: &SettingsStoreCreateIfUpToDateFunc{
+			defaultHook: i.CreateIfUpToDate,
+		},
+		DoneFunc: &SettingsStoreDoneFunc{
 			defaultHook: i.Done,
 		},
-		GetFunc: &SubRepoPermsStoreGetFunc{
-			defaultHook: i.Get,
+		GetLastestSchemaSettingsFunc: &SettingsStoreGetLastestSchemaSettingsFunc{
+			defaultHook: i.GetLastestSchemaSettings,
 		},
-		GetByUserFunc: &SubRepoPermsStoreGetByUserFunc{
-			defaultHook: i.GetByUser,
+		GetLatestFunc: &SettingsStoreGetLatestFunc{
+			defaultHook: i.GetLatest,
 		},
-		TransactFunc: &SubRepoPermsStoreTransactFunc{
-			defaultHook: i.Transact,
+		HandleFunc: &SettingsStoreHandleFunc{
+			defaultHook: i.Handle,
 		},
-		UpsertFunc: &SubRepoPermsStoreUpsertFunc{
-			defaultHook: i.Upsert,
+		ListAllFunc: &SettingsStoreListAllFunc{
+			defaultHook: i.ListAll,
 		},
-		UpsertWithSpecFunc: &SubRepoPermsStoreUpsertWithSpecFunc{
-			defaultHook: i.UpsertWithSpec,
+		TransactFunc: &SettingsStoreTransactFunc{
+			defaultHook: i.Transact,
 		},
-		WithFunc: &SubRepoPermsStoreWithFunc{
+		WithFunc: &SettingsStoreWithFunc{
 			defaultHook: i.With,
 		},
 	}
 }
 
-// SubRepoPermsStoreDoneFunc describes the behavior when the Done method of
-// the parent MockSubRepoPermsStore instance is invoked.
-type SubRepoPermsStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []SubRepoPermsStoreDoneFuncCall
+// SettingsStoreCreateIfUpToDateFunc describes the behavior when the
+// CreateIfUpToDate method of the parent MockSettingsStore instance is
+// invoked.
+type SettingsStoreCreateIfUpToDateFunc struct {
+	defaultHook func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error)
+	hooks       []func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error)
+	history     []SettingsStoreCreateIfUpToDateFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSubRepoPermsStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(SubRepoPermsStoreDoneFuncCall{v0, r0})
-	return r0
+// CreateIfUpToDate delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSettingsStore) CreateIfUpToDate(v0 context.Context, v1 api.SettingsSubject, v2 *int32, v3 *int32, v4 string) (*api.Settings, error) {
+	r0, r1 := m.CreateIfUpToDateFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CreateIfUpToDateFunc.appendCall(SettingsStoreCreateIfUpToDateFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
-// parent MockSubRepoPermsStore instance is invoked and the hook queue is
-// empty.
-func (f *SubRepoPermsStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+// SetDefaultHook sets function that is called when the CreateIfUpToDate
+// method of the parent MockSettingsStore instance is invoked and the hook
+// queue is empty.
+func (f *SettingsStoreCreateIfUpToDateFunc) SetDefaultHook(hook func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Done method of the parent MockSubRepoPermsStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *SubRepoPermsStoreDoneFunc) PushHook(hook func(error) error) {
+// CreateIfUpToDate method of the parent MockSettingsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SettingsStoreCreateIfUpToDateFunc) PushHook(hook func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29009,21 +29870,21 @@ func (f *SubRepoPermsStoreDoneFunc) PushHook(hook func(error) error) {
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SubRepoPermsStoreDoneFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(error) error {
-		return r0
+func (f *SettingsStoreCreateIfUpToDateFunc) SetDefaultReturn(r0 *api.Settings, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SubRepoPermsStoreDoneFunc) PushReturn(r0 error) {
-	f.PushHook(func(error) error {
-		return r0
+func (f *SettingsStoreCreateIfUpToDateFunc) PushReturn(r0 *api.Settings, r1 error) {
+	f.PushHook(func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error) {
+		return r0, r1
 	})
 }
 
-func (f *SubRepoPermsStoreDoneFunc) nextHook() func(error) error {
+func (f *SettingsStoreCreateIfUpToDateFunc) nextHook() func(context.Context, api.SettingsSubject, *int32, *int32, string) (*api.Settings, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -29036,75 +29897,90 @@ func (f *SubRepoPermsStoreDoneFunc) nextHook() func(error) error {
 	return hook
 }
 
-func (f *SubRepoPermsStoreDoneFunc) appendCall(r0 SubRepoPermsStoreDoneFuncCall) {
+func (f *SettingsStoreCreateIfUpToDateFunc) appendCall(r0 SettingsStoreCreateIfUpToDateFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SubRepoPermsStoreDoneFuncCall objects
-// describing the invocations of this function.
-func (f *SubRepoPermsStoreDoneFunc) History() []SubRepoPermsStoreDoneFuncCall {
+// History returns a sequence of SettingsStoreCreateIfUpToDateFuncCall
+// objects describing the invocations of this function.
+func (f *SettingsStoreCreateIfUpToDateFunc) History() []SettingsStoreCreateIfUpToDateFuncCall {
 	f.mutex.Lock()
-	history := make([]SubRepoPermsStoreDoneFuncCall, len(f.history))
+	history := make([]SettingsStoreCreateIfUpToDateFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SubRepoPermsStoreDoneFuncCall is an object that describes an invocation
-// of method Done on an instance of MockSubRepoPermsStore.
-type SubRepoPermsStoreDoneFuncCall struct {
+// SettingsStoreCreateIfUpToDateFuncCall is an object that describes an
+// invocation of method CreateIfUpToDate on an instance of
+// MockSettingsStore.
+type SettingsStoreCreateIfUpToDateFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 error
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.SettingsSubject
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *int32
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 *int32
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *api.Settings
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SubRepoPermsStoreDoneFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SettingsStoreCreateIfUpToDateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SubRepoPermsStoreDoneFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SettingsStoreCreateIfUpToDateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SubRepoPermsStoreGetFunc describes the behavior when the Get method of
-// the parent MockSubRepoPermsStore instance is invoked.
-type SubRepoPermsStoreGetFunc struct {
-	defaultHook func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error)
-	hooks       []func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error)
-	history     []SubRepoPermsStoreGetFuncCall
+// SettingsStoreDoneFunc describes the behavior when the Done method of the
+// parent MockSettingsStore instance is invoked.
+type SettingsStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []SettingsStoreDoneFuncCall
 	mutex       sync.Mutex
 }
 
-// Get delegates to the next hook function in the queue and stores the
+// Done delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSubRepoPermsStore) Get(v0 context.Context, v1 int32, v2 api.RepoID) (*authz.SubRepoPermissions, error) {
-	r0, r1 := m.GetFunc.nextHook()(v0, v1, v2)
-	m.GetFunc.appendCall(SubRepoPermsStoreGetFuncCall{v0, v1, v2, r0, r1})
-	return r0, r1
+func (m *MockSettingsStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(SettingsStoreDoneFuncCall{v0, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Get method of the
-// parent MockSubRepoPermsStore instance is invoked and the hook queue is
-// empty.
-func (f *SubRepoPermsStoreGetFunc) SetDefaultHook(hook func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error)) {
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockSettingsStore instance is invoked and the hook queue is empty.
+func (f *SettingsStoreDoneFunc) SetDefaultHook(hook func(error) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Get method of the parent MockSubRepoPermsStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
+// Done method of the parent MockSettingsStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *SubRepoPermsStoreGetFunc) PushHook(hook func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error)) {
+func (f *SettingsStoreDoneFunc) PushHook(hook func(error) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29112,21 +29988,21 @@ func (f *SubRepoPermsStoreGetFunc) PushHook(hook func(context.Context, int32, ap
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SubRepoPermsStoreGetFunc) SetDefaultReturn(r0 *authz.SubRepoPermissions, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error) {
-		return r0, r1
+func (f *SettingsStoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SubRepoPermsStoreGetFunc) PushReturn(r0 *authz.SubRepoPermissions, r1 error) {
-	f.PushHook(func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error) {
-		return r0, r1
+func (f *SettingsStoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
+		return r0
 	})
 }
 
-func (f *SubRepoPermsStoreGetFunc) nextHook() func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error) {
+func (f *SettingsStoreDoneFunc) nextHook() func(error) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -29139,84 +30015,2355 @@ func (f *SubRepoPermsStoreGetFunc) nextHook() func(context.Context, int32, api.R
 	return hook
 }
 
-func (f *SubRepoPermsStoreGetFunc) appendCall(r0 SubRepoPermsStoreGetFuncCall) {
+func (f *SettingsStoreDoneFunc) appendCall(r0 SettingsStoreDoneFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SubRepoPermsStoreGetFuncCall objects
+// History returns a sequence of SettingsStoreDoneFuncCall objects
 // describing the invocations of this function.
-func (f *SubRepoPermsStoreGetFunc) History() []SubRepoPermsStoreGetFuncCall {
+func (f *SettingsStoreDoneFunc) History() []SettingsStoreDoneFuncCall {
 	f.mutex.Lock()
-	history := make([]SubRepoPermsStoreGetFuncCall, len(f.history))
+	history := make([]SettingsStoreDoneFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SubRepoPermsStoreGetFuncCall is an object that describes an invocation of
-// method Get on an instance of MockSubRepoPermsStore.
-type SubRepoPermsStoreGetFuncCall struct {
+// SettingsStoreDoneFuncCall is an object that describes an invocation of
+// method Done on an instance of MockSettingsStore.
+type SettingsStoreDoneFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int32
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 api.RepoID
+	Arg0 error
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *authz.SubRepoPermissions
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SubRepoPermsStoreGetFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c SettingsStoreDoneFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SubRepoPermsStoreGetFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SettingsStoreDoneFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SubRepoPermsStoreGetByUserFunc describes the behavior when the GetByUser
-// method of the parent MockSubRepoPermsStore instance is invoked.
-type SubRepoPermsStoreGetByUserFunc struct {
-	defaultHook func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error)
-	hooks       []func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error)
-	history     []SubRepoPermsStoreGetByUserFuncCall
+// SettingsStoreGetLastestSchemaSettingsFunc describes the behavior when the
+// GetLastestSchemaSettings method of the parent MockSettingsStore instance
+// is invoked.
+type SettingsStoreGetLastestSchemaSettingsFunc struct {
+	defaultHook func(context.Context, api.SettingsSubject) (*schema.Settings, error)
+	hooks       []func(context.Context, api.SettingsSubject) (*schema.Settings, error)
+	history     []SettingsStoreGetLastestSchemaSettingsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByUser delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSubRepoPermsStore) GetByUser(v0 context.Context, v1 int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
-	r0, r1 := m.GetByUserFunc.nextHook()(v0, v1)
-	m.GetByUserFunc.appendCall(SubRepoPermsStoreGetByUserFuncCall{v0, v1, r0, r1})
+// GetLastestSchemaSettings delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSettingsStore) GetLastestSchemaSettings(v0 context.Context, v1 api.SettingsSubject) (*schema.Settings, error) {
+	r0, r1 := m.GetLastestSchemaSettingsFunc.nextHook()(v0, v1)
+	m.GetLastestSchemaSettingsFunc.appendCall(SettingsStoreGetLastestSchemaSettingsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByUser method of
-// the parent MockSubRepoPermsStore instance is invoked and the hook queue
-// is empty.
-func (f *SubRepoPermsStoreGetByUserFunc) SetDefaultHook(hook func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error)) {
+// SetDefaultHook sets function that is called when the
+// GetLastestSchemaSettings method of the parent MockSettingsStore instance
+// is invoked and the hook queue is empty.
+func (f *SettingsStoreGetLastestSchemaSettingsFunc) SetDefaultHook(hook func(context.Context, api.SettingsSubject) (*schema.Settings, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByUser method of the parent MockSubRepoPermsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SubRepoPermsStoreGetByUserFunc) PushHook(hook func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error)) {
+// GetLastestSchemaSettings method of the parent MockSettingsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SettingsStoreGetLastestSchemaSettingsFunc) PushHook(hook func(context.Context, api.SettingsSubject) (*schema.Settings, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SettingsStoreGetLastestSchemaSettingsFunc) SetDefaultReturn(r0 *schema.Settings, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.SettingsSubject) (*schema.Settings, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SettingsStoreGetLastestSchemaSettingsFunc) PushReturn(r0 *schema.Settings, r1 error) {
+	f.PushHook(func(context.Context, api.SettingsSubject) (*schema.Settings, error) {
+		return r0, r1
+	})
+}
+
+func (f *SettingsStoreGetLastestSchemaSettingsFunc) nextHook() func(context.Context, api.SettingsSubject) (*schema.Settings, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SettingsStoreGetLastestSchemaSettingsFunc) appendCall(r0 SettingsStoreGetLastestSchemaSettingsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SettingsStoreGetLastestSchemaSettingsFuncCall objects describing the
+// invocations of this function.
+func (f *SettingsStoreGetLastestSchemaSettingsFunc) History() []SettingsStoreGetLastestSchemaSettingsFuncCall {
+	f.mutex.Lock()
+	history := make([]SettingsStoreGetLastestSchemaSettingsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SettingsStoreGetLastestSchemaSettingsFuncCall is an object that describes
+// an invocation of method GetLastestSchemaSettings on an instance of
+// MockSettingsStore.
+type SettingsStoreGetLastestSchemaSettingsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.SettingsSubject
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *schema.Settings
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SettingsStoreGetLastestSchemaSettingsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SettingsStoreGetLastestSchemaSettingsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SettingsStoreGetLatestFunc describes the behavior when the GetLatest
+// method of the parent MockSettingsStore instance is invoked.
+type SettingsStoreGetLatestFunc struct {
+	defaultHook func(context.Context, api.SettingsSubject) (*api.Settings, error)
+	hooks       []func(context.Context, api.SettingsSubject) (*api.Settings, error)
+	history     []SettingsStoreGetLatestFuncCall
+	mutex       sync.Mutex
+}
+
+// GetLatest delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSettingsStore) GetLatest(v0 context.Context, v1 api.SettingsSubject) (*api.Settings, error) {
+	r0, r1 := m.GetLatestFunc.nextHook()(v0, v1)
+	m.GetLatestFunc.appendCall(SettingsStoreGetLatestFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetLatest method of
+// the parent MockSettingsStore instance is invoked and the hook queue is
+// empty.
+func (f *SettingsStoreGetLatestFunc) SetDefaultHook(hook func(context.Context, api.SettingsSubject) (*api.Settings, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetLatest method of the parent MockSettingsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SettingsStoreGetLatestFunc) PushHook(hook func(context.Context, api.SettingsSubject) (*api.Settings, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SettingsStoreGetLatestFunc) SetDefaultReturn(r0 *api.Settings, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.SettingsSubject) (*api.Settings, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SettingsStoreGetLatestFunc) PushReturn(r0 *api.Settings, r1 error) {
+	f.PushHook(func(context.Context, api.SettingsSubject) (*api.Settings, error) {
+		return r0, r1
+	})
+}
+
+func (f *SettingsStoreGetLatestFunc) nextHook() func(context.Context, api.SettingsSubject) (*api.Settings, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SettingsStoreGetLatestFunc) appendCall(r0 SettingsStoreGetLatestFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SettingsStoreGetLatestFuncCall objects
+// describing the invocations of this function.
+func (f *SettingsStoreGetLatestFunc) History() []SettingsStoreGetLatestFuncCall {
+	f.mutex.Lock()
+	history := make([]SettingsStoreGetLatestFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SettingsStoreGetLatestFuncCall is an object that describes an invocation
+// of method GetLatest on an instance of MockSettingsStore.
+type SettingsStoreGetLatestFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.SettingsSubject
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *api.Settings
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SettingsStoreGetLatestFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SettingsStoreGetLatestFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SettingsStoreHandleFunc describes the behavior when the Handle method of
+// the parent MockSettingsStore instance is invoked.
+type SettingsStoreHandleFunc struct {
+	defaultHook func() *basestore.TransactableHandle
+	hooks       []func() *basestore.TransactableHandle
+	history     []SettingsStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSettingsStore) Handle() *basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(SettingsStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockSettingsStore instance is invoked and the hook queue is empty.
+func (f *SettingsStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockSettingsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SettingsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SettingsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+	f.SetDefaultHook(func() *basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SettingsStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+	f.PushHook(func() *basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *SettingsStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SettingsStoreHandleFunc) appendCall(r0 SettingsStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SettingsStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *SettingsStoreHandleFunc) History() []SettingsStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]SettingsStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SettingsStoreHandleFuncCall is an object that describes an invocation of
+// method Handle on an instance of MockSettingsStore.
+type SettingsStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SettingsStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SettingsStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SettingsStoreListAllFunc describes the behavior when the ListAll method
+// of the parent MockSettingsStore instance is invoked.
+type SettingsStoreListAllFunc struct {
+	defaultHook func(context.Context, string) ([]*api.Settings, error)
+	hooks       []func(context.Context, string) ([]*api.Settings, error)
+	history     []SettingsStoreListAllFuncCall
+	mutex       sync.Mutex
+}
+
+// ListAll delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSettingsStore) ListAll(v0 context.Context, v1 string) ([]*api.Settings, error) {
+	r0, r1 := m.ListAllFunc.nextHook()(v0, v1)
+	m.ListAllFunc.appendCall(SettingsStoreListAllFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListAll method of
+// the parent MockSettingsStore instance is invoked and the hook queue is
+// empty.
+func (f *SettingsStoreListAllFunc) SetDefaultHook(hook func(context.Context, string) ([]*api.Settings, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListAll method of the parent MockSettingsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SettingsStoreListAllFunc) PushHook(hook func(context.Context, string) ([]*api.Settings, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SettingsStoreListAllFunc) SetDefaultReturn(r0 []*api.Settings, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]*api.Settings, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SettingsStoreListAllFunc) PushReturn(r0 []*api.Settings, r1 error) {
+	f.PushHook(func(context.Context, string) ([]*api.Settings, error) {
+		return r0, r1
+	})
+}
+
+func (f *SettingsStoreListAllFunc) nextHook() func(context.Context, string) ([]*api.Settings, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SettingsStoreListAllFunc) appendCall(r0 SettingsStoreListAllFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SettingsStoreListAllFuncCall objects
+// describing the invocations of this function.
+func (f *SettingsStoreListAllFunc) History() []SettingsStoreListAllFuncCall {
+	f.mutex.Lock()
+	history := make([]SettingsStoreListAllFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SettingsStoreListAllFuncCall is an object that describes an invocation of
+// method ListAll on an instance of MockSettingsStore.
+type SettingsStoreListAllFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*api.Settings
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SettingsStoreListAllFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SettingsStoreListAllFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SettingsStoreTransactFunc describes the behavior when the Transact method
+// of the parent MockSettingsStore instance is invoked.
+type SettingsStoreTransactFunc struct {
+	defaultHook func(context.Context) (SettingsStore, error)
+	hooks       []func(context.Context) (SettingsStore, error)
+	history     []SettingsStoreTransactFuncCall
+	mutex       sync.Mutex
+}
+
+// Transact delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSettingsStore) Transact(v0 context.Context) (SettingsStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(SettingsStoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockSettingsStore instance is invoked and the hook queue is
+// empty.
+func (f *SettingsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SettingsStore, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Transact method of the parent MockSettingsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SettingsStoreTransactFunc) PushHook(hook func(context.Context) (SettingsStore, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SettingsStoreTransactFunc) SetDefaultReturn(r0 SettingsStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (SettingsStore, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SettingsStoreTransactFunc) PushReturn(r0 SettingsStore, r1 error) {
+	f.PushHook(func(context.Context) (SettingsStore, error) {
+		return r0, r1
+	})
+}
+
+func (f *SettingsStoreTransactFunc) nextHook() func(context.Context) (SettingsStore, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SettingsStoreTransactFunc) appendCall(r0 SettingsStoreTransactFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SettingsStoreTransactFuncCall objects
+// describing the invocations of this function.
+func (f *SettingsStoreTransactFunc) History() []SettingsStoreTransactFuncCall {
+	f.mutex.Lock()
+	history := make([]SettingsStoreTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SettingsStoreTransactFuncCall is an object that describes an invocation
+// of method Transact on an instance of MockSettingsStore.
+type SettingsStoreTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 SettingsStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SettingsStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SettingsStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SettingsStoreWithFunc describes the behavior when the With method of the
+// parent MockSettingsStore instance is invoked.
+type SettingsStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) SettingsStore
+	hooks       []func(basestore.ShareableStore) SettingsStore
+	history     []SettingsStoreWithFuncCall
+	mutex       sync.Mutex
+}
+
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSettingsStore) With(v0 basestore.ShareableStore) SettingsStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(SettingsStoreWithFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockSettingsStore instance is invoked and the hook queue is empty.
+func (f *SettingsStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) SettingsStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// With method of the parent MockSettingsStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SettingsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) SettingsStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SettingsStoreWithFunc) SetDefaultReturn(r0 SettingsStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) SettingsStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SettingsStoreWithFunc) PushReturn(r0 SettingsStore) {
+	f.PushHook(func(basestore.ShareableStore) SettingsStore {
+		return r0
+	})
+}
+
+func (f *SettingsStoreWithFunc) nextHook() func(basestore.ShareableStore) SettingsStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SettingsStoreWithFunc) appendCall(r0 SettingsStoreWithFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SettingsStoreWithFuncCall objects
+// describing the invocations of this function.
+func (f *SettingsStoreWithFunc) History() []SettingsStoreWithFuncCall {
+	f.mutex.Lock()
+	history := make([]SettingsStoreWithFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SettingsStoreWithFuncCall is an object that describes an invocation of
+// method With on an instance of MockSettingsStore.
+type SettingsStoreWithFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 basestore.ShareableStore
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 SettingsStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SettingsStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SettingsStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockSubRepoPathRuleStore is a mock implementation of the
+// SubRepoPathRuleStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockSubRepoPathRuleStore struct {
+	// GetByUserFunc is an instance of a mock function object controlling
+	// the behavior of the method GetByUser.
+	GetByUserFunc *SubRepoPathRuleStoreGetByUserFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *SubRepoPathRuleStoreHandleFunc
+	// ListByRepoFunc is an instance of a mock function object controlling
+	// the behavior of the method ListByRepo.
+	ListByRepoFunc *SubRepoPathRuleStoreListByRepoFunc
+	// SetForRepoFunc is an instance of a mock function object controlling
+	// the behavior of the method SetForRepo.
+	SetForRepoFunc *SubRepoPathRuleStoreSetForRepoFunc
+}
+
+// NewMockSubRepoPathRuleStore creates a new mock of the SubRepoPathRuleStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockSubRepoPathRuleStore() *MockSubRepoPathRuleStore {
+	return &MockSubRepoPathRuleStore{
+		GetByUserFunc: &SubRepoPathRuleStoreGetByUserFunc{
+			defaultHook: func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+				return nil, nil
+			},
+		},
+		HandleFunc: &SubRepoPathRuleStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				return nil
+			},
+		},
+		ListByRepoFunc: &SubRepoPathRuleStoreListByRepoFunc{
+			defaultHook: func(context.Context, api.RepoID) ([]*SubRepoPathRule, error) {
+				return nil, nil
+			},
+		},
+		SetForRepoFunc: &SubRepoPathRuleStoreSetForRepoFunc{
+			defaultHook: func(context.Context, api.RepoID, []*SubRepoPathRule) error {
+				return nil
+			},
+		},
+	}
+}
+
+// NewStrictMockSubRepoPathRuleStore creates a new mock of the
+// SubRepoPathRuleStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockSubRepoPathRuleStore() *MockSubRepoPathRuleStore {
+	return &MockSubRepoPathRuleStore{
+		GetByUserFunc: &SubRepoPathRuleStoreGetByUserFunc{
+			defaultHook: func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+				panic("unexpected invocation of MockSubRepoPathRuleStore.GetByUser")
+			},
+		},
+		HandleFunc: &SubRepoPathRuleStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				panic("unexpected invocation of MockSubRepoPathRuleStore.Handle")
+			},
+		},
+		ListByRepoFunc: &SubRepoPathRuleStoreListByRepoFunc{
+			defaultHook: func(context.Context, api.RepoID) ([]*SubRepoPathRule, error) {
+				panic("unexpected invocation of MockSubRepoPathRuleStore.ListByRepo")
+			},
+		},
+		SetForRepoFunc: &SubRepoPathRuleStoreSetForRepoFunc{
+			defaultHook: func(context.Context, api.RepoID, []*SubRepoPathRule) error {
+				panic("unexpected invocation of MockSubRepoPathRuleStore.SetForRepo")
+			},
+		},
+	}
+}
+
+// NewMockSubRepoPathRuleStoreFrom creates a new mock of the
+// MockSubRepoPathRuleStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockSubRepoPathRuleStoreFrom(i SubRepoPathRuleStore) *MockSubRepoPathRuleStore {
+	return &MockSubRepoPathRuleStore{
+		GetByUserFunc: &SubRepoPathRuleStoreGetByUserFunc{
+			defaultHook: i.GetByUser,
+		},
+		HandleFunc: &SubRepoPathRuleStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		ListByRepoFunc: &SubRepoPathRuleStoreListByRepoFunc{
+			defaultHook: i.ListByRepo,
+		},
+		SetForRepoFunc: &SubRepoPathRuleStoreSetForRepoFunc{
+			defaultHook: i.SetForRepo,
+		},
+	}
+}
+
+// SubRepoPathRuleStoreGetByUserFunc describes the behavior when the
+// GetByUser method of the parent MockSubRepoPathRuleStore instance is
+// invoked.
+type SubRepoPathRuleStoreGetByUserFunc struct {
+	defaultHook func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error)
+	hooks       []func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error)
+	history     []SubRepoPathRuleStoreGetByUserFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByUser delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSubRepoPathRuleStore) GetByUser(v0 context.Context, v1 int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+	r0, r1 := m.GetByUserFunc.nextHook()(v0, v1)
+	m.GetByUserFunc.appendCall(SubRepoPathRuleStoreGetByUserFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByUser method of
+// the parent MockSubRepoPathRuleStore instance is invoked and the hook
+// queue is empty.
+func (f *SubRepoPathRuleStoreGetByUserFunc) SetDefaultHook(hook func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByUser method of the parent MockSubRepoPathRuleStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SubRepoPathRuleStoreGetByUserFunc) PushHook(hook func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SubRepoPathRuleStoreGetByUserFunc) SetDefaultReturn(r0 map[api.RepoName]authz.SubRepoPermissions, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SubRepoPathRuleStoreGetByUserFunc) PushReturn(r0 map[api.RepoName]authz.SubRepoPermissions, r1 error) {
+	f.PushHook(func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+		return r0, r1
+	})
+}
+
+func (f *SubRepoPathRuleStoreGetByUserFunc) nextHook() func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SubRepoPathRuleStoreGetByUserFunc) appendCall(r0 SubRepoPathRuleStoreGetByUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SubRepoPathRuleStoreGetByUserFuncCall
+// objects describing the invocations of this function.
+func (f *SubRepoPathRuleStoreGetByUserFunc) History() []SubRepoPathRuleStoreGetByUserFuncCall {
+	f.mutex.Lock()
+	history := make([]SubRepoPathRuleStoreGetByUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SubRepoPathRuleStoreGetByUserFuncCall is an object that describes an
+// invocation of method GetByUser on an instance of
+// MockSubRepoPathRuleStore.
+type SubRepoPathRuleStoreGetByUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoName]authz.SubRepoPermissions
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SubRepoPathRuleStoreGetByUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SubRepoPathRuleStoreGetByUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SubRepoPathRuleStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockSubRepoPathRuleStore instance is invoked.
+type SubRepoPathRuleStoreHandleFunc struct {
+	defaultHook func() *basestore.TransactableHandle
+	hooks       []func() *basestore.TransactableHandle
+	history     []SubRepoPathRuleStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSubRepoPathRuleStore) Handle() *basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(SubRepoPathRuleStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockSubRepoPathRuleStore instance is invoked and the hook queue is
+// empty.
+func (f *SubRepoPathRuleStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockSubRepoPathRuleStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SubRepoPathRuleStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SubRepoPathRuleStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+	f.SetDefaultHook(func() *basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SubRepoPathRuleStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+	f.PushHook(func() *basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *SubRepoPathRuleStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SubRepoPathRuleStoreHandleFunc) appendCall(r0 SubRepoPathRuleStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SubRepoPathRuleStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *SubRepoPathRuleStoreHandleFunc) History() []SubRepoPathRuleStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]SubRepoPathRuleStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SubRepoPathRuleStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockSubRepoPathRuleStore.
+type SubRepoPathRuleStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SubRepoPathRuleStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SubRepoPathRuleStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SubRepoPathRuleStoreListByRepoFunc describes the behavior when the
+// ListByRepo method of the parent MockSubRepoPathRuleStore instance is
+// invoked.
+type SubRepoPathRuleStoreListByRepoFunc struct {
+	defaultHook func(context.Context, api.RepoID) ([]*SubRepoPathRule, error)
+	hooks       []func(context.Context, api.RepoID) ([]*SubRepoPathRule, error)
+	history     []SubRepoPathRuleStoreListByRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// ListByRepo delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSubRepoPathRuleStore) ListByRepo(v0 context.Context, v1 api.RepoID) ([]*SubRepoPathRule, error) {
+	r0, r1 := m.ListByRepoFunc.nextHook()(v0, v1)
+	m.ListByRepoFunc.appendCall(SubRepoPathRuleStoreListByRepoFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListByRepo method of
+// the parent MockSubRepoPathRuleStore instance is invoked and the hook
+// queue is empty.
+func (f *SubRepoPathRuleStoreListByRepoFunc) SetDefaultHook(hook func(context.Context, api.RepoID) ([]*SubRepoPathRule, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListByRepo method of the parent MockSubRepoPathRuleStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SubRepoPathRuleStoreListByRepoFunc) PushHook(hook func(context.Context, api.RepoID) ([]*SubRepoPathRule, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SubRepoPathRuleStoreListByRepoFunc) SetDefaultReturn(r0 []*SubRepoPathRule, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) ([]*SubRepoPathRule, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SubRepoPathRuleStoreListByRepoFunc) PushReturn(r0 []*SubRepoPathRule, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID) ([]*SubRepoPathRule, error) {
+		return r0, r1
+	})
+}
+
+func (f *SubRepoPathRuleStoreListByRepoFunc) nextHook() func(context.Context, api.RepoID) ([]*SubRepoPathRule, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SubRepoPathRuleStoreListByRepoFunc) appendCall(r0 SubRepoPathRuleStoreListByRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SubRepoPathRuleStoreListByRepoFuncCall
+// objects describing the invocations of this function.
+func (f *SubRepoPathRuleStoreListByRepoFunc) History() []SubRepoPathRuleStoreListByRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]SubRepoPathRuleStoreListByRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SubRepoPathRuleStoreListByRepoFuncCall is an object that describes an
+// invocation of method ListByRepo on an instance of
+// MockSubRepoPathRuleStore.
+type SubRepoPathRuleStoreListByRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*SubRepoPathRule
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SubRepoPathRuleStoreListByRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SubRepoPathRuleStoreListByRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SubRepoPathRuleStoreSetForRepoFunc describes the behavior when the
+// SetForRepo method of the parent MockSubRepoPathRuleStore instance is
+// invoked.
+type SubRepoPathRuleStoreSetForRepoFunc struct {
+	defaultHook func(context.Context, api.RepoID, []*SubRepoPathRule) error
+	hooks       []func(context.Context, api.RepoID, []*SubRepoPathRule) error
+	history     []SubRepoPathRuleStoreSetForRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// SetForRepo delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSubRepoPathRuleStore) SetForRepo(v0 context.Context, v1 api.RepoID, v2 []*SubRepoPathRule) error {
+	r0 := m.SetForRepoFunc.nextHook()(v0, v1, v2)
+	m.SetForRepoFunc.appendCall(SubRepoPathRuleStoreSetForRepoFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetForRepo method of
+// the parent MockSubRepoPathRuleStore instance is invoked and the hook
+// queue is empty.
+func (f *SubRepoPathRuleStoreSetForRepoFunc) SetDefaultHook(hook func(context.Context, api.RepoID, []*SubRepoPathRule) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetForRepo method of the parent MockSubRepoPathRuleStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SubRepoPathRuleStoreSetForRepoFunc) PushHook(hook func(context.Context, api.RepoID, []*SubRepoPathRule) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SubRepoPathRuleStoreSetForRepoFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, []*SubRepoPathRule) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SubRepoPathRuleStoreSetForRepoFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID, []*SubRepoPathRule) error {
+		return r0
+	})
+}
+
+func (f *SubRepoPathRuleStoreSetForRepoFunc) nextHook() func(context.Context, api.RepoID, []*SubRepoPathRule) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SubRepoPathRuleStoreSetForRepoFunc) appendCall(r0 SubRepoPathRuleStoreSetForRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SubRepoPathRuleStoreSetForRepoFuncCall
+// objects describing the invocations of this function.
+func (f *SubRepoPathRuleStoreSetForRepoFunc) History() []SubRepoPathRuleStoreSetForRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]SubRepoPathRuleStoreSetForRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SubRepoPathRuleStoreSetForRepoFuncCall is an object that describes an
+// invocation of method SetForRepo on an instance of
+// MockSubRepoPathRuleStore.
+type SubRepoPathRuleStoreSetForRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*SubRepoPathRule
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SubRepoPathRuleStoreSetForRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SubRepoPathRuleStoreSetForRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockSubRepoPermsStore is a mock implementation of the SubRepoPermsStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockSubRepoPermsStore struct {
+	// DoneFunc is an instance of a mock function object controlling the
+	// behavior of the method Done.
+	DoneFunc *SubRepoPermsStoreDoneFunc
+	// GetFunc is an instance of a mock function object controlling the
+	// behavior of the method Get.
+	GetFunc *SubRepoPermsStoreGetFunc
+	// GetByUserFunc is an instance of a mock function object controlling
+	// the behavior of the method GetByUser.
+	GetByUserFunc *SubRepoPermsStoreGetByUserFunc
+	// TransactFunc is an instance of a mock function object controlling the
+	// behavior of the method Transact.
+	TransactFunc *SubRepoPermsStoreTransactFunc
+	// UpsertFunc is an instance of a mock function object controlling the
+	// behavior of the method Upsert.
+	UpsertFunc *SubRepoPermsStoreUpsertFunc
+	// UpsertWithSpecFunc is an instance of a mock function object
+	// controlling the behavior of the method UpsertWithSpec.
+	UpsertWithSpecFunc *SubRepoPermsStoreUpsertWithSpecFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *SubRepoPermsStoreWithFunc
+}
+
+// NewMockSubRepoPermsStore creates a new mock of the SubRepoPermsStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockSubRepoPermsStore() *MockSubRepoPermsStore {
+	return &MockSubRepoPermsStore{
+		DoneFunc: &SubRepoPermsStoreDoneFunc{
+			defaultHook: func(error) error {
+				return nil
+			},
+		},
+		GetFunc: &SubRepoPermsStoreGetFunc{
+			defaultHook: func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error) {
+				return nil, nil
+			},
+		},
+		GetByUserFunc: &SubRepoPermsStoreGetByUserFunc{
+			defaultHook: func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+				return nil, nil
+			},
+		},
+		TransactFunc: &SubRepoPermsStoreTransactFunc{
+			defaultHook: func(context.Context) (SubRepoPermsStore, error) {
+				return nil, nil
+			},
+		},
+		UpsertFunc: &SubRepoPermsStoreUpsertFunc{
+			defaultHook: func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error {
+				return nil
+			},
+		},
+		UpsertWithSpecFunc: &SubRepoPermsStoreUpsertWithSpecFunc{
+			defaultHook: func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error {
+				return nil
+			},
+		},
+		WithFunc: &SubRepoPermsStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) SubRepoPermsStore {
+				return nil
+			},
+		},
+	}
+}
+
+// NewStrictMockSubRepoPermsStore creates a new mock of the
+// SubRepoPermsStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockSubRepoPermsStore() *MockSubRepoPermsStore {
+	return &MockSubRepoPermsStore{
+		DoneFunc: &SubRepoPermsStoreDoneFunc{
+			defaultHook: func(error) error {
+				panic("unexpected invocation of MockSubRepoPermsStore.Done")
+			},
+		},
+		GetFunc: &SubRepoPermsStoreGetFunc{
+			defaultHook: func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error) {
+				panic("unexpected invocation of MockSubRepoPermsStore.Get")
+			},
+		},
+		GetByUserFunc: &SubRepoPermsStoreGetByUserFunc{
+			defaultHook: func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+				panic("unexpected invocation of MockSubRepoPermsStore.GetByUser")
+			},
+		},
+		TransactFunc: &SubRepoPermsStoreTransactFunc{
+			defaultHook: func(context.Context) (SubRepoPermsStore, error) {
+				panic("unexpected invocation of MockSubRepoPermsStore.Transact")
+			},
+		},
+		UpsertFunc: &SubRepoPermsStoreUpsertFunc{
+			defaultHook: func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error {
+				panic("unexpected invocation of MockSubRepoPermsStore.Upsert")
+			},
+		},
+		UpsertWithSpecFunc: &SubRepoPermsStoreUpsertWithSpecFunc{
+			defaultHook: func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error {
+				panic("unexpected invocation of MockSubRepoPermsStore.UpsertWithSpec")
+			},
+		},
+		WithFunc: &SubRepoPermsStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) SubRepoPermsStore {
+				panic("unexpected invocation of MockSubRepoPermsStore.With")
+			},
+		},
+	}
+}
+
+// NewMockSubRepoPermsStoreFrom creates a new mock of the
+// MockSubRepoPermsStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockSubRepoPermsStoreFrom(i SubRepoPermsStore) *MockSubRepoPermsStore {
+	return &MockSubRepoPermsStore{
+		DoneFunc: &SubRepoPermsStoreDoneFunc{
+			defaultHook: i.Done,
+		},
+		GetFunc: &SubRepoPermsStoreGetFunc{
+			defaultHook: i.Get,
+		},
+		GetByUserFunc: &SubRepoPermsStoreGetByUserFunc{
+			defaultHook: i.GetByUser,
+		},
+		TransactFunc: &SubRepoPermsStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		UpsertFunc: &SubRepoPermsStoreUpsertFunc{
+			defaultHook: i.Upsert,
+		},
+		UpsertWithSpecFunc: &SubRepoPermsStoreUpsertWithSpecFunc{
+			defaultHook: i.UpsertWithSpec,
+		},
+		WithFunc: &SubRepoPermsStoreWithFunc{
+			defaultHook: i.With,
+		},
+	}
+}
+
+// SubRepoPermsStoreDoneFunc describes the behavior when the Done method of
+// the parent MockSubRepoPermsStore instance is invoked.
+type SubRepoPermsStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []SubRepoPermsStoreDoneFuncCall
+	mutex       sync.Mutex
+}
+
+// Done delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSubRepoPermsStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(SubRepoPermsStoreDoneFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockSubRepoPermsStore instance is invoked and the hook queue is
+// empty.
+func (f *SubRepoPermsStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Done method of the parent MockSubRepoPermsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SubRepoPermsStoreDoneFunc) PushHook(hook func(error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SubRepoPermsStoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SubRepoPermsStoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
+		return r0
+	})
+}
+
+func (f *SubRepoPermsStoreDoneFunc) nextHook() func(error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SubRepoPermsStoreDoneFunc) appendCall(r0 SubRepoPermsStoreDoneFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SubRepoPermsStoreDoneFuncCall objects
+// describing the invocations of this function.
+func (f *SubRepoPermsStoreDoneFunc) History() []SubRepoPermsStoreDoneFuncCall {
+	f.mutex.Lock()
+	history := make([]SubRepoPermsStoreDoneFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SubRepoPermsStoreDoneFuncCall is an object that describes an invocation
+// of method Done on an instance of MockSubRepoPermsStore.
+type SubRepoPermsStoreDoneFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SubRepoPermsStoreDoneFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SubRepoPermsStoreDoneFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SubRepoPermsStoreGetFunc describes the behavior when the Get method of
+// the parent MockSubRepoPermsStore instance is invoked.
+type SubRepoPermsStoreGetFunc struct {
+	defaultHook func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error)
+	hooks       []func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error)
+	history     []SubRepoPermsStoreGetFuncCall
+	mutex       sync.Mutex
+}
+
+// Get delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSubRepoPermsStore) Get(v0 context.Context, v1 int32, v2 api.RepoID) (*authz.SubRepoPermissions, error) {
+	r0, r1 := m.GetFunc.nextHook()(v0, v1, v2)
+	m.GetFunc.appendCall(SubRepoPermsStoreGetFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Get method of the
+// parent MockSubRepoPermsStore instance is invoked and the hook queue is
+// empty.
+func (f *SubRepoPermsStoreGetFunc) SetDefaultHook(hook func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Get method of the parent MockSubRepoPermsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SubRepoPermsStoreGetFunc) PushHook(hook func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SubRepoPermsStoreGetFunc) SetDefaultReturn(r0 *authz.SubRepoPermissions, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SubRepoPermsStoreGetFunc) PushReturn(r0 *authz.SubRepoPermissions, r1 error) {
+	f.PushHook(func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error) {
+		return r0, r1
+	})
+}
+
+func (f *SubRepoPermsStoreGetFunc) nextHook() func(context.Context, int32, api.RepoID) (*authz.SubRepoPermissions, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SubRepoPermsStoreGetFunc) appendCall(r0 SubRepoPermsStoreGetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SubRepoPermsStoreGetFuncCall objects
+// describing the invocations of this function.
+func (f *SubRepoPermsStoreGetFunc) History() []SubRepoPermsStoreGetFuncCall {
+	f.mutex.Lock()
+	history := make([]SubRepoPermsStoreGetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SubRepoPermsStoreGetFuncCall is an object that describes an invocation of
+// method Get on an instance of MockSubRepoPermsStore.
+type SubRepoPermsStoreGetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *authz.SubRepoPermissions
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SubRepoPermsStoreGetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SubRepoPermsStoreGetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SubRepoPermsStoreGetByUserFunc describes the behavior when the GetByUser
+// method of the parent MockSubRepoPermsStore instance is invoked.
+type SubRepoPermsStoreGetByUserFunc struct {
+	defaultHook func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error)
+	hooks       []func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error)
+	history     []SubRepoPermsStoreGetByUserFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByUser delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSubRepoPermsStore) GetByUser(v0 context.Context, v1 int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+	r0, r1 := m.GetByUserFunc.nextHook()(v0, v1)
+	m.GetByUserFunc.appendCall(SubRepoPermsStoreGetByUserFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByUser method of
+// the parent MockSubRepoPermsStore instance is invoked and the hook queue
+// is empty.
+func (f *SubRepoPermsStoreGetByUserFunc) SetDefaultHook(hook func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByUser method of the parent MockSubRepoPermsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SubRepoPermsStoreGetByUserFunc) PushHook(hook func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SubRepoPermsStoreGetByUserFunc) SetDefaultReturn(r0 map[api.RepoName]authz.SubRepoPermissions, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SubRepoPermsStoreGetByUserFunc) PushReturn(r0 map[api.RepoName]authz.SubRepoPermissions, r1 error) {
+	f.PushHook(func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+		return r0, r1
+	})
+}
+
+func (f *SubRepoPermsStoreGetByUserFunc) nextHook() func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SubRepoPermsStoreGetByUserFunc) appendCall(r0 SubRepoPermsStoreGetByUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SubRepoPermsStoreGetByUserFuncCall objects
+// describing the invocations of this function.
+func (f *SubRepoPermsStoreGetByUserFunc) History() []SubRepoPermsStoreGetByUserFuncCall {
+	f.mutex.Lock()
+	history := make([]SubRepoPermsStoreGetByUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SubRepoPermsStoreGetByUserFuncCall is an object that describes an
+// invocation of method GetByUser on an instance of MockSubRepoPermsStore.
+type SubRepoPermsStoreGetByUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoName]authz.SubRepoPermissions
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SubRepoPermsStoreGetByUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SubRepoPermsStoreGetByUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SubRepoPermsStoreTransactFunc describes the behavior when the Transact
+// method of the parent MockSubRepoPermsStore instance is invoked.
+type SubRepoPermsStoreTransactFunc struct {
+	defaultHook func(context.Context) (SubRepoPermsStore, error)
+	hooks       []func(context.Context) (SubRepoPermsStore, error)
+	history     []SubRepoPermsStoreTransactFuncCall
+	mutex       sync.Mutex
+}
+
+// Transact delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSubRepoPermsStore) Transact(v0 context.Context) (SubRepoPermsStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(SubRepoPermsStoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockSubRepoPermsStore instance is invoked and the hook queue
+// is empty.
+func (f *SubRepoPermsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SubRepoPermsStore, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Transact method of the parent MockSubRepoPermsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SubRepoPermsStoreTransactFunc) PushHook(hook func(context.Context) (SubRepoPermsStore, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SubRepoPermsStoreTransactFunc) SetDefaultReturn(r0 SubRepoPermsStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (SubRepoPermsStore, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SubRepoPermsStoreTransactFunc) PushReturn(r0 SubRepoPermsStore, r1 error) {
+	f.PushHook(func(context.Context) (SubRepoPermsStore, error) {
+		return r0, r1
+	})
+}
+
+func (f *SubRepoPermsStoreTransactFunc) nextHook() func(context.Context) (SubRepoPermsStore, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SubRepoPermsStoreTransactFunc) appendCall(r0 SubRepoPermsStoreTransactFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SubRepoPermsStoreTransactFuncCall objects
+// describing the invocations of this function.
+func (f *SubRepoPermsStoreTransactFunc) History() []SubRepoPermsStoreTransactFuncCall {
+	f.mutex.Lock()
+	history := make([]SubRepoPermsStoreTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SubRepoPermsStoreTransactFuncCall is an object that describes an
+// invocation of method Transact on an instance of MockSubRepoPermsStore.
+type SubRepoPermsStoreTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 SubRepoPermsStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SubRepoPermsStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SubRepoPermsStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SubRepoPermsStoreUpsertFunc describes the behavior when the Upsert method
+// of the parent MockSubRepoPermsStore instance is invoked.
+type SubRepoPermsStoreUpsertFunc struct {
+	defaultHook func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error
+	hooks       []func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error
+	history     []SubRepoPermsStoreUpsertFuncCall
+	mutex       sync.Mutex
+}
+
+// Upsert delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSubRepoPermsStore) Upsert(v0 context.Context, v1 int32, v2 api.RepoID, v3 authz.SubRepoPermissions) error {
+	r0 := m.UpsertFunc.nextHook()(v0, v1, v2, v3)
+	m.UpsertFunc.appendCall(SubRepoPermsStoreUpsertFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Upsert method of the
+// parent MockSubRepoPermsStore instance is invoked and the hook queue is
+// empty.
+func (f *SubRepoPermsStoreUpsertFunc) SetDefaultHook(hook func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Upsert method of the parent MockSubRepoPermsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SubRepoPermsStoreUpsertFunc) PushHook(hook func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SubRepoPermsStoreUpsertFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SubRepoPermsStoreUpsertFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error {
+		return r0
+	})
+}
+
+func (f *SubRepoPermsStoreUpsertFunc) nextHook() func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SubRepoPermsStoreUpsertFunc) appendCall(r0 SubRepoPermsStoreUpsertFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SubRepoPermsStoreUpsertFuncCall objects
+// describing the invocations of this function.
+func (f *SubRepoPermsStoreUpsertFunc) History() []SubRepoPermsStoreUpsertFuncCall {
+	f.mutex.Lock()
+	history := make([]SubRepoPermsStoreUpsertFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SubRepoPermsStoreUpsertFuncCall is an object that describes an invocation
+// of method Upsert on an instance of MockSubRepoPermsStore.
+type SubRepoPermsStoreUpsertFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 authz.SubRepoPermissions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SubRepoPermsStoreUpsertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SubRepoPermsStoreUpsertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SubRepoPermsStoreUpsertWithSpecFunc describes the behavior when the
+// UpsertWithSpec method of the parent MockSubRepoPermsStore instance is
+// invoked.
+type SubRepoPermsStoreUpsertWithSpecFunc struct {
+	defaultHook func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error
+	hooks       []func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error
+	history     []SubRepoPermsStoreUpsertWithSpecFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertWithSpec delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSubRepoPermsStore) UpsertWithSpec(v0 context.Context, v1 int32, v2 api.ExternalRepoSpec, v3 authz.SubRepoPermissions) error {
+	r0 := m.UpsertWithSpecFunc.nextHook()(v0, v1, v2, v3)
+	m.UpsertWithSpecFunc.appendCall(SubRepoPermsStoreUpsertWithSpecFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpsertWithSpec
+// method of the parent MockSubRepoPermsStore instance is invoked and the
+// hook queue is empty.
+func (f *SubRepoPermsStoreUpsertWithSpecFunc) SetDefaultHook(hook func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertWithSpec method of the parent MockSubRepoPermsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SubRepoPermsStoreUpsertWithSpecFunc) PushHook(hook func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SubRepoPermsStoreUpsertWithSpecFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SubRepoPermsStoreUpsertWithSpecFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error {
+		return r0
+	})
+}
+
+func (f *SubRepoPermsStoreUpsertWithSpecFunc) nextHook() func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SubRepoPermsStoreUpsertWithSpecFunc) appendCall(r0 SubRepoPermsStoreUpsertWithSpecFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SubRepoPermsStoreUpsertWithSpecFuncCall
+// objects describing the invocations of this function.
+func (f *SubRepoPermsStoreUpsertWithSpecFunc) History() []SubRepoPermsStoreUpsertWithSpecFuncCall {
+	f.mutex.Lock()
+	history := make([]SubRepoPermsStoreUpsertWithSpecFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SubRepoPermsStoreUpsertWithSpecFuncCall is an object that describes an
+// invocation of method UpsertWithSpec on an instance of
+// MockSubRepoPermsStore.
+type SubRepoPermsStoreUpsertWithSpecFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.ExternalRepoSpec
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 authz.SubRepoPermissions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SubRepoPermsStoreUpsertWithSpecFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SubRepoPermsStoreUpsertWithSpecFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SubRepoPermsStoreWithFunc describes the behavior when the With method of
+// the parent MockSubRepoPermsStore instance is invoked.
+type SubRepoPermsStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) SubRepoPermsStore
+	hooks       []func(basestore.ShareableStore) SubRepoPermsStore
+	history     []SubRepoPermsStoreWithFuncCall
+	mutex       sync.Mutex
+}
+
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSubRepoPermsStore) With(v0 basestore.ShareableStore) SubRepoPermsStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(SubRepoPermsStoreWithFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockSubRepoPermsStore instance is invoked and the hook queue is
+// empty.
+func (f *SubRepoPermsStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) SubRepoPermsStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// With method of the parent MockSubRepoPermsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SubRepoPermsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) SubRepoPermsStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SubRepoPermsStoreWithFunc) SetDefaultReturn(r0 SubRepoPermsStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) SubRepoPermsStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SubRepoPermsStoreWithFunc) PushReturn(r0 SubRepoPermsStore) {
+	f.PushHook(func(basestore.ShareableStore) SubRepoPermsStore {
+		return r0
+	})
+}
+
+func (f *SubRepoPermsStoreWithFunc) nextHook() func(basestore.ShareableStore) SubRepoPermsStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SubRepoPermsStoreWithFunc) appendCall(r0 SubRepoPermsStoreWithFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SubRepoPermsStoreWithFuncCall objects
+// describing the invocations of this function.
+func (f *SubRepoPermsStoreWithFunc) History() []SubRepoPermsStoreWithFuncCall {
+	f.mutex.Lock()
+	history := make([]SubRepoPermsStoreWithFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SubRepoPermsStoreWithFuncCall is an object that describes an invocation
+// of method With on an instance of MockSubRepoPermsStore.
+type SubRepoPermsStoreWithFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 basestore.ShareableStore
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 SubRepoPermsStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SubRepoPermsStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SubRepoPermsStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockTemporarySettingsStore is a mock implementation of the
+// TemporarySettingsStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockTemporarySettingsStore struct {
+	// EditTemporarySettingsFunc is an instance of a mock function object
+	// controlling the behavior of the method EditTemporarySettings.
+	EditTemporarySettingsFunc *TemporarySettingsStoreEditTemporarySettingsFunc
+	// GetTemporarySettingsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetTemporarySettings.
+	GetTemporarySettingsFunc *TemporarySettingsStoreGetTemporarySettingsFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *TemporarySettingsStoreHandleFunc
+	// OverwriteTemporarySettingsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// OverwriteTemporarySettings.
+	OverwriteTemporarySettingsFunc *TemporarySettingsStoreOverwriteTemporarySettingsFunc
+}
+
+// NewMockTemporarySettingsStore creates a new mock of the
+// TemporarySettingsStore interface. All methods return zero values for all
+// results, unless overwritten.
+func NewMockTemporarySettingsStore() *MockTemporarySettingsStore {
+	return &MockTemporarySettingsStore{
+		EditTemporarySettingsFunc: &TemporarySettingsStoreEditTemporarySettingsFunc{
+			defaultHook: func(context.Context, int32, string) error {
+				return nil
+			},
+		},
+		GetTemporarySettingsFunc: &TemporarySettingsStoreGetTemporarySettingsFunc{
+			defaultHook: func(context.Context, int32) (*temporarysettings.TemporarySettings, error) {
+				return nil, nil
+			},
+		},
+		HandleFunc: &TemporarySettingsStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				return nil
+			},
+		},
+		OverwriteTemporarySettingsFunc: &TemporarySettingsStoreOverwriteTemporarySettingsFunc{
+			defaultHook: func(context.Context, int32, string) error {
+				return nil
+			},
+		},
+	}
+}
+
+// NewStrictMockTemporarySettingsStore creates a new mock of the
+// TemporarySettingsStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockTemporarySettingsStore() *MockTemporarySettingsStore {
+	return &MockTemporarySettingsStore{
+		EditTemporarySettingsFunc: &TemporarySettingsStoreEditTemporarySettingsFunc{
+			defaultHook: func(context.Context, int32, string) error {
+				panic("unexpected invocation of MockTemporarySettingsStore.EditTemporarySettings")
+			},
+		},
+		GetTemporarySettingsFunc: &TemporarySettingsStoreGetTemporarySettingsFunc{
+			defaultHook: func(context.Context, int32) (*temporarysettings.TemporarySettings, error) {
+				panic("unexpected invocation of MockTemporarySettingsStore.GetTemporarySettings")
+			},
+		},
+		HandleFunc: &TemporarySettingsStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				panic("unexpected invocation of MockTemporarySettingsStore.Handle")
+			},
+		},
+		OverwriteTemporarySettingsFunc: &TemporarySettingsStoreOverwriteTemporarySettingsFunc{
+			defaultHook: func(context.Context, int32, string) error {
+				panic("unexpected invocation of MockTemporarySettingsStore.OverwriteTemporarySettings")
+			},
+		},
+	}
+}
+
+// NewMockTemporarySettingsStoreFrom creates a new mock of the
+// MockTemporarySettingsStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockTemporarySettingsStoreFrom(i TemporarySettingsStore) *MockTemporarySettingsStore {
+	return &MockTemporarySettingsStore{
+		EditTemporarySettingsFunc: &TemporarySettingsStoreEditTemporarySettingsFunc{
+			defaultHook: i.EditTemporarySettings,
+		},
+		GetTemporarySettingsFunc: &TemporarySettingsStoreGetTemporarySettingsFunc{
+			defaultHook: i.GetTemporarySettings,
+		},
+		HandleFunc: &TemporarySettingsStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		OverwriteTemporarySettingsFunc: &TemporarySettingsStoreOverwriteTemporarySettingsFunc{
+			defaultHook: i.OverwriteTemporarySettings,
+		},
+	}
+}
+
+// TemporarySettingsStoreEditTemporarySettingsFunc describes the behavior
+// when the EditTemporarySettings method of the parent
+// MockTemporarySettingsStore instance is invoked.
+type TemporarySettingsStoreEditTemporarySettingsFunc struct {
+	defaultHook func(context.Context, int32, string) error
+	hooks       []func(context.Context, int32, string) error
+	history     []TemporarySettingsStoreEditTemporarySettingsFuncCall
+	mutex       sync.Mutex
+}
+
+// EditTemporarySettings delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockTemporarySettingsStore) EditTemporarySettings(v0 context.Context, v1 int32, v2 string) error {
+	r0 := m.EditTemporarySettingsFunc.nextHook()(v0, v1, v2)
+	m.EditTemporarySettingsFunc.appendCall(TemporarySettingsStoreEditTemporarySettingsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// EditTemporarySettings method of the parent MockTemporarySettingsStore
+// instance is invoked and the hook queue is empty.
+func (f *TemporarySettingsStoreEditTemporarySettingsFunc) SetDefaultHook(hook func(context.Context, int32, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// EditTemporarySettings method of the parent MockTemporarySettingsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *TemporarySettingsStoreEditTemporarySettingsFunc) PushHook(hook func(context.Context, int32, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *TemporarySettingsStoreEditTemporarySettingsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *TemporarySettingsStoreEditTemporarySettingsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string) error {
+		return r0
+	})
+}
+
+func (f *TemporarySettingsStoreEditTemporarySettingsFunc) nextHook() func(context.Context, int32, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *TemporarySettingsStoreEditTemporarySettingsFunc) appendCall(r0 TemporarySettingsStoreEditTemporarySettingsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// TemporarySettingsStoreEditTemporarySettingsFuncCall objects describing
+// the invocations of this function.
+func (f *TemporarySettingsStoreEditTemporarySettingsFunc) History() []TemporarySettingsStoreEditTemporarySettingsFuncCall {
+	f.mutex.Lock()
+	history := make([]TemporarySettingsStoreEditTemporarySettingsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// TemporarySettingsStoreEditTemporarySettingsFuncCall is an object that
+// describes an invocation of method EditTemporarySettings on an instance of
+// MockTemporarySettingsStore.
+type TemporarySettingsStoreEditTemporarySettingsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c TemporarySettingsStoreEditTemporarySettingsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c TemporarySettingsStoreEditTemporarySettingsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// TemporarySettingsStoreGetTemporarySettingsFunc describes the behavior
+// when the GetTemporarySettings method of the parent
+// MockTemporarySettingsStore instance is invoked.
+type TemporarySettingsStoreGetTemporarySettingsFunc struct {
+	defaultHook func(context.Context, int32) (*temporarysettings.TemporarySettings, error)
+	hooks       []func(context.Context, int32) (*temporarysettings.TemporarySettings, error)
+	history     []TemporarySettingsStoreGetTemporarySettingsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTemporarySettings delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockTemporarySettingsStore) GetTemporarySettings(v0 context.Context, v1 int32) (*temporarysettings.TemporarySettings, error) {
+	r0, r1 := m.GetTemporarySettingsFunc.nextHook()(v0, v1)
+	m.GetTemporarySettingsFunc.appendCall(TemporarySettingsStoreGetTemporarySettingsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetTemporarySettings
+// method of the parent MockTemporarySettingsStore instance is invoked and
+// the hook queue is empty.
+func (f *TemporarySettingsStoreGetTemporarySettingsFunc) SetDefaultHook(hook func(context.Context, int32) (*temporarysettings.TemporarySettings, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTemporarySettings method of the parent MockTemporarySettingsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *TemporarySettingsStoreGetTemporarySettingsFunc) PushHook(hook func(context.Context, int32) (*temporarysettings.TemporarySettings, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29224,21 +32371,521 @@ func (f *SubRepoPermsStoreGetByUserFunc) PushHook(hook func(context.Context, int
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SubRepoPermsStoreGetByUserFunc) SetDefaultReturn(r0 map[api.RepoName]authz.SubRepoPermissions, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+func (f *TemporarySettingsStoreGetTemporarySettingsFunc) SetDefaultReturn(r0 *temporarysettings.TemporarySettings, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*temporarysettings.TemporarySettings, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *TemporarySettingsStoreGetTemporarySettingsFunc) PushReturn(r0 *temporarysettings.TemporarySettings, r1 error) {
+	f.PushHook(func(context.Context, int32) (*temporarysettings.TemporarySettings, error) {
+		return r0, r1
+	})
+}
+
+func (f *TemporarySettingsStoreGetTemporarySettingsFunc) nextHook() func(context.Context, int32) (*temporarysettings.TemporarySettings, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *TemporarySettingsStoreGetTemporarySettingsFunc) appendCall(r0 TemporarySettingsStoreGetTemporarySettingsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// TemporarySettingsStoreGetTemporarySettingsFuncCall objects describing the
+// invocations of this function.
+func (f *TemporarySettingsStoreGetTemporarySettingsFunc) History() []TemporarySettingsStoreGetTemporarySettingsFuncCall {
+	f.mutex.Lock()
+	history := make([]TemporarySettingsStoreGetTemporarySettingsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// TemporarySettingsStoreGetTemporarySettingsFuncCall is an object that
+// describes an invocation of method GetTemporarySettings on an instance of
+// MockTemporarySettingsStore.
+type TemporarySettingsStoreGetTemporarySettingsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *temporarysettings.TemporarySettings
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c TemporarySettingsStoreGetTemporarySettingsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c TemporarySettingsStoreGetTemporarySettingsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// TemporarySettingsStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockTemporarySettingsStore instance is invoked.
+type TemporarySettingsStoreHandleFunc struct {
+	defaultHook func() *basestore.TransactableHandle
+	hooks       []func() *basestore.TransactableHandle
+	history     []TemporarySettingsStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockTemporarySettingsStore) Handle() *basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(TemporarySettingsStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockTemporarySettingsStore instance is invoked and the hook queue
+// is empty.
+func (f *TemporarySettingsStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockTemporarySettingsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *TemporarySettingsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *TemporarySettingsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+	f.SetDefaultHook(func() *basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *TemporarySettingsStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+	f.PushHook(func() *basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *TemporarySettingsStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *TemporarySettingsStoreHandleFunc) appendCall(r0 TemporarySettingsStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of TemporarySettingsStoreHandleFuncCall
+// objects describing the invocations of this function.
+func (f *TemporarySettingsStoreHandleFunc) History() []TemporarySettingsStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]TemporarySettingsStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// TemporarySettingsStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockTemporarySettingsStore.
+type TemporarySettingsStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c TemporarySettingsStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c TemporarySettingsStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// TemporarySettingsStoreOverwriteTemporarySettingsFunc describes the
+// behavior when the OverwriteTemporarySettings method of the parent
+// MockTemporarySettingsStore instance is invoked.
+type TemporarySettingsStoreOverwriteTemporarySettingsFunc struct {
+	defaultHook func(context.Context, int32, string) error
+	hooks       []func(context.Context, int32, string) error
+	history     []TemporarySettingsStoreOverwriteTemporarySettingsFuncCall
+	mutex       sync.Mutex
+}
+
+// OverwriteTemporarySettings delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockTemporarySettingsStore) OverwriteTemporarySettings(v0 context.Context, v1 int32, v2 string) error {
+	r0 := m.OverwriteTemporarySettingsFunc.nextHook()(v0, v1, v2)
+	m.OverwriteTemporarySettingsFunc.appendCall(TemporarySettingsStoreOverwriteTemporarySettingsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// OverwriteTemporarySettings method of the parent
+// MockTemporarySettingsStore instance is invoked and the hook queue is
+// empty.
+func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) SetDefaultHook(hook func(context.Context, int32, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// OverwriteTemporarySettings method of the parent
+// MockTemporarySettingsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) PushHook(hook func(context.Context, int32, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string) error {
+		return r0
+	})
+}
+
+func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) nextHook() func(context.Context, int32, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) appendCall(r0 TemporarySettingsStoreOverwriteTemporarySettingsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// TemporarySettingsStoreOverwriteTemporarySettingsFuncCall objects
+// describing the invocations of this function.
+func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) History() []TemporarySettingsStoreOverwriteTemporarySettingsFuncCall {
+	f.mutex.Lock()
+	history := make([]TemporarySettingsStoreOverwriteTemporarySettingsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// TemporarySettingsStoreOverwriteTemporarySettingsFuncCall is an object
+// that describes an invocation of method OverwriteTemporarySettings on an
+// instance of MockTemporarySettingsStore.
+type TemporarySettingsStoreOverwriteTemporarySettingsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c TemporarySettingsStoreOverwriteTemporarySettingsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c TemporarySettingsStoreOverwriteTemporarySettingsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockUserCredentialsStore is a mock implementation of the
+// UserCredentialsStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockUserCredentialsStore struct {
+	// CreateFunc is an instance of a mock function object controlling the
+	// behavior of the method Create.
+	CreateFunc *UserCredentialsStoreCreateFunc
+	// DeleteFunc is an instance of a mock function object controlling the
+	// behavior of the method Delete.
+	DeleteFunc *UserCredentialsStoreDeleteFunc
+	// GetByIDFunc is an instance of a mock function object controlling the
+	// behavior of the method GetByID.
+	GetByIDFunc *UserCredentialsStoreGetByIDFunc
+	// GetByScopeFunc is an instance of a mock function object controlling
+	// the behavior of the method GetByScope.
+	GetByScopeFunc *UserCredentialsStoreGetByScopeFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *UserCredentialsStoreHandleFunc
+	// ListFunc is an instance of a mock function object controlling the
+	// behavior of the method List.
+	ListFunc *UserCredentialsStoreListFunc
+	// TransactFunc is an instance of a mock function object controlling the
+	// behavior of the method Transact.
+	TransactFunc *UserCredentialsStoreTransactFunc
+	// UpdateFunc is an instance of a mock function object controlling the
+	// behavior of the method Update.
+	UpdateFunc *UserCredentialsStoreUpdateFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *UserCredentialsStoreWithFunc
+}
+
+// NewMockUserCredentialsStore creates a new mock of the
+// UserCredentialsStore interface. All methods return zero values for all
+// results, unless overwritten.
+func NewMockUserCredentialsStore() *MockUserCredentialsStore {
+	return &MockUserCredentialsStore{
+		CreateFunc: &UserCredentialsStoreCreateFunc{
+			defaultHook: func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error) {
+				return nil, nil
+			},
+		},
+		DeleteFunc: &UserCredentialsStoreDeleteFunc{
+			defaultHook: func(context.Context, int64) error {
+				return nil
+			},
+		},
+		GetByIDFunc: &UserCredentialsStoreGetByIDFunc{
+			defaultHook: func(context.Context, int64) (*UserCredential, error) {
+				return nil, nil
+			},
+		},
+		GetByScopeFunc: &UserCredentialsStoreGetByScopeFunc{
+			defaultHook: func(context.Context, UserCredentialScope) (*UserCredential, error) {
+				return nil, nil
+			},
+		},
+		HandleFunc: &UserCredentialsStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				return nil
+			},
+		},
+		ListFunc: &UserCredentialsStoreListFunc{
+			defaultHook: func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error) {
+				return nil, 0, nil
+			},
+		},
+		TransactFunc: &UserCredentialsStoreTransactFunc{
+			defaultHook: func(context.Context) (UserCredentialsStore, error) {
+				return nil, nil
+			},
+		},
+		UpdateFunc: &UserCredentialsStoreUpdateFunc{
+			defaultHook: func(context.Context, *UserCredential) error {
+				return nil
+			},
+		},
+		WithFunc: &UserCredentialsStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) UserCredentialsStore {
+				return nil
+			},
+		},
+	}
+}
+
+// NewStrictMockUserCredentialsStore creates a new mock of the
+// UserCredentialsStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockUserCredentialsStore() *MockUserCredentialsStore {
+	return &MockUserCredentialsStore{
+		CreateFunc: &UserCredentialsStoreCreateFunc{
+			defaultHook: func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error) {
+				panic("unexpected invocation of MockUserCredentialsStore.Create")
+			},
+		},
+		DeleteFunc: &UserCredentialsStoreDeleteFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockUserCredentialsStore.Delete")
+			},
+		},
+		GetByIDFunc: &UserCredentialsStoreGetByIDFunc{
+			defaultHook: func(context.Context, int64) (*UserCredential, error) {
+				panic("unexpected invocation of MockUserCredentialsStore.GetByID")
+			},
+		},
+		GetByScopeFunc: &UserCredentialsStoreGetByScopeFunc{
+			defaultHook: func(context.Context, UserCredentialScope) (*UserCredential, error) {
+				panic("unexpected invocation of MockUserCredentialsStore.GetByScope")
+			},
+		},
+		HandleFunc: &UserCredentialsStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				panic("unexpected invocation of MockUserCredentialsStore.Handle")
+			},
+		},
+		ListFunc: &UserCredentialsStoreListFunc{
+			defaultHook: func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error) {
+				panic("unexpected invocation of MockUserCredentialsStore.List")
+			},
+		},
+		TransactFunc: &UserCredentialsStoreTransactFunc{
+			defaultHook: func(context.Context) (UserCredentialsStore, error) {
+				panic("unexpected invocation of MockUserCredentialsStore.Transact")
+			},
+		},
+		UpdateFunc: &UserCredentialsStoreUpdateFunc{
+			defaultHook: func(context.Context, *UserCredential) error {
+				panic("unexpected invocation of MockUserCredentialsStore.Update")
+			},
+		},
+		WithFunc: &UserCredentialsStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) UserCredentialsStore {
+				panic("unexpected invocation of MockUserCredentialsStore.With")
+			},
+		},
+	}
+}
+
+// NewMockUserCredentialsStoreFrom creates a new mock of the
+// MockUserCredentialsStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockUserCredentialsStoreFrom(i UserCredentialsStore) *MockUserCredentialsStore {
+	return &MockUserCredentialsStore{
+		CreateFunc: &UserCredentialsStoreCreateFunc{
+			defaultHook: i.Create,
+		},
+		DeleteFunc: &UserCredentialsStoreDeleteFunc{
+			defaultHook: i.Delete,
+		},
+		GetByIDFunc: &UserCredentialsStoreGetByIDFunc{
+			defaultHook: i.GetByID,
+		},
+		GetByScopeFunc: &UserCredentialsStoreGetByScopeFunc{
+			defaultHook: i.GetByScope,
+		},
+		HandleFunc: &UserCredentialsStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		ListFunc: &UserCredentialsStoreListFunc{
+			defaultHook: i.List,
+		},
+		TransactFunc: &UserCredentialsStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		UpdateFunc: &UserCredentialsStoreUpdateFunc{
+			defaultHook: i.Update,
+		},
+		WithFunc: &UserCredentialsStoreWithFunc{
+			defaultHook: i.With,
+		},
+	}
+}
+
+// UserCredentialsStoreCreateFunc describes the behavior when the Create
+// method of the parent MockUserCredentialsStore instance is invoked.
+type UserCredentialsStoreCreateFunc struct {
+	defaultHook func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error)
+	hooks       []func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error)
+	history     []UserCredentialsStoreCreateFuncCall
+	mutex       sync.Mutex
+}
+
+// Create delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserCredentialsStore) Create(v0 context.Context, v1 UserCredentialScope, v2 auth.Authenticator) (*UserCredential, error) {
+	r0, r1 := m.CreateFunc.nextHook()(v0, v1, v2)
+	m.CreateFunc.appendCall(UserCredentialsStoreCreateFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockUserCredentialsStore instance is invoked and the hook queue is
+// empty.
+func (f *UserCredentialsStoreCreateFunc) SetDefaultHook(hook func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Create method of the parent MockUserCredentialsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserCredentialsStoreCreateFunc) PushHook(hook func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *UserCredentialsStoreCreateFunc) SetDefaultReturn(r0 *UserCredential, r1 error) {
+	f.SetDefaultHook(func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SubRepoPermsStoreGetByUserFunc) PushReturn(r0 map[api.RepoName]authz.SubRepoPermissions, r1 error) {
-	f.PushHook(func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+func (f *UserCredentialsStoreCreateFunc) PushReturn(r0 *UserCredential, r1 error) {
+	f.PushHook(func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error) {
 		return r0, r1
 	})
 }
 
-func (f *SubRepoPermsStoreGetByUserFunc) nextHook() func(context.Context, int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+func (f *UserCredentialsStoreCreateFunc) nextHook() func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -29251,35 +32898,38 @@ func (f *SubRepoPermsStoreGetByUserFunc) nextHook() func(context.Context, int32)
 	return hook
 }
 
-func (f *SubRepoPermsStoreGetByUserFunc) appendCall(r0 SubRepoPermsStoreGetByUserFuncCall) {
+func (f *UserCredentialsStoreCreateFunc) appendCall(r0 UserCredentialsStoreCreateFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SubRepoPermsStoreGetByUserFuncCall objects
+// History returns a sequence of UserCredentialsStoreCreateFuncCall objects
 // describing the invocations of this function.
-func (f *SubRepoPermsStoreGetByUserFunc) History() []SubRepoPermsStoreGetByUserFuncCall {
+func (f *UserCredentialsStoreCreateFunc) History() []UserCredentialsStoreCreateFuncCall {
 	f.mutex.Lock()
-	history := make([]SubRepoPermsStoreGetByUserFuncCall, len(f.history))
+	history := make([]UserCredentialsStoreCreateFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SubRepoPermsStoreGetByUserFuncCall is an object that describes an
-// invocation of method GetByUser on an instance of MockSubRepoPermsStore.
-type SubRepoPermsStoreGetByUserFuncCall struct {
+// UserCredentialsStoreCreateFuncCall is an object that describes an
+// invocation of method Create on an instance of MockUserCredentialsStore.
+type UserCredentialsStoreCreateFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 UserCredentialScope
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 auth.Authenticator
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 map[api.RepoName]authz.SubRepoPermissions
+	Result0 *UserCredential
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -29287,45 +32937,45 @@ type SubRepoPermsStoreGetByUserFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SubRepoPermsStoreGetByUserFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c UserCredentialsStoreCreateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SubRepoPermsStoreGetByUserFuncCall) Results() []interface{} {
+func (c UserCredentialsStoreCreateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SubRepoPermsStoreTransactFunc describes the behavior when the Transact
-// method of the parent MockSubRepoPermsStore instance is invoked.
-type SubRepoPermsStoreTransactFunc struct {
-	defaultHook func(context.Context) (SubRepoPermsStore, error)
-	hooks       []func(context.Context) (SubRepoPermsStore, error)
-	history     []SubRepoPermsStoreTransactFuncCall
+// UserCredentialsStoreDeleteFunc describes the behavior when the Delete
+// method of the parent MockUserCredentialsStore instance is invoked.
+type UserCredentialsStoreDeleteFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []UserCredentialsStoreDeleteFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
+// Delete delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSubRepoPermsStore) Transact(v0 context.Context) (SubRepoPermsStore, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(SubRepoPermsStoreTransactFuncCall{v0, r0, r1})
-	return r0, r1
+func (m *MockUserCredentialsStore) Delete(v0 context.Context, v1 int64) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1)
+	m.DeleteFunc.appendCall(UserCredentialsStoreDeleteFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
-// the parent MockSubRepoPermsStore instance is invoked and the hook queue
-// is empty.
-func (f *SubRepoPermsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (SubRepoPermsStore, error)) {
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockUserCredentialsStore instance is invoked and the hook queue is
+// empty.
+func (f *UserCredentialsStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockSubRepoPermsStore instance invokes the
+// Delete method of the parent MockUserCredentialsStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *SubRepoPermsStoreTransactFunc) PushHook(hook func(context.Context) (SubRepoPermsStore, error)) {
+func (f *UserCredentialsStoreDeleteFunc) PushHook(hook func(context.Context, int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29333,21 +32983,21 @@ func (f *SubRepoPermsStoreTransactFunc) PushHook(hook func(context.Context) (Sub
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SubRepoPermsStoreTransactFunc) SetDefaultReturn(r0 SubRepoPermsStore, r1 error) {
-	f.SetDefaultHook(func(context.Context) (SubRepoPermsStore, error) {
-		return r0, r1
+func (f *UserCredentialsStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SubRepoPermsStoreTransactFunc) PushReturn(r0 SubRepoPermsStore, r1 error) {
-	f.PushHook(func(context.Context) (SubRepoPermsStore, error) {
-		return r0, r1
+func (f *UserCredentialsStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
 	})
 }
 
-func (f *SubRepoPermsStoreTransactFunc) nextHook() func(context.Context) (SubRepoPermsStore, error) {
+func (f *UserCredentialsStoreDeleteFunc) nextHook() func(context.Context, int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -29360,78 +33010,78 @@ func (f *SubRepoPermsStoreTransactFunc) nextHook() func(context.Context) (SubRep
 	return hook
 }
 
-func (f *SubRepoPermsStoreTransactFunc) appendCall(r0 SubRepoPermsStoreTransactFuncCall) {
+func (f *UserCredentialsStoreDeleteFunc) appendCall(r0 UserCredentialsStoreDeleteFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SubRepoPermsStoreTransactFuncCall objects
+// History returns a sequence of UserCredentialsStoreDeleteFuncCall objects
 // describing the invocations of this function.
-func (f *SubRepoPermsStoreTransactFunc) History() []SubRepoPermsStoreTransactFuncCall {
+func (f *UserCredentialsStoreDeleteFunc) History() []UserCredentialsStoreDeleteFuncCall {
 	f.mutex.Lock()
-	history := make([]SubRepoPermsStoreTransactFuncCall, len(f.history))
+	history := make([]UserCredentialsStoreDeleteFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SubRepoPermsStoreTransactFuncCall is an object that describes an
-// invocation of method Transact on an instance of MockSubRepoPermsStore.
-type SubRepoPermsStoreTransactFuncCall struct {
+// UserCredentialsStoreDeleteFuncCall is an object that describes an
+// invocation of method Delete on an instance of MockUserCredentialsStore.
+type UserCredentialsStoreDeleteFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Result0 is the value of the 1st result returned from this method
+	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Result0 SubRepoPermsStore
-	// Result1 is the value of the 2nd result returned from this method
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SubRepoPermsStoreTransactFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c UserCredentialsStoreDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SubRepoPermsStoreTransactFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c UserCredentialsStoreDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SubRepoPermsStoreUpsertFunc describes the behavior when the Upsert method
-// of the parent MockSubRepoPermsStore instance is invoked.
-type SubRepoPermsStoreUpsertFunc struct {
-	defaultHook func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error
-	hooks       []func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error
-	history     []SubRepoPermsStoreUpsertFuncCall
+// UserCredentialsStoreGetByIDFunc describes the behavior when the GetByID
+// method of the parent MockUserCredentialsStore instance is invoked.
+type UserCredentialsStoreGetByIDFunc struct {
+	defaultHook func(context.Context, int64) (*UserCredential, error)
+	hooks       []func(context.Context, int64) (*UserCredential, error)
+	history     []UserCredentialsStoreGetByIDFuncCall
 	mutex       sync.Mutex
 }
 
-// Upsert delegates to the next hook function in the queue and stores the
+// GetByID delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSubRepoPermsStore) Upsert(v0 context.Context, v1 int32, v2 api.RepoID, v3 authz.SubRepoPermissions) error {
-	r0 := m.UpsertFunc.nextHook()(v0, v1, v2, v3)
-	m.UpsertFunc.appendCall(SubRepoPermsStoreUpsertFuncCall{v0, v1, v2, v3, r0})
-	return r0
+func (m *MockUserCredentialsStore) GetByID(v0 context.Context, v1 int64) (*UserCredential, error) {
+	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
+	m.GetByIDFunc.appendCall(UserCredentialsStoreGetByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Upsert method of the
-// parent MockSubRepoPermsStore instance is invoked and the hook queue is
-// empty.
-func (f *SubRepoPermsStoreUpsertFunc) SetDefaultHook(hook func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error) {
+// SetDefaultHook sets function that is called when the GetByID method of
+// the parent MockUserCredentialsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserCredentialsStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int64) (*UserCredential, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Upsert method of the parent MockSubRepoPermsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SubRepoPermsStoreUpsertFunc) PushHook(hook func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error) {
+// GetByID method of the parent MockUserCredentialsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserCredentialsStoreGetByIDFunc) PushHook(hook func(context.Context, int64) (*UserCredential, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29439,21 +33089,21 @@ func (f *SubRepoPermsStoreUpsertFunc) PushHook(hook func(context.Context, int32,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SubRepoPermsStoreUpsertFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error {
-		return r0
+func (f *UserCredentialsStoreGetByIDFunc) SetDefaultReturn(r0 *UserCredential, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*UserCredential, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SubRepoPermsStoreUpsertFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error {
-		return r0
+func (f *UserCredentialsStoreGetByIDFunc) PushReturn(r0 *UserCredential, r1 error) {
+	f.PushHook(func(context.Context, int64) (*UserCredential, error) {
+		return r0, r1
 	})
 }
 
-func (f *SubRepoPermsStoreUpsertFunc) nextHook() func(context.Context, int32, api.RepoID, authz.SubRepoPermissions) error {
+func (f *UserCredentialsStoreGetByIDFunc) nextHook() func(context.Context, int64) (*UserCredential, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -29466,86 +33116,82 @@ func (f *SubRepoPermsStoreUpsertFunc) nextHook() func(context.Context, int32, ap
 	return hook
 }
 
-func (f *SubRepoPermsStoreUpsertFunc) appendCall(r0 SubRepoPermsStoreUpsertFuncCall) {
+func (f *UserCredentialsStoreGetByIDFunc) appendCall(r0 UserCredentialsStoreGetByIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SubRepoPermsStoreUpsertFuncCall objects
+// History returns a sequence of UserCredentialsStoreGetByIDFuncCall objects
 // describing the invocations of this function.
-func (f *SubRepoPermsStoreUpsertFunc) History() []SubRepoPermsStoreUpsertFuncCall {
+func (f *UserCredentialsStoreGetByIDFunc) History() []UserCredentialsStoreGetByIDFuncCall {
 	f.mutex.Lock()
-	history := make([]SubRepoPermsStoreUpsertFuncCall, len(f.history))
+	history := make([]UserCredentialsStoreGetByIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SubRepoPermsStoreUpsertFuncCall is an object that describes an invocation
-// of method Upsert on an instance of MockSubRepoPermsStore.
-type SubRepoPermsStoreUpsertFuncCall struct {
+// UserCredentialsStoreGetByIDFuncCall is an object that describes an
+// invocation of method GetByID on an instance of MockUserCredentialsStore.
+type UserCredentialsStoreGetByIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 api.RepoID
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 authz.SubRepoPermissions
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *UserCredential
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SubRepoPermsStoreUpsertFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c UserCredentialsStoreGetByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SubRepoPermsStoreUpsertFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c UserCredentialsStoreGetByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SubRepoPermsStoreUpsertWithSpecFunc describes the behavior when the
-// UpsertWithSpec method of the parent MockSubRepoPermsStore instance is
+// UserCredentialsStoreGetByScopeFunc describes the behavior when the
+// GetByScope method of the parent MockUserCredentialsStore instance is
 // invoked.
-type SubRepoPermsStoreUpsertWithSpecFunc struct {
-	defaultHook func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error
-	hooks       []func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error
-	history     []SubRepoPermsStoreUpsertWithSpecFuncCall
+type UserCredentialsStoreGetByScopeFunc struct {
+	defaultHook func(context.Context, UserCredentialScope) (*UserCredential, error)
+	hooks       []func(context.Context, UserCredentialScope) (*UserCredential, error)
+	history     []UserCredentialsStoreGetByScopeFuncCall
 	mutex       sync.Mutex
 }
 
-// UpsertWithSpec delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockSubRepoPermsStore) UpsertWithSpec(v0 context.Context, v1 int32, v2 api.ExternalRepoSpec, v3 authz.SubRepoPermissions) error {
-	r0 := m.UpsertWithSpecFunc.nextHook()(v0, v1, v2, v3)
-	m.UpsertWithSpecFunc.appendCall(SubRepoPermsStoreUpsertWithSpecFuncCall{v0, v1, v2, v3, r0})
-	return r0
+// GetByScope delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUserCredentialsStore) GetByScope(v0 context.Context, v1 UserCredentialScope) (*UserCredential, error) {
+	r0, r1 := m.GetByScopeFunc.nextHook()(v0, v1)
+	m.GetByScopeFunc.appendCall(UserCredentialsStoreGetByScopeFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the UpsertWithSpec
-// method of the parent MockSubRepoPermsStore instance is invoked and the
-// hook queue is empty.
-func (f *SubRepoPermsStoreUpsertWithSpecFunc) SetDefaultHook(hook func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error) {
+// SetDefaultHook sets function that is called when the GetByScope method of
+// the parent MockUserCredentialsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserCredentialsStoreGetByScopeFunc) SetDefaultHook(hook func(context.Context, UserCredentialScope) (*UserCredential, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpsertWithSpec method of the parent MockSubRepoPermsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *SubRepoPermsStoreUpsertWithSpecFunc) PushHook(hook func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error) {
+// GetByScope method of the parent MockUserCredentialsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserCredentialsStoreGetByScopeFunc) PushHook(hook func(context.Context, UserCredentialScope) (*UserCredential, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29553,21 +33199,21 @@ func (f *SubRepoPermsStoreUpsertWithSpecFunc) PushHook(hook func(context.Context
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SubRepoPermsStoreUpsertWithSpecFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error {
-		return r0
+func (f *UserCredentialsStoreGetByScopeFunc) SetDefaultReturn(r0 *UserCredential, r1 error) {
+	f.SetDefaultHook(func(context.Context, UserCredentialScope) (*UserCredential, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SubRepoPermsStoreUpsertWithSpecFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error {
-		return r0
+func (f *UserCredentialsStoreGetByScopeFunc) PushReturn(r0 *UserCredential, r1 error) {
+	f.PushHook(func(context.Context, UserCredentialScope) (*UserCredential, error) {
+		return r0, r1
 	})
 }
 
-func (f *SubRepoPermsStoreUpsertWithSpecFunc) nextHook() func(context.Context, int32, api.ExternalRepoSpec, authz.SubRepoPermissions) error {
+func (f *UserCredentialsStoreGetByScopeFunc) nextHook() func(context.Context, UserCredentialScope) (*UserCredential, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -29580,85 +33226,82 @@ func (f *SubRepoPermsStoreUpsertWithSpecFunc) nextHook() func(context.Context, i
 	return hook
 }
 
-func (f *SubRepoPermsStoreUpsertWithSpecFunc) appendCall(r0 SubRepoPermsStoreUpsertWithSpecFuncCall) {
+func (f *UserCredentialsStoreGetByScopeFunc) appendCall(r0 UserCredentialsStoreGetByScopeFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SubRepoPermsStoreUpsertWithSpecFuncCall
+// History returns a sequence of UserCredentialsStoreGetByScopeFuncCall
 // objects describing the invocations of this function.
-func (f *SubRepoPermsStoreUpsertWithSpecFunc) History() []SubRepoPermsStoreUpsertWithSpecFuncCall {
+func (f *UserCredentialsStoreGetByScopeFunc) History() []UserCredentialsStoreGetByScopeFuncCall {
 	f.mutex.Lock()
-	history := make([]SubRepoPermsStoreUpsertWithSpecFuncCall, len(f.history))
+	history := make([]UserCredentialsStoreGetByScopeFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SubRepoPermsStoreUpsertWithSpecFuncCall is an object that describes an
-// invocation of method UpsertWithSpec on an instance of
-// MockSubRepoPermsStore.
-type SubRepoPermsStoreUpsertWithSpecFuncCall struct {
+// UserCredentialsStoreGetByScopeFuncCall is an object that describes an
+// invocation of method GetByScope on an instance of
+// MockUserCredentialsStore.
+type UserCredentialsStoreGetByScopeFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 api.ExternalRepoSpec
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 authz.SubRepoPermissions
+	Arg1 UserCredentialScope
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *UserCredential
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SubRepoPermsStoreUpsertWithSpecFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c UserCredentialsStoreGetByScopeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SubRepoPermsStoreUpsertWithSpecFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c UserCredentialsStoreGetByScopeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SubRepoPermsStoreWithFunc describes the behavior when the With method of
-// the parent MockSubRepoPermsStore instance is invoked.
-type SubRepoPermsStoreWithFunc struct {
-	defaultHook func(basestore.ShareableStore) SubRepoPermsStore
-	hooks       []func(basestore.ShareableStore) SubRepoPermsStore
-	history     []SubRepoPermsStoreWithFuncCall
+// UserCredentialsStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockUserCredentialsStore instance is invoked.
+type UserCredentialsStoreHandleFunc struct {
+	defaultHook func() *basestore.TransactableHandle
+	hooks       []func() *basestore.TransactableHandle
+	history     []UserCredentialsStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
-// With delegates to the next hook function in the queue and stores the
+// Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSubRepoPermsStore) With(v0 basestore.ShareableStore) SubRepoPermsStore {
-	r0 := m.WithFunc.nextHook()(v0)
-	m.WithFunc.appendCall(SubRepoPermsStoreWithFuncCall{v0, r0})
+func (m *MockUserCredentialsStore) Handle() *basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(UserCredentialsStoreHandleFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the With method of the
-// parent MockSubRepoPermsStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockUserCredentialsStore instance is invoked and the hook queue is
 // empty.
-func (f *SubRepoPermsStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) SubRepoPermsStore) {
+func (f *UserCredentialsStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// With method of the parent MockSubRepoPermsStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *SubRepoPermsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) SubRepoPermsStore) {
+// Handle method of the parent MockUserCredentialsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserCredentialsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29666,21 +33309,21 @@ func (f *SubRepoPermsStoreWithFunc) PushHook(hook func(basestore.ShareableStore)
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *SubRepoPermsStoreWithFunc) SetDefaultReturn(r0 SubRepoPermsStore) {
-	f.SetDefaultHook(func(basestore.ShareableStore) SubRepoPermsStore {
+func (f *UserCredentialsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+	f.SetDefaultHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *SubRepoPermsStoreWithFunc) PushReturn(r0 SubRepoPermsStore) {
-	f.PushHook(func(basestore.ShareableStore) SubRepoPermsStore {
+func (f *UserCredentialsStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+	f.PushHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
-func (f *SubRepoPermsStoreWithFunc) nextHook() func(basestore.ShareableStore) SubRepoPermsStore {
+func (f *UserCredentialsStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -29693,173 +33336,72 @@ func (f *SubRepoPermsStoreWithFunc) nextHook() func(basestore.ShareableStore) Su
 	return hook
 }
 
-func (f *SubRepoPermsStoreWithFunc) appendCall(r0 SubRepoPermsStoreWithFuncCall) {
+func (f *UserCredentialsStoreHandleFunc) appendCall(r0 UserCredentialsStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SubRepoPermsStoreWithFuncCall objects
+// History returns a sequence of UserCredentialsStoreHandleFuncCall objects
 // describing the invocations of this function.
-func (f *SubRepoPermsStoreWithFunc) History() []SubRepoPermsStoreWithFuncCall {
+func (f *UserCredentialsStoreHandleFunc) History() []UserCredentialsStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]SubRepoPermsStoreWithFuncCall, len(f.history))
+	history := make([]UserCredentialsStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SubRepoPermsStoreWithFuncCall is an object that describes an invocation
-// of method With on an instance of MockSubRepoPermsStore.
-type SubRepoPermsStoreWithFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 basestore.ShareableStore
+// UserCredentialsStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockUserCredentialsStore.
+type UserCredentialsStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 SubRepoPermsStore
+	Result0 *basestore.TransactableHandle
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SubRepoPermsStoreWithFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c UserCredentialsStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SubRepoPermsStoreWithFuncCall) Results() []interface{} {
+func (c UserCredentialsStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// MockTemporarySettingsStore is a mock implementation of the
-// TemporarySettingsStore interface (from the package
-// github.com/sourcegraph/sourcegraph/internal/database) used for unit
-// testing.
-type MockTemporarySettingsStore struct {
-	// EditTemporarySettingsFunc is an instance of a mock function object
-	// controlling the behavior of the method EditTemporarySettings.
-	EditTemporarySettingsFunc *TemporarySettingsStoreEditTemporarySettingsFunc
-	// GetTemporarySettingsFunc is an instance of a mock function object
-	// controlling the behavior of the method GetTemporarySettings.
-	GetTemporarySettingsFunc *TemporarySettingsStoreGetTemporarySettingsFunc
-	// HandleFunc is an instance of a mock function object controlling the
-	// behavior of the method Handle.
-	HandleFunc *TemporarySettingsStoreHandleFunc
-	// OverwriteTemporarySettingsFunc is an instance of a mock function
-	// object controlling the behavior of the method
-	// OverwriteTemporarySettings.
-	OverwriteTemporarySettingsFunc *TemporarySettingsStoreOverwriteTemporarySettingsFunc
-}
-
-// NewMockTemporarySettingsStore creates a new mock of the
-// TemporarySettingsStore interface. All methods return zero values for all
-// results, unless overwritten.
-func NewMockTemporarySettingsStore() *MockTemporarySettingsStore {
-	return &MockTemporarySettingsStore{
-		EditTemporarySettingsFunc: &TemporarySettingsStoreEditTemporarySettingsFunc{
-			defaultHook: func(context.Context, int32, string) error {
-				return nil
-			},
-		},
-		GetTemporarySettingsFunc: &TemporarySettingsStoreGetTemporarySettingsFunc{
-			defaultHook: func(context.Context, int32) (*temporarysettings.TemporarySettings, error) {
-				return nil, nil
-			},
-		},
-		HandleFunc: &TemporarySettingsStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				return nil
-			},
-		},
-		OverwriteTemporarySettingsFunc: &TemporarySettingsStoreOverwriteTemporarySettingsFunc{
-			defaultHook: func(context.Context, int32, string) error {
-				return nil
-			},
-		},
-	}
-}
-
-// NewStrictMockTemporarySettingsStore creates a new mock of the
-// TemporarySettingsStore interface. All methods panic on invocation, unless
-// overwritten.
-func NewStrictMockTemporarySettingsStore() *MockTemporarySettingsStore {
-	return &MockTemporarySettingsStore{
-		EditTemporarySettingsFunc: &TemporarySettingsStoreEditTemporarySettingsFunc{
-			defaultHook: func(context.Context, int32, string) error {
-				panic("unexpected invocation of MockTemporarySettingsStore.EditTemporarySettings")
-			},
-		},
-		GetTemporarySettingsFunc: &TemporarySettingsStoreGetTemporarySettingsFunc{
-			defaultHook: func(context.Context, int32) (*temporarysettings.TemporarySettings, error) {
-				panic("unexpected invocation of MockTemporarySettingsStore.GetTemporarySettings")
-			},
-		},
-		HandleFunc: &TemporarySettingsStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				panic("unexpected invocation of MockTemporarySettingsStore.Handle")
-			},
-		},
-		OverwriteTemporarySettingsFunc: &TemporarySettingsStoreOverwriteTemporarySettingsFunc{
-			defaultHook: func(context.Context, int32, string) error {
-				panic("unexpected invocation of MockTemporarySettingsStore.OverwriteTemporarySettings")
-			},
-		},
-	}
-}
-
-// NewMockTemporarySettingsStoreFrom creates a new mock of the
-// MockTemporarySettingsStore interface. All methods delegate to the given
-// implementation, unless overwritten.
-func NewMockTemporarySettingsStoreFrom(i TemporarySettingsStore) *MockTemporarySettingsStore {
-	return &MockTemporarySettingsStore{
-		EditTemporarySettingsFunc: &TemporarySettingsStoreEditTemporarySettingsFunc{
-			defaultHook: i.EditTemporarySettings,
-		},
-		GetTemporarySettingsFunc: &TemporarySettingsStoreGetTemporarySettingsFunc{
-			defaultHook: i.GetTemporarySettings,
-		},
-		HandleFunc: &TemporarySettingsStoreHandleFunc{
-			defaultHook: i.Handle,
-		},
-		OverwriteTemporarySettingsFunc: &TemporarySettingsStoreOverwriteTemporarySettingsFunc{
-			defaultHook: i.OverwriteTemporarySettings,
-		},
-	}
-}
-
-// TemporarySettingsStoreEditTemporarySettingsFunc describes the behavior
-// when the EditTemporarySettings method of the parent
-// MockTemporarySettingsStore instance is invoked.
-type TemporarySettingsStoreEditTemporarySettingsFunc struct {
-	defaultHook func(context.Context, int32, string) error
-	hooks       []func(context.Context, int32, string) error
-	history     []TemporarySettingsStoreEditTemporarySettingsFuncCall
+// UserCredentialsStoreListFunc describes the behavior when the List method
+// of the parent MockUserCredentialsStore instance is invoked.
+type UserCredentialsStoreListFunc struct {
+	defaultHook func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error)
+	hooks       []func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error)
+	history     []UserCredentialsStoreListFuncCall
 	mutex       sync.Mutex
 }
 
-// EditTemporarySettings delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockTemporarySettingsStore) EditTemporarySettings(v0 context.Context, v1 int32, v2 string) error {
-	r0 := m.EditTemporarySettingsFunc.nextHook()(v0, v1, v2)
-	m.EditTemporarySettingsFunc.appendCall(TemporarySettingsStoreEditTemporarySettingsFuncCall{v0, v1, v2, r0})
-	return r0
+// List delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserCredentialsStore) List(v0 context.Context, v1 UserCredentialsListOpts) ([]*UserCredential, int, error) {
+	r0, r1, r2 := m.ListFunc.nextHook()(v0, v1)
+	m.ListFunc.appendCall(UserCredentialsStoreListFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the
-// EditTemporarySettings method of the parent MockTemporarySettingsStore
-// instance is invoked and the hook queue is empty.
-func (f *TemporarySettingsStoreEditTemporarySettingsFunc) SetDefaultHook(hook func(context.Context, int32, string) error) {
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockUserCredentialsStore instance is invoked and the hook queue is
+// empty.
+func (f *UserCredentialsStoreListFunc) SetDefaultHook(hook func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// EditTemporarySettings method of the parent MockTemporarySettingsStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *TemporarySettingsStoreEditTemporarySettingsFunc) PushHook(hook func(context.Context, int32, string) error) {
+// List method of the parent MockUserCredentialsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserCredentialsStoreListFunc) PushHook(hook func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29867,21 +33409,21 @@ func (f *TemporarySettingsStoreEditTemporarySettingsFunc) PushHook(hook func(con
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *TemporarySettingsStoreEditTemporarySettingsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, string) error {
-		return r0
+func (f *UserCredentialsStoreListFunc) SetDefaultReturn(r0 []*UserCredential, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *TemporarySettingsStoreEditTemporarySettingsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, string) error {
-		return r0
+func (f *UserCredentialsStoreListFunc) PushReturn(r0 []*UserCredential, r1 int, r2 error) {
+	f.PushHook(func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *TemporarySettingsStoreEditTemporarySettingsFunc) nextHook() func(context.Context, int32, string) error {
+func (f *UserCredentialsStoreListFunc) nextHook() func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -29894,85 +33436,84 @@ func (f *TemporarySettingsStoreEditTemporarySettingsFunc) nextHook() func(contex
 	return hook
 }
 
-func (f *TemporarySettingsStoreEditTemporarySettingsFunc) appendCall(r0 TemporarySettingsStoreEditTemporarySettingsFuncCall) {
+func (f *UserCredentialsStoreListFunc) appendCall(r0 UserCredentialsStoreListFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// TemporarySettingsStoreEditTemporarySettingsFuncCall objects describing
-// the invocations of this function.
-func (f *TemporarySettingsStoreEditTemporarySettingsFunc) History() []TemporarySettingsStoreEditTemporarySettingsFuncCall {
+// History returns a sequence of UserCredentialsStoreListFuncCall objects
+// describing the invocations of this function.
+func (f *UserCredentialsStoreListFunc) History() []UserCredentialsStoreListFuncCall {
 	f.mutex.Lock()
-	history := make([]TemporarySettingsStoreEditTemporarySettingsFuncCall, len(f.history))
+	history := make([]UserCredentialsStoreListFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// TemporarySettingsStoreEditTemporarySettingsFuncCall is an object that
-// describes an invocation of method EditTemporarySettings on an instance of
-// MockTemporarySettingsStore.
-type TemporarySettingsStoreEditTemporarySettingsFuncCall struct {
+// UserCredentialsStoreListFuncCall is an object that describes an
+// invocation of method List on an instance of MockUserCredentialsStore.
+type UserCredentialsStoreListFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
+	Arg1 UserCredentialsListOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 []*UserCredential
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c TemporarySettingsStoreEditTemporarySettingsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c UserCredentialsStoreListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c TemporarySettingsStoreEditTemporarySettingsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c UserCredentialsStoreListFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// TemporarySettingsStoreGetTemporarySettingsFunc describes the behavior
-// when the GetTemporarySettings method of the parent
-// MockTemporarySettingsStore instance is invoked.
-type TemporarySettingsStoreGetTemporarySettingsFunc struct {
-	defaultHook func(context.Context, int32) (*temporarysettings.TemporarySettings, error)
-	hooks       []func(context.Context, int32) (*temporarysettings.TemporarySettings, error)
-	history     []TemporarySettingsStoreGetTemporarySettingsFuncCall
+// UserCredentialsStoreTransactFunc describes the behavior when the Transact
+// method of the parent MockUserCredentialsStore instance is invoked.
+type UserCredentialsStoreTransactFunc struct {
+	defaultHook func(context.Context) (UserCredentialsStore, error)
+	hooks       []func(context.Context) (UserCredentialsStore, error)
+	history     []UserCredentialsStoreTransactFuncCall
 	mutex       sync.Mutex
 }
 
-// GetTemporarySettings delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockTemporarySettingsStore) GetTemporarySettings(v0 context.Context, v1 int32) (*temporarysettings.TemporarySettings, error) {
-	r0, r1 := m.GetTemporarySettingsFunc.nextHook()(v0, v1)
-	m.GetTemporarySettingsFunc.appendCall(TemporarySettingsStoreGetTemporarySettingsFuncCall{v0, v1, r0, r1})
+// Transact delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserCredentialsStore) Transact(v0 context.Context) (UserCredentialsStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(UserCredentialsStoreTransactFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetTemporarySettings
-// method of the parent MockTemporarySettingsStore instance is invoked and
-// the hook queue is empty.
-func (f *TemporarySettingsStoreGetTemporarySettingsFunc) SetDefaultHook(hook func(context.Context, int32) (*temporarysettings.TemporarySettings, error)) {
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockUserCredentialsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserCredentialsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (UserCredentialsStore, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetTemporarySettings method of the parent MockTemporarySettingsStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *TemporarySettingsStoreGetTemporarySettingsFunc) PushHook(hook func(context.Context, int32) (*temporarysettings.TemporarySettings, error)) {
+// Transact method of the parent MockUserCredentialsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserCredentialsStoreTransactFunc) PushHook(hook func(context.Context) (UserCredentialsStore, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29980,21 +33521,21 @@ func (f *TemporarySettingsStoreGetTemporarySettingsFunc) PushHook(hook func(cont
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *TemporarySettingsStoreGetTemporarySettingsFunc) SetDefaultReturn(r0 *temporarysettings.TemporarySettings, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) (*temporarysettings.TemporarySettings, error) {
+func (f *UserCredentialsStoreTransactFunc) SetDefaultReturn(r0 UserCredentialsStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (UserCredentialsStore, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *TemporarySettingsStoreGetTemporarySettingsFunc) PushReturn(r0 *temporarysettings.TemporarySettings, r1 error) {
-	f.PushHook(func(context.Context, int32) (*temporarysettings.TemporarySettings, error) {
+func (f *UserCredentialsStoreTransactFunc) PushReturn(r0 UserCredentialsStore, r1 error) {
+	f.PushHook(func(context.Context) (UserCredentialsStore, error) {
 		return r0, r1
 	})
 }
 
-func (f *TemporarySettingsStoreGetTemporarySettingsFunc) nextHook() func(context.Context, int32) (*temporarysettings.TemporarySettings, error) {
+func (f *UserCredentialsStoreTransactFunc) nextHook() func(context.Context) (UserCredentialsStore, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30007,37 +33548,32 @@ func (f *TemporarySettingsStoreGetTemporarySettingsFunc) nextHook() func(context
 	return hook
 }
 
-func (f *TemporarySettingsStoreGetTemporarySettingsFunc) appendCall(r0 TemporarySettingsStoreGetTemporarySettingsFuncCall) {
+func (f *UserCredentialsStoreTransactFunc) appendCall(r0 UserCredentialsStoreTransactFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// TemporarySettingsStoreGetTemporarySettingsFuncCall objects describing the
-// invocations of this function.
-func (f *TemporarySettingsStoreGetTemporarySettingsFunc) History() []TemporarySettingsStoreGetTemporarySettingsFuncCall {
+// History returns a sequence of UserCredentialsStoreTransactFuncCall
+// objects describing the invocations of this function.
+func (f *UserCredentialsStoreTransactFunc) History() []UserCredentialsStoreTransactFuncCall {
 	f.mutex.Lock()
-	history := make([]TemporarySettingsStoreGetTemporarySettingsFuncCall, len(f.history))
+	history := make([]UserCredentialsStoreTransactFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// TemporarySettingsStoreGetTemporarySettingsFuncCall is an object that
-// describes an invocation of method GetTemporarySettings on an instance of
-// MockTemporarySettingsStore.
-type TemporarySettingsStoreGetTemporarySettingsFuncCall struct {
+// UserCredentialsStoreTransactFuncCall is an object that describes an
+// invocation of method Transact on an instance of MockUserCredentialsStore.
+type UserCredentialsStoreTransactFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *temporarysettings.TemporarySettings
+	Result0 UserCredentialsStore
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -30045,45 +33581,45 @@ type TemporarySettingsStoreGetTemporarySettingsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c TemporarySettingsStoreGetTemporarySettingsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c UserCredentialsStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c TemporarySettingsStoreGetTemporarySettingsFuncCall) Results() []interface{} {
+func (c UserCredentialsStoreTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// TemporarySettingsStoreHandleFunc describes the behavior when the Handle
-// method of the parent MockTemporarySettingsStore instance is invoked.
-type TemporarySettingsStoreHandleFunc struct {
-	defaultHook func() *basestore.TransactableHandle
-	hooks       []func() *basestore.TransactableHandle
-	history     []TemporarySettingsStoreHandleFuncCall
+// UserCredentialsStoreUpdateFunc describes the behavior when the Update
+// method of the parent MockUserCredentialsStore instance is invoked.
+type UserCredentialsStoreUpdateFunc struct {
+	defaultHook func(context.Context, *UserCredential) error
+	hooks       []func(context.Context, *UserCredential) error
+	history     []UserCredentialsStoreUpdateFuncCall
 	mutex       sync.Mutex
 }
 
-// Handle delegates to the next hook function in the queue and stores the
+// Update delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockTemporarySettingsStore) Handle() *basestore.TransactableHandle {
-	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(TemporarySettingsStoreHandleFuncCall{r0})
+func (m *MockUserCredentialsStore) Update(v0 context.Context, v1 *UserCredential) error {
+	r0 := m.UpdateFunc.nextHook()(v0, v1)
+	m.UpdateFunc.appendCall(UserCredentialsStoreUpdateFuncCall{v0, v1, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Handle method of the
-// parent MockTemporarySettingsStore instance is invoked and the hook queue
-// is empty.
-func (f *TemporarySettingsStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
+// SetDefaultHook sets function that is called when the Update method of the
+// parent MockUserCredentialsStore instance is invoked and the hook queue is
+// empty.
+func (f *UserCredentialsStoreUpdateFunc) SetDefaultHook(hook func(context.Context, *UserCredential) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockTemporarySettingsStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *TemporarySettingsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
+// Update method of the parent MockUserCredentialsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserCredentialsStoreUpdateFunc) PushHook(hook func(context.Context, *UserCredential) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30091,21 +33627,21 @@ func (f *TemporarySettingsStoreHandleFunc) PushHook(hook func() *basestore.Trans
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *TemporarySettingsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
-	f.SetDefaultHook(func() *basestore.TransactableHandle {
+func (f *UserCredentialsStoreUpdateFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *UserCredential) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *TemporarySettingsStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
-	f.PushHook(func() *basestore.TransactableHandle {
+func (f *UserCredentialsStoreUpdateFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *UserCredential) error {
 		return r0
 	})
 }
 
-func (f *TemporarySettingsStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
+func (f *UserCredentialsStoreUpdateFunc) nextHook() func(context.Context, *UserCredential) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30118,75 +33654,78 @@ func (f *TemporarySettingsStoreHandleFunc) nextHook() func() *basestore.Transact
 	return hook
 }
 
-func (f *TemporarySettingsStoreHandleFunc) appendCall(r0 TemporarySettingsStoreHandleFuncCall) {
+func (f *UserCredentialsStoreUpdateFunc) appendCall(r0 UserCredentialsStoreUpdateFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of TemporarySettingsStoreHandleFuncCall
-// objects describing the invocations of this function.
-func (f *TemporarySettingsStoreHandleFunc) History() []TemporarySettingsStoreHandleFuncCall {
+// History returns a sequence of UserCredentialsStoreUpdateFuncCall objects
+// describing the invocations of this function.
+func (f *UserCredentialsStoreUpdateFunc) History() []UserCredentialsStoreUpdateFuncCall {
 	f.mutex.Lock()
-	history := make([]TemporarySettingsStoreHandleFuncCall, len(f.history))
+	history := make([]UserCredentialsStoreUpdateFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// TemporarySettingsStoreHandleFuncCall is an object that describes an
-// invocation of method Handle on an instance of MockTemporarySettingsStore.
-type TemporarySettingsStoreHandleFuncCall struct {
+// UserCredentialsStoreUpdateFuncCall is an object that describes an
+// invocation of method Update on an instance of MockUserCredentialsStore.
+type UserCredentialsStoreUpdateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *UserCredential
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *basestore.TransactableHandle
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c TemporarySettingsStoreHandleFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c UserCredentialsStoreUpdateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c TemporarySettingsStoreHandleFuncCall) Results() []interface{} {
+func (c UserCredentialsStoreUpdateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// TemporarySettingsStoreOverwriteTemporarySettingsFunc describes the
-// behavior when the OverwriteTemporarySettings method of the parent
-// MockTemporarySettingsStore instance is invoked.
-type TemporarySettingsStoreOverwriteTemporarySettingsFunc struct {
-	defaultHook func(context.Context, int32, string) error
-	hooks       []func(context.Context, int32, string) error
-	history     []TemporarySettingsStoreOverwriteTemporarySettingsFuncCall
+// UserCredentialsStoreWithFunc describes the behavior when the With method
+// of the parent MockUserCredentialsStore instance is invoked.
+type UserCredentialsStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) UserCredentialsStore
+	hooks       []func(basestore.ShareableStore) UserCredentialsStore
+	history     []UserCredentialsStoreWithFuncCall
 	mutex       sync.Mutex
 }
 
-// OverwriteTemporarySettings delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockTemporarySettingsStore) OverwriteTemporarySettings(v0 context.Context, v1 int32, v2 string) error {
-	r0 := m.OverwriteTemporarySettingsFunc.nextHook()(v0, v1, v2)
-	m.OverwriteTemporarySettingsFunc.appendCall(TemporarySettingsStoreOverwriteTemporarySettingsFuncCall{v0, v1, v2, r0})
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserCredentialsStore) With(v0 basestore.ShareableStore) UserCredentialsStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(UserCredentialsStoreWithFuncCall{v0, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// OverwriteTemporarySettings method of the parent
-// MockTemporarySettingsStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockUserCredentialsStore instance is invoked and the hook queue is
 // empty.
-func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) SetDefaultHook(hook func(context.Context, int32, string) error) {
+func (f *UserCredentialsStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) UserCredentialsStore) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// OverwriteTemporarySettings method of the parent
-// MockTemporarySettingsStore instance invokes the hook at the front of the
-// queue and discards it. After the queue is empty, the default hook
-// function is invoked for any future action.
-func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) PushHook(hook func(context.Context, int32, string) error) {
+// With method of the parent MockUserCredentialsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserCredentialsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) UserCredentialsStore) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30194,21 +33733,21 @@ func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) PushHook(hook fun
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, string) error {
+func (f *UserCredentialsStoreWithFunc) SetDefaultReturn(r0 UserCredentialsStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) UserCredentialsStore {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, string) error {
+func (f *UserCredentialsStoreWithFunc) PushReturn(r0 UserCredentialsStore) {
+	f.PushHook(func(basestore.ShareableStore) UserCredentialsStore {
 		return r0
 	})
 }
 
-func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) nextHook() func(context.Context, int32, string) error {
+func (f *UserCredentialsStoreWithFunc) nextHook() func(basestore.ShareableStore) UserCredentialsStore {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30221,258 +33760,362 @@ func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) nextHook() func(c
 	return hook
 }
 
-func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) appendCall(r0 TemporarySettingsStoreOverwriteTemporarySettingsFuncCall) {
+func (f *UserCredentialsStoreWithFunc) appendCall(r0 UserCredentialsStoreWithFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// TemporarySettingsStoreOverwriteTemporarySettingsFuncCall objects
+// History returns a sequence of UserCredentialsStoreWithFuncCall objects
 // describing the invocations of this function.
-func (f *TemporarySettingsStoreOverwriteTemporarySettingsFunc) History() []TemporarySettingsStoreOverwriteTemporarySettingsFuncCall {
+func (f *UserCredentialsStoreWithFunc) History() []UserCredentialsStoreWithFuncCall {
 	f.mutex.Lock()
-	history := make([]TemporarySettingsStoreOverwriteTemporarySettingsFuncCall, len(f.history))
+	history := make([]UserCredentialsStoreWithFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// TemporarySettingsStoreOverwriteTemporarySettingsFuncCall is an object
-// that describes an invocation of method OverwriteTemporarySettings on an
-// instance of MockTemporarySettingsStore.
-type TemporarySettingsStoreOverwriteTemporarySettingsFuncCall struct {
+// UserCredentialsStoreWithFuncCall is an object that describes an
+// invocation of method With on an instance of MockUserCredentialsStore.
+type UserCredentialsStoreWithFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int32
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
+	Arg0 basestore.ShareableStore
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 UserCredentialsStore
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c TemporarySettingsStoreOverwriteTemporarySettingsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c UserCredentialsStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c TemporarySettingsStoreOverwriteTemporarySettingsFuncCall) Results() []interface{} {
+func (c UserCredentialsStoreWithFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// MockUserCredentialsStore is a mock implementation of the
-// UserCredentialsStore interface (from the package
+// MockUserEmailsStore is a mock implementation of the UserEmailsStore
+// interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/database) used for unit
 // testing.
-type MockUserCredentialsStore struct {
-	// CreateFunc is an instance of a mock function object controlling the
-	// behavior of the method Create.
-	CreateFunc *UserCredentialsStoreCreateFunc
-	// DeleteFunc is an instance of a mock function object controlling the
-	// behavior of the method Delete.
-	DeleteFunc *UserCredentialsStoreDeleteFunc
-	// GetByIDFunc is an instance of a mock function object controlling the
-	// behavior of the method GetByID.
-	GetByIDFunc *UserCredentialsStoreGetByIDFunc
-	// GetByScopeFunc is an instance of a mock function object controlling
-	// the behavior of the method GetByScope.
-	GetByScopeFunc *UserCredentialsStoreGetByScopeFunc
+type MockUserEmailsStore struct {
+	// AddFunc is an instance of a mock function object controlling the
+	// behavior of the method Add.
+	AddFunc *UserEmailsStoreAddFunc
+	// DoneFunc is an instance of a mock function object controlling the
+	// behavior of the method Done.
+	DoneFunc *UserEmailsStoreDoneFunc
+	// GetFunc is an instance of a mock function object controlling the
+	// behavior of the method Get.
+	GetFunc *UserEmailsStoreGetFunc
+	// GetInitialSiteAdminInfoFunc is an instance of a mock function object
+	// controlling the behavior of the method GetInitialSiteAdminInfo.
+	GetInitialSiteAdminInfoFunc *UserEmailsStoreGetInitialSiteAdminInfoFunc
+	// GetLatestVerificationSentEmailFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetLatestVerificationSentEmail.
+	GetLatestVerificationSentEmailFunc *UserEmailsStoreGetLatestVerificationSentEmailFunc
+	// GetPrimaryEmailFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPrimaryEmail.
+	GetPrimaryEmailFunc *UserEmailsStoreGetPrimaryEmailFunc
+	// GetVerifiedEmailsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetVerifiedEmails.
+	GetVerifiedEmailsFunc *UserEmailsStoreGetVerifiedEmailsFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
-	HandleFunc *UserCredentialsStoreHandleFunc
-	// ListFunc is an instance of a mock function object controlling the
-	// behavior of the method List.
-	ListFunc *UserCredentialsStoreListFunc
+	HandleFunc *UserEmailsStoreHandleFunc
+	// ListByUserFunc is an instance of a mock function object controlling
+	// the behavior of the method ListByUser.
+	ListByUserFunc *UserEmailsStoreListByUserFunc
+	// RemoveFunc is an instance of a mock function object controlling the
+	// behavior of the method Remove.
+	RemoveFunc *UserEmailsStoreRemoveFunc
+	// SetLastVerificationFunc is an instance of a mock function object
+	// controlling the behavior of the method SetLastVerification.
+	SetLastVerificationFunc *UserEmailsStoreSetLastVerificationFunc
+	// SetPrimaryEmailFunc is an instance of a mock function object
+	// controlling the behavior of the method SetPrimaryEmail.
+	SetPrimaryEmailFunc *UserEmailsStoreSetPrimaryEmailFunc
+	// SetVerifiedFunc is an instance of a mock function object controlling
+	// the behavior of the method SetVerified.
+	SetVerifiedFunc *UserEmailsStoreSetVerifiedFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
-	TransactFunc *UserCredentialsStoreTransactFunc
-	// UpdateFunc is an instance of a mock function object controlling the
-	// behavior of the method Update.
-	UpdateFunc *UserCredentialsStoreUpdateFunc
+	TransactFunc *UserEmailsStoreTransactFunc
+	// VerifyFunc is an instance of a mock function object controlling the
+	// behavior of the method Verify.
+	VerifyFunc *UserEmailsStoreVerifyFunc
 	// WithFunc is an instance of a mock function object controlling the
 	// behavior of the method With.
-	WithFunc *UserCredentialsStoreWithFunc
+	WithFunc *UserEmailsStoreWithFunc
 }
 
-// NewMockUserCredentialsStore creates a new mock of the
-// UserCredentialsStore interface. All methods return zero values for all
-// results, unless overwritten.
-func NewMockUserCredentialsStore() *MockUserCredentialsStore {
-	return &MockUserCredentialsStore{
-		CreateFunc: &UserCredentialsStoreCreateFunc{
-			defaultHook: func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error) {
-				return nil, nil
+// NewMockUserEmailsStore creates a new mock of the UserEmailsStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockUserEmailsStore() *MockUserEmailsStore {
+	return &MockUserEmailsStore{
+		AddFunc: &UserEmailsStoreAddFunc{
+			defaultHook: func(context.Context, int32, string, *string) error {
+				return nil
 			},
 		},
-		DeleteFunc: &UserCredentialsStoreDeleteFunc{
-			defaultHook: func(context.Context, int64) error {
+		DoneFunc: &UserEmailsStoreDoneFunc{
+			defaultHook: func(error) error {
 				return nil
 			},
 		},
-		GetByIDFunc: &UserCredentialsStoreGetByIDFunc{
-			defaultHook: func(context.Context, int64) (*UserCredential, error) {
+		GetFunc: &UserEmailsStoreGetFunc{
+			defaultHook: func(context.Context, int32, string) (string, bool, error) {
+				return "", false, nil
+			},
+		},
+		GetInitialSiteAdminInfoFunc: &UserEmailsStoreGetInitialSiteAdminInfoFunc{
+			defaultHook: func(context.Context) (string, bool, error) {
+				return "", false, nil
+			},
+		},
+		GetLatestVerificationSentEmailFunc: &UserEmailsStoreGetLatestVerificationSentEmailFunc{
+			defaultHook: func(context.Context, string) (*UserEmail, error) {
 				return nil, nil
 			},
 		},
-		GetByScopeFunc: &UserCredentialsStoreGetByScopeFunc{
-			defaultHook: func(context.Context, UserCredentialScope) (*UserCredential, error) {
+		GetPrimaryEmailFunc: &UserEmailsStoreGetPrimaryEmailFunc{
+			defaultHook: func(context.Context, int32) (string, bool, error) {
+				return "", false, nil
+			},
+		},
+		GetVerifiedEmailsFunc: &UserEmailsStoreGetVerifiedEmailsFunc{
+			defaultHook: func(context.Context, ...string) ([]*UserEmail, error) {
 				return nil, nil
 			},
 		},
-		HandleFunc: &UserCredentialsStoreHandleFunc{
+		HandleFunc: &UserEmailsStoreHandleFunc{
 			defaultHook: func() *basestore.TransactableHandle {
 				return nil
 			},
 		},
-		ListFunc: &UserCredentialsStoreListFunc{
-			defaultHook: func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error) {
-				return nil, 0, nil
+		ListByUserFunc: &UserEmailsStoreListByUserFunc{
+			defaultHook: func(context.Context, UserEmailsListOptions) ([]*UserEmail, error) {
+				return nil, nil
 			},
 		},
-		TransactFunc: &UserCredentialsStoreTransactFunc{
-			defaultHook: func(context.Context) (UserCredentialsStore, error) {
-				return nil, nil
+		RemoveFunc: &UserEmailsStoreRemoveFunc{
+			defaultHook: func(context.Context, int32, string) error {
+				return nil
 			},
 		},
-		UpdateFunc: &UserCredentialsStoreUpdateFunc{
-			defaultHook: func(context.Context, *UserCredential) error {
+		SetLastVerificationFunc: &UserEmailsStoreSetLastVerificationFunc{
+			defaultHook: func(context.Context, int32, string, string) error {
 				return nil
 			},
 		},
-		WithFunc: &UserCredentialsStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) UserCredentialsStore {
+		SetPrimaryEmailFunc: &UserEmailsStoreSetPrimaryEmailFunc{
+			defaultHook: func(context.Context, int32, string) error {
+				return nil
+			},
+		},
+		SetVerifiedFunc: &UserEmailsStoreSetVerifiedFunc{
+			defaultHook: func(context.Context, int32, string, bool) error {
+				return nil
+			},
+		},
+		TransactFunc: &UserEmailsStoreTransactFunc{
+			defaultHook: func(context.Context) (UserEmailsStore, error) {
+				return nil, nil
+			},
+		},
+		VerifyFunc: &UserEmailsStoreVerifyFunc{
+			defaultHook: func(context.Context, int32, string, string) (bool, error) {
+				return false, nil
+			},
+		},
+		WithFunc: &UserEmailsStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) UserEmailsStore {
 				return nil
 			},
 		},
 	}
 }
 
-// NewStrictMockUserCredentialsStore creates a new mock of the
-// UserCredentialsStore interface. All methods panic on invocation, unless
-// overwritten.
-func NewStrictMockUserCredentialsStore() *MockUserCredentialsStore {
-	return &MockUserCredentialsStore{
-		CreateFunc: &UserCredentialsStoreCreateFunc{
-			defaultHook: func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error) {
-				panic("unexpected invocation of MockUserCredentialsStore.Create")
+// NewStrictMockUserEmailsStore creates a new mock of the UserEmailsStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockUserEmailsStore() *MockUserEmailsStore {
+	return &MockUserEmailsStore{
+		AddFunc: &UserEmailsStoreAddFunc{
+			defaultHook: func(context.Context, int32, string, *string) error {
+				panic("unexpected invocation of MockUserEmailsStore.Add")
 			},
 		},
-		DeleteFunc: &UserCredentialsStoreDeleteFunc{
-			defaultHook: func(context.Context, int64) error {
-				panic("unexpected invocation of MockUserCredentialsStore.Delete")
+		DoneFunc: &UserEmailsStoreDoneFunc{
+			defaultHook: func(error) error {
+				panic("unexpected invocation of MockUserEmailsStore.Done")
 			},
 		},
-		GetByIDFunc: &UserCredentialsStoreGetByIDFunc{
-			defaultHook: func(context.Context, int64) (*UserCredential, error) {
-				panic("unexpected invocation of MockUserCredentialsStore.GetByID")
+		GetFunc: &UserEmailsStoreGetFunc{
+			defaultHook: func(context.Context, int32, string) (string, bool, error) {
+				panic("unexpected invocation of MockUserEmailsStore.Get")
 			},
 		},
-		GetByScopeFunc: &UserCredentialsStoreGetByScopeFunc{
-			defaultHook: func(context.Context, UserCredentialScope) (*UserCredential, error) {
-				panic("unexpected invocation of MockUserCredentialsStore.GetByScope")
+		GetInitialSiteAdminInfoFunc: &UserEmailsStoreGetInitialSiteAdminInfoFunc{
+			defaultHook: func(context.Context) (string, bool, error) {
+				panic("unexpected invocation of MockUserEmailsStore.GetInitialSiteAdminInfo")
 			},
 		},
-		HandleFunc: &UserCredentialsStoreHandleFunc{
+		GetLatestVerificationSentEmailFunc: &UserEmailsStoreGetLatestVerificationSentEmailFunc{
+			defaultHook: func(context.Context, string) (*UserEmail, error) {
+				panic("unexpected invocation of MockUserEmailsStore.GetLatestVerificationSentEmail")
+			},
+		},
+		GetPrimaryEmailFunc: &UserEmailsStoreGetPrimaryEmailFunc{
+			defaultHook: func(context.Context, int32) (string, bool, error) {
+				panic("unexpected invocation of MockUserEmailsStore.GetPrimaryEmail")
+			},
+		},
+		GetVerifiedEmailsFunc: &UserEmailsStoreGetVerifiedEmailsFunc{
+			defaultHook: func(context.Context, ...string) ([]*UserEmail, error) {
+				panic("unexpected invocation of MockUserEmailsStore.GetVerifiedEmails")
+			},
+		},
+		HandleFunc: &UserEmailsStoreHandleFunc{
 			defaultHook: func() *basestore.TransactableHandle {
-				panic("unexpected invocation of MockUserCredentialsStore.Handle")
+				panic("unexpected invocation of MockUserEmailsStore.Handle")
 			},
 		},
-		ListFunc: &UserCredentialsStoreListFunc{
-			defaultHook: func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error) {
-				panic("unexpected invocation of MockUserCredentialsStore.List")
+		ListByUserFunc: &UserEmailsStoreListByUserFunc{
+			defaultHook: func(context.Context, UserEmailsListOptions) ([]*UserEmail, error) {
+				panic("unexpected invocation of MockUserEmailsStore.ListByUser")
 			},
 		},
-		TransactFunc: &UserCredentialsStoreTransactFunc{
-			defaultHook: func(context.Context) (UserCredentialsStore, error) {
-				panic("unexpected invocation of MockUserCredentialsStore.Transact")
+		RemoveFunc: &UserEmailsStoreRemoveFunc{
+			defaultHook: func(context.Context, int32, string) error {
+				panic("unexpected invocation of MockUserEmailsStore.Remove")
+			},
+		},
+		SetLastVerificationFunc: &UserEmailsStoreSetLastVerificationFunc{
+			defaultHook: func(context.Context, int32, string, string) error {
+				panic("unexpected invocation of MockUserEmailsStore.SetLastVerification")
+			},
+		},
+		SetPrimaryEmailFunc: &UserEmailsStoreSetPrimaryEmailFunc{
+			defaultHook: func(context.Context, int32, string) error {
+				panic("unexpected invocation of MockUserEmailsStore.SetPrimaryEmail")
+			},
+		},
+		SetVerifiedFunc: &UserEmailsStoreSetVerifiedFunc{
+			defaultHook: func(context.Context, int32, string, bool) error {
+				panic("unexpected invocation of MockUserEmailsStore.SetVerified")
+			},
+		},
+		TransactFunc: &UserEmailsStoreTransactFunc{
+			defaultHook: func(context.Context) (UserEmailsStore, error) {
+				panic("unexpected invocation of MockUserEmailsStore.Transact")
 			},
 		},
-		UpdateFunc: &UserCredentialsStoreUpdateFunc{
-			defaultHook: func(context.Context, *UserCredential) error {
-				panic("unexpected invocation of MockUserCredentialsStore.Update")
+		VerifyFunc: &UserEmailsStoreVerifyFunc{
+			defaultHook: func(context.Context, int32, string, string) (bool, error) {
+				panic("unexpected invocation of MockUserEmailsStore.Verify")
 			},
 		},
-		WithFunc: &UserCredentialsStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) UserCredentialsStore {
-				panic("unexpected invocation of MockUserCredentialsStore.With")
+		WithFunc: &UserEmailsStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) UserEmailsStore {
+				panic("unexpected invocation of MockUserEmailsStore.With")
 			},
 		},
 	}
 }
 
-// NewMockUserCredentialsStoreFrom creates a new mock of the
-// MockUserCredentialsStore interface. All methods delegate to the given
-// implementation, unless overwritten.
-func NewMockUserCredentialsStoreFrom(i UserCredentialsStore) *MockUserCredentialsStore {
-	return &MockUserCredentialsStore{
-		CreateFunc: &UserCredentialsStoreCreateFunc{
-			defaultHook: i.Create,
+// NewMockUserEmailsStoreFrom creates a new mock of the MockUserEmailsStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockUserEmailsStoreFrom(i UserEmailsStore) *MockUserEmailsStore {
+	return &MockUserEmailsStore{
+		AddFunc: &UserEmailsStoreAddFunc{
+			defaultHook: i.Add,
 		},
-		DeleteFunc: &UserCredentialsStoreDeleteFunc{
-			defaultHook: i.Delete,
+		DoneFunc: &UserEmailsStoreDoneFunc{
+			defaultHook: i.Done,
 		},
-		GetByIDFunc: &UserCredentialsStoreGetByIDFunc{
-			defaultHook: i.GetByID,
+		GetFunc: &UserEmailsStoreGetFunc{
+			defaultHook: i.Get,
 		},
-		GetByScopeFunc: &UserCredentialsStoreGetByScopeFunc{
-			defaultHook: i.GetByScope,
+		GetInitialSiteAdminInfoFunc: &UserEmailsStoreGetInitialSiteAdminInfoFunc{
+			defaultHook: i.GetInitialSiteAdminInfo,
 		},
-		HandleFunc: &UserCredentialsStoreHandleFunc{
+		GetLatestVerificationSentEmailFunc: &UserEmailsStoreGetLatestVerificationSentEmailFunc{
+			defaultHook: i.GetLatestVerificationSentEmail,
+		},
+		GetPrimaryEmailFunc: &UserEmailsStoreGetPrimaryEmailFunc{
+			defaultHook: i.GetPrimaryEmail,
+		},
+		GetVerifiedEmailsFunc: &UserEmailsStoreGetVerifiedEmailsFunc{
+			defaultHook: i.GetVerifiedEmails,
+		},
+		HandleFunc: &UserEmailsStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
-		ListFunc: &UserCredentialsStoreListFunc{
-			defaultHook: i.List,
+		ListByUserFunc: &UserEmailsStoreListByUserFunc{
+			defaultHook: i.ListByUser,
 		},
-		TransactFunc: &UserCredentialsStoreTransactFunc{
+		RemoveFunc: &UserEmailsStoreRemoveFunc{
+			defaultHook: i.Remove,
+		},
+		SetLastVerificationFunc: &UserEmailsStoreSetLastVerificationFunc{
+			defaultHook: i.SetLastVerification,
+		},
+		SetPrimaryEmailFunc: &UserEmailsStoreSetPrimaryEmailFunc{
+			defaultHook: i.SetPrimaryEmail,
+		},
+		SetVerifiedFunc: &UserEmailsStoreSetVerifiedFunc{
+			defaultHook: i.SetVerified,
+		},
+		TransactFunc: &UserEmailsStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
-		UpdateFunc: &UserCredentialsStoreUpdateFunc{
-			defaultHook: i.Update,
+		VerifyFunc: &UserEmailsStoreVerifyFunc{
+			defaultHook: i.Verify,
 		},
-		WithFunc: &UserCredentialsStoreWithFunc{
+		WithFunc: &UserEmailsStoreWithFunc{
 			defaultHook: i.With,
 		},
 	}
 }
 
-// UserCredentialsStoreCreateFunc describes the behavior when the Create
-// method of the parent MockUserCredentialsStore instance is invoked.
-type UserCredentialsStoreCreateFunc struct {
-	defaultHook func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error)
-	hooks       []func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error)
-	history     []UserCredentialsStoreCreateFuncCall
+// UserEmailsStoreAddFunc describes the behavior when the Add method of the
+// parent MockUserEmailsStore instance is invoked.
+type UserEmailsStoreAddFunc struct {
+	defaultHook func(context.Context, int32, string, *string) error
+	hooks       []func(context.Context, int32, string, *string) error
+	history     []UserEmailsStoreAddFuncCall
 	mutex       sync.Mutex
 }
 
-// Create delegates to the next hook function in the queue and stores the
+// Add delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserCredentialsStore) Create(v0 context.Context, v1 UserCredentialScope, v2 auth.Authenticator) (*UserCredential, error) {
-	r0, r1 := m.CreateFunc.nextHook()(v0, v1, v2)
-	m.CreateFunc.appendCall(UserCredentialsStoreCreateFuncCall{v0, v1, v2, r0, r1})
-	return r0, r1
+func (m *MockUserEmailsStore) Add(v0 context.Context, v1 int32, v2 string, v3 *string) error {
+	r0 := m.AddFunc.nextHook()(v0, v1, v2, v3)
+	m.AddFunc.appendCall(UserEmailsStoreAddFuncCall{v0, v1, v2, v3, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Create method of the
-// parent MockUserCredentialsStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the Add method of the
+// parent MockUserEmailsStore instance is invoked and the hook queue is
 // empty.
-func (f *UserCredentialsStoreCreateFunc) SetDefaultHook(hook func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error)) {
+func (f *UserEmailsStoreAddFunc) SetDefaultHook(hook func(context.Context, int32, string, *string) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Create method of the parent MockUserCredentialsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *UserCredentialsStoreCreateFunc) PushHook(hook func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error)) {
+// Add method of the parent MockUserEmailsStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UserEmailsStoreAddFunc) PushHook(hook func(context.Context, int32, string, *string) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30480,21 +34123,21 @@ func (f *UserCredentialsStoreCreateFunc) PushHook(hook func(context.Context, Use
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserCredentialsStoreCreateFunc) SetDefaultReturn(r0 *UserCredential, r1 error) {
-	f.SetDefaultHook(func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error) {
-		return r0, r1
+func (f *UserEmailsStoreAddFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, *string) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserCredentialsStoreCreateFunc) PushReturn(r0 *UserCredential, r1 error) {
-	f.PushHook(func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error) {
-		return r0, r1
+func (f *UserEmailsStoreAddFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string, *string) error {
+		return r0
 	})
 }
 
-func (f *UserCredentialsStoreCreateFunc) nextHook() func(context.Context, UserCredentialScope, auth.Authenticator) (*UserCredential, error) {
+func (f *UserEmailsStoreAddFunc) nextHook() func(context.Context, int32, string, *string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30507,84 +34150,84 @@ func (f *UserCredentialsStoreCreateFunc) nextHook() func(context.Context, UserCr
 	return hook
 }
 
-func (f *UserCredentialsStoreCreateFunc) appendCall(r0 UserCredentialsStoreCreateFuncCall) {
+func (f *UserEmailsStoreAddFunc) appendCall(r0 UserEmailsStoreAddFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserCredentialsStoreCreateFuncCall objects
+// History returns a sequence of UserEmailsStoreAddFuncCall objects
 // describing the invocations of this function.
-func (f *UserCredentialsStoreCreateFunc) History() []UserCredentialsStoreCreateFuncCall {
+func (f *UserEmailsStoreAddFunc) History() []UserEmailsStoreAddFuncCall {
 	f.mutex.Lock()
-	history := make([]UserCredentialsStoreCreateFuncCall, len(f.history))
+	history := make([]UserEmailsStoreAddFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserCredentialsStoreCreateFuncCall is an object that describes an
-// invocation of method Create on an instance of MockUserCredentialsStore.
-type UserCredentialsStoreCreateFuncCall struct {
+// UserEmailsStoreAddFuncCall is an object that describes an invocation of
+// method Add on an instance of MockUserEmailsStore.
+type UserEmailsStoreAddFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 UserCredentialScope
+	Arg1 int32
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 auth.Authenticator
-	// Result0 is the value of the 1st result returned from this method
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Result0 *UserCredential
-	// Result1 is the value of the 2nd result returned from this method
+	Arg3 *string
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserCredentialsStoreCreateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c UserEmailsStoreAddFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserCredentialsStoreCreateFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c UserEmailsStoreAddFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// UserCredentialsStoreDeleteFunc describes the behavior when the Delete
-// method of the parent MockUserCredentialsStore instance is invoked.
-type UserCredentialsStoreDeleteFunc struct {
-	defaultHook func(context.Context, int64) error
-	hooks       []func(context.Context, int64) error
-	history     []UserCredentialsStoreDeleteFuncCall
+// UserEmailsStoreDoneFunc describes the behavior when the Done method of
+// the parent MockUserEmailsStore instance is invoked.
+type UserEmailsStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []UserEmailsStoreDoneFuncCall
 	mutex       sync.Mutex
 }
 
-// Delete delegates to the next hook function in the queue and stores the
+// Done delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserCredentialsStore) Delete(v0 context.Context, v1 int64) error {
-	r0 := m.DeleteFunc.nextHook()(v0, v1)
-	m.DeleteFunc.appendCall(UserCredentialsStoreDeleteFuncCall{v0, v1, r0})
+func (m *MockUserEmailsStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(UserEmailsStoreDoneFuncCall{v0, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Delete method of the
-// parent MockUserCredentialsStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockUserEmailsStore instance is invoked and the hook queue is
 // empty.
-func (f *UserCredentialsStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+func (f *UserEmailsStoreDoneFunc) SetDefaultHook(hook func(error) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Delete method of the parent MockUserCredentialsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *UserCredentialsStoreDeleteFunc) PushHook(hook func(context.Context, int64) error) {
+// Done method of the parent MockUserEmailsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UserEmailsStoreDoneFunc) PushHook(hook func(error) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30592,21 +34235,21 @@ func (f *UserCredentialsStoreDeleteFunc) PushHook(hook func(context.Context, int
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserCredentialsStoreDeleteFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64) error {
+func (f *UserEmailsStoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserCredentialsStoreDeleteFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64) error {
+func (f *UserEmailsStoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
 		return r0
 	})
 }
 
-func (f *UserCredentialsStoreDeleteFunc) nextHook() func(context.Context, int64) error {
+func (f *UserEmailsStoreDoneFunc) nextHook() func(error) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30619,32 +34262,29 @@ func (f *UserCredentialsStoreDeleteFunc) nextHook() func(context.Context, int64)
 	return hook
 }
 
-func (f *UserCredentialsStoreDeleteFunc) appendCall(r0 UserCredentialsStoreDeleteFuncCall) {
+func (f *UserEmailsStoreDoneFunc) appendCall(r0 UserEmailsStoreDoneFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserCredentialsStoreDeleteFuncCall objects
+// History returns a sequence of UserEmailsStoreDoneFuncCall objects
 // describing the invocations of this function.
-func (f *UserCredentialsStoreDeleteFunc) History() []UserCredentialsStoreDeleteFuncCall {
+func (f *UserEmailsStoreDoneFunc) History() []UserEmailsStoreDoneFuncCall {
 	f.mutex.Lock()
-	history := make([]UserCredentialsStoreDeleteFuncCall, len(f.history))
+	history := make([]UserEmailsStoreDoneFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserCredentialsStoreDeleteFuncCall is an object that describes an
-// invocation of method Delete on an instance of MockUserCredentialsStore.
-type UserCredentialsStoreDeleteFuncCall struct {
+// UserEmailsStoreDoneFuncCall is an object that describes an invocation of
+// method Done on an instance of MockUserEmailsStore.
+type UserEmailsStoreDoneFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int64
+	Arg0 error
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -30652,45 +34292,45 @@ type UserCredentialsStoreDeleteFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserCredentialsStoreDeleteFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c UserEmailsStoreDoneFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserCredentialsStoreDeleteFuncCall) Results() []interface{} {
+func (c UserEmailsStoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserCredentialsStoreGetByIDFunc describes the behavior when the GetByID
-// method of the parent MockUserCredentialsStore instance is invoked.
-type UserCredentialsStoreGetByIDFunc struct {
-	defaultHook func(context.Context, int64) (*UserCredential, error)
-	hooks       []func(context.Context, int64) (*UserCredential, error)
-	history     []UserCredentialsStoreGetByIDFuncCall
+// UserEmailsStoreGetFunc describes the behavior when the Get method of the
+// parent MockUserEmailsStore instance is invoked.
+type UserEmailsStoreGetFunc struct {
+	defaultHook func(context.Context, int32, string) (string, bool, error)
+	hooks       []func(context.Context, int32, string) (string, bool, error)
+	history     []UserEmailsStoreGetFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByID delegates to the next hook function in the queue and stores the
+// Get delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserCredentialsStore) GetByID(v0 context.Context, v1 int64) (*UserCredential, error) {
-	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
-	m.GetByIDFunc.appendCall(UserCredentialsStoreGetByIDFuncCall{v0, v1, r0, r1})
-	return r0, r1
+func (m *MockUserEmailsStore) Get(v0 context.Context, v1 int32, v2 string) (string, bool, error) {
+	r0, r1, r2 := m.GetFunc.nextHook()(v0, v1, v2)
+	m.GetFunc.appendCall(UserEmailsStoreGetFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the GetByID method of
-// the parent MockUserCredentialsStore instance is invoked and the hook
-// queue is empty.
-func (f *UserCredentialsStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int64) (*UserCredential, error)) {
+// SetDefaultHook sets function that is called when the Get method of the
+// parent MockUserEmailsStore instance is invoked and the hook queue is
+// empty.
+func (f *UserEmailsStoreGetFunc) SetDefaultHook(hook func(context.Context, int32, string) (string, bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByID method of the parent MockUserCredentialsStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *UserCredentialsStoreGetByIDFunc) PushHook(hook func(context.Context, int64) (*UserCredential, error)) {
+// Get method of the parent MockUserEmailsStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UserEmailsStoreGetFunc) PushHook(hook func(context.Context, int32, string) (string, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30698,21 +34338,21 @@ func (f *UserCredentialsStoreGetByIDFunc) PushHook(hook func(context.Context, in
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserCredentialsStoreGetByIDFunc) SetDefaultReturn(r0 *UserCredential, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (*UserCredential, error) {
-		return r0, r1
+func (f *UserEmailsStoreGetFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int32, string) (string, bool, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserCredentialsStoreGetByIDFunc) PushReturn(r0 *UserCredential, r1 error) {
-	f.PushHook(func(context.Context, int64) (*UserCredential, error) {
-		return r0, r1
+func (f *UserEmailsStoreGetFunc) PushReturn(r0 string, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int32, string) (string, bool, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *UserCredentialsStoreGetByIDFunc) nextHook() func(context.Context, int64) (*UserCredential, error) {
+func (f *UserEmailsStoreGetFunc) nextHook() func(context.Context, int32, string) (string, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30725,82 +34365,89 @@ func (f *UserCredentialsStoreGetByIDFunc) nextHook() func(context.Context, int64
 	return hook
 }
 
-func (f *UserCredentialsStoreGetByIDFunc) appendCall(r0 UserCredentialsStoreGetByIDFuncCall) {
+func (f *UserEmailsStoreGetFunc) appendCall(r0 UserEmailsStoreGetFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserCredentialsStoreGetByIDFuncCall objects
+// History returns a sequence of UserEmailsStoreGetFuncCall objects
 // describing the invocations of this function.
-func (f *UserCredentialsStoreGetByIDFunc) History() []UserCredentialsStoreGetByIDFuncCall {
+func (f *UserEmailsStoreGetFunc) History() []UserEmailsStoreGetFuncCall {
 	f.mutex.Lock()
-	history := make([]UserCredentialsStoreGetByIDFuncCall, len(f.history))
+	history := make([]UserEmailsStoreGetFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserCredentialsStoreGetByIDFuncCall is an object that describes an
-// invocation of method GetByID on an instance of MockUserCredentialsStore.
-type UserCredentialsStoreGetByIDFuncCall struct {
+// UserEmailsStoreGetFuncCall is an object that describes an invocation of
+// method Get on an instance of MockUserEmailsStore.
+type UserEmailsStoreGetFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int64
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *UserCredential
+	Result0 string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserCredentialsStoreGetByIDFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c UserEmailsStoreGetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserCredentialsStoreGetByIDFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c UserEmailsStoreGetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// UserCredentialsStoreGetByScopeFunc describes the behavior when the
-// GetByScope method of the parent MockUserCredentialsStore instance is
-// invoked.
-type UserCredentialsStoreGetByScopeFunc struct {
-	defaultHook func(context.Context, UserCredentialScope) (*UserCredential, error)
-	hooks       []func(context.Context, UserCredentialScope) (*UserCredential, error)
-	history     []UserCredentialsStoreGetByScopeFuncCall
+// UserEmailsStoreGetInitialSiteAdminInfoFunc describes the behavior when
+// the GetInitialSiteAdminInfo method of the parent MockUserEmailsStore
+// instance is invoked.
+type UserEmailsStoreGetInitialSiteAdminInfoFunc struct {
+	defaultHook func(context.Context) (string, bool, error)
+	hooks       []func(context.Context) (string, bool, error)
+	history     []UserEmailsStoreGetInitialSiteAdminInfoFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByScope delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockUserCredentialsStore) GetByScope(v0 context.Context, v1 UserCredentialScope) (*UserCredential, error) {
-	r0, r1 := m.GetByScopeFunc.nextHook()(v0, v1)
-	m.GetByScopeFunc.appendCall(UserCredentialsStoreGetByScopeFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// GetInitialSiteAdminInfo delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUserEmailsStore) GetInitialSiteAdminInfo(v0 context.Context) (string, bool, error) {
+	r0, r1, r2 := m.GetInitialSiteAdminInfoFunc.nextHook()(v0)
+	m.GetInitialSiteAdminInfoFunc.appendCall(UserEmailsStoreGetInitialSiteAdminInfoFuncCall{v0, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the GetByScope method of
-// the parent MockUserCredentialsStore instance is invoked and the hook
-// queue is empty.
-func (f *UserCredentialsStoreGetByScopeFunc) SetDefaultHook(hook func(context.Context, UserCredentialScope) (*UserCredential, error)) {
+// SetDefaultHook sets function that is called when the
+// GetInitialSiteAdminInfo method of the parent MockUserEmailsStore instance
+// is invoked and the hook queue is empty.
+func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) SetDefaultHook(hook func(context.Context) (string, bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByScope method of the parent MockUserCredentialsStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *UserCredentialsStoreGetByScopeFunc) PushHook(hook func(context.Context, UserCredentialScope) (*UserCredential, error)) {
+// GetInitialSiteAdminInfo method of the parent MockUserEmailsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) PushHook(hook func(context.Context) (string, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30808,21 +34455,21 @@ func (f *UserCredentialsStoreGetByScopeFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserCredentialsStoreGetByScopeFunc) SetDefaultReturn(r0 *UserCredential, r1 error) {
-	f.SetDefaultHook(func(context.Context, UserCredentialScope) (*UserCredential, error) {
-		return r0, r1
+func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context) (string, bool, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserCredentialsStoreGetByScopeFunc) PushReturn(r0 *UserCredential, r1 error) {
-	f.PushHook(func(context.Context, UserCredentialScope) (*UserCredential, error) {
-		return r0, r1
+func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) PushReturn(r0 string, r1 bool, r2 error) {
+	f.PushHook(func(context.Context) (string, bool, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *UserCredentialsStoreGetByScopeFunc) nextHook() func(context.Context, UserCredentialScope) (*UserCredential, error) {
+func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) nextHook() func(context.Context) (string, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30835,182 +34482,85 @@ func (f *UserCredentialsStoreGetByScopeFunc) nextHook() func(context.Context, Us
 	return hook
 }
 
-func (f *UserCredentialsStoreGetByScopeFunc) appendCall(r0 UserCredentialsStoreGetByScopeFuncCall) {
+func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) appendCall(r0 UserEmailsStoreGetInitialSiteAdminInfoFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserCredentialsStoreGetByScopeFuncCall
-// objects describing the invocations of this function.
-func (f *UserCredentialsStoreGetByScopeFunc) History() []UserCredentialsStoreGetByScopeFuncCall {
+// History returns a sequence of
+// UserEmailsStoreGetInitialSiteAdminInfoFuncCall objects describing the
+// invocations of this function.
+func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) History() []UserEmailsStoreGetInitialSiteAdminInfoFuncCall {
 	f.mutex.Lock()
-	history := make([]UserCredentialsStoreGetByScopeFuncCall, len(f.history))
+	history := make([]UserEmailsStoreGetInitialSiteAdminInfoFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserCredentialsStoreGetByScopeFuncCall is an object that describes an
-// invocation of method GetByScope on an instance of
-// MockUserCredentialsStore.
-type UserCredentialsStoreGetByScopeFuncCall struct {
+// UserEmailsStoreGetInitialSiteAdminInfoFuncCall is an object that
+// describes an invocation of method GetInitialSiteAdminInfo on an instance
+// of MockUserEmailsStore.
+type UserEmailsStoreGetInitialSiteAdminInfoFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 UserCredentialScope
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *UserCredential
+	Result0 string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
-}
-
-// Args returns an interface slice containing the arguments of this
-// invocation.
-func (c UserCredentialsStoreGetByScopeFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
-}
-
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c UserCredentialsStoreGetByScopeFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
-}
-
-// UserCredentialsStoreHandleFunc describes the behavior when the Handle
-// method of the parent MockUserCredentialsStore instance is invoked.
-type UserCredentialsStoreHandleFunc struct {
-	defaultHook func() *basestore.TransactableHandle
-	hooks       []func() *basestore.TransactableHandle
-	history     []UserCredentialsStoreHandleFuncCall
-	mutex       sync.Mutex
-}
-
-// Handle delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserCredentialsStore) Handle() *basestore.TransactableHandle {
-	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(UserCredentialsStoreHandleFuncCall{r0})
-	return r0
-}
-
-// SetDefaultHook sets function that is called when the Handle method of the
-// parent MockUserCredentialsStore instance is invoked and the hook queue is
-// empty.
-func (f *UserCredentialsStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
-	f.defaultHook = hook
-}
-
-// PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockUserCredentialsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *UserCredentialsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
-	f.mutex.Lock()
-	f.hooks = append(f.hooks, hook)
-	f.mutex.Unlock()
-}
-
-// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
-// the given values.
-func (f *UserCredentialsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
-	f.SetDefaultHook(func() *basestore.TransactableHandle {
-		return r0
-	})
-}
-
-// PushReturn calls PushDefaultHook with a function that returns the given
-// values.
-func (f *UserCredentialsStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
-	f.PushHook(func() *basestore.TransactableHandle {
-		return r0
-	})
-}
-
-func (f *UserCredentialsStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
-	f.mutex.Lock()
-	defer f.mutex.Unlock()
-
-	if len(f.hooks) == 0 {
-		return f.defaultHook
-	}
-
-	hook := f.hooks[0]
-	f.hooks = f.hooks[1:]
-	return hook
-}
-
-func (f *UserCredentialsStoreHandleFunc) appendCall(r0 UserCredentialsStoreHandleFuncCall) {
-	f.mutex.Lock()
-	f.history = append(f.history, r0)
-	f.mutex.Unlock()
-}
-
-// History returns a sequence of UserCredentialsStoreHandleFuncCall objects
-// describing the invocations of this function.
-func (f *UserCredentialsStoreHandleFunc) History() []UserCredentialsStoreHandleFuncCall {
-	f.mutex.Lock()
-	history := make([]UserCredentialsStoreHandleFuncCall, len(f.history))
-	copy(history, f.history)
-	f.mutex.Unlock()
-
-	return history
-}
-
-// UserCredentialsStoreHandleFuncCall is an object that describes an
-// invocation of method Handle on an instance of MockUserCredentialsStore.
-type UserCredentialsStoreHandleFuncCall struct {
-	// Result0 is the value of the 1st result returned from this method
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
 	// invocation.
-	Result0 *basestore.TransactableHandle
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserCredentialsStoreHandleFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c UserEmailsStoreGetInitialSiteAdminInfoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserCredentialsStoreHandleFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
-}
-
-// UserCredentialsStoreListFunc describes the behavior when the List method
-// of the parent MockUserCredentialsStore instance is invoked.
-type UserCredentialsStoreListFunc struct {
-	defaultHook func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error)
-	hooks       []func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error)
-	history     []UserCredentialsStoreListFuncCall
+func (c UserEmailsStoreGetInitialSiteAdminInfoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// UserEmailsStoreGetLatestVerificationSentEmailFunc describes the behavior
+// when the GetLatestVerificationSentEmail method of the parent
+// MockUserEmailsStore instance is invoked.
+type UserEmailsStoreGetLatestVerificationSentEmailFunc struct {
+	defaultHook func(context.Context, string) (*UserEmail, error)
+	hooks       []func(context.Context, string) (*UserEmail, error)
+	history     []UserEmailsStoreGetLatestVerificationSentEmailFuncCall
 	mutex       sync.Mutex
 }
 
-// List delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserCredentialsStore) List(v0 context.Context, v1 UserCredentialsListOpts) ([]*UserCredential, int, error) {
-	r0, r1, r2 := m.ListFunc.nextHook()(v0, v1)
-	m.ListFunc.appendCall(UserCredentialsStoreListFuncCall{v0, v1, r0, r1, r2})
-	return r0, r1, r2
+// GetLatestVerificationSentEmail delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockUserEmailsStore) GetLatestVerificationSentEmail(v0 context.Context, v1 string) (*UserEmail, error) {
+	r0, r1 := m.GetLatestVerificationSentEmailFunc.nextHook()(v0, v1)
+	m.GetLatestVerificationSentEmailFunc.appendCall(UserEmailsStoreGetLatestVerificationSentEmailFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the List method of the
-// parent MockUserCredentialsStore instance is invoked and the hook queue is
-// empty.
-func (f *UserCredentialsStoreListFunc) SetDefaultHook(hook func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error)) {
+// SetDefaultHook sets function that is called when the
+// GetLatestVerificationSentEmail method of the parent MockUserEmailsStore
+// instance is invoked and the hook queue is empty.
+func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) SetDefaultHook(hook func(context.Context, string) (*UserEmail, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// List method of the parent MockUserCredentialsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *UserCredentialsStoreListFunc) PushHook(hook func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error)) {
+// GetLatestVerificationSentEmail method of the parent MockUserEmailsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) PushHook(hook func(context.Context, string) (*UserEmail, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31018,21 +34568,21 @@ func (f *UserCredentialsStoreListFunc) PushHook(hook func(context.Context, UserC
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserCredentialsStoreListFunc) SetDefaultReturn(r0 []*UserCredential, r1 int, r2 error) {
-	f.SetDefaultHook(func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error) {
-		return r0, r1, r2
+func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) SetDefaultReturn(r0 *UserEmail, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (*UserEmail, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserCredentialsStoreListFunc) PushReturn(r0 []*UserCredential, r1 int, r2 error) {
-	f.PushHook(func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error) {
-		return r0, r1, r2
+func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) PushReturn(r0 *UserEmail, r1 error) {
+	f.PushHook(func(context.Context, string) (*UserEmail, error) {
+		return r0, r1
 	})
 }
 
-func (f *UserCredentialsStoreListFunc) nextHook() func(context.Context, UserCredentialsListOpts) ([]*UserCredential, int, error) {
+func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) nextHook() func(context.Context, string) (*UserEmail, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31045,84 +34595,84 @@ func (f *UserCredentialsStoreListFunc) nextHook() func(context.Context, UserCred
 	return hook
 }
 
-func (f *UserCredentialsStoreListFunc) appendCall(r0 UserCredentialsStoreListFuncCall) {
+func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) appendCall(r0 UserEmailsStoreGetLatestVerificationSentEmailFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserCredentialsStoreListFuncCall objects
-// describing the invocations of this function.
-func (f *UserCredentialsStoreListFunc) History() []UserCredentialsStoreListFuncCall {
+// History returns a sequence of
+// UserEmailsStoreGetLatestVerificationSentEmailFuncCall objects describing
+// the invocations of this function.
+func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) History() []UserEmailsStoreGetLatestVerificationSentEmailFuncCall {
 	f.mutex.Lock()
-	history := make([]UserCredentialsStoreListFuncCall, len(f.history))
+	history := make([]UserEmailsStoreGetLatestVerificationSentEmailFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserCredentialsStoreListFuncCall is an object that describes an
-// invocation of method List on an instance of MockUserCredentialsStore.
-type UserCredentialsStoreListFuncCall struct {
+// UserEmailsStoreGetLatestVerificationSentEmailFuncCall is an object that
+// describes an invocation of method GetLatestVerificationSentEmail on an
+// instance of MockUserEmailsStore.
+type UserEmailsStoreGetLatestVerificationSentEmailFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 UserCredentialsListOpts
+	Arg1 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*UserCredential
+	Result0 *UserEmail
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 int
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserCredentialsStoreListFuncCall) Args() []interface{} {
+func (c UserEmailsStoreGetLatestVerificationSentEmailFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserCredentialsStoreListFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c UserEmailsStoreGetLatestVerificationSentEmailFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserCredentialsStoreTransactFunc describes the behavior when the Transact
-// method of the parent MockUserCredentialsStore instance is invoked.
-type UserCredentialsStoreTransactFunc struct {
-	defaultHook func(context.Context) (UserCredentialsStore, error)
-	hooks       []func(context.Context) (UserCredentialsStore, error)
-	history     []UserCredentialsStoreTransactFuncCall
+// UserEmailsStoreGetPrimaryEmailFunc describes the behavior when the
+// GetPrimaryEmail method of the parent MockUserEmailsStore instance is
+// invoked.
+type UserEmailsStoreGetPrimaryEmailFunc struct {
+	defaultHook func(context.Context, int32) (string, bool, error)
+	hooks       []func(context.Context, int32) (string, bool, error)
+	history     []UserEmailsStoreGetPrimaryEmailFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserCredentialsStore) Transact(v0 context.Context) (UserCredentialsStore, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(UserCredentialsStoreTransactFuncCall{v0, r0, r1})
-	return r0, r1
+// GetPrimaryEmail delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserEmailsStore) GetPrimaryEmail(v0 context.Context, v1 int32) (string, bool, error) {
+	r0, r1, r2 := m.GetPrimaryEmailFunc.nextHook()(v0, v1)
+	m.GetPrimaryEmailFunc.appendCall(UserEmailsStoreGetPrimaryEmailFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
-// the parent MockUserCredentialsStore instance is invoked and the hook
+// SetDefaultHook sets function that is called when the GetPrimaryEmail
+// method of the parent MockUserEmailsStore instance is invoked and the hook
 // queue is empty.
-func (f *UserCredentialsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (UserCredentialsStore, error)) {
+func (f *UserEmailsStoreGetPrimaryEmailFunc) SetDefaultHook(hook func(context.Context, int32) (string, bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockUserCredentialsStore instance invokes
+// GetPrimaryEmail method of the parent MockUserEmailsStore instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *UserCredentialsStoreTransactFunc) PushHook(hook func(context.Context) (UserCredentialsStore, error)) {
+func (f *UserEmailsStoreGetPrimaryEmailFunc) PushHook(hook func(context.Context, int32) (string, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31130,21 +34680,21 @@ func (f *UserCredentialsStoreTransactFunc) PushHook(hook func(context.Context) (
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserCredentialsStoreTransactFunc) SetDefaultReturn(r0 UserCredentialsStore, r1 error) {
-	f.SetDefaultHook(func(context.Context) (UserCredentialsStore, error) {
-		return r0, r1
+func (f *UserEmailsStoreGetPrimaryEmailFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int32) (string, bool, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserCredentialsStoreTransactFunc) PushReturn(r0 UserCredentialsStore, r1 error) {
-	f.PushHook(func(context.Context) (UserCredentialsStore, error) {
-		return r0, r1
+func (f *UserEmailsStoreGetPrimaryEmailFunc) PushReturn(r0 string, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int32) (string, bool, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *UserCredentialsStoreTransactFunc) nextHook() func(context.Context) (UserCredentialsStore, error) {
+func (f *UserEmailsStoreGetPrimaryEmailFunc) nextHook() func(context.Context, int32) (string, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31157,78 +34707,87 @@ func (f *UserCredentialsStoreTransactFunc) nextHook() func(context.Context) (Use
 	return hook
 }
 
-func (f *UserCredentialsStoreTransactFunc) appendCall(r0 UserCredentialsStoreTransactFuncCall) {
+func (f *UserEmailsStoreGetPrimaryEmailFunc) appendCall(r0 UserEmailsStoreGetPrimaryEmailFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserCredentialsStoreTransactFuncCall
+// History returns a sequence of UserEmailsStoreGetPrimaryEmailFuncCall
 // objects describing the invocations of this function.
-func (f *UserCredentialsStoreTransactFunc) History() []UserCredentialsStoreTransactFuncCall {
+func (f *UserEmailsStoreGetPrimaryEmailFunc) History() []UserEmailsStoreGetPrimaryEmailFuncCall {
 	f.mutex.Lock()
-	history := make([]UserCredentialsStoreTransactFuncCall, len(f.history))
+	history := make([]UserEmailsStoreGetPrimaryEmailFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserCredentialsStoreTransactFuncCall is an object that describes an
-// invocation of method Transact on an instance of MockUserCredentialsStore.
-type UserCredentialsStoreTransactFuncCall struct {
+// UserEmailsStoreGetPrimaryEmailFuncCall is an object that describes an
+// invocation of method GetPrimaryEmail on an instance of
+// MockUserEmailsStore.
+type UserEmailsStoreGetPrimaryEmailFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 UserCredentialsStore
+	Result0 string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserCredentialsStoreTransactFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c UserEmailsStoreGetPrimaryEmailFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserCredentialsStoreTransactFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c UserEmailsStoreGetPrimaryEmailFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// UserCredentialsStoreUpdateFunc describes the behavior when the Update
-// method of the parent MockUserCredentialsStore instance is invoked.
-type UserCredentialsStoreUpdateFunc struct {
-	defaultHook func(context.Context, *UserCredential) error
-	hooks       []func(context.Context, *UserCredential) error
-	history     []UserCredentialsStoreUpdateFuncCall
+// UserEmailsStoreGetVerifiedEmailsFunc describes the behavior when the
+// GetVerifiedEmails method of the parent MockUserEmailsStore instance is
+// invoked.
+type UserEmailsStoreGetVerifiedEmailsFunc struct {
+	defaultHook func(context.Context, ...string) ([]*UserEmail, error)
+	hooks       []func(context.Context, ...string) ([]*UserEmail, error)
+	history     []UserEmailsStoreGetVerifiedEmailsFuncCall
 	mutex       sync.Mutex
 }
 
-// Update delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserCredentialsStore) Update(v0 context.Context, v1 *UserCredential) error {
-	r0 := m.UpdateFunc.nextHook()(v0, v1)
-	m.UpdateFunc.appendCall(UserCredentialsStoreUpdateFuncCall{v0, v1, r0})
-	return r0
+// GetVerifiedEmails delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserEmailsStore) GetVerifiedEmails(v0 context.Context, v1 ...string) ([]*UserEmail, error) {
+	r0, r1 := m.GetVerifiedEmailsFunc.nextHook()(v0, v1...)
+	m.GetVerifiedEmailsFunc.appendCall(UserEmailsStoreGetVerifiedEmailsFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Update method of the
-// parent MockUserCredentialsStore instance is invoked and the hook queue is
-// empty.
-func (f *UserCredentialsStoreUpdateFunc) SetDefaultHook(hook func(context.Context, *UserCredential) error) {
+// SetDefaultHook sets function that is called when the GetVerifiedEmails
+// method of the parent MockUserEmailsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserEmailsStoreGetVerifiedEmailsFunc) SetDefaultHook(hook func(context.Context, ...string) ([]*UserEmail, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Update method of the parent MockUserCredentialsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *UserCredentialsStoreUpdateFunc) PushHook(hook func(context.Context, *UserCredential) error) {
+// GetVerifiedEmails method of the parent MockUserEmailsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserEmailsStoreGetVerifiedEmailsFunc) PushHook(hook func(context.Context, ...string) ([]*UserEmail, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31236,21 +34795,21 @@ func (f *UserCredentialsStoreUpdateFunc) PushHook(hook func(context.Context, *Us
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserCredentialsStoreUpdateFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, *UserCredential) error {
-		return r0
+func (f *UserEmailsStoreGetVerifiedEmailsFunc) SetDefaultReturn(r0 []*UserEmail, r1 error) {
+	f.SetDefaultHook(func(context.Context, ...string) ([]*UserEmail, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserCredentialsStoreUpdateFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, *UserCredential) error {
-		return r0
+func (f *UserEmailsStoreGetVerifiedEmailsFunc) PushReturn(r0 []*UserEmail, r1 error) {
+	f.PushHook(func(context.Context, ...string) ([]*UserEmail, error) {
+		return r0, r1
 	})
 }
 
-func (f *UserCredentialsStoreUpdateFunc) nextHook() func(context.Context, *UserCredential) error {
+func (f *UserEmailsStoreGetVerifiedEmailsFunc) nextHook() func(context.Context, ...string) ([]*UserEmail, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31263,78 +34822,89 @@ func (f *UserCredentialsStoreUpdateFunc) nextHook() func(context.Context, *UserC
 	return hook
 }
 
-func (f *UserCredentialsStoreUpdateFunc) appendCall(r0 UserCredentialsStoreUpdateFuncCall) {
+func (f *UserEmailsStoreGetVerifiedEmailsFunc) appendCall(r0 UserEmailsStoreGetVerifiedEmailsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserCredentialsStoreUpdateFuncCall objects
-// describing the invocations of this function.
-func (f *UserCredentialsStoreUpdateFunc) History() []UserCredentialsStoreUpdateFuncCall {
+// History returns a sequence of UserEmailsStoreGetVerifiedEmailsFuncCall
+// objects describing the invocations of this function.
+func (f *UserEmailsStoreGetVerifiedEmailsFunc) History() []UserEmailsStoreGetVerifiedEmailsFuncCall {
 	f.mutex.Lock()
-	history := make([]UserCredentialsStoreUpdateFuncCall, len(f.history))
+	history := make([]UserEmailsStoreGetVerifiedEmailsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserCredentialsStoreUpdateFuncCall is an object that describes an
-// invocation of method Update on an instance of MockUserCredentialsStore.
-type UserCredentialsStoreUpdateFuncCall struct {
+// UserEmailsStoreGetVerifiedEmailsFuncCall is an object that describes an
+// invocation of method GetVerifiedEmails on an instance of
+// MockUserEmailsStore.
+type UserEmailsStoreGetVerifiedEmailsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 *UserCredential
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 []*UserEmail
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation.
-func (c UserCredentialsStoreUpdateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c UserEmailsStoreGetVerifiedEmailsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserCredentialsStoreUpdateFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c UserEmailsStoreGetVerifiedEmailsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserCredentialsStoreWithFunc describes the behavior when the With method
-// of the parent MockUserCredentialsStore instance is invoked.
-type UserCredentialsStoreWithFunc struct {
-	defaultHook func(basestore.ShareableStore) UserCredentialsStore
-	hooks       []func(basestore.ShareableStore) UserCredentialsStore
-	history     []UserCredentialsStoreWithFuncCall
+// UserEmailsStoreHandleFunc describes the behavior when the Handle method
+// of the parent MockUserEmailsStore instance is invoked.
+type UserEmailsStoreHandleFunc struct {
+	defaultHook func() *basestore.TransactableHandle
+	hooks       []func() *basestore.TransactableHandle
+	history     []UserEmailsStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
-// With delegates to the next hook function in the queue and stores the
+// Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserCredentialsStore) With(v0 basestore.ShareableStore) UserCredentialsStore {
-	r0 := m.WithFunc.nextHook()(v0)
-	m.WithFunc.appendCall(UserCredentialsStoreWithFuncCall{v0, r0})
+func (m *MockUserEmailsStore) Handle() *basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(UserEmailsStoreHandleFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the With method of the
-// parent MockUserCredentialsStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockUserEmailsStore instance is invoked and the hook queue is
 // empty.
-func (f *UserCredentialsStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) UserCredentialsStore) {
+func (f *UserEmailsStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// With method of the parent MockUserCredentialsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *UserCredentialsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) UserCredentialsStore) {
+// Handle method of the parent MockUserEmailsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UserEmailsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31342,21 +34912,21 @@ func (f *UserCredentialsStoreWithFunc) PushHook(hook func(basestore.ShareableSto
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserCredentialsStoreWithFunc) SetDefaultReturn(r0 UserCredentialsStore) {
-	f.SetDefaultHook(func(basestore.ShareableStore) UserCredentialsStore {
+func (f *UserEmailsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+	f.SetDefaultHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserCredentialsStoreWithFunc) PushReturn(r0 UserCredentialsStore) {
-	f.PushHook(func(basestore.ShareableStore) UserCredentialsStore {
+func (f *UserEmailsStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+	f.PushHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
-func (f *UserCredentialsStoreWithFunc) nextHook() func(basestore.ShareableStore) UserCredentialsStore {
+func (f *UserEmailsStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31369,362 +34939,181 @@ func (f *UserCredentialsStoreWithFunc) nextHook() func(basestore.ShareableStore)
 	return hook
 }
 
-func (f *UserCredentialsStoreWithFunc) appendCall(r0 UserCredentialsStoreWithFuncCall) {
+func (f *UserEmailsStoreHandleFunc) appendCall(r0 UserEmailsStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserCredentialsStoreWithFuncCall objects
+// History returns a sequence of UserEmailsStoreHandleFuncCall objects
 // describing the invocations of this function.
-func (f *UserCredentialsStoreWithFunc) History() []UserCredentialsStoreWithFuncCall {
+func (f *UserEmailsStoreHandleFunc) History() []UserEmailsStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]UserCredentialsStoreWithFuncCall, len(f.history))
+	history := make([]UserEmailsStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserCredentialsStoreWithFuncCall is an object that describes an
-// invocation of method With on an instance of MockUserCredentialsStore.
-type UserCredentialsStoreWithFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 basestore.ShareableStore
+// UserEmailsStoreHandleFuncCall is an object that describes an invocation
+// of method Handle on an instance of MockUserEmailsStore.
+type UserEmailsStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 UserCredentialsStore
+	Result0 *basestore.TransactableHandle
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserCredentialsStoreWithFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c UserEmailsStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserCredentialsStoreWithFuncCall) Results() []interface{} {
+func (c UserEmailsStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// MockUserEmailsStore is a mock implementation of the UserEmailsStore
-// interface (from the package
-// github.com/sourcegraph/sourcegraph/internal/database) used for unit
-// testing.
-type MockUserEmailsStore struct {
-	// AddFunc is an instance of a mock function object controlling the
-	// behavior of the method Add.
-	AddFunc *UserEmailsStoreAddFunc
-	// DoneFunc is an instance of a mock function object controlling the
-	// behavior of the method Done.
-	DoneFunc *UserEmailsStoreDoneFunc
-	// GetFunc is an instance of a mock function object controlling the
-	// behavior of the method Get.
-	GetFunc *UserEmailsStoreGetFunc
-	// GetInitialSiteAdminInfoFunc is an instance of a mock function object
-	// controlling the behavior of the method GetInitialSiteAdminInfo.
-	GetInitialSiteAdminInfoFunc *UserEmailsStoreGetInitialSiteAdminInfoFunc
-	// GetLatestVerificationSentEmailFunc is an instance of a mock function
-	// object controlling the behavior of the method
-	// GetLatestVerificationSentEmail.
-	GetLatestVerificationSentEmailFunc *UserEmailsStoreGetLatestVerificationSentEmailFunc
-	// GetPrimaryEmailFunc is an instance of a mock function object
-	// controlling the behavior of the method GetPrimaryEmail.
-	GetPrimaryEmailFunc *UserEmailsStoreGetPrimaryEmailFunc
-	// GetVerifiedEmailsFunc is an instance of a mock function object
-	// controlling the behavior of the method GetVerifiedEmails.
-	GetVerifiedEmailsFunc *UserEmailsStoreGetVerifiedEmailsFunc
-	// HandleFunc is an instance of a mock function object controlling the
-	// behavior of the method Handle.
-	HandleFunc *UserEmailsStoreHandleFunc
-	// ListByUserFunc is an instance of a mock function object controlling
-	// the behavior of the method ListByUser.
-	ListByUserFunc *UserEmailsStoreListByUserFunc
-	// RemoveFunc is an instance of a mock function object controlling the
-	// behavior of the method Remove.
-	RemoveFunc *UserEmailsStoreRemoveFunc
-	// SetLastVerificationFunc is an instance of a mock function object
-	// controlling the behavior of the method SetLastVerification.
-	SetLastVerificationFunc *UserEmailsStoreSetLastVerificationFunc
-	// SetPrimaryEmailFunc is an instance of a mock function object
-	// controlling the behavior of the method SetPrimaryEmail.
-	SetPrimaryEmailFunc *UserEmailsStoreSetPrimaryEmailFunc
-	// SetVerifiedFunc is an instance of a mock function object controlling
-	// the behavior of the method SetVerified.
-	SetVerifiedFunc *UserEmailsStoreSetVerifiedFunc
-	// TransactFunc is an instance of a mock function object controlling the
-	// behavior of the method Transact.
-	TransactFunc *UserEmailsStoreTransactFunc
-	// VerifyFunc is an instance of a mock function object controlling the
-	// behavior of the method Verify.
-	VerifyFunc *UserEmailsStoreVerifyFunc
-	// WithFunc is an instance of a mock function object controlling the
-	// behavior of the method With.
-	WithFunc *UserEmailsStoreWithFunc
+// UserEmailsStoreListByUserFunc describes the behavior when the ListByUser
+// method of the parent MockUserEmailsStore instance is invoked.
+type UserEmailsStoreListByUserFunc struct {
+	defaultHook func(context.Context, UserEmailsListOptions) ([]*UserEmail, error)
+	hooks       []func(context.Context, UserEmailsListOptions) ([]*UserEmail, error)
+	history     []UserEmailsStoreListByUserFuncCall
+	mutex       sync.Mutex
 }
 
-// NewMockUserEmailsStore creates a new mock of the UserEmailsStore
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockUserEmailsStore() *MockUserEmailsStore {
-	return &MockUserEmailsStore{
-		AddFunc: &UserEmailsStoreAddFunc{
-			defaultHook: func(context.Context, int32, string, *string) error {
-				return nil
-			},
-		},
-		DoneFunc: &UserEmailsStoreDoneFunc{
-			defaultHook: func(error) error {
-				return nil
-			},
-		},
-		GetFunc: &UserEmailsStoreGetFunc{
-			defaultHook: func(context.Context, int32, string) (string, bool, error) {
-				return "", false, nil
-			},
-		},
-		GetInitialSiteAdminInfoFunc: &UserEmailsStoreGetInitialSiteAdminInfoFunc{
-			defaultHook: func(context.Context) (string, bool, error) {
-				return "", false, nil
-			},
-		},
-		GetLatestVerificationSentEmailFunc: &UserEmailsStoreGetLatestVerificationSentEmailFunc{
-			defaultHook: func(context.Context, string) (*UserEmail, error) {
-				return nil, nil
-			},
-		},
-		GetPrimaryEmailFunc: &UserEmailsStoreGetPrimaryEmailFunc{
-			defaultHook: func(context.Context, int32) (string, bool, error) {
-				return "", false, nil
-			},
-		},
-		GetVerifiedEmailsFunc: &UserEmailsStoreGetVerifiedEmailsFunc{
-			defaultHook: func(context.Context, ...string) ([]*UserEmail, error) {
-				return nil, nil
-			},
-		},
-		HandleFunc: &UserEmailsStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				return nil
-			},
-		},
-		ListByUserFunc: &UserEmailsStoreListByUserFunc{
-			defaultHook: func(context.Context, UserEmailsListOptions) ([]*UserEmail, error) {
-				return nil, nil
-			},
-		},
-		RemoveFunc: &UserEmailsStoreRemoveFunc{
-			defaultHook: func(context.Context, int32, string) error {
-				return nil
-			},
-		},
-		SetLastVerificationFunc: &UserEmailsStoreSetLastVerificationFunc{
-			defaultHook: func(context.Context, int32, string, string) error {
-				return nil
-			},
-		},
-		SetPrimaryEmailFunc: &UserEmailsStoreSetPrimaryEmailFunc{
-			defaultHook: func(context.Context, int32, string) error {
-				return nil
-			},
-		},
-		SetVerifiedFunc: &UserEmailsStoreSetVerifiedFunc{
-			defaultHook: func(context.Context, int32, string, bool) error {
-				return nil
-			},
-		},
-		TransactFunc: &UserEmailsStoreTransactFunc{
-			defaultHook: func(context.Context) (UserEmailsStore, error) {
-				return nil, nil
-			},
-		},
-		VerifyFunc: &UserEmailsStoreVerifyFunc{
-			defaultHook: func(context.Context, int32, string, string) (bool, error) {
-				return false, nil
-			},
-		},
-		WithFunc: &UserEmailsStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) UserEmailsStore {
-				return nil
-			},
-		},
-	}
+// ListByUser delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUserEmailsStore) ListByUser(v0 context.Context, v1 UserEmailsListOptions) ([]*UserEmail, error) {
+	r0, r1 := m.ListByUserFunc.nextHook()(v0, v1)
+	m.ListByUserFunc.appendCall(UserEmailsStoreListByUserFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListByUser method of
+// the parent MockUserEmailsStore instance is invoked and the hook queue is
+// empty.
+func (f *UserEmailsStoreListByUserFunc) SetDefaultHook(hook func(context.Context, UserEmailsListOptions) ([]*UserEmail, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListByUser method of the parent MockUserEmailsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserEmailsStoreListByUserFunc) PushHook(hook func(context.Context, UserEmailsListOptions) ([]*UserEmail, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *UserEmailsStoreListByUserFunc) SetDefaultReturn(r0 []*UserEmail, r1 error) {
+	f.SetDefaultHook(func(context.Context, UserEmailsListOptions) ([]*UserEmail, error) {
+		return r0, r1
+	})
 }
 
-// NewStrictMockUserEmailsStore creates a new mock of the UserEmailsStore
-// interface. All methods panic on invocation, unless overwritten.
-func NewStrictMockUserEmailsStore() *MockUserEmailsStore {
-	return &MockUserEmailsStore{
-		AddFunc: &UserEmailsStoreAddFunc{
-			defaultHook: func(context.Context, int32, string, *string) error {
-				panic("unexpected invocation of MockUserEmailsStore.Add")
-			},
-		},
-		DoneFunc: &UserEmailsStoreDoneFunc{
-			defaultHook: func(error) error {
-				panic("unexpected invocation of MockUserEmailsStore.Done")
-			},
-		},
-		GetFunc: &UserEmailsStoreGetFunc{
-			defaultHook: func(context.Context, int32, string) (string, bool, error) {
-				panic("unexpected invocation of MockUserEmailsStore.Get")
-			},
-		},
-		GetInitialSiteAdminInfoFunc: &UserEmailsStoreGetInitialSiteAdminInfoFunc{
-			defaultHook: func(context.Context) (string, bool, error) {
-				panic("unexpected invocation of MockUserEmailsStore.GetInitialSiteAdminInfo")
-			},
-		},
-		GetLatestVerificationSentEmailFunc: &UserEmailsStoreGetLatestVerificationSentEmailFunc{
-			defaultHook: func(context.Context, string) (*UserEmail, error) {
-				panic("unexpected invocation of MockUserEmailsStore.GetLatestVerificationSentEmail")
-			},
-		},
-		GetPrimaryEmailFunc: &UserEmailsStoreGetPrimaryEmailFunc{
-			defaultHook: func(context.Context, int32) (string, bool, error) {
-				panic("unexpected invocation of MockUserEmailsStore.GetPrimaryEmail")
-			},
-		},
-		GetVerifiedEmailsFunc: &UserEmailsStoreGetVerifiedEmailsFunc{
-			defaultHook: func(context.Context, ...string) ([]*UserEmail, error) {
-				panic("unexpected invocation of MockUserEmailsStore.GetVerifiedEmails")
-			},
-		},
-		HandleFunc: &UserEmailsStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				panic("unexpected invocation of MockUserEmailsStore.Handle")
-			},
-		},
-		ListByUserFunc: &UserEmailsStoreListByUserFunc{
-			defaultHook: func(context.Context, UserEmailsListOptions) ([]*UserEmail, error) {
-				panic("unexpected invocation of MockUserEmailsStore.ListByUser")
-			},
-		},
-		RemoveFunc: &UserEmailsStoreRemoveFunc{
-			defaultHook: func(context.Context, int32, string) error {
-				panic("unexpected invocation of MockUserEmailsStore.Remove")
-			},
-		},
-		SetLastVerificationFunc: &UserEmailsStoreSetLastVerificationFunc{
-			defaultHook: func(context.Context, int32, string, string) error {
-				panic("unexpected invocation of MockUserEmailsStore.SetLastVerification")
-			},
-		},
-		SetPrimaryEmailFunc: &UserEmailsStoreSetPrimaryEmailFunc{
-			defaultHook: func(context.Context, int32, string) error {
-				panic("unexpected invocation of MockUserEmailsStore.SetPrimaryEmail")
-			},
-		},
-		SetVerifiedFunc: &UserEmailsStoreSetVerifiedFunc{
-			defaultHook: func(context.Context, int32, string, bool) error {
-				panic("unexpected invocation of MockUserEmailsStore.SetVerified")
-			},
-		},
-		TransactFunc: &UserEmailsStoreTransactFunc{
-			defaultHook: func(context.Context) (UserEmailsStore, error) {
-				panic("unexpected invocation of MockUserEmailsStore.Transact")
-			},
-		},
-		VerifyFunc: &UserEmailsStoreVerifyFunc{
-			defaultHook: func(context.Context, int32, string, string) (bool, error) {
-				panic("unexpected invocation of MockUserEmailsStore.Verify")
-			},
-		},
-		WithFunc: &UserEmailsStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) UserEmailsStore {
-				panic("unexpected invocation of MockUserEmailsStore.With")
-			},
-		},
-	}
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *UserEmailsStoreListByUserFunc) PushReturn(r0 []*UserEmail, r1 error) {
+	f.PushHook(func(context.Context, UserEmailsListOptions) ([]*UserEmail, error) {
+		return r0, r1
+	})
 }
 
-// NewMockUserEmailsStoreFrom creates a new mock of the MockUserEmailsStore
-// interface. All methods delegate to the given implementation, unless
-// overwritten.
-func NewMockUserEmailsStoreFrom(i UserEmailsStore) *MockUserEmailsStore {
-	return &MockUserEmailsStore{
-		AddFunc: &UserEmailsStoreAddFunc{
-			defaultHook: i.Add,
-		},
-		DoneFunc: &UserEmailsStoreDoneFunc{
-			defaultHook: i.Done,
-		},
-		GetFunc: &UserEmailsStoreGetFunc{
-			defaultHook: i.Get,
-		},
-		GetInitialSiteAdminInfoFunc: &UserEmailsStoreGetInitialSiteAdminInfoFunc{
-			defaultHook: i.GetInitialSiteAdminInfo,
-		},
-		GetLatestVerificationSentEmailFunc: &UserEmailsStoreGetLatestVerificationSentEmailFunc{
-			defaultHook: i.GetLatestVerificationSentEmail,
-		},
-		GetPrimaryEmailFunc: &UserEmailsStoreGetPrimaryEmailFunc{
-			defaultHook: i.GetPrimaryEmail,
-		},
-		GetVerifiedEmailsFunc: &UserEmailsStoreGetVerifiedEmailsFunc{
-			defaultHook: i.GetVerifiedEmails,
-		},
-		HandleFunc: &UserEmailsStoreHandleFunc{
-			defaultHook: i.Handle,
-		},
-		ListByUserFunc: &UserEmailsStoreListByUserFunc{
-			defaultHook: i.ListByUser,
-		},
-		RemoveFunc: &UserEmailsStoreRemoveFunc{
-			defaultHook: i.Remove,
-		},
-		SetLastVerificationFunc: &UserEmailsStoreSetLastVerificationFunc{
-			defaultHook: i.SetLastVerification,
-		},
-		SetPrimaryEmailFunc: &UserEmailsStoreSetPrimaryEmailFunc{
-			defaultHook: i.SetPrimaryEmail,
-		},
-		SetVerifiedFunc: &UserEmailsStoreSetVerifiedFunc{
-			defaultHook: i.SetVerified,
-		},
-		TransactFunc: &UserEmailsStoreTransactFunc{
-			defaultHook: i.Transact,
-		},
-		VerifyFunc: &UserEmailsStoreVerifyFunc{
-			defaultHook: i.Verify,
-		},
-		WithFunc: &UserEmailsStoreWithFunc{
-			defaultHook: i.With,
-		},
+func (f *UserEmailsStoreListByUserFunc) nextHook() func(context.Context, UserEmailsListOptions) ([]*UserEmail, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
 	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
 }
 
-// UserEmailsStoreAddFunc describes the behavior when the Add method of the
-// parent MockUserEmailsStore instance is invoked.
-type UserEmailsStoreAddFunc struct {
-	defaultHook func(context.Context, int32, string, *string) error
-	hooks       []func(context.Context, int32, string, *string) error
-	history     []UserEmailsStoreAddFuncCall
+func (f *UserEmailsStoreListByUserFunc) appendCall(r0 UserEmailsStoreListByUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UserEmailsStoreListByUserFuncCall objects
+// describing the invocations of this function.
+func (f *UserEmailsStoreListByUserFunc) History() []UserEmailsStoreListByUserFuncCall {
+	f.mutex.Lock()
+	history := make([]UserEmailsStoreListByUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserEmailsStoreListByUserFuncCall is an object that describes an
+// invocation of method ListByUser on an instance of MockUserEmailsStore.
+type UserEmailsStoreListByUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 UserEmailsListOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*UserEmail
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserEmailsStoreListByUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserEmailsStoreListByUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UserEmailsStoreRemoveFunc describes the behavior when the Remove method
+// of the parent MockUserEmailsStore instance is invoked.
+type UserEmailsStoreRemoveFunc struct {
+	defaultHook func(context.Context, int32, string) error
+	hooks       []func(context.Context, int32, string) error
+	history     []UserEmailsStoreRemoveFuncCall
 	mutex       sync.Mutex
 }
 
-// Add delegates to the next hook function in the queue and stores the
+// Remove delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserEmailsStore) Add(v0 context.Context, v1 int32, v2 string, v3 *string) error {
-	r0 := m.AddFunc.nextHook()(v0, v1, v2, v3)
-	m.AddFunc.appendCall(UserEmailsStoreAddFuncCall{v0, v1, v2, v3, r0})
+func (m *MockUserEmailsStore) Remove(v0 context.Context, v1 int32, v2 string) error {
+	r0 := m.RemoveFunc.nextHook()(v0, v1, v2)
+	m.RemoveFunc.appendCall(UserEmailsStoreRemoveFuncCall{v0, v1, v2, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Add method of the
+// SetDefaultHook sets function that is called when the Remove method of the
 // parent MockUserEmailsStore instance is invoked and the hook queue is
 // empty.
-func (f *UserEmailsStoreAddFunc) SetDefaultHook(hook func(context.Context, int32, string, *string) error) {
+func (f *UserEmailsStoreRemoveFunc) SetDefaultHook(hook func(context.Context, int32, string) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Add method of the parent MockUserEmailsStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
+// Remove method of the parent MockUserEmailsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *UserEmailsStoreAddFunc) PushHook(hook func(context.Context, int32, string, *string) error) {
+func (f *UserEmailsStoreRemoveFunc) PushHook(hook func(context.Context, int32, string) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31732,21 +35121,21 @@ func (f *UserEmailsStoreAddFunc) PushHook(hook func(context.Context, int32, stri
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreAddFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, string, *string) error {
+func (f *UserEmailsStoreRemoveFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreAddFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, string, *string) error {
+func (f *UserEmailsStoreRemoveFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string) error {
 		return r0
 	})
 }
 
-func (f *UserEmailsStoreAddFunc) nextHook() func(context.Context, int32, string, *string) error {
+func (f *UserEmailsStoreRemoveFunc) nextHook() func(context.Context, int32, string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31759,26 +35148,26 @@ func (f *UserEmailsStoreAddFunc) nextHook() func(context.Context, int32, string,
 	return hook
 }
 
-func (f *UserEmailsStoreAddFunc) appendCall(r0 UserEmailsStoreAddFuncCall) {
+func (f *UserEmailsStoreRemoveFunc) appendCall(r0 UserEmailsStoreRemoveFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreAddFuncCall objects
+// History returns a sequence of UserEmailsStoreRemoveFuncCall objects
 // describing the invocations of this function.
-func (f *UserEmailsStoreAddFunc) History() []UserEmailsStoreAddFuncCall {
+func (f *UserEmailsStoreRemoveFunc) History() []UserEmailsStoreRemoveFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreAddFuncCall, len(f.history))
+	history := make([]UserEmailsStoreRemoveFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreAddFuncCall is an object that describes an invocation of
-// method Add on an instance of MockUserEmailsStore.
-type UserEmailsStoreAddFuncCall struct {
+// UserEmailsStoreRemoveFuncCall is an object that describes an invocation
+// of method Remove on an instance of MockUserEmailsStore.
+type UserEmailsStoreRemoveFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -31788,9 +35177,6 @@ type UserEmailsStoreAddFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 *string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -31798,45 +35184,47 @@ type UserEmailsStoreAddFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreAddFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c UserEmailsStoreRemoveFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreAddFuncCall) Results() []interface{} {
+func (c UserEmailsStoreRemoveFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserEmailsStoreDoneFunc describes the behavior when the Done method of
-// the parent MockUserEmailsStore instance is invoked.
-type UserEmailsStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []UserEmailsStoreDoneFuncCall
+// UserEmailsStoreSetLastVerificationFunc describes the behavior when the
+// SetLastVerification method of the parent MockUserEmailsStore instance is
+// invoked.
+type UserEmailsStoreSetLastVerificationFunc struct {
+	defaultHook func(context.Context, int32, string, string) error
+	hooks       []func(context.Context, int32, string, string) error
+	history     []UserEmailsStoreSetLastVerificationFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserEmailsStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(UserEmailsStoreDoneFuncCall{v0, r0})
+// SetLastVerification delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserEmailsStore) SetLastVerification(v0 context.Context, v1 int32, v2 string, v3 string) error {
+	r0 := m.SetLastVerificationFunc.nextHook()(v0, v1, v2, v3)
+	m.SetLastVerificationFunc.appendCall(UserEmailsStoreSetLastVerificationFuncCall{v0, v1, v2, v3, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
-// parent MockUserEmailsStore instance is invoked and the hook queue is
-// empty.
-func (f *UserEmailsStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+// SetDefaultHook sets function that is called when the SetLastVerification
+// method of the parent MockUserEmailsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserEmailsStoreSetLastVerificationFunc) SetDefaultHook(hook func(context.Context, int32, string, string) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Done method of the parent MockUserEmailsStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *UserEmailsStoreDoneFunc) PushHook(hook func(error) error) {
+// SetLastVerification method of the parent MockUserEmailsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserEmailsStoreSetLastVerificationFunc) PushHook(hook func(context.Context, int32, string, string) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31844,21 +35232,21 @@ func (f *UserEmailsStoreDoneFunc) PushHook(hook func(error) error) {
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreDoneFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(error) error {
+func (f *UserEmailsStoreSetLastVerificationFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, string) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreDoneFunc) PushReturn(r0 error) {
-	f.PushHook(func(error) error {
+func (f *UserEmailsStoreSetLastVerificationFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string, string) error {
 		return r0
 	})
 }
 
-func (f *UserEmailsStoreDoneFunc) nextHook() func(error) error {
+func (f *UserEmailsStoreSetLastVerificationFunc) nextHook() func(context.Context, int32, string, string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31871,29 +35259,39 @@ func (f *UserEmailsStoreDoneFunc) nextHook() func(error) error {
 	return hook
 }
 
-func (f *UserEmailsStoreDoneFunc) appendCall(r0 UserEmailsStoreDoneFuncCall) {
+func (f *UserEmailsStoreSetLastVerificationFunc) appendCall(r0 UserEmailsStoreSetLastVerificationFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreDoneFuncCall objects
-// describing the invocations of this function.
-func (f *UserEmailsStoreDoneFunc) History() []UserEmailsStoreDoneFuncCall {
+// History returns a sequence of UserEmailsStoreSetLastVerificationFuncCall
+// objects describing the invocations of this function.
+func (f *UserEmailsStoreSetLastVerificationFunc) History() []UserEmailsStoreSetLastVerificationFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreDoneFuncCall, len(f.history))
+	history := make([]UserEmailsStoreSetLastVerificationFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreDoneFuncCall is an object that describes an invocation of
-// method Done on an instance of MockUserEmailsStore.
-type UserEmailsStoreDoneFuncCall struct {
+// UserEmailsStoreSetLastVerificationFuncCall is an object that describes an
+// invocation of method SetLastVerification on an instance of
+// MockUserEmailsStore.
+type UserEmailsStoreSetLastVerificationFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 error
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -31901,45 +35299,46 @@ type UserEmailsStoreDoneFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreDoneFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c UserEmailsStoreSetLastVerificationFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreDoneFuncCall) Results() []interface{} {
+func (c UserEmailsStoreSetLastVerificationFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserEmailsStoreGetFunc describes the behavior when the Get method of the
-// parent MockUserEmailsStore instance is invoked.
-type UserEmailsStoreGetFunc struct {
-	defaultHook func(context.Context, int32, string) (string, bool, error)
-	hooks       []func(context.Context, int32, string) (string, bool, error)
-	history     []UserEmailsStoreGetFuncCall
+// UserEmailsStoreSetPrimaryEmailFunc describes the behavior when the
+// SetPrimaryEmail method of the parent MockUserEmailsStore instance is
+// invoked.
+type UserEmailsStoreSetPrimaryEmailFunc struct {
+	defaultHook func(context.Context, int32, string) error
+	hooks       []func(context.Context, int32, string) error
+	history     []UserEmailsStoreSetPrimaryEmailFuncCall
 	mutex       sync.Mutex
 }
 
-// Get delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserEmailsStore) Get(v0 context.Context, v1 int32, v2 string) (string, bool, error) {
-	r0, r1, r2 := m.GetFunc.nextHook()(v0, v1, v2)
-	m.GetFunc.appendCall(UserEmailsStoreGetFuncCall{v0, v1, v2, r0, r1, r2})
-	return r0, r1, r2
+// SetPrimaryEmail delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserEmailsStore) SetPrimaryEmail(v0 context.Context, v1 int32, v2 string) error {
+	r0 := m.SetPrimaryEmailFunc.nextHook()(v0, v1, v2)
+	m.SetPrimaryEmailFunc.appendCall(UserEmailsStoreSetPrimaryEmailFuncCall{v0, v1, v2, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Get method of the
-// parent MockUserEmailsStore instance is invoked and the hook queue is
-// empty.
-func (f *UserEmailsStoreGetFunc) SetDefaultHook(hook func(context.Context, int32, string) (string, bool, error)) {
+// SetDefaultHook sets function that is called when the SetPrimaryEmail
+// method of the parent MockUserEmailsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserEmailsStoreSetPrimaryEmailFunc) SetDefaultHook(hook func(context.Context, int32, string) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Get method of the parent MockUserEmailsStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *UserEmailsStoreGetFunc) PushHook(hook func(context.Context, int32, string) (string, bool, error)) {
+// SetPrimaryEmail method of the parent MockUserEmailsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserEmailsStoreSetPrimaryEmailFunc) PushHook(hook func(context.Context, int32, string) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31947,21 +35346,21 @@ func (f *UserEmailsStoreGetFunc) PushHook(hook func(context.Context, int32, stri
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreGetFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
-	f.SetDefaultHook(func(context.Context, int32, string) (string, bool, error) {
-		return r0, r1, r2
+func (f *UserEmailsStoreSetPrimaryEmailFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreGetFunc) PushReturn(r0 string, r1 bool, r2 error) {
-	f.PushHook(func(context.Context, int32, string) (string, bool, error) {
-		return r0, r1, r2
+func (f *UserEmailsStoreSetPrimaryEmailFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string) error {
+		return r0
 	})
 }
 
-func (f *UserEmailsStoreGetFunc) nextHook() func(context.Context, int32, string) (string, bool, error) {
+func (f *UserEmailsStoreSetPrimaryEmailFunc) nextHook() func(context.Context, int32, string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31974,26 +35373,27 @@ func (f *UserEmailsStoreGetFunc) nextHook() func(context.Context, int32, string)
 	return hook
 }
 
-func (f *UserEmailsStoreGetFunc) appendCall(r0 UserEmailsStoreGetFuncCall) {
+func (f *UserEmailsStoreSetPrimaryEmailFunc) appendCall(r0 UserEmailsStoreSetPrimaryEmailFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreGetFuncCall objects
-// describing the invocations of this function.
-func (f *UserEmailsStoreGetFunc) History() []UserEmailsStoreGetFuncCall {
+// History returns a sequence of UserEmailsStoreSetPrimaryEmailFuncCall
+// objects describing the invocations of this function.
+func (f *UserEmailsStoreSetPrimaryEmailFunc) History() []UserEmailsStoreSetPrimaryEmailFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreGetFuncCall, len(f.history))
+	history := make([]UserEmailsStoreSetPrimaryEmailFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreGetFuncCall is an object that describes an invocation of
-// method Get on an instance of MockUserEmailsStore.
-type UserEmailsStoreGetFuncCall struct {
+// UserEmailsStoreSetPrimaryEmailFuncCall is an object that describes an
+// invocation of method SetPrimaryEmail on an instance of
+// MockUserEmailsStore.
+type UserEmailsStoreSetPrimaryEmailFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -32005,58 +35405,50 @@ type UserEmailsStoreGetFuncCall struct {
 	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 string
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 bool
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreGetFuncCall) Args() []interface{} {
+func (c UserEmailsStoreSetPrimaryEmailFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreGetFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c UserEmailsStoreSetPrimaryEmailFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// UserEmailsStoreGetInitialSiteAdminInfoFunc describes the behavior when
-// the GetInitialSiteAdminInfo method of the parent MockUserEmailsStore
-// instance is invoked.
-type UserEmailsStoreGetInitialSiteAdminInfoFunc struct {
-	defaultHook func(context.Context) (string, bool, error)
-	hooks       []func(context.Context) (string, bool, error)
-	history     []UserEmailsStoreGetInitialSiteAdminInfoFuncCall
+// UserEmailsStoreSetVerifiedFunc describes the behavior when the
+// SetVerified method of the parent MockUserEmailsStore instance is invoked.
+type UserEmailsStoreSetVerifiedFunc struct {
+	defaultHook func(context.Context, int32, string, bool) error
+	hooks       []func(context.Context, int32, string, bool) error
+	history     []UserEmailsStoreSetVerifiedFuncCall
 	mutex       sync.Mutex
 }
 
-// GetInitialSiteAdminInfo delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockUserEmailsStore) GetInitialSiteAdminInfo(v0 context.Context) (string, bool, error) {
-	r0, r1, r2 := m.GetInitialSiteAdminInfoFunc.nextHook()(v0)
-	m.GetInitialSiteAdminInfoFunc.appendCall(UserEmailsStoreGetInitialSiteAdminInfoFuncCall{v0, r0, r1, r2})
-	return r0, r1, r2
+// SetVerified delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUserEmailsStore) SetVerified(v0 context.Context, v1 int32, v2 string, v3 bool) error {
+	r0 := m.SetVerifiedFunc.nextHook()(v0, v1, v2, v3)
+	m.SetVerifiedFunc.appendCall(UserEmailsStoreSetVerifiedFuncCall{v0, v1, v2, v3, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// GetInitialSiteAdminInfo method of the parent MockUserEmailsStore instance
-// is invoked and the hook queue is empty.
-func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) SetDefaultHook(hook func(context.Context) (string, bool, error)) {
+// SetDefaultHook sets function that is called when the SetVerified method
+// of the parent MockUserEmailsStore instance is invoked and the hook queue
+// is empty.
+func (f *UserEmailsStoreSetVerifiedFunc) SetDefaultHook(hook func(context.Context, int32, string, bool) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetInitialSiteAdminInfo method of the parent MockUserEmailsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) PushHook(hook func(context.Context) (string, bool, error)) {
+// SetVerified method of the parent MockUserEmailsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserEmailsStoreSetVerifiedFunc) PushHook(hook func(context.Context, int32, string, bool) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32064,21 +35456,21 @@ func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) PushHook(hook func(context.
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
-	f.SetDefaultHook(func(context.Context) (string, bool, error) {
-		return r0, r1, r2
+func (f *UserEmailsStoreSetVerifiedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, bool) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) PushReturn(r0 string, r1 bool, r2 error) {
-	f.PushHook(func(context.Context) (string, bool, error) {
-		return r0, r1, r2
+func (f *UserEmailsStoreSetVerifiedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string, bool) error {
+		return r0
 	})
 }
 
-func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) nextHook() func(context.Context) (string, bool, error) {
+func (f *UserEmailsStoreSetVerifiedFunc) nextHook() func(context.Context, int32, string, bool) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32091,85 +35483,84 @@ func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) nextHook() func(context.Con
 	return hook
 }
 
-func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) appendCall(r0 UserEmailsStoreGetInitialSiteAdminInfoFuncCall) {
+func (f *UserEmailsStoreSetVerifiedFunc) appendCall(r0 UserEmailsStoreSetVerifiedFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// UserEmailsStoreGetInitialSiteAdminInfoFuncCall objects describing the
-// invocations of this function.
-func (f *UserEmailsStoreGetInitialSiteAdminInfoFunc) History() []UserEmailsStoreGetInitialSiteAdminInfoFuncCall {
+// History returns a sequence of UserEmailsStoreSetVerifiedFuncCall objects
+// describing the invocations of this function.
+func (f *UserEmailsStoreSetVerifiedFunc) History() []UserEmailsStoreSetVerifiedFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreGetInitialSiteAdminInfoFuncCall, len(f.history))
+	history := make([]UserEmailsStoreSetVerifiedFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
-	return history
-}
-
-// UserEmailsStoreGetInitialSiteAdminInfoFuncCall is an object that
-// describes an invocation of method GetInitialSiteAdminInfo on an instance
-// of MockUserEmailsStore.
-type UserEmailsStoreGetInitialSiteAdminInfoFuncCall struct {
+	return history
+}
+
+// UserEmailsStoreSetVerifiedFuncCall is an object that describes an
+// invocation of method SetVerified on an instance of MockUserEmailsStore.
+type UserEmailsStoreSetVerifiedFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Result0 is the value of the 1st result returned from this method
+	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Result0 string
-	// Result1 is the value of the 2nd result returned from this method
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Result1 bool
-	// Result2 is the value of the 3rd result returned from this method
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Result2 error
+	Arg3 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreGetInitialSiteAdminInfoFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c UserEmailsStoreSetVerifiedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreGetInitialSiteAdminInfoFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c UserEmailsStoreSetVerifiedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// UserEmailsStoreGetLatestVerificationSentEmailFunc describes the behavior
-// when the GetLatestVerificationSentEmail method of the parent
-// MockUserEmailsStore instance is invoked.
-type UserEmailsStoreGetLatestVerificationSentEmailFunc struct {
-	defaultHook func(context.Context, string) (*UserEmail, error)
-	hooks       []func(context.Context, string) (*UserEmail, error)
-	history     []UserEmailsStoreGetLatestVerificationSentEmailFuncCall
+// UserEmailsStoreTransactFunc describes the behavior when the Transact
+// method of the parent MockUserEmailsStore instance is invoked.
+type UserEmailsStoreTransactFunc struct {
+	defaultHook func(context.Context) (UserEmailsStore, error)
+	hooks       []func(context.Context) (UserEmailsStore, error)
+	history     []UserEmailsStoreTransactFuncCall
 	mutex       sync.Mutex
 }
 
-// GetLatestVerificationSentEmail delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockUserEmailsStore) GetLatestVerificationSentEmail(v0 context.Context, v1 string) (*UserEmail, error) {
-	r0, r1 := m.GetLatestVerificationSentEmailFunc.nextHook()(v0, v1)
-	m.GetLatestVerificationSentEmailFunc.appendCall(UserEmailsStoreGetLatestVerificationSentEmailFuncCall{v0, v1, r0, r1})
+// Transact delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserEmailsStore) Transact(v0 context.Context) (UserEmailsStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(UserEmailsStoreTransactFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// GetLatestVerificationSentEmail method of the parent MockUserEmailsStore
-// instance is invoked and the hook queue is empty.
-func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) SetDefaultHook(hook func(context.Context, string) (*UserEmail, error)) {
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockUserEmailsStore instance is invoked and the hook queue is
+// empty.
+func (f *UserEmailsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (UserEmailsStore, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetLatestVerificationSentEmail method of the parent MockUserEmailsStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) PushHook(hook func(context.Context, string) (*UserEmail, error)) {
+// Transact method of the parent MockUserEmailsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserEmailsStoreTransactFunc) PushHook(hook func(context.Context) (UserEmailsStore, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32177,21 +35568,21 @@ func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) PushHook(hook func(c
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) SetDefaultReturn(r0 *UserEmail, r1 error) {
-	f.SetDefaultHook(func(context.Context, string) (*UserEmail, error) {
+func (f *UserEmailsStoreTransactFunc) SetDefaultReturn(r0 UserEmailsStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (UserEmailsStore, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) PushReturn(r0 *UserEmail, r1 error) {
-	f.PushHook(func(context.Context, string) (*UserEmail, error) {
+func (f *UserEmailsStoreTransactFunc) PushReturn(r0 UserEmailsStore, r1 error) {
+	f.PushHook(func(context.Context) (UserEmailsStore, error) {
 		return r0, r1
 	})
 }
 
-func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) nextHook() func(context.Context, string) (*UserEmail, error) {
+func (f *UserEmailsStoreTransactFunc) nextHook() func(context.Context) (UserEmailsStore, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32204,37 +35595,32 @@ func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) nextHook() func(cont
 	return hook
 }
 
-func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) appendCall(r0 UserEmailsStoreGetLatestVerificationSentEmailFuncCall) {
+func (f *UserEmailsStoreTransactFunc) appendCall(r0 UserEmailsStoreTransactFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// UserEmailsStoreGetLatestVerificationSentEmailFuncCall objects describing
-// the invocations of this function.
-func (f *UserEmailsStoreGetLatestVerificationSentEmailFunc) History() []UserEmailsStoreGetLatestVerificationSentEmailFuncCall {
+// History returns a sequence of UserEmailsStoreTransactFuncCall objects
+// describing the invocations of this function.
+func (f *UserEmailsStoreTransactFunc) History() []UserEmailsStoreTransactFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreGetLatestVerificationSentEmailFuncCall, len(f.history))
+	history := make([]UserEmailsStoreTransactFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreGetLatestVerificationSentEmailFuncCall is an object that
-// describes an invocation of method GetLatestVerificationSentEmail on an
-// instance of MockUserEmailsStore.
-type UserEmailsStoreGetLatestVerificationSentEmailFuncCall struct {
+// UserEmailsStoreTransactFuncCall is an object that describes an invocation
+// of method Transact on an instance of MockUserEmailsStore.
+type UserEmailsStoreTransactFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *UserEmail
+	Result0 UserEmailsStore
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -32242,46 +35628,45 @@ type UserEmailsStoreGetLatestVerificationSentEmailFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreGetLatestVerificationSentEmailFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c UserEmailsStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreGetLatestVerificationSentEmailFuncCall) Results() []interface{} {
+func (c UserEmailsStoreTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserEmailsStoreGetPrimaryEmailFunc describes the behavior when the
-// GetPrimaryEmail method of the parent MockUserEmailsStore instance is
-// invoked.
-type UserEmailsStoreGetPrimaryEmailFunc struct {
-	defaultHook func(context.Context, int32) (string, bool, error)
-	hooks       []func(context.Context, int32) (string, bool, error)
-	history     []UserEmailsStoreGetPrimaryEmailFuncCall
+// UserEmailsStoreVerifyFunc describes the behavior when the Verify method
+// of the parent MockUserEmailsStore instance is invoked.
+type UserEmailsStoreVerifyFunc struct {
+	defaultHook func(context.Context, int32, string, string) (bool, error)
+	hooks       []func(context.Context, int32, string, string) (bool, error)
+	history     []UserEmailsStoreVerifyFuncCall
 	mutex       sync.Mutex
 }
 
-// GetPrimaryEmail delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockUserEmailsStore) GetPrimaryEmail(v0 context.Context, v1 int32) (string, bool, error) {
-	r0, r1, r2 := m.GetPrimaryEmailFunc.nextHook()(v0, v1)
-	m.GetPrimaryEmailFunc.appendCall(UserEmailsStoreGetPrimaryEmailFuncCall{v0, v1, r0, r1, r2})
-	return r0, r1, r2
+// Verify delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserEmailsStore) Verify(v0 context.Context, v1 int32, v2 string, v3 string) (bool, error) {
+	r0, r1 := m.VerifyFunc.nextHook()(v0, v1, v2, v3)
+	m.VerifyFunc.appendCall(UserEmailsStoreVerifyFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetPrimaryEmail
-// method of the parent MockUserEmailsStore instance is invoked and the hook
-// queue is empty.
-func (f *UserEmailsStoreGetPrimaryEmailFunc) SetDefaultHook(hook func(context.Context, int32) (string, bool, error)) {
+// SetDefaultHook sets function that is called when the Verify method of the
+// parent MockUserEmailsStore instance is invoked and the hook queue is
+// empty.
+func (f *UserEmailsStoreVerifyFunc) SetDefaultHook(hook func(context.Context, int32, string, string) (bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetPrimaryEmail method of the parent MockUserEmailsStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *UserEmailsStoreGetPrimaryEmailFunc) PushHook(hook func(context.Context, int32) (string, bool, error)) {
+// Verify method of the parent MockUserEmailsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UserEmailsStoreVerifyFunc) PushHook(hook func(context.Context, int32, string, string) (bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32289,21 +35674,21 @@ func (f *UserEmailsStoreGetPrimaryEmailFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreGetPrimaryEmailFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
-	f.SetDefaultHook(func(context.Context, int32) (string, bool, error) {
-		return r0, r1, r2
+func (f *UserEmailsStoreVerifyFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, string) (bool, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreGetPrimaryEmailFunc) PushReturn(r0 string, r1 bool, r2 error) {
-	f.PushHook(func(context.Context, int32) (string, bool, error) {
-		return r0, r1, r2
+func (f *UserEmailsStoreVerifyFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int32, string, string) (bool, error) {
+		return r0, r1
 	})
 }
 
-func (f *UserEmailsStoreGetPrimaryEmailFunc) nextHook() func(context.Context, int32) (string, bool, error) {
+func (f *UserEmailsStoreVerifyFunc) nextHook() func(context.Context, int32, string, string) (bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32316,87 +35701,87 @@ func (f *UserEmailsStoreGetPrimaryEmailFunc) nextHook() func(context.Context, in
 	return hook
 }
 
-func (f *UserEmailsStoreGetPrimaryEmailFunc) appendCall(r0 UserEmailsStoreGetPrimaryEmailFuncCall) {
+func (f *UserEmailsStoreVerifyFunc) appendCall(r0 UserEmailsStoreVerifyFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreGetPrimaryEmailFuncCall
-// objects describing the invocations of this function.
-func (f *UserEmailsStoreGetPrimaryEmailFunc) History() []UserEmailsStoreGetPrimaryEmailFuncCall {
+// History returns a sequence of UserEmailsStoreVerifyFuncCall objects
+// describing the invocations of this function.
+func (f *UserEmailsStoreVerifyFunc) History() []UserEmailsStoreVerifyFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreGetPrimaryEmailFuncCall, len(f.history))
+	history := make([]UserEmailsStoreVerifyFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreGetPrimaryEmailFuncCall is an object that describes an
-// invocation of method GetPrimaryEmail on an instance of
-// MockUserEmailsStore.
-type UserEmailsStoreGetPrimaryEmailFuncCall struct {
+// UserEmailsStoreVerifyFuncCall is an object that describes an invocation
+// of method Verify on an instance of MockUserEmailsStore.
+type UserEmailsStoreVerifyFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 string
+	Result0 bool
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 bool
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreGetPrimaryEmailFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c UserEmailsStoreVerifyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreGetPrimaryEmailFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c UserEmailsStoreVerifyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserEmailsStoreGetVerifiedEmailsFunc describes the behavior when the
-// GetVerifiedEmails method of the parent MockUserEmailsStore instance is
-// invoked.
-type UserEmailsStoreGetVerifiedEmailsFunc struct {
-	defaultHook func(context.Context, ...string) ([]*UserEmail, error)
-	hooks       []func(context.Context, ...string) ([]*UserEmail, error)
-	history     []UserEmailsStoreGetVerifiedEmailsFuncCall
+// UserEmailsStoreWithFunc describes the behavior when the With method of
+// the parent MockUserEmailsStore instance is invoked.
+type UserEmailsStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) UserEmailsStore
+	hooks       []func(basestore.ShareableStore) UserEmailsStore
+	history     []UserEmailsStoreWithFuncCall
 	mutex       sync.Mutex
 }
 
-// GetVerifiedEmails delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockUserEmailsStore) GetVerifiedEmails(v0 context.Context, v1 ...string) ([]*UserEmail, error) {
-	r0, r1 := m.GetVerifiedEmailsFunc.nextHook()(v0, v1...)
-	m.GetVerifiedEmailsFunc.appendCall(UserEmailsStoreGetVerifiedEmailsFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserEmailsStore) With(v0 basestore.ShareableStore) UserEmailsStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(UserEmailsStoreWithFuncCall{v0, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the GetVerifiedEmails
-// method of the parent MockUserEmailsStore instance is invoked and the hook
-// queue is empty.
-func (f *UserEmailsStoreGetVerifiedEmailsFunc) SetDefaultHook(hook func(context.Context, ...string) ([]*UserEmail, error)) {
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockUserEmailsStore instance is invoked and the hook queue is
+// empty.
+func (f *UserEmailsStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) UserEmailsStore) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetVerifiedEmails method of the parent MockUserEmailsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *UserEmailsStoreGetVerifiedEmailsFunc) PushHook(hook func(context.Context, ...string) ([]*UserEmail, error)) {
+// With method of the parent MockUserEmailsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UserEmailsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) UserEmailsStore) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32404,21 +35789,21 @@ func (f *UserEmailsStoreGetVerifiedEmailsFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreGetVerifiedEmailsFunc) SetDefaultReturn(r0 []*UserEmail, r1 error) {
-	f.SetDefaultHook(func(context.Context, ...string) ([]*UserEmail, error) {
-		return r0, r1
+func (f *UserEmailsStoreWithFunc) SetDefaultReturn(r0 UserEmailsStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) UserEmailsStore {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreGetVerifiedEmailsFunc) PushReturn(r0 []*UserEmail, r1 error) {
-	f.PushHook(func(context.Context, ...string) ([]*UserEmail, error) {
-		return r0, r1
+func (f *UserEmailsStoreWithFunc) PushReturn(r0 UserEmailsStore) {
+	f.PushHook(func(basestore.ShareableStore) UserEmailsStore {
+		return r0
 	})
 }
 
-func (f *UserEmailsStoreGetVerifiedEmailsFunc) nextHook() func(context.Context, ...string) ([]*UserEmail, error) {
+func (f *UserEmailsStoreWithFunc) nextHook() func(basestore.ShareableStore) UserEmailsStore {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32431,89 +35816,396 @@ func (f *UserEmailsStoreGetVerifiedEmailsFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *UserEmailsStoreGetVerifiedEmailsFunc) appendCall(r0 UserEmailsStoreGetVerifiedEmailsFuncCall) {
+func (f *UserEmailsStoreWithFunc) appendCall(r0 UserEmailsStoreWithFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreGetVerifiedEmailsFuncCall
-// objects describing the invocations of this function.
-func (f *UserEmailsStoreGetVerifiedEmailsFunc) History() []UserEmailsStoreGetVerifiedEmailsFuncCall {
+// History returns a sequence of UserEmailsStoreWithFuncCall objects
+// describing the invocations of this function.
+func (f *UserEmailsStoreWithFunc) History() []UserEmailsStoreWithFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreGetVerifiedEmailsFuncCall, len(f.history))
+	history := make([]UserEmailsStoreWithFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreGetVerifiedEmailsFuncCall is an object that describes an
-// invocation of method GetVerifiedEmails on an instance of
-// MockUserEmailsStore.
-type UserEmailsStoreGetVerifiedEmailsFuncCall struct {
+// UserEmailsStoreWithFuncCall is an object that describes an invocation of
+// method With on an instance of MockUserEmailsStore.
+type UserEmailsStoreWithFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 context.Context
-	// Arg1 is a slice containing the values of the variadic arguments
-	// passed to this method invocation.
-	Arg1 []string
+	Arg0 basestore.ShareableStore
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*UserEmail
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 UserEmailsStore
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation. The variadic slice argument is flattened in this array such
-// that one positional argument and three variadic arguments would result in
-// a slice of four, not two.
-func (c UserEmailsStoreGetVerifiedEmailsFuncCall) Args() []interface{} {
-	trailing := []interface{}{}
-	for _, val := range c.Arg1 {
-		trailing = append(trailing, val)
+// invocation.
+func (c UserEmailsStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserEmailsStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockUserExternalAccountsStore is a mock implementation of the
+// UserExternalAccountsStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockUserExternalAccountsStore struct {
+	// AssociateUserAndSaveFunc is an instance of a mock function object
+	// controlling the behavior of the method AssociateUserAndSave.
+	AssociateUserAndSaveFunc *UserExternalAccountsStoreAssociateUserAndSaveFunc
+	// CountFunc is an instance of a mock function object controlling the
+	// behavior of the method Count.
+	CountFunc *UserExternalAccountsStoreCountFunc
+	// CreateUserAndSaveFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateUserAndSave.
+	CreateUserAndSaveFunc *UserExternalAccountsStoreCreateUserAndSaveFunc
+	// DeleteFunc is an instance of a mock function object controlling the
+	// behavior of the method Delete.
+	DeleteFunc *UserExternalAccountsStoreDeleteFunc
+	// DoneFunc is an instance of a mock function object controlling the
+	// behavior of the method Done.
+	DoneFunc *UserExternalAccountsStoreDoneFunc
+	// ExecResultFunc is an instance of a mock function object controlling
+	// the behavior of the method ExecResult.
+	ExecResultFunc *UserExternalAccountsStoreExecResultFunc
+	// GetFunc is an instance of a mock function object controlling the
+	// behavior of the method Get.
+	GetFunc *UserExternalAccountsStoreGetFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *UserExternalAccountsStoreHandleFunc
+	// InsertFunc is an instance of a mock function object controlling the
+	// behavior of the method Insert.
+	InsertFunc *UserExternalAccountsStoreInsertFunc
+	// ListFunc is an instance of a mock function object controlling the
+	// behavior of the method List.
+	ListFunc *UserExternalAccountsStoreListFunc
+	// ListBySQLFunc is an instance of a mock function object controlling
+	// the behavior of the method ListBySQL.
+	ListBySQLFunc *UserExternalAccountsStoreListBySQLFunc
+	// LookupUserAndSaveFunc is an instance of a mock function object
+	// controlling the behavior of the method LookupUserAndSave.
+	LookupUserAndSaveFunc *UserExternalAccountsStoreLookupUserAndSaveFunc
+	// QueryRowFunc is an instance of a mock function object controlling the
+	// behavior of the method QueryRow.
+	QueryRowFunc *UserExternalAccountsStoreQueryRowFunc
+	// TouchExpiredFunc is an instance of a mock function object controlling
+	// the behavior of the method TouchExpired.
+	TouchExpiredFunc *UserExternalAccountsStoreTouchExpiredFunc
+	// TouchLastValidFunc is an instance of a mock function object
+	// controlling the behavior of the method TouchLastValid.
+	TouchLastValidFunc *UserExternalAccountsStoreTouchLastValidFunc
+	// TransactFunc is an instance of a mock function object controlling the
+	// behavior of the method Transact.
+	TransactFunc *UserExternalAccountsStoreTransactFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *UserExternalAccountsStoreWithFunc
+	// WithEncryptionKeyFunc is an instance of a mock function object
+	// controlling the behavior of the method WithEncryptionKey.
+	WithEncryptionKeyFunc *UserExternalAccountsStoreWithEncryptionKeyFunc
+}
+
+// NewMockUserExternalAccountsStore creates a new mock of the
+// UserExternalAccountsStore interface. All methods return zero values for
+// all results, unless overwritten.
+func NewMockUserExternalAccountsStore() *MockUserExternalAccountsStore {
+	return &MockUserExternalAccountsStore{
+		AssociateUserAndSaveFunc: &UserExternalAccountsStoreAssociateUserAndSaveFunc{
+			defaultHook: func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
+				return nil
+			},
+		},
+		CountFunc: &UserExternalAccountsStoreCountFunc{
+			defaultHook: func(context.Context, ExternalAccountsListOptions) (int, error) {
+				return 0, nil
+			},
+		},
+		CreateUserAndSaveFunc: &UserExternalAccountsStoreCreateUserAndSaveFunc{
+			defaultHook: func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+				return 0, nil
+			},
+		},
+		DeleteFunc: &UserExternalAccountsStoreDeleteFunc{
+			defaultHook: func(context.Context, int32) error {
+				return nil
+			},
+		},
+		DoneFunc: &UserExternalAccountsStoreDoneFunc{
+			defaultHook: func(error) error {
+				return nil
+			},
+		},
+		ExecResultFunc: &UserExternalAccountsStoreExecResultFunc{
+			defaultHook: func(context.Context, *sqlf.Query) (sql.Result, error) {
+				return nil, nil
+			},
+		},
+		GetFunc: &UserExternalAccountsStoreGetFunc{
+			defaultHook: func(context.Context, int32) (*extsvc.Account, error) {
+				return nil, nil
+			},
+		},
+		HandleFunc: &UserExternalAccountsStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				return nil
+			},
+		},
+		InsertFunc: &UserExternalAccountsStoreInsertFunc{
+			defaultHook: func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
+				return nil
+			},
+		},
+		ListFunc: &UserExternalAccountsStoreListFunc{
+			defaultHook: func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+				return nil, nil
+			},
+		},
+		ListBySQLFunc: &UserExternalAccountsStoreListBySQLFunc{
+			defaultHook: func(context.Context, *sqlf.Query) ([]*extsvc.Account, error) {
+				return nil, nil
+			},
+		},
+		LookupUserAndSaveFunc: &UserExternalAccountsStoreLookupUserAndSaveFunc{
+			defaultHook: func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+				return 0, nil
+			},
+		},
+		QueryRowFunc: &UserExternalAccountsStoreQueryRowFunc{
+			defaultHook: func(context.Context, *sqlf.Query) *sql.Row {
+				return nil
+			},
+		},
+		TouchExpiredFunc: &UserExternalAccountsStoreTouchExpiredFunc{
+			defaultHook: func(context.Context, int32) error {
+				return nil
+			},
+		},
+		TouchLastValidFunc: &UserExternalAccountsStoreTouchLastValidFunc{
+			defaultHook: func(context.Context, int32) error {
+				return nil
+			},
+		},
+		TransactFunc: &UserExternalAccountsStoreTransactFunc{
+			defaultHook: func(context.Context) (UserExternalAccountsStore, error) {
+				return nil, nil
+			},
+		},
+		WithFunc: &UserExternalAccountsStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) UserExternalAccountsStore {
+				return nil
+			},
+		},
+		WithEncryptionKeyFunc: &UserExternalAccountsStoreWithEncryptionKeyFunc{
+			defaultHook: func(encryption.Key) UserExternalAccountsStore {
+				return nil
+			},
+		},
+	}
+}
+
+// NewStrictMockUserExternalAccountsStore creates a new mock of the
+// UserExternalAccountsStore interface. All methods panic on invocation,
+// unless overwritten.
+func NewStrictMockUserExternalAccountsStore() *MockUserExternalAccountsStore {
+	return &MockUserExternalAccountsStore{
+		AssociateUserAndSaveFunc: &UserExternalAccountsStoreAssociateUserAndSaveFunc{
+			defaultHook: func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
+				panic("unexpected invocation of MockUserExternalAccountsStore.AssociateUserAndSave")
+			},
+		},
+		CountFunc: &UserExternalAccountsStoreCountFunc{
+			defaultHook: func(context.Context, ExternalAccountsListOptions) (int, error) {
+				panic("unexpected invocation of MockUserExternalAccountsStore.Count")
+			},
+		},
+		CreateUserAndSaveFunc: &UserExternalAccountsStoreCreateUserAndSaveFunc{
+			defaultHook: func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+				panic("unexpected invocation of MockUserExternalAccountsStore.CreateUserAndSave")
+			},
+		},
+		DeleteFunc: &UserExternalAccountsStoreDeleteFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockUserExternalAccountsStore.Delete")
+			},
+		},
+		DoneFunc: &UserExternalAccountsStoreDoneFunc{
+			defaultHook: func(error) error {
+				panic("unexpected invocation of MockUserExternalAccountsStore.Done")
+			},
+		},
+		ExecResultFunc: &UserExternalAccountsStoreExecResultFunc{
+			defaultHook: func(context.Context, *sqlf.Query) (sql.Result, error) {
+				panic("unexpected invocation of MockUserExternalAccountsStore.ExecResult")
+			},
+		},
+		GetFunc: &UserExternalAccountsStoreGetFunc{
+			defaultHook: func(context.Context, int32) (*extsvc.Account, error) {
+				panic("unexpected invocation of MockUserExternalAccountsStore.Get")
+			},
+		},
+		HandleFunc: &UserExternalAccountsStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				panic("unexpected invocation of MockUserExternalAccountsStore.Handle")
+			},
+		},
+		InsertFunc: &UserExternalAccountsStoreInsertFunc{
+			defaultHook: func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
+				panic("unexpected invocation of MockUserExternalAccountsStore.Insert")
+			},
+		},
+		ListFunc: &UserExternalAccountsStoreListFunc{
+			defaultHook: func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+				panic("unexpected invocation of MockUserExternalAccountsStore.List")
+			},
+		},
+		ListBySQLFunc: &UserExternalAccountsStoreListBySQLFunc{
+			defaultHook: func(context.Context, *sqlf.Query) ([]*extsvc.Account, error) {
+				panic("unexpected invocation of MockUserExternalAccountsStore.ListBySQL")
+			},
+		},
+		LookupUserAndSaveFunc: &UserExternalAccountsStoreLookupUserAndSaveFunc{
+			defaultHook: func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+				panic("unexpected invocation of MockUserExternalAccountsStore.LookupUserAndSave")
+			},
+		},
+		QueryRowFunc: &UserExternalAccountsStoreQueryRowFunc{
+			defaultHook: func(context.Context, *sqlf.Query) *sql.Row {
+				panic("unexpected invocation of MockUserExternalAccountsStore.QueryRow")
+			},
+		},
+		TouchExpiredFunc: &UserExternalAccountsStoreTouchExpiredFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockUserExternalAccountsStore.TouchExpired")
+			},
+		},
+		TouchLastValidFunc: &UserExternalAccountsStoreTouchLastValidFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockUserExternalAccountsStore.TouchLastValid")
+			},
+		},
+		TransactFunc: &UserExternalAccountsStoreTransactFunc{
+			defaultHook: func(context.Context) (UserExternalAccountsStore, error) {
+				panic("unexpected invocation of MockUserExternalAccountsStore.Transact")
+			},
+		},
+		WithFunc: &UserExternalAccountsStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) UserExternalAccountsStore {
+				panic("unexpected invocation of MockUserExternalAccountsStore.With")
+			},
+		},
+		WithEncryptionKeyFunc: &UserExternalAccountsStoreWithEncryptionKeyFunc{
+			defaultHook: func(encryption.Key) UserExternalAccountsStore {
+				panic("unexpected invocation of MockUserExternalAccountsStore.WithEncryptionKey")
+			},
+		},
 	}
-
-	return append([]interface{}{c.Arg0}, trailing...)
 }
 
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c UserEmailsStoreGetVerifiedEmailsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+// NewMockUserExternalAccountsStoreFrom creates a new mock of the
+// MockUserExternalAccountsStore interface. All methods delegate to the
+// given implementation, unless overwritten.
+func NewMockUserExternalAccountsStoreFrom(i UserExternalAccountsStore) *MockUserExternalAccountsStore {
+	return &MockUserExternalAccountsStore{
+		AssociateUserAndSaveFunc: &UserExternalAccountsStoreAssociateUserAndSaveFunc{
+			defaultHook: i.AssociateUserAndSave,
+		},
+		CountFunc: &UserExternalAccountsStoreCountFunc{
+			defaultHook: i.Count,
+		},
+		CreateUserAndSaveFunc: &UserExternalAccountsStoreCreateUserAndSaveFunc{
+			defaultHook: i.CreateUserAndSave,
+		},
+		DeleteFunc: &UserExternalAccountsStoreDeleteFunc{
+			defaultHook: i.Delete,
+		},
+		DoneFunc: &UserExternalAccountsStoreDoneFunc{
+			defaultHook: i.Done,
+		},
+		ExecResultFunc: &UserExternalAccountsStoreExecResultFunc{
+			defaultHook: i.ExecResult,
+		},
+		GetFunc: &UserExternalAccountsStoreGetFunc{
+			defaultHook: i.Get,
+		},
+		HandleFunc: &UserExternalAccountsStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		InsertFunc: &UserExternalAccountsStoreInsertFunc{
+			defaultHook: i.Insert,
+		},
+		ListFunc: &UserExternalAccountsStoreListFunc{
+			defaultHook: i.List,
+		},
+		ListBySQLFunc: &UserExternalAccountsStoreListBySQLFunc{
+			defaultHook: i.ListBySQL,
+		},
+		LookupUserAndSaveFunc: &UserExternalAccountsStoreLookupUserAndSaveFunc{
+			defaultHook: i.LookupUserAndSave,
+		},
+		QueryRowFunc: &UserExternalAccountsStoreQueryRowFunc{
+			defaultHook: i.QueryRow,
+		},
+		TouchExpiredFunc: &UserExternalAccountsStoreTouchExpiredFunc{
+			defaultHook: i.TouchExpired,
+		},
+		TouchLastValidFunc: &UserExternalAccountsStoreTouchLastValidFunc{
+			defaultHook: i.TouchLastValid,
+		},
+		TransactFunc: &UserExternalAccountsStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		WithFunc: &UserExternalAccountsStoreWithFunc{
+			defaultHook: i.With,
+		},
+		WithEncryptionKeyFunc: &UserExternalAccountsStoreWithEncryptionKeyFunc{
+			defaultHook: i.WithEncryptionKey,
+		},
+	}
 }
 
-// UserEmailsStoreHandleFunc describes the behavior when the Handle method
-// of the parent MockUserEmailsStore instance is invoked.
-type UserEmailsStoreHandleFunc struct {
-	defaultHook func() *basestore.TransactableHandle
-	hooks       []func() *basestore.TransactableHandle
-	history     []UserEmailsStoreHandleFuncCall
+// UserExternalAccountsStoreAssociateUserAndSaveFunc describes the behavior
+// when the AssociateUserAndSave method of the parent
+// MockUserExternalAccountsStore instance is invoked.
+type UserExternalAccountsStoreAssociateUserAndSaveFunc struct {
+	defaultHook func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error
+	hooks       []func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error
+	history     []UserExternalAccountsStoreAssociateUserAndSaveFuncCall
 	mutex       sync.Mutex
 }
 
-// Handle delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserEmailsStore) Handle() *basestore.TransactableHandle {
-	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(UserEmailsStoreHandleFuncCall{r0})
+// AssociateUserAndSave delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserExternalAccountsStore) AssociateUserAndSave(v0 context.Context, v1 int32, v2 extsvc.AccountSpec, v3 extsvc.AccountData) error {
+	r0 := m.AssociateUserAndSaveFunc.nextHook()(v0, v1, v2, v3)
+	m.AssociateUserAndSaveFunc.appendCall(UserExternalAccountsStoreAssociateUserAndSaveFuncCall{v0, v1, v2, v3, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Handle method of the
-// parent MockUserEmailsStore instance is invoked and the hook queue is
-// empty.
-func (f *UserEmailsStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
+// SetDefaultHook sets function that is called when the AssociateUserAndSave
+// method of the parent MockUserExternalAccountsStore instance is invoked
+// and the hook queue is empty.
+func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) SetDefaultHook(hook func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockUserEmailsStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *UserEmailsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
+// AssociateUserAndSave method of the parent MockUserExternalAccountsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) PushHook(hook func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32521,21 +36213,21 @@ func (f *UserEmailsStoreHandleFunc) PushHook(hook func() *basestore.Transactable
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
-	f.SetDefaultHook(func() *basestore.TransactableHandle {
+func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
-	f.PushHook(func() *basestore.TransactableHandle {
+func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
 		return r0
 	})
 }
 
-func (f *UserEmailsStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
+func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) nextHook() func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32548,72 +36240,86 @@ func (f *UserEmailsStoreHandleFunc) nextHook() func() *basestore.TransactableHan
 	return hook
 }
 
-func (f *UserEmailsStoreHandleFunc) appendCall(r0 UserEmailsStoreHandleFuncCall) {
+func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) appendCall(r0 UserExternalAccountsStoreAssociateUserAndSaveFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreHandleFuncCall objects
-// describing the invocations of this function.
-func (f *UserEmailsStoreHandleFunc) History() []UserEmailsStoreHandleFuncCall {
+// History returns a sequence of
+// UserExternalAccountsStoreAssociateUserAndSaveFuncCall objects describing
+// the invocations of this function.
+func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) History() []UserExternalAccountsStoreAssociateUserAndSaveFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreHandleFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreAssociateUserAndSaveFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreHandleFuncCall is an object that describes an invocation
-// of method Handle on an instance of MockUserEmailsStore.
-type UserEmailsStoreHandleFuncCall struct {
+// UserExternalAccountsStoreAssociateUserAndSaveFuncCall is an object that
+// describes an invocation of method AssociateUserAndSave on an instance of
+// MockUserExternalAccountsStore.
+type UserExternalAccountsStoreAssociateUserAndSaveFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 extsvc.AccountSpec
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 extsvc.AccountData
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *basestore.TransactableHandle
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreHandleFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c UserExternalAccountsStoreAssociateUserAndSaveFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreHandleFuncCall) Results() []interface{} {
+func (c UserExternalAccountsStoreAssociateUserAndSaveFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserEmailsStoreListByUserFunc describes the behavior when the ListByUser
-// method of the parent MockUserEmailsStore instance is invoked.
-type UserEmailsStoreListByUserFunc struct {
-	defaultHook func(context.Context, UserEmailsListOptions) ([]*UserEmail, error)
-	hooks       []func(context.Context, UserEmailsListOptions) ([]*UserEmail, error)
-	history     []UserEmailsStoreListByUserFuncCall
+// UserExternalAccountsStoreCountFunc describes the behavior when the Count
+// method of the parent MockUserExternalAccountsStore instance is invoked.
+type UserExternalAccountsStoreCountFunc struct {
+	defaultHook func(context.Context, ExternalAccountsListOptions) (int, error)
+	hooks       []func(context.Context, ExternalAccountsListOptions) (int, error)
+	history     []UserExternalAccountsStoreCountFuncCall
 	mutex       sync.Mutex
 }
 
-// ListByUser delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockUserEmailsStore) ListByUser(v0 context.Context, v1 UserEmailsListOptions) ([]*UserEmail, error) {
-	r0, r1 := m.ListByUserFunc.nextHook()(v0, v1)
-	m.ListByUserFunc.appendCall(UserEmailsStoreListByUserFuncCall{v0, v1, r0, r1})
+// Count delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserExternalAccountsStore) Count(v0 context.Context, v1 ExternalAccountsListOptions) (int, error) {
+	r0, r1 := m.CountFunc.nextHook()(v0, v1)
+	m.CountFunc.appendCall(UserExternalAccountsStoreCountFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListByUser method of
-// the parent MockUserEmailsStore instance is invoked and the hook queue is
-// empty.
-func (f *UserEmailsStoreListByUserFunc) SetDefaultHook(hook func(context.Context, UserEmailsListOptions) ([]*UserEmail, error)) {
+// SetDefaultHook sets function that is called when the Count method of the
+// parent MockUserExternalAccountsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserExternalAccountsStoreCountFunc) SetDefaultHook(hook func(context.Context, ExternalAccountsListOptions) (int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListByUser method of the parent MockUserEmailsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *UserEmailsStoreListByUserFunc) PushHook(hook func(context.Context, UserEmailsListOptions) ([]*UserEmail, error)) {
+// Count method of the parent MockUserExternalAccountsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserExternalAccountsStoreCountFunc) PushHook(hook func(context.Context, ExternalAccountsListOptions) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32621,21 +36327,21 @@ func (f *UserEmailsStoreListByUserFunc) PushHook(hook func(context.Context, User
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreListByUserFunc) SetDefaultReturn(r0 []*UserEmail, r1 error) {
-	f.SetDefaultHook(func(context.Context, UserEmailsListOptions) ([]*UserEmail, error) {
+func (f *UserExternalAccountsStoreCountFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, ExternalAccountsListOptions) (int, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreListByUserFunc) PushReturn(r0 []*UserEmail, r1 error) {
-	f.PushHook(func(context.Context, UserEmailsListOptions) ([]*UserEmail, error) {
+func (f *UserExternalAccountsStoreCountFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, ExternalAccountsListOptions) (int, error) {
 		return r0, r1
 	})
 }
 
-func (f *UserEmailsStoreListByUserFunc) nextHook() func(context.Context, UserEmailsListOptions) ([]*UserEmail, error) {
+func (f *UserExternalAccountsStoreCountFunc) nextHook() func(context.Context, ExternalAccountsListOptions) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32648,35 +36354,36 @@ func (f *UserEmailsStoreListByUserFunc) nextHook() func(context.Context, UserEma
 	return hook
 }
 
-func (f *UserEmailsStoreListByUserFunc) appendCall(r0 UserEmailsStoreListByUserFuncCall) {
+func (f *UserExternalAccountsStoreCountFunc) appendCall(r0 UserExternalAccountsStoreCountFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreListByUserFuncCall objects
-// describing the invocations of this function.
-func (f *UserEmailsStoreListByUserFunc) History() []UserEmailsStoreListByUserFuncCall {
+// History returns a sequence of UserExternalAccountsStoreCountFuncCall
+// objects describing the invocations of this function.
+func (f *UserExternalAccountsStoreCountFunc) History() []UserExternalAccountsStoreCountFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreListByUserFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreCountFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreListByUserFuncCall is an object that describes an
-// invocation of method ListByUser on an instance of MockUserEmailsStore.
-type UserEmailsStoreListByUserFuncCall struct {
+// UserExternalAccountsStoreCountFuncCall is an object that describes an
+// invocation of method Count on an instance of
+// MockUserExternalAccountsStore.
+type UserExternalAccountsStoreCountFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 UserEmailsListOptions
+	Arg1 ExternalAccountsListOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*UserEmail
+	Result0 int
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -32684,156 +36391,47 @@ type UserEmailsStoreListByUserFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreListByUserFuncCall) Args() []interface{} {
+func (c UserExternalAccountsStoreCountFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreListByUserFuncCall) Results() []interface{} {
+func (c UserExternalAccountsStoreCountFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserEmailsStoreRemoveFunc describes the behavior when the Remove method
-// of the parent MockUserEmailsStore instance is invoked.
-type UserEmailsStoreRemoveFunc struct {
-	defaultHook func(context.Context, int32, string) error
-	hooks       []func(context.Context, int32, string) error
-	history     []UserEmailsStoreRemoveFuncCall
-	mutex       sync.Mutex
-}
-
-// Remove delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserEmailsStore) Remove(v0 context.Context, v1 int32, v2 string) error {
-	r0 := m.RemoveFunc.nextHook()(v0, v1, v2)
-	m.RemoveFunc.appendCall(UserEmailsStoreRemoveFuncCall{v0, v1, v2, r0})
-	return r0
-}
-
-// SetDefaultHook sets function that is called when the Remove method of the
-// parent MockUserEmailsStore instance is invoked and the hook queue is
-// empty.
-func (f *UserEmailsStoreRemoveFunc) SetDefaultHook(hook func(context.Context, int32, string) error) {
-	f.defaultHook = hook
-}
-
-// PushHook adds a function to the end of hook queue. Each invocation of the
-// Remove method of the parent MockUserEmailsStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *UserEmailsStoreRemoveFunc) PushHook(hook func(context.Context, int32, string) error) {
-	f.mutex.Lock()
-	f.hooks = append(f.hooks, hook)
-	f.mutex.Unlock()
-}
-
-// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
-// the given values.
-func (f *UserEmailsStoreRemoveFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, string) error {
-		return r0
-	})
-}
-
-// PushReturn calls PushDefaultHook with a function that returns the given
-// values.
-func (f *UserEmailsStoreRemoveFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, string) error {
-		return r0
-	})
-}
-
-func (f *UserEmailsStoreRemoveFunc) nextHook() func(context.Context, int32, string) error {
-	f.mutex.Lock()
-	defer f.mutex.Unlock()
-
-	if len(f.hooks) == 0 {
-		return f.defaultHook
-	}
-
-	hook := f.hooks[0]
-	f.hooks = f.hooks[1:]
-	return hook
-}
-
-func (f *UserEmailsStoreRemoveFunc) appendCall(r0 UserEmailsStoreRemoveFuncCall) {
-	f.mutex.Lock()
-	f.history = append(f.history, r0)
-	f.mutex.Unlock()
-}
-
-// History returns a sequence of UserEmailsStoreRemoveFuncCall objects
-// describing the invocations of this function.
-func (f *UserEmailsStoreRemoveFunc) History() []UserEmailsStoreRemoveFuncCall {
-	f.mutex.Lock()
-	history := make([]UserEmailsStoreRemoveFuncCall, len(f.history))
-	copy(history, f.history)
-	f.mutex.Unlock()
-
-	return history
-}
-
-// UserEmailsStoreRemoveFuncCall is an object that describes an invocation
-// of method Remove on an instance of MockUserEmailsStore.
-type UserEmailsStoreRemoveFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int32
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
-	// Result0 is the value of the 1st result returned from this method
-	// invocation.
-	Result0 error
-}
-
-// Args returns an interface slice containing the arguments of this
-// invocation.
-func (c UserEmailsStoreRemoveFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
-}
-
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c UserEmailsStoreRemoveFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
-}
-
-// UserEmailsStoreSetLastVerificationFunc describes the behavior when the
-// SetLastVerification method of the parent MockUserEmailsStore instance is
-// invoked.
-type UserEmailsStoreSetLastVerificationFunc struct {
-	defaultHook func(context.Context, int32, string, string) error
-	hooks       []func(context.Context, int32, string, string) error
-	history     []UserEmailsStoreSetLastVerificationFuncCall
+// UserExternalAccountsStoreCreateUserAndSaveFunc describes the behavior
+// when the CreateUserAndSave method of the parent
+// MockUserExternalAccountsStore instance is invoked.
+type UserExternalAccountsStoreCreateUserAndSaveFunc struct {
+	defaultHook func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error)
+	hooks       []func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error)
+	history     []UserExternalAccountsStoreCreateUserAndSaveFuncCall
 	mutex       sync.Mutex
 }
 
-// SetLastVerification delegates to the next hook function in the queue and
+// CreateUserAndSave delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockUserEmailsStore) SetLastVerification(v0 context.Context, v1 int32, v2 string, v3 string) error {
-	r0 := m.SetLastVerificationFunc.nextHook()(v0, v1, v2, v3)
-	m.SetLastVerificationFunc.appendCall(UserEmailsStoreSetLastVerificationFuncCall{v0, v1, v2, v3, r0})
-	return r0
+func (m *MockUserExternalAccountsStore) CreateUserAndSave(v0 context.Context, v1 NewUser, v2 extsvc.AccountSpec, v3 extsvc.AccountData) (int32, error) {
+	r0, r1 := m.CreateUserAndSaveFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateUserAndSaveFunc.appendCall(UserExternalAccountsStoreCreateUserAndSaveFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the SetLastVerification
-// method of the parent MockUserEmailsStore instance is invoked and the hook
-// queue is empty.
-func (f *UserEmailsStoreSetLastVerificationFunc) SetDefaultHook(hook func(context.Context, int32, string, string) error) {
+// SetDefaultHook sets function that is called when the CreateUserAndSave
+// method of the parent MockUserExternalAccountsStore instance is invoked
+// and the hook queue is empty.
+func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) SetDefaultHook(hook func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// SetLastVerification method of the parent MockUserEmailsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *UserEmailsStoreSetLastVerificationFunc) PushHook(hook func(context.Context, int32, string, string) error) {
+// CreateUserAndSave method of the parent MockUserExternalAccountsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) PushHook(hook func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32841,21 +36439,21 @@ func (f *UserEmailsStoreSetLastVerificationFunc) PushHook(hook func(context.Cont
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreSetLastVerificationFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, string, string) error {
-		return r0
+func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) SetDefaultReturn(r0 int32, r1 error) {
+	f.SetDefaultHook(func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreSetLastVerificationFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, string, string) error {
-		return r0
+func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) PushReturn(r0 int32, r1 error) {
+	f.PushHook(func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+		return r0, r1
 	})
 }
 
-func (f *UserEmailsStoreSetLastVerificationFunc) nextHook() func(context.Context, int32, string, string) error {
+func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) nextHook() func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32868,108 +36466,113 @@ func (f *UserEmailsStoreSetLastVerificationFunc) nextHook() func(context.Context
 	return hook
 }
 
-func (f *UserEmailsStoreSetLastVerificationFunc) appendCall(r0 UserEmailsStoreSetLastVerificationFuncCall) {
+func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) appendCall(r0 UserExternalAccountsStoreCreateUserAndSaveFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreSetLastVerificationFuncCall
-// objects describing the invocations of this function.
-func (f *UserEmailsStoreSetLastVerificationFunc) History() []UserEmailsStoreSetLastVerificationFuncCall {
+// History returns a sequence of
+// UserExternalAccountsStoreCreateUserAndSaveFuncCall objects describing the
+// invocations of this function.
+func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) History() []UserExternalAccountsStoreCreateUserAndSaveFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreSetLastVerificationFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreCreateUserAndSaveFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreSetLastVerificationFuncCall is an object that describes an
-// invocation of method SetLastVerification on an instance of
-// MockUserEmailsStore.
-type UserEmailsStoreSetLastVerificationFuncCall struct {
+// UserExternalAccountsStoreCreateUserAndSaveFuncCall is an object that
+// describes an invocation of method CreateUserAndSave on an instance of
+// MockUserExternalAccountsStore.
+type UserExternalAccountsStoreCreateUserAndSaveFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 NewUser
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 string
+	Arg2 extsvc.AccountSpec
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 string
+	Arg3 extsvc.AccountData
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 int32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreSetLastVerificationFuncCall) Args() []interface{} {
+func (c UserExternalAccountsStoreCreateUserAndSaveFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreSetLastVerificationFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c UserExternalAccountsStoreCreateUserAndSaveFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserEmailsStoreSetPrimaryEmailFunc describes the behavior when the
-// SetPrimaryEmail method of the parent MockUserEmailsStore instance is
+// UserExternalAccountsStoreDeleteFunc describes the behavior when the
+// Delete method of the parent MockUserExternalAccountsStore instance is
 // invoked.
-type UserEmailsStoreSetPrimaryEmailFunc struct {
-	defaultHook func(context.Context, int32, string) error
-	hooks       []func(context.Context, int32, string) error
-	history     []UserEmailsStoreSetPrimaryEmailFuncCall
+type UserExternalAccountsStoreDeleteFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []UserExternalAccountsStoreDeleteFuncCall
 	mutex       sync.Mutex
 }
 
-// SetPrimaryEmail delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockUserEmailsStore) SetPrimaryEmail(v0 context.Context, v1 int32, v2 string) error {
-	r0 := m.SetPrimaryEmailFunc.nextHook()(v0, v1, v2)
-	m.SetPrimaryEmailFunc.appendCall(UserEmailsStoreSetPrimaryEmailFuncCall{v0, v1, v2, r0})
+// Delete delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserExternalAccountsStore) Delete(v0 context.Context, v1 int32) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1)
+	m.DeleteFunc.appendCall(UserExternalAccountsStoreDeleteFuncCall{v0, v1, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the SetPrimaryEmail
-// method of the parent MockUserEmailsStore instance is invoked and the hook
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockUserExternalAccountsStore instance is invoked and the hook
 // queue is empty.
-func (f *UserEmailsStoreSetPrimaryEmailFunc) SetDefaultHook(hook func(context.Context, int32, string) error) {
+func (f *UserExternalAccountsStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int32) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// SetPrimaryEmail method of the parent MockUserEmailsStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *UserEmailsStoreSetPrimaryEmailFunc) PushHook(hook func(context.Context, int32, string) error) {
+// Delete method of the parent MockUserExternalAccountsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserExternalAccountsStoreDeleteFunc) PushHook(hook func(context.Context, int32) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
 }
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
-// the given values.
-func (f *UserEmailsStoreSetPrimaryEmailFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, string) error {
+// the given values.
+func (f *UserExternalAccountsStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreSetPrimaryEmailFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, string) error {
+func (f *UserExternalAccountsStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
 		return r0
 	})
 }
 
-func (f *UserEmailsStoreSetPrimaryEmailFunc) nextHook() func(context.Context, int32, string) error {
+func (f *UserExternalAccountsStoreDeleteFunc) nextHook() func(context.Context, int32) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32982,36 +36585,33 @@ func (f *UserEmailsStoreSetPrimaryEmailFunc) nextHook() func(context.Context, in
 	return hook
 }
 
-func (f *UserEmailsStoreSetPrimaryEmailFunc) appendCall(r0 UserEmailsStoreSetPrimaryEmailFuncCall) {
+func (f *UserExternalAccountsStoreDeleteFunc) appendCall(r0 UserExternalAccountsStoreDeleteFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreSetPrimaryEmailFuncCall
+// History returns a sequence of UserExternalAccountsStoreDeleteFuncCall
 // objects describing the invocations of this function.
-func (f *UserEmailsStoreSetPrimaryEmailFunc) History() []UserEmailsStoreSetPrimaryEmailFuncCall {
+func (f *UserExternalAccountsStoreDeleteFunc) History() []UserExternalAccountsStoreDeleteFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreSetPrimaryEmailFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreDeleteFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreSetPrimaryEmailFuncCall is an object that describes an
-// invocation of method SetPrimaryEmail on an instance of
-// MockUserEmailsStore.
-type UserEmailsStoreSetPrimaryEmailFuncCall struct {
+// UserExternalAccountsStoreDeleteFuncCall is an object that describes an
+// invocation of method Delete on an instance of
+// MockUserExternalAccountsStore.
+type UserExternalAccountsStoreDeleteFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int32
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -33019,45 +36619,45 @@ type UserEmailsStoreSetPrimaryEmailFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreSetPrimaryEmailFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c UserExternalAccountsStoreDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreSetPrimaryEmailFuncCall) Results() []interface{} {
+func (c UserExternalAccountsStoreDeleteFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserEmailsStoreSetVerifiedFunc describes the behavior when the
-// SetVerified method of the parent MockUserEmailsStore instance is invoked.
-type UserEmailsStoreSetVerifiedFunc struct {
-	defaultHook func(context.Context, int32, string, bool) error
-	hooks       []func(context.Context, int32, string, bool) error
-	history     []UserEmailsStoreSetVerifiedFuncCall
+// UserExternalAccountsStoreDoneFunc describes the behavior when the Done
+// method of the parent MockUserExternalAccountsStore instance is invoked.
+type UserExternalAccountsStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []UserExternalAccountsStoreDoneFuncCall
 	mutex       sync.Mutex
 }
 
-// SetVerified delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockUserEmailsStore) SetVerified(v0 context.Context, v1 int32, v2 string, v3 bool) error {
-	r0 := m.SetVerifiedFunc.nextHook()(v0, v1, v2, v3)
-	m.SetVerifiedFunc.appendCall(UserEmailsStoreSetVerifiedFuncCall{v0, v1, v2, v3, r0})
+// Done delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserExternalAccountsStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(UserExternalAccountsStoreDoneFuncCall{v0, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the SetVerified method
-// of the parent MockUserEmailsStore instance is invoked and the hook queue
-// is empty.
-func (f *UserEmailsStoreSetVerifiedFunc) SetDefaultHook(hook func(context.Context, int32, string, bool) error) {
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockUserExternalAccountsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserExternalAccountsStoreDoneFunc) SetDefaultHook(hook func(error) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// SetVerified method of the parent MockUserEmailsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *UserEmailsStoreSetVerifiedFunc) PushHook(hook func(context.Context, int32, string, bool) error) {
+// Done method of the parent MockUserExternalAccountsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserExternalAccountsStoreDoneFunc) PushHook(hook func(error) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -33065,21 +36665,21 @@ func (f *UserEmailsStoreSetVerifiedFunc) PushHook(hook func(context.Context, int
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreSetVerifiedFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, string, bool) error {
+func (f *UserExternalAccountsStoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreSetVerifiedFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, string, bool) error {
+func (f *UserExternalAccountsStoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
 		return r0
 	})
 }
 
-func (f *UserEmailsStoreSetVerifiedFunc) nextHook() func(context.Context, int32, string, bool) error {
+func (f *UserExternalAccountsStoreDoneFunc) nextHook() func(error) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -33092,38 +36692,30 @@ func (f *UserEmailsStoreSetVerifiedFunc) nextHook() func(context.Context, int32,
 	return hook
 }
 
-func (f *UserEmailsStoreSetVerifiedFunc) appendCall(r0 UserEmailsStoreSetVerifiedFuncCall) {
+func (f *UserExternalAccountsStoreDoneFunc) appendCall(r0 UserExternalAccountsStoreDoneFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreSetVerifiedFuncCall objects
-// describing the invocations of this function.
-func (f *UserEmailsStoreSetVerifiedFunc) History() []UserEmailsStoreSetVerifiedFuncCall {
+// History returns a sequence of UserExternalAccountsStoreDoneFuncCall
+// objects describing the invocations of this function.
+func (f *UserExternalAccountsStoreDoneFunc) History() []UserExternalAccountsStoreDoneFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreSetVerifiedFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreDoneFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreSetVerifiedFuncCall is an object that describes an
-// invocation of method SetVerified on an instance of MockUserEmailsStore.
-type UserEmailsStoreSetVerifiedFuncCall struct {
+// UserExternalAccountsStoreDoneFuncCall is an object that describes an
+// invocation of method Done on an instance of
+// MockUserExternalAccountsStore.
+type UserExternalAccountsStoreDoneFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int32
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 bool
+	Arg0 error
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -33131,45 +36723,47 @@ type UserEmailsStoreSetVerifiedFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreSetVerifiedFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c UserExternalAccountsStoreDoneFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreSetVerifiedFuncCall) Results() []interface{} {
+func (c UserExternalAccountsStoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserEmailsStoreTransactFunc describes the behavior when the Transact
-// method of the parent MockUserEmailsStore instance is invoked.
-type UserEmailsStoreTransactFunc struct {
-	defaultHook func(context.Context) (UserEmailsStore, error)
-	hooks       []func(context.Context) (UserEmailsStore, error)
-	history     []UserEmailsStoreTransactFuncCall
+// UserExternalAccountsStoreExecResultFunc describes the behavior when the
+// ExecResult method of the parent MockUserExternalAccountsStore instance is
+// invoked.
+type UserExternalAccountsStoreExecResultFunc struct {
+	defaultHook func(context.Context, *sqlf.Query) (sql.Result, error)
+	hooks       []func(context.Context, *sqlf.Query) (sql.Result, error)
+	history     []UserExternalAccountsStoreExecResultFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserEmailsStore) Transact(v0 context.Context) (UserEmailsStore, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(UserEmailsStoreTransactFuncCall{v0, r0, r1})
+// ExecResult delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUserExternalAccountsStore) ExecResult(v0 context.Context, v1 *sqlf.Query) (sql.Result, error) {
+	r0, r1 := m.ExecResultFunc.nextHook()(v0, v1)
+	m.ExecResultFunc.appendCall(UserExternalAccountsStoreExecResultFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
-// the parent MockUserEmailsStore instance is invoked and the hook queue is
-// empty.
-func (f *UserEmailsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (UserEmailsStore, error)) {
+// SetDefaultHook sets function that is called when the ExecResult method of
+// the parent MockUserExternalAccountsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserExternalAccountsStoreExecResultFunc) SetDefaultHook(hook func(context.Context, *sqlf.Query) (sql.Result, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockUserEmailsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *UserEmailsStoreTransactFunc) PushHook(hook func(context.Context) (UserEmailsStore, error)) {
+// ExecResult method of the parent MockUserExternalAccountsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserExternalAccountsStoreExecResultFunc) PushHook(hook func(context.Context, *sqlf.Query) (sql.Result, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -33177,21 +36771,21 @@ func (f *UserEmailsStoreTransactFunc) PushHook(hook func(context.Context) (UserE
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreTransactFunc) SetDefaultReturn(r0 UserEmailsStore, r1 error) {
-	f.SetDefaultHook(func(context.Context) (UserEmailsStore, error) {
+func (f *UserExternalAccountsStoreExecResultFunc) SetDefaultReturn(r0 sql.Result, r1 error) {
+	f.SetDefaultHook(func(context.Context, *sqlf.Query) (sql.Result, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreTransactFunc) PushReturn(r0 UserEmailsStore, r1 error) {
-	f.PushHook(func(context.Context) (UserEmailsStore, error) {
+func (f *UserExternalAccountsStoreExecResultFunc) PushReturn(r0 sql.Result, r1 error) {
+	f.PushHook(func(context.Context, *sqlf.Query) (sql.Result, error) {
 		return r0, r1
 	})
 }
 
-func (f *UserEmailsStoreTransactFunc) nextHook() func(context.Context) (UserEmailsStore, error) {
+func (f *UserExternalAccountsStoreExecResultFunc) nextHook() func(context.Context, *sqlf.Query) (sql.Result, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -33204,32 +36798,36 @@ func (f *UserEmailsStoreTransactFunc) nextHook() func(context.Context) (UserEmai
 	return hook
 }
 
-func (f *UserEmailsStoreTransactFunc) appendCall(r0 UserEmailsStoreTransactFuncCall) {
+func (f *UserExternalAccountsStoreExecResultFunc) appendCall(r0 UserExternalAccountsStoreExecResultFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreTransactFuncCall objects
-// describing the invocations of this function.
-func (f *UserEmailsStoreTransactFunc) History() []UserEmailsStoreTransactFuncCall {
+// History returns a sequence of UserExternalAccountsStoreExecResultFuncCall
+// objects describing the invocations of this function.
+func (f *UserExternalAccountsStoreExecResultFunc) History() []UserExternalAccountsStoreExecResultFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreTransactFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreExecResultFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreTransactFuncCall is an object that describes an invocation
-// of method Transact on an instance of MockUserEmailsStore.
-type UserEmailsStoreTransactFuncCall struct {
+// UserExternalAccountsStoreExecResultFuncCall is an object that describes
+// an invocation of method ExecResult on an instance of
+// MockUserExternalAccountsStore.
+type UserExternalAccountsStoreExecResultFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *sqlf.Query
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 UserEmailsStore
+	Result0 sql.Result
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -33237,45 +36835,45 @@ type UserEmailsStoreTransactFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreTransactFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c UserExternalAccountsStoreExecResultFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreTransactFuncCall) Results() []interface{} {
+func (c UserExternalAccountsStoreExecResultFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserEmailsStoreVerifyFunc describes the behavior when the Verify method
-// of the parent MockUserEmailsStore instance is invoked.
-type UserEmailsStoreVerifyFunc struct {
-	defaultHook func(context.Context, int32, string, string) (bool, error)
-	hooks       []func(context.Context, int32, string, string) (bool, error)
-	history     []UserEmailsStoreVerifyFuncCall
+// UserExternalAccountsStoreGetFunc describes the behavior when the Get
+// method of the parent MockUserExternalAccountsStore instance is invoked.
+type UserExternalAccountsStoreGetFunc struct {
+	defaultHook func(context.Context, int32) (*extsvc.Account, error)
+	hooks       []func(context.Context, int32) (*extsvc.Account, error)
+	history     []UserExternalAccountsStoreGetFuncCall
 	mutex       sync.Mutex
 }
 
-// Verify delegates to the next hook function in the queue and stores the
+// Get delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserEmailsStore) Verify(v0 context.Context, v1 int32, v2 string, v3 string) (bool, error) {
-	r0, r1 := m.VerifyFunc.nextHook()(v0, v1, v2, v3)
-	m.VerifyFunc.appendCall(UserEmailsStoreVerifyFuncCall{v0, v1, v2, v3, r0, r1})
+func (m *MockUserExternalAccountsStore) Get(v0 context.Context, v1 int32) (*extsvc.Account, error) {
+	r0, r1 := m.GetFunc.nextHook()(v0, v1)
+	m.GetFunc.appendCall(UserExternalAccountsStoreGetFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Verify method of the
-// parent MockUserEmailsStore instance is invoked and the hook queue is
-// empty.
-func (f *UserEmailsStoreVerifyFunc) SetDefaultHook(hook func(context.Context, int32, string, string) (bool, error)) {
+// SetDefaultHook sets function that is called when the Get method of the
+// parent MockUserExternalAccountsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserExternalAccountsStoreGetFunc) SetDefaultHook(hook func(context.Context, int32) (*extsvc.Account, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Verify method of the parent MockUserEmailsStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *UserEmailsStoreVerifyFunc) PushHook(hook func(context.Context, int32, string, string) (bool, error)) {
+// Get method of the parent MockUserExternalAccountsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserExternalAccountsStoreGetFunc) PushHook(hook func(context.Context, int32) (*extsvc.Account, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -33283,21 +36881,21 @@ func (f *UserEmailsStoreVerifyFunc) PushHook(hook func(context.Context, int32, s
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreVerifyFunc) SetDefaultReturn(r0 bool, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32, string, string) (bool, error) {
+func (f *UserExternalAccountsStoreGetFunc) SetDefaultReturn(r0 *extsvc.Account, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*extsvc.Account, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreVerifyFunc) PushReturn(r0 bool, r1 error) {
-	f.PushHook(func(context.Context, int32, string, string) (bool, error) {
+func (f *UserExternalAccountsStoreGetFunc) PushReturn(r0 *extsvc.Account, r1 error) {
+	f.PushHook(func(context.Context, int32) (*extsvc.Account, error) {
 		return r0, r1
 	})
 }
 
-func (f *UserEmailsStoreVerifyFunc) nextHook() func(context.Context, int32, string, string) (bool, error) {
+func (f *UserExternalAccountsStoreGetFunc) nextHook() func(context.Context, int32) (*extsvc.Account, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -33310,41 +36908,35 @@ func (f *UserEmailsStoreVerifyFunc) nextHook() func(context.Context, int32, stri
 	return hook
 }
 
-func (f *UserEmailsStoreVerifyFunc) appendCall(r0 UserEmailsStoreVerifyFuncCall) {
+func (f *UserExternalAccountsStoreGetFunc) appendCall(r0 UserExternalAccountsStoreGetFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreVerifyFuncCall objects
-// describing the invocations of this function.
-func (f *UserEmailsStoreVerifyFunc) History() []UserEmailsStoreVerifyFuncCall {
+// History returns a sequence of UserExternalAccountsStoreGetFuncCall
+// objects describing the invocations of this function.
+func (f *UserExternalAccountsStoreGetFunc) History() []UserExternalAccountsStoreGetFuncCall {
 	f.mutex.Lock()
-	history := make([]UserEmailsStoreVerifyFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreGetFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserEmailsStoreVerifyFuncCall is an object that describes an invocation
-// of method Verify on an instance of MockUserEmailsStore.
-type UserEmailsStoreVerifyFuncCall struct {
+// UserExternalAccountsStoreGetFuncCall is an object that describes an
+// invocation of method Get on an instance of MockUserExternalAccountsStore.
+type UserExternalAccountsStoreGetFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int32
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 bool
+	Result0 *extsvc.Account
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -33352,45 +36944,47 @@ type UserEmailsStoreVerifyFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserEmailsStoreVerifyFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c UserExternalAccountsStoreGetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserEmailsStoreVerifyFuncCall) Results() []interface{} {
+func (c UserExternalAccountsStoreGetFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserEmailsStoreWithFunc describes the behavior when the With method of
-// the parent MockUserEmailsStore instance is invoked.
-type UserEmailsStoreWithFunc struct {
-	defaultHook func(basestore.ShareableStore) UserEmailsStore
-	hooks       []func(basestore.ShareableStore) UserEmailsStore
-	history     []UserEmailsStoreWithFuncCall
+// UserExternalAccountsStoreHandleFunc describes the behavior when the
+// Handle method of the parent MockUserExternalAccountsStore instance is
+// invoked.
+type UserExternalAccountsStoreHandleFunc struct {
+	defaultHook func() *basestore.TransactableHandle
+	hooks       []func() *basestore.TransactableHandle
+	history     []UserExternalAccountsStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
-// With delegates to the next hook function in the queue and stores the
+// Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserEmailsStore) With(v0 basestore.ShareableStore) UserEmailsStore {
-	r0 := m.WithFunc.nextHook()(v0)
-	m.WithFunc.appendCall(UserEmailsStoreWithFuncCall{v0, r0})
+func (m *MockUserExternalAccountsStore) Handle() *basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(UserExternalAccountsStoreHandleFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the With method of the
-// parent MockUserEmailsStore instance is invoked and the hook queue is
-// empty.
-func (f *UserEmailsStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) UserEmailsStore) {
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockUserExternalAccountsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserExternalAccountsStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// With method of the parent MockUserEmailsStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *UserEmailsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) UserEmailsStore) {
+// Handle method of the parent MockUserExternalAccountsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserExternalAccountsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -33398,21 +36992,21 @@ func (f *UserEmailsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) U
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserEmailsStoreWithFunc) SetDefaultReturn(r0 UserEmailsStore) {
-	f.SetDefaultHook(func(basestore.ShareableStore) UserEmailsStore {
+func (f *UserExternalAccountsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+	f.SetDefaultHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserEmailsStoreWithFunc) PushReturn(r0 UserEmailsStore) {
-	f.PushHook(func(basestore.ShareableStore) UserEmailsStore {
+func (f *UserExternalAccountsStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+	f.PushHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
-func (f *UserEmailsStoreWithFunc) nextHook() func(basestore.ShareableStore) UserEmailsStore {
+func (f *UserExternalAccountsStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -33425,396 +37019,75 @@ func (f *UserEmailsStoreWithFunc) nextHook() func(basestore.ShareableStore) User
 	return hook
 }
 
-func (f *UserEmailsStoreWithFunc) appendCall(r0 UserEmailsStoreWithFuncCall) {
+func (f *UserExternalAccountsStoreHandleFunc) appendCall(r0 UserExternalAccountsStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserEmailsStoreWithFuncCall objects
-// describing the invocations of this function.
-func (f *UserEmailsStoreWithFunc) History() []UserEmailsStoreWithFuncCall {
-	f.mutex.Lock()
-	history := make([]UserEmailsStoreWithFuncCall, len(f.history))
-	copy(history, f.history)
-	f.mutex.Unlock()
-
-	return history
-}
-
-// UserEmailsStoreWithFuncCall is an object that describes an invocation of
-// method With on an instance of MockUserEmailsStore.
-type UserEmailsStoreWithFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 basestore.ShareableStore
-	// Result0 is the value of the 1st result returned from this method
-	// invocation.
-	Result0 UserEmailsStore
-}
-
-// Args returns an interface slice containing the arguments of this
-// invocation.
-func (c UserEmailsStoreWithFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
-}
-
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c UserEmailsStoreWithFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
-}
-
-// MockUserExternalAccountsStore is a mock implementation of the
-// UserExternalAccountsStore interface (from the package
-// github.com/sourcegraph/sourcegraph/internal/database) used for unit
-// testing.
-type MockUserExternalAccountsStore struct {
-	// AssociateUserAndSaveFunc is an instance of a mock function object
-	// controlling the behavior of the method AssociateUserAndSave.
-	AssociateUserAndSaveFunc *UserExternalAccountsStoreAssociateUserAndSaveFunc
-	// CountFunc is an instance of a mock function object controlling the
-	// behavior of the method Count.
-	CountFunc *UserExternalAccountsStoreCountFunc
-	// CreateUserAndSaveFunc is an instance of a mock function object
-	// controlling the behavior of the method CreateUserAndSave.
-	CreateUserAndSaveFunc *UserExternalAccountsStoreCreateUserAndSaveFunc
-	// DeleteFunc is an instance of a mock function object controlling the
-	// behavior of the method Delete.
-	DeleteFunc *UserExternalAccountsStoreDeleteFunc
-	// DoneFunc is an instance of a mock function object controlling the
-	// behavior of the method Done.
-	DoneFunc *UserExternalAccountsStoreDoneFunc
-	// ExecResultFunc is an instance of a mock function object controlling
-	// the behavior of the method ExecResult.
-	ExecResultFunc *UserExternalAccountsStoreExecResultFunc
-	// GetFunc is an instance of a mock function object controlling the
-	// behavior of the method Get.
-	GetFunc *UserExternalAccountsStoreGetFunc
-	// HandleFunc is an instance of a mock function object controlling the
-	// behavior of the method Handle.
-	HandleFunc *UserExternalAccountsStoreHandleFunc
-	// InsertFunc is an instance of a mock function object controlling the
-	// behavior of the method Insert.
-	InsertFunc *UserExternalAccountsStoreInsertFunc
-	// ListFunc is an instance of a mock function object controlling the
-	// behavior of the method List.
-	ListFunc *UserExternalAccountsStoreListFunc
-	// ListBySQLFunc is an instance of a mock function object controlling
-	// the behavior of the method ListBySQL.
-	ListBySQLFunc *UserExternalAccountsStoreListBySQLFunc
-	// LookupUserAndSaveFunc is an instance of a mock function object
-	// controlling the behavior of the method LookupUserAndSave.
-	LookupUserAndSaveFunc *UserExternalAccountsStoreLookupUserAndSaveFunc
-	// QueryRowFunc is an instance of a mock function object controlling the
-	// behavior of the method QueryRow.
-	QueryRowFunc *UserExternalAccountsStoreQueryRowFunc
-	// TouchExpiredFunc is an instance of a mock function object controlling
-	// the behavior of the method TouchExpired.
-	TouchExpiredFunc *UserExternalAccountsStoreTouchExpiredFunc
-	// TouchLastValidFunc is an instance of a mock function object
-	// controlling the behavior of the method TouchLastValid.
-	TouchLastValidFunc *UserExternalAccountsStoreTouchLastValidFunc
-	// TransactFunc is an instance of a mock function object controlling the
-	// behavior of the method Transact.
-	TransactFunc *UserExternalAccountsStoreTransactFunc
-	// WithFunc is an instance of a mock function object controlling the
-	// behavior of the method With.
-	WithFunc *UserExternalAccountsStoreWithFunc
-	// WithEncryptionKeyFunc is an instance of a mock function object
-	// controlling the behavior of the method WithEncryptionKey.
-	WithEncryptionKeyFunc *UserExternalAccountsStoreWithEncryptionKeyFunc
-}
-
-// NewMockUserExternalAccountsStore creates a new mock of the
-// UserExternalAccountsStore interface. All methods return zero values for
-// all results, unless overwritten.
-func NewMockUserExternalAccountsStore() *MockUserExternalAccountsStore {
-	return &MockUserExternalAccountsStore{
-		AssociateUserAndSaveFunc: &UserExternalAccountsStoreAssociateUserAndSaveFunc{
-			defaultHook: func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
-				return nil
-			},
-		},
-		CountFunc: &UserExternalAccountsStoreCountFunc{
-			defaultHook: func(context.Context, ExternalAccountsListOptions) (int, error) {
-				return 0, nil
-			},
-		},
-		CreateUserAndSaveFunc: &UserExternalAccountsStoreCreateUserAndSaveFunc{
-			defaultHook: func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
-				return 0, nil
-			},
-		},
-		DeleteFunc: &UserExternalAccountsStoreDeleteFunc{
-			defaultHook: func(context.Context, int32) error {
-				return nil
-			},
-		},
-		DoneFunc: &UserExternalAccountsStoreDoneFunc{
-			defaultHook: func(error) error {
-				return nil
-			},
-		},
-		ExecResultFunc: &UserExternalAccountsStoreExecResultFunc{
-			defaultHook: func(context.Context, *sqlf.Query) (sql.Result, error) {
-				return nil, nil
-			},
-		},
-		GetFunc: &UserExternalAccountsStoreGetFunc{
-			defaultHook: func(context.Context, int32) (*extsvc.Account, error) {
-				return nil, nil
-			},
-		},
-		HandleFunc: &UserExternalAccountsStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				return nil
-			},
-		},
-		InsertFunc: &UserExternalAccountsStoreInsertFunc{
-			defaultHook: func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
-				return nil
-			},
-		},
-		ListFunc: &UserExternalAccountsStoreListFunc{
-			defaultHook: func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error) {
-				return nil, nil
-			},
-		},
-		ListBySQLFunc: &UserExternalAccountsStoreListBySQLFunc{
-			defaultHook: func(context.Context, *sqlf.Query) ([]*extsvc.Account, error) {
-				return nil, nil
-			},
-		},
-		LookupUserAndSaveFunc: &UserExternalAccountsStoreLookupUserAndSaveFunc{
-			defaultHook: func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
-				return 0, nil
-			},
-		},
-		QueryRowFunc: &UserExternalAccountsStoreQueryRowFunc{
-			defaultHook: func(context.Context, *sqlf.Query) *sql.Row {
-				return nil
-			},
-		},
-		TouchExpiredFunc: &UserExternalAccountsStoreTouchExpiredFunc{
-			defaultHook: func(context.Context, int32) error {
-				return nil
-			},
-		},
-		TouchLastValidFunc: &UserExternalAccountsStoreTouchLastValidFunc{
-			defaultHook: func(context.Context, int32) error {
-				return nil
-			},
-		},
-		TransactFunc: &UserExternalAccountsStoreTransactFunc{
-			defaultHook: func(context.Context) (UserExternalAccountsStore, error) {
-				return nil, nil
-			},
-		},
-		WithFunc: &UserExternalAccountsStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) UserExternalAccountsStore {
-				return nil
-			},
-		},
-		WithEncryptionKeyFunc: &UserExternalAccountsStoreWithEncryptionKeyFunc{
-			defaultHook: func(encryption.Key) UserExternalAccountsStore {
-				return nil
-			},
-		},
-	}
+// History returns a sequence of UserExternalAccountsStoreHandleFuncCall
+// objects describing the invocations of this function.
+func (f *UserExternalAccountsStoreHandleFunc) History() []UserExternalAccountsStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]UserExternalAccountsStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
 }
 
-// NewStrictMockUserExternalAccountsStore creates a new mock of the
-// UserExternalAccountsStore interface. All methods panic on invocation,
-// unless overwritten.
-func NewStrictMockUserExternalAccountsStore() *MockUserExternalAccountsStore {
-	return &MockUserExternalAccountsStore{
-		AssociateUserAndSaveFunc: &UserExternalAccountsStoreAssociateUserAndSaveFunc{
-			defaultHook: func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
-				panic("unexpected invocation of MockUserExternalAccountsStore.AssociateUserAndSave")
-			},
-		},
-		CountFunc: &UserExternalAccountsStoreCountFunc{
-			defaultHook: func(context.Context, ExternalAccountsListOptions) (int, error) {
-				panic("unexpected invocation of MockUserExternalAccountsStore.Count")
-			},
-		},
-		CreateUserAndSaveFunc: &UserExternalAccountsStoreCreateUserAndSaveFunc{
-			defaultHook: func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
-				panic("unexpected invocation of MockUserExternalAccountsStore.CreateUserAndSave")
-			},
-		},
-		DeleteFunc: &UserExternalAccountsStoreDeleteFunc{
-			defaultHook: func(context.Context, int32) error {
-				panic("unexpected invocation of MockUserExternalAccountsStore.Delete")
-			},
-		},
-		DoneFunc: &UserExternalAccountsStoreDoneFunc{
-			defaultHook: func(error) error {
-				panic("unexpected invocation of MockUserExternalAccountsStore.Done")
-			},
-		},
-		ExecResultFunc: &UserExternalAccountsStoreExecResultFunc{
-			defaultHook: func(context.Context, *sqlf.Query) (sql.Result, error) {
-				panic("unexpected invocation of MockUserExternalAccountsStore.ExecResult")
-			},
-		},
-		GetFunc: &UserExternalAccountsStoreGetFunc{
-			defaultHook: func(context.Context, int32) (*extsvc.Account, error) {
-				panic("unexpected invocation of MockUserExternalAccountsStore.Get")
-			},
-		},
-		HandleFunc: &UserExternalAccountsStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				panic("unexpected invocation of MockUserExternalAccountsStore.Handle")
-			},
-		},
-		InsertFunc: &UserExternalAccountsStoreInsertFunc{
-			defaultHook: func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
-				panic("unexpected invocation of MockUserExternalAccountsStore.Insert")
-			},
-		},
-		ListFunc: &UserExternalAccountsStoreListFunc{
-			defaultHook: func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error) {
-				panic("unexpected invocation of MockUserExternalAccountsStore.List")
-			},
-		},
-		ListBySQLFunc: &UserExternalAccountsStoreListBySQLFunc{
-			defaultHook: func(context.Context, *sqlf.Query) ([]*extsvc.Account, error) {
-				panic("unexpected invocation of MockUserExternalAccountsStore.ListBySQL")
-			},
-		},
-		LookupUserAndSaveFunc: &UserExternalAccountsStoreLookupUserAndSaveFunc{
-			defaultHook: func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
-				panic("unexpected invocation of MockUserExternalAccountsStore.LookupUserAndSave")
-			},
-		},
-		QueryRowFunc: &UserExternalAccountsStoreQueryRowFunc{
-			defaultHook: func(context.Context, *sqlf.Query) *sql.Row {
-				panic("unexpected invocation of MockUserExternalAccountsStore.QueryRow")
-			},
-		},
-		TouchExpiredFunc: &UserExternalAccountsStoreTouchExpiredFunc{
-			defaultHook: func(context.Context, int32) error {
-				panic("unexpected invocation of MockUserExternalAccountsStore.TouchExpired")
-			},
-		},
-		TouchLastValidFunc: &UserExternalAccountsStoreTouchLastValidFunc{
-			defaultHook: func(context.Context, int32) error {
-				panic("unexpected invocation of MockUserExternalAccountsStore.TouchLastValid")
-			},
-		},
-		TransactFunc: &UserExternalAccountsStoreTransactFunc{
-			defaultHook: func(context.Context) (UserExternalAccountsStore, error) {
-				panic("unexpected invocation of MockUserExternalAccountsStore.Transact")
-			},
-		},
-		WithFunc: &UserExternalAccountsStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) UserExternalAccountsStore {
-				panic("unexpected invocation of MockUserExternalAccountsStore.With")
-			},
-		},
-		WithEncryptionKeyFunc: &UserExternalAccountsStoreWithEncryptionKeyFunc{
-			defaultHook: func(encryption.Key) UserExternalAccountsStore {
-				panic("unexpected invocation of MockUserExternalAccountsStore.WithEncryptionKey")
-			},
-		},
-	}
+// UserExternalAccountsStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of
+// MockUserExternalAccountsStore.
+type UserExternalAccountsStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *basestore.TransactableHandle
 }
 
-// NewMockUserExternalAccountsStoreFrom creates a new mock of the
-// MockUserExternalAccountsStore interface. All methods delegate to the
-// given implementation, unless overwritten.
-func NewMockUserExternalAccountsStoreFrom(i UserExternalAccountsStore) *MockUserExternalAccountsStore {
-	return &MockUserExternalAccountsStore{
-		AssociateUserAndSaveFunc: &UserExternalAccountsStoreAssociateUserAndSaveFunc{
-			defaultHook: i.AssociateUserAndSave,
-		},
-		CountFunc: &UserExternalAccountsStoreCountFunc{
-			defaultHook: i.Count,
-		},
-		CreateUserAndSaveFunc: &UserExternalAccountsStoreCreateUserAndSaveFunc{
-			defaultHook: i.CreateUserAndSave,
-		},
-		DeleteFunc: &UserExternalAccountsStoreDeleteFunc{
-			defaultHook: i.Delete,
-		},
-		DoneFunc: &UserExternalAccountsStoreDoneFunc{
-			defaultHook: i.Done,
-		},
-		ExecResultFunc: &UserExternalAccountsStoreExecResultFunc{
-			defaultHook: i.ExecResult,
-		},
-		GetFunc: &UserExternalAccountsStoreGetFunc{
-			defaultHook: i.Get,
-		},
-		HandleFunc: &UserExternalAccountsStoreHandleFunc{
-			defaultHook: i.Handle,
-		},
-		InsertFunc: &UserExternalAccountsStoreInsertFunc{
-			defaultHook: i.Insert,
-		},
-		ListFunc: &UserExternalAccountsStoreListFunc{
-			defaultHook: i.List,
-		},
-		ListBySQLFunc: &UserExternalAccountsStoreListBySQLFunc{
-			defaultHook: i.ListBySQL,
-		},
-		LookupUserAndSaveFunc: &UserExternalAccountsStoreLookupUserAndSaveFunc{
-			defaultHook: i.LookupUserAndSave,
-		},
-		QueryRowFunc: &UserExternalAccountsStoreQueryRowFunc{
-			defaultHook: i.QueryRow,
-		},
-		TouchExpiredFunc: &UserExternalAccountsStoreTouchExpiredFunc{
-			defaultHook: i.TouchExpired,
-		},
-		TouchLastValidFunc: &UserExternalAccountsStoreTouchLastValidFunc{
-			defaultHook: i.TouchLastValid,
-		},
-		TransactFunc: &UserExternalAccountsStoreTransactFunc{
-			defaultHook: i.Transact,
-		},
-		WithFunc: &UserExternalAccountsStoreWithFunc{
-			defaultHook: i.With,
-		},
-		WithEncryptionKeyFunc: &UserExternalAccountsStoreWithEncryptionKeyFunc{
-			defaultHook: i.WithEncryptionKey,
-		},
-	}
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserExternalAccountsStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
-// UserExternalAccountsStoreAssociateUserAndSaveFunc describes the behavior
-// when the AssociateUserAndSave method of the parent
-// MockUserExternalAccountsStore instance is invoked.
-type UserExternalAccountsStoreAssociateUserAndSaveFunc struct {
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserExternalAccountsStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UserExternalAccountsStoreInsertFunc describes the behavior when the
+// Insert method of the parent MockUserExternalAccountsStore instance is
+// invoked.
+type UserExternalAccountsStoreInsertFunc struct {
 	defaultHook func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error
 	hooks       []func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error
-	history     []UserExternalAccountsStoreAssociateUserAndSaveFuncCall
+	history     []UserExternalAccountsStoreInsertFuncCall
 	mutex       sync.Mutex
 }
 
-// AssociateUserAndSave delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) AssociateUserAndSave(v0 context.Context, v1 int32, v2 extsvc.AccountSpec, v3 extsvc.AccountData) error {
-	r0 := m.AssociateUserAndSaveFunc.nextHook()(v0, v1, v2, v3)
-	m.AssociateUserAndSaveFunc.appendCall(UserExternalAccountsStoreAssociateUserAndSaveFuncCall{v0, v1, v2, v3, r0})
+// Insert delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserExternalAccountsStore) Insert(v0 context.Context, v1 int32, v2 extsvc.AccountSpec, v3 extsvc.AccountData) error {
+	r0 := m.InsertFunc.nextHook()(v0, v1, v2, v3)
+	m.InsertFunc.appendCall(UserExternalAccountsStoreInsertFuncCall{v0, v1, v2, v3, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the AssociateUserAndSave
-// method of the parent MockUserExternalAccountsStore instance is invoked
-// and the hook queue is empty.
-func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) SetDefaultHook(hook func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error) {
+// SetDefaultHook sets function that is called when the Insert method of the
+// parent MockUserExternalAccountsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserExternalAccountsStoreInsertFunc) SetDefaultHook(hook func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// AssociateUserAndSave method of the parent MockUserExternalAccountsStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) PushHook(hook func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error) {
+// Insert method of the parent MockUserExternalAccountsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserExternalAccountsStoreInsertFunc) PushHook(hook func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -33822,7 +37095,7 @@ func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) PushHook(hook func(c
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) SetDefaultReturn(r0 error) {
+func (f *UserExternalAccountsStoreInsertFunc) SetDefaultReturn(r0 error) {
 	f.SetDefaultHook(func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
 		return r0
 	})
@@ -33830,13 +37103,13 @@ func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) SetDefaultReturn(r0
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) PushReturn(r0 error) {
+func (f *UserExternalAccountsStoreInsertFunc) PushReturn(r0 error) {
 	f.PushHook(func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
 		return r0
 	})
 }
 
-func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) nextHook() func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
+func (f *UserExternalAccountsStoreInsertFunc) nextHook() func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -33849,28 +37122,27 @@ func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) nextHook() func(cont
 	return hook
 }
 
-func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) appendCall(r0 UserExternalAccountsStoreAssociateUserAndSaveFuncCall) {
+func (f *UserExternalAccountsStoreInsertFunc) appendCall(r0 UserExternalAccountsStoreInsertFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// UserExternalAccountsStoreAssociateUserAndSaveFuncCall objects describing
-// the invocations of this function.
-func (f *UserExternalAccountsStoreAssociateUserAndSaveFunc) History() []UserExternalAccountsStoreAssociateUserAndSaveFuncCall {
+// History returns a sequence of UserExternalAccountsStoreInsertFuncCall
+// objects describing the invocations of this function.
+func (f *UserExternalAccountsStoreInsertFunc) History() []UserExternalAccountsStoreInsertFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreAssociateUserAndSaveFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreInsertFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreAssociateUserAndSaveFuncCall is an object that
-// describes an invocation of method AssociateUserAndSave on an instance of
+// UserExternalAccountsStoreInsertFuncCall is an object that describes an
+// invocation of method Insert on an instance of
 // MockUserExternalAccountsStore.
-type UserExternalAccountsStoreAssociateUserAndSaveFuncCall struct {
+type UserExternalAccountsStoreInsertFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -33890,45 +37162,45 @@ type UserExternalAccountsStoreAssociateUserAndSaveFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreAssociateUserAndSaveFuncCall) Args() []interface{} {
+func (c UserExternalAccountsStoreInsertFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreAssociateUserAndSaveFuncCall) Results() []interface{} {
+func (c UserExternalAccountsStoreInsertFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserExternalAccountsStoreCountFunc describes the behavior when the Count
+// UserExternalAccountsStoreListFunc describes the behavior when the List
 // method of the parent MockUserExternalAccountsStore instance is invoked.
-type UserExternalAccountsStoreCountFunc struct {
-	defaultHook func(context.Context, ExternalAccountsListOptions) (int, error)
-	hooks       []func(context.Context, ExternalAccountsListOptions) (int, error)
-	history     []UserExternalAccountsStoreCountFuncCall
+type UserExternalAccountsStoreListFunc struct {
+	defaultHook func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error)
+	hooks       []func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error)
+	history     []UserExternalAccountsStoreListFuncCall
 	mutex       sync.Mutex
 }
 
-// Count delegates to the next hook function in the queue and stores the
+// List delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) Count(v0 context.Context, v1 ExternalAccountsListOptions) (int, error) {
-	r0, r1 := m.CountFunc.nextHook()(v0, v1)
-	m.CountFunc.appendCall(UserExternalAccountsStoreCountFuncCall{v0, v1, r0, r1})
+func (m *MockUserExternalAccountsStore) List(v0 context.Context, v1 ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+	r0, r1 := m.ListFunc.nextHook()(v0, v1)
+	m.ListFunc.appendCall(UserExternalAccountsStoreListFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Count method of the
+// SetDefaultHook sets function that is called when the List method of the
 // parent MockUserExternalAccountsStore instance is invoked and the hook
 // queue is empty.
-func (f *UserExternalAccountsStoreCountFunc) SetDefaultHook(hook func(context.Context, ExternalAccountsListOptions) (int, error)) {
+func (f *UserExternalAccountsStoreListFunc) SetDefaultHook(hook func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Count method of the parent MockUserExternalAccountsStore instance invokes
+// List method of the parent MockUserExternalAccountsStore instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *UserExternalAccountsStoreCountFunc) PushHook(hook func(context.Context, ExternalAccountsListOptions) (int, error)) {
+func (f *UserExternalAccountsStoreListFunc) PushHook(hook func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -33936,21 +37208,21 @@ func (f *UserExternalAccountsStoreCountFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreCountFunc) SetDefaultReturn(r0 int, r1 error) {
-	f.SetDefaultHook(func(context.Context, ExternalAccountsListOptions) (int, error) {
+func (f *UserExternalAccountsStoreListFunc) SetDefaultReturn(r0 []*extsvc.Account, r1 error) {
+	f.SetDefaultHook(func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreCountFunc) PushReturn(r0 int, r1 error) {
-	f.PushHook(func(context.Context, ExternalAccountsListOptions) (int, error) {
+func (f *UserExternalAccountsStoreListFunc) PushReturn(r0 []*extsvc.Account, r1 error) {
+	f.PushHook(func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error) {
 		return r0, r1
 	})
 }
 
-func (f *UserExternalAccountsStoreCountFunc) nextHook() func(context.Context, ExternalAccountsListOptions) (int, error) {
+func (f *UserExternalAccountsStoreListFunc) nextHook() func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -33963,27 +37235,27 @@ func (f *UserExternalAccountsStoreCountFunc) nextHook() func(context.Context, Ex
 	return hook
 }
 
-func (f *UserExternalAccountsStoreCountFunc) appendCall(r0 UserExternalAccountsStoreCountFuncCall) {
+func (f *UserExternalAccountsStoreListFunc) appendCall(r0 UserExternalAccountsStoreListFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserExternalAccountsStoreCountFuncCall
+// History returns a sequence of UserExternalAccountsStoreListFuncCall
 // objects describing the invocations of this function.
-func (f *UserExternalAccountsStoreCountFunc) History() []UserExternalAccountsStoreCountFuncCall {
+func (f *UserExternalAccountsStoreListFunc) History() []UserExternalAccountsStoreListFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreCountFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreListFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreCountFuncCall is an object that describes an
-// invocation of method Count on an instance of
+// UserExternalAccountsStoreListFuncCall is an object that describes an
+// invocation of method List on an instance of
 // MockUserExternalAccountsStore.
-type UserExternalAccountsStoreCountFuncCall struct {
+type UserExternalAccountsStoreListFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -33992,7 +37264,7 @@ type UserExternalAccountsStoreCountFuncCall struct {
 	Arg1 ExternalAccountsListOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int
+	Result0 []*extsvc.Account
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -34000,47 +37272,47 @@ type UserExternalAccountsStoreCountFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreCountFuncCall) Args() []interface{} {
+func (c UserExternalAccountsStoreListFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreCountFuncCall) Results() []interface{} {
+func (c UserExternalAccountsStoreListFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserExternalAccountsStoreCreateUserAndSaveFunc describes the behavior
-// when the CreateUserAndSave method of the parent
-// MockUserExternalAccountsStore instance is invoked.
-type UserExternalAccountsStoreCreateUserAndSaveFunc struct {
-	defaultHook func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error)
-	hooks       []func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error)
-	history     []UserExternalAccountsStoreCreateUserAndSaveFuncCall
+// UserExternalAccountsStoreListBySQLFunc describes the behavior when the
+// ListBySQL method of the parent MockUserExternalAccountsStore instance is
+// invoked.
+type UserExternalAccountsStoreListBySQLFunc struct {
+	defaultHook func(context.Context, *sqlf.Query) ([]*extsvc.Account, error)
+	hooks       []func(context.Context, *sqlf.Query) ([]*extsvc.Account, error)
+	history     []UserExternalAccountsStoreListBySQLFuncCall
 	mutex       sync.Mutex
 }
 
-// CreateUserAndSave delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) CreateUserAndSave(v0 context.Context, v1 NewUser, v2 extsvc.AccountSpec, v3 extsvc.AccountData) (int32, error) {
-	r0, r1 := m.CreateUserAndSaveFunc.nextHook()(v0, v1, v2, v3)
-	m.CreateUserAndSaveFunc.appendCall(UserExternalAccountsStoreCreateUserAndSaveFuncCall{v0, v1, v2, v3, r0, r1})
+// ListBySQL delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserExternalAccountsStore) ListBySQL(v0 context.Context, v1 *sqlf.Query) ([]*extsvc.Account, error) {
+	r0, r1 := m.ListBySQLFunc.nextHook()(v0, v1)
+	m.ListBySQLFunc.appendCall(UserExternalAccountsStoreListBySQLFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CreateUserAndSave
-// method of the parent MockUserExternalAccountsStore instance is invoked
-// and the hook queue is empty.
-func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) SetDefaultHook(hook func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error)) {
+// SetDefaultHook sets function that is called when the ListBySQL method of
+// the parent MockUserExternalAccountsStore instance is invoked and the hook
+// queue is empty.
+func (f *UserExternalAccountsStoreListBySQLFunc) SetDefaultHook(hook func(context.Context, *sqlf.Query) ([]*extsvc.Account, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CreateUserAndSave method of the parent MockUserExternalAccountsStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) PushHook(hook func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error)) {
+// ListBySQL method of the parent MockUserExternalAccountsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserExternalAccountsStoreListBySQLFunc) PushHook(hook func(context.Context, *sqlf.Query) ([]*extsvc.Account, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -34048,21 +37320,21 @@ func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) PushHook(hook func(cont
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) SetDefaultReturn(r0 int32, r1 error) {
-	f.SetDefaultHook(func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+func (f *UserExternalAccountsStoreListBySQLFunc) SetDefaultReturn(r0 []*extsvc.Account, r1 error) {
+	f.SetDefaultHook(func(context.Context, *sqlf.Query) ([]*extsvc.Account, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) PushReturn(r0 int32, r1 error) {
-	f.PushHook(func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+func (f *UserExternalAccountsStoreListBySQLFunc) PushReturn(r0 []*extsvc.Account, r1 error) {
+	f.PushHook(func(context.Context, *sqlf.Query) ([]*extsvc.Account, error) {
 		return r0, r1
 	})
 }
 
-func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) nextHook() func(context.Context, NewUser, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+func (f *UserExternalAccountsStoreListBySQLFunc) nextHook() func(context.Context, *sqlf.Query) ([]*extsvc.Account, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -34075,43 +37347,36 @@ func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) nextHook() func(context
 	return hook
 }
 
-func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) appendCall(r0 UserExternalAccountsStoreCreateUserAndSaveFuncCall) {
+func (f *UserExternalAccountsStoreListBySQLFunc) appendCall(r0 UserExternalAccountsStoreListBySQLFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// UserExternalAccountsStoreCreateUserAndSaveFuncCall objects describing the
-// invocations of this function.
-func (f *UserExternalAccountsStoreCreateUserAndSaveFunc) History() []UserExternalAccountsStoreCreateUserAndSaveFuncCall {
+// History returns a sequence of UserExternalAccountsStoreListBySQLFuncCall
+// objects describing the invocations of this function.
+func (f *UserExternalAccountsStoreListBySQLFunc) History() []UserExternalAccountsStoreListBySQLFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreCreateUserAndSaveFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreListBySQLFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreCreateUserAndSaveFuncCall is an object that
-// describes an invocation of method CreateUserAndSave on an instance of
+// UserExternalAccountsStoreListBySQLFuncCall is an object that describes an
+// invocation of method ListBySQL on an instance of
 // MockUserExternalAccountsStore.
-type UserExternalAccountsStoreCreateUserAndSaveFuncCall struct {
+type UserExternalAccountsStoreListBySQLFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 NewUser
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 extsvc.AccountSpec
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 extsvc.AccountData
+	Arg1 *sqlf.Query
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int32
+	Result0 []*extsvc.Account
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -34119,47 +37384,47 @@ type UserExternalAccountsStoreCreateUserAndSaveFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreCreateUserAndSaveFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c UserExternalAccountsStoreListBySQLFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreCreateUserAndSaveFuncCall) Results() []interface{} {
+func (c UserExternalAccountsStoreListBySQLFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserExternalAccountsStoreDeleteFunc describes the behavior when the
-// Delete method of the parent MockUserExternalAccountsStore instance is
-// invoked.
-type UserExternalAccountsStoreDeleteFunc struct {
-	defaultHook func(context.Context, int32) error
-	hooks       []func(context.Context, int32) error
-	history     []UserExternalAccountsStoreDeleteFuncCall
+// UserExternalAccountsStoreLookupUserAndSaveFunc describes the behavior
+// when the LookupUserAndSave method of the parent
+// MockUserExternalAccountsStore instance is invoked.
+type UserExternalAccountsStoreLookupUserAndSaveFunc struct {
+	defaultHook func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error)
+	hooks       []func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error)
+	history     []UserExternalAccountsStoreLookupUserAndSaveFuncCall
 	mutex       sync.Mutex
 }
 
-// Delete delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) Delete(v0 context.Context, v1 int32) error {
-	r0 := m.DeleteFunc.nextHook()(v0, v1)
-	m.DeleteFunc.appendCall(UserExternalAccountsStoreDeleteFuncCall{v0, v1, r0})
-	return r0
+// LookupUserAndSave delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserExternalAccountsStore) LookupUserAndSave(v0 context.Context, v1 extsvc.AccountSpec, v2 extsvc.AccountData) (int32, error) {
+	r0, r1 := m.LookupUserAndSaveFunc.nextHook()(v0, v1, v2)
+	m.LookupUserAndSaveFunc.appendCall(UserExternalAccountsStoreLookupUserAndSaveFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Delete method of the
-// parent MockUserExternalAccountsStore instance is invoked and the hook
-// queue is empty.
-func (f *UserExternalAccountsStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int32) error) {
+// SetDefaultHook sets function that is called when the LookupUserAndSave
+// method of the parent MockUserExternalAccountsStore instance is invoked
+// and the hook queue is empty.
+func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) SetDefaultHook(hook func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Delete method of the parent MockUserExternalAccountsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *UserExternalAccountsStoreDeleteFunc) PushHook(hook func(context.Context, int32) error) {
+// LookupUserAndSave method of the parent MockUserExternalAccountsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) PushHook(hook func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -34167,21 +37432,21 @@ func (f *UserExternalAccountsStoreDeleteFunc) PushHook(hook func(context.Context
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreDeleteFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32) error {
-		return r0
+func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) SetDefaultReturn(r0 int32, r1 error) {
+	f.SetDefaultHook(func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreDeleteFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32) error {
-		return r0
+func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) PushReturn(r0 int32, r1 error) {
+	f.PushHook(func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+		return r0, r1
 	})
 }
 
-func (f *UserExternalAccountsStoreDeleteFunc) nextHook() func(context.Context, int32) error {
+func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) nextHook() func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -34194,79 +37459,88 @@ func (f *UserExternalAccountsStoreDeleteFunc) nextHook() func(context.Context, i
 	return hook
 }
 
-func (f *UserExternalAccountsStoreDeleteFunc) appendCall(r0 UserExternalAccountsStoreDeleteFuncCall) {
+func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) appendCall(r0 UserExternalAccountsStoreLookupUserAndSaveFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserExternalAccountsStoreDeleteFuncCall
-// objects describing the invocations of this function.
-func (f *UserExternalAccountsStoreDeleteFunc) History() []UserExternalAccountsStoreDeleteFuncCall {
+// History returns a sequence of
+// UserExternalAccountsStoreLookupUserAndSaveFuncCall objects describing the
+// invocations of this function.
+func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) History() []UserExternalAccountsStoreLookupUserAndSaveFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreDeleteFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreLookupUserAndSaveFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreDeleteFuncCall is an object that describes an
-// invocation of method Delete on an instance of
+// UserExternalAccountsStoreLookupUserAndSaveFuncCall is an object that
+// describes an invocation of method LookupUserAndSave on an instance of
 // MockUserExternalAccountsStore.
-type UserExternalAccountsStoreDeleteFuncCall struct {
+type UserExternalAccountsStoreLookupUserAndSaveFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 extsvc.AccountSpec
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 extsvc.AccountData
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 int32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreDeleteFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c UserExternalAccountsStoreLookupUserAndSaveFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreDeleteFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c UserExternalAccountsStoreLookupUserAndSaveFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserExternalAccountsStoreDoneFunc describes the behavior when the Done
-// method of the parent MockUserExternalAccountsStore instance is invoked.
-type UserExternalAccountsStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []UserExternalAccountsStoreDoneFuncCall
+// UserExternalAccountsStoreQueryRowFunc describes the behavior when the
+// QueryRow method of the parent MockUserExternalAccountsStore instance is
+// invoked.
+type UserExternalAccountsStoreQueryRowFunc struct {
+	defaultHook func(context.Context, *sqlf.Query) *sql.Row
+	hooks       []func(context.Context, *sqlf.Query) *sql.Row
+	history     []UserExternalAccountsStoreQueryRowFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
+// QueryRow delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(UserExternalAccountsStoreDoneFuncCall{v0, r0})
+func (m *MockUserExternalAccountsStore) QueryRow(v0 context.Context, v1 *sqlf.Query) *sql.Row {
+	r0 := m.QueryRowFunc.nextHook()(v0, v1)
+	m.QueryRowFunc.appendCall(UserExternalAccountsStoreQueryRowFuncCall{v0, v1, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
-// parent MockUserExternalAccountsStore instance is invoked and the hook
+// SetDefaultHook sets function that is called when the QueryRow method of
+// the parent MockUserExternalAccountsStore instance is invoked and the hook
 // queue is empty.
-func (f *UserExternalAccountsStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+func (f *UserExternalAccountsStoreQueryRowFunc) SetDefaultHook(hook func(context.Context, *sqlf.Query) *sql.Row) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Done method of the parent MockUserExternalAccountsStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *UserExternalAccountsStoreDoneFunc) PushHook(hook func(error) error) {
+// QueryRow method of the parent MockUserExternalAccountsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserExternalAccountsStoreQueryRowFunc) PushHook(hook func(context.Context, *sqlf.Query) *sql.Row) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -34274,21 +37548,21 @@ func (f *UserExternalAccountsStoreDoneFunc) PushHook(hook func(error) error) {
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreDoneFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(error) error {
+func (f *UserExternalAccountsStoreQueryRowFunc) SetDefaultReturn(r0 *sql.Row) {
+	f.SetDefaultHook(func(context.Context, *sqlf.Query) *sql.Row {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreDoneFunc) PushReturn(r0 error) {
-	f.PushHook(func(error) error {
+func (f *UserExternalAccountsStoreQueryRowFunc) PushReturn(r0 *sql.Row) {
+	f.PushHook(func(context.Context, *sqlf.Query) *sql.Row {
 		return r0
 	})
 }
 
-func (f *UserExternalAccountsStoreDoneFunc) nextHook() func(error) error {
+func (f *UserExternalAccountsStoreQueryRowFunc) nextHook() func(context.Context, *sqlf.Query) *sql.Row {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -34301,78 +37575,81 @@ func (f *UserExternalAccountsStoreDoneFunc) nextHook() func(error) error {
 	return hook
 }
 
-func (f *UserExternalAccountsStoreDoneFunc) appendCall(r0 UserExternalAccountsStoreDoneFuncCall) {
+func (f *UserExternalAccountsStoreQueryRowFunc) appendCall(r0 UserExternalAccountsStoreQueryRowFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserExternalAccountsStoreDoneFuncCall
+// History returns a sequence of UserExternalAccountsStoreQueryRowFuncCall
 // objects describing the invocations of this function.
-func (f *UserExternalAccountsStoreDoneFunc) History() []UserExternalAccountsStoreDoneFuncCall {
+func (f *UserExternalAccountsStoreQueryRowFunc) History() []UserExternalAccountsStoreQueryRowFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreDoneFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreQueryRowFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreDoneFuncCall is an object that describes an
-// invocation of method Done on an instance of
+// UserExternalAccountsStoreQueryRowFuncCall is an object that describes an
+// invocation of method QueryRow on an instance of
 // MockUserExternalAccountsStore.
-type UserExternalAccountsStoreDoneFuncCall struct {
+type UserExternalAccountsStoreQueryRowFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 error
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *sqlf.Query
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *sql.Row
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreDoneFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c UserExternalAccountsStoreQueryRowFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreDoneFuncCall) Results() []interface{} {
+func (c UserExternalAccountsStoreQueryRowFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserExternalAccountsStoreExecResultFunc describes the behavior when the
-// ExecResult method of the parent MockUserExternalAccountsStore instance is
-// invoked.
-type UserExternalAccountsStoreExecResultFunc struct {
-	defaultHook func(context.Context, *sqlf.Query) (sql.Result, error)
-	hooks       []func(context.Context, *sqlf.Query) (sql.Result, error)
-	history     []UserExternalAccountsStoreExecResultFuncCall
+// UserExternalAccountsStoreTouchExpiredFunc describes the behavior when the
+// TouchExpired method of the parent MockUserExternalAccountsStore instance
+// is invoked.
+type UserExternalAccountsStoreTouchExpiredFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []UserExternalAccountsStoreTouchExpiredFuncCall
 	mutex       sync.Mutex
 }
 
-// ExecResult delegates to the next hook function in the queue and stores
+// TouchExpired delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) ExecResult(v0 context.Context, v1 *sqlf.Query) (sql.Result, error) {
-	r0, r1 := m.ExecResultFunc.nextHook()(v0, v1)
-	m.ExecResultFunc.appendCall(UserExternalAccountsStoreExecResultFuncCall{v0, v1, r0, r1})
-	return r0, r1
+func (m *MockUserExternalAccountsStore) TouchExpired(v0 context.Context, v1 int32) error {
+	r0 := m.TouchExpiredFunc.nextHook()(v0, v1)
+	m.TouchExpiredFunc.appendCall(UserExternalAccountsStoreTouchExpiredFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the ExecResult method of
-// the parent MockUserExternalAccountsStore instance is invoked and the hook
-// queue is empty.
-func (f *UserExternalAccountsStoreExecResultFunc) SetDefaultHook(hook func(context.Context, *sqlf.Query) (sql.Result, error)) {
+// SetDefaultHook sets function that is called when the TouchExpired method
+// of the parent MockUserExternalAccountsStore instance is invoked and the
+// hook queue is empty.
+func (f *UserExternalAccountsStoreTouchExpiredFunc) SetDefaultHook(hook func(context.Context, int32) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ExecResult method of the parent MockUserExternalAccountsStore instance
+// TouchExpired method of the parent MockUserExternalAccountsStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *UserExternalAccountsStoreExecResultFunc) PushHook(hook func(context.Context, *sqlf.Query) (sql.Result, error)) {
+func (f *UserExternalAccountsStoreTouchExpiredFunc) PushHook(hook func(context.Context, int32) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -34380,21 +37657,21 @@ func (f *UserExternalAccountsStoreExecResultFunc) PushHook(hook func(context.Con
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreExecResultFunc) SetDefaultReturn(r0 sql.Result, r1 error) {
-	f.SetDefaultHook(func(context.Context, *sqlf.Query) (sql.Result, error) {
-		return r0, r1
+func (f *UserExternalAccountsStoreTouchExpiredFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreExecResultFunc) PushReturn(r0 sql.Result, r1 error) {
-	f.PushHook(func(context.Context, *sqlf.Query) (sql.Result, error) {
-		return r0, r1
+func (f *UserExternalAccountsStoreTouchExpiredFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
+		return r0
 	})
 }
 
-func (f *UserExternalAccountsStoreExecResultFunc) nextHook() func(context.Context, *sqlf.Query) (sql.Result, error) {
+func (f *UserExternalAccountsStoreTouchExpiredFunc) nextHook() func(context.Context, int32) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -34407,82 +37684,82 @@ func (f *UserExternalAccountsStoreExecResultFunc) nextHook() func(context.Contex
 	return hook
 }
 
-func (f *UserExternalAccountsStoreExecResultFunc) appendCall(r0 UserExternalAccountsStoreExecResultFuncCall) {
+func (f *UserExternalAccountsStoreTouchExpiredFunc) appendCall(r0 UserExternalAccountsStoreTouchExpiredFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserExternalAccountsStoreExecResultFuncCall
-// objects describing the invocations of this function.
-func (f *UserExternalAccountsStoreExecResultFunc) History() []UserExternalAccountsStoreExecResultFuncCall {
+// History returns a sequence of
+// UserExternalAccountsStoreTouchExpiredFuncCall objects describing the
+// invocations of this function.
+func (f *UserExternalAccountsStoreTouchExpiredFunc) History() []UserExternalAccountsStoreTouchExpiredFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreExecResultFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreTouchExpiredFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreExecResultFuncCall is an object that describes
-// an invocation of method ExecResult on an instance of
+// UserExternalAccountsStoreTouchExpiredFuncCall is an object that describes
+// an invocation of method TouchExpired on an instance of
 // MockUserExternalAccountsStore.
-type UserExternalAccountsStoreExecResultFuncCall struct {
+type UserExternalAccountsStoreTouchExpiredFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *sqlf.Query
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 sql.Result
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreExecResultFuncCall) Args() []interface{} {
+func (c UserExternalAccountsStoreTouchExpiredFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreExecResultFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c UserExternalAccountsStoreTouchExpiredFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// UserExternalAccountsStoreGetFunc describes the behavior when the Get
-// method of the parent MockUserExternalAccountsStore instance is invoked.
-type UserExternalAccountsStoreGetFunc struct {
-	defaultHook func(context.Context, int32) (*extsvc.Account, error)
-	hooks       []func(context.Context, int32) (*extsvc.Account, error)
-	history     []UserExternalAccountsStoreGetFuncCall
+// UserExternalAccountsStoreTouchLastValidFunc describes the behavior when
+// the TouchLastValid method of the parent MockUserExternalAccountsStore
+// instance is invoked.
+type UserExternalAccountsStoreTouchLastValidFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []UserExternalAccountsStoreTouchLastValidFuncCall
 	mutex       sync.Mutex
 }
 
-// Get delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) Get(v0 context.Context, v1 int32) (*extsvc.Account, error) {
-	r0, r1 := m.GetFunc.nextHook()(v0, v1)
-	m.GetFunc.appendCall(UserExternalAccountsStoreGetFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// TouchLastValid delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserExternalAccountsStore) TouchLastValid(v0 context.Context, v1 int32) error {
+	r0 := m.TouchLastValidFunc.nextHook()(v0, v1)
+	m.TouchLastValidFunc.appendCall(UserExternalAccountsStoreTouchLastValidFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Get method of the
-// parent MockUserExternalAccountsStore instance is invoked and the hook
-// queue is empty.
-func (f *UserExternalAccountsStoreGetFunc) SetDefaultHook(hook func(context.Context, int32) (*extsvc.Account, error)) {
+// SetDefaultHook sets function that is called when the TouchLastValid
+// method of the parent MockUserExternalAccountsStore instance is invoked
+// and the hook queue is empty.
+func (f *UserExternalAccountsStoreTouchLastValidFunc) SetDefaultHook(hook func(context.Context, int32) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Get method of the parent MockUserExternalAccountsStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *UserExternalAccountsStoreGetFunc) PushHook(hook func(context.Context, int32) (*extsvc.Account, error)) {
+// TouchLastValid method of the parent MockUserExternalAccountsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserExternalAccountsStoreTouchLastValidFunc) PushHook(hook func(context.Context, int32) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -34490,21 +37767,21 @@ func (f *UserExternalAccountsStoreGetFunc) PushHook(hook func(context.Context, i
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreGetFunc) SetDefaultReturn(r0 *extsvc.Account, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) (*extsvc.Account, error) {
-		return r0, r1
+func (f *UserExternalAccountsStoreTouchLastValidFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreGetFunc) PushReturn(r0 *extsvc.Account, r1 error) {
-	f.PushHook(func(context.Context, int32) (*extsvc.Account, error) {
-		return r0, r1
+func (f *UserExternalAccountsStoreTouchLastValidFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
+		return r0
 	})
 }
 
-func (f *UserExternalAccountsStoreGetFunc) nextHook() func(context.Context, int32) (*extsvc.Account, error) {
+func (f *UserExternalAccountsStoreTouchLastValidFunc) nextHook() func(context.Context, int32) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -34517,26 +37794,28 @@ func (f *UserExternalAccountsStoreGetFunc) nextHook() func(context.Context, int3
 	return hook
 }
 
-func (f *UserExternalAccountsStoreGetFunc) appendCall(r0 UserExternalAccountsStoreGetFuncCall) {
+func (f *UserExternalAccountsStoreTouchLastValidFunc) appendCall(r0 UserExternalAccountsStoreTouchLastValidFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserExternalAccountsStoreGetFuncCall
-// objects describing the invocations of this function.
-func (f *UserExternalAccountsStoreGetFunc) History() []UserExternalAccountsStoreGetFuncCall {
+// History returns a sequence of
+// UserExternalAccountsStoreTouchLastValidFuncCall objects describing the
+// invocations of this function.
+func (f *UserExternalAccountsStoreTouchLastValidFunc) History() []UserExternalAccountsStoreTouchLastValidFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreGetFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreTouchLastValidFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreGetFuncCall is an object that describes an
-// invocation of method Get on an instance of MockUserExternalAccountsStore.
-type UserExternalAccountsStoreGetFuncCall struct {
+// UserExternalAccountsStoreTouchLastValidFuncCall is an object that
+// describes an invocation of method TouchLastValid on an instance of
+// MockUserExternalAccountsStore.
+type UserExternalAccountsStoreTouchLastValidFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -34545,55 +37824,52 @@ type UserExternalAccountsStoreGetFuncCall struct {
 	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *extsvc.Account
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreGetFuncCall) Args() []interface{} {
+func (c UserExternalAccountsStoreTouchLastValidFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreGetFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c UserExternalAccountsStoreTouchLastValidFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// UserExternalAccountsStoreHandleFunc describes the behavior when the
-// Handle method of the parent MockUserExternalAccountsStore instance is
+// UserExternalAccountsStoreTransactFunc describes the behavior when the
+// Transact method of the parent MockUserExternalAccountsStore instance is
 // invoked.
-type UserExternalAccountsStoreHandleFunc struct {
-	defaultHook func() *basestore.TransactableHandle
-	hooks       []func() *basestore.TransactableHandle
-	history     []UserExternalAccountsStoreHandleFuncCall
+type UserExternalAccountsStoreTransactFunc struct {
+	defaultHook func(context.Context) (UserExternalAccountsStore, error)
+	hooks       []func(context.Context) (UserExternalAccountsStore, error)
+	history     []UserExternalAccountsStoreTransactFuncCall
 	mutex       sync.Mutex
 }
 
-// Handle delegates to the next hook function in the queue and stores the
+// Transact delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) Handle() *basestore.TransactableHandle {
-	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(UserExternalAccountsStoreHandleFuncCall{r0})
-	return r0
+func (m *MockUserExternalAccountsStore) Transact(v0 context.Context) (UserExternalAccountsStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(UserExternalAccountsStoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Handle method of the
-// parent MockUserExternalAccountsStore instance is invoked and the hook
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockUserExternalAccountsStore instance is invoked and the hook
 // queue is empty.
-func (f *UserExternalAccountsStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
+func (f *UserExternalAccountsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (UserExternalAccountsStore, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockUserExternalAccountsStore instance
+// Transact method of the parent MockUserExternalAccountsStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *UserExternalAccountsStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
+func (f *UserExternalAccountsStoreTransactFunc) PushHook(hook func(context.Context) (UserExternalAccountsStore, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -34601,21 +37877,21 @@ func (f *UserExternalAccountsStoreHandleFunc) PushHook(hook func() *basestore.Tr
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
-	f.SetDefaultHook(func() *basestore.TransactableHandle {
-		return r0
+func (f *UserExternalAccountsStoreTransactFunc) SetDefaultReturn(r0 UserExternalAccountsStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (UserExternalAccountsStore, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
-	f.PushHook(func() *basestore.TransactableHandle {
-		return r0
+func (f *UserExternalAccountsStoreTransactFunc) PushReturn(r0 UserExternalAccountsStore, r1 error) {
+	f.PushHook(func(context.Context) (UserExternalAccountsStore, error) {
+		return r0, r1
 	})
 }
 
-func (f *UserExternalAccountsStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
+func (f *UserExternalAccountsStoreTransactFunc) nextHook() func(context.Context) (UserExternalAccountsStore, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -34628,75 +37904,79 @@ func (f *UserExternalAccountsStoreHandleFunc) nextHook() func() *basestore.Trans
 	return hook
 }
 
-func (f *UserExternalAccountsStoreHandleFunc) appendCall(r0 UserExternalAccountsStoreHandleFuncCall) {
+func (f *UserExternalAccountsStoreTransactFunc) appendCall(r0 UserExternalAccountsStoreTransactFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserExternalAccountsStoreHandleFuncCall
+// History returns a sequence of UserExternalAccountsStoreTransactFuncCall
 // objects describing the invocations of this function.
-func (f *UserExternalAccountsStoreHandleFunc) History() []UserExternalAccountsStoreHandleFuncCall {
+func (f *UserExternalAccountsStoreTransactFunc) History() []UserExternalAccountsStoreTransactFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreHandleFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreTransactFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreHandleFuncCall is an object that describes an
-// invocation of method Handle on an instance of
+// UserExternalAccountsStoreTransactFuncCall is an object that describes an
+// invocation of method Transact on an instance of
 // MockUserExternalAccountsStore.
-type UserExternalAccountsStoreHandleFuncCall struct {
+type UserExternalAccountsStoreTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *basestore.TransactableHandle
+	Result0 UserExternalAccountsStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreHandleFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c UserExternalAccountsStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreHandleFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c UserExternalAccountsStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserExternalAccountsStoreInsertFunc describes the behavior when the
-// Insert method of the parent MockUserExternalAccountsStore instance is
-// invoked.
-type UserExternalAccountsStoreInsertFunc struct {
-	defaultHook func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error
-	hooks       []func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error
-	history     []UserExternalAccountsStoreInsertFuncCall
+// UserExternalAccountsStoreWithFunc describes the behavior when the With
+// method of the parent MockUserExternalAccountsStore instance is invoked.
+type UserExternalAccountsStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) UserExternalAccountsStore
+	hooks       []func(basestore.ShareableStore) UserExternalAccountsStore
+	history     []UserExternalAccountsStoreWithFuncCall
 	mutex       sync.Mutex
 }
 
-// Insert delegates to the next hook function in the queue and stores the
+// With delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) Insert(v0 context.Context, v1 int32, v2 extsvc.AccountSpec, v3 extsvc.AccountData) error {
-	r0 := m.InsertFunc.nextHook()(v0, v1, v2, v3)
-	m.InsertFunc.appendCall(UserExternalAccountsStoreInsertFuncCall{v0, v1, v2, v3, r0})
+func (m *MockUserExternalAccountsStore) With(v0 basestore.ShareableStore) UserExternalAccountsStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(UserExternalAccountsStoreWithFuncCall{v0, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Insert method of the
+// SetDefaultHook sets function that is called when the With method of the
 // parent MockUserExternalAccountsStore instance is invoked and the hook
 // queue is empty.
-func (f *UserExternalAccountsStoreInsertFunc) SetDefaultHook(hook func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error) {
+func (f *UserExternalAccountsStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) UserExternalAccountsStore) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Insert method of the parent MockUserExternalAccountsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *UserExternalAccountsStoreInsertFunc) PushHook(hook func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error) {
+// With method of the parent MockUserExternalAccountsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserExternalAccountsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) UserExternalAccountsStore) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -34704,21 +37984,21 @@ func (f *UserExternalAccountsStoreInsertFunc) PushHook(hook func(context.Context
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreInsertFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
+func (f *UserExternalAccountsStoreWithFunc) SetDefaultReturn(r0 UserExternalAccountsStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) UserExternalAccountsStore {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreInsertFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
+func (f *UserExternalAccountsStoreWithFunc) PushReturn(r0 UserExternalAccountsStore) {
+	f.PushHook(func(basestore.ShareableStore) UserExternalAccountsStore {
 		return r0
 	})
 }
 
-func (f *UserExternalAccountsStoreInsertFunc) nextHook() func(context.Context, int32, extsvc.AccountSpec, extsvc.AccountData) error {
+func (f *UserExternalAccountsStoreWithFunc) nextHook() func(basestore.ShareableStore) UserExternalAccountsStore {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -34731,85 +38011,78 @@ func (f *UserExternalAccountsStoreInsertFunc) nextHook() func(context.Context, i
 	return hook
 }
 
-func (f *UserExternalAccountsStoreInsertFunc) appendCall(r0 UserExternalAccountsStoreInsertFuncCall) {
+func (f *UserExternalAccountsStoreWithFunc) appendCall(r0 UserExternalAccountsStoreWithFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserExternalAccountsStoreInsertFuncCall
+// History returns a sequence of UserExternalAccountsStoreWithFuncCall
 // objects describing the invocations of this function.
-func (f *UserExternalAccountsStoreInsertFunc) History() []UserExternalAccountsStoreInsertFuncCall {
+func (f *UserExternalAccountsStoreWithFunc) History() []UserExternalAccountsStoreWithFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreInsertFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreWithFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreInsertFuncCall is an object that describes an
-// invocation of method Insert on an instance of
+// UserExternalAccountsStoreWithFuncCall is an object that describes an
+// invocation of method With on an instance of
 // MockUserExternalAccountsStore.
-type UserExternalAccountsStoreInsertFuncCall struct {
+type UserExternalAccountsStoreWithFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int32
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 extsvc.AccountSpec
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 extsvc.AccountData
+	Arg0 basestore.ShareableStore
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 UserExternalAccountsStore
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreInsertFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c UserExternalAccountsStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreInsertFuncCall) Results() []interface{} {
+func (c UserExternalAccountsStoreWithFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserExternalAccountsStoreListFunc describes the behavior when the List
-// method of the parent MockUserExternalAccountsStore instance is invoked.
-type UserExternalAccountsStoreListFunc struct {
-	defaultHook func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error)
-	hooks       []func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error)
-	history     []UserExternalAccountsStoreListFuncCall
+// UserExternalAccountsStoreWithEncryptionKeyFunc describes the behavior
+// when the WithEncryptionKey method of the parent
+// MockUserExternalAccountsStore instance is invoked.
+type UserExternalAccountsStoreWithEncryptionKeyFunc struct {
+	defaultHook func(encryption.Key) UserExternalAccountsStore
+	hooks       []func(encryption.Key) UserExternalAccountsStore
+	history     []UserExternalAccountsStoreWithEncryptionKeyFuncCall
 	mutex       sync.Mutex
 }
 
-// List delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) List(v0 context.Context, v1 ExternalAccountsListOptions) ([]*extsvc.Account, error) {
-	r0, r1 := m.ListFunc.nextHook()(v0, v1)
-	m.ListFunc.appendCall(UserExternalAccountsStoreListFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// WithEncryptionKey delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserExternalAccountsStore) WithEncryptionKey(v0 encryption.Key) UserExternalAccountsStore {
+	r0 := m.WithEncryptionKeyFunc.nextHook()(v0)
+	m.WithEncryptionKeyFunc.appendCall(UserExternalAccountsStoreWithEncryptionKeyFuncCall{v0, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the List method of the
-// parent MockUserExternalAccountsStore instance is invoked and the hook
-// queue is empty.
-func (f *UserExternalAccountsStoreListFunc) SetDefaultHook(hook func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error)) {
+// SetDefaultHook sets function that is called when the WithEncryptionKey
+// method of the parent MockUserExternalAccountsStore instance is invoked
+// and the hook queue is empty.
+func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) SetDefaultHook(hook func(encryption.Key) UserExternalAccountsStore) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// List method of the parent MockUserExternalAccountsStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *UserExternalAccountsStoreListFunc) PushHook(hook func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error)) {
+// WithEncryptionKey method of the parent MockUserExternalAccountsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) PushHook(hook func(encryption.Key) UserExternalAccountsStore) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -34817,21 +38090,21 @@ func (f *UserExternalAccountsStoreListFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreListFunc) SetDefaultReturn(r0 []*extsvc.Account, r1 error) {
-	f.SetDefaultHook(func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error) {
-		return r0, r1
+func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) SetDefaultReturn(r0 UserExternalAccountsStore) {
+	f.SetDefaultHook(func(encryption.Key) UserExternalAccountsStore {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreListFunc) PushReturn(r0 []*extsvc.Account, r1 error) {
-	f.PushHook(func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error) {
-		return r0, r1
+func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) PushReturn(r0 UserExternalAccountsStore) {
+	f.PushHook(func(encryption.Key) UserExternalAccountsStore {
+		return r0
 	})
 }
 
-func (f *UserExternalAccountsStoreListFunc) nextHook() func(context.Context, ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) nextHook() func(encryption.Key) UserExternalAccountsStore {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -34844,84 +38117,172 @@ func (f *UserExternalAccountsStoreListFunc) nextHook() func(context.Context, Ext
 	return hook
 }
 
-func (f *UserExternalAccountsStoreListFunc) appendCall(r0 UserExternalAccountsStoreListFuncCall) {
+func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) appendCall(r0 UserExternalAccountsStoreWithEncryptionKeyFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserExternalAccountsStoreListFuncCall
-// objects describing the invocations of this function.
-func (f *UserExternalAccountsStoreListFunc) History() []UserExternalAccountsStoreListFuncCall {
+// History returns a sequence of
+// UserExternalAccountsStoreWithEncryptionKeyFuncCall objects describing the
+// invocations of this function.
+func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) History() []UserExternalAccountsStoreWithEncryptionKeyFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreListFuncCall, len(f.history))
+	history := make([]UserExternalAccountsStoreWithEncryptionKeyFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreListFuncCall is an object that describes an
-// invocation of method List on an instance of
+// UserExternalAccountsStoreWithEncryptionKeyFuncCall is an object that
+// describes an invocation of method WithEncryptionKey on an instance of
 // MockUserExternalAccountsStore.
-type UserExternalAccountsStoreListFuncCall struct {
+type UserExternalAccountsStoreWithEncryptionKeyFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 ExternalAccountsListOptions
+	Arg0 encryption.Key
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*extsvc.Account
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 UserExternalAccountsStore
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreListFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c UserExternalAccountsStoreWithEncryptionKeyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserExternalAccountsStoreWithEncryptionKeyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockUserPublicRepoStore is a mock implementation of the
+// UserPublicRepoStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockUserPublicRepoStore struct {
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *UserPublicRepoStoreHandleFunc
+	// ListByUserFunc is an instance of a mock function object controlling
+	// the behavior of the method ListByUser.
+	ListByUserFunc *UserPublicRepoStoreListByUserFunc
+	// SetUserRepoFunc is an instance of a mock function object controlling
+	// the behavior of the method SetUserRepo.
+	SetUserRepoFunc *UserPublicRepoStoreSetUserRepoFunc
+	// SetUserReposFunc is an instance of a mock function object controlling
+	// the behavior of the method SetUserRepos.
+	SetUserReposFunc *UserPublicRepoStoreSetUserReposFunc
+}
+
+// NewMockUserPublicRepoStore creates a new mock of the UserPublicRepoStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockUserPublicRepoStore() *MockUserPublicRepoStore {
+	return &MockUserPublicRepoStore{
+		HandleFunc: &UserPublicRepoStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				return nil
+			},
+		},
+		ListByUserFunc: &UserPublicRepoStoreListByUserFunc{
+			defaultHook: func(context.Context, int32) ([]UserPublicRepo, error) {
+				return nil, nil
+			},
+		},
+		SetUserRepoFunc: &UserPublicRepoStoreSetUserRepoFunc{
+			defaultHook: func(context.Context, UserPublicRepo) error {
+				return nil
+			},
+		},
+		SetUserReposFunc: &UserPublicRepoStoreSetUserReposFunc{
+			defaultHook: func(context.Context, int32, []UserPublicRepo) error {
+				return nil
+			},
+		},
+	}
+}
+
+// NewStrictMockUserPublicRepoStore creates a new mock of the
+// UserPublicRepoStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockUserPublicRepoStore() *MockUserPublicRepoStore {
+	return &MockUserPublicRepoStore{
+		HandleFunc: &UserPublicRepoStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				panic("unexpected invocation of MockUserPublicRepoStore.Handle")
+			},
+		},
+		ListByUserFunc: &UserPublicRepoStoreListByUserFunc{
+			defaultHook: func(context.Context, int32) ([]UserPublicRepo, error) {
+				panic("unexpected invocation of MockUserPublicRepoStore.ListByUser")
+			},
+		},
+		SetUserRepoFunc: &UserPublicRepoStoreSetUserRepoFunc{
+			defaultHook: func(context.Context, UserPublicRepo) error {
+				panic("unexpected invocation of MockUserPublicRepoStore.SetUserRepo")
+			},
+		},
+		SetUserReposFunc: &UserPublicRepoStoreSetUserReposFunc{
+			defaultHook: func(context.Context, int32, []UserPublicRepo) error {
+				panic("unexpected invocation of MockUserPublicRepoStore.SetUserRepos")
+			},
+		},
+	}
 }
 
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c UserExternalAccountsStoreListFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+// NewMockUserPublicRepoStoreFrom creates a new mock of the
+// MockUserPublicRepoStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockUserPublicRepoStoreFrom(i UserPublicRepoStore) *MockUserPublicRepoStore {
+	return &MockUserPublicRepoStore{
+		HandleFunc: &UserPublicRepoStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		ListByUserFunc: &UserPublicRepoStoreListByUserFunc{
+			defaultHook: i.ListByUser,
+		},
+		SetUserRepoFunc: &UserPublicRepoStoreSetUserRepoFunc{
+			defaultHook: i.SetUserRepo,
+		},
+		SetUserReposFunc: &UserPublicRepoStoreSetUserReposFunc{
+			defaultHook: i.SetUserRepos,
+		},
+	}
 }
 
-// UserExternalAccountsStoreListBySQLFunc describes the behavior when the
-// ListBySQL method of the parent MockUserExternalAccountsStore instance is
-// invoked.
-type UserExternalAccountsStoreListBySQLFunc struct {
-	defaultHook func(context.Context, *sqlf.Query) ([]*extsvc.Account, error)
-	hooks       []func(context.Context, *sqlf.Query) ([]*extsvc.Account, error)
-	history     []UserExternalAccountsStoreListBySQLFuncCall
+// UserPublicRepoStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockUserPublicRepoStore instance is invoked.
+type UserPublicRepoStoreHandleFunc struct {
+	defaultHook func() *basestore.TransactableHandle
+	hooks       []func() *basestore.TransactableHandle
+	history     []UserPublicRepoStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
-// ListBySQL delegates to the next hook function in the queue and stores the
+// Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) ListBySQL(v0 context.Context, v1 *sqlf.Query) ([]*extsvc.Account, error) {
-	r0, r1 := m.ListBySQLFunc.nextHook()(v0, v1)
-	m.ListBySQLFunc.appendCall(UserExternalAccountsStoreListBySQLFuncCall{v0, v1, r0, r1})
-	return r0, r1
+func (m *MockUserPublicRepoStore) Handle() *basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(UserPublicRepoStoreHandleFuncCall{r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the ListBySQL method of
-// the parent MockUserExternalAccountsStore instance is invoked and the hook
-// queue is empty.
-func (f *UserExternalAccountsStoreListBySQLFunc) SetDefaultHook(hook func(context.Context, *sqlf.Query) ([]*extsvc.Account, error)) {
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockUserPublicRepoStore instance is invoked and the hook queue is
+// empty.
+func (f *UserPublicRepoStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListBySQL method of the parent MockUserExternalAccountsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *UserExternalAccountsStoreListBySQLFunc) PushHook(hook func(context.Context, *sqlf.Query) ([]*extsvc.Account, error)) {
+// Handle method of the parent MockUserPublicRepoStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserPublicRepoStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -34929,21 +38290,21 @@ func (f *UserExternalAccountsStoreListBySQLFunc) PushHook(hook func(context.Cont
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreListBySQLFunc) SetDefaultReturn(r0 []*extsvc.Account, r1 error) {
-	f.SetDefaultHook(func(context.Context, *sqlf.Query) ([]*extsvc.Account, error) {
-		return r0, r1
+func (f *UserPublicRepoStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+	f.SetDefaultHook(func() *basestore.TransactableHandle {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreListBySQLFunc) PushReturn(r0 []*extsvc.Account, r1 error) {
-	f.PushHook(func(context.Context, *sqlf.Query) ([]*extsvc.Account, error) {
-		return r0, r1
+func (f *UserPublicRepoStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+	f.PushHook(func() *basestore.TransactableHandle {
+		return r0
 	})
 }
 
-func (f *UserExternalAccountsStoreListBySQLFunc) nextHook() func(context.Context, *sqlf.Query) ([]*extsvc.Account, error) {
+func (f *UserPublicRepoStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -34956,84 +38317,73 @@ func (f *UserExternalAccountsStoreListBySQLFunc) nextHook() func(context.Context
 	return hook
 }
 
-func (f *UserExternalAccountsStoreListBySQLFunc) appendCall(r0 UserExternalAccountsStoreListBySQLFuncCall) {
+func (f *UserPublicRepoStoreHandleFunc) appendCall(r0 UserPublicRepoStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserExternalAccountsStoreListBySQLFuncCall
-// objects describing the invocations of this function.
-func (f *UserExternalAccountsStoreListBySQLFunc) History() []UserExternalAccountsStoreListBySQLFuncCall {
+// History returns a sequence of UserPublicRepoStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *UserPublicRepoStoreHandleFunc) History() []UserPublicRepoStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreListBySQLFuncCall, len(f.history))
+	history := make([]UserPublicRepoStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreListBySQLFuncCall is an object that describes an
-// invocation of method ListBySQL on an instance of
-// MockUserExternalAccountsStore.
-type UserExternalAccountsStoreListBySQLFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 *sqlf.Query
+// UserPublicRepoStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockUserPublicRepoStore.
+type UserPublicRepoStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*extsvc.Account
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 *basestore.TransactableHandle
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreListBySQLFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c UserPublicRepoStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreListBySQLFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c UserPublicRepoStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// UserExternalAccountsStoreLookupUserAndSaveFunc describes the behavior
-// when the LookupUserAndSave method of the parent
-// MockUserExternalAccountsStore instance is invoked.
-type UserExternalAccountsStoreLookupUserAndSaveFunc struct {
-	defaultHook func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error)
-	hooks       []func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error)
-	history     []UserExternalAccountsStoreLookupUserAndSaveFuncCall
+// UserPublicRepoStoreListByUserFunc describes the behavior when the
+// ListByUser method of the parent MockUserPublicRepoStore instance is
+// invoked.
+type UserPublicRepoStoreListByUserFunc struct {
+	defaultHook func(context.Context, int32) ([]UserPublicRepo, error)
+	hooks       []func(context.Context, int32) ([]UserPublicRepo, error)
+	history     []UserPublicRepoStoreListByUserFuncCall
 	mutex       sync.Mutex
 }
 
-// LookupUserAndSave delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) LookupUserAndSave(v0 context.Context, v1 extsvc.AccountSpec, v2 extsvc.AccountData) (int32, error) {
-	r0, r1 := m.LookupUserAndSaveFunc.nextHook()(v0, v1, v2)
-	m.LookupUserAndSaveFunc.appendCall(UserExternalAccountsStoreLookupUserAndSaveFuncCall{v0, v1, v2, r0, r1})
+// ListByUser delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUserPublicRepoStore) ListByUser(v0 context.Context, v1 int32) ([]UserPublicRepo, error) {
+	r0, r1 := m.ListByUserFunc.nextHook()(v0, v1)
+	m.ListByUserFunc.appendCall(UserPublicRepoStoreListByUserFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the LookupUserAndSave
-// method of the parent MockUserExternalAccountsStore instance is invoked
-// and the hook queue is empty.
-func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) SetDefaultHook(hook func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error)) {
+// SetDefaultHook sets function that is called when the ListByUser method of
+// the parent MockUserPublicRepoStore instance is invoked and the hook queue
+// is empty.
+func (f *UserPublicRepoStoreListByUserFunc) SetDefaultHook(hook func(context.Context, int32) ([]UserPublicRepo, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// LookupUserAndSave method of the parent MockUserExternalAccountsStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) PushHook(hook func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error)) {
+// ListByUser method of the parent MockUserPublicRepoStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserPublicRepoStoreListByUserFunc) PushHook(hook func(context.Context, int32) ([]UserPublicRepo, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -35041,21 +38391,21 @@ func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) PushHook(hook func(cont
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) SetDefaultReturn(r0 int32, r1 error) {
-	f.SetDefaultHook(func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+func (f *UserPublicRepoStoreListByUserFunc) SetDefaultReturn(r0 []UserPublicRepo, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]UserPublicRepo, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) PushReturn(r0 int32, r1 error) {
-	f.PushHook(func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+func (f *UserPublicRepoStoreListByUserFunc) PushReturn(r0 []UserPublicRepo, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]UserPublicRepo, error) {
 		return r0, r1
 	})
 }
 
-func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) nextHook() func(context.Context, extsvc.AccountSpec, extsvc.AccountData) (int32, error) {
+func (f *UserPublicRepoStoreListByUserFunc) nextHook() func(context.Context, int32) ([]UserPublicRepo, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -35068,40 +38418,36 @@ func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) nextHook() func(context
 	return hook
 }
 
-func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) appendCall(r0 UserExternalAccountsStoreLookupUserAndSaveFuncCall) {
+func (f *UserPublicRepoStoreListByUserFunc) appendCall(r0 UserPublicRepoStoreListByUserFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// UserExternalAccountsStoreLookupUserAndSaveFuncCall objects describing the
-// invocations of this function.
-func (f *UserExternalAccountsStoreLookupUserAndSaveFunc) History() []UserExternalAccountsStoreLookupUserAndSaveFuncCall {
+// History returns a sequence of UserPublicRepoStoreListByUserFuncCall
+// objects describing the invocations of this function.
+func (f *UserPublicRepoStoreListByUserFunc) History() []UserPublicRepoStoreListByUserFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreLookupUserAndSaveFuncCall, len(f.history))
+	history := make([]UserPublicRepoStoreListByUserFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreLookupUserAndSaveFuncCall is an object that
-// describes an invocation of method LookupUserAndSave on an instance of
-// MockUserExternalAccountsStore.
-type UserExternalAccountsStoreLookupUserAndSaveFuncCall struct {
+// UserPublicRepoStoreListByUserFuncCall is an object that describes an
+// invocation of method ListByUser on an instance of
+// MockUserPublicRepoStore.
+type UserPublicRepoStoreListByUserFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 extsvc.AccountSpec
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 extsvc.AccountData
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int32
+	Result0 []UserPublicRepo
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -35109,47 +38455,46 @@ type UserExternalAccountsStoreLookupUserAndSaveFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreLookupUserAndSaveFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c UserPublicRepoStoreListByUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreLookupUserAndSaveFuncCall) Results() []interface{} {
+func (c UserPublicRepoStoreListByUserFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserExternalAccountsStoreQueryRowFunc describes the behavior when the
-// QueryRow method of the parent MockUserExternalAccountsStore instance is
+// UserPublicRepoStoreSetUserRepoFunc describes the behavior when the
+// SetUserRepo method of the parent MockUserPublicRepoStore instance is
 // invoked.
-type UserExternalAccountsStoreQueryRowFunc struct {
-	defaultHook func(context.Context, *sqlf.Query) *sql.Row
-	hooks       []func(context.Context, *sqlf.Query) *sql.Row
-	history     []UserExternalAccountsStoreQueryRowFuncCall
+type UserPublicRepoStoreSetUserRepoFunc struct {
+	defaultHook func(context.Context, UserPublicRepo) error
+	hooks       []func(context.Context, UserPublicRepo) error
+	history     []UserPublicRepoStoreSetUserRepoFuncCall
 	mutex       sync.Mutex
 }
 
-// QueryRow delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) QueryRow(v0 context.Context, v1 *sqlf.Query) *sql.Row {
-	r0 := m.QueryRowFunc.nextHook()(v0, v1)
-	m.QueryRowFunc.appendCall(UserExternalAccountsStoreQueryRowFuncCall{v0, v1, r0})
+// SetUserRepo delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUserPublicRepoStore) SetUserRepo(v0 context.Context, v1 UserPublicRepo) error {
+	r0 := m.SetUserRepoFunc.nextHook()(v0, v1)
+	m.SetUserRepoFunc.appendCall(UserPublicRepoStoreSetUserRepoFuncCall{v0, v1, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the QueryRow method of
-// the parent MockUserExternalAccountsStore instance is invoked and the hook
+// SetDefaultHook sets function that is called when the SetUserRepo method
+// of the parent MockUserPublicRepoStore instance is invoked and the hook
 // queue is empty.
-func (f *UserExternalAccountsStoreQueryRowFunc) SetDefaultHook(hook func(context.Context, *sqlf.Query) *sql.Row) {
+func (f *UserPublicRepoStoreSetUserRepoFunc) SetDefaultHook(hook func(context.Context, UserPublicRepo) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// QueryRow method of the parent MockUserExternalAccountsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *UserExternalAccountsStoreQueryRowFunc) PushHook(hook func(context.Context, *sqlf.Query) *sql.Row) {
+// SetUserRepo method of the parent MockUserPublicRepoStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserPublicRepoStoreSetUserRepoFunc) PushHook(hook func(context.Context, UserPublicRepo) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -35157,21 +38502,21 @@ func (f *UserExternalAccountsStoreQueryRowFunc) PushHook(hook func(context.Conte
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreQueryRowFunc) SetDefaultReturn(r0 *sql.Row) {
-	f.SetDefaultHook(func(context.Context, *sqlf.Query) *sql.Row {
+func (f *UserPublicRepoStoreSetUserRepoFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, UserPublicRepo) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreQueryRowFunc) PushReturn(r0 *sql.Row) {
-	f.PushHook(func(context.Context, *sqlf.Query) *sql.Row {
+func (f *UserPublicRepoStoreSetUserRepoFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, UserPublicRepo) error {
 		return r0
 	})
 }
 
-func (f *UserExternalAccountsStoreQueryRowFunc) nextHook() func(context.Context, *sqlf.Query) *sql.Row {
+func (f *UserPublicRepoStoreSetUserRepoFunc) nextHook() func(context.Context, UserPublicRepo) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -35184,81 +38529,81 @@ func (f *UserExternalAccountsStoreQueryRowFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *UserExternalAccountsStoreQueryRowFunc) appendCall(r0 UserExternalAccountsStoreQueryRowFuncCall) {
+func (f *UserPublicRepoStoreSetUserRepoFunc) appendCall(r0 UserPublicRepoStoreSetUserRepoFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserExternalAccountsStoreQueryRowFuncCall
+// History returns a sequence of UserPublicRepoStoreSetUserRepoFuncCall
 // objects describing the invocations of this function.
-func (f *UserExternalAccountsStoreQueryRowFunc) History() []UserExternalAccountsStoreQueryRowFuncCall {
+func (f *UserPublicRepoStoreSetUserRepoFunc) History() []UserPublicRepoStoreSetUserRepoFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreQueryRowFuncCall, len(f.history))
+	history := make([]UserPublicRepoStoreSetUserRepoFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreQueryRowFuncCall is an object that describes an
-// invocation of method QueryRow on an instance of
-// MockUserExternalAccountsStore.
-type UserExternalAccountsStoreQueryRowFuncCall struct {
+// UserPublicRepoStoreSetUserRepoFuncCall is an object that describes an
+// invocation of method SetUserRepo on an instance of
+// MockUserPublicRepoStore.
+type UserPublicRepoStoreSetUserRepoFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *sqlf.Query
+	Arg1 UserPublicRepo
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *sql.Row
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreQueryRowFuncCall) Args() []interface{} {
+func (c UserPublicRepoStoreSetUserRepoFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreQueryRowFuncCall) Results() []interface{} {
+func (c UserPublicRepoStoreSetUserRepoFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserExternalAccountsStoreTouchExpiredFunc describes the behavior when the
-// TouchExpired method of the parent MockUserExternalAccountsStore instance
-// is invoked.
-type UserExternalAccountsStoreTouchExpiredFunc struct {
-	defaultHook func(context.Context, int32) error
-	hooks       []func(context.Context, int32) error
-	history     []UserExternalAccountsStoreTouchExpiredFuncCall
+// UserPublicRepoStoreSetUserReposFunc describes the behavior when the
+// SetUserRepos method of the parent MockUserPublicRepoStore instance is
+// invoked.
+type UserPublicRepoStoreSetUserReposFunc struct {
+	defaultHook func(context.Context, int32, []UserPublicRepo) error
+	hooks       []func(context.Context, int32, []UserPublicRepo) error
+	history     []UserPublicRepoStoreSetUserReposFuncCall
 	mutex       sync.Mutex
 }
 
-// TouchExpired delegates to the next hook function in the queue and stores
+// SetUserRepos delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) TouchExpired(v0 context.Context, v1 int32) error {
-	r0 := m.TouchExpiredFunc.nextHook()(v0, v1)
-	m.TouchExpiredFunc.appendCall(UserExternalAccountsStoreTouchExpiredFuncCall{v0, v1, r0})
+func (m *MockUserPublicRepoStore) SetUserRepos(v0 context.Context, v1 int32, v2 []UserPublicRepo) error {
+	r0 := m.SetUserReposFunc.nextHook()(v0, v1, v2)
+	m.SetUserReposFunc.appendCall(UserPublicRepoStoreSetUserReposFuncCall{v0, v1, v2, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the TouchExpired method
-// of the parent MockUserExternalAccountsStore instance is invoked and the
-// hook queue is empty.
-func (f *UserExternalAccountsStoreTouchExpiredFunc) SetDefaultHook(hook func(context.Context, int32) error) {
+// SetDefaultHook sets function that is called when the SetUserRepos method
+// of the parent MockUserPublicRepoStore instance is invoked and the hook
+// queue is empty.
+func (f *UserPublicRepoStoreSetUserReposFunc) SetDefaultHook(hook func(context.Context, int32, []UserPublicRepo) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// TouchExpired method of the parent MockUserExternalAccountsStore instance
+// SetUserRepos method of the parent MockUserPublicRepoStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *UserExternalAccountsStoreTouchExpiredFunc) PushHook(hook func(context.Context, int32) error) {
+func (f *UserPublicRepoStoreSetUserReposFunc) PushHook(hook func(context.Context, int32, []UserPublicRepo) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -35266,21 +38611,21 @@ func (f *UserExternalAccountsStoreTouchExpiredFunc) PushHook(hook func(context.C
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreTouchExpiredFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32) error {
+func (f *UserPublicRepoStoreSetUserReposFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, []UserPublicRepo) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreTouchExpiredFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32) error {
+func (f *UserPublicRepoStoreSetUserReposFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, []UserPublicRepo) error {
 		return r0
 	})
 }
 
-func (f *UserExternalAccountsStoreTouchExpiredFunc) nextHook() func(context.Context, int32) error {
+func (f *UserPublicRepoStoreSetUserReposFunc) nextHook() func(context.Context, int32, []UserPublicRepo) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -35293,34 +38638,36 @@ func (f *UserExternalAccountsStoreTouchExpiredFunc) nextHook() func(context.Cont
 	return hook
 }
 
-func (f *UserExternalAccountsStoreTouchExpiredFunc) appendCall(r0 UserExternalAccountsStoreTouchExpiredFuncCall) {
+func (f *UserPublicRepoStoreSetUserReposFunc) appendCall(r0 UserPublicRepoStoreSetUserReposFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// UserExternalAccountsStoreTouchExpiredFuncCall objects describing the
-// invocations of this function.
-func (f *UserExternalAccountsStoreTouchExpiredFunc) History() []UserExternalAccountsStoreTouchExpiredFuncCall {
+// History returns a sequence of UserPublicRepoStoreSetUserReposFuncCall
+// objects describing the invocations of this function.
+func (f *UserPublicRepoStoreSetUserReposFunc) History() []UserPublicRepoStoreSetUserReposFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreTouchExpiredFuncCall, len(f.history))
+	history := make([]UserPublicRepoStoreSetUserReposFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreTouchExpiredFuncCall is an object that describes
-// an invocation of method TouchExpired on an instance of
-// MockUserExternalAccountsStore.
-type UserExternalAccountsStoreTouchExpiredFuncCall struct {
+// UserPublicRepoStoreSetUserReposFuncCall is an object that describes an
+// invocation of method SetUserRepos on an instance of
+// MockUserPublicRepoStore.
+type UserPublicRepoStoreSetUserReposFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []UserPublicRepo
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -35328,47 +38675,204 @@ type UserExternalAccountsStoreTouchExpiredFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreTouchExpiredFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c UserPublicRepoStoreSetUserReposFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreTouchExpiredFuncCall) Results() []interface{} {
+func (c UserPublicRepoStoreSetUserReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserExternalAccountsStoreTouchLastValidFunc describes the behavior when
-// the TouchLastValid method of the parent MockUserExternalAccountsStore
-// instance is invoked.
-type UserExternalAccountsStoreTouchLastValidFunc struct {
-	defaultHook func(context.Context, int32) error
-	hooks       []func(context.Context, int32) error
-	history     []UserExternalAccountsStoreTouchLastValidFuncCall
+// MockUserSessionStore is a mock implementation of the
+// UserSessionStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockUserSessionStore struct {
+	// DeleteFunc is an instance of a mock function object controlling the
+	// behavior of the method Delete.
+	DeleteFunc *UserSessionStoreDeleteFunc
+	// DeleteByUserFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteByUser.
+	DeleteByUserFunc *UserSessionStoreDeleteByUserFunc
+	// GetByIDFunc is an instance of a mock function object controlling the
+	// behavior of the method GetByID.
+	GetByIDFunc *UserSessionStoreGetByIDFunc
+	// GetByKeyFunc is an instance of a mock function object controlling the
+	// behavior of the method GetByKey.
+	GetByKeyFunc *UserSessionStoreGetByKeyFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *UserSessionStoreHandleFunc
+	// ListByUserFunc is an instance of a mock function object controlling
+	// the behavior of the method ListByUser.
+	ListByUserFunc *UserSessionStoreListByUserFunc
+	// TouchFunc is an instance of a mock function object controlling the
+	// behavior of the method Touch.
+	TouchFunc *UserSessionStoreTouchFunc
+	// UpsertFunc is an instance of a mock function object controlling the
+	// behavior of the method Upsert.
+	UpsertFunc *UserSessionStoreUpsertFunc
+}
+
+// NewMockUserSessionStore creates a new mock of the UserSessionStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockUserSessionStore() *MockUserSessionStore {
+	return &MockUserSessionStore{
+		DeleteFunc: &UserSessionStoreDeleteFunc{
+			defaultHook: func(context.Context, int64) error {
+				return nil
+			},
+		},
+		DeleteByUserFunc: &UserSessionStoreDeleteByUserFunc{
+			defaultHook: func(context.Context, int32, string) error {
+				return nil
+			},
+		},
+		GetByIDFunc: &UserSessionStoreGetByIDFunc{
+			defaultHook: func(context.Context, int64) (*UserSession, error) {
+				return nil, nil
+			},
+		},
+		GetByKeyFunc: &UserSessionStoreGetByKeyFunc{
+			defaultHook: func(context.Context, string) (*UserSession, error) {
+				return nil, nil
+			},
+		},
+		HandleFunc: &UserSessionStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				return nil
+			},
+		},
+		ListByUserFunc: &UserSessionStoreListByUserFunc{
+			defaultHook: func(context.Context, int32) ([]*UserSession, error) {
+				return nil, nil
+			},
+		},
+		TouchFunc: &UserSessionStoreTouchFunc{
+			defaultHook: func(context.Context, string, string, string, time.Time) error {
+				return nil
+			},
+		},
+		UpsertFunc: &UserSessionStoreUpsertFunc{
+			defaultHook: func(context.Context, *UserSession) error {
+				return nil
+			},
+		},
+	}
+}
+
+// NewStrictMockUserSessionStore creates a new mock of the
+// UserSessionStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockUserSessionStore() *MockUserSessionStore {
+	return &MockUserSessionStore{
+		DeleteFunc: &UserSessionStoreDeleteFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockUserSessionStore.Delete")
+			},
+		},
+		DeleteByUserFunc: &UserSessionStoreDeleteByUserFunc{
+			defaultHook: func(context.Context, int32, string) error {
+				panic("unexpected invocation of MockUserSessionStore.DeleteByUser")
+			},
+		},
+		GetByIDFunc: &UserSessionStoreGetByIDFunc{
+			defaultHook: func(context.Context, int64) (*UserSession, error) {
+				panic("unexpected invocation of MockUserSessionStore.GetByID")
+			},
+		},
+		GetByKeyFunc: &UserSessionStoreGetByKeyFunc{
+			defaultHook: func(context.Context, string) (*UserSession, error) {
+				panic("unexpected invocation of MockUserSessionStore.GetByKey")
+			},
+		},
+		HandleFunc: &UserSessionStoreHandleFunc{
+			defaultHook: func() *basestore.TransactableHandle {
+				panic("unexpected invocation of MockUserSessionStore.Handle")
+			},
+		},
+		ListByUserFunc: &UserSessionStoreListByUserFunc{
+			defaultHook: func(context.Context, int32) ([]*UserSession, error) {
+				panic("unexpected invocation of MockUserSessionStore.ListByUser")
+			},
+		},
+		TouchFunc: &UserSessionStoreTouchFunc{
+			defaultHook: func(context.Context, string, string, string, time.Time) error {
+				panic("unexpected invocation of MockUserSessionStore.Touch")
+			},
+		},
+		UpsertFunc: &UserSessionStoreUpsertFunc{
+			defaultHook: func(context.Context, *UserSession) error {
+				panic("unexpected invocation of MockUserSessionStore.Upsert")
+			},
+		},
+	}
+}
+
+// NewMockUserSessionStoreFrom creates a new mock of the
+// MockUserSessionStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockUserSessionStoreFrom(i UserSessionStore) *MockUserSessionStore {
+	return &MockUserSessionStore{
+		DeleteFunc: &UserSessionStoreDeleteFunc{
+			defaultHook: i.Delete,
+		},
+		DeleteByUserFunc: &UserSessionStoreDeleteByUserFunc{
+			defaultHook: i.DeleteByUser,
+		},
+		GetByIDFunc: &UserSessionStoreGetByIDFunc{
+			defaultHook: i.GetByID,
+		},
+		GetByKeyFunc: &UserSessionStoreGetByKeyFunc{
+			defaultHook: i.GetByKey,
+		},
+		HandleFunc: &UserSessionStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		ListByUserFunc: &UserSessionStoreListByUserFunc{
+			defaultHook: i.ListByUser,
+		},
+		TouchFunc: &UserSessionStoreTouchFunc{
+			defaultHook: i.Touch,
+		},
+		UpsertFunc: &UserSessionStoreUpsertFunc{
+			defaultHook: i.Upsert,
+		},
+	}
+}
+
+// UserSessionStoreDeleteFunc describes the behavior when the Delete method
+// of the parent MockUserSessionStore instance is invoked.
+type UserSessionStoreDeleteFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []UserSessionStoreDeleteFuncCall
 	mutex       sync.Mutex
 }
 
-// TouchLastValid delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) TouchLastValid(v0 context.Context, v1 int32) error {
-	r0 := m.TouchLastValidFunc.nextHook()(v0, v1)
-	m.TouchLastValidFunc.appendCall(UserExternalAccountsStoreTouchLastValidFuncCall{v0, v1, r0})
+// Delete delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserSessionStore) Delete(v0 context.Context, v1 int64) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1)
+	m.DeleteFunc.appendCall(UserSessionStoreDeleteFuncCall{v0, v1, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the TouchLastValid
-// method of the parent MockUserExternalAccountsStore instance is invoked
-// and the hook queue is empty.
-func (f *UserExternalAccountsStoreTouchLastValidFunc) SetDefaultHook(hook func(context.Context, int32) error) {
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockUserSessionStore instance is invoked and the hook queue is
+// empty.
+func (f *UserSessionStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// TouchLastValid method of the parent MockUserExternalAccountsStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *UserExternalAccountsStoreTouchLastValidFunc) PushHook(hook func(context.Context, int32) error) {
+// Delete method of the parent MockUserSessionStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserSessionStoreDeleteFunc) PushHook(hook func(context.Context, int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -35376,21 +38880,21 @@ func (f *UserExternalAccountsStoreTouchLastValidFunc) PushHook(hook func(context
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreTouchLastValidFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32) error {
+func (f *UserSessionStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreTouchLastValidFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32) error {
+func (f *UserSessionStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
 		return r0
 	})
 }
 
-func (f *UserExternalAccountsStoreTouchLastValidFunc) nextHook() func(context.Context, int32) error {
+func (f *UserSessionStoreDeleteFunc) nextHook() func(context.Context, int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -35403,34 +38907,32 @@ func (f *UserExternalAccountsStoreTouchLastValidFunc) nextHook() func(context.Co
 	return hook
 }
 
-func (f *UserExternalAccountsStoreTouchLastValidFunc) appendCall(r0 UserExternalAccountsStoreTouchLastValidFuncCall) {
+func (f *UserSessionStoreDeleteFunc) appendCall(r0 UserSessionStoreDeleteFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// UserExternalAccountsStoreTouchLastValidFuncCall objects describing the
-// invocations of this function.
-func (f *UserExternalAccountsStoreTouchLastValidFunc) History() []UserExternalAccountsStoreTouchLastValidFuncCall {
+// History returns a sequence of UserSessionStoreDeleteFuncCall objects
+// describing the invocations of this function.
+func (f *UserSessionStoreDeleteFunc) History() []UserSessionStoreDeleteFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreTouchLastValidFuncCall, len(f.history))
+	history := make([]UserSessionStoreDeleteFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreTouchLastValidFuncCall is an object that
-// describes an invocation of method TouchLastValid on an instance of
-// MockUserExternalAccountsStore.
-type UserExternalAccountsStoreTouchLastValidFuncCall struct {
+// UserSessionStoreDeleteFuncCall is an object that describes an invocation
+// of method Delete on an instance of MockUserSessionStore.
+type UserSessionStoreDeleteFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -35438,47 +38940,46 @@ type UserExternalAccountsStoreTouchLastValidFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreTouchLastValidFuncCall) Args() []interface{} {
+func (c UserSessionStoreDeleteFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreTouchLastValidFuncCall) Results() []interface{} {
+func (c UserSessionStoreDeleteFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserExternalAccountsStoreTransactFunc describes the behavior when the
-// Transact method of the parent MockUserExternalAccountsStore instance is
+// UserSessionStoreDeleteByUserFunc describes the behavior when the
+// DeleteByUser method of the parent MockUserSessionStore instance is
 // invoked.
-type UserExternalAccountsStoreTransactFunc struct {
-	defaultHook func(context.Context) (UserExternalAccountsStore, error)
-	hooks       []func(context.Context) (UserExternalAccountsStore, error)
-	history     []UserExternalAccountsStoreTransactFuncCall
+type UserSessionStoreDeleteByUserFunc struct {
+	defaultHook func(context.Context, int32, string) error
+	hooks       []func(context.Context, int32, string) error
+	history     []UserSessionStoreDeleteByUserFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) Transact(v0 context.Context) (UserExternalAccountsStore, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(UserExternalAccountsStoreTransactFuncCall{v0, r0, r1})
-	return r0, r1
+// DeleteByUser delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUserSessionStore) DeleteByUser(v0 context.Context, v1 int32, v2 string) error {
+	r0 := m.DeleteByUserFunc.nextHook()(v0, v1, v2)
+	m.DeleteByUserFunc.appendCall(UserSessionStoreDeleteByUserFuncCall{v0, v1, v2, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
-// the parent MockUserExternalAccountsStore instance is invoked and the hook
-// queue is empty.
-func (f *UserExternalAccountsStoreTransactFunc) SetDefaultHook(hook func(context.Context) (UserExternalAccountsStore, error)) {
+// SetDefaultHook sets function that is called when the DeleteByUser method
+// of the parent MockUserSessionStore instance is invoked and the hook queue
+// is empty.
+func (f *UserSessionStoreDeleteByUserFunc) SetDefaultHook(hook func(context.Context, int32, string) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockUserExternalAccountsStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *UserExternalAccountsStoreTransactFunc) PushHook(hook func(context.Context) (UserExternalAccountsStore, error)) {
+// DeleteByUser method of the parent MockUserSessionStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserSessionStoreDeleteByUserFunc) PushHook(hook func(context.Context, int32, string) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -35486,21 +38987,21 @@ func (f *UserExternalAccountsStoreTransactFunc) PushHook(hook func(context.Conte
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreTransactFunc) SetDefaultReturn(r0 UserExternalAccountsStore, r1 error) {
-	f.SetDefaultHook(func(context.Context) (UserExternalAccountsStore, error) {
-		return r0, r1
+func (f *UserSessionStoreDeleteByUserFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreTransactFunc) PushReturn(r0 UserExternalAccountsStore, r1 error) {
-	f.PushHook(func(context.Context) (UserExternalAccountsStore, error) {
-		return r0, r1
+func (f *UserSessionStoreDeleteByUserFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string) error {
+		return r0
 	})
 }
 
-func (f *UserExternalAccountsStoreTransactFunc) nextHook() func(context.Context) (UserExternalAccountsStore, error) {
+func (f *UserSessionStoreDeleteByUserFunc) nextHook() func(context.Context, int32, string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -35513,79 +39014,81 @@ func (f *UserExternalAccountsStoreTransactFunc) nextHook() func(context.Context)
 	return hook
 }
 
-func (f *UserExternalAccountsStoreTransactFunc) appendCall(r0 UserExternalAccountsStoreTransactFuncCall) {
+func (f *UserSessionStoreDeleteByUserFunc) appendCall(r0 UserSessionStoreDeleteByUserFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserExternalAccountsStoreTransactFuncCall
+// History returns a sequence of UserSessionStoreDeleteByUserFuncCall
 // objects describing the invocations of this function.
-func (f *UserExternalAccountsStoreTransactFunc) History() []UserExternalAccountsStoreTransactFuncCall {
+func (f *UserSessionStoreDeleteByUserFunc) History() []UserSessionStoreDeleteByUserFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreTransactFuncCall, len(f.history))
+	history := make([]UserSessionStoreDeleteByUserFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreTransactFuncCall is an object that describes an
-// invocation of method Transact on an instance of
-// MockUserExternalAccountsStore.
-type UserExternalAccountsStoreTransactFuncCall struct {
+// UserSessionStoreDeleteByUserFuncCall is an object that describes an
+// invocation of method DeleteByUser on an instance of MockUserSessionStore.
+type UserSessionStoreDeleteByUserFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Result0 is the value of the 1st result returned from this method
+	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Result0 UserExternalAccountsStore
-	// Result1 is the value of the 2nd result returned from this method
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Result1 error
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreTransactFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c UserSessionStoreDeleteByUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreTransactFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c UserSessionStoreDeleteByUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// UserExternalAccountsStoreWithFunc describes the behavior when the With
-// method of the parent MockUserExternalAccountsStore instance is invoked.
-type UserExternalAccountsStoreWithFunc struct {
-	defaultHook func(basestore.ShareableStore) UserExternalAccountsStore
-	hooks       []func(basestore.ShareableStore) UserExternalAccountsStore
-	history     []UserExternalAccountsStoreWithFuncCall
+// UserSessionStoreGetByIDFunc describes the behavior when the GetByID
+// method of the parent MockUserSessionStore instance is invoked.
+type UserSessionStoreGetByIDFunc struct {
+	defaultHook func(context.Context, int64) (*UserSession, error)
+	hooks       []func(context.Context, int64) (*UserSession, error)
+	history     []UserSessionStoreGetByIDFuncCall
 	mutex       sync.Mutex
 }
 
-// With delegates to the next hook function in the queue and stores the
+// GetByID delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) With(v0 basestore.ShareableStore) UserExternalAccountsStore {
-	r0 := m.WithFunc.nextHook()(v0)
-	m.WithFunc.appendCall(UserExternalAccountsStoreWithFuncCall{v0, r0})
-	return r0
+func (m *MockUserSessionStore) GetByID(v0 context.Context, v1 int64) (*UserSession, error) {
+	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
+	m.GetByIDFunc.appendCall(UserSessionStoreGetByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the With method of the
-// parent MockUserExternalAccountsStore instance is invoked and the hook
-// queue is empty.
-func (f *UserExternalAccountsStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) UserExternalAccountsStore) {
+// SetDefaultHook sets function that is called when the GetByID method of
+// the parent MockUserSessionStore instance is invoked and the hook queue is
+// empty.
+func (f *UserSessionStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int64) (*UserSession, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// With method of the parent MockUserExternalAccountsStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *UserExternalAccountsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) UserExternalAccountsStore) {
+// GetByID method of the parent MockUserSessionStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserSessionStoreGetByIDFunc) PushHook(hook func(context.Context, int64) (*UserSession, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -35593,21 +39096,21 @@ func (f *UserExternalAccountsStoreWithFunc) PushHook(hook func(basestore.Shareab
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreWithFunc) SetDefaultReturn(r0 UserExternalAccountsStore) {
-	f.SetDefaultHook(func(basestore.ShareableStore) UserExternalAccountsStore {
-		return r0
+func (f *UserSessionStoreGetByIDFunc) SetDefaultReturn(r0 *UserSession, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*UserSession, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreWithFunc) PushReturn(r0 UserExternalAccountsStore) {
-	f.PushHook(func(basestore.ShareableStore) UserExternalAccountsStore {
-		return r0
+func (f *UserSessionStoreGetByIDFunc) PushReturn(r0 *UserSession, r1 error) {
+	f.PushHook(func(context.Context, int64) (*UserSession, error) {
+		return r0, r1
 	})
 }
 
-func (f *UserExternalAccountsStoreWithFunc) nextHook() func(basestore.ShareableStore) UserExternalAccountsStore {
+func (f *UserSessionStoreGetByIDFunc) nextHook() func(context.Context, int64) (*UserSession, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -35620,78 +39123,81 @@ func (f *UserExternalAccountsStoreWithFunc) nextHook() func(basestore.ShareableS
 	return hook
 }
 
-func (f *UserExternalAccountsStoreWithFunc) appendCall(r0 UserExternalAccountsStoreWithFuncCall) {
+func (f *UserSessionStoreGetByIDFunc) appendCall(r0 UserSessionStoreGetByIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserExternalAccountsStoreWithFuncCall
-// objects describing the invocations of this function.
-func (f *UserExternalAccountsStoreWithFunc) History() []UserExternalAccountsStoreWithFuncCall {
+// History returns a sequence of UserSessionStoreGetByIDFuncCall objects
+// describing the invocations of this function.
+func (f *UserSessionStoreGetByIDFunc) History() []UserSessionStoreGetByIDFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreWithFuncCall, len(f.history))
+	history := make([]UserSessionStoreGetByIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreWithFuncCall is an object that describes an
-// invocation of method With on an instance of
-// MockUserExternalAccountsStore.
-type UserExternalAccountsStoreWithFuncCall struct {
+// UserSessionStoreGetByIDFuncCall is an object that describes an invocation
+// of method GetByID on an instance of MockUserSessionStore.
+type UserSessionStoreGetByIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 basestore.ShareableStore
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 UserExternalAccountsStore
+	Result0 *UserSession
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreWithFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c UserSessionStoreGetByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreWithFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c UserSessionStoreGetByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserExternalAccountsStoreWithEncryptionKeyFunc describes the behavior
-// when the WithEncryptionKey method of the parent
-// MockUserExternalAccountsStore instance is invoked.
-type UserExternalAccountsStoreWithEncryptionKeyFunc struct {
-	defaultHook func(encryption.Key) UserExternalAccountsStore
-	hooks       []func(encryption.Key) UserExternalAccountsStore
-	history     []UserExternalAccountsStoreWithEncryptionKeyFuncCall
+// UserSessionStoreGetByKeyFunc describes the behavior when the GetByKey
+// method of the parent MockUserSessionStore instance is invoked.
+type UserSessionStoreGetByKeyFunc struct {
+	defaultHook func(context.Context, string) (*UserSession, error)
+	hooks       []func(context.Context, string) (*UserSession, error)
+	history     []UserSessionStoreGetByKeyFuncCall
 	mutex       sync.Mutex
 }
 
-// WithEncryptionKey delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockUserExternalAccountsStore) WithEncryptionKey(v0 encryption.Key) UserExternalAccountsStore {
-	r0 := m.WithEncryptionKeyFunc.nextHook()(v0)
-	m.WithEncryptionKeyFunc.appendCall(UserExternalAccountsStoreWithEncryptionKeyFuncCall{v0, r0})
-	return r0
+// GetByKey delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserSessionStore) GetByKey(v0 context.Context, v1 string) (*UserSession, error) {
+	r0, r1 := m.GetByKeyFunc.nextHook()(v0, v1)
+	m.GetByKeyFunc.appendCall(UserSessionStoreGetByKeyFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the WithEncryptionKey
-// method of the parent MockUserExternalAccountsStore instance is invoked
-// and the hook queue is empty.
-func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) SetDefaultHook(hook func(encryption.Key) UserExternalAccountsStore) {
+// SetDefaultHook sets function that is called when the GetByKey method of
+// the parent MockUserSessionStore instance is invoked and the hook queue is
+// empty.
+func (f *UserSessionStoreGetByKeyFunc) SetDefaultHook(hook func(context.Context, string) (*UserSession, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// WithEncryptionKey method of the parent MockUserExternalAccountsStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) PushHook(hook func(encryption.Key) UserExternalAccountsStore) {
+// GetByKey method of the parent MockUserSessionStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserSessionStoreGetByKeyFunc) PushHook(hook func(context.Context, string) (*UserSession, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -35699,21 +39205,21 @@ func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) PushHook(hook func(encr
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) SetDefaultReturn(r0 UserExternalAccountsStore) {
-	f.SetDefaultHook(func(encryption.Key) UserExternalAccountsStore {
-		return r0
+func (f *UserSessionStoreGetByKeyFunc) SetDefaultReturn(r0 *UserSession, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (*UserSession, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) PushReturn(r0 UserExternalAccountsStore) {
-	f.PushHook(func(encryption.Key) UserExternalAccountsStore {
-		return r0
+func (f *UserSessionStoreGetByKeyFunc) PushReturn(r0 *UserSession, r1 error) {
+	f.PushHook(func(context.Context, string) (*UserSession, error) {
+		return r0, r1
 	})
 }
 
-func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) nextHook() func(encryption.Key) UserExternalAccountsStore {
+func (f *UserSessionStoreGetByKeyFunc) nextHook() func(context.Context, string) (*UserSession, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -35726,172 +39232,81 @@ func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) nextHook() func(encrypt
 	return hook
 }
 
-func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) appendCall(r0 UserExternalAccountsStoreWithEncryptionKeyFuncCall) {
+func (f *UserSessionStoreGetByKeyFunc) appendCall(r0 UserSessionStoreGetByKeyFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// UserExternalAccountsStoreWithEncryptionKeyFuncCall objects describing the
-// invocations of this function.
-func (f *UserExternalAccountsStoreWithEncryptionKeyFunc) History() []UserExternalAccountsStoreWithEncryptionKeyFuncCall {
+// History returns a sequence of UserSessionStoreGetByKeyFuncCall objects
+// describing the invocations of this function.
+func (f *UserSessionStoreGetByKeyFunc) History() []UserSessionStoreGetByKeyFuncCall {
 	f.mutex.Lock()
-	history := make([]UserExternalAccountsStoreWithEncryptionKeyFuncCall, len(f.history))
+	history := make([]UserSessionStoreGetByKeyFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserExternalAccountsStoreWithEncryptionKeyFuncCall is an object that
-// describes an invocation of method WithEncryptionKey on an instance of
-// MockUserExternalAccountsStore.
-type UserExternalAccountsStoreWithEncryptionKeyFuncCall struct {
+// UserSessionStoreGetByKeyFuncCall is an object that describes an
+// invocation of method GetByKey on an instance of MockUserSessionStore.
+type UserSessionStoreGetByKeyFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 encryption.Key
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 UserExternalAccountsStore
+	Result0 *UserSession
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserExternalAccountsStoreWithEncryptionKeyFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c UserSessionStoreGetByKeyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserExternalAccountsStoreWithEncryptionKeyFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
-}
-
-// MockUserPublicRepoStore is a mock implementation of the
-// UserPublicRepoStore interface (from the package
-// github.com/sourcegraph/sourcegraph/internal/database) used for unit
-// testing.
-type MockUserPublicRepoStore struct {
-	// HandleFunc is an instance of a mock function object controlling the
-	// behavior of the method Handle.
-	HandleFunc *UserPublicRepoStoreHandleFunc
-	// ListByUserFunc is an instance of a mock function object controlling
-	// the behavior of the method ListByUser.
-	ListByUserFunc *UserPublicRepoStoreListByUserFunc
-	// SetUserRepoFunc is an instance of a mock function object controlling
-	// the behavior of the method SetUserRepo.
-	SetUserRepoFunc *UserPublicRepoStoreSetUserRepoFunc
-	// SetUserReposFunc is an instance of a mock function object controlling
-	// the behavior of the method SetUserRepos.
-	SetUserReposFunc *UserPublicRepoStoreSetUserReposFunc
-}
-
-// NewMockUserPublicRepoStore creates a new mock of the UserPublicRepoStore
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockUserPublicRepoStore() *MockUserPublicRepoStore {
-	return &MockUserPublicRepoStore{
-		HandleFunc: &UserPublicRepoStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				return nil
-			},
-		},
-		ListByUserFunc: &UserPublicRepoStoreListByUserFunc{
-			defaultHook: func(context.Context, int32) ([]UserPublicRepo, error) {
-				return nil, nil
-			},
-		},
-		SetUserRepoFunc: &UserPublicRepoStoreSetUserRepoFunc{
-			defaultHook: func(context.Context, UserPublicRepo) error {
-				return nil
-			},
-		},
-		SetUserReposFunc: &UserPublicRepoStoreSetUserReposFunc{
-			defaultHook: func(context.Context, int32, []UserPublicRepo) error {
-				return nil
-			},
-		},
-	}
-}
-
-// NewStrictMockUserPublicRepoStore creates a new mock of the
-// UserPublicRepoStore interface. All methods panic on invocation, unless
-// overwritten.
-func NewStrictMockUserPublicRepoStore() *MockUserPublicRepoStore {
-	return &MockUserPublicRepoStore{
-		HandleFunc: &UserPublicRepoStoreHandleFunc{
-			defaultHook: func() *basestore.TransactableHandle {
-				panic("unexpected invocation of MockUserPublicRepoStore.Handle")
-			},
-		},
-		ListByUserFunc: &UserPublicRepoStoreListByUserFunc{
-			defaultHook: func(context.Context, int32) ([]UserPublicRepo, error) {
-				panic("unexpected invocation of MockUserPublicRepoStore.ListByUser")
-			},
-		},
-		SetUserRepoFunc: &UserPublicRepoStoreSetUserRepoFunc{
-			defaultHook: func(context.Context, UserPublicRepo) error {
-				panic("unexpected invocation of MockUserPublicRepoStore.SetUserRepo")
-			},
-		},
-		SetUserReposFunc: &UserPublicRepoStoreSetUserReposFunc{
-			defaultHook: func(context.Context, int32, []UserPublicRepo) error {
-				panic("unexpected invocation of MockUserPublicRepoStore.SetUserRepos")
-			},
-		},
-	}
-}
-
-// NewMockUserPublicRepoStoreFrom creates a new mock of the
-// MockUserPublicRepoStore interface. All methods delegate to the given
-// implementation, unless overwritten.
-func NewMockUserPublicRepoStoreFrom(i UserPublicRepoStore) *MockUserPublicRepoStore {
-	return &MockUserPublicRepoStore{
-		HandleFunc: &UserPublicRepoStoreHandleFunc{
-			defaultHook: i.Handle,
-		},
-		ListByUserFunc: &UserPublicRepoStoreListByUserFunc{
-			defaultHook: i.ListByUser,
-		},
-		SetUserRepoFunc: &UserPublicRepoStoreSetUserRepoFunc{
-			defaultHook: i.SetUserRepo,
-		},
-		SetUserReposFunc: &UserPublicRepoStoreSetUserReposFunc{
-			defaultHook: i.SetUserRepos,
-		},
-	}
+func (c UserSessionStoreGetByKeyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserPublicRepoStoreHandleFunc describes the behavior when the Handle
-// method of the parent MockUserPublicRepoStore instance is invoked.
-type UserPublicRepoStoreHandleFunc struct {
+// UserSessionStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockUserSessionStore instance is invoked.
+type UserSessionStoreHandleFunc struct {
 	defaultHook func() *basestore.TransactableHandle
 	hooks       []func() *basestore.TransactableHandle
-	history     []UserPublicRepoStoreHandleFuncCall
+	history     []UserSessionStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
 // Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUserPublicRepoStore) Handle() *basestore.TransactableHandle {
+func (m *MockUserSessionStore) Handle() *basestore.TransactableHandle {
 	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(UserPublicRepoStoreHandleFuncCall{r0})
+	m.HandleFunc.appendCall(UserSessionStoreHandleFuncCall{r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the Handle method of the
-// parent MockUserPublicRepoStore instance is invoked and the hook queue is
+// parent MockUserSessionStore instance is invoked and the hook queue is
 // empty.
-func (f *UserPublicRepoStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
+func (f *UserSessionStoreHandleFunc) SetDefaultHook(hook func() *basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockUserPublicRepoStore instance invokes the
+// Handle method of the parent MockUserSessionStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *UserPublicRepoStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
+func (f *UserSessionStoreHandleFunc) PushHook(hook func() *basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -35899,7 +39314,7 @@ func (f *UserPublicRepoStoreHandleFunc) PushHook(hook func() *basestore.Transact
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserPublicRepoStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
+func (f *UserSessionStoreHandleFunc) SetDefaultReturn(r0 *basestore.TransactableHandle) {
 	f.SetDefaultHook(func() *basestore.TransactableHandle {
 		return r0
 	})
@@ -35907,13 +39322,13 @@ func (f *UserPublicRepoStoreHandleFunc) SetDefaultReturn(r0 *basestore.Transacta
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserPublicRepoStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
+func (f *UserSessionStoreHandleFunc) PushReturn(r0 *basestore.TransactableHandle) {
 	f.PushHook(func() *basestore.TransactableHandle {
 		return r0
 	})
 }
 
-func (f *UserPublicRepoStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
+func (f *UserSessionStoreHandleFunc) nextHook() func() *basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -35926,26 +39341,26 @@ func (f *UserPublicRepoStoreHandleFunc) nextHook() func() *basestore.Transactabl
 	return hook
 }
 
-func (f *UserPublicRepoStoreHandleFunc) appendCall(r0 UserPublicRepoStoreHandleFuncCall) {
+func (f *UserSessionStoreHandleFunc) appendCall(r0 UserSessionStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserPublicRepoStoreHandleFuncCall objects
+// History returns a sequence of UserSessionStoreHandleFuncCall objects
 // describing the invocations of this function.
-func (f *UserPublicRepoStoreHandleFunc) History() []UserPublicRepoStoreHandleFuncCall {
+func (f *UserSessionStoreHandleFunc) History() []UserSessionStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]UserPublicRepoStoreHandleFuncCall, len(f.history))
+	history := make([]UserSessionStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserPublicRepoStoreHandleFuncCall is an object that describes an
-// invocation of method Handle on an instance of MockUserPublicRepoStore.
-type UserPublicRepoStoreHandleFuncCall struct {
+// UserSessionStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockUserSessionStore.
+type UserSessionStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *basestore.TransactableHandle
@@ -35953,46 +39368,45 @@ type UserPublicRepoStoreHandleFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserPublicRepoStoreHandleFuncCall) Args() []interface{} {
+func (c UserSessionStoreHandleFuncCall) Args() []interface{} {
 	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserPublicRepoStoreHandleFuncCall) Results() []interface{} {
+func (c UserSessionStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserPublicRepoStoreListByUserFunc describes the behavior when the
-// ListByUser method of the parent MockUserPublicRepoStore instance is
-// invoked.
-type UserPublicRepoStoreListByUserFunc struct {
-	defaultHook func(context.Context, int32) ([]UserPublicRepo, error)
-	hooks       []func(context.Context, int32) ([]UserPublicRepo, error)
-	history     []UserPublicRepoStoreListByUserFuncCall
+// UserSessionStoreListByUserFunc describes the behavior when the ListByUser
+// method of the parent MockUserSessionStore instance is invoked.
+type UserSessionStoreListByUserFunc struct {
+	defaultHook func(context.Context, int32) ([]*UserSession, error)
+	hooks       []func(context.Context, int32) ([]*UserSession, error)
+	history     []UserSessionStoreListByUserFuncCall
 	mutex       sync.Mutex
 }
 
 // ListByUser delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockUserPublicRepoStore) ListByUser(v0 context.Context, v1 int32) ([]UserPublicRepo, error) {
+func (m *MockUserSessionStore) ListByUser(v0 context.Context, v1 int32) ([]*UserSession, error) {
 	r0, r1 := m.ListByUserFunc.nextHook()(v0, v1)
-	m.ListByUserFunc.appendCall(UserPublicRepoStoreListByUserFuncCall{v0, v1, r0, r1})
+	m.ListByUserFunc.appendCall(UserSessionStoreListByUserFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the ListByUser method of
-// the parent MockUserPublicRepoStore instance is invoked and the hook queue
-// is empty.
-func (f *UserPublicRepoStoreListByUserFunc) SetDefaultHook(hook func(context.Context, int32) ([]UserPublicRepo, error)) {
+// the parent MockUserSessionStore instance is invoked and the hook queue is
+// empty.
+func (f *UserSessionStoreListByUserFunc) SetDefaultHook(hook func(context.Context, int32) ([]*UserSession, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListByUser method of the parent MockUserPublicRepoStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *UserPublicRepoStoreListByUserFunc) PushHook(hook func(context.Context, int32) ([]UserPublicRepo, error)) {
+// ListByUser method of the parent MockUserSessionStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserSessionStoreListByUserFunc) PushHook(hook func(context.Context, int32) ([]*UserSession, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -36000,21 +39414,21 @@ func (f *UserPublicRepoStoreListByUserFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserPublicRepoStoreListByUserFunc) SetDefaultReturn(r0 []UserPublicRepo, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) ([]UserPublicRepo, error) {
+func (f *UserSessionStoreListByUserFunc) SetDefaultReturn(r0 []*UserSession, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]*UserSession, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserPublicRepoStoreListByUserFunc) PushReturn(r0 []UserPublicRepo, r1 error) {
-	f.PushHook(func(context.Context, int32) ([]UserPublicRepo, error) {
+func (f *UserSessionStoreListByUserFunc) PushReturn(r0 []*UserSession, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]*UserSession, error) {
 		return r0, r1
 	})
 }
 
-func (f *UserPublicRepoStoreListByUserFunc) nextHook() func(context.Context, int32) ([]UserPublicRepo, error) {
+func (f *UserSessionStoreListByUserFunc) nextHook() func(context.Context, int32) ([]*UserSession, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -36027,27 +39441,26 @@ func (f *UserPublicRepoStoreListByUserFunc) nextHook() func(context.Context, int
 	return hook
 }
 
-func (f *UserPublicRepoStoreListByUserFunc) appendCall(r0 UserPublicRepoStoreListByUserFuncCall) {
+func (f *UserSessionStoreListByUserFunc) appendCall(r0 UserSessionStoreListByUserFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserPublicRepoStoreListByUserFuncCall
-// objects describing the invocations of this function.
-func (f *UserPublicRepoStoreListByUserFunc) History() []UserPublicRepoStoreListByUserFuncCall {
+// History returns a sequence of UserSessionStoreListByUserFuncCall objects
+// describing the invocations of this function.
+func (f *UserSessionStoreListByUserFunc) History() []UserSessionStoreListByUserFuncCall {
 	f.mutex.Lock()
-	history := make([]UserPublicRepoStoreListByUserFuncCall, len(f.history))
+	history := make([]UserSessionStoreListByUserFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserPublicRepoStoreListByUserFuncCall is an object that describes an
-// invocation of method ListByUser on an instance of
-// MockUserPublicRepoStore.
-type UserPublicRepoStoreListByUserFuncCall struct {
+// UserSessionStoreListByUserFuncCall is an object that describes an
+// invocation of method ListByUser on an instance of MockUserSessionStore.
+type UserSessionStoreListByUserFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -36056,7 +39469,7 @@ type UserPublicRepoStoreListByUserFuncCall struct {
 	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []UserPublicRepo
+	Result0 []*UserSession
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -36064,46 +39477,45 @@ type UserPublicRepoStoreListByUserFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserPublicRepoStoreListByUserFuncCall) Args() []interface{} {
+func (c UserSessionStoreListByUserFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserPublicRepoStoreListByUserFuncCall) Results() []interface{} {
+func (c UserSessionStoreListByUserFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// UserPublicRepoStoreSetUserRepoFunc describes the behavior when the
-// SetUserRepo method of the parent MockUserPublicRepoStore instance is
-// invoked.
-type UserPublicRepoStoreSetUserRepoFunc struct {
-	defaultHook func(context.Context, UserPublicRepo) error
-	hooks       []func(context.Context, UserPublicRepo) error
-	history     []UserPublicRepoStoreSetUserRepoFuncCall
+// UserSessionStoreTouchFunc describes the behavior when the Touch method of
+// the parent MockUserSessionStore instance is invoked.
+type UserSessionStoreTouchFunc struct {
+	defaultHook func(context.Context, string, string, string, time.Time) error
+	hooks       []func(context.Context, string, string, string, time.Time) error
+	history     []UserSessionStoreTouchFuncCall
 	mutex       sync.Mutex
 }
 
-// SetUserRepo delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockUserPublicRepoStore) SetUserRepo(v0 context.Context, v1 UserPublicRepo) error {
-	r0 := m.SetUserRepoFunc.nextHook()(v0, v1)
-	m.SetUserRepoFunc.appendCall(UserPublicRepoStoreSetUserRepoFuncCall{v0, v1, r0})
+// Touch delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserSessionStore) Touch(v0 context.Context, v1 string, v2 string, v3 string, v4 time.Time) error {
+	r0 := m.TouchFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.TouchFunc.appendCall(UserSessionStoreTouchFuncCall{v0, v1, v2, v3, v4, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the SetUserRepo method
-// of the parent MockUserPublicRepoStore instance is invoked and the hook
-// queue is empty.
-func (f *UserPublicRepoStoreSetUserRepoFunc) SetDefaultHook(hook func(context.Context, UserPublicRepo) error) {
+// SetDefaultHook sets function that is called when the Touch method of the
+// parent MockUserSessionStore instance is invoked and the hook queue is
+// empty.
+func (f *UserSessionStoreTouchFunc) SetDefaultHook(hook func(context.Context, string, string, string, time.Time) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// SetUserRepo method of the parent MockUserPublicRepoStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *UserPublicRepoStoreSetUserRepoFunc) PushHook(hook func(context.Context, UserPublicRepo) error) {
+// Touch method of the parent MockUserSessionStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UserSessionStoreTouchFunc) PushHook(hook func(context.Context, string, string, string, time.Time) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -36111,21 +39523,21 @@ func (f *UserPublicRepoStoreSetUserRepoFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserPublicRepoStoreSetUserRepoFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, UserPublicRepo) error {
+func (f *UserSessionStoreTouchFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, string, string, time.Time) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserPublicRepoStoreSetUserRepoFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, UserPublicRepo) error {
+func (f *UserSessionStoreTouchFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, string, string, time.Time) error {
 		return r0
 	})
 }
 
-func (f *UserPublicRepoStoreSetUserRepoFunc) nextHook() func(context.Context, UserPublicRepo) error {
+func (f *UserSessionStoreTouchFunc) nextHook() func(context.Context, string, string, string, time.Time) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -36138,33 +39550,41 @@ func (f *UserPublicRepoStoreSetUserRepoFunc) nextHook() func(context.Context, Us
 	return hook
 }
 
-func (f *UserPublicRepoStoreSetUserRepoFunc) appendCall(r0 UserPublicRepoStoreSetUserRepoFuncCall) {
+func (f *UserSessionStoreTouchFunc) appendCall(r0 UserSessionStoreTouchFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserPublicRepoStoreSetUserRepoFuncCall
-// objects describing the invocations of this function.
-func (f *UserPublicRepoStoreSetUserRepoFunc) History() []UserPublicRepoStoreSetUserRepoFuncCall {
+// History returns a sequence of UserSessionStoreTouchFuncCall objects
+// describing the invocations of this function.
+func (f *UserSessionStoreTouchFunc) History() []UserSessionStoreTouchFuncCall {
 	f.mutex.Lock()
-	history := make([]UserPublicRepoStoreSetUserRepoFuncCall, len(f.history))
+	history := make([]UserSessionStoreTouchFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserPublicRepoStoreSetUserRepoFuncCall is an object that describes an
-// invocation of method SetUserRepo on an instance of
-// MockUserPublicRepoStore.
-type UserPublicRepoStoreSetUserRepoFuncCall struct {
+// UserSessionStoreTouchFuncCall is an object that describes an invocation
+// of method Touch on an instance of MockUserSessionStore.
+type UserSessionStoreTouchFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 UserPublicRepo
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 time.Time
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -36172,47 +39592,45 @@ type UserPublicRepoStoreSetUserRepoFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserPublicRepoStoreSetUserRepoFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c UserSessionStoreTouchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserPublicRepoStoreSetUserRepoFuncCall) Results() []interface{} {
+func (c UserSessionStoreTouchFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UserPublicRepoStoreSetUserReposFunc describes the behavior when the
-// SetUserRepos method of the parent MockUserPublicRepoStore instance is
-// invoked.
-type UserPublicRepoStoreSetUserReposFunc struct {
-	defaultHook func(context.Context, int32, []UserPublicRepo) error
-	hooks       []func(context.Context, int32, []UserPublicRepo) error
-	history     []UserPublicRepoStoreSetUserReposFuncCall
+// UserSessionStoreUpsertFunc describes the behavior when the Upsert method
+// of the parent MockUserSessionStore instance is invoked.
+type UserSessionStoreUpsertFunc struct {
+	defaultHook func(context.Context, *UserSession) error
+	hooks       []func(context.Context, *UserSession) error
+	history     []UserSessionStoreUpsertFuncCall
 	mutex       sync.Mutex
 }
 
-// SetUserRepos delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockUserPublicRepoStore) SetUserRepos(v0 context.Context, v1 int32, v2 []UserPublicRepo) error {
-	r0 := m.SetUserReposFunc.nextHook()(v0, v1, v2)
-	m.SetUserReposFunc.appendCall(UserPublicRepoStoreSetUserReposFuncCall{v0, v1, v2, r0})
+// Upsert delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserSessionStore) Upsert(v0 context.Context, v1 *UserSession) error {
+	r0 := m.UpsertFunc.nextHook()(v0, v1)
+	m.UpsertFunc.appendCall(UserSessionStoreUpsertFuncCall{v0, v1, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the SetUserRepos method
-// of the parent MockUserPublicRepoStore instance is invoked and the hook
-// queue is empty.
-func (f *UserPublicRepoStoreSetUserReposFunc) SetDefaultHook(hook func(context.Context, int32, []UserPublicRepo) error) {
+// SetDefaultHook sets function that is called when the Upsert method of the
+// parent MockUserSessionStore instance is invoked and the hook queue is
+// empty.
+func (f *UserSessionStoreUpsertFunc) SetDefaultHook(hook func(context.Context, *UserSession) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// SetUserRepos method of the parent MockUserPublicRepoStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *UserPublicRepoStoreSetUserReposFunc) PushHook(hook func(context.Context, int32, []UserPublicRepo) error) {
+// Upsert method of the parent MockUserSessionStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserSessionStoreUpsertFunc) PushHook(hook func(context.Context, *UserSession) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -36220,21 +39638,21 @@ func (f *UserPublicRepoStoreSetUserReposFunc) PushHook(hook func(context.Context
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *UserPublicRepoStoreSetUserReposFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, []UserPublicRepo) error {
+func (f *UserSessionStoreUpsertFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *UserSession) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *UserPublicRepoStoreSetUserReposFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, []UserPublicRepo) error {
+func (f *UserSessionStoreUpsertFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *UserSession) error {
 		return r0
 	})
 }
 
-func (f *UserPublicRepoStoreSetUserReposFunc) nextHook() func(context.Context, int32, []UserPublicRepo) error {
+func (f *UserSessionStoreUpsertFunc) nextHook() func(context.Context, *UserSession) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -36247,36 +39665,32 @@ func (f *UserPublicRepoStoreSetUserReposFunc) nextHook() func(context.Context, i
 	return hook
 }
 
-func (f *UserPublicRepoStoreSetUserReposFunc) appendCall(r0 UserPublicRepoStoreSetUserReposFuncCall) {
+func (f *UserSessionStoreUpsertFunc) appendCall(r0 UserSessionStoreUpsertFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UserPublicRepoStoreSetUserReposFuncCall
-// objects describing the invocations of this function.
-func (f *UserPublicRepoStoreSetUserReposFunc) History() []UserPublicRepoStoreSetUserReposFuncCall {
+// History returns a sequence of UserSessionStoreUpsertFuncCall objects
+// describing the invocations of this function.
+func (f *UserSessionStoreUpsertFunc) History() []UserSessionStoreUpsertFuncCall {
 	f.mutex.Lock()
-	history := make([]UserPublicRepoStoreSetUserReposFuncCall, len(f.history))
+	history := make([]UserSessionStoreUpsertFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UserPublicRepoStoreSetUserReposFuncCall is an object that describes an
-// invocation of method SetUserRepos on an instance of
-// MockUserPublicRepoStore.
-type UserPublicRepoStoreSetUserReposFuncCall struct {
+// UserSessionStoreUpsertFuncCall is an object that describes an invocation
+// of method Upsert on an instance of MockUserSessionStore.
+type UserSessionStoreUpsertFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 []UserPublicRepo
+	Arg1 *UserSession
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -36284,13 +39698,13 @@ type UserPublicRepoStoreSetUserReposFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UserPublicRepoStoreSetUserReposFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c UserSessionStoreUpsertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UserPublicRepoStoreSetUserReposFuncCall) Results() []interface{} {
+func (c UserSessionStoreUpsertFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
@@ -36370,6 +39784,9 @@ type MockUserStore struct {
 	// a mock function object controlling the behavior of the method
 	// RandomizePasswordAndClearPasswordResetRateLimit.
 	RandomizePasswordAndClearPasswordResetRateLimitFunc *UserStoreRandomizePasswordAndClearPasswordResetRateLimitFunc
+	// RecoverFunc is an instance of a mock function object controlling the
+	// behavior of the method Recover.
+	RecoverFunc *UserStoreRecoverFunc
 	// RenewPasswordResetCodeFunc is an instance of a mock function object
 	// controlling the behavior of the method RenewPasswordResetCode.
 	RenewPasswordResetCodeFunc *UserStoreRenewPasswordResetCodeFunc
@@ -36522,6 +39939,11 @@ func NewMockUserStore() *MockUserStore {
 				return nil
 			},
 		},
+		RecoverFunc: &UserStoreRecoverFunc{
+			defaultHook: func(context.Context, int32) error {
+				return nil
+			},
+		},
 		RenewPasswordResetCodeFunc: &UserStoreRenewPasswordResetCodeFunc{
 			defaultHook: func(context.Context, int32) (string, error) {
 				return "", nil
@@ -36694,6 +40116,11 @@ func NewStrictMockUserStore() *MockUserStore {
 				panic("unexpected invocation of MockUserStore.RandomizePasswordAndClearPasswordResetRateLimit")
 			},
 		},
+		RecoverFunc: &UserStoreRecoverFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockUserStore.Recover")
+			},
+		},
 		RenewPasswordResetCodeFunc: &UserStoreRenewPasswordResetCodeFunc{
 			defaultHook: func(context.Context, int32) (string, error) {
 				panic("unexpected invocation of MockUserStore.RenewPasswordResetCode")
@@ -36820,6 +40247,9 @@ func NewMockUserStoreFrom(i UserStore) *MockUserStore {
 		RandomizePasswordAndClearPasswordResetRateLimitFunc: &UserStoreRandomizePasswordAndClearPasswordResetRateLimitFunc{
 			defaultHook: i.RandomizePasswordAndClearPasswordResetRateLimit,
 		},
+		RecoverFunc: &UserStoreRecoverFunc{
+			defaultHook: i.Recover,
+		},
 		RenewPasswordResetCodeFunc: &UserStoreRenewPasswordResetCodeFunc{
 			defaultHook: i.RenewPasswordResetCode,
 		},
@@ -39352,6 +42782,111 @@ func (c UserStoreRandomizePasswordAndClearPasswordResetRateLimitFuncCall) Result
 	return []interface{}{c.Result0}
 }
 
+// UserStoreRecoverFunc describes the behavior when the Recover method of
+// the parent MockUserStore instance is invoked.
+type UserStoreRecoverFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []UserStoreRecoverFuncCall
+	mutex       sync.Mutex
+}
+
+// Recover delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserStore) Recover(v0 context.Context, v1 int32) error {
+	r0 := m.RecoverFunc.nextHook()(v0, v1)
+	m.RecoverFunc.appendCall(UserStoreRecoverFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Recover method of
+// the parent MockUserStore instance is invoked and the hook queue is empty.
+func (f *UserStoreRecoverFunc) SetDefaultHook(hook func(context.Context, int32) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Recover method of the parent MockUserStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UserStoreRecoverFunc) PushHook(hook func(context.Context, int32) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *UserStoreRecoverFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *UserStoreRecoverFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
+		return r0
+	})
+}
+
+func (f *UserStoreRecoverFunc) nextHook() func(context.Context, int32) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserStoreRecoverFunc) appendCall(r0 UserStoreRecoverFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UserStoreRecoverFuncCall objects describing
+// the invocations of this function.
+func (f *UserStoreRecoverFunc) History() []UserStoreRecoverFuncCall {
+	f.mutex.Lock()
+	history := make([]UserStoreRecoverFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserStoreRecoverFuncCall is an object that describes an invocation of
+// method Recover on an instance of MockUserStore.
+type UserStoreRecoverFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserStoreRecoverFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserStoreRecoverFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // UserStoreRenewPasswordResetCodeFunc describes the behavior when the
 // RenewPasswordResetCode method of the parent MockUserStore instance is
 // invoked.
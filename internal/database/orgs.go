@@ -28,7 +28,9 @@ func (e *OrgNotFoundError) NotFound() bool {
 	return true
 }
 
-var errOrgNameAlreadyExists = errors.New("organization name is already taken (by a user or another organization)")
+// ErrOrgNameAlreadyExists is returned when creating an organization whose name
+// is already used by a user or another organization.
+var ErrOrgNameAlreadyExists = errors.New("organization name is already taken (by a user or another organization)")
 
 type OrgStore interface {
 	Count(context.Context, OrgsListOptions) (int, error)
@@ -229,7 +231,7 @@ func (o *orgStore) Create(ctx context.Context, name string, displayName *string)
 		if errors.As(err, &e) {
 			switch e.ConstraintName {
 			case "orgs_name":
-				return nil, errOrgNameAlreadyExists
+				return nil, ErrOrgNameAlreadyExists
 			case "orgs_name_max_length", "orgs_name_valid_chars":
 				return nil, errors.Errorf("org name invalid: %s", e.ConstraintName)
 			case "orgs_display_name_max_length":
@@ -242,7 +244,7 @@ func (o *orgStore) Create(ctx context.Context, name string, displayName *string)
 
 	// Reserve organization name in shared users+orgs namespace.
 	if _, err := tx.Handle().DB().ExecContext(ctx, "INSERT INTO names(name, org_id) VALUES($1, $2)", newOrg.Name, newOrg.ID); err != nil {
-		return nil, errOrgNameAlreadyExists
+		return nil, ErrOrgNameAlreadyExists
 	}
 
 	return newOrg, nil
@@ -0,0 +1,337 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// Permission is a capability beyond those of regular users that can be
+// granted to users and organizations through roles. Site admins implicitly
+// have all permissions.
+type Permission string
+
+const (
+	// PermissionBatchChangesAdmin allows administering all batch changes and
+	// managing global batch changes credentials.
+	PermissionBatchChangesAdmin Permission = "BATCH_CHANGES_ADMIN"
+	// PermissionInsightsEdit allows managing the series of all code insights.
+	PermissionInsightsEdit Permission = "INSIGHTS_EDIT"
+	// PermissionCodeMonitorsManage allows managing the code monitors of all
+	// users.
+	PermissionCodeMonitorsManage Permission = "CODE_MONITORS_MANAGE"
+	// PermissionRepoAdmin allows administering all repositories.
+	PermissionRepoAdmin Permission = "REPO_ADMIN"
+)
+
+// AllPermissions are all permissions, which site admins implicitly have.
+var AllPermissions = []Permission{
+	PermissionBatchChangesAdmin,
+	PermissionCodeMonitorsManage,
+	PermissionInsightsEdit,
+	PermissionRepoAdmin,
+}
+
+// Role is a named set of permissions.
+type Role struct {
+	ID          int32
+	Name        string
+	Description string
+	Permissions []Permission
+	CreatedAt   time.Time
+}
+
+// RoleAssignment assigns a role either to a user, or to an organization whose
+// members then have the role.
+type RoleAssignment struct {
+	ID        int32
+	RoleID    int32
+	UserID    int32
+	OrgID     int32
+	CreatedAt time.Time
+}
+
+// Validate returns an error if the assignment can't be stored.
+func (a *RoleAssignment) Validate() error {
+	if a.RoleID == 0 {
+		return errors.New("a role assignment must have a role")
+	}
+	if (a.UserID == 0) == (a.OrgID == 0) {
+		return errors.New("a role must be assigned to either a user or an organization")
+	}
+	return nil
+}
+
+// RoleNotFoundErr is returned when a role can't be found.
+type RoleNotFoundErr struct {
+	ID   int32
+	Name string
+}
+
+func (e *RoleNotFoundErr) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("role not found: name=%q", e.Name)
+	}
+	return fmt.Sprintf("role not found: id=%d", e.ID)
+}
+
+func (e *RoleNotFoundErr) NotFound() bool {
+	return true
+}
+
+// ListRoleAssignmentsOpts filters the role assignments returned by
+// RoleStore.ListAssignments. Zero values don't filter.
+type ListRoleAssignmentsOpts struct {
+	RoleID int32
+	UserID int32
+	OrgID  int32
+}
+
+// RoleStore stores roles and their assignments to users and organizations.
+type RoleStore interface {
+	basestore.ShareableStore
+
+	// List returns all roles ordered by name.
+	List(ctx context.Context) ([]*Role, error)
+
+	// GetByID returns the role with the given ID, or a *RoleNotFoundErr.
+	GetByID(ctx context.Context, id int32) (*Role, error)
+
+	// GetByName returns the role with the given name, or a *RoleNotFoundErr.
+	GetByName(ctx context.Context, name string) (*Role, error)
+
+	// Assign assigns a role to the user or organization of the assignment.
+	// Assigning a role that is already assigned is not an error.
+	Assign(ctx context.Context, a *RoleAssignment) error
+
+	// Unassign removes the assignment of a role to the user or organization
+	// of the assignment. Removing an assignment that doesn't exist is not an
+	// error.
+	Unassign(ctx context.Context, a *RoleAssignment) error
+
+	// ListAssignments returns the role assignments matching the options,
+	// ordered by ID.
+	ListAssignments(ctx context.Context, opts ListRoleAssignmentsOpts) ([]*RoleAssignment, error)
+
+	// ListPermissionsForUser returns the permissions granted to the user by
+	// the roles assigned to them directly or through their organizations,
+	// ordered by name. It doesn't take site admin status into account.
+	ListPermissionsForUser(ctx context.Context, userID int32) ([]Permission, error)
+
+	// UserHasPermission returns whether a role assigned to the user directly
+	// or through one of their organizations grants the permission. It doesn't
+	// take site admin status into account.
+	UserHasPermission(ctx context.Context, userID int32, p Permission) (bool, error)
+}
+
+type roleStore struct {
+	*basestore.Store
+}
+
+// Roles instantiates and returns a new RoleStore with prepared statements.
+func Roles(db dbutil.DB) RoleStore {
+	return &roleStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// RolesWith instantiates and returns a new RoleStore using the other store handle.
+func RolesWith(other basestore.ShareableStore) RoleStore {
+	return &roleStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+var roleColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("name"),
+	sqlf.Sprintf("description"),
+	sqlf.Sprintf("permissions"),
+	sqlf.Sprintf("created_at"),
+}
+
+func (s *roleStore) List(ctx context.Context) ([]*Role, error) {
+	q := sqlf.Sprintf(`
+-- source: internal/database/roles.go:List
+SELECT %s
+FROM roles
+ORDER BY name
+`, sqlf.Join(roleColumns, ", "))
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing roles")
+	}
+	defer rows.Close()
+
+	var roles []*Role
+	for rows.Next() {
+		r, err := scanRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, r)
+	}
+	return roles, rows.Err()
+}
+
+func (s *roleStore) GetByID(ctx context.Context, id int32) (*Role, error) {
+	q := sqlf.Sprintf(`
+-- source: internal/database/roles.go:GetByID
+SELECT %s
+FROM roles
+WHERE id = %s
+`, sqlf.Join(roleColumns, ", "), id)
+	r, err := scanRole(s.QueryRow(ctx, q))
+	if err == sql.ErrNoRows {
+		return nil, &RoleNotFoundErr{ID: id}
+	}
+	return r, err
+}
+
+func (s *roleStore) GetByName(ctx context.Context, name string) (*Role, error) {
+	q := sqlf.Sprintf(`
+-- source: internal/database/roles.go:GetByName
+SELECT %s
+FROM roles
+WHERE name = %s
+`, sqlf.Join(roleColumns, ", "), name)
+	r, err := scanRole(s.QueryRow(ctx, q))
+	if err == sql.ErrNoRows {
+		return nil, &RoleNotFoundErr{Name: name}
+	}
+	return r, err
+}
+
+func scanRole(sc dbutil.Scanner) (*Role, error) {
+	var (
+		r           Role
+		permissions []string
+	)
+	if err := sc.Scan(&r.ID, &r.Name, &r.Description, pq.Array(&permissions), &r.CreatedAt); err != nil {
+		return nil, err
+	}
+	r.Permissions = make([]Permission, len(permissions))
+	for i, p := range permissions {
+		r.Permissions[i] = Permission(p)
+	}
+	return &r, nil
+}
+
+func (s *roleStore) Assign(ctx context.Context, a *RoleAssignment) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	q := sqlf.Sprintf(`
+-- source: internal/database/roles.go:Assign
+INSERT INTO role_assignments (role_id, user_id, org_id)
+VALUES (%s, %s, %s)
+ON CONFLICT DO NOTHING
+`, a.RoleID, nullInt32Column(a.UserID), nullInt32Column(a.OrgID))
+	return errors.Wrap(s.Exec(ctx, q), "assigning role")
+}
+
+func (s *roleStore) Unassign(ctx context.Context, a *RoleAssignment) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	q := sqlf.Sprintf(`
+-- source: internal/database/roles.go:Unassign
+DELETE FROM role_assignments
+WHERE role_id = %s AND user_id IS NOT DISTINCT FROM %s AND org_id IS NOT DISTINCT FROM %s
+`, a.RoleID, nullInt32Column(a.UserID), nullInt32Column(a.OrgID))
+	return errors.Wrap(s.Exec(ctx, q), "unassigning role")
+}
+
+func (s *roleStore) ListAssignments(ctx context.Context, opts ListRoleAssignmentsOpts) ([]*RoleAssignment, error) {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.RoleID != 0 {
+		conds = append(conds, sqlf.Sprintf("role_id = %s", opts.RoleID))
+	}
+	if opts.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("user_id = %s", opts.UserID))
+	}
+	if opts.OrgID != 0 {
+		conds = append(conds, sqlf.Sprintf("org_id = %s", opts.OrgID))
+	}
+
+	q := sqlf.Sprintf(`
+-- source: internal/database/roles.go:ListAssignments
+SELECT id, role_id, user_id, org_id, created_at
+FROM role_assignments
+WHERE %s
+ORDER BY id
+`, sqlf.Join(conds, "AND"))
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing role assignments")
+	}
+	defer rows.Close()
+
+	var assignments []*RoleAssignment
+	for rows.Next() {
+		var (
+			a             RoleAssignment
+			userID, orgID sql.NullInt32
+		)
+		if err := rows.Scan(&a.ID, &a.RoleID, &userID, &orgID, &a.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "scanning row")
+		}
+		a.UserID = userID.Int32
+		a.OrgID = orgID.Int32
+		assignments = append(assignments, &a)
+	}
+	return assignments, rows.Err()
+}
+
+// userRoleAssignmentsCond matches the role assignments that apply to the
+// user, directly or through an organization they are a member of.
+func userRoleAssignmentsCond(userID int32) *sqlf.Query {
+	return sqlf.Sprintf(`(
+	ra.user_id = %s
+	OR ra.org_id IN (
+		SELECT om.org_id
+		FROM org_members om
+		JOIN orgs o ON o.id = om.org_id
+		WHERE om.user_id = %s AND o.deleted_at IS NULL
+	)
+)`, userID, userID)
+}
+
+func (s *roleStore) ListPermissionsForUser(ctx context.Context, userID int32) ([]Permission, error) {
+	q := sqlf.Sprintf(`
+-- source: internal/database/roles.go:ListPermissionsForUser
+SELECT DISTINCT permission
+FROM role_assignments ra
+JOIN roles r ON r.id = ra.role_id
+CROSS JOIN LATERAL unnest(r.permissions) AS permission
+WHERE %s
+ORDER BY permission
+`, userRoleAssignmentsCond(userID))
+	permissions, err := basestore.ScanStrings(s.Query(ctx, q))
+	if err != nil {
+		return nil, errors.Wrap(err, "listing permissions for user")
+	}
+	result := make([]Permission, len(permissions))
+	for i, p := range permissions {
+		result[i] = Permission(p)
+	}
+	return result, nil
+}
+
+func (s *roleStore) UserHasPermission(ctx context.Context, userID int32, p Permission) (bool, error) {
+	q := sqlf.Sprintf(`
+-- source: internal/database/roles.go:UserHasPermission
+SELECT EXISTS (
+	SELECT 1
+	FROM role_assignments ra
+	JOIN roles r ON r.id = ra.role_id
+	WHERE %s AND %s = ANY(r.permissions)
+)
+`, userRoleAssignmentsCond(userID), string(p))
+	ok, _, err := basestore.ScanFirstBool(s.Query(ctx, q))
+	return ok, errors.Wrap(err, "checking permission of user")
+}
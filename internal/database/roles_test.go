@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestRoleAssignmentValidate(t *testing.T) {
+	for _, a := range []*RoleAssignment{
+		{UserID: 1},
+		{RoleID: 1},
+		{RoleID: 1, UserID: 1, OrgID: 1},
+	} {
+		require.Error(t, a.Validate(), "%+v", a)
+	}
+	require.NoError(t, (&RoleAssignment{RoleID: 1, OrgID: 1}).Validate())
+}
+
+func TestRoles(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := NewDB(dbtest.NewDB(t))
+	ctx := context.Background()
+
+	alice, err := db.Users().Create(ctx, NewUser{Username: "alice"})
+	require.NoError(t, err)
+	bob, err := db.Users().Create(ctx, NewUser{Username: "bob"})
+	require.NoError(t, err)
+	org, err := db.Orgs().Create(ctx, "monitoring", nil)
+	require.NoError(t, err)
+	_, err = db.OrgMembers().Create(ctx, org.ID, bob.ID)
+	require.NoError(t, err)
+
+	// The built-in roles are created by the migration.
+	roles, err := db.Roles().List(ctx)
+	require.NoError(t, err)
+	require.Len(t, roles, 4)
+
+	batchChangesAdmin, err := db.Roles().GetByName(ctx, "batch_changes_admin")
+	require.NoError(t, err)
+	require.Equal(t, []Permission{PermissionBatchChangesAdmin}, batchChangesAdmin.Permissions)
+	codeMonitorManager, err := db.Roles().GetByName(ctx, "code_monitor_manager")
+	require.NoError(t, err)
+
+	_, err = db.Roles().GetByName(ctx, "unknown")
+	require.True(t, errcode.IsNotFound(err))
+
+	require.NoError(t, db.Roles().Assign(ctx, &RoleAssignment{RoleID: batchChangesAdmin.ID, UserID: alice.ID}))
+	// Assigning a role twice is not an error.
+	require.NoError(t, db.Roles().Assign(ctx, &RoleAssignment{RoleID: batchChangesAdmin.ID, UserID: alice.ID}))
+	require.NoError(t, db.Roles().Assign(ctx, &RoleAssignment{RoleID: codeMonitorManager.ID, OrgID: org.ID}))
+
+	assignments, err := db.Roles().ListAssignments(ctx, ListRoleAssignmentsOpts{RoleID: batchChangesAdmin.ID})
+	require.NoError(t, err)
+	require.Len(t, assignments, 1)
+	require.Equal(t, alice.ID, assignments[0].UserID)
+
+	ok, err := db.Roles().UserHasPermission(ctx, alice.ID, PermissionBatchChangesAdmin)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = db.Roles().UserHasPermission(ctx, alice.ID, PermissionCodeMonitorsManage)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Bob has the role of his organization.
+	permissions, err := db.Roles().ListPermissionsForUser(ctx, bob.ID)
+	require.NoError(t, err)
+	require.Equal(t, []Permission{PermissionCodeMonitorsManage}, permissions)
+
+	require.NoError(t, db.Roles().Unassign(ctx, &RoleAssignment{RoleID: codeMonitorManager.ID, OrgID: org.ID}))
+	ok, err = db.Roles().UserHasPermission(ctx, bob.ID, PermissionCodeMonitorsManage)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
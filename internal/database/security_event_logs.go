@@ -2,14 +2,20 @@ package database
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
-	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auditlog"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/sentry"
@@ -41,11 +47,29 @@ const (
 	SecurityEventNameRoleChangeDenied  SecurityEventName = "RoleChangeDenied"
 	SecurityEventNameRoleChangeGranted SecurityEventName = "RoleChangeGranted"
 
+	SecurityEventNameRoleAssigned   SecurityEventName = "RoleAssigned"
+	SecurityEventNameRoleUnassigned SecurityEventName = "RoleUnassigned"
+
 	SecurityEventNameAccessGranted SecurityEventName = "AccessGranted"
+
+	SecurityEventNameAccessTokenCreated SecurityEventName = "AccessTokenCreated"
+	SecurityEventNameAccessTokenDeleted SecurityEventName = "AccessTokenDeleted"
+
+	SecurityEventNameSiteConfigUpdated SecurityEventName = "SiteConfigUpdated"
+
+	SecurityEventNameExternalServiceCreated SecurityEventName = "ExternalServiceCreated"
+	SecurityEventNameExternalServiceUpdated SecurityEventName = "ExternalServiceUpdated"
+	SecurityEventNameExternalServiceDeleted SecurityEventName = "ExternalServiceDeleted"
+
+	SecurityEventNameRepoPermissionsUpdated  SecurityEventName = "RepoPermissionsUpdated"
+	SecurityEventNameSubRepoPathRulesUpdated SecurityEventName = "SubRepoPathRulesUpdated"
+
+	SecurityEventNameBatchChangeApplied SecurityEventName = "BatchChangeApplied"
 )
 
 // SecurityEvent contains information needed for logging a security-relevant event.
 type SecurityEvent struct {
+	ID              int64
 	Name            SecurityEventName
 	URL             string
 	UserID          uint32
@@ -53,6 +77,18 @@ type SecurityEvent struct {
 	Argument        json.RawMessage
 	Source          string
 	Timestamp       time.Time
+
+	// Before and After hold the state of the changed resource for audited
+	// changes, such as site configuration edits.
+	Before json.RawMessage
+	After  json.RawMessage
+
+	// Version, PreviousHash and Hash are set by the store. Hash covers the event
+	// and PreviousHash, which chains each event to the one inserted before it so
+	// that modified or deleted rows can be detected.
+	Version      string
+	PreviousHash string
+	Hash         string
 }
 
 // A SecurityEventLogStore provides persistence for security events.
@@ -65,38 +101,263 @@ func SecurityEventLogs(db dbutil.DB) *SecurityEventLogStore {
 	return &SecurityEventLogStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
 }
 
-// Insert adds a new security event to the store.
-func (s *SecurityEventLogStore) Insert(ctx context.Context, e *SecurityEvent) error {
-	argument := e.Argument
-	if argument == nil {
-		argument = []byte(`{}`)
+// securityEventLogsChainLockKey is the advisory lock key that serializes
+// inserts, so that every event is chained to its predecessor.
+const securityEventLogsChainLockKey = 0x5ec10600
+
+// Insert adds a new security event to the store, chaining it to the most
+// recently inserted event. On success, the ID, Version, PreviousHash and Hash
+// fields of e are set.
+func (s *SecurityEventLogStore) Insert(ctx context.Context, e *SecurityEvent) (err error) {
+	if e.Argument == nil {
+		e.Argument = []byte(`{}`)
 	}
+	e.Version = version.Version()
+	// Postgres stores timestamps with microsecond precision, which the hash must
+	// match to be verifiable.
+	e.Timestamp = e.Timestamp.UTC().Truncate(time.Microsecond)
 
-	_, err := s.Handle().DB().ExecContext(
-		ctx,
-		"INSERT INTO security_event_logs(name, url, user_id, anonymous_user_id, source, argument, version, timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf("SELECT pg_advisory_xact_lock(%s)", securityEventLogsChainLockKey)); err != nil {
+		return err
+	}
+
+	var previousHash sql.NullString
+	err = tx.QueryRow(ctx, sqlf.Sprintf("SELECT hash FROM security_event_logs WHERE hash IS NOT NULL ORDER BY id DESC LIMIT 1")).Scan(&previousHash)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	e.PreviousHash = previousHash.String
+
+	if e.Hash, err = e.computeHash(); err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, sqlf.Sprintf(
+		"INSERT INTO security_event_logs(name, url, user_id, anonymous_user_id, source, argument, version, timestamp, before, after, previous_hash, hash) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s) RETURNING id",
 		e.Name,
 		e.URL,
 		e.UserID,
 		e.AnonymousUserID,
 		e.Source,
-		argument,
-		version.Version(),
-		e.Timestamp.UTC(),
-	)
+		[]byte(e.Argument),
+		e.Version,
+		e.Timestamp,
+		nullJSON(e.Before),
+		nullJSON(e.After),
+		nullStringColumn(e.PreviousHash),
+		e.Hash,
+	)).Scan(&e.ID)
 	if err != nil {
 		return errors.Wrap(err, "INSERT")
 	}
 	return nil
 }
 
+// computeHash returns the hex encoded SHA-256 hash of the event and its
+// PreviousHash. JSON values are canonicalized first, since Postgres doesn't
+// preserve their formatting in jsonb columns.
+func (e *SecurityEvent) computeHash() (string, error) {
+	var canonical [3]json.RawMessage
+	for i, v := range []json.RawMessage{e.Argument, e.Before, e.After} {
+		c, err := canonicalJSON(v)
+		if err != nil {
+			return "", err
+		}
+		canonical[i] = c
+	}
+
+	b, err := json.Marshal(struct {
+		PreviousHash    string          `json:"previousHash"`
+		Name            string          `json:"name"`
+		URL             string          `json:"url"`
+		UserID          uint32          `json:"userID"`
+		AnonymousUserID string          `json:"anonymousUserID"`
+		Source          string          `json:"source"`
+		Argument        json.RawMessage `json:"argument"`
+		Before          json.RawMessage `json:"before"`
+		After           json.RawMessage `json:"after"`
+		Version         string          `json:"version"`
+		Timestamp       string          `json:"timestamp"`
+	}{
+		PreviousHash:    e.PreviousHash,
+		Name:            string(e.Name),
+		URL:             e.URL,
+		UserID:          e.UserID,
+		AnonymousUserID: e.AnonymousUserID,
+		Source:          e.Source,
+		Argument:        canonical[0],
+		Before:          canonical[1],
+		After:           canonical[2],
+		Version:         e.Version,
+		Timestamp:       e.Timestamp.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON re-encodes the JSON value v with sorted object keys and no
+// insignificant whitespace. An empty value is encoded as null.
+func canonicalJSON(v json.RawMessage) (json.RawMessage, error) {
+	if len(v) == 0 {
+		return json.RawMessage("null"), nil
+	}
+	var x interface{}
+	if err := json.Unmarshal(v, &x); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON")
+	}
+	return json.Marshal(x)
+}
+
+func nullJSON(v json.RawMessage) interface{} {
+	if len(v) == 0 {
+		return nil
+	}
+	return []byte(v)
+}
+
+// SecurityEventsListOptions specifies the options for listing security events.
+type SecurityEventsListOptions struct {
+	// Names, if non-empty, only includes events with one of the given names.
+	Names []string
+	// UserID, if non-zero, only includes events of the given user.
+	UserID int32
+	// Since and Until, if set, only include events in the given time range.
+	Since, Until *time.Time
+	// Cursor, if non-zero, only includes events with an ID less than Cursor.
+	Cursor int64
+	// Limit, if non-zero, limits the number of events returned.
+	Limit int
+}
+
+func (o SecurityEventsListOptions) conds() *sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if len(o.Names) > 0 {
+		conds = append(conds, sqlf.Sprintf("name = ANY(%s)", pq.Array(o.Names)))
+	}
+	if o.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("user_id = %s", o.UserID))
+	}
+	if o.Since != nil {
+		conds = append(conds, sqlf.Sprintf("timestamp >= %s", *o.Since))
+	}
+	if o.Until != nil {
+		conds = append(conds, sqlf.Sprintf("timestamp < %s", *o.Until))
+	}
+	if o.Cursor != 0 {
+		conds = append(conds, sqlf.Sprintf("id < %s", o.Cursor))
+	}
+	return sqlf.Join(conds, "AND")
+}
+
+const securityEventColumns = "id, name, url, user_id, anonymous_user_id, source, argument, version, timestamp, before, after, previous_hash, hash"
+
+// List returns the security events matching the options, newest first.
+func (s *SecurityEventLogStore) List(ctx context.Context, opts SecurityEventsListOptions) ([]*SecurityEvent, error) {
+	limit := sqlf.Sprintf("")
+	if opts.Limit > 0 {
+		limit = sqlf.Sprintf("LIMIT %d", opts.Limit)
+	}
+	return s.list(ctx, sqlf.Sprintf("SELECT "+securityEventColumns+" FROM security_event_logs WHERE %s ORDER BY id DESC %s", opts.conds(), limit))
+}
+
+// Count returns the number of security events matching the options. Cursor
+// and Limit are ignored.
+func (s *SecurityEventLogStore) Count(ctx context.Context, opts SecurityEventsListOptions) (int, error) {
+	opts.Cursor = 0
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf("SELECT COUNT(*) FROM security_event_logs WHERE %s", opts.conds())))
+	return count, err
+}
+
+func (s *SecurityEventLogStore) list(ctx context.Context, q *sqlf.Query) (_ []*SecurityEvent, err error) {
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var events []*SecurityEvent
+	for rows.Next() {
+		var (
+			e                       SecurityEvent
+			argument, before, after []byte
+			previousHash, hash      sql.NullString
+		)
+		if err := rows.Scan(&e.ID, &e.Name, &e.URL, &e.UserID, &e.AnonymousUserID, &e.Source, &argument, &e.Version, &e.Timestamp, &before, &after, &previousHash, &hash); err != nil {
+			return nil, err
+		}
+		e.Argument, e.Before, e.After = argument, before, after
+		e.PreviousHash, e.Hash = previousHash.String, hash.String
+		events = append(events, &e)
+	}
+	return events, nil
+}
+
+// SecurityEventLogVerification is the result of verifying the hash chain of
+// the security event log.
+type SecurityEventLogVerification struct {
+	// Verified is the number of events whose hash was verified.
+	Verified int
+	// FirstInvalidID is the ID of the first event whose hash or link to the
+	// previous event doesn't match, or zero if the chain is intact.
+	FirstInvalidID int64
+}
+
+// VerifyChain recomputes the hashes of all hashed events in insertion order
+// and checks that each event is chained to its predecessor. The first
+// remaining event may point to an event that has since been deleted by the
+// retention policy, so its link is not checked.
+func (s *SecurityEventLogStore) VerifyChain(ctx context.Context) (*SecurityEventLogVerification, error) {
+	const batchSize = 1000
+
+	var (
+		res      SecurityEventLogVerification
+		lastID   int64
+		lastHash string
+		first    = true
+	)
+	for {
+		events, err := s.list(ctx, sqlf.Sprintf(
+			"SELECT "+securityEventColumns+" FROM security_event_logs WHERE hash IS NOT NULL AND id > %s ORDER BY id ASC LIMIT %d",
+			lastID,
+			batchSize,
+		))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range events {
+			hash, err := e.computeHash()
+			if err != nil || hash != e.Hash || (!first && e.PreviousHash != lastHash) {
+				res.FirstInvalidID = e.ID
+				return &res, nil
+			}
+			res.Verified++
+			first = false
+			lastID, lastHash = e.ID, e.Hash
+		}
+
+		if len(events) < batchSize {
+			return &res, nil
+		}
+	}
+}
+
 // LogEvent will log security events.
 //
 // Note that it does not return an error and will instead simply log it.
 func (s *SecurityEventLogStore) LogEvent(ctx context.Context, e *SecurityEvent) {
-	// We don't want to begin logging authentication or authorization events in
-	// on-premises installations yet.
-	if !envvar.SourcegraphDotComMode() {
+	// Security events are only recorded on Sourcegraph.com and in instances
+	// that enabled the audit log.
+	if !conf.AuditLogEnabled() {
 		return
 	}
 
@@ -106,5 +367,51 @@ func (s *SecurityEventLogStore) LogEvent(ctx context.Context, e *SecurityEvent)
 		// We want to capture in sentry as it includes a stack trace which will allow us
 		// to track down the root cause.
 		sentry.CaptureError(err, map[string]string{})
+		return
+	}
+
+	if j, err := json.Marshal(e); err == nil {
+		auditlog.Export(j)
+	}
+}
+
+// LogAuditEvent logs a change made by the actor in ctx to a resource. before
+// and after are the JSON-serializable states of the resource, and either may be
+// nil for created or deleted resources. Secrets must be redacted by the caller.
+func (s *SecurityEventLogStore) LogAuditEvent(ctx context.Context, name SecurityEventName, argument, before, after interface{}) {
+	if !conf.AuditLogEnabled() {
+		return
+	}
+
+	a := actor.FromContext(ctx)
+	event := &SecurityEvent{
+		Name:      name,
+		UserID:    uint32(a.UID),
+		Source:    "BACKEND",
+		Timestamp: time.Now(),
 	}
+	// Internal actors and background processes don't have a user, which would
+	// violate the security_event_logs_check_has_user constraint.
+	if a.UID == 0 {
+		event.AnonymousUserID = "internal"
+	}
+
+	for _, f := range []struct {
+		dst *json.RawMessage
+		v   interface{}
+	}{{&event.Argument, argument}, {&event.Before, before}, {&event.After, after}} {
+		if f.v == nil {
+			continue
+		}
+		b, err := json.Marshal(f.v)
+		if err != nil {
+			log15.Error("failed to marshal audit event", "name", name, "err", err)
+			return
+		}
+		if string(b) != "null" {
+			*f.dst = b
+		}
+	}
+
+	s.LogEvent(ctx, event)
 }
@@ -2,8 +2,12 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 )
@@ -67,3 +71,115 @@ func TestSecurityEventLogs_ValidInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestSecurityEventLogs_HashChain(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+	store := SecurityEventLogs(db)
+
+	events := []*SecurityEvent{
+		{Name: SecurityEventNameSiteConfigUpdated, UserID: 1, Source: "BACKEND", Before: json.RawMessage(`{"b": 1, "a": 2}`), After: json.RawMessage(`{"a": 3}`)},
+		{Name: SecurityEventNameAccessTokenCreated, UserID: 1, Source: "BACKEND", Argument: json.RawMessage(`{"note": "ci"}`)},
+		{Name: SecurityEventNameAccessTokenDeleted, UserID: 2, Source: "BACKEND", Timestamp: time.Now()},
+	}
+	for _, e := range events {
+		if err := store.Insert(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if events[0].PreviousHash != "" {
+		t.Errorf("unexpected previous hash of first event: %q", events[0].PreviousHash)
+	}
+	for i := 1; i < len(events); i++ {
+		if have, want := events[i].PreviousHash, events[i-1].Hash; have != want {
+			t.Errorf("event %d: have previous hash %q, want %q", i, have, want)
+		}
+	}
+
+	res, err := store.VerifyChain(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&SecurityEventLogVerification{Verified: 3}, res); diff != "" {
+		t.Fatalf("unexpected verification (-want +have):\n%s", diff)
+	}
+
+	t.Run("List", func(t *testing.T) {
+		have, err := store.List(ctx, SecurityEventsListOptions{UserID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 2 || have[0].ID != events[1].ID || have[1].ID != events[0].ID {
+			t.Fatalf("unexpected events: %+v", have)
+		}
+
+		have, err = store.List(ctx, SecurityEventsListOptions{Names: []string{string(SecurityEventNameAccessTokenDeleted)}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].ID != events[2].ID {
+			t.Fatalf("unexpected events: %+v", have)
+		}
+
+		count, err := store.Count(ctx, SecurityEventsListOptions{Cursor: events[2].ID, Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 3 {
+			t.Fatalf("have count %d, want 3", count)
+		}
+	})
+
+	t.Run("retention deletes the oldest events", func(t *testing.T) {
+		if _, err := db.ExecContext(ctx, "DELETE FROM security_event_logs WHERE id = $1", events[0].ID); err != nil {
+			t.Fatal(err)
+		}
+		res, err := store.VerifyChain(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&SecurityEventLogVerification{Verified: 2}, res); diff != "" {
+			t.Fatalf("unexpected verification (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("tampering is detected", func(t *testing.T) {
+		if _, err := db.ExecContext(ctx, "UPDATE security_event_logs SET user_id = 3 WHERE id = $1", events[1].ID); err != nil {
+			t.Fatal(err)
+		}
+		res, err := store.VerifyChain(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&SecurityEventLogVerification{FirstInvalidID: events[1].ID}, res); diff != "" {
+			t.Fatalf("unexpected verification (-want +have):\n%s", diff)
+		}
+	})
+}
+
+func TestSecurityEvent_ComputeHash(t *testing.T) {
+	ts := time.Date(2021, 10, 1, 12, 0, 0, 123456789, time.UTC)
+	a := &SecurityEvent{Name: "test_event", UserID: 1, Source: "WEB", Timestamp: ts, Argument: json.RawMessage(`{"b": 1, "a": [1, 2]}`)}
+	b := &SecurityEvent{Name: "test_event", UserID: 1, Source: "WEB", Timestamp: ts.Truncate(time.Microsecond), Argument: json.RawMessage(`{"a":[1,2],"b":1}`)}
+
+	hashA, err := a.computeHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashB, err := b.computeHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hashA != hashB {
+		t.Fatalf("hashes of equivalent events differ: %q != %q", hashA, hashB)
+	}
+
+	b.PreviousHash = "abc"
+	if hashC, _ := b.computeHash(); hashC == hashB {
+		t.Fatal("hash doesn't cover the previous hash")
+	}
+}
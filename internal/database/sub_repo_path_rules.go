@@ -0,0 +1,281 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// SubRepoPathRulesSupportedCodeHostKinds are the kinds of code hosts whose
+// repositories can have path rules. Other code hosts either provide
+// sub-repository permissions themselves or don't have repositories large
+// enough to need them.
+var SubRepoPathRulesSupportedCodeHostKinds = []string{extsvc.KindGitHub, extsvc.KindGitLab}
+
+// SubRepoPathRuleAction is the effect a SubRepoPathRule has on the paths it
+// matches.
+type SubRepoPathRuleAction string
+
+const (
+	SubRepoPathRuleAllow SubRepoPathRuleAction = "allow"
+	SubRepoPathRuleDeny  SubRepoPathRuleAction = "deny"
+)
+
+// SubRepoPathRule is an admin-managed rule that allows or denies access to the
+// paths of a repository matching a glob pattern. A rule applies to a single
+// user if UserID is set, to the members of an organization if OrgID is set, and
+// to all users otherwise.
+type SubRepoPathRule struct {
+	ID        int32
+	RepoID    api.RepoID
+	UserID    int32
+	OrgID     int32
+	Pattern   string // relative to the repository root, e.g. "internal/secret/**"
+	Action    SubRepoPathRuleAction
+	CreatedAt time.Time
+}
+
+// Validate returns an error if the rule can't be stored.
+func (r *SubRepoPathRule) Validate() error {
+	if r.UserID != 0 && r.OrgID != 0 {
+		return errors.New("a path rule can apply to either a user or an organization, not both")
+	}
+	if r.Action != SubRepoPathRuleAllow && r.Action != SubRepoPathRuleDeny {
+		return errors.Errorf("invalid path rule action %q", r.Action)
+	}
+	if strings.TrimPrefix(r.Pattern, "/") == "" {
+		return errors.New("path rule pattern must not be empty")
+	}
+	if _, err := glob.Compile(r.Pattern, '/'); err != nil {
+		return errors.Wrapf(err, "invalid path rule pattern %q", r.Pattern)
+	}
+	return nil
+}
+
+// SubRepoPathRuleStore stores the path rules of repositories, which are a
+// source of sub-repository permissions for code hosts that don't provide them.
+//
+// The rules of a repository are ordered, and later rules take precedence over
+// earlier ones. Once a repository has any rules, users can only access the
+// paths that a rule applying to them allows, so the first rule is usually one
+// that allows "**" to all users.
+type SubRepoPathRuleStore interface {
+	basestore.ShareableStore
+
+	// ListByRepo returns the rules of the repository in order.
+	ListByRepo(ctx context.Context, repoID api.RepoID) ([]*SubRepoPathRule, error)
+
+	// SetForRepo replaces the rules of the repository. The RepoID and ID of
+	// the given rules are ignored.
+	SetForRepo(ctx context.Context, repoID api.RepoID, rules []*SubRepoPathRule) error
+
+	// GetByUser returns the sub-repository permissions that the rules grant
+	// the user, keyed by repository. Every repository that has rules has an
+	// entry, even if none of its rules apply to the user.
+	GetByUser(ctx context.Context, userID int32) (map[api.RepoName]authz.SubRepoPermissions, error)
+}
+
+type subRepoPathRuleStore struct {
+	*basestore.Store
+}
+
+// SubRepoPathRules instantiates and returns a new SubRepoPathRuleStore with prepared statements.
+func SubRepoPathRules(db dbutil.DB) SubRepoPathRuleStore {
+	return &subRepoPathRuleStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// SubRepoPathRulesWith instantiates and returns a new SubRepoPathRuleStore using the other store handle.
+func SubRepoPathRulesWith(other basestore.ShareableStore) SubRepoPathRuleStore {
+	return &subRepoPathRuleStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *subRepoPathRuleStore) ListByRepo(ctx context.Context, repoID api.RepoID) ([]*SubRepoPathRule, error) {
+	q := sqlf.Sprintf(`
+-- source: internal/database/sub_repo_path_rules.go:ListByRepo
+SELECT id, repo_id, user_id, org_id, pattern, action, created_at
+FROM sub_repo_path_rules
+WHERE repo_id = %s
+ORDER BY position
+`, repoID)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing sub-repo path rules")
+	}
+	defer rows.Close()
+
+	var rules []*SubRepoPathRule
+	for rows.Next() {
+		var (
+			r             SubRepoPathRule
+			userID, orgID sql.NullInt32
+		)
+		if err := rows.Scan(&r.ID, &r.RepoID, &userID, &orgID, &r.Pattern, &r.Action, &r.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "scanning row")
+		}
+		r.UserID = userID.Int32
+		r.OrgID = orgID.Int32
+		rules = append(rules, &r)
+	}
+	return rules, rows.Err()
+}
+
+func (s *subRepoPathRuleStore) SetForRepo(ctx context.Context, repoID api.RepoID, rules []*SubRepoPathRule) (err error) {
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf("DELETE FROM sub_repo_path_rules WHERE repo_id = %s", repoID)); err != nil {
+		return errors.Wrap(err, "deleting sub-repo path rules")
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	values := make([]*sqlf.Query, len(rules))
+	for i, r := range rules {
+		values[i] = sqlf.Sprintf("(%s, %s, %s, %s, %s, %s)",
+			repoID, i, nullInt32Column(r.UserID), nullInt32Column(r.OrgID), r.Pattern, r.Action)
+	}
+	q := sqlf.Sprintf(`
+-- source: internal/database/sub_repo_path_rules.go:SetForRepo
+INSERT INTO sub_repo_path_rules (repo_id, position, user_id, org_id, pattern, action)
+VALUES %s
+`, sqlf.Join(values, ",\n"))
+	return errors.Wrap(tx.Exec(ctx, q), "inserting sub-repo path rules")
+}
+
+func (s *subRepoPathRuleStore) GetByUser(ctx context.Context, userID int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
+	q := sqlf.Sprintf(`
+-- source: internal/database/sub_repo_path_rules.go:GetByUser
+SELECT
+	r.name,
+	rule.pattern,
+	rule.action,
+	(rule.user_id IS NULL AND rule.org_id IS NULL)
+		OR rule.user_id = %s
+		OR rule.org_id IN (SELECT org_id FROM org_members WHERE user_id = %s) AS applies
+FROM sub_repo_path_rules rule
+JOIN repo r ON r.id = rule.repo_id
+WHERE r.deleted_at IS NULL
+ORDER BY rule.repo_id, rule.position
+`, userID, userID)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting sub-repo path rules by user")
+	}
+	defer rows.Close()
+
+	applicable := make(map[api.RepoName][]*SubRepoPathRule)
+	for rows.Next() {
+		var (
+			repoName api.RepoName
+			rule     SubRepoPathRule
+			applies  bool
+		)
+		if err := rows.Scan(&repoName, &rule.Pattern, &rule.Action, &applies); err != nil {
+			return nil, errors.Wrap(err, "scanning row")
+		}
+		if _, ok := applicable[repoName]; !ok {
+			applicable[repoName] = []*SubRepoPathRule{}
+		}
+		if applies {
+			applicable[repoName] = append(applicable[repoName], &rule)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make(map[api.RepoName]authz.SubRepoPermissions, len(applicable))
+	for repoName, rules := range applicable {
+		perms, err := subRepoPermsFromPathRules(repoName, rules)
+		if err != nil {
+			return nil, errors.Wrapf(err, "evaluating path rules of %q", repoName)
+		}
+		result[repoName] = perms
+	}
+	return result, nil
+}
+
+// subRepoPermsFromPathRules converts the ordered path rules of a repository
+// that apply to a user to the include and exclude patterns understood by the
+// authz.SubRepoPermissionChecker, where exclusions always win.
+//
+// Like the Perforce protections table, a later rule overrides earlier rules it
+// conflicts with: an allow rule drops the earlier deny rules it covers
+// completely. An allow rule that covers only part of an earlier deny rule, such
+// as allowing "secret/public/**" after denying "secret/**", can't override it,
+// so the paths stay denied.
+func subRepoPermsFromPathRules(repoName api.RepoName, rules []*SubRepoPathRule) (authz.SubRepoPermissions, error) {
+	perms := authz.SubRepoPermissions{
+		PathIncludes: []string{},
+		PathExcludes: []string{},
+	}
+	for _, rule := range rules {
+		// The checker matches patterns against the repository name joined with
+		// the path.
+		pattern := string(repoName) + "/" + strings.TrimPrefix(rule.Pattern, "/")
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return perms, errors.Wrapf(err, "compiling pattern %q", rule.Pattern)
+		}
+
+		switch rule.Action {
+		case SubRepoPathRuleAllow:
+			perms.PathExcludes = dropCoveredPatterns(perms.PathExcludes, pattern, g)
+			perms.PathIncludes = append(perms.PathIncludes, pattern)
+		case SubRepoPathRuleDeny:
+			perms.PathIncludes = dropCoveredPatterns(perms.PathIncludes, pattern, g)
+			perms.PathExcludes = append(perms.PathExcludes, pattern)
+		}
+	}
+	return perms, nil
+}
+
+// dropCoveredPatterns returns the patterns that don't only match paths which
+// are also matched by the pattern p (compiled to g).
+func dropCoveredPatterns(patterns []string, p string, g glob.Glob) []string {
+	kept := patterns[:0]
+	for _, other := range patterns {
+		if !patternCovers(p, g, other) {
+			kept = append(kept, other)
+		}
+	}
+	return kept
+}
+
+// patternCovers reports whether every path matched by the pattern other is
+// also matched by the pattern p (compiled to g). It errs on the side of
+// returning false, which keeps deny rules in place.
+func patternCovers(p string, g glob.Glob, other string) bool {
+	if p == other {
+		return true
+	}
+	// A pattern ending in "/**" covers all patterns below its directory.
+	if strings.HasSuffix(p, "/**") && !strings.ContainsAny(strings.TrimSuffix(p, "**"), globMetaChars) {
+		return strings.HasPrefix(other, strings.TrimSuffix(p, "**"))
+	}
+	// Otherwise only a literal path can be checked with certainty.
+	return !strings.ContainsAny(other, globMetaChars) && g.Match(other)
+}
+
+const globMetaChars = `*?[]{}\`
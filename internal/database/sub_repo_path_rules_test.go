@@ -0,0 +1,184 @@
+package database
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/gobwas/glob"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSubRepoPermsFromPathRules(t *testing.T) {
+	allow := func(pattern string) *SubRepoPathRule {
+		return &SubRepoPathRule{Pattern: pattern, Action: SubRepoPathRuleAllow}
+	}
+	deny := func(pattern string) *SubRepoPathRule {
+		return &SubRepoPathRule{Pattern: pattern, Action: SubRepoPathRuleDeny}
+	}
+
+	// canRead evaluates the permissions the same way the
+	// authz.SubRepoPermissionChecker does.
+	canRead := func(t *testing.T, perms authz.SubRepoPermissions, p string) bool {
+		t.Helper()
+		toMatch := path.Join("github.com/a/b", p)
+		for _, pattern := range perms.PathExcludes {
+			if glob.MustCompile(pattern, '/').Match(toMatch) {
+				return false
+			}
+		}
+		for _, pattern := range perms.PathIncludes {
+			if glob.MustCompile(pattern, '/').Match(toMatch) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, tc := range []struct {
+		name     string
+		rules    []*SubRepoPathRule
+		readable []string
+		hidden   []string
+	}{
+		{
+			name:   "no rules",
+			hidden: []string{"README.md", "secret/key"},
+		},
+		{
+			name:     "allow all",
+			rules:    []*SubRepoPathRule{allow("**")},
+			readable: []string{"README.md", "secret/key"},
+		},
+		{
+			name:     "deny directory",
+			rules:    []*SubRepoPathRule{allow("**"), deny("secret/**")},
+			readable: []string{"README.md", "secrets.md"},
+			hidden:   []string{"secret/key", "secret/nested/key"},
+		},
+		{
+			name:     "later allow overrides deny",
+			rules:    []*SubRepoPathRule{allow("**"), deny("secret/**"), allow("/secret/**")},
+			readable: []string{"README.md", "secret/key"},
+		},
+		{
+			name:     "narrower allow can't override deny",
+			rules:    []*SubRepoPathRule{allow("**"), deny("secret/**"), allow("secret/public/**")},
+			readable: []string{"README.md"},
+			hidden:   []string{"secret/key", "secret/public/key"},
+		},
+		{
+			name:     "allow overrides denied file",
+			rules:    []*SubRepoPathRule{allow("**"), deny("secret/key"), allow("secret/*")},
+			readable: []string{"secret/key", "secret/other"},
+		},
+		{
+			name:     "wildcard allow keeps wildcard deny",
+			rules:    []*SubRepoPathRule{allow("**"), deny("a*"), allow("a?")},
+			readable: []string{"README.md"},
+			hidden:   []string{"ab", "abc"},
+		},
+		{
+			name:   "later deny overrides allow",
+			rules:  []*SubRepoPathRule{allow("docs/**"), deny("**")},
+			hidden: []string{"docs/index.md", "README.md"},
+		},
+		{
+			name:     "allow list",
+			rules:    []*SubRepoPathRule{allow("docs/**"), allow("*.md")},
+			readable: []string{"docs/index.md", "README.md"},
+			hidden:   []string{"main.go", "docs"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			perms, err := subRepoPermsFromPathRules("github.com/a/b", tc.rules)
+			require.NoError(t, err)
+			for _, p := range tc.readable {
+				require.True(t, canRead(t, perms, p), "want %q to be readable with %+v", p, perms)
+			}
+			for _, p := range tc.hidden {
+				require.False(t, canRead(t, perms, p), "want %q to be hidden with %+v", p, perms)
+			}
+		})
+	}
+}
+
+func TestSubRepoPathRuleValidate(t *testing.T) {
+	for _, r := range []*SubRepoPathRule{
+		{Pattern: "**", Action: "maybe"},
+		{Pattern: "", Action: SubRepoPathRuleAllow},
+		{Pattern: "/", Action: SubRepoPathRuleAllow},
+		{Pattern: "[", Action: SubRepoPathRuleDeny},
+		{Pattern: "**", Action: SubRepoPathRuleDeny, UserID: 1, OrgID: 1},
+	} {
+		require.Error(t, r.Validate(), "%+v", r)
+	}
+	require.NoError(t, (&SubRepoPathRule{Pattern: "src/**/*.go", Action: SubRepoPathRuleDeny, OrgID: 1}).Validate())
+}
+
+func TestSubRepoPathRules(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := NewDB(dbtest.NewDB(t))
+	ctx := context.Background()
+
+	alice, err := db.Users().Create(ctx, NewUser{Username: "alice"})
+	require.NoError(t, err)
+	bob, err := db.Users().Create(ctx, NewUser{Username: "bob"})
+	require.NoError(t, err)
+	org, err := db.Orgs().Create(ctx, "security", nil)
+	require.NoError(t, err)
+	_, err = db.OrgMembers().Create(ctx, org.ID, bob.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Repos().Create(ctx, &types.Repo{Name: "github.com/a/mono"}, &types.Repo{Name: "github.com/a/other"}))
+	mono, err := db.Repos().GetByName(ctx, "github.com/a/mono")
+	require.NoError(t, err)
+
+	rules := []*SubRepoPathRule{
+		{Pattern: "**", Action: SubRepoPathRuleAllow},
+		{Pattern: "secret/**", Action: SubRepoPathRuleDeny},
+		{Pattern: "secret/**", Action: SubRepoPathRuleAllow, OrgID: org.ID},
+	}
+	require.NoError(t, db.SubRepoPathRules().SetForRepo(ctx, mono.ID, rules))
+
+	got, err := db.SubRepoPathRules().ListByRepo(ctx, mono.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	require.Equal(t, org.ID, got[2].OrgID)
+	require.Equal(t, SubRepoPathRuleDeny, got[1].Action)
+
+	perms, err := db.SubRepoPerms().GetByUser(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[api.RepoName]authz.SubRepoPermissions{
+		"github.com/a/mono": {
+			PathIncludes: []string{"github.com/a/mono/**"},
+			PathExcludes: []string{"github.com/a/mono/secret/**"},
+		},
+	}, perms)
+
+	perms, err = db.SubRepoPerms().GetByUser(ctx, bob.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[api.RepoName]authz.SubRepoPermissions{
+		"github.com/a/mono": {
+			PathIncludes: []string{"github.com/a/mono/**", "github.com/a/mono/secret/**"},
+			PathExcludes: []string{},
+		},
+	}, perms)
+
+	// Replacing the rules removes the old ones.
+	require.NoError(t, db.SubRepoPathRules().SetForRepo(ctx, mono.ID, nil))
+	perms, err = db.SubRepoPerms().GetByUser(ctx, bob.ID)
+	require.NoError(t, err)
+	require.Empty(t, perms)
+
+	// Invalid rules are rejected.
+	require.Error(t, db.SubRepoPathRules().SetForRepo(ctx, mono.ID, []*SubRepoPathRule{{Pattern: "[", Action: SubRepoPathRuleDeny}}))
+}
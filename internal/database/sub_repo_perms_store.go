@@ -146,7 +146,9 @@ WHERE user_id = %s
 	return perms, nil
 }
 
-// GetByUser fetches all sub repo perms for a user keyed by repo.
+// GetByUser fetches all sub repo perms for a user keyed by repo. It includes the
+// permissions granted by the path rules of repositories whose code host doesn't
+// provide sub repo permissions.
 func (s *subRepoPermsStore) GetByUser(ctx context.Context, userID int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
 	q := sqlf.Sprintf(`
 SELECT r.name, path_includes, path_excludes
@@ -175,5 +177,17 @@ WHERE user_id = %s
 		return nil, errors.Wrap(err, "closing rows")
 	}
 
+	ruled, err := SubRepoPathRulesWith(s).GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for repoName, perms := range ruled {
+		// Path rules can only be set for repositories of code hosts that don't
+		// sync sub repo permissions, so synced permissions are never overridden.
+		if _, ok := result[repoName]; !ok {
+			result[repoName] = perms
+		}
+	}
+
 	return result, nil
 }
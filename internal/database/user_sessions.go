@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// UserSession describes a signed-in session of a user. The session data itself
+// lives in the session store; this is only an index of it, used to list and
+// revoke sessions.
+type UserSession struct {
+	ID         int64
+	UserID     int32
+	Key        string // the SHA-256 hash of the session ID, never shown to users
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+}
+
+// ErrUserSessionNotFound occurs when a database operation expects a specific
+// user session to exist but it does not exist.
+var ErrUserSessionNotFound = errors.New("user session not found")
+
+// UserSessionStore implements persistence for the index of user sessions.
+type UserSessionStore interface {
+	basestore.ShareableStore
+
+	// Upsert adds the session to the index, or updates the session with the
+	// same key. A session store reuses the session ID when a different user
+	// signs in with the same cookie, in which case the session is taken over by
+	// that user.
+	Upsert(ctx context.Context, s *UserSession) error
+
+	// GetByKey returns the unexpired session with the given key. It returns
+	// ErrUserSessionNotFound if the session doesn't exist, was revoked or has
+	// expired.
+	GetByKey(ctx context.Context, key string) (*UserSession, error)
+
+	// GetByID returns the unexpired session with the given ID.
+	GetByID(ctx context.Context, id int64) (*UserSession, error)
+
+	// ListByUser returns the unexpired sessions of the user, most recently
+	// seen first.
+	ListByUser(ctx context.Context, userID int32) ([]*UserSession, error)
+
+	// Touch records that the session with the given key was used by the client
+	// with the IP address and user agent, and extends its expiry.
+	Touch(ctx context.Context, key, ipAddress, userAgent string, expiresAt time.Time) error
+
+	// Delete revokes the session with the given ID.
+	Delete(ctx context.Context, id int64) error
+
+	// DeleteByUser revokes all sessions of the user except the one with the
+	// key exceptKey (if not empty), including sessions that were not added to
+	// the index yet.
+	DeleteByUser(ctx context.Context, userID int32, exceptKey string) error
+}
+
+type userSessionStore struct {
+	*basestore.Store
+}
+
+// UserSessions instantiates and returns a new UserSessionStore with prepared statements.
+func UserSessions(db dbutil.DB) UserSessionStore {
+	return &userSessionStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// UserSessionsWith instantiates and returns a new UserSessionStore using the other store handle.
+func UserSessionsWith(other basestore.ShareableStore) UserSessionStore {
+	return &userSessionStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+const userSessionColumns = `id, user_id, key, ip_address, user_agent, created_at, last_seen_at, expires_at`
+
+func (s *userSessionStore) Upsert(ctx context.Context, us *UserSession) error {
+	q := sqlf.Sprintf(`
+-- source: internal/database/user_sessions.go:Upsert
+INSERT INTO user_sessions (user_id, key, ip_address, user_agent, expires_at)
+VALUES (%s, %s, %s, %s, %s)
+ON CONFLICT (key) DO UPDATE SET
+	user_id = excluded.user_id,
+	ip_address = excluded.ip_address,
+	user_agent = excluded.user_agent,
+	created_at = CASE WHEN user_sessions.user_id = excluded.user_id THEN user_sessions.created_at ELSE now() END,
+	last_seen_at = now(),
+	expires_at = excluded.expires_at
+RETURNING `+userSessionColumns,
+		us.UserID, us.Key, nullStringColumn(us.IPAddress), nullStringColumn(us.UserAgent), us.ExpiresAt,
+	)
+	got, err := scanUserSession(s.QueryRow(ctx, q))
+	if err != nil {
+		return err
+	}
+	*us = *got
+	return nil
+}
+
+func (s *userSessionStore) GetByKey(ctx context.Context, key string) (*UserSession, error) {
+	return s.get(ctx, sqlf.Sprintf("key = %s", key))
+}
+
+func (s *userSessionStore) GetByID(ctx context.Context, id int64) (*UserSession, error) {
+	return s.get(ctx, sqlf.Sprintf("id = %s", id))
+}
+
+func (s *userSessionStore) get(ctx context.Context, cond *sqlf.Query) (*UserSession, error) {
+	q := sqlf.Sprintf(`
+-- source: internal/database/user_sessions.go:get
+SELECT `+userSessionColumns+`
+FROM user_sessions
+WHERE %s AND expires_at > now()
+`, cond)
+	us, err := scanUserSession(s.QueryRow(ctx, q))
+	if err == sql.ErrNoRows {
+		return nil, ErrUserSessionNotFound
+	}
+	return us, err
+}
+
+func (s *userSessionStore) ListByUser(ctx context.Context, userID int32) ([]*UserSession, error) {
+	q := sqlf.Sprintf(`
+-- source: internal/database/user_sessions.go:ListByUser
+SELECT `+userSessionColumns+`
+FROM user_sessions
+WHERE user_id = %s AND expires_at > now()
+ORDER BY last_seen_at DESC, id DESC
+`, userID)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*UserSession
+	for rows.Next() {
+		us, err := scanUserSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, us)
+	}
+	return sessions, rows.Err()
+}
+
+func (s *userSessionStore) Touch(ctx context.Context, key, ipAddress, userAgent string, expiresAt time.Time) error {
+	q := sqlf.Sprintf(`
+-- source: internal/database/user_sessions.go:Touch
+UPDATE user_sessions
+SET last_seen_at = now(), ip_address = %s, user_agent = %s, expires_at = %s
+WHERE key = %s
+`, nullStringColumn(ipAddress), nullStringColumn(userAgent), expiresAt, key)
+	return s.Exec(ctx, q)
+}
+
+func (s *userSessionStore) Delete(ctx context.Context, id int64) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf("DELETE FROM user_sessions WHERE id = %s", id))
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrUserSessionNotFound
+	}
+	return nil
+}
+
+func (s *userSessionStore) DeleteByUser(ctx context.Context, userID int32, exceptKey string) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Sessions that were not added to the index yet are revoked by the
+	// invalidated_sessions_at check, which doesn't apply to indexed sessions.
+	if err := tx.Exec(ctx, sqlf.Sprintf("UPDATE users SET invalidated_sessions_at = now() WHERE id = %s", userID)); err != nil {
+		return err
+	}
+	return tx.Exec(ctx, sqlf.Sprintf("DELETE FROM user_sessions WHERE user_id = %s AND key <> %s", userID, exceptKey))
+}
+
+func scanUserSession(sc dbutil.Scanner) (*UserSession, error) {
+	var (
+		us                   UserSession
+		ipAddress, userAgent sql.NullString
+	)
+	if err := sc.Scan(
+		&us.ID,
+		&us.UserID,
+		&us.Key,
+		&ipAddress,
+		&userAgent,
+		&us.CreatedAt,
+		&us.LastSeenAt,
+		&us.ExpiresAt,
+	); err != nil {
+		return nil, err
+	}
+	us.IPAddress = ipAddress.String
+	us.UserAgent = userAgent.String
+	return &us, nil
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestUserSessions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := NewDB(dbtest.NewDB(t))
+	ctx := context.Background()
+
+	alice, err := db.Users().Create(ctx, NewUser{Username: "alice"})
+	require.NoError(t, err)
+	bob, err := db.Users().Create(ctx, NewUser{Username: "bob"})
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour)
+	upsert := func(userID int32, key string) *UserSession {
+		t.Helper()
+		s := &UserSession{UserID: userID, Key: key, IPAddress: "203.0.113.1", UserAgent: "Mozilla/5.0", ExpiresAt: expiresAt}
+		require.NoError(t, db.UserSessions().Upsert(ctx, s))
+		return s
+	}
+
+	s1 := upsert(alice.ID, "a1")
+	s2 := upsert(alice.ID, "a2")
+	upsert(bob.ID, "b1")
+
+	// Upserting an existing key updates the session.
+	again := upsert(alice.ID, "a1")
+	require.Equal(t, s1.ID, again.ID)
+
+	// Expired sessions are ignored.
+	expired := &UserSession{UserID: alice.ID, Key: "a3", ExpiresAt: time.Now().Add(-time.Minute)}
+	require.NoError(t, db.UserSessions().Upsert(ctx, expired))
+	_, err = db.UserSessions().GetByKey(ctx, "a3")
+	require.Equal(t, ErrUserSessionNotFound, err)
+
+	got, err := db.UserSessions().GetByKey(ctx, "a2")
+	require.NoError(t, err)
+	require.Equal(t, s2.ID, got.ID)
+	require.Equal(t, "203.0.113.1", got.IPAddress)
+
+	sessions, err := db.UserSessions().ListByUser(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	require.NoError(t, db.UserSessions().Touch(ctx, "a2", "198.51.100.1", "curl/7.79.1", expiresAt))
+	got, err = db.UserSessions().GetByID(ctx, s2.ID)
+	require.NoError(t, err)
+	require.Equal(t, "198.51.100.1", got.IPAddress)
+	require.Equal(t, "curl/7.79.1", got.UserAgent)
+
+	require.NoError(t, db.UserSessions().Delete(ctx, s2.ID))
+	require.Equal(t, ErrUserSessionNotFound, db.UserSessions().Delete(ctx, s2.ID))
+
+	// Revoking all sessions except one keeps that session.
+	upsert(alice.ID, "a4")
+	require.NoError(t, db.UserSessions().DeleteByUser(ctx, alice.ID, "a4"))
+	sessions, err = db.UserSessions().ListByUser(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, "a4", sessions[0].Key)
+
+	// Invalidating the sessions of a user removes them from the index.
+	require.NoError(t, db.Users().InvalidateSessionsByID(ctx, alice.ID))
+	sessions, err = db.UserSessions().ListByUser(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 0)
+
+	// Other users' sessions are unaffected.
+	sessions, err = db.UserSessions().ListByUser(ctx, bob.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+}
@@ -75,6 +75,7 @@ type UserStore interface {
 	List(context.Context, *UsersListOptions) (_ []*types.User, err error)
 	ListDates(context.Context) ([]types.UserDates, error)
 	RandomizePasswordAndClearPasswordResetRateLimit(context.Context, int32) error
+	Recover(context.Context, int32) error
 	RenewPasswordResetCode(context.Context, int32) (string, error)
 	SetIsSiteAdmin(ctx context.Context, id int32, isSiteAdmin bool) error
 	SetPassword(ctx context.Context, id int32, resetCode, newPassword string) (bool, error)
@@ -512,7 +513,8 @@ func (u *userStore) Delete(ctx context.Context, id int32) (err error) {
 	}
 	defer func() { err = tx.Done(err) }()
 
-	res, err := tx.ExecResult(ctx, sqlf.Sprintf("UPDATE users SET deleted_at=now() WHERE id=%s AND deleted_at IS NULL", id))
+	// Sessions are invalidated so that they stay invalid if the user is recovered.
+	res, err := tx.ExecResult(ctx, sqlf.Sprintf("UPDATE users SET deleted_at=now(), invalidated_sessions_at=now() WHERE id=%s AND deleted_at IS NULL", id))
 	if err != nil {
 		return err
 	}
@@ -534,6 +536,9 @@ func (u *userStore) Delete(ctx context.Context, id int32) (err error) {
 	if err := tx.Exec(ctx, sqlf.Sprintf("DELETE FROM user_emails WHERE user_id=%s", id)); err != nil {
 		return err
 	}
+	if err := tx.Exec(ctx, sqlf.Sprintf("DELETE FROM user_sessions WHERE user_id=%s", id)); err != nil {
+		return err
+	}
 	if err := tx.Exec(ctx, sqlf.Sprintf("UPDATE user_external_accounts SET deleted_at=now() WHERE user_id=%s AND deleted_at IS NULL", id)); err != nil {
 		return err
 	}
@@ -549,6 +554,40 @@ func (u *userStore) Delete(ctx context.Context, id int32) (err error) {
 	return nil
 }
 
+// Recover restores a soft-deleted user: it reserves the username again and
+// restores the external accounts that were deleted along with the user. Email
+// addresses and access tokens are not restored.
+func (u *userStore) Recover(ctx context.Context, id int32) (err error) {
+	tx, err := u.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	var (
+		username  string
+		deletedAt time.Time
+	)
+	err = tx.QueryRow(ctx, sqlf.Sprintf("SELECT username, deleted_at FROM users WHERE id=%s AND deleted_at IS NOT NULL FOR UPDATE", id)).Scan(&username, &deletedAt)
+	if err == sql.ErrNoRows {
+		return userNotFoundErr{args: []interface{}{id}}
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Exec(ctx, sqlf.Sprintf("UPDATE users SET deleted_at=NULL, updated_at=now() WHERE id=%s", id)); err != nil {
+		return err
+	}
+	// Reserve the username again, unless it has been taken in the meantime.
+	if err := tx.Exec(ctx, sqlf.Sprintf("INSERT INTO names(name, user_id) VALUES(%s, %s)", username, id)); err != nil {
+		return errCannotCreateUser{errorCodeUsernameExists}
+	}
+	// External accounts are soft-deleted in the same transaction as the user,
+	// so they share its deletion timestamp.
+	return tx.Exec(ctx, sqlf.Sprintf("UPDATE user_external_accounts SET deleted_at=NULL WHERE user_id=%s AND deleted_at=%s", id, deletedAt))
+}
+
 // HardDelete removes the user and all resources associated with this user.
 func (u *userStore) HardDelete(ctx context.Context, id int32) (err error) {
 	// Wrap in transaction because we delete from multiple tables.
@@ -742,7 +781,7 @@ func (u *userStore) InvalidateSessionsByID(ctx context.Context, id int32) (err e
 	if nrows == 0 {
 		return userNotFoundErr{args: []interface{}{id}}
 	}
-	return nil
+	return tx.Exec(ctx, sqlf.Sprintf("DELETE FROM user_sessions WHERE user_id=%d", id))
 }
 
 func (u *userStore) Count(ctx context.Context, opt *UsersListOptions) (int, error) {
@@ -768,6 +807,9 @@ type UsersListOptions struct {
 
 	Tag string // only include users with this tag
 
+	// IncludeDeleted includes soft-deleted users, with their DeletedAt set.
+	IncludeDeleted bool
+
 	*LimitOffset
 }
 
@@ -822,7 +864,9 @@ ORDER BY id ASC
 
 func (*userStore) listSQL(opt UsersListOptions) (conds []*sqlf.Query) {
 	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
-	conds = append(conds, sqlf.Sprintf("deleted_at IS NULL"))
+	if !opt.IncludeDeleted {
+		conds = append(conds, sqlf.Sprintf("deleted_at IS NULL"))
+	}
 	if opt.Query != "" {
 		query := "%" + opt.Query + "%"
 		conds = append(conds, sqlf.Sprintf("(username ILIKE %s OR display_name ILIKE %s)", query, query))
@@ -858,7 +902,7 @@ func (u *userStore) getOneBySQL(ctx context.Context, q *sqlf.Query) (*types.User
 
 // getBySQL returns users matching the SQL query, if any exist.
 func (u *userStore) getBySQL(ctx context.Context, query *sqlf.Query) ([]*types.User, error) {
-	q := sqlf.Sprintf("SELECT u.id, u.username, u.display_name, u.avatar_url, u.created_at, u.updated_at, u.site_admin, u.passwd IS NOT NULL, u.tags, u.invalidated_sessions_at, u.tos_accepted FROM users u %s", query)
+	q := sqlf.Sprintf("SELECT u.id, u.username, u.display_name, u.avatar_url, u.created_at, u.updated_at, u.site_admin, u.passwd IS NOT NULL, u.tags, u.invalidated_sessions_at, u.tos_accepted, u.deleted_at FROM users u %s", query)
 	rows, err := u.Query(ctx, q)
 	if err != nil {
 		return nil, err
@@ -869,7 +913,7 @@ func (u *userStore) getBySQL(ctx context.Context, query *sqlf.Query) ([]*types.U
 	for rows.Next() {
 		var u types.User
 		var displayName, avatarURL sql.NullString
-		err := rows.Scan(&u.ID, &u.Username, &displayName, &avatarURL, &u.CreatedAt, &u.UpdatedAt, &u.SiteAdmin, &u.BuiltinAuth, pq.Array(&u.Tags), &u.InvalidatedSessionsAt, &u.TosAccepted)
+		err := rows.Scan(&u.ID, &u.Username, &displayName, &avatarURL, &u.CreatedAt, &u.UpdatedAt, &u.SiteAdmin, &u.BuiltinAuth, pq.Array(&u.Tags), &u.InvalidatedSessionsAt, &u.TosAccepted, &u.DeletedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -726,6 +726,62 @@ func TestUsers_Delete(t *testing.T) {
 	}
 }
 
+func TestUsers_Recover(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+	ctx = actor.WithActor(ctx, &actor.Actor{UID: 1, Internal: true})
+
+	user, err := Users(db).Create(ctx, NewUser{Username: "u"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := extsvc.AccountSpec{ServiceType: "xa", ServiceID: "xb", ClientID: "xc", AccountID: "xd"}
+	if err := ExternalAccounts(db).AssociateUserAndSave(ctx, user.ID, spec, extsvc.AccountData{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Active users cannot be recovered.
+	if err := Users(db).Recover(ctx, user.ID); !errcode.IsNotFound(err) {
+		t.Fatalf("got err %v, want NotFound", err)
+	}
+
+	if err := Users(db).Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := Users(db).List(ctx, &UsersListOptions{UserIDs: []int32{user.ID}, IncludeDeleted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].DeletedAt == nil {
+		t.Fatalf("expected the soft-deleted user to be listed with DeletedAt set, got %+v", users)
+	}
+
+	if err := Users(db).Recover(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	recovered, err := Users(db).GetByUsername(ctx, "u")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recovered.ID != user.ID || recovered.DeletedAt != nil {
+		t.Fatalf("unexpected recovered user: %+v", recovered)
+	}
+
+	accounts, err := ExternalAccounts(db).List(ctx, ExternalAccountsListOptions{UserID: user.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 1 {
+		t.Fatalf("got %d external accounts, want 1", len(accounts))
+	}
+}
+
 func TestUsers_HasTag(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -1,6 +1,7 @@
 package bitbucketcloud
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -16,6 +17,8 @@ import (
 	"github.com/opentracing-contrib/go-stdlib/nethttp"
 	"golang.org/x/time/rate"
 
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
@@ -52,7 +55,7 @@ type Client struct {
 
 	// RateLimit is the self-imposed rate limiter (since Bitbucket does not have a concept
 	// of rate limiting in HTTP response headers).
-	RateLimit *rate.Limiter
+	RateLimit ratelimit.Limiter
 }
 
 // NewClient creates a new Bitbucket Cloud API client with given apiURL. If a nil httpClient
@@ -86,6 +89,27 @@ func NewClient(apiURL *url.URL, httpClient httpcli.Doer) *Client {
 	}
 }
 
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTPClient, and RateLimiter as the current Client, except authenticated with
+// the username and app password of the given authenticator, which must be an
+// *auth.BasicAuth or an *auth.BasicAuthWithSSH.
+func (c *Client) WithAuthenticator(a auth.Authenticator) (*Client, error) {
+	var ba *auth.BasicAuth
+	switch a := a.(type) {
+	case *auth.BasicAuth:
+		ba = a
+	case *auth.BasicAuthWithSSH:
+		ba = &a.BasicAuth
+	default:
+		return nil, errors.Errorf("authenticator type unsupported for Bitbucket Cloud clients: %T", a)
+	}
+
+	cc := *c
+	cc.Username = ba.Username
+	cc.AppPassword = ba.Password
+	return &cc, nil
+}
+
 // Repos returns a list of repositories that are fetched and populated based on given account
 // name and pagination criteria. If the account requested is a team, results will be filtered
 // down to the ones that the app password's user has access to.
@@ -144,6 +168,20 @@ func (c *Client) reqPage(ctx context.Context, url string, results interface{}) (
 	return &next, nil
 }
 
+// newJSONRequest returns a request to the given path of the API with the JSON
+// encoding of body as its body, if body isn't nil.
+func newJSONRequest(method, path string, body interface{}) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshalling request body")
+		}
+		r = bytes.NewReader(bs)
+	}
+	return http.NewRequest(method, path, r)
+}
+
 func (c *Client) do(ctx context.Context, req *http.Request, result interface{}) error {
 	req.URL = c.URL.ResolveReference(req.URL)
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
@@ -234,6 +272,11 @@ type Repo struct {
 	Parent      *Repo  `json:"parent"`
 	IsPrivate   bool   `json:"is_private"`
 	Links       Links  `json:"links"`
+	Language    string `json:"language,omitempty"`
+
+	// UpdatedOn is the last time the repository was updated, which includes
+	// pushes.
+	UpdatedOn *time.Time `json:"updated_on,omitempty"`
 }
 
 type Links struct {
@@ -274,6 +317,30 @@ func (e *httpError) Unauthorized() bool {
 	return e.StatusCode == http.StatusUnauthorized
 }
 
+func (e *httpError) Forbidden() bool {
+	return e.StatusCode == http.StatusForbidden
+}
+
 func (e *httpError) NotFound() bool {
 	return e.StatusCode == http.StatusNotFound
 }
+
+// IsNotFound reports whether err is a Bitbucket Cloud API not found error.
+func IsNotFound(err error) bool {
+	return errcode.IsNotFound(err)
+}
+
+// IsUnauthorized reports whether err is a Bitbucket Cloud API 401 error.
+func IsUnauthorized(err error) bool {
+	return errcode.IsUnauthorized(err)
+}
+
+// HTTPErrorCode returns err's HTTP status code, if it is a Bitbucket Cloud API
+// HTTP error. Otherwise it returns 0.
+func HTTPErrorCode(err error) int {
+	var e *httpError
+	if errors.As(err, &e) {
+		return e.StatusCode
+	}
+	return 0
+}
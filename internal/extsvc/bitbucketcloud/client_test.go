@@ -36,6 +36,7 @@ func TestClient_Repos(t *testing.T) {
 				},
 				HTML: Link{"https://bitbucket.org/sglocal/mux"},
 			},
+			UpdatedOn: mustParseTime(t, "2019-07-10T21:19:51.119139+00:00"),
 		},
 		"python-langserver": {
 			Slug:      "python-langserver",
@@ -51,6 +52,7 @@ func TestClient_Repos(t *testing.T) {
 				},
 				HTML: Link{"https://bitbucket.org/sglocal/python-langserver"},
 			},
+			UpdatedOn: mustParseTime(t, "2019-07-10T22:39:58.395470+00:00"),
 		},
 	}
 
@@ -119,3 +121,12 @@ func TestClient_Repos(t *testing.T) {
 		})
 	}
 }
+
+func mustParseTime(t testing.TB, s string) *time.Time {
+	t.Helper()
+	ts, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ts
+}
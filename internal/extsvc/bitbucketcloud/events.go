@@ -0,0 +1,126 @@
+package bitbucketcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	eventTypeHeader = "X-Event-Key"
+)
+
+func WebhookEventType(r *http.Request) string {
+	return r.Header.Get(eventTypeHeader)
+}
+
+func ParseWebhookEvent(eventType string, payload []byte) (e interface{}, err error) {
+	switch eventType {
+	case "pullrequest:approved":
+		e = &PullRequestApprovedEvent{}
+	case "pullrequest:unapproved":
+		e = &PullRequestUnapprovedEvent{}
+	case "pullrequest:changes_request_created":
+		e = &PullRequestChangesRequestCreatedEvent{}
+	case "pullrequest:changes_request_removed":
+		e = &PullRequestChangesRequestRemovedEvent{}
+	case "pullrequest:fulfilled":
+		e = &PullRequestFulfilledEvent{}
+	case "pullrequest:rejected":
+		e = &PullRequestRejectedEvent{}
+	case "repo:commit_status_created", "repo:commit_status_updated":
+		e = &RepoCommitStatusEvent{}
+	default:
+		return nil, errors.Errorf("unknown webhook event type: %q", eventType)
+	}
+	return e, json.Unmarshal(payload, e)
+}
+
+// PullRequestEvent is the part of the payload shared by all pull request
+// webhook events.
+type PullRequestEvent struct {
+	Actor       Account     `json:"actor"`
+	PullRequest PullRequest `json:"pullrequest"`
+	Repository  Repo        `json:"repository"`
+}
+
+// Review is the approval or change request carried by review webhook events.
+type Review struct {
+	Date time.Time `json:"date"`
+	User Account   `json:"user"`
+}
+
+type PullRequestApprovedEvent struct {
+	PullRequestEvent
+	Approval Review `json:"approval"`
+}
+
+type PullRequestUnapprovedEvent struct {
+	PullRequestEvent
+	Approval Review `json:"approval"`
+}
+
+type PullRequestChangesRequestCreatedEvent struct {
+	PullRequestEvent
+	ChangesRequest Review `json:"changes_request"`
+}
+
+type PullRequestChangesRequestRemovedEvent struct {
+	PullRequestEvent
+	ChangesRequest Review `json:"changes_request"`
+}
+
+// PullRequestFulfilledEvent is sent when a pull request is merged.
+type PullRequestFulfilledEvent struct {
+	PullRequestEvent
+}
+
+// PullRequestRejectedEvent is sent when a pull request is declined.
+type PullRequestRejectedEvent struct {
+	PullRequestEvent
+}
+
+// RepoCommitStatusEvent is sent when a build status of a commit is created or
+// updated.
+type RepoCommitStatusEvent struct {
+	Actor        Account     `json:"actor"`
+	Repository   Repo        `json:"repository"`
+	CommitStatus BuildStatus `json:"commit_status"`
+}
+
+// ParticipantAction is the review action of a pull request participant.
+type ParticipantAction string
+
+const (
+	ParticipantActionApproved              ParticipantAction = "approved"
+	ParticipantActionUnapproved            ParticipantAction = "unapproved"
+	ParticipantActionChangesRequestCreated ParticipantAction = "changes_request_created"
+	ParticipantActionChangesRequestRemoved ParticipantAction = "changes_request_removed"
+)
+
+// ParticipantStatusEvent is a review of a pull request by one of its
+// participants.
+type ParticipantStatusEvent struct {
+	Date   time.Time         `json:"date"`
+	User   Account           `json:"user"`
+	Action ParticipantAction `json:"action"`
+}
+
+func (e *ParticipantStatusEvent) Key() string {
+	return fmt.Sprintf("%s:%s:%d", e.Action, e.User.UUID, e.Date.UnixNano())
+}
+
+// PullRequestStateEvent is a change of the state of a pull request to merged
+// or declined.
+type PullRequestStateEvent struct {
+	Date  time.Time        `json:"date"`
+	Actor Account          `json:"actor"`
+	State PullRequestState `json:"state"`
+}
+
+func (e *PullRequestStateEvent) Key() string {
+	return fmt.Sprintf("%s:%s:%d", e.State, e.Actor.UUID, e.Date.UnixNano())
+}
@@ -0,0 +1,73 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// WorkspaceMembership is the membership of a user in a workspace.
+type WorkspaceMembership struct {
+	User *Account `json:"user"`
+}
+
+// RepoPermission is the effective permission of a user on a repository, which
+// is the highest level of permission granted to the user directly or through
+// groups.
+type RepoPermission struct {
+	// Permission is one of "read", "write" or "admin".
+	Permission string   `json:"permission"`
+	User       *Account `json:"user"`
+	Repository *Repo    `json:"repository"`
+}
+
+// WorkspaceMembers returns a page of the members of the given workspace.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-members-get
+func (c *Client) WorkspaceMembers(ctx context.Context, pageToken *PageToken, workspace string) ([]*WorkspaceMembership, *PageToken, error) {
+	var members []*WorkspaceMembership
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &members)
+	} else {
+		next, err = c.page(ctx, fmt.Sprintf("/2.0/workspaces/%s/members", workspace), nil, pageToken, &members)
+	}
+	return members, next, err
+}
+
+// RepoPermissions returns a page of the effective permissions of the users of
+// the given repository. The credentials of the client must belong to an
+// administrator of the workspace.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-repo-slug-get
+func (c *Client) RepoPermissions(ctx context.Context, pageToken *PageToken, workspace, repoSlug string) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &perms)
+	} else {
+		path := fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories/%s", workspace, repoSlug)
+		next, err = c.page(ctx, path, nil, pageToken, &perms)
+	}
+	return perms, next, err
+}
+
+// UserRepoPermissions returns a page of the effective permissions of the user
+// with the given UUID on the repositories of the given workspace. The
+// credentials of the client must belong to an administrator of the workspace.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-get
+func (c *Client) UserRepoPermissions(ctx context.Context, pageToken *PageToken, workspace, userUUID string) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &perms)
+	} else {
+		qry := url.Values{"q": []string{fmt.Sprintf("user.uuid=%q", userUUID)}}
+		next, err = c.page(ctx, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories", workspace), qry, pageToken, &perms)
+	}
+	return perms, next, err
+}
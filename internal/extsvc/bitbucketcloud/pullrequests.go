@@ -0,0 +1,328 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PullRequest is a Bitbucket Cloud pull request.
+type PullRequest struct {
+	ID                int                 `json:"id"`
+	Title             string              `json:"title"`
+	Description       string              `json:"description"`
+	State             PullRequestState    `json:"state"`
+	Author            Account             `json:"author"`
+	Source            PullRequestEndpoint `json:"source"`
+	Destination       PullRequestEndpoint `json:"destination"`
+	MergeCommit       *PullRequestCommit  `json:"merge_commit,omitempty"`
+	CommentCount      int64               `json:"comment_count"`
+	TaskCount         int64               `json:"task_count"`
+	CloseSourceBranch bool                `json:"close_source_branch"`
+	ClosedBy          *Account            `json:"closed_by,omitempty"`
+	Reason            string              `json:"reason,omitempty"`
+	CreatedOn         time.Time           `json:"created_on"`
+	UpdatedOn         time.Time           `json:"updated_on"`
+	Reviewers         []Account           `json:"reviewers"`
+	Participants      []Participant       `json:"participants"`
+	Links             Links               `json:"links"`
+
+	// Statuses are the build statuses of the pull request's commits. They are
+	// loaded separately from the pull request itself.
+	Statuses []*CommitStatus `json:"statuses,omitempty"`
+}
+
+// PullRequestState is the state of a pull request.
+type PullRequestState string
+
+const (
+	PullRequestStateMerged     PullRequestState = "MERGED"
+	PullRequestStateSuperseded PullRequestState = "SUPERSEDED"
+	PullRequestStateOpen       PullRequestState = "OPEN"
+	PullRequestStateDeclined   PullRequestState = "DECLINED"
+)
+
+// PullRequestEndpoint is the source or destination of a pull request.
+type PullRequestEndpoint struct {
+	Repo   PullRequestEndpointRepo   `json:"repository"`
+	Branch PullRequestEndpointBranch `json:"branch"`
+	Commit PullRequestCommit         `json:"commit"`
+}
+
+// PullRequestEndpointRepo is the repository of a pull request endpoint.
+type PullRequestEndpointRepo struct {
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	UUID     string `json:"uuid"`
+	Links    Links  `json:"links"`
+}
+
+// PullRequestEndpointBranch is the branch of a pull request endpoint.
+type PullRequestEndpointBranch struct {
+	Name string `json:"name"`
+}
+
+// PullRequestCommit is a commit referenced by a pull request. The API returns
+// abbreviated hashes for these commits.
+type PullRequestCommit struct {
+	Hash string `json:"hash"`
+}
+
+// Participant is a user participating in a pull request.
+type Participant struct {
+	User           Account          `json:"user"`
+	Role           ParticipantRole  `json:"role"`
+	Approved       bool             `json:"approved"`
+	State          ParticipantState `json:"state"`
+	ParticipatedOn time.Time        `json:"participated_on"`
+}
+
+// ParticipantRole is the role of a pull request participant.
+type ParticipantRole string
+
+const (
+	ParticipantRoleParticipant ParticipantRole = "PARTICIPANT"
+	ParticipantRoleReviewer    ParticipantRole = "REVIEWER"
+)
+
+// ParticipantState is the review state of a pull request participant. It is
+// empty if the participant hasn't reviewed the pull request.
+type ParticipantState string
+
+const (
+	ParticipantStateApproved         ParticipantState = "approved"
+	ParticipantStateChangesRequested ParticipantState = "changes_requested"
+)
+
+// BuildStatus is the status of a build of a commit.
+type BuildStatus struct {
+	UUID        string           `json:"uuid"`
+	Key         string           `json:"key"`
+	RefName     string           `json:"refname"`
+	URL         string           `json:"url"`
+	State       BuildStatusState `json:"state"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	CreatedOn   time.Time        `json:"created_on"`
+	UpdatedOn   time.Time        `json:"updated_on"`
+	Links       BuildStatusLinks `json:"links"`
+}
+
+// BuildStatusLinks are the links of a build status.
+type BuildStatusLinks struct {
+	Self   Link `json:"self"`
+	Commit Link `json:"commit"`
+}
+
+// CommitHash returns the full hash of the commit the build status belongs to,
+// which the API only returns as part of the commit link.
+func (s *BuildStatus) CommitHash() string {
+	href := s.Links.Commit.Href
+	return href[strings.LastIndex(href, "/")+1:]
+}
+
+// BuildStatusState is the state of a build.
+type BuildStatusState string
+
+const (
+	BuildStatusStateSuccessful BuildStatusState = "SUCCESSFUL"
+	BuildStatusStateFailed     BuildStatusState = "FAILED"
+	BuildStatusStateInProgress BuildStatusState = "INPROGRESS"
+	BuildStatusStateStopped    BuildStatusState = "STOPPED"
+)
+
+// CommitStatus is the build status of a commit of a pull request.
+type CommitStatus struct {
+	Commit string      `json:"commit"`
+	Status BuildStatus `json:"status"`
+}
+
+func (s *CommitStatus) Key() string {
+	return fmt.Sprintf("%s:%s", s.Commit, s.Status.Key)
+}
+
+// PullRequestInput is the input used to create and update pull requests.
+type PullRequestInput struct {
+	Title       string
+	Description string
+	// SourceBranch is the branch the pull request is opened from.
+	SourceBranch string
+	// SourceRepo is the repository the source branch is in. If nil, the
+	// source branch is in the destination repository.
+	SourceRepo *Repo
+	// DestinationBranch is the branch the pull request is opened against. If
+	// empty, the main branch of the destination repository is used.
+	DestinationBranch string
+}
+
+// MarshalJSON marshals the input into the shape expected by the API.
+func (input *PullRequestInput) MarshalJSON() ([]byte, error) {
+	type branch struct {
+		Name string `json:"name"`
+	}
+	type repository struct {
+		FullName string `json:"full_name"`
+	}
+	type source struct {
+		Branch     branch      `json:"branch"`
+		Repository *repository `json:"repository,omitempty"`
+	}
+	type destination struct {
+		Branch branch `json:"branch"`
+	}
+	type request struct {
+		Title       string       `json:"title"`
+		Description string       `json:"description,omitempty"`
+		Source      source       `json:"source"`
+		Destination *destination `json:"destination,omitempty"`
+	}
+
+	req := request{
+		Title:       input.Title,
+		Description: input.Description,
+		Source:      source{Branch: branch{Name: input.SourceBranch}},
+	}
+	if input.SourceRepo != nil {
+		req.Source.Repository = &repository{FullName: input.SourceRepo.FullName}
+	}
+	if input.DestinationBranch != "" {
+		req.Destination = &destination{Branch: branch{Name: input.DestinationBranch}}
+	}
+	return json.Marshal(req)
+}
+
+// CreatePullRequest opens a new pull request in the given repository.
+//
+// If an open pull request already exists for the source branch, Bitbucket
+// Cloud updates and returns it instead of creating a new one.
+func (c *Client) CreatePullRequest(ctx context.Context, repo *Repo, input PullRequestInput) (*PullRequest, error) {
+	req, err := newJSONRequest("POST", pullRequestsPath(repo), &input)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// GetPullRequest retrieves the pull request with the given ID in the given
+// repository.
+func (c *Client) GetPullRequest(ctx context.Context, repo *Repo, id int64) (*PullRequest, error) {
+	req, err := http.NewRequest("GET", pullRequestPath(repo, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// GetPullRequestStatuses retrieves the build statuses of the commits of the
+// given pull request.
+func (c *Client) GetPullRequestStatuses(ctx context.Context, repo *Repo, id int64) ([]*BuildStatus, error) {
+	var statuses []*BuildStatus
+	next, err := c.page(ctx, pullRequestPath(repo, id)+"/statuses", nil, nil, &statuses)
+	for err == nil && next.HasMore() {
+		var page []*BuildStatus
+		next, err = c.reqPage(ctx, next.Next, &page)
+		statuses = append(statuses, page...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// UpdatePullRequest updates the title, description and destination branch of
+// the given pull request.
+func (c *Client) UpdatePullRequest(ctx context.Context, repo *Repo, id int64, input PullRequestInput) (*PullRequest, error) {
+	req, err := newJSONRequest("PUT", pullRequestPath(repo, id), &input)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// DeclinePullRequest declines the given pull request. Declined pull requests
+// can't be reopened.
+func (c *Client) DeclinePullRequest(ctx context.Context, repo *Repo, id int64) (*PullRequest, error) {
+	req, err := http.NewRequest("POST", pullRequestPath(repo, id)+"/decline", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// MergeStrategy is the strategy used to merge a pull request.
+type MergeStrategy string
+
+const (
+	MergeStrategyMergeCommit MergeStrategy = "merge_commit"
+	MergeStrategySquash      MergeStrategy = "squash"
+	MergeStrategyFastForward MergeStrategy = "fast_forward"
+)
+
+// MergePullRequestOpts are the options used to merge a pull request.
+type MergePullRequestOpts struct {
+	Message           *string        `json:"message,omitempty"`
+	CloseSourceBranch *bool          `json:"close_source_branch,omitempty"`
+	MergeStrategy     *MergeStrategy `json:"merge_strategy,omitempty"`
+}
+
+// MergePullRequest merges the given pull request.
+func (c *Client) MergePullRequest(ctx context.Context, repo *Repo, id int64, opts MergePullRequestOpts) (*PullRequest, error) {
+	req, err := newJSONRequest("POST", pullRequestPath(repo, id)+"/merge", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// CreatePullRequestComment posts a comment with the given Markdown text on the
+// given pull request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, repo *Repo, id int64, text string) error {
+	type content struct {
+		Raw string `json:"raw"`
+	}
+	req, err := newJSONRequest("POST", pullRequestPath(repo, id)+"/comments", struct {
+		Content content `json:"content"`
+	}{Content: content{Raw: text}})
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, req, nil)
+}
+
+func pullRequestsPath(repo *Repo) string {
+	return "/2.0/repositories/" + repo.FullName + "/pullrequests"
+}
+
+func pullRequestPath(repo *Repo, id int64) string {
+	return pullRequestsPath(repo) + "/" + strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,153 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func newTestDoerClient(t *testing.T, doer httpcli.DoerFunc) *Client {
+	t.Helper()
+	return NewClient(&url.URL{Scheme: "https", Host: "api.bitbucket.org"}, doer)
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+	}
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	repo := &Repo{FullName: "sglocal/mux"}
+	fork := &Repo{FullName: "fork/mux"}
+
+	for name, tc := range map[string]struct {
+		input PullRequestInput
+		want  string
+	}{
+		"same repository": {
+			input: PullRequestInput{Title: "title", Description: "body", SourceBranch: "feature"},
+			want:  `{"title":"title","description":"body","source":{"branch":{"name":"feature"}}}`,
+		},
+		"from fork with destination": {
+			input: PullRequestInput{Title: "title", SourceBranch: "feature", SourceRepo: fork, DestinationBranch: "main"},
+			want:  `{"title":"title","source":{"branch":{"name":"feature"},"repository":{"full_name":"fork/mux"}},"destination":{"branch":{"name":"main"}}}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cli := newTestDoerClient(t, func(r *http.Request) (*http.Response, error) {
+				if have, want := r.Method, "POST"; have != want {
+					t.Errorf("unexpected method: have %q, want %q", have, want)
+				}
+				if have, want := r.URL.Path, "/2.0/repositories/sglocal/mux/pullrequests"; have != want {
+					t.Errorf("unexpected path: have %q, want %q", have, want)
+				}
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tc.want, string(body)); diff != "" {
+					t.Errorf("unexpected body (-want +have):\n%s", diff)
+				}
+				return jsonResponse(201, `{"id":42,"state":"OPEN"}`), nil
+			})
+
+			pr, err := cli.CreatePullRequest(context.Background(), repo, tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pr.ID != 42 || pr.State != PullRequestStateOpen {
+				t.Errorf("unexpected pull request: %+v", pr)
+			}
+		})
+	}
+}
+
+func TestClient_GetPullRequestStatuses(t *testing.T) {
+	repo := &Repo{FullName: "sglocal/mux"}
+	pages := map[string]string{
+		"/2.0/repositories/sglocal/mux/pullrequests/1/statuses":        `{"values":[{"key":"a","state":"SUCCESSFUL"}],"next":"https://api.bitbucket.org/2.0/repositories/sglocal/mux/pullrequests/1/statuses?page=2"}`,
+		"/2.0/repositories/sglocal/mux/pullrequests/1/statuses?page=2": `{"values":[{"key":"b","state":"FAILED"}]}`,
+	}
+
+	cli := newTestDoerClient(t, func(r *http.Request) (*http.Response, error) {
+		body, ok := pages[r.URL.RequestURI()]
+		if !ok {
+			return jsonResponse(404, ""), nil
+		}
+		return jsonResponse(200, body), nil
+	})
+
+	statuses, err := cli.GetPullRequestStatuses(context.Background(), repo, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*BuildStatus{
+		{Key: "a", State: BuildStatusStateSuccessful},
+		{Key: "b", State: BuildStatusStateFailed},
+	}
+	if diff := cmp.Diff(want, statuses); diff != "" {
+		t.Errorf("unexpected statuses (-want +have):\n%s", diff)
+	}
+}
+
+func TestClient_GetPullRequest_NotFound(t *testing.T) {
+	cli := newTestDoerClient(t, func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(404, `{"type":"error"}`), nil
+	})
+
+	_, err := cli.GetPullRequest(context.Background(), &Repo{FullName: "sglocal/mux"}, 1)
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if have, want := HTTPErrorCode(err), 404; have != want {
+		t.Errorf("unexpected status code: have %d, want %d", have, want)
+	}
+}
+
+func TestBuildStatus_CommitHash(t *testing.T) {
+	s := BuildStatus{Links: BuildStatusLinks{Commit: Link{Href: "https://api.bitbucket.org/2.0/repositories/sglocal/mux/commit/deadbeef"}}}
+	if have, want := s.CommitHash(), "deadbeef"; have != want {
+		t.Errorf("unexpected hash: have %q, want %q", have, want)
+	}
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"pullrequest": map[string]interface{}{"id": 7},
+		"approval": map[string]interface{}{
+			"date": "2021-10-01T12:00:00Z",
+			"user": map[string]interface{}{"uuid": "{user}"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := ParseWebhookEvent("pullrequest:approved", payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	approved, ok := e.(*PullRequestApprovedEvent)
+	if !ok {
+		t.Fatalf("unexpected event type %T", e)
+	}
+	if approved.PullRequest.ID != 7 || approved.Approval.User.UUID != "{user}" {
+		t.Errorf("unexpected event: %+v", approved)
+	}
+
+	if _, err := ParseWebhookEvent("repo:push", payload); err == nil {
+		t.Error("expected error for unknown event type")
+	}
+}
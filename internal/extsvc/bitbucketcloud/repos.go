@@ -0,0 +1,59 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Repo returns the repository with the given slug in the given workspace.
+func (c *Client) Repo(ctx context.Context, namespace, slug string) (*Repo, error) {
+	req, err := http.NewRequest("GET", "/2.0/repositories/"+namespace+"/"+slug, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo Repo
+	if err := c.do(ctx, req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// ForkInput defines the options when forking a repository.
+type ForkInput struct {
+	// Name is the name of the fork. If empty, the fork has the same name as
+	// the upstream repository.
+	Name *string `json:"name,omitempty"`
+	// Workspace is the workspace the fork is created in.
+	Workspace ForkInputWorkspace `json:"workspace"`
+}
+
+// ForkInputWorkspace identifies the workspace a fork is created in.
+type ForkInputWorkspace struct {
+	Slug string `json:"slug"`
+}
+
+// ForkRepository forks the upstream repository into the workspace given in
+// the input.
+func (c *Client) ForkRepository(ctx context.Context, upstream *Repo, input ForkInput) (*Repo, error) {
+	req, err := newJSONRequest("POST", "/2.0/repositories/"+upstream.FullName+"/forks", input)
+	if err != nil {
+		return nil, err
+	}
+
+	var fork Repo
+	if err := c.do(ctx, req, &fork); err != nil {
+		return nil, err
+	}
+	return &fork, nil
+}
+
+// Namespace returns the workspace of the repository, which is the first
+// component of its full name.
+func (r *Repo) Namespace() string {
+	if i := strings.Index(r.FullName, "/"); i >= 0 {
+		return r.FullName[:i]
+	}
+	return ""
+}
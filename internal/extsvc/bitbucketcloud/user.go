@@ -0,0 +1,35 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Account is a Bitbucket Cloud user or team account.
+type Account struct {
+	UUID          string    `json:"uuid"`
+	AccountID     string    `json:"account_id"`
+	Username      string    `json:"username,omitempty"`
+	Nickname      string    `json:"nickname"`
+	DisplayName   string    `json:"display_name"`
+	AccountStatus string    `json:"account_status,omitempty"`
+	CreatedOn     time.Time `json:"created_on,omitempty"`
+	Links         Links     `json:"links"`
+}
+
+// CurrentUser returns the account of the user the client is authenticated as.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-get
+func (c *Client) CurrentUser(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequest("GET", "/2.0/user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user Account
+	if err := c.do(ctx, req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -79,7 +79,7 @@ type Client struct {
 
 	// RateLimit is the self-imposed rate limiter (since Bitbucket does not have a concept
 	// of rate limiting in HTTP response headers).
-	RateLimit *rate.Limiter
+	RateLimit ratelimit.Limiter
 }
 
 // NewClient returns an authenticated Bitbucket Server API client with
@@ -577,8 +577,21 @@ func (c *Client) CreatePullRequest(ctx context.Context, pr *PullRequest) error {
 		// return errors.Wrap(err, "fetching default reviewers")
 	}
 
+	// Reviewers set on the given PullRequest are requested in addition to the
+	// default reviewers of the repository.
+	for _, r := range pr.Reviewers {
+		if r.User != nil {
+			defaultReviewers = append(defaultReviewers, r.User.Name)
+		}
+	}
+
 	reviewers := make([]reviewer, 0, len(defaultReviewers))
+	seen := make(map[string]struct{}, len(defaultReviewers))
 	for _, r := range defaultReviewers {
+		if _, ok := seen[r]; ok {
+			continue
+		}
+		seen[r] = struct{}{}
 		reviewers = append(reviewers, reviewer{User: struct {
 			Name string `json:"name"`
 		}{Name: r}})
@@ -1230,6 +1243,7 @@ type PullRequest struct {
 			Href string `json:"href"`
 		} `json:"self"`
 	} `json:"links"`
+	Properties *PullRequestProperties `json:"properties,omitempty"`
 
 	Activities   []*Activity     `json:"activities,omitempty"`
 	Commits      []*Commit       `json:"commits,omitempty"`
@@ -1239,6 +1253,21 @@ type PullRequest struct {
 	BuildStatuses []*BuildStatus `json:"buildstatuses,omitempty"`
 }
 
+// PullRequestProperties are the computed properties of a pull request.
+type PullRequestProperties struct {
+	MergeResult *PullRequestMergeResult `json:"mergeResult,omitempty"`
+}
+
+// PullRequestMergeResult is the outcome of the last merge check of a pull
+// request.
+type PullRequestMergeResult struct {
+	// Outcome is one of CLEAN, CONFLICTED or UNKNOWN.
+	Outcome string `json:"outcome"`
+	// Current is false if the pull request has changed since the last merge
+	// check.
+	Current bool `json:"current"`
+}
+
 // PullRequestAuthor is the author of a pull request.
 type PullRequestAuthor struct {
 	User     *User  `json:"user"`
@@ -30,6 +30,9 @@ func ParseWebhookEvent(eventType string, payload []byte) (e interface{}, err err
 	case "pr:participant:status":
 		e = &PullRequestParticipantStatusEvent{}
 		return e, json.Unmarshal(payload, e)
+	case RepoModifiedEventKey:
+		e = &RepoModifiedEvent{}
+		return e, json.Unmarshal(payload, e)
 	default:
 		return nil, errors.Errorf("unknown webhook event type: %q", eventType)
 	}
@@ -60,6 +63,17 @@ func (a *ParticipantStatusEvent) Key() string {
 	return fmt.Sprintf("%s:%d:%d", a.Action, a.User.ID, a.CreatedDate)
 }
 
+// RepoModifiedEventKey is the event key of a RepoModifiedEvent.
+const RepoModifiedEventKey = "repo:modified"
+
+// RepoModifiedEvent is sent by native repository webhooks when a repository is
+// renamed, moved or has its public access changed.
+type RepoModifiedEvent struct {
+	Actor User  `json:"actor"`
+	Old   *Repo `json:"old"`
+	New   *Repo `json:"new"`
+}
+
 type BuildStatusEvent struct {
 	Commit       string        `json:"commit"`
 	Status       BuildStatus   `json:"status"`
@@ -0,0 +1,172 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+// installationTokenRefreshWindow is how long before the expiry of an
+// installation access token we mint a new one. GitHub issues tokens that are
+// valid for one hour.
+const installationTokenRefreshWindow = 5 * time.Minute
+
+// InstallationAuthenticator implements auth.Authenticator for a GitHub App
+// installation. It mints installation access tokens using the App's JWT
+// authenticator and transparently refreshes them before they expire.
+//
+// All clients sharing the same App and installation produce the same Hash, so
+// rate limits are tracked per installation rather than per minted token. They
+// also share the minted token, so constructing a new authenticator (e.g. for
+// every external service source) doesn't mint a new one.
+type InstallationAuthenticator struct {
+	installationID int64
+	appAuth        auth.Authenticator
+	client         *V3Client
+
+	// now is overridden in tests.
+	now func() time.Time
+
+	token *installationToken
+}
+
+// installationToken is the access token of an installation, shared by all
+// authenticators of that installation.
+type installationToken struct {
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+var (
+	installationTokensMu sync.Mutex
+	// installationTokens is keyed by API URL, App and installation ID.
+	installationTokens = map[string]*installationToken{}
+)
+
+func sharedInstallationToken(apiURL *url.URL, appAuth auth.Authenticator, installationID int64) *installationToken {
+	key := apiURL.String() + ":" + appAuth.Hash() + ":" + strconv.FormatInt(installationID, 10)
+
+	installationTokensMu.Lock()
+	defer installationTokensMu.Unlock()
+
+	t, ok := installationTokens[key]
+	if !ok {
+		t = &installationToken{}
+		installationTokens[key] = t
+	}
+	return t
+}
+
+var _ auth.Authenticator = &InstallationAuthenticator{}
+
+// NewInstallationAuthenticator returns an authenticator for the given
+// installation of the GitHub App authenticated by appAuth. apiURL must point to
+// the base URL of the GitHub API; see the docstring for V3Client.apiURL.
+func NewInstallationAuthenticator(apiURL *url.URL, installationID int64, appAuth auth.Authenticator, cli httpcli.Doer) *InstallationAuthenticator {
+	return &InstallationAuthenticator{
+		installationID: installationID,
+		appAuth:        appAuth,
+		client:         NewV3Client(apiURL, appAuth, cli),
+		now:            time.Now,
+		token:          sharedInstallationToken(apiURL, appAuth, installationID),
+	}
+}
+
+// NewInstallationAuthenticatorFromConfig is a convenience constructor parsing
+// the GitHub App settings as they are stored in external service and site
+// configuration: a decimal app ID, a base64-encoded PEM private key and a
+// decimal installation ID.
+func NewInstallationAuthenticatorFromConfig(apiURL *url.URL, appID, encodedPrivateKey, installationID string, cli httpcli.Doer) (*InstallationAuthenticator, error) {
+	privateKey, err := base64.StdEncoding.DecodeString(encodedPrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode private key")
+	}
+
+	appAuth, err := auth.NewOAuthBearerTokenWithGitHubApp(appID, privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "new authenticator with GitHub App")
+	}
+
+	id, err := strconv.ParseInt(installationID, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse installation ID")
+	}
+
+	return NewInstallationAuthenticator(apiURL, id, appAuth, cli), nil
+}
+
+// InstallationID returns the ID of the installation this authenticator acts
+// as.
+func (a *InstallationAuthenticator) InstallationID() int64 {
+	return a.installationID
+}
+
+// Authenticate implements auth.Authenticator. A new installation access token
+// is minted first if there is none yet or the current one is about to expire.
+func (a *InstallationAuthenticator) Authenticate(req *http.Request) error {
+	token, err := a.getToken(req.Context())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Hash implements auth.Authenticator.
+func (a *InstallationAuthenticator) Hash() string {
+	shaSum := sha256.Sum256([]byte(a.appAuth.Hash() + ":" + strconv.FormatInt(a.installationID, 10)))
+	return hex.EncodeToString(shaSum[:])
+}
+
+// Refresh unconditionally mints a new installation access token.
+func (a *InstallationAuthenticator) Refresh(ctx context.Context) error {
+	a.token.mu.Lock()
+	defer a.token.mu.Unlock()
+	return a.refreshLocked(ctx)
+}
+
+func (a *InstallationAuthenticator) getToken(ctx context.Context) (string, error) {
+	a.token.mu.Lock()
+	defer a.token.mu.Unlock()
+
+	if a.token.token == "" || !a.now().Add(installationTokenRefreshWindow).Before(a.token.expiresAt) {
+		if err := a.refreshLocked(ctx); err != nil {
+			return "", err
+		}
+	}
+	return a.token.token, nil
+}
+
+func (a *InstallationAuthenticator) refreshLocked(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	token, err := a.client.CreateAppInstallationAccessToken(ctx, a.installationID)
+	if err != nil {
+		return errors.Wrap(err, "create app installation access token")
+	}
+	if token.Token == nil || *token.Token == "" {
+		return errors.New("empty token returned")
+	}
+
+	a.token.token = *token.Token
+	if token.ExpiresAt != nil {
+		a.token.expiresAt = *token.ExpiresAt
+	} else {
+		// Installation access tokens expire after one hour.
+		a.token.expiresAt = a.now().Add(time.Hour)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+)
+
+type mockInstallationTokenDoer struct {
+	count     int
+	expiresAt time.Time
+}
+
+func (s *mockInstallationTokenDoer) Do(req *http.Request) (*http.Response, error) {
+	s.count++
+	body := fmt.Sprintf(`{"token": "installation-token-%d", "expires_at": %q}`, s.count, s.expiresAt.Format(time.RFC3339))
+	return &http.Response{
+		Request:    req,
+		StatusCode: http.StatusCreated,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func TestInstallationAuthenticator(t *testing.T) {
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	doer := &mockInstallationTokenDoer{expiresAt: now.Add(time.Hour)}
+
+	apiURL := &url.URL{Scheme: "https", Host: "example.com", Path: "/"}
+	a := NewInstallationAuthenticator(apiURL, 42, &auth.OAuthBearerToken{Token: "app-jwt"}, doer)
+	a.now = func() time.Time { return now }
+
+	authenticate := func() string {
+		t.Helper()
+		req, err := http.NewRequest("GET", "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := a.Authenticate(req); err != nil {
+			t.Fatal(err)
+		}
+		return req.Header.Get("Authorization")
+	}
+
+	if have, want := authenticate(), "Bearer installation-token-1"; have != want {
+		t.Fatalf("unexpected header: have=%q want=%q", have, want)
+	}
+
+	// The token is still valid and should be reused.
+	now = now.Add(30 * time.Minute)
+	if have, want := authenticate(), "Bearer installation-token-1"; have != want {
+		t.Fatalf("unexpected header: have=%q want=%q", have, want)
+	}
+
+	// The token is about to expire and should be refreshed.
+	now = now.Add(26 * time.Minute)
+	if have, want := authenticate(), "Bearer installation-token-2"; have != want {
+		t.Fatalf("unexpected header: have=%q want=%q", have, want)
+	}
+	if doer.count != 2 {
+		t.Fatalf("unexpected number of minted tokens: %d", doer.count)
+	}
+
+	// The hash must only depend on the App and installation, so that rate limits
+	// are tracked per installation.
+	other := NewInstallationAuthenticator(apiURL, 42, &auth.OAuthBearerToken{Token: "app-jwt"}, doer)
+	if a.Hash() != other.Hash() {
+		t.Fatal("expected equal hashes for the same installation")
+	}
+	other = NewInstallationAuthenticator(apiURL, 43, &auth.OAuthBearerToken{Token: "app-jwt"}, doer)
+	if a.Hash() == other.Hash() {
+		t.Fatal("expected different hashes for different installations")
+	}
+}
+
+func TestInstallationAuthenticator_SharedToken(t *testing.T) {
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	doer := &mockInstallationTokenDoer{expiresAt: now.Add(time.Hour)}
+
+	apiURL := &url.URL{Scheme: "https", Host: "shared.example.com", Path: "/"}
+	newAuthenticator := func(installationID int64) *InstallationAuthenticator {
+		a := NewInstallationAuthenticator(apiURL, installationID, &auth.OAuthBearerToken{Token: "app-jwt"}, doer)
+		a.now = func() time.Time { return now }
+		return a
+	}
+
+	authenticate := func(a *InstallationAuthenticator) string {
+		t.Helper()
+		req, err := http.NewRequest("GET", "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := a.Authenticate(req); err != nil {
+			t.Fatal(err)
+		}
+		return req.Header.Get("Authorization")
+	}
+
+	if have, want := authenticate(newAuthenticator(1)), "Bearer installation-token-1"; have != want {
+		t.Fatalf("unexpected header: have=%q want=%q", have, want)
+	}
+
+	// A new authenticator for the same installation reuses the minted token.
+	if have, want := authenticate(newAuthenticator(1)), "Bearer installation-token-1"; have != want {
+		t.Fatalf("unexpected header: have=%q want=%q", have, want)
+	}
+
+	// A different installation gets its own token.
+	if have, want := authenticate(newAuthenticator(2)), "Bearer installation-token-2"; have != want {
+		t.Fatalf("unexpected header: have=%q want=%q", have, want)
+	}
+
+	// Refreshing near expiry through one authenticator is visible to the others.
+	now = now.Add(56 * time.Minute)
+	doer.expiresAt = now.Add(time.Hour)
+	if have, want := authenticate(newAuthenticator(1)), "Bearer installation-token-3"; have != want {
+		t.Fatalf("unexpected header: have=%q want=%q", have, want)
+	}
+	if have, want := authenticate(newAuthenticator(1)), "Bearer installation-token-3"; have != want {
+		t.Fatalf("unexpected header: have=%q want=%q", have, want)
+	}
+	if doer.count != 3 {
+		t.Fatalf("unexpected number of minted tokens: %d", doer.count)
+	}
+}
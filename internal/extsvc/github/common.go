@@ -182,6 +182,7 @@ type PullRequest struct {
 	TimelineItems  []TimelineItem
 	Commits        struct{ Nodes []CommitWithChecks }
 	IsDraft        bool
+	Mergeable      string
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
@@ -962,6 +963,321 @@ func (c *V4Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest
 	return c.requestGraphQL(ctx, createPullRequestCommentMutation, input, &result)
 }
 
+const requestReviewsMutation = `
+mutation RequestReviews($input: RequestReviewsInput!) {
+  requestReviews(input: $input) {
+    pullRequest { id }
+  }
+}
+`
+
+// RequestPullRequestReviewers requests reviews on the PullRequest from the
+// given users and teams. Users are identified by their login, teams by
+// "org/team-slug". Existing review requests are kept.
+//
+// Reviewers that can't be requested, such as the author of the pull request
+// and users or teams that don't exist, are skipped and returned.
+func (c *V4Client) RequestPullRequestReviewers(ctx context.Context, pr *PullRequest, users, teams []string) (skipped []string, err error) {
+	var requested []string
+	for _, login := range users {
+		if strings.EqualFold(login, pr.Author.Login) {
+			skipped = append(skipped, login)
+			continue
+		}
+		requested = append(requested, login)
+	}
+	users = requested
+
+	if len(users) == 0 && len(teams) == 0 {
+		return skipped, nil
+	}
+
+	userIDs, teamIDs, notFound, err := c.resolveReviewerIDs(ctx, users, teams)
+	if err != nil {
+		return skipped, err
+	}
+	skipped = append(skipped, notFound...)
+	if len(userIDs) == 0 && len(teamIDs) == 0 {
+		return skipped, nil
+	}
+
+	var result struct {
+		RequestReviews struct {
+			PullRequest struct {
+				ID string
+			} `json:"pullRequest"`
+		} `json:"requestReviews"`
+	}
+	input := map[string]interface{}{"input": struct {
+		PullRequestID string   `json:"pullRequestId"`
+		UserIDs       []string `json:"userIds,omitempty"`
+		TeamIDs       []string `json:"teamIds,omitempty"`
+		Union         bool     `json:"union"`
+	}{
+		PullRequestID: pr.ID,
+		UserIDs:       userIDs,
+		TeamIDs:       teamIDs,
+		Union:         true,
+	}}
+	return skipped, c.requestGraphQL(ctx, requestReviewsMutation, input, &result)
+}
+
+// resolveReviewerIDs looks up the GraphQL node IDs of the given users and
+// teams in a single request. Users and teams that don't exist, and teams not
+// in the format "org/team-slug", are returned in notFound.
+func (c *V4Client) resolveReviewerIDs(ctx context.Context, users, teams []string) (userIDs, teamIDs, notFound []string, err error) {
+	var (
+		params     []string
+		fields     []string
+		vars       = map[string]interface{}{}
+		validTeams []string
+	)
+	for i, login := range users {
+		params = append(params, fmt.Sprintf("$u%d: String!", i))
+		fields = append(fields, fmt.Sprintf("u%d: user(login: $u%d) { id }", i, i))
+		vars[fmt.Sprintf("u%d", i)] = login
+	}
+	for _, team := range teams {
+		parts := strings.SplitN(team, "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			notFound = append(notFound, team)
+			continue
+		}
+		i := len(validTeams)
+		validTeams = append(validTeams, team)
+		org, slug := parts[0], parts[1]
+		params = append(params, fmt.Sprintf("$o%d: String!", i), fmt.Sprintf("$t%d: String!", i))
+		fields = append(fields, fmt.Sprintf("t%d: organization(login: $o%d) { team(slug: $t%d) { id } }", i, i, i))
+		vars[fmt.Sprintf("o%d", i)] = org
+		vars[fmt.Sprintf("t%d", i)] = slug
+	}
+	if len(fields) == 0 {
+		return nil, nil, notFound, nil
+	}
+
+	q := fmt.Sprintf("query ResolveReviewers(%s) {\n%s\n}", strings.Join(params, ", "), strings.Join(fields, "\n"))
+
+	var result map[string]*struct {
+		ID   string
+		Team *struct {
+			ID string
+		}
+	}
+	// Users and teams that don't exist yield NOT_FOUND errors, but the
+	// others are still resolved.
+	if err := c.requestGraphQL(ctx, q, vars, &result); err != nil && !IsNotFound(err) {
+		return nil, nil, nil, errors.Wrap(err, "resolving reviewers")
+	}
+
+	for i, login := range users {
+		u := result[fmt.Sprintf("u%d", i)]
+		if u == nil || u.ID == "" {
+			notFound = append(notFound, login)
+			continue
+		}
+		userIDs = append(userIDs, u.ID)
+	}
+	for i, team := range validTeams {
+		o := result[fmt.Sprintf("t%d", i)]
+		if o == nil || o.Team == nil || o.Team.ID == "" {
+			notFound = append(notFound, team)
+			continue
+		}
+		teamIDs = append(teamIDs, o.Team.ID)
+	}
+	return userIDs, teamIDs, notFound, nil
+}
+
+// SetPullRequestMetadata sets the labels, assignees and milestone of the
+// PullRequest in the repository owner/name to the given ones. Labels and
+// assignees the pull request has but that aren't given are removed, and its
+// milestone is cleared if milestone is empty. Labels and the milestone are
+// identified by their name, assignees by their login.
+func (c *V4Client) SetPullRequestMetadata(ctx context.Context, owner, name string, pr *PullRequest, labels, assignees []string, milestone string) error {
+	current, want, err := c.resolvePullRequestMetadata(ctx, owner, name, pr, labels, assignees, milestone)
+	if err != nil {
+		return err
+	}
+
+	var (
+		params []string
+		fields []string
+		vars   = map[string]interface{}{}
+	)
+	addLabelIDs, removeLabelIDs := diffNodeIDs(current.labelIDs, want.labelIDs)
+	if len(addLabelIDs) > 0 {
+		params = append(params, "$addLabels: AddLabelsToLabelableInput!")
+		fields = append(fields, "addLabelsToLabelable(input: $addLabels) { clientMutationId }")
+		vars["addLabels"] = map[string]interface{}{"labelableId": pr.ID, "labelIds": addLabelIDs}
+	}
+	if len(removeLabelIDs) > 0 {
+		params = append(params, "$removeLabels: RemoveLabelsFromLabelableInput!")
+		fields = append(fields, "removeLabelsFromLabelable(input: $removeLabels) { clientMutationId }")
+		vars["removeLabels"] = map[string]interface{}{"labelableId": pr.ID, "labelIds": removeLabelIDs}
+	}
+	addAssigneeIDs, removeAssigneeIDs := diffNodeIDs(current.assigneeIDs, want.assigneeIDs)
+	if len(addAssigneeIDs) > 0 {
+		params = append(params, "$addAssignees: AddAssigneesToAssignableInput!")
+		fields = append(fields, "addAssigneesToAssignable(input: $addAssignees) { clientMutationId }")
+		vars["addAssignees"] = map[string]interface{}{"assignableId": pr.ID, "assigneeIds": addAssigneeIDs}
+	}
+	if len(removeAssigneeIDs) > 0 {
+		params = append(params, "$removeAssignees: RemoveAssigneesFromAssignableInput!")
+		fields = append(fields, "removeAssigneesFromAssignable(input: $removeAssignees) { clientMutationId }")
+		vars["removeAssignees"] = map[string]interface{}{"assignableId": pr.ID, "assigneeIds": removeAssigneeIDs}
+	}
+	if current.milestoneID != want.milestoneID {
+		// A null milestoneId removes the pull request from its milestone.
+		var milestoneID interface{}
+		if want.milestoneID != "" {
+			milestoneID = want.milestoneID
+		}
+		params = append(params, "$milestone: UpdatePullRequestInput!")
+		fields = append(fields, "updatePullRequest(input: $milestone) { clientMutationId }")
+		vars["milestone"] = map[string]interface{}{"pullRequestId": pr.ID, "milestoneId": milestoneID}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	q := fmt.Sprintf("mutation SetPullRequestMetadata(%s) {\n%s\n}", strings.Join(params, ", "), strings.Join(fields, "\n"))
+	return c.requestGraphQL(ctx, q, vars, nil)
+}
+
+// pullRequestMetadataIDs are the GraphQL node IDs of the labels, assignees and
+// milestone of a pull request.
+type pullRequestMetadataIDs struct {
+	labelIDs    []string
+	assigneeIDs []string
+	milestoneID string
+}
+
+// diffNodeIDs returns the IDs in want that aren't in have, and the IDs in
+// have that aren't in want.
+func diffNodeIDs(have, want []string) (added, removed []string) {
+	haveSet := make(map[string]struct{}, len(have))
+	for _, id := range have {
+		haveSet[id] = struct{}{}
+	}
+	wantSet := make(map[string]struct{}, len(want))
+	for _, id := range want {
+		wantSet[id] = struct{}{}
+		if _, ok := haveSet[id]; !ok {
+			added = append(added, id)
+		}
+	}
+	for _, id := range have {
+		if _, ok := wantSet[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
+
+// resolvePullRequestMetadata looks up the GraphQL node IDs of the labels,
+// assignees and milestone the pull request currently has, and of the given
+// labels, users and milestone, in a single request.
+func (c *V4Client) resolvePullRequestMetadata(ctx context.Context, owner, name string, pr *PullRequest, labels, assignees []string, milestone string) (current, want pullRequestMetadataIDs, err error) {
+	var (
+		params     = []string{"$pr: ID!"}
+		repoFields []string
+		fields     = []string{"pr: node(id: $pr) {\n... on PullRequest {\nlabels(first: 100) { nodes { id } }\nassignees(first: 100) { nodes { id } }\nmilestone { id }\n}\n}"}
+		vars       = map[string]interface{}{"pr": pr.ID}
+	)
+	for i, label := range labels {
+		params = append(params, fmt.Sprintf("$l%d: String!", i))
+		repoFields = append(repoFields, fmt.Sprintf("l%d: label(name: $l%d) { id }", i, i))
+		vars[fmt.Sprintf("l%d", i)] = label
+	}
+	if milestone != "" {
+		repoFields = append(repoFields, "milestones(first: 100, states: [OPEN], orderBy: {field: DUE_DATE, direction: ASC}) { nodes { id title } }")
+	}
+	for i, login := range assignees {
+		params = append(params, fmt.Sprintf("$u%d: String!", i))
+		fields = append(fields, fmt.Sprintf("u%d: user(login: $u%d) { id }", i, i))
+		vars[fmt.Sprintf("u%d", i)] = login
+	}
+	if len(repoFields) > 0 {
+		params = append(params, "$owner: String!", "$name: String!")
+		fields = append(fields, fmt.Sprintf("repository(owner: $owner, name: $name) {\n%s\n}", strings.Join(repoFields, "\n")))
+		vars["owner"] = owner
+		vars["name"] = name
+	}
+
+	q := fmt.Sprintf("query ResolvePullRequestMetadata(%s) {\n%s\n}", strings.Join(params, ", "), strings.Join(fields, "\n"))
+
+	type node struct {
+		ID    string
+		Title string
+	}
+	var result map[string]json.RawMessage
+	if err := c.requestGraphQL(ctx, q, vars, &result); err != nil {
+		return current, want, errors.Wrap(err, "resolving pull request metadata")
+	}
+
+	var prState struct {
+		Labels    struct{ Nodes []node }
+		Assignees struct{ Nodes []node }
+		Milestone *node
+	}
+	if raw, ok := result["pr"]; ok {
+		if err := json.Unmarshal(raw, &prState); err != nil {
+			return current, want, err
+		}
+	}
+	for _, l := range prState.Labels.Nodes {
+		current.labelIDs = append(current.labelIDs, l.ID)
+	}
+	for _, u := range prState.Assignees.Nodes {
+		current.assigneeIDs = append(current.assigneeIDs, u.ID)
+	}
+	if prState.Milestone != nil {
+		current.milestoneID = prState.Milestone.ID
+	}
+
+	var repo map[string]json.RawMessage
+	if raw, ok := result["repository"]; ok {
+		if err := json.Unmarshal(raw, &repo); err != nil {
+			return current, want, err
+		}
+	}
+
+	for i, label := range labels {
+		var l *node
+		if err := json.Unmarshal(repo[fmt.Sprintf("l%d", i)], &l); err != nil || l == nil || l.ID == "" {
+			return current, want, errors.Errorf("label %q not found in repository %s/%s", label, owner, name)
+		}
+		want.labelIDs = append(want.labelIDs, l.ID)
+	}
+	for i, login := range assignees {
+		var u *node
+		if err := json.Unmarshal(result[fmt.Sprintf("u%d", i)], &u); err != nil || u == nil || u.ID == "" {
+			return current, want, errors.Errorf("user %q not found", login)
+		}
+		want.assigneeIDs = append(want.assigneeIDs, u.ID)
+	}
+	if milestone != "" {
+		var milestones struct{ Nodes []node }
+		if raw, ok := repo["milestones"]; ok {
+			if err := json.Unmarshal(raw, &milestones); err != nil {
+				return current, want, err
+			}
+		}
+		for _, m := range milestones.Nodes {
+			if m.Title == milestone {
+				want.milestoneID = m.ID
+				break
+			}
+		}
+		if want.milestoneID == "" {
+			return current, want, errors.Errorf("open milestone %q not found in repository %s/%s", milestone, owner, name)
+		}
+	}
+
+	return current, want, nil
+}
+
 const mergePullRequestMutation = `
 mutation MergePullRequest($input: MergePullRequestInput!) {
   mergePullRequest(input: $input) {
@@ -1404,6 +1720,7 @@ fragment pr on PullRequest {
   baseRefOid
   headRefName
   baseRefName
+  mergeable
   %s
   author {
     ...actor
@@ -1705,12 +2022,58 @@ type Repository struct {
 	StargazerCount int `json:",omitempty"`
 	ForkCount      int `json:",omitempty"`
 
+	// Metadata retained for filtering
+	PrimaryLanguage  *Language         `json:",omitempty"`
+	RepositoryTopics *RepositoryTopics `json:",omitempty"`
+	PushedAt         *time.Time        `json:",omitempty"`
+
 	// This is available for GitHub Enterprise Cloud and GitHub Enterprise Server 3.3.0+ and is used
 	// to identify if a repository is public or private or internal.
 	// https://developer.github.com/changes/2019-12-03-internal-visibility-changes/#repository-visibility-fields
 	Visibility Visibility `json:",omitempty"`
 }
 
+// Language is a programming language as detected by GitHub.
+type Language struct {
+	Name string
+}
+
+// RepositoryTopics is the connection of topics a repository is tagged with.
+type RepositoryTopics struct {
+	Nodes []RepositoryTopic
+}
+
+// RepositoryTopic is a topic a repository is tagged with.
+type RepositoryTopic struct {
+	Topic Topic
+}
+
+// Topic is a GitHub topic.
+type Topic struct {
+	Name string
+}
+
+// Language returns the name of the primary language of the repository, or an
+// empty string if it is unknown.
+func (r *Repository) Language() string {
+	if r.PrimaryLanguage == nil {
+		return ""
+	}
+	return r.PrimaryLanguage.Name
+}
+
+// Topics returns the names of the topics the repository is tagged with.
+func (r *Repository) Topics() []string {
+	if r.RepositoryTopics == nil {
+		return nil
+	}
+	topics := make([]string, 0, len(r.RepositoryTopics.Nodes))
+	for _, n := range r.RepositoryTopics.Nodes {
+		topics = append(topics, n.Topic.Name)
+	}
+	return topics
+}
+
 func ownerNameCacheKey(owner, name string) string       { return "0:" + owner + "/" + name }
 func nameWithOwnerCacheKey(nameWithOwner string) string { return "0:" + nameWithOwner }
 func nodeIDCacheKey(id string) string                   { return "1:" + id }
@@ -1779,6 +2142,9 @@ type restRepository struct {
 	Stars       int                       `json:"stargazers_count"`
 	Forks       int                       `json:"forks_count"`
 	Visibility  string                    `json:"visibility"`
+	Language    string                    `json:"language"`
+	Topics      []string                  `json:"topics"`
+	PushedAt    *time.Time                `json:"pushed_at"`
 }
 
 // getRepositoryFromAPI attempts to fetch a repository from the GitHub API without use of the redis cache.
@@ -1814,6 +2180,18 @@ func convertRestRepo(restRepo restRepository) *Repository {
 		ViewerPermission: convertRestRepoPermissions(restRepo.Permissions),
 		StargazerCount:   restRepo.Stars,
 		ForkCount:        restRepo.Forks,
+		PushedAt:         restRepo.PushedAt,
+	}
+
+	if restRepo.Language != "" {
+		repo.PrimaryLanguage = &Language{Name: restRepo.Language}
+	}
+
+	if len(restRepo.Topics) > 0 {
+		repo.RepositoryTopics = &RepositoryTopics{Nodes: make([]RepositoryTopic, 0, len(restRepo.Topics))}
+		for _, t := range restRepo.Topics {
+			repo.RepositoryTopics.Nodes = append(repo.RepositoryTopics.Nodes, RepositoryTopic{Topic: Topic{Name: t}})
+		}
 	}
 
 	if conf.ExperimentalFeatures().EnableGithubInternalRepoVisibility {
@@ -13,7 +13,6 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/google/go-github/v41/github"
-	"golang.org/x/time/rate"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
@@ -54,7 +53,7 @@ type V3Client struct {
 	rateLimitMonitor *ratelimit.Monitor
 
 	// rateLimit is our self imposed rate limiter
-	rateLimit *rate.Limiter
+	rateLimit ratelimit.Limiter
 
 	// resource specifies which API this client is intended for.
 	// One of 'rest' or 'search'.
@@ -98,6 +97,7 @@ func newV3Client(apiURL *url.URL, a auth.Authenticator, resource string, cli htt
 		}
 		return category
 	})
+	cli = httpcli.NewConditionalCacheMiddleware(conditionalCache, "github", true)(cli)
 
 	var tokenHash string
 	if a != nil {
@@ -202,6 +202,11 @@ func (c *V3Client) request(ctx context.Context, req *http.Request, result interf
 	return doRequest(ctx, c.apiURL, c.auth, c.rateLimitMonitor, c.httpClient, req, result)
 }
 
+// conditionalCache stores responses of the GitHub API by their ETag, so that
+// unchanged resources can be revalidated with conditional requests, which do
+// not count against the rate limit.
+var conditionalCache = rcache.NewWithTTL("gh_conditional", int((24 * time.Hour).Seconds()))
+
 // newRepoCache creates a new cache for GitHub repository metadata. The backing
 // store is Redis. A checksum of the authenticator and API URL are used as a
 // Redis key prefix to prevent collisions with caches for different
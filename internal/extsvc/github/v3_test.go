@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
@@ -49,6 +50,14 @@ func TestNewRepoCache(t *testing.T) {
 	})
 }
 
+func mustParseTime(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
 func TestListAffiliatedRepositories(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -67,6 +76,7 @@ func TestListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr-repos/private-org-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					PushedAt:         mustParseTime("2020-05-11T12:20:40Z"),
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzQwNzM=",
 					DatabaseID:       263034073,
@@ -74,18 +84,21 @@ func TestListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr/private-user-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					PushedAt:         mustParseTime("2020-05-11T12:20:14Z"),
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzM5NDk=",
 					DatabaseID:       263033949,
 					NameWithOwner:    "sourcegraph-vcr/public-user-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr/public-user-repo-1",
 					ViewerPermission: "ADMIN",
+					PushedAt:         mustParseTime("2020-05-11T12:19:47Z"),
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzM3NjE=",
 					DatabaseID:       263033761,
 					NameWithOwner:    "sourcegraph-vcr-repos/public-org-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr-repos/public-org-repo-1",
 					ViewerPermission: "ADMIN",
+					PushedAt:         mustParseTime("2020-05-11T12:18:51Z"),
 				},
 			},
 		},
@@ -99,12 +112,14 @@ func TestListAffiliatedRepositories(t *testing.T) {
 					NameWithOwner:    "sourcegraph-vcr/public-user-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr/public-user-repo-1",
 					ViewerPermission: "ADMIN",
+					PushedAt:         mustParseTime("2020-05-11T12:19:47Z"),
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzM3NjE=",
 					DatabaseID:       263033761,
 					NameWithOwner:    "sourcegraph-vcr-repos/public-org-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr-repos/public-org-repo-1",
 					ViewerPermission: "ADMIN",
+					PushedAt:         mustParseTime("2020-05-11T12:18:51Z"),
 				},
 			},
 		},
@@ -119,6 +134,7 @@ func TestListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr-repos/private-org-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					PushedAt:         mustParseTime("2020-05-11T12:20:40Z"),
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzQwNzM=",
 					DatabaseID:       263034073,
@@ -126,6 +142,7 @@ func TestListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr/private-user-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					PushedAt:         mustParseTime("2020-05-11T12:20:14Z"),
 				},
 			},
 		},
@@ -140,12 +157,14 @@ func TestListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr/private-user-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					PushedAt:         mustParseTime("2020-05-11T12:20:14Z"),
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzM5NDk=",
 					DatabaseID:       263033949,
 					NameWithOwner:    "sourcegraph-vcr/public-user-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr/public-user-repo-1",
 					ViewerPermission: "ADMIN",
+					PushedAt:         mustParseTime("2020-05-11T12:19:47Z"),
 				},
 			},
 		},
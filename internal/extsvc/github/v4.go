@@ -17,7 +17,6 @@ import (
 	"github.com/graphql-go/graphql/language/parser"
 	"github.com/graphql-go/graphql/language/visitor"
 	"github.com/inconshreveable/log15"
-	"golang.org/x/time/rate"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
@@ -49,7 +48,7 @@ type V4Client struct {
 	rateLimitMonitor *ratelimit.Monitor
 
 	// rateLimit is our self imposed rate limiter.
-	rateLimit *rate.Limiter
+	rateLimit ratelimit.Limiter
 }
 
 // NewV4Client creates a new GitHub GraphQL API client with an optional default
@@ -545,6 +544,9 @@ fragment RepositoryFields on Repository {
 	viewerPermission
 	stargazerCount
 	forkCount
+	primaryLanguage { name }
+	repositoryTopics(first: 100) { nodes { topic { name } } }
+	pushedAt
 }
 	`
 	}
@@ -575,6 +577,9 @@ fragment RepositoryFields on Repository {
 	isLocked
 	isDisabled
 	forkCount
+	primaryLanguage { name }
+	repositoryTopics(first: 100) { nodes { topic { name } } }
+	pushedAt
 	%s
 }
 	`, strings.Join(conditionalGHEFields, "\n	"))
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
@@ -11,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
@@ -701,6 +704,137 @@ func TestV4Client_SearchRepos_Enterprise(t *testing.T) {
 	}
 }
 
+// graphQLRecorder responds to GraphQL requests with the given responses, in
+// order, and records the requests it received.
+type graphQLRecorder struct {
+	responses []string
+	requests  []struct {
+		Query     string
+		Variables map[string]interface{}
+	}
+}
+
+func (r *graphQLRecorder) Do(req *http.Request) (*http.Response, error) {
+	var body struct {
+		Query     string
+		Variables map[string]interface{}
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	if len(r.responses) == 0 {
+		return nil, errors.Errorf("unexpected request: %s", body.Query)
+	}
+	r.requests = append(r.requests, body)
+	resp := r.responses[0]
+	r.responses = r.responses[1:]
+	return &http.Response{
+		Request:    req,
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(resp)),
+	}, nil
+}
+
+func TestSetPullRequestMetadata(t *testing.T) {
+	apiURL := &url.URL{Scheme: "https", Host: "example.com", Path: "/"}
+	pr := &PullRequest{ID: "pr"}
+
+	t.Run("removes what isn't given", func(t *testing.T) {
+		rec := &graphQLRecorder{responses: []string{
+			`{"data": {"pr": {"labels": {"nodes": [{"id": "l-keep"}, {"id": "l-old"}]}, "assignees": {"nodes": [{"id": "u-old"}]}, "milestone": {"id": "m-old"}}, "repository": {"l0": {"id": "l-keep"}}}}`,
+			`{"data": {}}`,
+		}}
+		c := NewV4Client(apiURL, nil, rec)
+
+		if err := c.SetPullRequestMetadata(context.Background(), "o", "r", pr, []string{"keep"}, nil, ""); err != nil {
+			t.Fatal(err)
+		}
+		if len(rec.requests) != 2 {
+			t.Fatalf("unexpected number of requests: %d", len(rec.requests))
+		}
+
+		mutation := rec.requests[1]
+		for _, field := range []string{"removeLabelsFromLabelable", "removeAssigneesFromAssignable", "updatePullRequest"} {
+			if !strings.Contains(mutation.Query, field) {
+				t.Errorf("mutation does not contain %s: %s", field, mutation.Query)
+			}
+		}
+		for _, field := range []string{"addLabelsToLabelable", "addAssigneesToAssignable"} {
+			if strings.Contains(mutation.Query, field) {
+				t.Errorf("mutation unexpectedly contains %s: %s", field, mutation.Query)
+			}
+		}
+
+		want := map[string]interface{}{
+			"removeLabels":    map[string]interface{}{"labelableId": "pr", "labelIds": []interface{}{"l-old"}},
+			"removeAssignees": map[string]interface{}{"assignableId": "pr", "assigneeIds": []interface{}{"u-old"}},
+			"milestone":       map[string]interface{}{"pullRequestId": "pr", "milestoneId": nil},
+		}
+		if diff := cmp.Diff(want, mutation.Variables); diff != "" {
+			t.Errorf("unexpected variables (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("nothing to change", func(t *testing.T) {
+		rec := &graphQLRecorder{responses: []string{
+			`{"data": {"pr": {"labels": {"nodes": [{"id": "l-keep"}]}, "assignees": {"nodes": []}, "milestone": null}, "repository": {"l0": {"id": "l-keep"}}}}`,
+		}}
+		c := NewV4Client(apiURL, nil, rec)
+
+		if err := c.SetPullRequestMetadata(context.Background(), "o", "r", pr, []string{"keep"}, nil, ""); err != nil {
+			t.Fatal(err)
+		}
+		if len(rec.requests) != 1 {
+			t.Fatalf("unexpected number of requests: %d", len(rec.requests))
+		}
+	})
+}
+
+func TestRequestPullRequestReviewers(t *testing.T) {
+	apiURL := &url.URL{Scheme: "https", Host: "example.com", Path: "/"}
+	pr := &PullRequest{ID: "pr", Author: Actor{Login: "Author"}}
+
+	t.Run("skips the author and unknown reviewers", func(t *testing.T) {
+		rec := &graphQLRecorder{responses: []string{
+			`{"data": {"u0": {"id": "u-alice"}, "u1": null, "t0": {"team": null}}, "errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a User with the login of 'deleted'."}]}`,
+			`{"data": {"requestReviews": {"pullRequest": {"id": "pr"}}}}`,
+		}}
+		c := NewV4Client(apiURL, nil, rec)
+
+		skipped, err := c.RequestPullRequestReviewers(context.Background(), pr, []string{"author", "alice", "deleted"}, []string{"org/missing", "invalid"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"author", "invalid", "deleted", "org/missing"}, skipped); diff != "" {
+			t.Errorf("unexpected skipped reviewers (-want +got):\n%s", diff)
+		}
+
+		if len(rec.requests) != 2 {
+			t.Fatalf("unexpected number of requests: %d", len(rec.requests))
+		}
+		input := rec.requests[1].Variables["input"].(map[string]interface{})
+		if diff := cmp.Diff([]interface{}{"u-alice"}, input["userIds"]); diff != "" {
+			t.Errorf("unexpected user IDs (-want +got):\n%s", diff)
+		}
+		if _, ok := input["teamIds"]; ok {
+			t.Errorf("unexpected team IDs: %v", input["teamIds"])
+		}
+	})
+
+	t.Run("nobody left to request", func(t *testing.T) {
+		rec := &graphQLRecorder{}
+		c := NewV4Client(apiURL, nil, rec)
+
+		skipped, err := c.RequestPullRequestReviewers(context.Background(), pr, []string{"author"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"author"}, skipped); diff != "" {
+			t.Errorf("unexpected skipped reviewers (-want +got):\n%s", diff)
+		}
+	})
+}
+
 func TestV4Client_WithAuthenticator(t *testing.T) {
 	uri, err := url.Parse("https://github.com")
 	if err != nil {
@@ -15,7 +15,6 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
-	"golang.org/x/time/rate"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
@@ -83,6 +82,11 @@ type CommonOp struct {
 	NoCache bool
 }
 
+// conditionalCache stores responses of the GitLab API by their ETag, so that
+// unchanged pages can be revalidated with conditional requests instead of
+// being transferred again.
+var conditionalCache = rcache.NewWithTTL("gl_conditional", int((24 * time.Hour).Seconds()))
+
 func NewClientProvider(baseURL *url.URL, cli httpcli.Doer) *ClientProvider {
 	if cli == nil {
 		cli = httpcli.ExternalDoer
@@ -96,6 +100,7 @@ func NewClientProvider(baseURL *url.URL, cli httpcli.Doer) *ClientProvider {
 		}
 		return category
 	})
+	cli = httpcli.NewConditionalCacheMiddleware(conditionalCache, "gitlab", false)(cli)
 
 	return &ClientProvider{
 		baseURL:       baseURL.ResolveReference(&url.URL{Path: path.Join(baseURL.Path, "api/v4") + "/"}),
@@ -167,7 +172,7 @@ type Client struct {
 	projCache        *rcache.Cache
 	Auth             auth.Authenticator
 	rateLimitMonitor *ratelimit.Monitor
-	rateLimiter      *rate.Limiter // Our internal rate limiter
+	rateLimiter      ratelimit.Limiter // Our internal rate limiter
 }
 
 // newClient creates a new GitLab API client with an optional personal access token to authenticate requests.
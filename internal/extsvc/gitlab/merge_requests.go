@@ -44,9 +44,14 @@ type MergeRequest struct {
 	WebURL                 string            `json:"web_url"`
 	WorkInProgress         bool              `json:"work_in_progress"`
 	Author                 User              `json:"author"`
+	HasConflicts           bool              `json:"has_conflicts"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
+	// DivergedCommitsCount is the number of commits the target branch is
+	// ahead of the source branch. It is only returned by GetMergeRequest.
+	DivergedCommitsCount int `json:"diverged_commits_count"`
+
 	// The fields below are computed from other REST API requests when getting a
 	// Merge Request. Once our minimum version is GitLab 12.0, we can use the
 	// GraphQL API to retrieve all of this data at once, but until then, we have
@@ -87,11 +92,16 @@ var (
 )
 
 type CreateMergeRequestOpts struct {
-	SourceBranch    string `json:"source_branch"`
-	TargetBranch    string `json:"target_branch"`
-	TargetProjectID int    `json:"target_project_id,omitempty"`
-	Title           string `json:"title"`
-	Description     string `json:"description,omitempty"`
+	SourceBranch    string  `json:"source_branch"`
+	TargetBranch    string  `json:"target_branch"`
+	TargetProjectID int     `json:"target_project_id,omitempty"`
+	Title           string  `json:"title"`
+	Description     string  `json:"description,omitempty"`
+	ReviewerIDs     []int32 `json:"reviewer_ids,omitempty"`
+	AssigneeIDs     []int32 `json:"assignee_ids,omitempty"`
+	// Labels is a comma-separated list of label names.
+	Labels      string `json:"labels,omitempty"`
+	MilestoneID ID     `json:"milestone_id,omitempty"`
 	// TODO: other fields at
 	// https://docs.gitlab.com/ee/api/merge_requests.html#create-mr as needed.
 }
@@ -132,7 +142,7 @@ func (c *Client) GetMergeRequest(ctx context.Context, project *Project, iid ID)
 
 	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d/merge_requests/%d", project.ID, iid), nil)
+	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d/merge_requests/%d?include_diverged_commits_count=true", project.ID, iid), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating request to get a merge request")
 	}
@@ -202,6 +212,13 @@ type UpdateMergeRequestOpts struct {
 	Title        string                       `json:"title"`
 	Description  string                       `json:"description,omitempty"`
 	StateEvent   UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
+	// AssigneeIDs, Labels and MilestoneID replace the assignees, labels and
+	// milestone of the merge request if they are not nil. Labels is a
+	// comma-separated list of label names. An empty list or a MilestoneID of
+	// 0 removes all of them.
+	AssigneeIDs *[]int32 `json:"assignee_ids,omitempty"`
+	Labels      *string  `json:"labels,omitempty"`
+	MilestoneID *ID      `json:"milestone_id,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
@@ -7,6 +7,8 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 )
 
 func TestWIP(t *testing.T) {
@@ -183,6 +185,26 @@ func TestGetMergeRequest(t *testing.T) {
 			t.Errorf("unexpected non-nil error: %+v", err)
 		}
 	})
+
+	t.Run("diverged commits", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = httpcli.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if have, want := req.URL.Query().Get("include_diverged_commits_count"), "true"; have != want {
+				t.Errorf("unexpected include_diverged_commits_count: have %q, want %q", have, want)
+			}
+			return (&mockHTTPResponseBody{
+				responseBody: `{"iid":42,"has_conflicts":true,"diverged_commits_count":3}`,
+			}).Do(req)
+		})
+
+		mr, err := client.GetMergeRequest(ctx, project, 42)
+		if err != nil {
+			t.Fatalf("unexpected non-nil error: %+v", err)
+		}
+		if diff := cmp.Diff(mr, &MergeRequest{IID: 42, HasConflicts: true, DivergedCommitsCount: 3}); diff != "" {
+			t.Errorf("unexpected merge request: %s", diff)
+		}
+	})
 }
 
 func TestGetOpenMergeRequestByRefs(t *testing.T) {
@@ -0,0 +1,49 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type Milestone struct {
+	ID    ID     `json:"id"`
+	IID   ID     `json:"iid"`
+	Title string `json:"title"`
+	State string `json:"state"`
+}
+
+// GetActiveMilestoneByTitle returns the active milestone of the project with
+// the given title, or nil if no such milestone exists.
+func (c *Client) GetActiveMilestoneByTitle(ctx context.Context, project *Project, title string) (*Milestone, error) {
+	if MockGetActiveMilestoneByTitle != nil {
+		return MockGetActiveMilestoneByTitle(c, ctx, project, title)
+	}
+
+	values := make(url.Values)
+	values.Add("title", title)
+	values.Add("state", "active")
+	u := &url.URL{
+		Path: fmt.Sprintf("projects/%d/milestones", project.ID), RawQuery: values.Encode(),
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to get milestone by title")
+	}
+
+	resp := []*Milestone{}
+	if _, _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to get milestone by title")
+	}
+	if len(resp) == 0 {
+		return nil, nil
+	}
+	return resp[0], nil
+}
@@ -33,6 +33,18 @@ type Project struct {
 	Archived          bool           `json:"archived"`
 	StarCount         int            `json:"star_count"`
 	ForksCount        int            `json:"forks_count"`
+	Topics            []string       `json:"topics,omitempty"`           // Topics of the project (GitLab 14.5+; previously "tag_list")
+	TagList           []string       `json:"tag_list,omitempty"`         // Deprecated alias of topics on older GitLab versions
+	LastActivityAt    *time.Time     `json:"last_activity_at,omitempty"` // Last push or other activity on the project
+}
+
+// ProjectTopics returns the topics of the project, falling back to the
+// deprecated tag list on GitLab versions predating topics.
+func (p *Project) ProjectTopics() []string {
+	if len(p.Topics) > 0 {
+		return p.Topics
+	}
+	return p.TagList
 }
 
 type ProjectCommon struct {
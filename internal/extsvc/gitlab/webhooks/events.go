@@ -25,6 +25,35 @@ type PipelineEvent struct {
 	MergeRequest *gitlab.MergeRequest `json:"merge_request"`
 }
 
+// MemberEvent is sent by group webhooks and system hooks when a user is added
+// to, removed from, or has their access level changed in a group or a project.
+// Project member events are only sent by system hooks.
+type MemberEvent struct {
+	EventName string `json:"event_name"`
+
+	UserID       int32  `json:"user_id"`
+	UserUsername string `json:"user_username"`
+
+	// ProjectID and ProjectPathWithNamespace are set for project member
+	// events.
+	ProjectID                int32  `json:"project_id"`
+	ProjectPathWithNamespace string `json:"project_path_with_namespace"`
+
+	// GroupID and GroupPath are set for group member events.
+	GroupID   int32  `json:"group_id"`
+	GroupPath string `json:"group_path"`
+}
+
+// Names of the events unmarshalled into a MemberEvent.
+const (
+	EventNameUserAddToProject      = "user_add_to_team"
+	EventNameUserUpdateForProject  = "user_update_for_team"
+	EventNameUserRemoveFromProject = "user_remove_from_team"
+	EventNameUserAddToGroup        = "user_add_to_group"
+	EventNameUserUpdateForGroup    = "user_update_for_group"
+	EventNameUserRemoveFromGroup   = "user_remove_from_group"
+)
+
 var ErrObjectKindUnknown = errors.New("unknown object kind")
 
 type downcaster interface {
@@ -32,7 +61,7 @@ type downcaster interface {
 }
 
 // UnmarshalEvent unmarshals the given JSON into an event type. Possible return
-// types are *MergeRequestEvent.
+// types are *MergeRequestEvent, *PipelineEvent and *MemberEvent.
 //
 // Errors caused by a valid payload being of an unknown type may be
 // distinguished from other errors by checking for ErrObjectKindUnknown in the
@@ -45,9 +74,11 @@ func UnmarshalEvent(data []byte) (interface{}, error) {
 	// Since we only care about the object_kind field, we'll start by
 	// unmarshalling into a minimal type that only has that field. We use
 	// object_kind instead of event_type because not all GitLab webhook types
-	// include event_type, whereas object_kind is generally reliable.
+	// include event_type, whereas object_kind is generally reliable. Member
+	// events are the exception: they only include event_name.
 	var event struct {
 		ObjectKind string `json:"object_kind"`
+		EventName  string `json:"event_name"`
 	}
 	if err := json.Unmarshal(data, &event); err != nil {
 		return nil, errors.Wrap(err, "determining object kind")
@@ -60,6 +91,14 @@ func UnmarshalEvent(data []byte) (interface{}, error) {
 		typedEvent = &mergeRequestEvent{}
 	case "pipeline":
 		typedEvent = &PipelineEvent{}
+	case "":
+		switch event.EventName {
+		case EventNameUserAddToProject, EventNameUserUpdateForProject, EventNameUserRemoveFromProject,
+			EventNameUserAddToGroup, EventNameUserUpdateForGroup, EventNameUserRemoveFromGroup:
+			typedEvent = &MemberEvent{}
+		default:
+			return nil, errors.Wrapf(ErrObjectKindUnknown, "event name: %s", event.EventName)
+		}
 	default:
 		return nil, errors.Wrapf(ErrObjectKindUnknown, "kind: %s", event.ObjectKind)
 	}
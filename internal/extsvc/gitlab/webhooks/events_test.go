@@ -110,4 +110,36 @@ func TestUnmarshalEvent(t *testing.T) {
 			t.Errorf("unexpected IID: have %d; want %d", pe.Pipeline.ID, want)
 		}
 	})
+	t.Run("valid group member", func(t *testing.T) {
+		event, err := UnmarshalEvent([]byte(`
+			{
+				"event_name": "user_remove_from_group",
+				"group_id": 78,
+				"group_path": "storefronts",
+				"user_id": 41,
+				"user_username": "johnsmith"
+			}
+		`))
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		me := event.(*MemberEvent)
+		if want := int32(41); me.UserID != want {
+			t.Errorf("unexpected user_id: have %d; want %d", me.UserID, want)
+		}
+		if want := int32(78); me.GroupID != want {
+			t.Errorf("unexpected group_id: have %d; want %d", me.GroupID, want)
+		}
+	})
+
+	t.Run("unknown event name", func(t *testing.T) {
+		event, err := UnmarshalEvent([]byte(`{"event_name":"project_create"}`))
+		if event != nil {
+			t.Errorf("unexpected non-nil event: %+v", event)
+		}
+		if !errors.Is(err, ErrObjectKindUnknown) {
+			t.Errorf("unexpected error chain: %+v", err)
+		}
+	})
 }
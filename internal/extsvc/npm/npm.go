@@ -99,7 +99,7 @@ func Exists(ctx context.Context, client Client, dependency reposource.NPMDepende
 type HTTPClient struct {
 	registryURL string
 	doer        httpcli.Doer
-	limiter     *rate.Limiter
+	limiter     ratelimit.Limiter
 }
 
 func NewHTTPClient(registryURL string, rateLimit *schema.NPMRateLimit) *HTTPClient {
@@ -38,7 +38,7 @@ type Client struct {
 
 	// RateLimit is the self-imposed rate limiter (since Pagure does not have a concept
 	// of rate limiting in HTTP response headers).
-	RateLimit *rate.Limiter
+	RateLimit ratelimit.Limiter
 }
 
 // NewClient returns an authenticated Pagure API client with
@@ -271,6 +271,9 @@ type CreateCommitFromPatchRequest struct {
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
+	// Signing specifies whether the commit will be signed: if nil, the commit
+	// is not signed, if non-nil, it is signed with the given key.
+	Signing *CommitSigningConfig
 }
 
 // PatchCommitInfo will be used for commit information when creating a commit from a patch
@@ -301,6 +304,21 @@ type PushConfig struct {
 	Passphrase string
 }
 
+// CommitSigningConfig provides the key used to sign a commit.
+type CommitSigningConfig struct {
+	// Format is the format of the key, either "openpgp" or "ssh". See
+	// commitsigning.Format.
+	Format string
+
+	// PrivateKey is the armored OpenPGP private key or the OpenSSH private
+	// key.
+	PrivateKey string
+
+	// Passphrase is the passphrase to decrypt the private key. It is only
+	// required if the private key is encrypted.
+	Passphrase string
+}
+
 // CreateCommitFromPatchResponse is the response type returned after creating
 // a commit from a patch
 type CreateCommitFromPatchResponse struct {
@@ -0,0 +1,196 @@
+package httpcli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gregjones/httpcache"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// maxConditionalCacheBodySize is the largest response body that is stored by
+// the conditional cache middleware. Larger responses are passed through
+// unchanged.
+const maxConditionalCacheBodySize = 2 << 20 // 2 MiB
+
+var (
+	conditionalCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_httpcli_conditional_cache_requests_total",
+		Help: "Total number of cacheable requests sent through the conditional cache middleware, by result (hit, miss, uncacheable).",
+	}, []string{"subsystem", "result"})
+
+	conditionalCacheSavedBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_httpcli_conditional_cache_saved_bytes_total",
+		Help: "Total number of response body bytes served from the conditional cache instead of being transferred again.",
+	}, []string{"subsystem"})
+
+	conditionalCacheSavedRateLimit = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_httpcli_conditional_cache_saved_rate_limit_total",
+		Help: "Total number of requests served from the conditional cache whose 304 Not Modified response didn't count against the API rate limit of the code host.",
+	}, []string{"subsystem"})
+)
+
+// conditionalCacheKeyHeaders are the request headers that cache entries are
+// keyed by, in addition to the method and URL. They include every header that
+// code hosts accept credentials in, so that responses are never shared between
+// credentials.
+var conditionalCacheKeyHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	// GitLab
+	"Private-Token",
+	"Job-Token",
+	// Responses differ by media type.
+	"Accept",
+}
+
+// cachedConditionalResponse is the representation of a response stored by
+// NewConditionalCacheMiddleware.
+type cachedConditionalResponse struct {
+	ETag       string      `json:"etag"`
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// NewConditionalCacheMiddleware returns a Middleware that stores successful GET
+// responses carrying an ETag header in the given cache, and revalidates them on
+// subsequent requests by sending If-None-Match. When the server answers with
+// 304 Not Modified, the cached response is returned to the caller instead,
+// with its headers updated from the 304 response (e.g. fresh rate limit
+// headers).
+//
+// Code hosts such as GitHub do not count 304 responses against the API rate
+// limit, so a hit avoids both re-transferring and re-paying for unchanged
+// pages. notModifiedIsFree tells whether that's the case for the code host, in
+// which case hits are also recorded as rate limit savings.
+//
+// Cache entries are keyed by the method, URL and the credential and Accept
+// headers of the request, so responses are never shared between credentials.
+// subsystem is used to label the metrics recorded by the middleware.
+func NewConditionalCacheMiddleware(cache httpcache.Cache, subsystem string, notModifiedIsFree bool) Middleware {
+	return func(cli Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			// Requests that are already conditional are the caller's business.
+			if req.Method != http.MethodGet || req.Header.Get("Range") != "" || req.Header.Get("If-None-Match") != "" {
+				return cli.Do(req)
+			}
+
+			key := conditionalCacheKey(req)
+
+			var cached *cachedConditionalResponse
+			if b, ok := cache.Get(key); ok {
+				cached = &cachedConditionalResponse{}
+				if err := json.Unmarshal(b, cached); err != nil {
+					log15.Warn("httpcli: ignoring malformed conditional cache entry", "subsystem", subsystem, "error", err)
+					cached = nil
+				} else {
+					req.Header.Set("If-None-Match", cached.ETag)
+				}
+			}
+
+			resp, err := cli.Do(req)
+			if err != nil {
+				return resp, err
+			}
+
+			if cached != nil && resp.StatusCode == http.StatusNotModified {
+				conditionalCacheRequests.WithLabelValues(subsystem, "hit").Inc()
+				conditionalCacheSavedBytes.WithLabelValues(subsystem).Add(float64(len(cached.Body)))
+				if notModifiedIsFree {
+					conditionalCacheSavedRateLimit.WithLabelValues(subsystem).Inc()
+				}
+				resp.Body.Close()
+				return cached.response(req, resp.Header), nil
+			}
+
+			etag := resp.Header.Get("ETag")
+			if resp.StatusCode != http.StatusOK || etag == "" {
+				conditionalCacheRequests.WithLabelValues(subsystem, "uncacheable").Inc()
+				return resp, nil
+			}
+			conditionalCacheRequests.WithLabelValues(subsystem, "miss").Inc()
+
+			body, err := io.ReadAll(io.LimitReader(resp.Body, maxConditionalCacheBodySize+1))
+			if err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+
+			if len(body) > maxConditionalCacheBodySize {
+				// Too big to cache; hand back the rest of the body untouched.
+				resp.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+				return resp, nil
+			}
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+
+			b, err := json.Marshal(&cachedConditionalResponse{
+				ETag:       etag,
+				StatusCode: resp.StatusCode,
+				Header:     resp.Header,
+				Body:       body,
+			})
+			if err != nil {
+				log15.Warn("httpcli: failed to marshal conditional cache entry", "subsystem", subsystem, "error", err)
+				return resp, nil
+			}
+			cache.Set(key, b)
+
+			return resp, nil
+		})
+	}
+}
+
+// response reconstructs an *http.Response from the cached entry, overlaying
+// the headers of the fresh 304 response.
+func (c *cachedConditionalResponse) response(req *http.Request, fresh http.Header) *http.Response {
+	header := c.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	for k, v := range fresh {
+		if k == "Content-Length" {
+			continue
+		}
+		header[k] = v
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(c.StatusCode) + " " + http.StatusText(c.StatusCode),
+		StatusCode:    c.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+func conditionalCacheKey(req *http.Request) string {
+	h := sha256.New()
+	write := func(s string) {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	write(req.Method)
+	write(req.URL.String())
+	for _, name := range conditionalCacheKeyHeaders {
+		write(strings.Join(req.Header.Values(name), ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,132 @@
+package httpcli
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gregjones/httpcache"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestConditionalCacheMiddleware(t *testing.T) {
+	var (
+		requests    int
+		ifNoneMatch []string
+	)
+	cli := NewConditionalCacheMiddleware(httpcache.NewMemoryCache(), "test", true)(DoerFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		ifNoneMatch = append(ifNoneMatch, req.Header.Get("If-None-Match"))
+
+		header := make(http.Header)
+		header.Set("X-RateLimit-Remaining", strings.Repeat("9", requests))
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			return &http.Response{
+				StatusCode: http.StatusNotModified,
+				Header:     header,
+				Body:       io.NopCloser(strings.NewReader("")),
+				Request:    req,
+			}, nil
+		}
+
+		header.Set("ETag", `"v1"`)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader("body")),
+			Request:    req,
+		}, nil
+	}))
+
+	do := func(token string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest("GET", "https://example.com/repos", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", token)
+		resp, err := cli.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	readBody := func(resp *http.Response) string {
+		t.Helper()
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	// First request populates the cache.
+	resp := do("a")
+	if have, want := readBody(resp), "body"; have != want {
+		t.Fatalf("unexpected body: have %q, want %q", have, want)
+	}
+
+	// Second request is revalidated and served from the cache with fresh headers.
+	savedRateLimit := testutil.ToFloat64(conditionalCacheSavedRateLimit.WithLabelValues("test"))
+	resp = do("a")
+	if have, want := testutil.ToFloat64(conditionalCacheSavedRateLimit.WithLabelValues("test"))-savedRateLimit, 1.0; have != want {
+		t.Fatalf("unexpected rate limit savings: have %v, want %v", have, want)
+	}
+	if have, want := resp.StatusCode, http.StatusOK; have != want {
+		t.Fatalf("unexpected status: have %d, want %d", have, want)
+	}
+	if have, want := readBody(resp), "body"; have != want {
+		t.Fatalf("unexpected body: have %q, want %q", have, want)
+	}
+	if have, want := resp.Header.Get("X-RateLimit-Remaining"), "99"; have != want {
+		t.Fatalf("unexpected rate limit header: have %q, want %q", have, want)
+	}
+
+	// A request with different credentials must not be served from the cache.
+	resp = do("b")
+	if have, want := readBody(resp), "body"; have != want {
+		t.Fatalf("unexpected body: have %q, want %q", have, want)
+	}
+
+	if have, want := strings.Join(ifNoneMatch, "|"), `|"v1"|`; have != want {
+		t.Fatalf("unexpected If-None-Match headers: have %q, want %q", have, want)
+	}
+}
+
+func TestConditionalCacheKey(t *testing.T) {
+	newRequest := func(header map[string]string) *http.Request {
+		t.Helper()
+		req, err := http.NewRequest("GET", "https://gitlab.example.com/api/v4/projects", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		return req
+	}
+
+	base := conditionalCacheKey(newRequest(map[string]string{"Private-Token": "a"}))
+
+	for name, tc := range map[string]struct {
+		header   map[string]string
+		wantSame bool
+	}{
+		"same token":              {header: map[string]string{"Private-Token": "a"}, wantSame: true},
+		"unrelated header":        {header: map[string]string{"Private-Token": "a", "X-Request-Id": "1"}, wantSame: true},
+		"different private token": {header: map[string]string{"Private-Token": "b"}},
+		"job token":               {header: map[string]string{"Job-Token": "a"}},
+		"authorization":           {header: map[string]string{"Private-Token": "a", "Authorization": "Bearer a"}},
+		"accept":                  {header: map[string]string{"Private-Token": "a", "Accept": "text/plain"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			key := conditionalCacheKey(newRequest(tc.header))
+			if have := key == base; have != tc.wantSame {
+				t.Errorf("unexpected key equality: have %v, want %v", have, tc.wantSame)
+			}
+		})
+	}
+}
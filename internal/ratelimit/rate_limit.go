@@ -1,40 +1,91 @@
 package ratelimit
 
 import (
+	"context"
+	"strconv"
 	"sync"
 
 	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
 )
 
+var distributed, _ = strconv.ParseBool(env.Get("SRC_DISTRIBUTED_RATE_LIMITS", "false", "Share code host rate limits between all replicas of all services through Redis."))
+
 // DefaultRegistry is the default global rate limit registry. It will hold rate limit mappings
 // for each instance of our services.
-var DefaultRegistry = NewRegistry()
+var DefaultRegistry = newDefaultRegistry()
 
-// NewRegistry creates a new empty registry.
-func NewRegistry() *Registry {
-	return &Registry{
-		rateLimiters: make(map[string]*rate.Limiter),
+func newDefaultRegistry() Registry {
+	if distributed {
+		return NewRedisRegistry(redispool.Store)
 	}
+	return NewRegistry()
+}
+
+// Limiter is a rate limiter for requests to a single code host. It is
+// implemented by *rate.Limiter.
+type Limiter interface {
+	// Wait is shorthand for WaitN(ctx, 1).
+	Wait(ctx context.Context) error
+	// WaitN blocks until n events are allowed to happen, or returns an error if
+	// ctx is done first or n exceeds the burst size.
+	WaitN(ctx context.Context, n int) error
+	// Limit returns the maximum overall event rate.
+	Limit() rate.Limit
+	// SetLimit sets a new limit for the limiter.
+	SetLimit(newLimit rate.Limit)
+	// Burst returns the maximum burst size.
+	Burst() int
+	// SetBurst sets a new burst size for the limiter.
+	SetBurst(newBurst int)
 }
 
-// Registry keeps a mapping of external service URL to *rate.Limiter.
+var _ Limiter = &rate.Limiter{}
+
+// Registry keeps a mapping of external service URL to Limiter.
 // By default an infinite limiter is returned.
-type Registry struct {
+type Registry interface {
+	// Get fetches the rate limiter associated with the given code host. If none has been
+	// configured an infinite limiter is returned.
+	Get(baseURL string) Limiter
+	// GetOrSet fetches the rate limiter associated with the given code host. If none has been configured
+	// yet, the provided limiter will be set. A nil limiter will fall back to an infinite limiter.
+	GetOrSet(baseURL string, fallback *rate.Limiter) Limiter
+	// Count returns the total number of rate limiters in the registry
+	Count() int
+}
+
+// NewRegistry creates a new empty registry whose limiters are local to the
+// current process.
+func NewRegistry() Registry {
+	return newInMemoryRegistry()
+}
+
+func newInMemoryRegistry() *inMemoryRegistry {
+	return &inMemoryRegistry{
+		rateLimiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// inMemoryRegistry is a Registry of *rate.Limiter instances.
+type inMemoryRegistry struct {
 	mu sync.Mutex
 	// Rate limiter per code host, keys are the normalized base URL for a
 	// code host.
 	rateLimiters map[string]*rate.Limiter
 }
 
-// Get fetches the rate limiter associated with the given code host. If none has been
-// configured an infinite limiter is returned.
-func (r *Registry) Get(baseURL string) *rate.Limiter {
-	return r.GetOrSet(baseURL, nil)
+func (r *inMemoryRegistry) Get(baseURL string) Limiter {
+	return r.getOrSet(baseURL, nil)
+}
+
+func (r *inMemoryRegistry) GetOrSet(baseURL string, fallback *rate.Limiter) Limiter {
+	return r.getOrSet(baseURL, fallback)
 }
 
-// GetOrSet fetches the rate limiter associated with the given code host. If none has been configured
-// yet, the provided limiter will be set. A nil limiter will fall back to an infinite limiter.
-func (r *Registry) GetOrSet(baseURL string, fallback *rate.Limiter) *rate.Limiter {
+func (r *inMemoryRegistry) getOrSet(baseURL string, fallback *rate.Limiter) *rate.Limiter {
 	baseURL = normaliseURL(baseURL)
 	if fallback == nil {
 		// Burst is ignored when rate.Inf is used
@@ -51,7 +102,7 @@ func (r *Registry) GetOrSet(baseURL string, fallback *rate.Limiter) *rate.Limite
 }
 
 // Count returns the total number of rate limiters in the registry
-func (r *Registry) Count() int {
+func (r *inMemoryRegistry) Count() int {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	return len(r.rateLimiters)
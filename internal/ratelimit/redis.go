@@ -0,0 +1,336 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gomodule/redigo/redis"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+)
+
+// backgroundReserve is the fraction of a bucket's burst that background
+// requests may not consume, so that interactive requests from users are
+// still served promptly while large syncs are running.
+const backgroundReserve = 0.2
+
+// redisKeyPrefix is the prefix of all keys written by the Redis registry.
+const redisKeyPrefix = "v1:ratelimit:"
+
+// bucketTTL is how long an untouched token bucket is kept in Redis. A bucket
+// that has been idle for longer than it takes to refill is equivalent to a
+// full one, so this only needs to cover slow refill rates.
+const bucketTTL = 24 * time.Hour
+
+// exceedsBurst is returned by takeTokensScript if more tokens than the burst
+// are requested.
+const exceedsBurst = -2
+
+var errExceedsBurst = errors.New("exceeds limiter's burst")
+
+var redisLimiterRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_ratelimit_redis_requests_total",
+	Help: "Total number of token requests made against the distributed code host rate limiter, by priority and result (granted, throttled, error).",
+}, []string{"priority", "result"})
+
+// Priority is the priority of requests made to a code host. Background
+// requests leave a reserve of each rate limit for interactive requests.
+type Priority int
+
+const (
+	// PriorityInteractive is used for requests made on behalf of a user
+	// waiting for the result. It is the default.
+	PriorityInteractive Priority = iota
+	// PriorityBackground is used for requests made by background jobs such
+	// as repository and permissions syncing.
+	PriorityBackground
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityBackground:
+		return "background"
+	default:
+		return "interactive"
+	}
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context carrying the given request priority.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the request priority carried by ctx, defaulting
+// to PriorityInteractive.
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityInteractive
+}
+
+// takeTokensScript atomically refills the token bucket stored at KEYS[1]
+// according to the limit and burst stored at KEYS[2] (falling back to
+// ARGV[1] and ARGV[2]) and tries to take ARGV[4] tokens from it. The fraction
+// ARGV[5] of the burst is reserved and cannot be taken, but the reserve is
+// shrunk so that requests of up to the burst can always be granted eventually.
+// ARGV[3] is the current time in milliseconds and ARGV[6] the TTL of the bucket
+// in milliseconds.
+//
+// The script returns 0 if the tokens were taken, -1 if they will never be
+// available because the limit is zero, exceedsBurst if more tokens than the
+// burst were requested, or else the number of milliseconds to wait until
+// enough tokens are available. A negative limit means the limit is infinite.
+var takeTokensScript = redis.NewScript(2, `
+local limit = tonumber(redis.call('HGET', KEYS[2], 'limit') or ARGV[1])
+local burst = tonumber(redis.call('HGET', KEYS[2], 'burst') or ARGV[2])
+local now = tonumber(ARGV[3])
+local n = tonumber(ARGV[4])
+local reserve = tonumber(ARGV[5]) * burst
+
+if limit < 0 then
+	return 0
+end
+if n > burst then
+	return -2
+end
+reserve = math.min(reserve, burst - n)
+
+local tokens = tonumber(redis.call('HGET', KEYS[1], 'tokens') or burst)
+local ts = tonumber(redis.call('HGET', KEYS[1], 'ts') or now)
+if now > ts then
+	tokens = math.min(burst, tokens + (now - ts) * limit / 1000)
+end
+
+local wait = 0
+if tokens - n >= reserve then
+	tokens = tokens - n
+elseif limit > 0 then
+	wait = math.ceil((n + reserve - tokens) * 1000 / limit)
+else
+	wait = -1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], ARGV[6])
+return wait
+`)
+
+// NewRedisRegistry returns a Registry whose limiters are shared by all
+// processes using the same Redis pool. Limits set through SetLimit and
+// SetBurst apply to every replica of every service, so a code host sees the
+// configured request rate in total rather than per process.
+//
+// If Redis is unavailable, limiters fall back to enforcing the limit locally.
+func NewRedisRegistry(pool *redis.Pool) Registry {
+	return &redisRegistry{
+		pool:  pool,
+		local: newInMemoryRegistry(),
+	}
+}
+
+type redisRegistry struct {
+	pool *redis.Pool
+
+	// local holds the process-local limiters that provide the default limit
+	// and burst and are used when Redis is unavailable.
+	local *inMemoryRegistry
+}
+
+func (r *redisRegistry) Get(baseURL string) Limiter {
+	return r.GetOrSet(baseURL, nil)
+}
+
+func (r *redisRegistry) GetOrSet(baseURL string, fallback *rate.Limiter) Limiter {
+	return &redisLimiter{
+		pool:  r.pool,
+		key:   redisKeyPrefix + normaliseURL(baseURL),
+		local: r.local.getOrSet(baseURL, fallback),
+		now:   time.Now,
+	}
+}
+
+func (r *redisRegistry) Count() int {
+	return r.local.Count()
+}
+
+// redisLimiter is a Limiter backed by a token bucket stored in Redis.
+type redisLimiter struct {
+	pool *redis.Pool
+	// key is the key of the token bucket. The limit and burst are stored at
+	// key + ":config".
+	key   string
+	local *rate.Limiter
+
+	// now is overridden in tests.
+	now func() time.Time
+}
+
+var _ Limiter = &redisLimiter{}
+
+func (l *redisLimiter) Wait(ctx context.Context) error {
+	return l.WaitN(ctx, 1)
+}
+
+func (l *redisLimiter) WaitN(ctx context.Context, n int) error {
+	priority := PriorityFromContext(ctx)
+
+	reserve := 0.0
+	if priority == PriorityBackground {
+		reserve = backgroundReserve
+	}
+
+	for {
+		wait, err := l.take(n, reserve)
+		if errors.Is(err, errExceedsBurst) {
+			return errors.Wrapf(err, "rate: Wait(n=%d)", n)
+		}
+		if err != nil {
+			redisLimiterRequests.WithLabelValues(priority.String(), "error").Inc()
+			log15.Warn("ratelimit: falling back to local rate limiter", "key", l.key, "error", err)
+			return l.local.WaitN(ctx, n)
+		}
+		if wait == 0 {
+			redisLimiterRequests.WithLabelValues(priority.String(), "granted").Inc()
+			return nil
+		}
+		redisLimiterRequests.WithLabelValues(priority.String(), "throttled").Inc()
+
+		if wait < 0 {
+			return errors.Newf("rate: Wait(n=%d) would block forever with a zero limit", n)
+		}
+		if deadline, ok := ctx.Deadline(); ok && l.now().Add(wait).After(deadline) {
+			return errors.Newf("rate: Wait(n=%d) would exceed context deadline", n)
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// take tries to take n tokens from the bucket and returns how long to wait
+// before trying again, 0 if the tokens were taken or a negative duration if
+// they will never be available.
+func (l *redisLimiter) take(n int, reserve float64) (time.Duration, error) {
+	c := l.pool.Get()
+	defer c.Close()
+
+	ms, err := redis.Int64(takeTokensScript.Do(c,
+		l.key,
+		l.key+":config",
+		formatLimit(l.local.Limit()),
+		l.local.Burst(),
+		l.now().UnixNano()/int64(time.Millisecond),
+		n,
+		strconv.FormatFloat(reserve, 'f', -1, 64),
+		bucketTTL.Milliseconds(),
+	))
+	if err != nil {
+		return 0, errors.Wrap(err, "running token bucket script")
+	}
+	switch {
+	case ms == exceedsBurst:
+		return 0, errExceedsBurst
+	case ms < 0:
+		return -1, nil
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// Limit returns the shared limit if one has been set, or else the limit of
+// the local limiter.
+func (l *redisLimiter) Limit() rate.Limit {
+	if limit, _, err := l.shared(); err == nil && limit != nil {
+		return *limit
+	}
+	return l.local.Limit()
+}
+
+// Burst returns the shared burst if one has been set, or else the burst of
+// the local limiter.
+func (l *redisLimiter) Burst() int {
+	if _, burst, err := l.shared(); err == nil && burst > 0 {
+		return burst
+	}
+	return l.local.Burst()
+}
+
+// SetLimit sets the limit for all processes sharing the limiter.
+func (l *redisLimiter) SetLimit(newLimit rate.Limit) {
+	l.local.SetLimit(newLimit)
+	l.setShared("limit", formatLimit(newLimit))
+}
+
+// SetBurst sets the burst for all processes sharing the limiter.
+func (l *redisLimiter) SetBurst(newBurst int) {
+	l.local.SetBurst(newBurst)
+	l.setShared("burst", strconv.Itoa(newBurst))
+}
+
+func (l *redisLimiter) setShared(field, value string) {
+	c := l.pool.Get()
+	defer c.Close()
+
+	if _, err := c.Do("HSET", l.key+":config", field, value); err != nil {
+		log15.Warn("ratelimit: failed to store shared rate limit", "key", l.key, "field", field, "error", err)
+	}
+}
+
+// shared reads the limit and burst stored in Redis. A nil limit or zero burst
+// means none has been set.
+func (l *redisLimiter) shared() (*rate.Limit, int, error) {
+	c := l.pool.Get()
+	defer c.Close()
+
+	values, err := redis.Strings(c.Do("HMGET", l.key+":config", "limit", "burst"))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var limit *rate.Limit
+	if values[0] != "" {
+		f, err := strconv.ParseFloat(values[0], 64)
+		if err != nil {
+			return nil, 0, err
+		}
+		lim := parseLimit(f)
+		limit = &lim
+	}
+
+	var burst int
+	if values[1] != "" {
+		if burst, err = strconv.Atoi(values[1]); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return limit, burst, nil
+}
+
+// formatLimit encodes a limit for storage in Redis, where rate.Inf is
+// represented as -1.
+func formatLimit(limit rate.Limit) string {
+	if limit == rate.Inf {
+		return "-1"
+	}
+	return strconv.FormatFloat(float64(limit), 'f', -1, 64)
+}
+
+func parseLimit(f float64) rate.Limit {
+	if f < 0 || math.IsInf(f, 1) {
+		return rate.Inf
+	}
+	return rate.Limit(f)
+}
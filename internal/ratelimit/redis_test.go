@@ -0,0 +1,159 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gomodule/redigo/redis"
+	"golang.org/x/time/rate"
+)
+
+func newTestPool(t *testing.T) *redis.Pool {
+	t.Helper()
+
+	pool := &redis.Pool{
+		MaxIdle:     3,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", "127.0.0.1:6379")
+		},
+	}
+
+	c := pool.Get()
+	defer c.Close()
+
+	// If we are not on CI, skip the test if our redis connection fails.
+	if os.Getenv("CI") == "" {
+		if _, err := c.Do("PING"); err != nil {
+			t.Skip("could not connect to redis", err)
+		}
+	}
+
+	key := redisKeyPrefix + normaliseURL(testBaseURL(t))
+	if _, err := c.Do("DEL", key, key+":config"); err != nil {
+		t.Fatal(err)
+	}
+
+	return pool
+}
+
+func testBaseURL(t *testing.T) string {
+	return "https://" + t.Name() + ".example.com/"
+}
+
+func TestRedisRegistry_SharedLimit(t *testing.T) {
+	pool := newTestPool(t)
+	baseURL := testBaseURL(t)
+
+	// Two registries act as two replicas of a service.
+	a := NewRedisRegistry(pool).Get(baseURL)
+	b := NewRedisRegistry(pool).Get(baseURL)
+
+	a.SetLimit(1)
+	a.SetBurst(2)
+
+	if have, want := b.Limit(), rate.Limit(1); have != want {
+		t.Fatalf("unexpected limit: have %v, want %v", have, want)
+	}
+	if have, want := b.Burst(), 2; have != want {
+		t.Fatalf("unexpected burst: have %d, want %d", have, want)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := a.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// The bucket is now empty, and refilling it takes longer than the deadline.
+	if err := b.Wait(ctx); err == nil {
+		t.Fatal("expected error waiting for an empty bucket")
+	}
+
+	if err := a.WaitN(ctx, 3); err == nil {
+		t.Fatal("expected error waiting for more tokens than the burst")
+	}
+}
+
+func TestRedisRegistry_BackgroundReserve(t *testing.T) {
+	pool := newTestPool(t)
+
+	l := NewRedisRegistry(pool).GetOrSet(testBaseURL(t), rate.NewLimiter(0.1, 10))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	background := WithPriority(ctx, PriorityBackground)
+	for i := 0; i < 8; i++ {
+		if err := l.Wait(background); err != nil {
+			t.Fatalf("background request %d: %s", i, err)
+		}
+	}
+	if err := l.Wait(background); err == nil {
+		t.Fatal("expected background requests to leave the reserve alone")
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("interactive request %d: %s", i, err)
+		}
+	}
+}
+
+func TestRedisRegistry_BackgroundReserveLargeRequest(t *testing.T) {
+	pool := newTestPool(t)
+
+	l := NewRedisRegistry(pool).GetOrSet(testBaseURL(t), rate.NewLimiter(1000, 10))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Requests for more tokens than are left above the reserve must still be
+	// granted once the bucket is full enough, rather than waiting forever.
+	background := WithPriority(ctx, PriorityBackground)
+	for _, n := range []int{10, 9, 10} {
+		if err := l.WaitN(background, n); err != nil {
+			t.Fatalf("background request for %d tokens: %s", n, err)
+		}
+	}
+}
+
+func TestRedisRegistry_LocalFallback(t *testing.T) {
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return nil, errors.New("redis is down")
+		},
+	}
+
+	l := NewRedisRegistry(pool).GetOrSet("https://example.com/", rate.NewLimiter(1, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if have, want := l.Limit(), rate.Limit(1); have != want {
+		t.Fatalf("unexpected limit: have %v, want %v", have, want)
+	}
+	if err := l.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("expected the local limiter to be enforced")
+	}
+}
+
+func TestPriorityFromContext(t *testing.T) {
+	ctx := context.Background()
+	if have, want := PriorityFromContext(ctx), PriorityInteractive; have != want {
+		t.Fatalf("unexpected default priority: have %s, want %s", have, want)
+	}
+	ctx = WithPriority(ctx, PriorityBackground)
+	if have, want := PriorityFromContext(ctx), PriorityBackground; have != want {
+		t.Fatalf("unexpected priority: have %s, want %s", have, want)
+	}
+}
@@ -0,0 +1,86 @@
+// Package realip determines the IP address of the client that sent an HTTP
+// request, taking the reverse proxies in front of Sourcegraph into account.
+package realip
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+var trustedProxies = parseCIDRs(env.Get(
+	"SRC_TRUSTED_PROXIES",
+	"127.0.0.0/8,::1/128,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,fc00::/7",
+	"Comma-separated list of CIDR ranges of the reverse proxies whose X-Forwarded-For header is trusted to determine the IP address of clients.",
+))
+
+// FromRequest returns the IP address of the client that sent r.
+//
+// The X-Forwarded-For header can be set by anyone, so it's only used if the
+// request was received from a trusted proxy. It's then read from right to
+// left, since every proxy appends the address it received the request from,
+// and the first address that isn't a trusted proxy is returned.
+func FromRequest(r *http.Request) string {
+	return fromRequest(r, trustedProxies)
+}
+
+func fromRequest(r *http.Request, trusted []*net.IPNet) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if !isTrusted(ip, trusted) {
+		return ip
+	}
+
+	var forwardedFor []string
+	for _, v := range r.Header.Values("X-Forwarded-For") {
+		forwardedFor = append(forwardedFor, strings.Split(v, ",")...)
+	}
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		addr := strings.TrimSpace(forwardedFor[i])
+		if net.ParseIP(addr) == nil {
+			// Whatever comes before an invalid address can't be trusted.
+			break
+		}
+		ip = addr
+		if !isTrusted(addr, trusted) {
+			break
+		}
+	}
+	return ip
+}
+
+func isTrusted(ip string, trusted []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, n := range trusted {
+		if n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCIDRs(s string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range strings.Split(s, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			log15.Warn("realip: ignoring invalid trusted proxy range", "cidr", cidr, "error", err)
+			continue
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
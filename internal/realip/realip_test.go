@@ -0,0 +1,66 @@
+package realip
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromRequest(t *testing.T) {
+	trusted := parseCIDRs("10.0.0.0/8, ::1/128")
+
+	for name, tc := range map[string]struct {
+		remoteAddr   string
+		forwardedFor []string
+		want         string
+	}{
+		"no proxy": {
+			remoteAddr: "203.0.113.1:1234",
+			want:       "203.0.113.1",
+		},
+		"untrusted peer": {
+			remoteAddr:   "203.0.113.1:1234",
+			forwardedFor: []string{"198.51.100.1"},
+			want:         "203.0.113.1",
+		},
+		"trusted proxy": {
+			remoteAddr:   "10.0.0.2:1234",
+			forwardedFor: []string{"203.0.113.1"},
+			want:         "203.0.113.1",
+		},
+		"spoofed address before the client": {
+			remoteAddr:   "10.0.0.2:1234",
+			forwardedFor: []string{"198.51.100.1, 203.0.113.1, 10.0.0.1"},
+			want:         "203.0.113.1",
+		},
+		"multiple headers": {
+			remoteAddr:   "[::1]:1234",
+			forwardedFor: []string{"198.51.100.1", "203.0.113.1"},
+			want:         "203.0.113.1",
+		},
+		"only trusted proxies": {
+			remoteAddr:   "10.0.0.2:1234",
+			forwardedFor: []string{"10.0.0.3, 10.0.0.1"},
+			want:         "10.0.0.3",
+		},
+		"invalid address": {
+			remoteAddr:   "10.0.0.2:1234",
+			forwardedFor: []string{"203.0.113.1, garbage, 10.0.0.1"},
+			want:         "10.0.0.1",
+		},
+		"trusted proxy without header": {
+			remoteAddr: "10.0.0.2:1234",
+			want:       "10.0.0.2",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tc.remoteAddr
+			for _, v := range tc.forwardedFor {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if have := fromRequest(r, trusted); have != tc.want {
+				t.Errorf("unexpected IP: have %q, want %q", have, tc.want)
+			}
+		})
+	}
+}
@@ -3,11 +3,9 @@ package repos
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
@@ -144,61 +142,14 @@ func newGithubSource(svc *types.ExternalService, c *schema.GitHubConnection, cf
 	)
 
 	useGitHubApp := false
-	dotcomConfig := conf.SiteConfig().Dotcom
-	if envvar.SourcegraphDotComMode() &&
-		c.GithubAppInstallationID != "" &&
-		dotcomConfig != nil &&
-		dotcomConfig.GithubAppCloud != nil &&
-		dotcomConfig.GithubAppCloud.AppID != "" &&
-		dotcomConfig.GithubAppCloud.PrivateKey != "" {
-		privateKey, err := base64.StdEncoding.DecodeString(dotcomConfig.GithubAppCloud.PrivateKey)
-		if err != nil {
-			return nil, errors.Wrap(err, "decode private key")
-		}
-
-		auther, err := auth.NewOAuthBearerTokenWithGitHubApp(dotcomConfig.GithubAppCloud.AppID, privateKey)
-		if err != nil {
-			return nil, errors.Wrap(err, "new authenticator with GitHub App")
-		}
-
-		apiURL, err := url.Parse("https://github.com")
-		if err != nil {
-			return nil, errors.Wrap(err, "parse github.com")
-		}
-		client := github.NewV3Client(apiURL, auther, nil)
-
-		installationID, err := strconv.ParseInt(c.GithubAppInstallationID, 10, 64)
-		if err != nil {
-			return nil, errors.Wrap(err, "parse installation ID")
-		}
-
-		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
-		defer cancel()
-
-		// TODO(cloud-saas): This code path is called every time a user visits the page
-		// https://sourcegraph.test:3443/organizations/test-org1/settings/repositories,
-		// and making an access token with every single refresh feels like a waste. We
-		// probably need to either reduce the call times of this code path or consider
-		// storing the access token within the external service config and create a new
-		// column (e.g. "token_expired_at") to store its expiry (as installation access
-		// tokens are forced to expire after one hour).
-		//
-		// As a side effect of having this extra column, it could also be used to
-		// indicate the token expiry for non-GitHub App cases because we are having
-		// revoked/expired access tokens in some external services but we currently do
-		// not stop trying on these external services, as well as lacking a reliable way
-		// to inform users about their revoked/expired access tokens.
-		token, err := client.CreateAppInstallationAccessToken(ctx, installationID)
-		if err != nil {
-			return nil, errors.Wrap(err, "create app installation access token")
-		}
-		if token.Token == nil {
-			return nil, errors.New("empty token returned")
-		}
-
-		auther = &auth.OAuthBearerToken{Token: *token.Token}
-		v3Client = github.NewV3Client(apiURL, auther, cli)
-		v4Client = github.NewV4Client(apiURL, auther, cli)
+	installationAuther, err := newGitHubAppInstallationAuthenticator(apiURL, c, cli)
+	if err != nil {
+		return nil, err
+	}
+	if installationAuther != nil {
+		v3Client = github.NewV3Client(apiURL, installationAuther, cli)
+		v4Client = github.NewV4Client(apiURL, installationAuther, cli)
+		searchClient = github.NewV3SearchClient(apiURL, installationAuther, cli)
 
 		useGitHubApp = true
 	}
@@ -239,6 +190,35 @@ func newGithubSource(svc *types.ExternalService, c *schema.GitHubConnection, cf
 	}, nil
 }
 
+// newGitHubAppInstallationAuthenticator returns an authenticator for the GitHub
+// App installation configured on the connection, or nil if the connection is
+// not authenticated through a GitHub App.
+//
+// Connections on Sourcegraph.com that only set githubAppInstallationID use the
+// site-wide Sourcegraph Cloud GitHub App.
+func newGitHubAppInstallationAuthenticator(apiURL *url.URL, c *schema.GitHubConnection, cli httpcli.Doer) (*github.InstallationAuthenticator, error) {
+	if c.GithubAppInstallationID == "" {
+		return nil, nil
+	}
+
+	if c.GithubAppID != "" && c.GithubAppPrivateKey != "" {
+		auther, err := github.NewInstallationAuthenticatorFromConfig(apiURL, c.GithubAppID, c.GithubAppPrivateKey, c.GithubAppInstallationID, cli)
+		return auther, errors.Wrap(err, "new GitHub App installation authenticator")
+	}
+
+	dotcomConfig := conf.SiteConfig().Dotcom
+	if envvar.SourcegraphDotComMode() &&
+		dotcomConfig != nil &&
+		dotcomConfig.GithubAppCloud != nil &&
+		dotcomConfig.GithubAppCloud.AppID != "" &&
+		dotcomConfig.GithubAppCloud.PrivateKey != "" {
+		auther, err := github.NewInstallationAuthenticatorFromConfig(apiURL, dotcomConfig.GithubAppCloud.AppID, dotcomConfig.GithubAppCloud.PrivateKey, c.GithubAppInstallationID, cli)
+		return auther, errors.Wrap(err, "new GitHub App installation authenticator")
+	}
+
+	return nil, nil
+}
+
 func (s GithubSource) WithAuthenticator(a auth.Authenticator) (Source, error) {
 	switch a.(type) {
 	case *auth.OAuthBearerToken,
@@ -75,6 +75,11 @@ var (
 		Help: "Incremented each time the scheduler updates a managed repository due to hitting a deadline.",
 	})
 
+	schedQuietWindowPostponed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_repoupdater_sched_quiet_window_postponed",
+		Help: "Incremented each time the scheduler postpones an update of a managed repository because of a quiet window.",
+	})
+
 	schedManualFetch = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "src_repoupdater_sched_manual_fetch",
 		Help: "Incremented each time the scheduler updates a repository due to user traffic.",
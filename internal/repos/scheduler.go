@@ -100,6 +100,11 @@ const (
 // backoff by doubling the current interval. This ensures that problematic repos
 // don't stay in the front of the schedule clogging up the queue.
 //
+// Update policies from the gitUpdatePolicies site configuration can override
+// this per repo based on its attributes: a policy either fixes the interval or
+// bounds the heuristic, and may define quiet windows during which due updates
+// are postponed. The policy of a repo is re-evaluated after every update.
+//
 // When it is time for a repo to update, the scheduler inserts the repo into a queue.
 //
 // A worker continuously dequeues repos and sends updates to gitserver, but its concurrency
@@ -115,6 +120,11 @@ type updateScheduler struct {
 type configuredRepo struct {
 	ID   api.RepoID
 	Name api.RepoName
+
+	// Attributes used to match update policies.
+	Archived bool `json:",omitempty"`
+	Fork     bool `json:",omitempty"`
+	Stars    int  `json:",omitempty"`
 }
 
 // notifyChanBuffer controls the buffer size of notification channels.
@@ -163,6 +173,14 @@ func (s *updateScheduler) runSchedule() {
 			break
 		}
 
+		if due := repoUpdate.Policy.postpone(timeNow()); due.After(timeNow()) {
+			// We are in a quiet window of the repo's update policy.
+			schedQuietWindowPostponed.Inc()
+			repoUpdate.Due = due
+			heap.Fix(s.schedule, 0)
+			continue
+		}
+
 		schedAutoFetch.Inc()
 		s.updateQueue.enqueue(repoUpdate.Repo, priorityLow)
 		repoUpdate.Due = timeNow().Add(repoUpdate.Interval)
@@ -207,19 +225,28 @@ func (s *updateScheduler) runUpdateLoop(ctx context.Context) {
 					schedError.WithLabelValues("repoUpdateResponse").Inc()
 					log15.Error("runUpdateLoop: error updating repo", "uri", repo.Name, "err", resp.Error)
 				}
+				var lastChanged *time.Time
+				if resp != nil {
+					lastChanged = resp.LastChanged
+				}
+				policy := getUpdatePolicy(conf.Get(), repo, lastChanged)
+				s.schedule.setPolicy(repo, policy)
+
 				if interval := getCustomInterval(conf.Get(), string(repo.Name)); interval > 0 {
-					s.schedule.updateInterval(repo, interval)
+					s.schedule.updateInterval(repo, interval, true)
+				} else if policy != nil && policy.Interval > 0 {
+					s.schedule.updateInterval(repo, policy.Interval, false)
 				} else if err != nil || (resp != nil && resp.Error != "") {
 					// On error we will double the current interval so that we back off and don't
 					// get stuck with problematic repos with low intervals.
 					if currentInterval, ok := s.schedule.getCurrentInterval(repo); ok {
-						s.schedule.updateInterval(repo, currentInterval*2)
+						s.schedule.updateInterval(repo, currentInterval*2, false)
 					}
 				} else if resp != nil && resp.LastFetched != nil && resp.LastChanged != nil {
 					// This is the heuristic that is described in the updateScheduler documentation.
 					// Update that documentation if you update this logic.
 					interval := resp.LastFetched.Sub(*resp.LastChanged) / 2
-					s.schedule.updateInterval(repo, interval)
+					s.schedule.updateInterval(repo, interval, false)
 				}
 			}(ctx, repo, cancel)
 		}
@@ -359,8 +386,11 @@ func (s *updateScheduler) remove(r *types.Repo) {
 
 func configuredRepoFromRepo(r *types.Repo) configuredRepo {
 	repo := configuredRepo{
-		ID:   r.ID,
-		Name: r.Name,
+		ID:       r.ID,
+		Name:     r.Name,
+		Archived: r.Archived,
+		Fork:     r.Fork,
+		Stars:    r.Stars,
 	}
 
 	return repo
@@ -447,6 +477,9 @@ func (s *updateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedu
 			IntervalSeconds: int(update.Interval / time.Second),
 			Due:             update.Due,
 		}
+		if update.Policy != nil {
+			result.Schedule.Policy = update.Policy.Name
+		}
 	}
 	s.schedule.mu.Unlock()
 
@@ -669,7 +702,8 @@ type scheduledRepoUpdate struct {
 	Repo     configuredRepo // the repo to update
 	Interval time.Duration  // how regularly the repo is updated
 	Due      time.Time      // the next time that the repo will be enqueued for a update
-	Index    int            `json:"-"` // the index in the heap
+	Policy   *updatePolicy  `json:",omitempty"` // the update policy matched by the repo, if any
+	Index    int            `json:"-"`          // the index in the heap
 }
 
 // upsert inserts or updates a repo in the schedule.
@@ -743,8 +777,9 @@ func (s *schedule) insertNew(repos []types.MinimalRepo) {
 	configuredRepos := make([]configuredRepo, len(repos))
 	for i := range repos {
 		configuredRepos[i] = configuredRepo{
-			ID:   repos[i].ID,
-			Name: repos[i].Name,
+			ID:    repos[i].ID,
+			Name:  repos[i].Name,
+			Stars: repos[i].Stars,
 		}
 	}
 
@@ -773,18 +808,26 @@ func (s *schedule) insertNew(repos []types.MinimalRepo) {
 
 // updateInterval updates the update interval of a repo in the schedule.
 // It does nothing if the repo is not in the schedule.
-func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) {
+//
+// Computed intervals are kept within the bounds of the repo's update policy.
+// Intervals configured in gitUpdateInterval take precedence over the policy and
+// are only kept within the default bounds.
+func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration, configured bool) {
 	if repo.ID == 0 {
 		panic("repo.id is zero")
 	}
 
 	s.mu.Lock()
 	if update := s.index[repo.ID]; update != nil {
+		min, max := minDelay, maxDelay
+		if !configured {
+			min, max = update.Policy.bounds()
+		}
 		switch {
-		case interval > maxDelay:
-			update.Interval = maxDelay
-		case interval < minDelay:
-			update.Interval = minDelay
+		case interval > max:
+			update.Interval = max
+		case interval < min:
+			update.Interval = min
 		default:
 			update.Interval = interval
 		}
@@ -796,7 +839,7 @@ func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) {
 			update.Interval = update.Interval + time.Duration(s.randGenerator.Int63n(2*delta)-delta)
 		}
 
-		update.Due = timeNow().Add(update.Interval)
+		update.Due = update.Policy.postpone(timeNow().Add(update.Interval))
 		log15.Debug("updated repo", "repo", repo.Name, "due", update.Due.Sub(timeNow()))
 		heap.Fix(s, update.Index)
 		s.rescheduleTimer()
@@ -804,6 +847,17 @@ func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) {
 	s.mu.Unlock()
 }
 
+// setPolicy sets the update policy of a repo in the schedule. It does nothing
+// if the repo is not in the schedule.
+func (s *schedule) setPolicy(repo configuredRepo, policy *updatePolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if update := s.index[repo.ID]; update != nil {
+		update.Policy = policy
+	}
+}
+
 // getCurrentInterval gets the current interval for the supplied repo and a bool
 // indicating whether it was found.
 func (s *schedule) getCurrentInterval(repo configuredRepo) (time.Duration, bool) {
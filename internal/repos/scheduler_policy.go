@@ -0,0 +1,167 @@
+package repos
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// An updatePolicy controls how often a repository is updated by the scheduler.
+// Policies are configured in the gitUpdatePolicies site configuration and
+// matched against repository attributes.
+type updatePolicy struct {
+	Name string `json:",omitempty"`
+
+	// Interval is the fixed interval between updates. If zero, the
+	// scheduler's backoff heuristic is used, bounded by MinInterval and
+	// MaxInterval.
+	Interval    time.Duration `json:",omitempty"`
+	MinInterval time.Duration `json:",omitempty"`
+	MaxInterval time.Duration `json:",omitempty"`
+
+	// QuietWindows are the times of day during which scheduled updates are
+	// postponed.
+	QuietWindows []quietWindow `json:",omitempty"`
+}
+
+// A quietWindow is a time of day in UTC, given as offsets from midnight.
+// Windows with an End before their Start span midnight.
+type quietWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// getUpdatePolicy returns the first policy in the site configuration that
+// matches repo, or nil if none does. lastChanged is the time of the last
+// change to the repository reported by gitserver, if known.
+func getUpdatePolicy(c *conf.Unified, repo configuredRepo, lastChanged *time.Time) *updatePolicy {
+	if c == nil {
+		return nil
+	}
+	for i, p := range c.GitUpdatePolicies {
+		if !updatePolicyMatches(p, repo, lastChanged) {
+			continue
+		}
+
+		policy := &updatePolicy{
+			Name:        p.Name,
+			Interval:    time.Duration(p.Interval) * time.Minute,
+			MinInterval: time.Duration(p.MinInterval) * time.Minute,
+			MaxInterval: time.Duration(p.MaxInterval) * time.Minute,
+		}
+		if policy.Name == "" {
+			policy.Name = "#" + strconv.Itoa(i+1)
+		}
+		for _, w := range p.QuietWindows {
+			start, err := parseTimeOfDay(w.Start)
+			if err != nil {
+				log15.Warn("error parsing GitUpdatePolicy quiet window", "policy", policy.Name, "error", err)
+				continue
+			}
+			end, err := parseTimeOfDay(w.End)
+			if err != nil {
+				log15.Warn("error parsing GitUpdatePolicy quiet window", "policy", policy.Name, "error", err)
+				continue
+			}
+			policy.QuietWindows = append(policy.QuietWindows, quietWindow{Start: start, End: end})
+		}
+		return policy
+	}
+	return nil
+}
+
+func updatePolicyMatches(p *schema.UpdatePolicy, repo configuredRepo, lastChanged *time.Time) bool {
+	if p.Archived && !repo.Archived {
+		return false
+	}
+	if p.Fork && !repo.Fork {
+		return false
+	}
+	if p.MinStars > 0 && repo.Stars < p.MinStars {
+		return false
+	}
+	if p.InactiveDays > 0 {
+		if lastChanged == nil || timeNow().Sub(*lastChanged) < time.Duration(p.InactiveDays)*24*time.Hour {
+			return false
+		}
+	}
+	if p.Pattern != "" {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			log15.Warn("error compiling GitUpdatePolicy pattern", "error", err)
+			return false
+		}
+		if !re.MatchString(string(repo.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// bounds returns the minimum and maximum interval between updates of
+// repositories the policy applies to.
+func (p *updatePolicy) bounds() (min, max time.Duration) {
+	min, max = minDelay, maxDelay
+	if p == nil {
+		return min, max
+	}
+	if p.Interval > 0 {
+		// A fixed interval may exceed the default maximum, e.g. to update
+		// archived repositories weekly.
+		if p.Interval > max {
+			max = p.Interval
+		}
+		return min, max
+	}
+	if p.MinInterval > min {
+		min = p.MinInterval
+	}
+	if p.MaxInterval > 0 {
+		max = p.MaxInterval
+	}
+	if max < min {
+		max = min
+	}
+	return min, max
+}
+
+// postpone returns the time at which an update due at t may run: t itself, or
+// the end of the quiet window t falls into.
+func (p *updatePolicy) postpone(t time.Time) time.Time {
+	if p == nil {
+		return t
+	}
+
+	utc := t.UTC()
+	midnight := time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC)
+	offset := utc.Sub(midnight)
+
+	for _, w := range p.QuietWindows {
+		switch {
+		case w.Start <= w.End:
+			if offset >= w.Start && offset < w.End {
+				return midnight.Add(w.End)
+			}
+		case offset >= w.Start:
+			// The window spans midnight and t is before midnight.
+			return midnight.Add(24*time.Hour + w.End)
+		case offset < w.End:
+			// The window spans midnight and t is after midnight.
+			return midnight.Add(w.End)
+		}
+	}
+	return t
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
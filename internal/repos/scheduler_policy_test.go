@@ -0,0 +1,140 @@
+package repos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGetUpdatePolicy(t *testing.T) {
+	mockTime(defaultTime)
+	defer func() { timeNow = nil }()
+
+	c := &conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			GitUpdatePolicies: []*schema.UpdatePolicy{
+				{Name: "archived", Archived: true, Interval: 10080},
+				{Name: "critical", Pattern: "^github.com/sourcegraph/", MinStars: 100, Interval: 1},
+				{Name: "forks", Fork: true, Interval: 1440},
+				{Name: "inactive", InactiveDays: 90, MaxInterval: 720},
+				{QuietWindows: []*schema.QuietWindow{{Start: "22:00", End: "06:00"}}},
+			},
+		},
+	}
+
+	inactive := defaultTime.Add(-91 * 24 * time.Hour)
+	active := defaultTime.Add(-time.Hour)
+
+	for _, tc := range []struct {
+		name        string
+		c           *conf.Unified
+		repo        configuredRepo
+		lastChanged *time.Time
+		want        *updatePolicy
+	}{
+		{
+			name: "nil config",
+			repo: configuredRepo{Name: "github.com/sourcegraph/sourcegraph"},
+		},
+		{
+			name: "archived",
+			c:    c,
+			repo: configuredRepo{Name: "github.com/sourcegraph/sourcegraph", Archived: true, Stars: 1000},
+			want: &updatePolicy{Name: "archived", Interval: 7 * 24 * time.Hour},
+		},
+		{
+			name: "critical",
+			c:    c,
+			repo: configuredRepo{Name: "github.com/sourcegraph/sourcegraph", Stars: 1000},
+			want: &updatePolicy{Name: "critical", Interval: time.Minute},
+		},
+		{
+			name: "not enough stars",
+			c:    c,
+			repo: configuredRepo{Name: "github.com/sourcegraph/sourcegraph", Fork: true, Stars: 10},
+			want: &updatePolicy{Name: "forks", Interval: 24 * time.Hour},
+		},
+		{
+			name:        "inactive",
+			c:           c,
+			repo:        configuredRepo{Name: "github.com/foo/bar"},
+			lastChanged: &inactive,
+			want:        &updatePolicy{Name: "inactive", MaxInterval: 12 * time.Hour},
+		},
+		{
+			name:        "catch-all",
+			c:           c,
+			repo:        configuredRepo{Name: "github.com/foo/bar"},
+			lastChanged: &active,
+			want: &updatePolicy{
+				Name:         "#5",
+				QuietWindows: []quietWindow{{Start: 22 * time.Hour, End: 6 * time.Hour}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have := getUpdatePolicy(tc.c, tc.repo, tc.lastChanged)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("unexpected policy (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdatePolicy_bounds(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		policy   *updatePolicy
+		min, max time.Duration
+	}{
+		{name: "no policy", min: minDelay, max: maxDelay},
+		{name: "fixed interval", policy: &updatePolicy{Interval: 7 * 24 * time.Hour}, min: minDelay, max: 7 * 24 * time.Hour},
+		{name: "backoff cap", policy: &updatePolicy{MaxInterval: 24 * time.Hour}, min: minDelay, max: 24 * time.Hour},
+		{name: "minimum", policy: &updatePolicy{MinInterval: time.Hour}, min: time.Hour, max: maxDelay},
+		{name: "minimum above maximum", policy: &updatePolicy{MinInterval: 2 * time.Hour, MaxInterval: time.Hour}, min: 2 * time.Hour, max: 2 * time.Hour},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			min, max := tc.policy.bounds()
+			if min != tc.min || max != tc.max {
+				t.Fatalf("unexpected bounds: have [%s, %s], want [%s, %s]", min, max, tc.min, tc.max)
+			}
+		})
+	}
+}
+
+func TestUpdatePolicy_postpone(t *testing.T) {
+	day := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour, min int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+
+	policy := &updatePolicy{QuietWindows: []quietWindow{
+		{Start: 9 * time.Hour, End: 17 * time.Hour},
+		{Start: 22 * time.Hour, End: 6 * time.Hour},
+	}}
+
+	for _, tc := range []struct {
+		t, want time.Time
+	}{
+		{t: at(8, 59), want: at(8, 59)},
+		{t: at(9, 0), want: at(17, 0)},
+		{t: at(16, 59), want: at(17, 0)},
+		{t: at(17, 0), want: at(17, 0)},
+		{t: at(23, 0), want: at(30, 0)},
+		{t: at(5, 0), want: at(6, 0)},
+		{t: at(6, 0), want: at(6, 0)},
+	} {
+		if have := policy.postpone(tc.t); !have.Equal(tc.want) {
+			t.Errorf("postpone(%s): have %s, want %s", tc.t, have, tc.want)
+		}
+	}
+
+	var none *updatePolicy
+	if have := none.postpone(at(12, 0)); !have.Equal(at(12, 0)) {
+		t.Errorf("nil policy postponed update to %s", have)
+	}
+}
@@ -878,11 +878,14 @@ func TestSchedule_updateInterval(t *testing.T) {
 	e := configuredRepo{ID: 5, Name: "e"}
 
 	type updateCall struct {
-		time     time.Time
-		repo     configuredRepo
-		interval time.Duration
+		time       time.Time
+		repo       configuredRepo
+		interval   time.Duration
+		configured bool
 	}
 
+	boundedPolicy := &updatePolicy{MaxInterval: time.Hour}
+
 	tests := []struct {
 		name                string
 		initialSchedule     []*scheduledRepoUpdate
@@ -975,6 +978,63 @@ func TestSchedule_updateInterval(t *testing.T) {
 			timeAfterFuncDelays: []time.Duration{maxDelay},
 			wakeupNotifications: 1,
 		},
+		{
+			name: "policy maximum interval",
+			initialSchedule: []*scheduledRepoUpdate{
+				{
+					Repo:     a,
+					Interval: minDelay,
+					Due:      defaultTime.Add(minDelay),
+					Policy:   boundedPolicy,
+				},
+			},
+			updateCalls: []*updateCall{
+				{
+					repo:     a,
+					time:     defaultTime,
+					interval: 4 * time.Hour,
+				},
+			},
+			finalSchedule: []*scheduledRepoUpdate{
+				{
+					Repo:     a,
+					Interval: time.Hour,
+					Due:      defaultTime.Add(time.Hour),
+					Policy:   boundedPolicy,
+				},
+			},
+			timeAfterFuncDelays: []time.Duration{time.Hour},
+			wakeupNotifications: 1,
+		},
+		{
+			name: "configured interval takes precedence over policy",
+			initialSchedule: []*scheduledRepoUpdate{
+				{
+					Repo:     a,
+					Interval: minDelay,
+					Due:      defaultTime.Add(minDelay),
+					Policy:   boundedPolicy,
+				},
+			},
+			updateCalls: []*updateCall{
+				{
+					repo:       a,
+					time:       defaultTime,
+					interval:   4 * time.Hour,
+					configured: true,
+				},
+			},
+			finalSchedule: []*scheduledRepoUpdate{
+				{
+					Repo:     a,
+					Interval: 4 * time.Hour,
+					Due:      defaultTime.Add(4 * time.Hour),
+					Policy:   boundedPolicy,
+				},
+			},
+			timeAfterFuncDelays: []time.Duration{4 * time.Hour},
+			wakeupNotifications: 1,
+		},
 		{
 			name: "update later",
 			initialSchedule: []*scheduledRepoUpdate{
@@ -1040,7 +1100,7 @@ func TestSchedule_updateInterval(t *testing.T) {
 
 			for _, call := range test.updateCalls {
 				mockTime(call.time)
-				s.schedule.updateInterval(call.repo, call.interval)
+				s.schedule.updateInterval(call.repo, call.interval, call.configured)
 			}
 
 			verifySchedule(t, s, test.finalSchedule)
@@ -1193,6 +1253,9 @@ func TestUpdateScheduler_runSchedule(t *testing.T) {
 	d := configuredRepo{ID: 4, Name: "d"}
 	e := configuredRepo{ID: 5, Name: "e"}
 
+	quietPolicy := &updatePolicy{QuietWindows: []quietWindow{{Start: 0, End: 2 * time.Hour}}}
+	quietEnd := time.Date(2000, 1, 1, 2, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name                  string
 		initialSchedule       []*scheduledRepoUpdate
@@ -1288,6 +1351,19 @@ func TestUpdateScheduler_runSchedule(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "update due in quiet window is postponed",
+			initialSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: time.Minute, Due: defaultTime, Policy: quietPolicy},
+			},
+			finalSchedule: []*scheduledRepoUpdate{
+				{Repo: a, Interval: time.Minute, Due: quietEnd, Policy: quietPolicy},
+			},
+			timeAfterFuncDelays: []time.Duration{quietEnd.Sub(defaultTime)},
+			expectedNotifications: func(s *updateScheduler) []chan struct{} {
+				return []chan struct{}{s.schedule.wakeup}
+			},
+		},
 	}
 
 	for _, test := range tests {
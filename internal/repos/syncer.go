@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -121,6 +122,9 @@ func (s *syncHandler) Handle(ctx context.Context, record workerutil.Record) (err
 		return errors.Errorf("expected repos.SyncJob, got %T", record)
 	}
 
+	// Leave part of the code host rate limits to requests made on behalf of users.
+	ctx = ratelimit.WithPriority(ctx, ratelimit.PriorityBackground)
+
 	return s.syncer.SyncExternalService(ctx, sj.ExternalServiceID, s.minSyncInterval())
 }
 
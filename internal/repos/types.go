@@ -20,14 +20,14 @@ type externalServiceLister interface {
 
 // RateLimitSyncer syncs rate limits based on external service configuration
 type RateLimitSyncer struct {
-	registry      *ratelimit.Registry
+	registry      ratelimit.Registry
 	serviceLister externalServiceLister
 	// How many services to fetch in each DB call
 	limit int64
 }
 
 // NewRateLimitSyncer returns a new syncer
-func NewRateLimitSyncer(registry *ratelimit.Registry, serviceLister externalServiceLister) *RateLimitSyncer {
+func NewRateLimitSyncer(registry ratelimit.Registry, serviceLister externalServiceLister) *RateLimitSyncer {
 	r := &RateLimitSyncer{
 		registry:      registry,
 		serviceLister: serviceLister,
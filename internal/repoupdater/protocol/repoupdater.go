@@ -34,6 +34,7 @@ type RepoScheduleState struct {
 	Total           int
 	IntervalSeconds int
 	Due             time.Time
+	Policy          string `json:",omitempty"` // the name of the update policy applying to the repo, if any
 }
 
 type RepoQueueState struct {
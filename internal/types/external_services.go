@@ -4,6 +4,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
+type BitbucketCloudConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.BitbucketCloudConnection
+}
+
 type BitbucketServerConnection struct {
 	// The unique resource identifier of the external service.
 	URN string
@@ -75,7 +75,11 @@ func redactField(buf string, paths ...[]string) (string, error) {
 func redactionInfo(cfg interface{}) ([]jsonStringField, error) {
 	switch cfg := cfg.(type) {
 	case *schema.GitHubConnection:
-		return []jsonStringField{{[]string{"token"}, &cfg.Token}}, nil
+		fields := []jsonStringField{{[]string{"token"}, &cfg.Token}}
+		if cfg.GithubAppPrivateKey != "" {
+			fields = append(fields, jsonStringField{[]string{"githubAppPrivateKey"}, &cfg.GithubAppPrivateKey})
+		}
+		return fields, nil
 	case *schema.GitLabConnection:
 		return []jsonStringField{{[]string{"token"}, &cfg.Token}}, nil
 	case *schema.BitbucketServerConnection:
@@ -661,6 +661,9 @@ type User struct {
 	Tags                  []string
 	InvalidatedSessionsAt time.Time
 	TosAccepted           bool
+	// DeletedAt is only set for soft-deleted users, which are only returned
+	// when explicitly requested (see database.UsersListOptions.IncludeDeleted).
+	DeletedAt *time.Time
 }
 
 type Org struct {
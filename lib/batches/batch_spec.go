@@ -2,9 +2,12 @@ package batches
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/sourcegraph/sourcegraph/lib/batches/env"
@@ -46,6 +49,219 @@ type ChangesetTemplate struct {
 	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
 	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
 	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	AutoMerge *AutoMergePolicy             `json:"autoMerge,omitempty" yaml:"autoMerge,omitempty"`
+	Rebase    *RebasePolicy                `json:"rebase,omitempty" yaml:"rebase,omitempty"`
+	Rollout   *RolloutPolicy               `json:"rollout,omitempty" yaml:"rollout,omitempty"`
+	Reviewers *ReviewersPolicy             `json:"reviewers,omitempty" yaml:"reviewers,omitempty"`
+	Labels    []string                     `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Assignees []string                     `json:"assignees,omitempty" yaml:"assignees,omitempty"`
+	Milestone string                       `json:"milestone,omitempty" yaml:"milestone,omitempty"`
+}
+
+// AutoMergePolicy describes when the changesets of a batch change are merged
+// automatically.
+type AutoMergePolicy struct {
+	RequiredCheckState AutoMergeCheckState `json:"requiredCheckState,omitempty" yaml:"requiredCheckState,omitempty"`
+	RequiredApprovals  int                 `json:"requiredApprovals,omitempty" yaml:"requiredApprovals,omitempty"`
+	MergeMethod        AutoMergeMethod     `json:"mergeMethod,omitempty" yaml:"mergeMethod,omitempty"`
+	MergeWindow        *AutoMergeWindow    `json:"mergeWindow,omitempty" yaml:"mergeWindow,omitempty"`
+}
+
+// AutoMergeCheckState is the state the checks of a changeset must be in for
+// it to be merged automatically.
+type AutoMergeCheckState string
+
+const (
+	AutoMergeCheckStatePassed AutoMergeCheckState = "passed"
+	AutoMergeCheckStateAny    AutoMergeCheckState = "any"
+)
+
+// AutoMergeMethod is the way changesets are merged automatically.
+type AutoMergeMethod string
+
+const (
+	AutoMergeMethodMerge  AutoMergeMethod = "merge"
+	AutoMergeMethodSquash AutoMergeMethod = "squash"
+)
+
+// CheckState returns the required check state, falling back to
+// AutoMergeCheckStatePassed if none is set.
+func (p *AutoMergePolicy) CheckState() AutoMergeCheckState {
+	if p.RequiredCheckState == "" {
+		return AutoMergeCheckStatePassed
+	}
+	return p.RequiredCheckState
+}
+
+// Squash returns whether changesets are squash merged.
+func (p *AutoMergePolicy) Squash() bool {
+	return p.MergeMethod == AutoMergeMethodSquash
+}
+
+// AutoMergeWindow is the time window (in UTC) in which changesets are merged
+// automatically.
+type AutoMergeWindow struct {
+	Days  []string `json:"days,omitempty" yaml:"days,omitempty"`
+	Start string   `json:"start,omitempty" yaml:"start,omitempty"`
+	End   string   `json:"end,omitempty" yaml:"end,omitempty"`
+}
+
+// RebasePolicy describes when the steps of a changeset are re-executed on the
+// latest commit of its base branch.
+type RebasePolicy struct {
+	When RebaseTrigger `json:"when,omitempty" yaml:"when,omitempty"`
+}
+
+// RebaseTrigger is the state of a changeset that triggers its re-execution.
+type RebaseTrigger string
+
+const (
+	// RebaseTriggerConflicting re-executes changesets that conflict with their
+	// base branch.
+	RebaseTriggerConflicting RebaseTrigger = "conflicting"
+	// RebaseTriggerBehind re-executes changesets that conflict with or are
+	// behind their base branch.
+	RebaseTriggerBehind RebaseTrigger = "behind"
+)
+
+// OnBehind returns whether changesets are re-executed as soon as their base
+// branch has moved on, and not only when they conflict with it.
+func (p *RebasePolicy) OnBehind() bool {
+	return p.When == RebaseTriggerBehind
+}
+
+// RolloutPolicy describes how quickly the changesets of a batch change are
+// published to the code host.
+type RolloutPolicy struct {
+	// Rate is the maximum number of changesets published in a given time unit,
+	// in the form "N/UNIT".
+	Rate string `json:"rate,omitempty" yaml:"rate,omitempty"`
+	// Waves are glob patterns of repository names. The changesets in
+	// repositories matching a wave are only published once all changesets in
+	// repositories matching a previous wave have been published. Changesets in
+	// repositories that match no wave are published last.
+	Waves []string `json:"waves,omitempty" yaml:"waves,omitempty"`
+	// MaxFailingChecks is the percentage of published changesets that can have
+	// failing checks before publishing further changesets is paused.
+	MaxFailingChecks *int `json:"maxFailingChecks,omitempty" yaml:"maxFailingChecks,omitempty"`
+}
+
+// ParseRate parses the rate of the policy into the number of changesets that
+// can be published per the returned duration. If no rate is set, n is -1.
+func (p *RolloutPolicy) ParseRate() (n int, per time.Duration, err error) {
+	if p.Rate == "" {
+		return -1, 0, nil
+	}
+
+	parts := strings.SplitN(p.Rate, "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, errors.Errorf("malformed rate: %q", p.Rate)
+	}
+
+	n, err = strconv.Atoi(parts[0])
+	if err != nil || n < 1 {
+		return 0, 0, errors.Errorf("malformed rate: %q", p.Rate)
+	}
+
+	switch strings.ToLower(parts[1]) {
+	case "min", "mins", "minute", "minutes":
+		per = time.Minute
+	case "hr", "hrs", "hour", "hours":
+		per = time.Hour
+	case "day", "days":
+		per = 24 * time.Hour
+	default:
+		return 0, 0, errors.Errorf("malformed rate unit: %q", p.Rate)
+	}
+
+	return n, per, nil
+}
+
+// RolloutWaves are the compiled wave patterns of a RolloutPolicy.
+type RolloutWaves []glob.Glob
+
+// CompileWaves compiles the wave patterns of the policy once, so that many
+// repositories can be matched against them. Invalid patterns never match.
+func (p *RolloutPolicy) CompileWaves() RolloutWaves {
+	waves := make(RolloutWaves, len(p.Waves))
+	for i, pattern := range p.Waves {
+		if g, err := glob.Compile(pattern); err == nil {
+			waves[i] = g
+		}
+	}
+	return waves
+}
+
+// Wave returns the index of the first wave whose pattern matches the given
+// repository name. Repositories that match no wave are in the wave after the
+// last one.
+func (w RolloutWaves) Wave(repoName string) int {
+	for i, g := range w {
+		if g != nil && g.Match(repoName) {
+			return i
+		}
+	}
+	return len(w)
+}
+
+// ReviewersPolicy describes who is requested to review the changesets of a
+// batch change.
+type ReviewersPolicy struct {
+	// Users are the usernames of users on the code host.
+	Users []string `json:"users,omitempty" yaml:"users,omitempty"`
+	// Teams are the names of teams on the code host, in the form
+	// "organization/team".
+	Teams []string `json:"teams,omitempty" yaml:"teams,omitempty"`
+	// FromCodeOwners requests reviews from the code owners of the changed
+	// files, as defined in the CODEOWNERS file of the repository.
+	FromCodeOwners bool `json:"fromCodeOwners,omitempty" yaml:"fromCodeOwners,omitempty"`
+	// Overrides change the reviewers of the changesets in repositories
+	// matching their glob patterns. Later overrides take precedence.
+	Overrides []ReviewersOverride `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+}
+
+// ReviewersOverride changes the reviewers of the changesets in the
+// repositories matching Repository. Only the fields that are set are changed.
+type ReviewersOverride struct {
+	Repository     string   `json:"repository" yaml:"repository"`
+	Users          []string `json:"users,omitempty" yaml:"users,omitempty"`
+	Teams          []string `json:"teams,omitempty" yaml:"teams,omitempty"`
+	FromCodeOwners *bool    `json:"fromCodeOwners,omitempty" yaml:"fromCodeOwners,omitempty"`
+}
+
+// ForRepository returns the reviewers of the changesets in the repository
+// with the given name, after applying all matching overrides. It returns nil
+// if no reviewers are requested.
+func (p *ReviewersPolicy) ForRepository(repoName string) *ChangesetReviewers {
+	if p == nil {
+		return nil
+	}
+
+	r := &ChangesetReviewers{
+		Users:          p.Users,
+		Teams:          p.Teams,
+		FromCodeOwners: p.FromCodeOwners,
+	}
+	for _, o := range p.Overrides {
+		g, err := glob.Compile(o.Repository)
+		if err != nil || !g.Match(repoName) {
+			continue
+		}
+		if o.Users != nil {
+			r.Users = o.Users
+		}
+		if o.Teams != nil {
+			r.Teams = o.Teams
+		}
+		if o.FromCodeOwners != nil {
+			r.FromCodeOwners = *o.FromCodeOwners
+		}
+	}
+
+	if len(r.Users) == 0 && len(r.Teams) == 0 && !r.FromCodeOwners {
+		return nil
+	}
+	return r
 }
 
 type GitCommitAuthor struct {
@@ -89,6 +305,12 @@ func (oqor *OnQueryOrRepository) GetBranches() ([]string, error) {
 }
 
 type Step struct {
+	// ID identifies the step for the Needs of other steps.
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+	// Needs are the IDs of the steps that must finish before this step runs.
+	// See StepGraph.
+	Needs []string `json:"needs,omitempty" yaml:"needs,omitempty"`
+
 	Run       string            `json:"run,omitempty" yaml:"run"`
 	Container string            `json:"container,omitempty" yaml:"container"`
 	Env       env.Environment   `json:"env,omitempty" yaml:"env"`
@@ -133,6 +355,7 @@ type ParseBatchSpecOptions struct {
 	AllowArrayEnvironments bool
 	AllowTransformChanges  bool
 	AllowConditionalExec   bool
+	AllowStepDependencies  bool
 }
 
 func ParseBatchSpec(data []byte, opts ParseBatchSpecOptions) (*BatchSpec, error) {
@@ -194,6 +417,39 @@ func parseBatchSpec(schema string, data []byte, opts ParseBatchSpecOptions) (*Ba
 		}
 	}
 
+	if HasStepDependencies(spec.Steps) && !opts.AllowStepDependencies {
+		errs = multierror.Append(errs, NewValidationError(errors.New("batch spec uses 'needs' to declare step dependencies, which is not supported in this Sourcegraph version")))
+	} else if _, err := NewStepGraph(spec.Steps); err != nil {
+		var multiErr *multierror.Error
+		if errors.As(err, &multiErr) {
+			for _, e := range multiErr.Errors {
+				errs = multierror.Append(errs, NewValidationError(e))
+			}
+		} else {
+			errs = multierror.Append(errs, NewValidationError(err))
+		}
+	}
+
+	if spec.ChangesetTemplate != nil && spec.ChangesetTemplate.Rollout != nil {
+		rollout := spec.ChangesetTemplate.Rollout
+		if _, _, err := rollout.ParseRate(); err != nil {
+			errs = multierror.Append(errs, NewValidationError(errors.Wrap(err, "changesetTemplate.rollout.rate")))
+		}
+		for i, pattern := range rollout.Waves {
+			if _, err := glob.Compile(pattern); err != nil {
+				errs = multierror.Append(errs, NewValidationError(errors.Wrapf(err, "changesetTemplate.rollout.waves[%d]", i)))
+			}
+		}
+	}
+
+	if spec.ChangesetTemplate != nil && spec.ChangesetTemplate.Reviewers != nil {
+		for i, o := range spec.ChangesetTemplate.Reviewers.Overrides {
+			if _, err := glob.Compile(o.Repository); err != nil {
+				errs = multierror.Append(errs, NewValidationError(errors.Wrapf(err, "changesetTemplate.reviewers.overrides[%d].repository", i)))
+			}
+		}
+	}
+
 	return &spec, errs.ErrorOrNil()
 }
 
@@ -3,6 +3,7 @@ package batches
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -128,6 +129,72 @@ changesetTemplate:
 		}
 	})
 
+	t.Run("step dependencies", func(t *testing.T) {
+		const specTemplate = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - id: hello
+    run: echo Hello | tee -a $(find -name README.md)
+    container: alpine:3
+  - id: world
+    run: echo World | tee -a $(find -name README.md)
+    container: alpine:3
+  - run: echo done
+    container: alpine:3
+    needs: [%s]
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: false
+`
+
+		t.Run("valid", func(t *testing.T) {
+			batchSpec, err := ParseBatchSpec([]byte(fmt.Sprintf(specTemplate, "hello, world")), ParseBatchSpecOptions{AllowStepDependencies: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, []string{"hello", "world"}, batchSpec.Steps[2].Needs)
+		})
+
+		t.Run("unsupported", func(t *testing.T) {
+			_, err := ParseBatchSpec([]byte(fmt.Sprintf(specTemplate, "hello, world")), ParseBatchSpecOptions{})
+			if err == nil {
+				t.Fatal("no error returned")
+			}
+
+			wantErr := `1 error occurred:
+	* batch spec uses 'needs' to declare step dependencies, which is not supported in this Sourcegraph version
+
+`
+			haveErr := err.Error()
+			if haveErr != wantErr {
+				t.Fatalf("wrong error. want=%q, have=%q", wantErr, haveErr)
+			}
+		})
+
+		t.Run("unknown step", func(t *testing.T) {
+			_, err := ParseBatchSpec([]byte(fmt.Sprintf(specTemplate, "hello, universe")), ParseBatchSpecOptions{AllowStepDependencies: true})
+			if err == nil {
+				t.Fatal("no error returned")
+			}
+
+			wantErr := `1 error occurred:
+	* step 3 needs step "universe", which doesn't exist
+
+`
+			haveErr := err.Error()
+			if haveErr != wantErr {
+				t.Fatalf("wrong error. want=%q, have=%q", wantErr, haveErr)
+			}
+		})
+	})
+
 	t.Run("parsing if attribute", func(t *testing.T) {
 		const specTemplate = `
 name: hello-world
@@ -208,6 +275,247 @@ changesetTemplate:
 			t.Fatalf("wrong error. want=%q, have=%q", wantErr, haveErr)
 		}
 	})
+
+	t.Run("autoMerge policy", func(t *testing.T) {
+		const specTemplate = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+  autoMerge:
+%s
+`
+
+		t.Run("valid", func(t *testing.T) {
+			spec := fmt.Sprintf(specTemplate, `    requiredApprovals: 2
+    mergeMethod: squash
+    mergeWindow:
+      days: [monday, tuesday]
+      start: "9:00"
+      end: "17:00"`)
+
+			batchSpec, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := &AutoMergePolicy{
+				RequiredApprovals: 2,
+				MergeMethod:       AutoMergeMethodSquash,
+				MergeWindow: &AutoMergeWindow{
+					Days:  []string{"monday", "tuesday"},
+					Start: "9:00",
+					End:   "17:00",
+				},
+			}
+			assert.Equal(t, want, batchSpec.ChangesetTemplate.AutoMerge)
+			assert.Equal(t, AutoMergeCheckStatePassed, batchSpec.ChangesetTemplate.AutoMerge.CheckState())
+			assert.True(t, batchSpec.ChangesetTemplate.AutoMerge.Squash())
+		})
+
+		for name, policy := range map[string]string{
+			"unknown merge method": `    mergeMethod: rebase`,
+			"unknown check state":  `    requiredCheckState: failed`,
+			"negative approvals":   `    requiredApprovals: -1`,
+			"start without end":    "    mergeWindow:\n      start: \"9:00\"",
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := ParseBatchSpec([]byte(fmt.Sprintf(specTemplate, policy)), ParseBatchSpecOptions{})
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("rebase policy", func(t *testing.T) {
+		const specTemplate = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+  rebase:
+%s
+`
+
+		for name, tc := range map[string]struct {
+			policy   string
+			onBehind bool
+		}{
+			"default":     {policy: `    {}`, onBehind: false},
+			"conflicting": {policy: `    when: conflicting`, onBehind: false},
+			"behind":      {policy: `    when: behind`, onBehind: true},
+		} {
+			t.Run(name, func(t *testing.T) {
+				batchSpec, err := ParseBatchSpec([]byte(fmt.Sprintf(specTemplate, tc.policy)), ParseBatchSpecOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tc.onBehind, batchSpec.ChangesetTemplate.Rebase.OnBehind())
+			})
+		}
+
+		t.Run("unknown trigger", func(t *testing.T) {
+			_, err := ParseBatchSpec([]byte(fmt.Sprintf(specTemplate, `    when: always`)), ParseBatchSpecOptions{})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("rollout policy", func(t *testing.T) {
+		const specTemplate = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+  rollout:
+%s
+`
+
+		t.Run("valid", func(t *testing.T) {
+			policy := `    rate: 10/hour
+    waves:
+      - github.com/sourcegraph/canary-*
+      - github.com/sourcegraph/*
+    maxFailingChecks: 20`
+
+			batchSpec, err := ParseBatchSpec([]byte(fmt.Sprintf(specTemplate, policy)), ParseBatchSpecOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			rollout := batchSpec.ChangesetTemplate.Rollout
+
+			n, per, err := rollout.ParseRate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, 10, n)
+			assert.Equal(t, time.Hour, per)
+			assert.Equal(t, 20, *rollout.MaxFailingChecks)
+
+			waves := rollout.CompileWaves()
+			assert.Equal(t, 0, waves.Wave("github.com/sourcegraph/canary-service"))
+			assert.Equal(t, 1, waves.Wave("github.com/sourcegraph/sourcegraph"))
+			assert.Equal(t, 2, waves.Wave("github.com/other/service"))
+		})
+
+		t.Run("no rate", func(t *testing.T) {
+			batchSpec, err := ParseBatchSpec([]byte(fmt.Sprintf(specTemplate, `    maxFailingChecks: 0`)), ParseBatchSpecOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			n, _, err := batchSpec.ChangesetTemplate.Rollout.ParseRate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, -1, n)
+		})
+
+		for name, policy := range map[string]string{
+			"invalid rate":     `    rate: 10/week`,
+			"zero rate":        `    rate: 0/hour`,
+			"invalid wave":     `    waves: ["github.com/[sourcegraph"]`,
+			"invalid maximum":  `    maxFailingChecks: 120`,
+			"unknown property": `    batchSize: 10`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := ParseBatchSpec([]byte(fmt.Sprintf(specTemplate, policy)), ParseBatchSpecOptions{})
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestReviewersPolicy_ForRepository(t *testing.T) {
+	const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+  reviewers:
+    users: [alice]
+    teams: [sourcegraph/batchers]
+    overrides:
+      - repository: github.com/sourcegraph/*
+        fromCodeOwners: true
+      - repository: github.com/sourcegraph/docs
+        users: [bob]
+        teams: []
+      - repository: github.com/other/*
+        users: []
+        teams: []
+`
+
+	batchSpec, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := batchSpec.ChangesetTemplate.Reviewers
+
+	for repo, want := range map[string]*ChangesetReviewers{
+		"github.com/acme/repo": {
+			Users: []string{"alice"},
+			Teams: []string{"sourcegraph/batchers"},
+		},
+		"github.com/sourcegraph/sourcegraph": {
+			Users:          []string{"alice"},
+			Teams:          []string{"sourcegraph/batchers"},
+			FromCodeOwners: true,
+		},
+		"github.com/sourcegraph/docs": {
+			Users:          []string{"bob"},
+			Teams:          []string{},
+			FromCodeOwners: true,
+		},
+		"github.com/other/repo": nil,
+	} {
+		t.Run(repo, func(t *testing.T) {
+			assert.Equal(t, want, policy.ForRepository(repo))
+		})
+	}
+
+	t.Run("no policy", func(t *testing.T) {
+		var policy *ReviewersPolicy
+		assert.Nil(t, policy.ForRepository("github.com/sourcegraph/sourcegraph"))
+	})
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
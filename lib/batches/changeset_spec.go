@@ -81,6 +81,23 @@ type ChangesetSpec struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published PublishedValue `json:"published,omitempty"`
+
+	AutoMerge *AutoMergePolicy `json:"autoMerge,omitempty"`
+	Rebase    *RebasePolicy    `json:"rebase,omitempty"`
+	Rollout   *RolloutPolicy   `json:"rollout,omitempty"`
+
+	Reviewers *ChangesetReviewers `json:"reviewers,omitempty"`
+
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Milestone string   `json:"milestone,omitempty"`
+}
+
+// ChangesetReviewers are the reviewers requested for a single changeset.
+type ChangesetReviewers struct {
+	Users          []string `json:"users,omitempty"`
+	Teams          []string `json:"teams,omitempty"`
+	FromCodeOwners bool     `json:"fromCodeOwners,omitempty"`
 }
 
 // MarshalJSON overwrites the default behavior of the json lib while unmarshalling
@@ -103,6 +120,13 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Body           string                 `json:"body,omitempty"`
 		Commits        []GitCommitDescription `json:"commits,omitempty"`
 		Published      *PublishedValue        `json:"published,omitempty"`
+		AutoMerge      *AutoMergePolicy       `json:"autoMerge,omitempty"`
+		Rebase         *RebasePolicy          `json:"rebase,omitempty"`
+		Rollout        *RolloutPolicy         `json:"rollout,omitempty"`
+		Reviewers      *ChangesetReviewers    `json:"reviewers,omitempty"`
+		Labels         []string               `json:"labels,omitempty"`
+		Assignees      []string               `json:"assignees,omitempty"`
+		Milestone      string                 `json:"milestone,omitempty"`
 	}{
 		BaseRepository: c.BaseRepository,
 		ExternalID:     c.ExternalID,
@@ -113,6 +137,13 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Title:          c.Title,
 		Body:           c.Body,
 		Commits:        c.Commits,
+		AutoMerge:      c.AutoMerge,
+		Rebase:         c.Rebase,
+		Rollout:        c.Rollout,
+		Reviewers:      c.Reviewers,
+		Labels:         c.Labels,
+		Assignees:      c.Assignees,
+		Milestone:      c.Milestone,
 	}
 	if !c.Published.Nil() {
 		v.Published = &c.Published
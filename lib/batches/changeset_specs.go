@@ -94,6 +94,21 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, features ChangesetSpecFeatur
 		return nil, err
 	}
 
+	labels, err := renderChangesetTemplateList("labels", input.Template.Labels, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees, err := renderChangesetTemplateList("assignees", input.Template.Assignees, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	milestone, err := template.RenderChangesetTemplateField("milestone", input.Template.Milestone, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO: As a next step, we should extend the ChangesetTemplateContext to also include
 	// TransformChanges.Group and then change validateGroups and groupFileDiffs to, for each group,
 	// render the branch name *before* grouping the diffs.
@@ -135,6 +150,13 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, features ChangesetSpecFeatur
 				},
 			},
 			Published: PublishedValue{Val: published},
+			AutoMerge: input.Template.AutoMerge,
+			Rebase:    input.Template.Rebase,
+			Rollout:   input.Template.Rollout,
+			Reviewers: input.Template.Reviewers.ForRepository(input.Repository.Name),
+			Labels:    labels,
+			Assignees: assignees,
+			Milestone: milestone,
 		}, nil
 	}
 
@@ -171,6 +193,23 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, features ChangesetSpecFeatur
 	return specs, nil
 }
 
+// renderChangesetTemplateList renders each of the given values of a list field
+// in the changeset template. Values that render to an empty string are
+// dropped, so that templates can add values conditionally.
+func renderChangesetTemplateList(name string, values []string, tmplCtx *template.ChangesetTemplateContext) ([]string, error) {
+	var rendered []string
+	for _, v := range values {
+		r, err := template.RenderChangesetTemplateField(name, v, tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+		if r != "" {
+			rendered = append(rendered, r)
+		}
+	}
+	return rendered, nil
+}
+
 type RepoFetcher func(context.Context, []string) (map[string]string, error)
 
 func BuildImportChangesetSpecs(ctx context.Context, importChangesets []ImportChangeset, repoFetcher RepoFetcher) (specs []*ChangesetSpec, errs error) {
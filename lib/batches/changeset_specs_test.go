@@ -172,6 +172,24 @@ func TestCreateChangesetSpecs(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "labels, assignees and milestone",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Labels = []string{"batch-change", "${{ repository.name }}", "${{ if eq repository.name \"nope\" }}nope${{ end }}"}
+				input.Template.Assignees = []string{"alice", "${{ batch_change.name }}"}
+				input.Template.Milestone = "${{ repository.branch }}"
+				input.Template.Published = parsePublishedFieldString(t, "false")
+			}),
+			features: featuresAllEnabled,
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.Labels = []string{"batch-change", "github.com/sourcegraph/src-cli"}
+					s.Assignees = []string{"alice", "the name"}
+					s.Milestone = "my-cool-base-ref"
+				}),
+			},
+			wantErr: "",
+		},
 		{
 			name: "publish in UI on an unsupported version",
 			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
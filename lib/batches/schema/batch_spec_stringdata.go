@@ -125,6 +125,19 @@ const BatchSpecJSON = `{
         "additionalProperties": false,
         "required": ["run", "container"],
         "properties": {
+          "id": {
+            "type": "string",
+            "description": "An identifier of the step, which other steps can reference in ` + "`" + `needs` + "`" + `.",
+            "pattern": "^[A-Za-z_][A-Za-z0-9_-]*$"
+          },
+          "needs": {
+            "type": "array",
+            "description": "The IDs of the steps that must finish before this step runs. Once any step uses ` + "`" + `needs` + "`" + `, steps only wait for the steps they need, and steps that don't need each other run in parallel. The outputs of the needed steps are available in this step.",
+            "items": {
+              "type": "string"
+            },
+            "uniqueItems": true
+          },
           "run": {
             "type": "string",
             "description": "The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout."
@@ -374,6 +387,176 @@ const BatchSpecJSON = `{
               }
             }
           ]
+        },
+        "autoMerge": {
+          "title": "AutoMergePolicy",
+          "type": "object",
+          "description": "A policy to merge the changesets automatically once they are open and their checks and reviews pass. The policy is evaluated whenever the state of a changeset is synced from the code host.",
+          "additionalProperties": false,
+          "properties": {
+            "requiredCheckState": {
+              "type": "string",
+              "description": "The state the checks of a changeset must be in for it to be merged. \"passed\" requires all checks to have passed, \"any\" ignores the checks. Defaults to \"passed\".",
+              "enum": ["passed", "any"]
+            },
+            "requiredApprovals": {
+              "type": "integer",
+              "description": "The number of reviewers that must have approved a changeset for it to be merged. Changesets with requested changes are never merged.",
+              "minimum": 0
+            },
+            "mergeMethod": {
+              "type": "string",
+              "description": "How changesets are merged. Defaults to \"merge\".",
+              "enum": ["merge", "squash"]
+            },
+            "mergeWindow": {
+              "title": "AutoMergeWindow",
+              "type": "object",
+              "description": "The time window (in UTC) in which changesets are merged. If omitted, changesets are merged at any time.",
+              "additionalProperties": false,
+              "properties": {
+                "days": {
+                  "description": "Day(s) the window applies to. If omitted, the window applies to all days of the week.",
+                  "type": "array",
+                  "items": {
+                    "type": "string",
+                    "pattern": "^([mM]on(day)?|[tT]ue(s|sday)?|[wW]ed(nesday)?|[tT]hu(r|rs|rsday)?|[fF]ri(day)?|[sS]at(urday)?|[sS]un(day)?)$"
+                  }
+                },
+                "start": {
+                  "description": "Window start time. If omitted, changesets are merged at any time of the day(s) that match this window.",
+                  "type": "string",
+                  "pattern": "^[0-9]?[0-9]:[0-9]{2}$"
+                },
+                "end": {
+                  "description": "Window end time. If omitted, changesets are merged at any time of the day(s) that match this window.",
+                  "type": "string",
+                  "pattern": "^[0-9]?[0-9]:[0-9]{2}$"
+                }
+              },
+              "dependencies": {
+                "start": ["end"],
+                "end": ["start"]
+              }
+            }
+          }
+        },
+        "rebase": {
+          "title": "RebasePolicy",
+          "type": "object",
+          "description": "A policy to re-execute the steps of the changesets on the latest commit of their base branch and force-push the result, once they conflict with or fall behind the base branch. Only changesets that were created by a batch spec executed on Sourcegraph can be re-executed.",
+          "additionalProperties": false,
+          "properties": {
+            "when": {
+              "type": "string",
+              "description": "When to re-execute a changeset. \"conflicting\" re-executes it once it conflicts with its base branch, \"behind\" re-executes it as soon as its base branch has new commits. Defaults to \"conflicting\".",
+              "enum": ["conflicting", "behind"]
+            }
+          }
+        },
+        "rollout": {
+          "title": "RolloutPolicy",
+          "type": "object",
+          "description": "A policy to publish the changesets gradually, so that reviewers and CI aren't overwhelmed. Changesets that are held back by the policy are shown as scheduled.",
+          "additionalProperties": false,
+          "properties": {
+            "rate": {
+              "type": "string",
+              "description": "The maximum number of changesets published per minute, hour or day, such as \"10/hour\".",
+              "pattern": "^[0-9]+\\/(min|mins|minute|minutes|hr|hrs|hour|hours|day|days)$"
+            },
+            "waves": {
+              "type": "array",
+              "description": "Glob patterns of repository names, such as \"github.com/my-org/canary-*\". The changesets in repositories matching a wave are only published once all changesets in repositories matching the previous waves have been published. Changesets in repositories that match no wave are published last.",
+              "items": {
+                "type": "string"
+              }
+            },
+            "maxFailingChecks": {
+              "type": "integer",
+              "description": "The percentage of published changesets that may have failing checks. Once it is exceeded, publishing further changesets is paused until the checks are fixed.",
+              "minimum": 0,
+              "maximum": 100
+            }
+          }
+        },
+        "reviewers": {
+          "title": "ReviewersPolicy",
+          "type": "object",
+          "description": "The users and teams that are requested to review the changesets when they are published.",
+          "additionalProperties": false,
+          "properties": {
+            "users": {
+              "type": "array",
+              "description": "The usernames of the users on the code host who are requested to review the changesets.",
+              "items": {
+                "type": "string"
+              }
+            },
+            "teams": {
+              "type": "array",
+              "description": "The teams on the code host that are requested to review the changesets, in the form \"organization/team\". Teams are only supported on GitHub.",
+              "items": {
+                "type": "string"
+              }
+            },
+            "fromCodeOwners": {
+              "type": "boolean",
+              "description": "Whether to request reviews from the owners of the changed files, as defined by the CODEOWNERS file of the repository."
+            },
+            "overrides": {
+              "type": "array",
+              "description": "Changes to the reviewers of the changesets in specific repositories. Only the properties that are set are changed, and later overrides take precedence.",
+              "items": {
+                "title": "ReviewersOverride",
+                "type": "object",
+                "additionalProperties": false,
+                "required": ["repository"],
+                "properties": {
+                  "repository": {
+                    "type": "string",
+                    "description": "A glob pattern of the names of the repositories to which the override applies, such as \"github.com/my-org/*\"."
+                  },
+                  "users": {
+                    "type": "array",
+                    "description": "The usernames of the users on the code host who are requested to review the changesets in the matching repositories.",
+                    "items": {
+                      "type": "string"
+                    }
+                  },
+                  "teams": {
+                    "type": "array",
+                    "description": "The teams on the code host that are requested to review the changesets in the matching repositories, in the form \"organization/team\". Teams are only supported on GitHub.",
+                    "items": {
+                      "type": "string"
+                    }
+                  },
+                  "fromCodeOwners": {
+                    "type": "boolean",
+                    "description": "Whether to request reviews from the owners of the changed files, as defined by the CODEOWNERS file of the repository."
+                  }
+                }
+              }
+            }
+          }
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to add to the changesets. Each label can use templating.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The usernames of the users on the code host to assign to the changesets. Each username can use templating.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "milestone": {
+          "type": "string",
+          "description": "The title of the milestone to set on the changesets. It can use templating."
         }
       }
     }
@@ -0,0 +1,379 @@
+package batches
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+)
+
+// HasStepDependencies returns true if any of the steps declares the steps it
+// needs, which means that steps only wait for the steps they need and
+// independent steps can run in parallel.
+func HasStepDependencies(steps []Step) bool {
+	for _, s := range steps {
+		if len(s.Needs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// StepGraph describes the order in which the steps of a batch spec are
+// executed. Step runners don't use it yet, so batch specs using `needs` are
+// still rejected server-side.
+//
+// If none of the steps declares the steps it needs, the steps run one after
+// another, in the order in which they are listed. Otherwise every step only
+// waits for the steps it needs, and steps that don't need each other run in
+// parallel.
+type StepGraph struct {
+	steps []Step
+	// needs are the indexes of the steps each step directly depends on.
+	needs [][]int
+	// order is a topological order of the steps. Steps that are independent
+	// of each other keep the order in which they are listed.
+	order []int
+	// position is the position of each step in order.
+	position []int
+	// ancestors are the indexes of all steps each step depends on, directly or
+	// transitively, in topological order.
+	ancestors [][]int
+}
+
+// NewStepGraph builds the StepGraph of the given steps. It returns an error if
+// step IDs are not unique, a step needs a step that doesn't exist, the steps
+// depend on each other in a cycle, or steps that may run in parallel set the
+// same output.
+func NewStepGraph(steps []Step) (*StepGraph, error) {
+	g := &StepGraph{steps: steps, needs: make([][]int, len(steps))}
+
+	ids := make(map[string]int, len(steps))
+	var errs *multierror.Error
+	for i, s := range steps {
+		if s.ID == "" {
+			continue
+		}
+		if j, ok := ids[s.ID]; ok {
+			errs = multierror.Append(errs, errors.Errorf("steps %d and %d have the same id %q", j+1, i+1, s.ID))
+			continue
+		}
+		ids[s.ID] = i
+	}
+
+	if HasStepDependencies(steps) {
+		for i, s := range steps {
+			for _, id := range s.Needs {
+				j, ok := ids[id]
+				if !ok {
+					errs = multierror.Append(errs, errors.Errorf("%s needs step %q, which doesn't exist", g.stepName(i), id))
+					continue
+				}
+				g.needs[i] = append(g.needs[i], j)
+			}
+		}
+	} else {
+		for i := 1; i < len(steps); i++ {
+			g.needs[i] = []int{i - 1}
+		}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	if err := g.sort(); err != nil {
+		return nil, err
+	}
+	g.computeAncestors()
+
+	if err := g.checkOutputs(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// stepName returns a human-readable name of the step for error messages.
+func (g *StepGraph) stepName(i int) string {
+	if id := g.steps[i].ID; id != "" {
+		return fmt.Sprintf("step %d (%q)", i+1, id)
+	}
+	return fmt.Sprintf("step %d", i+1)
+}
+
+// sort computes a topological order of the steps with Kahn's algorithm,
+// always picking the ready step that is listed first.
+func (g *StepGraph) sort() error {
+	n := len(g.steps)
+	remaining := make([]int, n)
+	dependents := make([][]int, n)
+	for i, needs := range g.needs {
+		remaining[i] = len(needs)
+		for _, j := range needs {
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	var ready []int
+	for i := range remaining {
+		if remaining[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	g.order = make([]int, 0, n)
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		i := ready[0]
+		ready = ready[1:]
+		g.order = append(g.order, i)
+		for _, d := range dependents[i] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	if len(g.order) != n {
+		return errors.Errorf("the steps depend on each other in a cycle: %s", g.findCycle())
+	}
+
+	g.position = make([]int, n)
+	for pos, i := range g.order {
+		g.position[i] = pos
+	}
+	return nil
+}
+
+// findCycle returns a description of a dependency cycle in the graph. It must
+// only be called if the graph has a cycle.
+func (g *StepGraph) findCycle() string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(g.steps))
+	var stack []int
+
+	var visit func(i int) []int
+	visit = func(i int) []int {
+		state[i] = visiting
+		stack = append(stack, i)
+		for _, j := range g.needs[i] {
+			switch state[j] {
+			case visiting:
+				for k, s := range stack {
+					if s == j {
+						return append(append([]int{}, stack[k:]...), j)
+					}
+				}
+			case unvisited:
+				if cycle := visit(j); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = visited
+		return nil
+	}
+
+	for i := range g.steps {
+		if state[i] != unvisited {
+			continue
+		}
+		if cycle := visit(i); cycle != nil {
+			names := make([]string, len(cycle))
+			for k, s := range cycle {
+				names[k] = g.stepName(s)
+			}
+			return strings.Join(names, " needs ")
+		}
+	}
+	return ""
+}
+
+func (g *StepGraph) computeAncestors() {
+	n := len(g.steps)
+	sets := make([]map[int]struct{}, n)
+	g.ancestors = make([][]int, n)
+
+	for _, i := range g.order {
+		set := map[int]struct{}{}
+		for _, j := range g.needs[i] {
+			set[j] = struct{}{}
+			for a := range sets[j] {
+				set[a] = struct{}{}
+			}
+		}
+		sets[i] = set
+
+		ancestors := make([]int, 0, len(set))
+		for a := range set {
+			ancestors = append(ancestors, a)
+		}
+		sort.Slice(ancestors, func(x, y int) bool { return g.position[ancestors[x]] < g.position[ancestors[y]] })
+		g.ancestors[i] = ancestors
+	}
+}
+
+// checkOutputs makes sure that steps that may run in parallel don't set the
+// same output, since the value of the output would then depend on which of
+// the steps finished last.
+func (g *StepGraph) checkOutputs() error {
+	var errs *multierror.Error
+	for i := range g.steps {
+		for j := i + 1; j < len(g.steps); j++ {
+			if g.DependsOn(i, j) || g.DependsOn(j, i) {
+				continue
+			}
+			for name := range g.steps[i].Outputs {
+				if _, ok := g.steps[j].Outputs[name]; ok {
+					errs = multierror.Append(errs, errors.Errorf("%s and %s both set the output %q, but may run in parallel", g.stepName(i), g.stepName(j), name))
+				}
+			}
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// Len returns the number of steps in the graph.
+func (g *StepGraph) Len() int { return len(g.steps) }
+
+// Sequential returns true if the steps run one after another.
+func (g *StepGraph) Sequential() bool {
+	for pos, i := range g.order {
+		if len(g.ancestors[i]) != pos {
+			return false
+		}
+	}
+	return true
+}
+
+// Needs returns the indexes of the steps that the step with index i directly
+// depends on.
+func (g *StepGraph) Needs(i int) []int { return g.needs[i] }
+
+// Ancestors returns the indexes of all steps that the step with index i
+// depends on, directly or transitively, in the order in which they run.
+func (g *StepGraph) Ancestors(i int) []int { return g.ancestors[i] }
+
+// DependsOn returns true if the step with index i depends on the step with
+// index j, directly or transitively.
+func (g *StepGraph) DependsOn(i, j int) bool {
+	for _, a := range g.ancestors[i] {
+		if a == j {
+			return true
+		}
+	}
+	return false
+}
+
+// Order returns the indexes of the steps in an order in which they can run one
+// after another.
+func (g *StepGraph) Order() []int { return g.order }
+
+// StepRunFunc runs the step with index i. outputs are the outputs set by the
+// steps it depends on, directly or transitively. It returns the outputs set by
+// the step itself.
+type StepRunFunc func(ctx context.Context, i int, outputs map[string]interface{}) (map[string]interface{}, error)
+
+// Run runs the steps in the graph, at most parallelism at a time, and returns
+// the outputs set by all steps. A step is started as soon as all the steps it
+// needs have finished. Once a step fails, no further steps are started, the
+// context passed to the running steps is canceled, and the error of the first
+// failed step is returned.
+func (g *StepGraph) Run(ctx context.Context, parallelism int, run StepRunFunc) (map[string]interface{}, error) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	n := len(g.steps)
+	results := make([]map[string]interface{}, n)
+	remaining := make([]int, n)
+	dependents := make([][]int, n)
+	for i, needs := range g.needs {
+		remaining[i] = len(needs)
+		for _, j := range needs {
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	var ready []int
+	for _, i := range g.order {
+		if remaining[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	type result struct {
+		step    int
+		outputs map[string]interface{}
+		err     error
+	}
+	done := make(chan result)
+
+	var (
+		running  int
+		firstErr error
+	)
+	for {
+		for firstErr == nil && running < parallelism && len(ready) > 0 {
+			i := ready[0]
+			ready = ready[1:]
+			inputs := g.mergeOutputs(g.ancestors[i], results)
+			running++
+			go func(i int) {
+				outputs, err := run(ctx, i, inputs)
+				done <- result{step: i, outputs: outputs, err: err}
+			}(i)
+		}
+		if running == 0 {
+			break
+		}
+
+		r := <-done
+		running--
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+				cancel()
+			}
+			continue
+		}
+
+		results[r.step] = r.outputs
+		for _, d := range dependents[r.step] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+		sort.Slice(ready, func(x, y int) bool { return g.position[ready[x]] < g.position[ready[y]] })
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return g.mergeOutputs(g.order, results), nil
+}
+
+// mergeOutputs merges the outputs of the given steps, which must be in
+// topological order, into a new map.
+func (g *StepGraph) mergeOutputs(steps []int, results []map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for _, i := range steps {
+		for k, v := range results[i] {
+			merged[k] = v
+		}
+	}
+	return merged
+}
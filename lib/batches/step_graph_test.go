@@ -0,0 +1,166 @@
+package batches
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewStepGraph(t *testing.T) {
+	t.Run("sequential", func(t *testing.T) {
+		g, err := NewStepGraph([]Step{{Run: "a"}, {ID: "b", Run: "b"}, {Run: "c"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g.Sequential() {
+			t.Fatal("steps without needs are not sequential")
+		}
+		if have, want := g.Ancestors(2), []int{0, 1}; !cmp.Equal(have, want) {
+			t.Fatalf("wrong ancestors: %s", cmp.Diff(want, have))
+		}
+	})
+
+	t.Run("graph", func(t *testing.T) {
+		g, err := NewStepGraph([]Step{
+			{ID: "format", Needs: []string{"setup"}},
+			{ID: "lint", Needs: []string{"setup"}},
+			{ID: "commit", Needs: []string{"format", "lint"}},
+			{ID: "setup"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.Sequential() {
+			t.Fatal("graph is sequential")
+		}
+		if have, want := g.Order(), []int{3, 0, 1, 2}; !cmp.Equal(have, want) {
+			t.Fatalf("wrong order: %s", cmp.Diff(want, have))
+		}
+		if have, want := g.Ancestors(2), []int{3, 0, 1}; !cmp.Equal(have, want) {
+			t.Fatalf("wrong ancestors: %s", cmp.Diff(want, have))
+		}
+		if g.DependsOn(0, 1) || g.DependsOn(1, 0) {
+			t.Fatal("independent steps depend on each other")
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		steps   []Step
+		wantErr string
+	}{
+		"duplicate id": {
+			steps:   []Step{{ID: "a"}, {ID: "a"}},
+			wantErr: `steps 1 and 2 have the same id "a"`,
+		},
+		"unknown step": {
+			steps:   []Step{{ID: "a", Needs: []string{"b"}}},
+			wantErr: `step 1 ("a") needs step "b", which doesn't exist`,
+		},
+		"cycle": {
+			steps: []Step{
+				{ID: "a", Needs: []string{"c"}},
+				{ID: "b", Needs: []string{"a"}},
+				{ID: "c", Needs: []string{"b"}},
+			},
+			wantErr: `the steps depend on each other in a cycle: step 1 ("a") needs step 3 ("c") needs step 2 ("b") needs step 1 ("a")`,
+		},
+		"parallel outputs": {
+			steps: []Step{
+				{ID: "a", Outputs: Outputs{"x": {}}},
+				{ID: "b", Needs: []string{"a"}, Outputs: Outputs{"x": {}}},
+				{ID: "c", Needs: []string{"a"}, Outputs: Outputs{"x": {}}},
+			},
+			wantErr: `step 2 ("b") and step 3 ("c") both set the output "x", but may run in parallel`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewStepGraph(tc.steps)
+			if err == nil {
+				t.Fatal("no error returned")
+			}
+			var multiErr interface{ WrappedErrors() []error }
+			if errors.As(err, &multiErr) {
+				err = multiErr.WrappedErrors()[0]
+			}
+			if have := err.Error(); have != tc.wantErr {
+				t.Fatalf("wrong error. want=%q, have=%q", tc.wantErr, have)
+			}
+		})
+	}
+}
+
+func TestStepGraph_Run(t *testing.T) {
+	g, err := NewStepGraph([]Step{
+		{ID: "setup"},
+		{ID: "format", Needs: []string{"setup"}},
+		{ID: "lint", Needs: []string{"setup"}},
+		{ID: "commit", Needs: []string{"format", "lint"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("parallel", func(t *testing.T) {
+		// format and lint only finish once both of them are running.
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		var mu sync.Mutex
+		inputs := map[int]map[string]interface{}{}
+
+		outputs, err := g.Run(context.Background(), 2, func(ctx context.Context, i int, in map[string]interface{}) (map[string]interface{}, error) {
+			mu.Lock()
+			inputs[i] = in
+			mu.Unlock()
+
+			switch i {
+			case 1, 2:
+				wg.Done()
+				wg.Wait()
+			}
+			return map[string]interface{}{g.steps[i].ID: i}, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantInputs := map[int]map[string]interface{}{
+			0: {},
+			1: {"setup": 0},
+			2: {"setup": 0},
+			3: {"setup": 0, "format": 1, "lint": 2},
+		}
+		if diff := cmp.Diff(wantInputs, inputs); diff != "" {
+			t.Fatalf("wrong inputs: %s", diff)
+		}
+		wantOutputs := map[string]interface{}{"setup": 0, "format": 1, "lint": 2, "commit": 3}
+		if diff := cmp.Diff(wantOutputs, outputs); diff != "" {
+			t.Fatalf("wrong outputs: %s", diff)
+		}
+	})
+
+	t.Run("failing step", func(t *testing.T) {
+		var mu sync.Mutex
+		var ran []int
+
+		_, err := g.Run(context.Background(), 1, func(ctx context.Context, i int, in map[string]interface{}) (map[string]interface{}, error) {
+			mu.Lock()
+			ran = append(ran, i)
+			mu.Unlock()
+
+			if i == 1 {
+				return nil, errors.New("format failed")
+			}
+			return nil, nil
+		})
+		if err == nil || err.Error() != "format failed" {
+			t.Fatalf("wrong error: %v", err)
+		}
+		if want := []int{0, 1}; !cmp.Equal(ran, want) {
+			t.Fatalf("wrong steps ran: %s", cmp.Diff(want, ran))
+		}
+	})
+}
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP INDEX IF EXISTS access_tokens_expires_at;
+
+ALTER TABLE access_tokens
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS expiry_notified_at,
+    DROP COLUMN IF EXISTS last_used_ip;
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- +++
+-- parent: 1528395968
+-- +++
+
+BEGIN;
+
+ALTER TABLE access_tokens
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS expiry_notified_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS last_used_ip TEXT;
+
+CREATE INDEX IF NOT EXISTS access_tokens_expires_at ON access_tokens USING btree (expires_at) WHERE deleted_at IS NULL AND expires_at IS NOT NULL;
+
+COMMIT;
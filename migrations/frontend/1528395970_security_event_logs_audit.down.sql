@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP INDEX IF EXISTS security_event_logs_name;
+
+ALTER TABLE security_event_logs
+    DROP COLUMN IF EXISTS before,
+    DROP COLUMN IF EXISTS after,
+    DROP COLUMN IF EXISTS previous_hash,
+    DROP COLUMN IF EXISTS hash;
+
+COMMIT;
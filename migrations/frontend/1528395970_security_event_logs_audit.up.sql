@@ -0,0 +1,20 @@
+-- +++
+-- parent: 1528395969
+-- +++
+
+BEGIN;
+
+ALTER TABLE security_event_logs
+    ADD COLUMN IF NOT EXISTS before JSONB,
+    ADD COLUMN IF NOT EXISTS after JSONB,
+    ADD COLUMN IF NOT EXISTS previous_hash TEXT,
+    ADD COLUMN IF NOT EXISTS hash TEXT;
+
+COMMENT ON COLUMN security_event_logs.before IS 'The state of the changed resource before the event, for audited changes.';
+COMMENT ON COLUMN security_event_logs.after IS 'The state of the changed resource after the event, for audited changes.';
+COMMENT ON COLUMN security_event_logs.previous_hash IS 'The hash of the previous event in the audit log hash chain.';
+COMMENT ON COLUMN security_event_logs.hash IS 'The SHA-256 hash of the event and previous_hash, chaining the audit log.';
+
+CREATE INDEX IF NOT EXISTS security_event_logs_name ON security_event_logs USING btree (name);
+
+COMMIT;
@@ -0,0 +1,24 @@
+-- +++
+-- parent: 1528395970
+-- +++
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id bigserial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    key text NOT NULL,
+    ip_address text,
+    user_agent text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_seen_at timestamp with time zone NOT NULL DEFAULT now(),
+    expires_at timestamp with time zone NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_sessions_key ON user_sessions(key);
+CREATE INDEX IF NOT EXISTS user_sessions_user_id ON user_sessions(user_id);
+
+COMMENT ON TABLE user_sessions IS 'An index of the signed-in sessions of users. The session data itself is stored in Redis.';
+COMMENT ON COLUMN user_sessions.key IS 'The SHA-256 hash of the ID of the session in the session store.';
+
+COMMIT;
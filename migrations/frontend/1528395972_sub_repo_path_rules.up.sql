@@ -0,0 +1,30 @@
+-- +++
+-- parent: 1528395971
+-- +++
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS sub_repo_path_rules (
+    id serial PRIMARY KEY,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    position integer NOT NULL,
+    user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+    pattern text NOT NULL,
+    action text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT sub_repo_path_rules_action_check CHECK (action IN ('allow', 'deny')),
+    CONSTRAINT sub_repo_path_rules_subject_check CHECK (user_id IS NULL OR org_id IS NULL)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS sub_repo_path_rules_repo_id_position ON sub_repo_path_rules(repo_id, position);
+CREATE INDEX IF NOT EXISTS sub_repo_path_rules_user_id ON sub_repo_path_rules(user_id);
+CREATE INDEX IF NOT EXISTS sub_repo_path_rules_org_id ON sub_repo_path_rules(org_id);
+
+COMMENT ON TABLE sub_repo_path_rules IS 'Admin-managed rules that allow or deny access to paths of repositories whose code host does not provide sub-repository permissions.';
+COMMENT ON COLUMN sub_repo_path_rules.position IS 'The order of the rule within the rules of the repository. Rules with a higher position take precedence.';
+COMMENT ON COLUMN sub_repo_path_rules.pattern IS 'A glob pattern matched against paths relative to the repository root.';
+COMMENT ON COLUMN sub_repo_path_rules.user_id IS 'The user the rule applies to. If both user_id and org_id are NULL, the rule applies to all users.';
+COMMENT ON COLUMN sub_repo_path_rules.org_id IS 'The organization whose members the rule applies to.';
+
+COMMIT;
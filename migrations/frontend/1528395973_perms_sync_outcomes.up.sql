@@ -0,0 +1,20 @@
+-- +++
+-- parent: 1528395972
+-- +++
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS perms_sync_outcomes (
+    object_type text NOT NULL,
+    object_id integer NOT NULL,
+    started_at timestamp with time zone NOT NULL,
+    finished_at timestamp with time zone NOT NULL,
+    error text,
+    PRIMARY KEY (object_type, object_id)
+);
+
+COMMENT ON TABLE perms_sync_outcomes IS 'The outcome of the most recent permissions sync of each user and repository.';
+COMMENT ON COLUMN perms_sync_outcomes.object_type IS 'Either "user" for user-centric or "repo" for repository-centric permissions syncs.';
+COMMENT ON COLUMN perms_sync_outcomes.error IS 'The error the sync failed with, or NULL if it succeeded.';
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS role_assignments;
+DROP TABLE IF EXISTS roles;
+
+COMMIT;
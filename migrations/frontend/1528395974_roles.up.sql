@@ -0,0 +1,42 @@
+-- +++
+-- parent: 1528395973
+-- +++
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS roles (
+    id serial PRIMARY KEY,
+    name text NOT NULL,
+    description text NOT NULL DEFAULT '',
+    permissions text[] NOT NULL DEFAULT '{}',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT roles_name_unique UNIQUE (name)
+);
+
+COMMENT ON TABLE roles IS 'Named sets of permissions that can be assigned to users and organizations.';
+COMMENT ON COLUMN roles.permissions IS 'The permissions granted by the role, e.g. BATCH_CHANGES_ADMIN. Site admins implicitly have all permissions.';
+
+CREATE TABLE IF NOT EXISTS role_assignments (
+    id serial PRIMARY KEY,
+    role_id integer NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT role_assignments_subject_check CHECK ((user_id IS NULL) <> (org_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS role_assignments_role_id_user_id ON role_assignments(role_id, user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS role_assignments_role_id_org_id ON role_assignments(role_id, org_id) WHERE org_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS role_assignments_user_id ON role_assignments(user_id);
+CREATE INDEX IF NOT EXISTS role_assignments_org_id ON role_assignments(org_id);
+
+COMMENT ON TABLE role_assignments IS 'Assignments of roles to users, or to organizations whose members then have the role.';
+
+INSERT INTO roles (name, description, permissions) VALUES
+    ('batch_changes_admin', 'Can administer all batch changes and manage global batch changes credentials.', '{BATCH_CHANGES_ADMIN}'),
+    ('insights_editor', 'Can manage the series of all code insights.', '{INSIGHTS_EDIT}'),
+    ('code_monitor_manager', 'Can manage the code monitors of all users.', '{CODE_MONITORS_MANAGE}'),
+    ('repo_admin', 'Can administer all repositories, e.g. trigger updates and check their mirror connections.', '{REPO_ADMIN}')
+ON CONFLICT (name) DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,35 @@
+-- +++
+-- parent: 1528395976
+-- +++
+
+BEGIN;
+
+ALTER TABLE
+  batch_changes
+ADD COLUMN IF NOT EXISTS
+  schedule TEXT,
+ADD COLUMN IF NOT EXISTS
+  schedule_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+ADD COLUMN IF NOT EXISTS
+  next_scheduled_run_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS batch_changes_next_scheduled_run_at ON batch_changes (next_scheduled_run_at) WHERE schedule IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS batch_change_scheduled_runs (
+  id              BIGSERIAL PRIMARY KEY,
+
+  batch_change_id BIGINT NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+  batch_spec_id   BIGINT REFERENCES batch_specs(id) ON DELETE SET NULL DEFERRABLE,
+
+  state           TEXT NOT NULL DEFAULT 'resolving',
+  failure_message TEXT,
+
+  created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS batch_change_scheduled_runs_batch_change_id ON batch_change_scheduled_runs (batch_change_id);
+CREATE INDEX IF NOT EXISTS batch_change_scheduled_runs_state ON batch_change_scheduled_runs (state);
+
+COMMIT;
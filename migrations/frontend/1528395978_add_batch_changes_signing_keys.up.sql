@@ -0,0 +1,27 @@
+-- +++
+-- parent: 1528395977
+-- +++
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS batch_changes_signing_keys (
+  id                BIGSERIAL PRIMARY KEY,
+
+  -- A NULL user_id is the site-wide signing key.
+  user_id           INTEGER REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+
+  format            TEXT NOT NULL,
+  private_key       BYTEA NOT NULL,
+  public_key        TEXT NOT NULL,
+  committer_name    TEXT NOT NULL,
+  committer_email   TEXT NOT NULL,
+  encryption_key_id TEXT NOT NULL DEFAULT '',
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_changes_signing_keys_user_id_unique ON batch_changes_signing_keys (user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS batch_changes_signing_keys_site_unique ON batch_changes_signing_keys ((user_id IS NULL)) WHERE user_id IS NULL;
+
+COMMIT;
@@ -66,6 +66,32 @@ type ApiRatelimit struct {
 	PerUser int `json:"perUser"`
 }
 
+// AuditLog description: Configures the security audit log, which records security-relevant events such as sign-ins, access token creation, site configuration and code host connection edits, repository permission changes and batch change applies. Events are hash-chained to detect tampering.
+type AuditLog struct {
+	// Enabled description: Whether to record security events in the audit log. The audit log is always enabled on Sourcegraph.com.
+	Enabled bool `json:"enabled,omitempty"`
+	// Export description: Streams every recorded event as a JSON line to the given destinations, in addition to storing it in the database. Each service exports the events it records.
+	Export *AuditLogExport `json:"export,omitempty"`
+}
+
+// AuditLogExport description: Streams every recorded event as a JSON line to the given destinations, in addition to storing it in the database. Each service exports the events it records.
+type AuditLogExport struct {
+	// File description: The path of a local file to append events to. It is created if it does not exist.
+	File string `json:"file,omitempty"`
+	// Syslog description: A syslog server to send events to.
+	Syslog *AuditLogSyslog `json:"syslog,omitempty"`
+}
+
+// AuditLogSyslog description: A syslog server to send events to.
+type AuditLogSyslog struct {
+	// Address description: The address of the syslog server.
+	Address string `json:"address"`
+	// Network description: The network protocol used to connect to the syslog server.
+	Network string `json:"network,omitempty"`
+	// Tag description: The tag of the syslog messages.
+	Tag string `json:"tag,omitempty"`
+}
+
 // AuthAccessTokens description: Settings for access tokens, which enable external tools to access the Sourcegraph API with the privileges of the user.
 type AuthAccessTokens struct {
 	// Allow description: Allow or restrict the use of access tokens. The default is "all-users-create", which enables all users to create access tokens. Use "none" to disable access tokens entirely. Use "site-admin-create" to restrict creation of new tokens to admin users (existing tokens will still work until revoked).
@@ -84,6 +110,7 @@ type AuthProviders struct {
 	HttpHeader    *HTTPHeaderAuthProvider
 	Github        *GitHubAuthProvider
 	Gitlab        *GitLabAuthProvider
+	Ldap          *LDAPAuthProvider
 }
 
 func (v AuthProviders) MarshalJSON() ([]byte, error) {
@@ -105,6 +132,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.Gitlab != nil {
 		return json.Marshal(v.Gitlab)
 	}
+	if v.Ldap != nil {
+		return json.Marshal(v.Ldap)
+	}
 	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
 }
 func (v *AuthProviders) UnmarshalJSON(data []byte) error {
@@ -123,12 +153,36 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Gitlab)
 	case "http-header":
 		return json.Unmarshal(data, &v.HttpHeader)
+	case "ldap":
+		return json.Unmarshal(data, &v.Ldap)
 	case "openidconnect":
 		return json.Unmarshal(data, &v.Openidconnect)
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "ldap"})
+}
+
+// AutoMergePolicy description: A policy to merge the changesets automatically once they are open and their checks and reviews pass. The policy is evaluated whenever the state of a changeset is synced from the code host.
+type AutoMergePolicy struct {
+	// MergeMethod description: How changesets are merged. Defaults to "merge".
+	MergeMethod string `json:"mergeMethod,omitempty"`
+	// MergeWindow description: The time window (in UTC) in which changesets are merged. If omitted, changesets are merged at any time.
+	MergeWindow *AutoMergeWindow `json:"mergeWindow,omitempty"`
+	// RequiredApprovals description: The number of reviewers that must have approved a changeset for it to be merged. Changesets with requested changes are never merged.
+	RequiredApprovals int `json:"requiredApprovals,omitempty"`
+	// RequiredCheckState description: The state the checks of a changeset must be in for it to be merged. "passed" requires all checks to have passed, "any" ignores the checks. Defaults to "passed".
+	RequiredCheckState string `json:"requiredCheckState,omitempty"`
+}
+
+// AutoMergeWindow description: The time window (in UTC) in which changesets are merged. If omitted, changesets are merged at any time.
+type AutoMergeWindow struct {
+	// Days description: Day(s) the window applies to. If omitted, the window applies to all days of the week.
+	Days []string `json:"days,omitempty"`
+	// End description: Window end time. If omitted, changesets are merged at any time of the day(s) that match this window.
+	End string `json:"end,omitempty"`
+	// Start description: Window start time. If omitted, changesets are merged at any time of the day(s) that match this window.
+	Start string `json:"start,omitempty"`
 }
 
 type BackendInsight struct {
@@ -159,6 +213,24 @@ type BatchChangeRolloutWindow struct {
 	Start string `json:"start,omitempty"`
 }
 
+// BatchChangesRiskScoring description: Configures how the risk score of a changeset spec is computed from its diff when previewing a batch change. The score is the sum of each weight multiplied by the corresponding count.
+type BatchChangesRiskScoring struct {
+	// BinaryFileWeight description: The score added for each changed binary file.
+	BinaryFileWeight *float64 `json:"binaryFileWeight,omitempty"`
+	// DeletedFileWeight description: The score added for each deleted file.
+	DeletedFileWeight *float64 `json:"deletedFileWeight,omitempty"`
+	// FilesChangedWeight description: The score added for each changed file.
+	FilesChangedWeight *float64 `json:"filesChangedWeight,omitempty"`
+	// HighRiskThreshold description: Changeset specs with a risk score of at least this value are considered high risk.
+	HighRiskThreshold *float64 `json:"highRiskThreshold,omitempty"`
+	// LinesChangedWeight description: The score added for each added, changed or deleted line.
+	LinesChangedWeight *float64 `json:"linesChangedWeight,omitempty"`
+	// LockfileWeight description: The score added for each changed lockfile.
+	LockfileWeight *float64 `json:"lockfileWeight,omitempty"`
+	// Lockfiles description: Glob patterns of lockfiles. Patterns without a slash match the file name in any directory, other patterns match the path from the root of the repository.
+	Lockfiles []string `json:"lockfiles,omitempty"`
+}
+
 // BatchSpec description: A batch specification, which describes the batch change and what kinds of changes to make (or what existing changesets to track).
 type BatchSpec struct {
 	// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
@@ -179,12 +251,20 @@ type BatchSpec struct {
 	Workspaces []*WorkspaceConfiguration `json:"workspaces,omitempty"`
 }
 
+// BitbucketCloudAuthorization description: If non-null, enforces Bitbucket Cloud repository permissions. Permissions are read from the workspaces listed in "teams", whose administrator the "username" must be.
+type BitbucketCloudAuthorization struct {
+	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Bitbucket Cloud accounts (the Bitbucket Cloud "nickname") and `auth.enableUsernameChanges` must be set to false for security reasons.
+	IdentityProvider BitbucketCloudIdentityProvider `json:"identityProvider"`
+}
+
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
 type BitbucketCloudConnection struct {
 	// ApiURL description: The API URL of Bitbucket Cloud, such as https://api.bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.
 	ApiURL string `json:"apiURL,omitempty"`
 	// AppPassword description: The app password to use when authenticating to the Bitbucket Cloud. Also set the corresponding "username" field.
 	AppPassword string `json:"appPassword"`
+	// Authorization description: If non-null, enforces Bitbucket Cloud repository permissions. Permissions are read from the workspaces listed in "teams", whose administrator the "username" must be.
+	Authorization *BitbucketCloudAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over "teams" configuration.
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
@@ -211,6 +291,33 @@ type BitbucketCloudConnection struct {
 	Url string `json:"url"`
 	// Username description: The username to use when authenticating to the Bitbucket Cloud. Also set the corresponding "appPassword" field.
 	Username string `json:"username"`
+	// Webhooks description: An array of webhook configurations. Bitbucket Cloud webhooks used by batch changes must point at /.api/bitbucket-cloud-webhooks, with the external service ID in the externalServiceID query parameter and one of these secrets in the secret query parameter.
+	Webhooks []*BitbucketCloudWebhook `json:"webhooks,omitempty"`
+}
+
+// BitbucketCloudIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Bitbucket Cloud accounts (the Bitbucket Cloud "nickname") and `auth.enableUsernameChanges` must be set to false for security reasons.
+type BitbucketCloudIdentityProvider struct {
+	Username *BitbucketCloudUsernameIdentity
+}
+
+func (v BitbucketCloudIdentityProvider) MarshalJSON() ([]byte, error) {
+	if v.Username != nil {
+		return json.Marshal(v.Username)
+	}
+	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
+}
+func (v *BitbucketCloudIdentityProvider) UnmarshalJSON(data []byte) error {
+	var d struct {
+		DiscriminantProperty string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	switch d.DiscriminantProperty {
+	case "username":
+		return json.Unmarshal(data, &v.Username)
+	}
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"username"})
 }
 
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
@@ -220,6 +327,13 @@ type BitbucketCloudRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+type BitbucketCloudUsernameIdentity struct {
+	Type string `json:"type"`
+}
+type BitbucketCloudWebhook struct {
+	// Secret description: The secret used to authenticate incoming webhook requests
+	Secret string `json:"secret"`
+}
 
 // BitbucketServerAuthorization description: If non-null, enforces Bitbucket Server repository permissions.
 type BitbucketServerAuthorization struct {
@@ -393,14 +507,28 @@ type BuiltinAuthProvider struct {
 
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
+	// Assignees description: The usernames of the users on the code host to assign to the changesets. Each username can use templating.
+	Assignees []string `json:"assignees,omitempty"`
+	// AutoMerge description: A policy to merge the changesets automatically once they are open and their checks and reviews pass. The policy is evaluated whenever the state of a changeset is synced from the code host.
+	AutoMerge *AutoMergePolicy `json:"autoMerge,omitempty"`
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
 	// Branch description: The name of the Git branch to create or update on each repository with the changes.
 	Branch string `json:"branch"`
 	// Commit description: The Git commit to create with the changes.
 	Commit ExpandedGitCommitDescription `json:"commit"`
+	// Labels description: The labels to add to the changesets. Each label can use templating.
+	Labels []string `json:"labels,omitempty"`
+	// Milestone description: The title of the milestone to set on the changesets. It can use templating.
+	Milestone string `json:"milestone,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.
 	Published interface{} `json:"published,omitempty"`
+	// Rebase description: A policy to re-execute the steps of the changesets on the latest commit of their base branch and force-push the result, once they conflict with or fall behind the base branch. Only changesets that were created by a batch spec executed on Sourcegraph can be re-executed.
+	Rebase *RebasePolicy `json:"rebase,omitempty"`
+	// Reviewers description: The users and teams that are requested to review the changesets when they are published.
+	Reviewers *ReviewersPolicy `json:"reviewers,omitempty"`
+	// Rollout description: A policy to publish the changesets gradually, so that reviewers and CI aren't overwhelmed. Changesets that are held back by the policy are shown as scheduled.
+	Rollout *RolloutPolicy `json:"rollout,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
@@ -719,8 +847,12 @@ type GitHubConnection struct {
 	//
 	// If "ssh", Sourcegraph will access GitHub repositories using Git URLs of the form git@github.com:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// GithubAppID description: The ID of the GitHub App used to authenticate with this GitHub instance instead of a personal access token. Requires `githubAppPrivateKey` and `githubAppInstallationID` to be set.
+	GithubAppID string `json:"githubAppID,omitempty"`
 	// GithubAppInstallationID description: The installation ID of the GitHub App.
 	GithubAppInstallationID string `json:"githubAppInstallationID,omitempty"`
+	// GithubAppPrivateKey description: The base64-encoded PEM private key of the GitHub App identified by `githubAppID`. Installation access tokens are minted from this key and refreshed automatically before they expire.
+	GithubAppPrivateKey string `json:"githubAppPrivateKey,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. GitHub repositories can no longer be enabled or disabled explicitly. Configure repositories to be mirrored via "repos", "exclude" and "repositoryQuery" instead.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// Orgs description: An array of organization names identifying GitHub organizations whose repositories should be mirrored on Sourcegraph.
@@ -984,6 +1116,46 @@ type JVMPackagesConnection struct {
 }
 
 // Log description: Configuration for logging and alerting, including to external services.
+type LDAPAttributeMapping struct {
+	// DisplayName description: The attribute holding the display name.
+	DisplayName string `json:"displayName,omitempty"`
+	// Email description: The attribute holding the email address. Users without an email address can't sign in.
+	Email string `json:"email,omitempty"`
+	// Username description: The attribute holding the username.
+	Username string `json:"username,omitempty"`
+}
+type LDAPAuthProvider struct {
+	// AllowSignup description: Allows new visitors to sign up for accounts via LDAP authentication. If false, users signing in via LDAP must have an existing Sourcegraph account, which will be linked to their LDAP identity after sign-in.
+	AllowSignup *bool `json:"allowSignup,omitempty"`
+	// Attributes description: The user entry attributes that Sourcegraph user properties are mapped from.
+	Attributes *LDAPAttributeMapping `json:"attributes,omitempty"`
+	// BindDN description: The DN of the service account used to search for users and groups. If empty, searches are performed anonymously.
+	BindDN string `json:"bindDN,omitempty"`
+	// BindPassword description: The password of the service account given by `bindDN`.
+	BindPassword string `json:"bindPassword,omitempty"`
+	// Certificate description: TLS certificate of the LDAP server, if it uses a self-signed certificate.
+	Certificate string `json:"certificate,omitempty"`
+	// ConfigID description: An identifier that can be used to reference this authentication provider in other parts of the config. For example, in configuration for a code host, you may want to designate this authentication provider as the identity provider for the code host.
+	ConfigID    string `json:"configID,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	// GroupOrgMap description: Maps group DNs to the names of organizations. Users are added to the organizations of their groups, and removed from organizations listed here when they leave the corresponding groups.
+	GroupOrgMap map[string][]string `json:"groupOrgMap,omitempty"`
+	// GroupSearchBase description: The DN under which groups are searched. If empty, the groups of a user are read from the `memberOf` attribute of the user entry, as maintained by Active Directory and the OpenLDAP memberof overlay.
+	GroupSearchBase string `json:"groupSearchBase,omitempty"`
+	// GroupSearchFilter description: The LDAP filter that matches the groups of a user under `groupSearchBase`. `{dn}` and `{username}` are replaced with the escaped DN and username of the user.
+	GroupSearchFilter string `json:"groupSearchFilter,omitempty"`
+	// StartTLS description: Upgrade ldap:// connections to TLS with the StartTLS extended operation.
+	StartTLS bool `json:"startTLS,omitempty"`
+	// SyncIntervalMinutes description: How often the attributes and organization memberships of users who signed in with this provider are resynced from LDAP. Users whose entry no longer exists are signed out. Set to 0 to disable resyncing.
+	SyncIntervalMinutes *int   `json:"syncIntervalMinutes,omitempty"`
+	Type                string `json:"type"`
+	// Url description: The URL of the LDAP server. Use the ldaps scheme for LDAP over TLS.
+	Url string `json:"url"`
+	// UserSearchBase description: The DN under which users are searched.
+	UserSearchBase string `json:"userSearchBase"`
+	// UserSearchFilter description: The LDAP filter that matches exactly one user entry. `{username}` is replaced with the escaped username entered on sign-in.
+	UserSearchFilter string `json:"userSearchFilter,omitempty"`
+}
 type Log struct {
 	// Sentry description: Configuration for Sentry
 	Sentry *Sentry `json:"sentry,omitempty"`
@@ -1331,6 +1503,12 @@ type PhabricatorConnection struct {
 	// Url description: URL of a Phabricator instance, such as https://phabricator.example.com
 	Url string `json:"url,omitempty"`
 }
+type QuietWindow struct {
+	// End description: The end of the window in UTC, formatted as HH:MM. Windows ending before they start span midnight.
+	End string `json:"end"`
+	// Start description: The start of the window in UTC, formatted as HH:MM.
+	Start string `json:"start"`
+}
 type QuickLink struct {
 	// Description description: A description for this quick link
 	Description string `json:"description,omitempty"`
@@ -1347,6 +1525,12 @@ type Ranking struct {
 	// RepoScores description: a map of URI directories to numeric scores for specifying search result importance, like {"github.com": 500, "github.com/sourcegraph": 300, "github.com/sourcegraph/sourcegraph": 100}. Would rank "github.com/sourcegraph/sourcegraph" as 500+300+100=900, and "github.com/other/foo" as 500.
 	RepoScores map[string]float64 `json:"repoScores,omitempty"`
 }
+
+// RebasePolicy description: A policy to re-execute the steps of the changesets on the latest commit of their base branch and force-push the result, once they conflict with or fall behind the base branch. Only changesets that were created by a batch spec executed on Sourcegraph can be re-executed.
+type RebasePolicy struct {
+	// When description: When to re-execute a changeset. "conflicting" re-executes it once it conflicts with its base branch, "behind" re-executes it as soon as its base branch has new commits. Defaults to "conflicting".
+	When string `json:"when,omitempty"`
+}
 type Repos struct {
 	// Callsign description: The unique Phabricator identifier for the repository, like 'MUX'.
 	Callsign string `json:"callsign"`
@@ -1359,6 +1543,38 @@ type Responders struct {
 	Type     string `json:"type,omitempty"`
 	Username string `json:"username,omitempty"`
 }
+type ReviewersOverride struct {
+	// FromCodeOwners description: Whether to request reviews from the owners of the changed files, as defined by the CODEOWNERS file of the repository.
+	FromCodeOwners bool `json:"fromCodeOwners,omitempty"`
+	// Repository description: A glob pattern of the names of the repositories to which the override applies, such as "github.com/my-org/*".
+	Repository string `json:"repository"`
+	// Teams description: The teams on the code host that are requested to review the changesets in the matching repositories, in the form "organization/team". Teams are only supported on GitHub.
+	Teams []string `json:"teams,omitempty"`
+	// Users description: The usernames of the users on the code host who are requested to review the changesets in the matching repositories.
+	Users []string `json:"users,omitempty"`
+}
+
+// ReviewersPolicy description: The users and teams that are requested to review the changesets when they are published.
+type ReviewersPolicy struct {
+	// FromCodeOwners description: Whether to request reviews from the owners of the changed files, as defined by the CODEOWNERS file of the repository.
+	FromCodeOwners bool `json:"fromCodeOwners,omitempty"`
+	// Overrides description: Changes to the reviewers of the changesets in specific repositories. Only the properties that are set are changed, and later overrides take precedence.
+	Overrides []*ReviewersOverride `json:"overrides,omitempty"`
+	// Teams description: The teams on the code host that are requested to review the changesets, in the form "organization/team". Teams are only supported on GitHub.
+	Teams []string `json:"teams,omitempty"`
+	// Users description: The usernames of the users on the code host who are requested to review the changesets.
+	Users []string `json:"users,omitempty"`
+}
+
+// RolloutPolicy description: A policy to publish the changesets gradually, so that reviewers and CI aren't overwhelmed. Changesets that are held back by the policy are shown as scheduled.
+type RolloutPolicy struct {
+	// MaxFailingChecks description: The percentage of published changesets that may have failing checks. Once it is exceeded, publishing further changesets is paused until the checks are fixed.
+	MaxFailingChecks int `json:"maxFailingChecks,omitempty"`
+	// Rate description: The maximum number of changesets published per minute, hour or day, such as "10/hour".
+	Rate string `json:"rate,omitempty"`
+	// Waves description: Glob patterns of repository names, such as "github.com/my-org/canary-*". The changesets in repositories matching a wave are only published once all changesets in repositories matching the previous waves have been published. Changesets in repositories that match no wave are published last.
+	Waves []string `json:"waves,omitempty"`
+}
 
 // SAMLAuthProvider description: Configures the SAML authentication provider for SSO.
 //
@@ -1593,6 +1809,8 @@ type SiteConfiguration struct {
 	ApiRatelimit *ApiRatelimit `json:"api.ratelimit,omitempty"`
 	// ApidocsSearchIndexSizeLimitFactor description: Limit factor for API docs search index size. A multiple of 250 million symbols. 1.0 indicates 250 million symbols (approx 12.5k repos) can be indexed. 2.0 indicates double that, and so on. See https://docs.sourcegraph.com/code_intelligence/apidocs
 	ApidocsSearchIndexSizeLimitFactor float64 `json:"apidocs.search.index-size-limit-factor,omitempty"`
+	// AuditLog description: Configures the security audit log, which records security-relevant events such as sign-ins, access token creation, site configuration and code host connection edits, repository permission changes and batch change applies. Events are hash-chained to detect tampering.
+	AuditLog *AuditLog `json:"auditLog,omitempty"`
 	// AuthAccessTokens description: Settings for access tokens, which enable external tools to access the Sourcegraph API with the privileges of the user.
 	AuthAccessTokens *AuthAccessTokens `json:"auth.accessTokens,omitempty"`
 	// AuthEnableUsernameChanges description: Enables users to change their username after account creation. Warning: setting this to be true has security implications if you have enabled (or will at any point in the future enable) repository permissions with an option that relies on username equivalency between Sourcegraph and an external service or authentication provider. Do NOT set this to true if you are using non-built-in authentication OR rely on username equivalency for repository permissions.
@@ -1629,8 +1847,12 @@ type SiteConfiguration struct {
 	BatchChangesEnabled *bool `json:"batchChanges.enabled,omitempty"`
 	// BatchChangesEnforceForks description: When enabled, all branches created by batch changes will be pushed to forks of the original repository.
 	BatchChangesEnforceForks bool `json:"batchChanges.enforceForks,omitempty"`
+	// BatchChangesRequireSignedCommits description: When enabled, changesets are only pushed when their commits can be signed with the signing key of the user that applied the batch change or the site-wide signing key.
+	BatchChangesRequireSignedCommits bool `json:"batchChanges.requireSignedCommits,omitempty"`
 	// BatchChangesRestrictToAdmins description: When enabled, only site admins can create and apply batch changes.
 	BatchChangesRestrictToAdmins *bool `json:"batchChanges.restrictToAdmins,omitempty"`
+	// BatchChangesRiskScoring description: Configures how the risk score of a changeset spec is computed from its diff when previewing a batch change. The score is the sum of each weight multiplied by the corresponding count.
+	BatchChangesRiskScoring *BatchChangesRiskScoring `json:"batchChanges.riskScoring,omitempty"`
 	// BatchChangesRolloutWindows description: Specifies specific windows, which can have associated rate limits, to be used when publishing changesets. All days and times are handled in UTC.
 	BatchChangesRolloutWindows *[]*BatchChangeRolloutWindow `json:"batchChanges.rolloutWindows,omitempty"`
 	// Branding description: Customize Sourcegraph homepage logo and search icon.
@@ -1693,6 +1915,8 @@ type SiteConfiguration struct {
 	GitMaxConcurrentClones int `json:"gitMaxConcurrentClones,omitempty"`
 	// GitUpdateInterval description: JSON array of repo name patterns and update intervals. If a repo matches a pattern, the associated interval will be used. If it matches no patterns a default backoff heuristic will be used. Pattern matches are attempted in the order they are provided.
 	GitUpdateInterval []*UpdateIntervalRule `json:"gitUpdateInterval,omitempty"`
+	// GitUpdatePolicies description: JSON array of update policies based on repository attributes. The first policy matching a repository determines how often it is updated. Repositories matching a `gitUpdateInterval` pattern use that interval, but are still subject to the quiet windows of their policy.
+	GitUpdatePolicies []*UpdatePolicy `json:"gitUpdatePolicies,omitempty"`
 	// GithubClientID description: Client ID for GitHub. (DEPRECATED)
 	GithubClientID string `json:"githubClientID,omitempty"`
 	// GithubClientSecret description: Client secret for GitHub. (DEPRECATED)
@@ -1747,6 +1971,8 @@ type SiteConfiguration struct {
 	RepoConcurrentExternalServiceSyncers int `json:"repoConcurrentExternalServiceSyncers,omitempty"`
 	// RepoListUpdateInterval description: Interval (in minutes) for checking code hosts (such as GitHub, Gitolite, etc.) for new repositories.
 	RepoListUpdateInterval int `json:"repoListUpdateInterval,omitempty"`
+	// ScimAccessToken description: The bearer token that identity providers use to authenticate against the SCIM 2.0 user and group provisioning API at /.api/scim/v2. SCIM provisioning is disabled when this is unset.
+	ScimAccessToken string `json:"scim.accessToken,omitempty"`
 	// SearchIndexEnabled description: Whether indexed search is enabled. If unset Sourcegraph detects the environment to decide if indexed search is enabled. Indexed search is RAM heavy, and is disabled by default in the single docker image. All other environments will have it enabled by default. The size of all your repository working copies is the amount of additional RAM required.
 	SearchIndexEnabled *bool `json:"search.index.enabled,omitempty"`
 	// SearchIndexSymbolsEnabled description: Whether indexed symbol search is enabled. This is contingent on the indexed search configuration, and is true by default for instances with indexed search enabled. Enabling this will cause every repository to re-index, which is a time consuming (several hours) operation. Additionally, it requires more storage and ram to accommodate the added symbols information in the search index.
@@ -1777,6 +2003,10 @@ type Step struct {
 	Files map[string]string `json:"files,omitempty"`
 	// If description: A condition to check before executing steps. Supports templating. The value 'true' is interpreted as true.
 	If interface{} `json:"if,omitempty"`
+	// Id description: An identifier of the step, which other steps can reference in `needs`.
+	Id string `json:"id,omitempty"`
+	// Needs description: The IDs of the steps that must finish before this step runs. Once any step uses `needs`, steps only wait for the steps they need, and steps that don't need each other run in parallel. The outputs of the needed steps are available in this step.
+	Needs []string `json:"needs,omitempty"`
 	// Outputs description: Output variables of this step that can be referenced in the changesetTemplate or other steps via outputs.<name-of-output>
 	Outputs map[string]OutputVariable `json:"outputs,omitempty"`
 	// Run description: The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout.
@@ -1819,6 +2049,28 @@ type UpdateIntervalRule struct {
 	// Pattern description: A regular expression matching a repo name
 	Pattern string `json:"pattern"`
 }
+type UpdatePolicy struct {
+	// Archived description: If true, the policy only applies to archived repositories.
+	Archived bool `json:"archived,omitempty"`
+	// Fork description: If true, the policy only applies to forks.
+	Fork bool `json:"fork,omitempty"`
+	// InactiveDays description: The policy only applies to repositories without new commits for at least this many days.
+	InactiveDays int `json:"inactiveDays,omitempty"`
+	// Interval description: The number of minutes to wait until the next update. If not set, the default backoff heuristic is used.
+	Interval int `json:"interval,omitempty"`
+	// MaxInterval description: The maximum number of minutes between updates chosen by the default backoff heuristic.
+	MaxInterval int `json:"maxInterval,omitempty"`
+	// MinInterval description: The minimum number of minutes between updates chosen by the default backoff heuristic.
+	MinInterval int `json:"minInterval,omitempty"`
+	// MinStars description: The policy only applies to repositories with at least this many stars.
+	MinStars int `json:"minStars,omitempty"`
+	// Name description: A name for the policy, shown in the update schedule of matching repositories.
+	Name string `json:"name,omitempty"`
+	// Pattern description: A regular expression matching the names of the repositories the policy applies to.
+	Pattern string `json:"pattern,omitempty"`
+	// QuietWindows description: Times of day during which matching repositories are not updated automatically. Updates that fall due during a quiet window are postponed until it ends. Manually requested updates are not affected.
+	QuietWindows []*QuietWindow `json:"quietWindows,omitempty"`
+}
 type UsernameIdentity struct {
 	Type string `json:"type"`
 }